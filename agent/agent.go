@@ -24,6 +24,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/hashicorp/yamux"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/expfmt"
 	"github.com/spf13/afero"
@@ -31,6 +32,8 @@ import (
 	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"storj.io/drpc"
 	"tailscale.com/net/speedtest"
 	"tailscale.com/tailcfg"
@@ -174,6 +177,7 @@ func New(options Options) Agent {
 		lifecycleUpdate:                    make(chan struct{}, 1),
 		lifecycleReported:                  make(chan codersdk.WorkspaceAgentLifecycle, 1),
 		lifecycleStates:                    []agentsdk.PostLifecycleRequest{{State: codersdk.WorkspaceAgentLifecycleCreated}},
+		reportConnectionsUpdate:            make(chan struct{}, 1),
 		ignorePorts:                        options.IgnorePorts,
 		portCacheDuration:                  options.PortCacheDuration,
 		reportMetadataInterval:             options.ReportMetadataInterval,
@@ -249,6 +253,10 @@ type agent struct {
 	lifecycleStates            []agentsdk.PostLifecycleRequest
 	lifecycleLastReportedIndex int // Keeps track of the last lifecycle state we successfully reported.
 
+	reportConnectionsUpdate chan struct{}
+	reportConnectionsMu     sync.Mutex // Protects following.
+	reportConnections       []*proto.Connection
+
 	network       *tailnet.Conn
 	addresses     []netip.Prefix
 	statsReporter *statsReporter
@@ -281,6 +289,18 @@ func (a *agent) init() {
 		UpdateEnv:           a.updateCommandEnv,
 		WorkingDirectory:    func() string { return a.manifest.Load().Directory },
 		BlockFileTransfer:   a.blockFileTransfer,
+		ReportConnection: func(id uuid.UUID, connectionType agentssh.ConnectionType, ip string) func(code int, reason string) {
+			var connType proto.Connection_Type
+			switch connectionType {
+			case agentssh.ConnectionTypeVSCode:
+				connType = proto.Connection_VSCODE
+			case agentssh.ConnectionTypeJetBrains:
+				connType = proto.Connection_JETBRAINS
+			default:
+				connType = proto.Connection_SSH
+			}
+			return a.reportConnection(id, connType, ip)
+		},
 	})
 	if err != nil {
 		panic(err)
@@ -708,6 +728,120 @@ func (a *agent) setLifecycle(state codersdk.WorkspaceAgentLifecycle) {
 	}
 }
 
+// reportConnectionBufferLimit is the maximum number of connection reports
+// kept while they can't be sent, e.g. when disconnected from coderd.
+const reportConnectionBufferLimit = 2048
+
+// reportConnection queues a report of a new connection to the agent, and
+// returns a function that queues the report of its disconnection.
+func (a *agent) reportConnection(id uuid.UUID, connectionType proto.Connection_Type, ip string) (disconnected func(code int, reason string)) {
+	// The IP is reported without the port.
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+
+	start := time.Now()
+	a.queueConnectionReport(&proto.Connection{
+		Id:        id[:],
+		Action:    proto.Connection_CONNECT,
+		Type:      connectionType,
+		Timestamp: timestamppb.New(start),
+		Ip:        ip,
+	})
+	return func(code int, reason string) {
+		now := time.Now()
+		a.queueConnectionReport(&proto.Connection{
+			Id:         id[:],
+			Action:     proto.Connection_DISCONNECT,
+			Type:       connectionType,
+			Timestamp:  timestamppb.New(now),
+			Ip:         ip,
+			StatusCode: int32(code),
+			Reason:     reason,
+			Duration:   durationpb.New(now.Sub(start)),
+		})
+	}
+}
+
+func (a *agent) queueConnectionReport(conn *proto.Connection) {
+	a.reportConnectionsMu.Lock()
+	if len(a.reportConnections) >= reportConnectionBufferLimit {
+		a.reportConnectionsMu.Unlock()
+		a.logger.Warn(a.hardCtx, "connection report buffer limit reached, dropping report",
+			slog.F("connection_id", uuid.UUID(conn.GetId())),
+			slog.F("action", conn.GetAction()),
+		)
+		return
+	}
+	a.reportConnections = append(a.reportConnections, conn)
+	a.reportConnectionsMu.Unlock()
+
+	select {
+	case a.reportConnectionsUpdate <- struct{}{}:
+	default:
+	}
+}
+
+// reportConnectionsLoop sends queued connection reports to coderd, in the
+// order they were made.
+func (a *agent) reportConnectionsLoop(ctx context.Context, conn drpc.Conn) error {
+	aAPI := proto.NewDRPCAgentClient(conn)
+	for {
+		select {
+		case <-a.reportConnectionsUpdate:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		for {
+			a.reportConnectionsMu.Lock()
+			if len(a.reportConnections) == 0 {
+				a.reportConnectionsMu.Unlock()
+				break
+			}
+			payload := a.reportConnections[0]
+			a.reportConnectionsMu.Unlock()
+
+			logger := a.logger.With(slog.F("payload", payload))
+			logger.Debug(ctx, "reporting connection")
+			_, err := aAPI.ReportConnection(ctx, &proto.ReportConnectionRequest{
+				Connection: payload,
+			})
+			if err != nil {
+				if ctx.Err() != nil || reportConnectionRetryable(err) {
+					return xerrors.Errorf("failed to report connection: %w", err)
+				}
+				// coderd rejected the report, so sending it again would
+				// fail the same way and block the reports behind it.
+				logger.Warn(ctx, "coderd rejected connection report, dropping it", slog.Error(err))
+			} else {
+				logger.Debug(ctx, "successfully reported connection")
+			}
+
+			// Only this loop removes reports, so the first one is still
+			// the one that was sent.
+			a.reportConnectionsMu.Lock()
+			a.reportConnections = a.reportConnections[1:]
+			a.reportConnectionsMu.Unlock()
+		}
+	}
+}
+
+// reportConnectionRetryable returns whether a connection report failed
+// because the connection to coderd broke, rather than because coderd
+// rejected it.
+func reportConnectionRetryable(err error) bool {
+	var netErr net.Error
+	return xerrors.Is(err, io.EOF) || xerrors.Is(err, io.ErrUnexpectedEOF) ||
+		xerrors.Is(err, io.ErrClosedPipe) || xerrors.Is(err, net.ErrClosed) ||
+		xerrors.Is(err, yamux.ErrSessionShutdown) || xerrors.Is(err, yamux.ErrStreamClosed) ||
+		xerrors.Is(err, yamux.ErrConnectionReset) || xerrors.As(err, &netErr) ||
+		drpc.ClosedError.Has(err) ||
+		// dRPC returns context.Canceled if the transport was closed, even if
+		// the context of the RPC is not canceled.
+		xerrors.Is(err, context.Canceled)
+}
+
 // fetchServiceBannerLoop fetches the service banner on an interval.  It will
 // not be fetched immediately; the expectation is that it is primed elsewhere
 // (and must be done before the session actually starts).
@@ -798,6 +932,10 @@ func (a *agent) run() (retErr error) {
 	// lifecycle reporting has to be via gracefulShutdownBehaviorRemain
 	connMan.start("report lifecycle", gracefulShutdownBehaviorRemain, a.reportLifecycle)
 
+	// connections closed while shutting down are reported too, so this is
+	// also gracefulShutdownBehaviorRemain
+	connMan.start("report connections", gracefulShutdownBehaviorRemain, a.reportConnectionsLoop)
+
 	// metadata reporting can cease as soon as we start gracefully shutting down
 	connMan.start("report metadata", gracefulShutdownBehaviorStop, a.reportMetadata)
 
@@ -1159,6 +1297,15 @@ func (a *agent) createTailnet(ctx context.Context, agentID uuid.UUID, derpMap *t
 		}
 	}()
 
+	// Connections to ports without a listener are forwarded to the loopback
+	// interface, e.g. by "coder port-forward".
+	network.SetForwardTCPCallback(func(src, _ netip.AddrPort) func() {
+		disconnected := a.reportConnection(uuid.New(), proto.Connection_PORT_FORWARDING, src.String())
+		return func() {
+			disconnected(0, "")
+		}
+	})
+
 	sshListener, err := network.Listen("tcp", ":"+strconv.Itoa(workspacesdk.AgentSSHPort))
 	if err != nil {
 		return nil, xerrors.Errorf("listen on the ssh port: %w", err)
@@ -1405,10 +1552,19 @@ func (a *agent) handleReconnectingPTY(ctx context.Context, logger slog.Logger, m
 	a.connCountReconnectingPTY.Add(1)
 	defer a.connCountReconnectingPTY.Add(-1)
 
-	connectionID := uuid.NewString()
-	connLogger := logger.With(slog.F("message_id", msg.ID), slog.F("connection_id", connectionID))
+	connectionID := uuid.New()
+	connLogger := logger.With(slog.F("message_id", msg.ID), slog.F("connection_id", connectionID.String()))
 	connLogger.Debug(ctx, "starting handler")
 
+	disconnected := a.reportConnection(connectionID, proto.Connection_RECONNECTING_PTY, conn.RemoteAddr().String())
+	defer func() {
+		if err := retErr; err != nil {
+			disconnected(1, err.Error())
+			return
+		}
+		disconnected(0, "")
+	}()
+
 	defer func() {
 		if err := retErr; err != nil {
 			a.closeMutex.Lock()
@@ -1476,7 +1632,7 @@ func (a *agent) handleReconnectingPTY(ctx context.Context, logger slog.Logger, m
 		connected = true
 		sendConnected <- rpty
 	}
-	return rpty.Attach(ctx, connectionID, conn, msg.Height, msg.Width, connLogger)
+	return rpty.Attach(ctx, connectionID.String(), conn, msg.Height, msg.Width, connLogger)
 }
 
 // Collect collects additional stats from the agent
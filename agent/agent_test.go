@@ -1830,6 +1830,152 @@ func TestAgent_Dial(t *testing.T) {
 	}
 }
 
+func TestAgent_ReportConnection(t *testing.T) {
+	t.Parallel()
+
+	// requireConnection waits for the connect and disconnect reports of a
+	// connection of the given type.
+	requireConnection := func(t *testing.T, client *agenttest.Client, connType proto.Connection_Type) (connect, disconnect *proto.Connection) {
+		require.Eventually(t, func() bool {
+			connect, disconnect = nil, nil
+			for _, c := range client.GetConnectionReports() {
+				if c.GetType() != connType {
+					continue
+				}
+				switch c.GetAction() {
+				case proto.Connection_CONNECT:
+					connect = c
+				case proto.Connection_DISCONNECT:
+					disconnect = c
+				}
+			}
+			return connect != nil && disconnect != nil
+		}, testutil.WaitLong, testutil.IntervalFast)
+		require.Equal(t, connect.GetId(), disconnect.GetId())
+		require.NotEmpty(t, connect.GetIp())
+		require.NotNil(t, disconnect.GetDuration())
+		return connect, disconnect
+	}
+
+	t.Run("SSH", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		//nolint:dogsled
+		conn, client, _, _, _ := setupAgent(t, agentsdk.Manifest{}, 0)
+		sshClient, err := conn.SSHClient(ctx)
+		require.NoError(t, err)
+		defer sshClient.Close()
+		session, err := sshClient.NewSession()
+		require.NoError(t, err)
+		err = session.Run("exit 3")
+		var exitErr *ssh.ExitError
+		require.ErrorAs(t, err, &exitErr)
+		_ = session.Close()
+
+		_, disconnect := requireConnection(t, client, proto.Connection_SSH)
+		require.EqualValues(t, 3, disconnect.GetStatusCode())
+	})
+
+	t.Run("VSCode", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		//nolint:dogsled
+		conn, client, _, _, _ := setupAgent(t, agentsdk.Manifest{}, 0)
+		sshClient, err := conn.SSHClient(ctx)
+		require.NoError(t, err)
+		defer sshClient.Close()
+		session, err := sshClient.NewSession()
+		require.NoError(t, err)
+		err = session.Setenv(agentssh.MagicSessionTypeEnvironmentVariable, agentssh.MagicSessionTypeVSCode)
+		require.NoError(t, err)
+		err = session.Run("true")
+		require.NoError(t, err)
+		_ = session.Close()
+
+		_, disconnect := requireConnection(t, client, proto.Connection_VSCODE)
+		require.EqualValues(t, 0, disconnect.GetStatusCode())
+	})
+
+	t.Run("ReconnectingPTY", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		//nolint:dogsled
+		conn, client, _, _, _ := setupAgent(t, agentsdk.Manifest{}, 0)
+		ptyConn, err := conn.ReconnectingPTY(ctx, uuid.New(), 80, 80, "bash")
+		require.NoError(t, err)
+		_ = ptyConn.Close()
+
+		requireConnection(t, client, proto.Connection_RECONNECTING_PTY)
+	})
+
+	t.Run("PortForwarding", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer l.Close()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			c, err := l.Accept()
+			if assert.NoError(t, err, "accept connection") {
+				defer c.Close()
+				testAccept(ctx, t, c)
+			}
+		}()
+
+		//nolint:dogsled
+		agentConn, client, _, _, _ := setupAgent(t, agentsdk.Manifest{}, 0)
+		require.True(t, agentConn.AwaitReachable(ctx))
+		conn, err := agentConn.DialContext(ctx, "tcp", l.Addr().String())
+		require.NoError(t, err)
+		testDial(ctx, t, conn)
+		_ = conn.Close()
+		<-done
+
+		requireConnection(t, client, proto.Connection_PORT_FORWARDING)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		//nolint:dogsled
+		conn, client, _, _, _ := setupAgent(t, agentsdk.Manifest{}, 0)
+		// A report that is rejected must not hold up the reports after it.
+		client.SetReportConnectionFunc(func(c *proto.Connection) error {
+			if c.GetAction() == proto.Connection_CONNECT {
+				return xerrors.New("rejected")
+			}
+			return nil
+		})
+		sshClient, err := conn.SSHClient(ctx)
+		require.NoError(t, err)
+		defer sshClient.Close()
+		session, err := sshClient.NewSession()
+		require.NoError(t, err)
+		err = session.Run("true")
+		require.NoError(t, err)
+		_ = session.Close()
+
+		require.Eventually(t, func() bool {
+			for _, c := range client.GetConnectionReports() {
+				if c.GetType() == proto.Connection_SSH && c.GetAction() == proto.Connection_DISCONNECT {
+					return true
+				}
+			}
+			return false
+		}, testutil.WaitLong, testutil.IntervalFast)
+		for _, c := range client.GetConnectionReports() {
+			require.NotEqual(t, proto.Connection_CONNECT, c.GetAction())
+		}
+	})
+}
+
 // TestAgent_UpdatedDERP checks that agents can handle their DERP map being
 // updated, and that clients can also handle it.
 func TestAgent_UpdatedDERP(t *testing.T) {
@@ -84,8 +84,22 @@ type Config struct {
 	X11SocketDir string
 	// BlockFileTransfer restricts use of file transfer applications.
 	BlockFileTransfer bool
+	// ReportConnection is called when an SSH session or a JetBrains port
+	// forward is opened, with the address of the client. The returned
+	// function is called when it is closed.
+	ReportConnection func(id uuid.UUID, connectionType ConnectionType, ip string) (disconnected func(code int, reason string))
 }
 
+// ConnectionType is the type of a connection passed to
+// Config.ReportConnection.
+type ConnectionType string
+
+const (
+	ConnectionTypeSSH       ConnectionType = "ssh"
+	ConnectionTypeVSCode    ConnectionType = "vscode"
+	ConnectionTypeJetBrains ConnectionType = "jetbrains"
+)
+
 type Server struct {
 	mu        sync.RWMutex // Protects following.
 	fs        afero.Fs
@@ -136,6 +150,11 @@ func NewServer(ctx context.Context, logger slog.Logger, prometheusRegistry *prom
 	if config.AnnouncementBanners == nil {
 		config.AnnouncementBanners = func() *[]codersdk.BannerConfig { return &[]codersdk.BannerConfig{} }
 	}
+	if config.ReportConnection == nil {
+		config.ReportConnection = func(uuid.UUID, ConnectionType, string) func(int, string) {
+			return func(int, string) {}
+		}
+	}
 	if config.WorkingDirectory == nil {
 		config.WorkingDirectory = func() string {
 			home, err := userHomeDir()
@@ -166,7 +185,7 @@ func NewServer(ctx context.Context, logger slog.Logger, prometheusRegistry *prom
 		ChannelHandlers: map[string]ssh.ChannelHandler{
 			"direct-tcpip": func(srv *ssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx ssh.Context) {
 				// Wrapper is designed to find and track JetBrains Gateway connections.
-				wrapped := NewJetbrainsChannelWatcher(ctx, s.logger, newChan, &s.connCountJetBrains)
+				wrapped := NewJetbrainsChannelWatcher(ctx, s.logger, s.config.ReportConnection, newChan, &s.connCountJetBrains)
 				ssh.DirectTCPIPHandler(srv, conn, wrapped, ctx)
 			},
 			"direct-streamlocal@openssh.com": directStreamLocalHandler,
@@ -253,12 +272,13 @@ func (s *Server) ConnStats() ConnStats {
 
 func (s *Server) sessionHandler(session ssh.Session) {
 	ctx := session.Context()
+	// Assigning a random uuid for each session is useful for tracking
+	// logs for the same ssh session.
+	id := uuid.New()
 	logger := s.logger.With(
 		slog.F("remote_addr", session.RemoteAddr()),
 		slog.F("local_addr", session.LocalAddr()),
-		// Assigning a random uuid for each session is useful for tracking
-		// logs for the same ssh session.
-		slog.F("id", uuid.NewString()),
+		slog.F("id", id.String()),
 	)
 	logger.Info(ctx, "handling ssh session")
 
@@ -270,11 +290,28 @@ func (s *Server) sessionHandler(session ssh.Session) {
 	}
 	defer s.trackSession(session, false)
 
+	// JetBrains launches hundreds of ssh sessions, so its connections are
+	// reported by the port forwarding channel watcher instead.
+	var reason string
+	if magicType := magicSessionType(session.Environ()); magicType != MagicSessionTypeJetBrains {
+		connectionType := ConnectionTypeSSH
+		if magicType == MagicSessionTypeVSCode {
+			connectionType = ConnectionTypeVSCode
+		}
+		disconnected := s.config.ReportConnection(id, connectionType, session.RemoteAddr().String())
+		recorder := &exitCodeRecorder{Session: session}
+		session = recorder
+		defer func() {
+			disconnected(recorder.code, reason)
+		}()
+	}
+
 	extraEnv := make([]string, 0)
 	x11, hasX11 := session.X11()
 	if hasX11 {
 		handled := s.x11Handler(session.Context(), x11)
 		if !handled {
+			reason = "x11 handler failed"
 			_ = session.Exit(1)
 			logger.Error(ctx, "x11 handler failed")
 			return
@@ -290,6 +327,7 @@ func (s *Server) sessionHandler(session ssh.Session) {
 			errorMessage := fmt.Sprintf("\x02%s\n", BlockedFileTransferErrorMessage)
 			_, _ = session.Write([]byte(errorMessage))
 		}
+		reason = BlockedFileTransferErrorMessage
 		_ = session.Exit(BlockedFileTransferErrorCode)
 		return
 	}
@@ -301,6 +339,7 @@ func (s *Server) sessionHandler(session ssh.Session) {
 		return
 	default:
 		logger.Warn(ctx, "unsupported subsystem", slog.F("subsystem", ss))
+		reason = fmt.Sprintf("unsupported subsystem %q", ss)
 		_ = session.Exit(1)
 		return
 	}
@@ -335,6 +374,7 @@ func (s *Server) sessionHandler(session ssh.Session) {
 	}
 	if err != nil {
 		logger.Warn(ctx, "ssh session failed", slog.Error(err))
+		reason = err.Error()
 		// This exit code is designed to be unlikely to be confused for a legit exit code
 		// from the process.
 		_ = session.Exit(MagicSessionErrorCode)
@@ -375,6 +415,31 @@ func (s *Server) fileTransferBlocked(session ssh.Session) bool {
 	return false
 }
 
+// magicSessionType returns the lowercased value of the magic session type
+// environment variable, or an empty string if it is not set.
+func magicSessionType(env []string) string {
+	var magicType string
+	for _, kv := range env {
+		if !strings.HasPrefix(kv, MagicSessionTypeEnvironmentVariable) {
+			continue
+		}
+		magicType = strings.ToLower(strings.TrimPrefix(kv, MagicSessionTypeEnvironmentVariable+"="))
+	}
+	return magicType
+}
+
+// exitCodeRecorder records the exit code sent to the client, so it can be
+// reported once the session is closed.
+type exitCodeRecorder struct {
+	ssh.Session
+	code int
+}
+
+func (r *exitCodeRecorder) Exit(code int) error {
+	r.code = code
+	return r.Session.Exit(code)
+}
+
 func (s *Server) sessionStart(logger slog.Logger, session ssh.Session, extraEnv []string) (retErr error) {
 	ctx := session.Context()
 	env := append(session.Environ(), extraEnv...)
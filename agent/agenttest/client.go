@@ -124,6 +124,10 @@ func (c *Client) GetLifecycleStates() []codersdk.WorkspaceAgentLifecycle {
 	return c.fakeAgentAPI.GetLifecycleStates()
 }
 
+func (c *Client) GetConnectionReports() []*agentproto.Connection {
+	return c.fakeAgentAPI.GetConnections()
+}
+
 func (c *Client) GetStartup() <-chan *agentproto.Startup {
 	return c.fakeAgentAPI.startupCh
 }
@@ -142,6 +146,10 @@ func (c *Client) SetAnnouncementBannersFunc(f func() ([]codersdk.BannerConfig, e
 	c.fakeAgentAPI.SetAnnouncementBannersFunc(f)
 }
 
+func (c *Client) SetReportConnectionFunc(f func(*agentproto.Connection) error) {
+	c.fakeAgentAPI.SetReportConnectionFunc(f)
+}
+
 func (c *Client) PushDERPMapUpdate(update *tailcfg.DERPMap) error {
 	timer := time.NewTimer(testutil.WaitShort)
 	defer timer.Stop()
@@ -170,8 +178,10 @@ type FakeAgentAPI struct {
 	logsCh          chan<- *agentproto.BatchCreateLogsRequest
 	lifecycleStates []codersdk.WorkspaceAgentLifecycle
 	metadata        map[string]agentsdk.Metadata
+	connections     []*agentproto.Connection
 
 	getAnnouncementBannersFunc func() ([]codersdk.BannerConfig, error)
+	reportConnectionFunc       func(*agentproto.Connection) error
 }
 
 func (f *FakeAgentAPI) GetManifest(context.Context, *agentproto.GetManifestRequest) (*agentproto.Manifest, error) {
@@ -301,6 +311,33 @@ func (f *FakeAgentAPI) BatchCreateLogs(ctx context.Context, req *agentproto.Batc
 	return &agentproto.BatchCreateLogsResponse{}, nil
 }
 
+func (f *FakeAgentAPI) GetConnections() []*agentproto.Connection {
+	f.Lock()
+	defer f.Unlock()
+	return slices.Clone(f.connections)
+}
+
+func (f *FakeAgentAPI) ReportConnection(ctx context.Context, req *agentproto.ReportConnectionRequest) (*agentproto.ReportConnectionResponse, error) {
+	f.logger.Debug(ctx, "report connection", slog.F("req", req))
+	f.Lock()
+	defer f.Unlock()
+	if f.reportConnectionFunc != nil {
+		if err := f.reportConnectionFunc(req.GetConnection()); err != nil {
+			return nil, err
+		}
+	}
+	f.connections = append(f.connections, req.GetConnection())
+	return &agentproto.ReportConnectionResponse{}, nil
+}
+
+// SetReportConnectionFunc sets a function that is called for every reported
+// connection. Reports it returns an error for are rejected.
+func (f *FakeAgentAPI) SetReportConnectionFunc(fn func(*agentproto.Connection) error) {
+	f.Lock()
+	defer f.Unlock()
+	f.reportConnectionFunc = fn
+}
+
 func NewFakeAgentAPI(t testing.TB, logger slog.Logger, manifest *agentproto.Manifest, statsCh chan *agentproto.Stats) *FakeAgentAPI {
 	return &FakeAgentAPI{
 		t:           t,
@@ -30,6 +30,7 @@ func (r *RootCmd) create() *serpent.Command {
 		parameterFlags     workspaceParameterFlags
 		autoUpdates        string
 		copyParametersFrom string
+		presetName         string
 		// Organization context is only required if more than 1 template
 		// shares the same name across multiple organizations.
 		orgContext = NewOrganizationContext()
@@ -237,6 +238,14 @@ func (r *RootCmd) create() *serpent.Command {
 				return xerrors.Errorf("can't parse given parameter defaults: %w", err)
 			}
 
+			var preset codersdk.TemplateVersionPreset
+			if presetName != "" {
+				preset, err = templateVersionPreset(inv, client, templateVersionID, presetName)
+				if err != nil {
+					return err
+				}
+			}
+
 			var sourceWorkspaceParameters []codersdk.WorkspaceBuildParameter
 			if copyParametersFrom != "" {
 				sourceWorkspaceParameters, err = client.WorkspaceBuildParameters(inv.Context(), sourceWorkspace.LatestBuild.ID)
@@ -255,6 +264,7 @@ func (r *RootCmd) create() *serpent.Command {
 				RichParameterDefaults: cliBuildParameterDefaults,
 
 				SourceWorkspaceParameters: sourceWorkspaceParameters,
+				PresetParameters:          preset.Parameters,
 			})
 			if err != nil {
 				return xerrors.Errorf("prepare build: %w", err)
@@ -274,12 +284,13 @@ func (r *RootCmd) create() *serpent.Command {
 			}
 
 			workspace, err := client.CreateWorkspace(inv.Context(), template.OrganizationID, workspaceOwner, codersdk.CreateWorkspaceRequest{
-				TemplateVersionID:   templateVersionID,
-				Name:                workspaceName,
-				AutostartSchedule:   schedSpec,
-				TTLMillis:           ttlMillis,
-				RichParameterValues: richParameters,
-				AutomaticUpdates:    codersdk.AutomaticUpdates(autoUpdates),
+				TemplateVersionID:       templateVersionID,
+				Name:                    workspaceName,
+				AutostartSchedule:       schedSpec,
+				TTLMillis:               ttlMillis,
+				RichParameterValues:     richParameters,
+				AutomaticUpdates:        codersdk.AutomaticUpdates(autoUpdates),
+				TemplateVersionPresetID: preset.ID,
 			})
 			if err != nil {
 				return xerrors.Errorf("create workspace: %w", err)
@@ -326,6 +337,12 @@ func (r *RootCmd) create() *serpent.Command {
 			Default:     string(codersdk.AutomaticUpdatesNever),
 			Value:       serpent.StringOf(&autoUpdates),
 		},
+		serpent.Option{
+			Flag:        "preset",
+			Env:         "CODER_WORKSPACE_PRESET",
+			Description: "Specify the name of a template version preset to use its parameter values.",
+			Value:       serpent.StringOf(&presetName),
+		},
 		serpent.Option{
 			Flag:        "copy-parameters-from",
 			Env:         "CODER_WORKSPACE_COPY_PARAMETERS_FROM",
@@ -347,6 +364,7 @@ type prepWorkspaceBuildArgs struct {
 
 	LastBuildParameters       []codersdk.WorkspaceBuildParameter
 	SourceWorkspaceParameters []codersdk.WorkspaceBuildParameter
+	PresetParameters          []codersdk.WorkspaceBuildParameter
 
 	PromptBuildOptions bool
 	BuildOptions       []codersdk.WorkspaceBuildParameter
@@ -357,6 +375,26 @@ type prepWorkspaceBuildArgs struct {
 	RichParameterDefaults []codersdk.WorkspaceBuildParameter
 }
 
+// templateVersionPreset returns the preset of the template version with the
+// given name.
+func templateVersionPreset(inv *serpent.Invocation, client *codersdk.Client, templateVersionID uuid.UUID, name string) (codersdk.TemplateVersionPreset, error) {
+	templateVersion, err := client.TemplateVersion(inv.Context(), templateVersionID)
+	if err != nil {
+		return codersdk.TemplateVersionPreset{}, xerrors.Errorf("get template version: %w", err)
+	}
+	names := make([]string, 0, len(templateVersion.Presets))
+	for _, preset := range templateVersion.Presets {
+		if preset.Name == name {
+			return preset, nil
+		}
+		names = append(names, preset.Name)
+	}
+	if len(names) == 0 {
+		return codersdk.TemplateVersionPreset{}, xerrors.Errorf("preset %q not found, template version %q has no presets", name, templateVersion.Name)
+	}
+	return codersdk.TemplateVersionPreset{}, xerrors.Errorf("preset %q not found, available presets: %s", name, strings.Join(names, ", "))
+}
+
 // prepWorkspaceBuild will ensure a workspace build will succeed on the latest template version.
 // Any missing params will be prompted to the user. It supports rich parameters.
 func prepWorkspaceBuild(inv *serpent.Invocation, client *codersdk.Client, args prepWorkspaceBuildArgs) ([]codersdk.WorkspaceBuildParameter, error) {
@@ -383,6 +421,7 @@ func prepWorkspaceBuild(inv *serpent.Invocation, client *codersdk.Client, args p
 	resolver := new(ParameterResolver).
 		WithLastBuildParameters(args.LastBuildParameters).
 		WithSourceWorkspaceParameters(args.SourceWorkspaceParameters).
+		WithPresetParameters(args.PresetParameters).
 		WithPromptBuildOptions(args.PromptBuildOptions).
 		WithBuildOptions(args.BuildOptions).
 		WithPromptRichParameters(args.PromptRichParameters).
@@ -14,6 +14,7 @@ func (r *RootCmd) expCmd() *serpent.Command {
 			r.scaletestCmd(),
 			r.errorExample(),
 			r.promptExample(),
+			r.rbacCmd(),
 		},
 	}
 	return cmd
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) rbacCmd() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "rbac",
+		Short: "Debug role based access control.",
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.rbacExplain(),
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) rbacExplain() *serpent.Command {
+	var (
+		user         string
+		action       string
+		object       string
+		owner        string
+		organization string
+		scope        string
+
+		client    = new(codersdk.Client)
+		formatter = cliui.NewOutputFormatter(
+			cliui.ChangeFormatterData(cliui.TextFormat(), func(data any) (any, error) {
+				explanation, ok := data.(codersdk.AuthorizationExplanation)
+				if !ok {
+					return nil, xerrors.Errorf("expected AuthorizationExplanation, got %T", data)
+				}
+				return formatExplanation(explanation), nil
+			}),
+			cliui.JSONFormat(),
+		)
+	)
+	cmd := &serpent.Command{
+		Use:   "explain",
+		Short: "Explain whether a user is allowed to perform an action, and why.",
+		Long: FormatExamples(
+			Example{
+				Description: "Explain why a user cannot update a template",
+				Command:     "coder exp rbac explain --user alice --action update --object template:6f1f5d4e-0b8a-4ea2-9bd9-c5e1b4b8f3a1",
+			},
+			Example{
+				Description: "Explain whether a user can create workspaces for themselves in an organization",
+				Command:     "coder exp rbac explain --user alice --action create --object workspace --owner alice --organization coder",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			resourceType, resourceID, _ := strings.Cut(object, ":")
+			req := codersdk.AuthorizationExplainRequest{
+				Object: codersdk.AuthorizationObject{
+					ResourceType: codersdk.RBACResource(resourceType),
+					ResourceID:   resourceID,
+				},
+				Action: codersdk.RBACAction(action),
+				Scope:  codersdk.APIKeyScope(scope),
+			}
+			if owner != "" {
+				ownerUser, err := client.User(ctx, owner)
+				if err != nil {
+					return xerrors.Errorf("fetch owner: %w", err)
+				}
+				req.Object.OwnerID = ownerUser.ID.String()
+			}
+			if organization != "" {
+				org, err := client.OrganizationByName(ctx, organization)
+				if err != nil {
+					return xerrors.Errorf("fetch organization: %w", err)
+				}
+				req.Object.OrganizationID = org.ID.String()
+			}
+
+			explanation, err := client.ExplainAuthorization(ctx, user, req)
+			if err != nil {
+				return xerrors.Errorf("explain authorization: %w", err)
+			}
+
+			out, err := formatter.Format(ctx, explanation)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "user",
+			Description: "The username or ID of the user to explain the request for.",
+			Default:     codersdk.Me,
+			Value:       serpent.StringOf(&user),
+		},
+		{
+			Flag:        "action",
+			Description: "The action to perform, e.g. read, update.",
+			Required:    true,
+			Value:       serpent.StringOf(&action),
+		},
+		{
+			Flag: "object",
+			Description: "The object to perform the action on, as <resource_type>[:<resource_id>]. " +
+				"The owner and organization of workspaces, templates, users and groups are looked up by ID.",
+			Required: true,
+			Value:    serpent.StringOf(&object),
+		},
+		{
+			Flag:        "owner",
+			Description: "The username or ID of the user that owns the object.",
+			Value:       serpent.StringOf(&owner),
+		},
+		{
+			Flag:        "organization",
+			Description: "The name or ID of the organization that owns the object.",
+			Value:       serpent.StringOf(&organization),
+		},
+		{
+			Flag:        "scope",
+			Description: "The API key scope to evaluate the request with.",
+			Default:     string(codersdk.APIKeyScopeAll),
+			Value: serpent.EnumOf(&scope,
+				string(codersdk.APIKeyScopeAll),
+				string(codersdk.APIKeyScopeApplicationConnect),
+			),
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func formatExplanation(e codersdk.AuthorizationExplanation) string {
+	var sb strings.Builder
+	result := "denied"
+	if e.Allowed {
+		result = "allowed"
+	}
+	object := string(e.Object.ResourceType)
+	if e.Object.ResourceID != "" {
+		object += ":" + e.Object.ResourceID
+	}
+	_, _ = fmt.Fprintf(&sb, "%s\n\n", cliui.Bold(fmt.Sprintf("Request %s on %s.", result, object)))
+	if e.Object.OwnerID != "" {
+		_, _ = fmt.Fprintf(&sb, "Object owner:        %s\n", e.Object.OwnerID)
+	}
+	if e.Object.OrganizationID != "" {
+		member := "not a member"
+		if e.OrganizationMember {
+			member = "member"
+		}
+		_, _ = fmt.Fprintf(&sb, "Object organization: %s (%s)\n", e.Object.OrganizationID, member)
+	}
+	_, _ = fmt.Fprintf(&sb, "Roles:               %s\n", strings.Join(e.SubjectRoles, ", "))
+	_, _ = fmt.Fprintf(&sb, "Scope:               %s\n\n", e.Scope)
+
+	decisions := func(d codersdk.AuthorizationLevelDecisions) string {
+		return fmt.Sprintf("site=%s org=%s user=%s", d.Site, d.Organization, d.User)
+	}
+	_, _ = fmt.Fprintf(&sb, "Allowed by roles:    %-5t (%s)\n", e.RoleAllowed, decisions(e.RoleDecisions))
+	_, _ = fmt.Fprintf(&sb, "Allowed by ACL:      %t\n", e.ACLAllowed)
+	_, _ = fmt.Fprintf(&sb, "Allowed by scope:    %-5t (%s)\n", e.ScopeAllowed, decisions(e.ScopeDecisions))
+
+	if len(e.Permissions) > 0 {
+		_, _ = fmt.Fprintf(&sb, "\nMatching permissions:\n")
+		for _, perm := range e.Permissions {
+			source := perm.Role
+			if perm.Scope {
+				source = "scope " + source
+			}
+			negate := ""
+			if perm.Negate {
+				negate = "-"
+			}
+			_, _ = fmt.Fprintf(&sb, "  %s (%s): %s%s:%s\n", source, perm.Level, negate, perm.ResourceType, perm.Action)
+		}
+	}
+	if len(e.ACL) > 0 {
+		_, _ = fmt.Fprintf(&sb, "\nMatching ACL entries:\n")
+		for _, entry := range e.ACL {
+			actions := make([]string, 0, len(entry.Actions))
+			for _, action := range entry.Actions {
+				actions = append(actions, string(action))
+			}
+			subject := "user " + entry.UserID
+			if entry.GroupID != "" {
+				subject = "group " + entry.GroupID
+				if entry.GroupID == e.Object.OrganizationID {
+					subject = "group Everyone"
+				}
+			}
+			_, _ = fmt.Fprintf(&sb, "  %s: %s\n", subject, strings.Join(actions, ", "))
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
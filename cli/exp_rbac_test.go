@@ -0,0 +1,69 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestRBACExplain(t *testing.T) {
+	t.Parallel()
+
+	client, db := coderdtest.NewWithDatabase(t, nil)
+	owner := coderdtest.CreateFirstUser(t, client)
+	_, member := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+	template := dbgen.Template(t, db, database.Template{
+		OrganizationID: owner.OrganizationID,
+		CreatedBy:      owner.UserID,
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		t.Parallel()
+
+		inv, root := clitest.New(t, "exp", "rbac", "explain",
+			"--user", member.Username,
+			"--action", "update",
+			"--object", "template:"+template.ID.String(),
+		)
+		clitest.SetupConfig(t, client, root)
+		var stdout bytes.Buffer
+		inv.Stdout = &stdout
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		require.NoError(t, inv.WithContext(ctx).Run())
+		require.Contains(t, stdout.String(), "Request denied on template:"+template.ID.String())
+		require.Contains(t, stdout.String(), "Allowed by roles:    false")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		inv, root := clitest.New(t, "exp", "rbac", "explain",
+			"--user", member.Username,
+			"--action", "create",
+			"--object", "workspace",
+			"--owner", member.Username,
+			"--organization", owner.OrganizationID.String(),
+			"--output", "json",
+		)
+		clitest.SetupConfig(t, client, root)
+		var stdout bytes.Buffer
+		inv.Stdout = &stdout
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		var explanation codersdk.AuthorizationExplanation
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &explanation))
+		require.True(t, explanation.Allowed)
+		require.Equal(t, member.ID.String(), explanation.Object.OwnerID)
+		require.Equal(t, owner.OrganizationID.String(), explanation.Object.OrganizationID)
+	})
+}
@@ -107,14 +107,60 @@ func (r *RootCmd) loginWithPassword(
 	client *codersdk.Client,
 	email, password string,
 ) error {
-	resp, err := client.LoginWithPassword(inv.Context(), codersdk.LoginWithPasswordRequest{
+	req := codersdk.LoginWithPasswordRequest{
 		Email:    email,
 		Password: password,
-	})
+	}
+	resp, err := client.LoginWithPassword(inv.Context(), req)
+	if codersdk.IsMFARequiredError(err) {
+		req.MFACode, err = promptMFACode(inv, "Enter the code from your authenticator app or a recovery code:")
+		if err != nil {
+			return err
+		}
+		resp, err = client.LoginWithPassword(inv.Context(), req)
+	}
 	if err != nil {
 		return xerrors.Errorf("login with password: %w", err)
 	}
 
+	if resp.MFAEnrollmentRequired {
+		// The session can only be used to enroll, so log in again once
+		// enrollment is complete.
+		client.SetSessionToken(resp.SessionToken)
+		err = enrollTOTP(inv, client)
+		if err != nil {
+			return err
+		}
+		req.MFACode, err = promptMFACode(inv, "Enter the next code from your authenticator app to finish logging in:")
+		if err != nil {
+			return err
+		}
+		resp, err = client.LoginWithPassword(inv.Context(), req)
+		if err != nil {
+			return xerrors.Errorf("login with password: %w", err)
+		}
+	}
+
+	if resp.PasswordChangeRequired {
+		// The session can only be used to change the password. Changing it
+		// logs out every session, so log in again with the new password.
+		client.SetSessionToken(resp.SessionToken)
+		req.Password, err = changeExpiredPassword(inv, client, req.Password)
+		if err != nil {
+			return err
+		}
+		if req.MFACode != "" {
+			req.MFACode, err = promptMFACode(inv, "Enter the next code from your authenticator app to finish logging in:")
+			if err != nil {
+				return err
+			}
+		}
+		resp, err = client.LoginWithPassword(inv.Context(), req)
+		if err != nil {
+			return xerrors.Errorf("login with password: %w", err)
+		}
+	}
+
 	sessionToken := resp.SessionToken
 	config := r.createConfig()
 	err = config.Session().Write(sessionToken)
@@ -139,6 +185,72 @@ func (r *RootCmd) loginWithPassword(
 	return nil
 }
 
+func promptMFACode(inv *serpent.Invocation, text string) (string, error) {
+	code, err := cliui.Prompt(inv, cliui.PromptOptions{
+		Text:     text,
+		Validate: cliui.ValidateNotEmpty,
+	})
+	if err != nil {
+		return "", xerrors.Errorf("mfa code prompt: %w", err)
+	}
+	return code, nil
+}
+
+// changeExpiredPassword prompts for a new password and changes the expired
+// password of the authenticated user.
+func changeExpiredPassword(inv *serpent.Invocation, client *codersdk.Client, oldPassword string) (string, error) {
+	_, _ = fmt.Fprintln(inv.Stdout, "Your password has expired and must be changed.")
+	password, err := promptFirstPassword(inv)
+	if err != nil {
+		return "", err
+	}
+	err = client.UpdateUserPassword(inv.Context(), codersdk.Me, codersdk.UpdateUserPasswordRequest{
+		OldPassword: oldPassword,
+		Password:    password,
+	})
+	if err != nil {
+		return "", xerrors.Errorf("change password: %w", err)
+	}
+	return password, nil
+}
+
+// enrollTOTP enrolls the authenticated user in TOTP and prints their recovery
+// codes.
+func enrollTOTP(inv *serpent.Invocation, client *codersdk.Client) error {
+	ctx := inv.Context()
+	enrollment, err := client.EnrollTOTP(ctx, codersdk.Me)
+	if err != nil {
+		return xerrors.Errorf("enroll in totp: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(inv.Stdout, "This deployment requires multi-factor authentication. Add the following to your authenticator app:\n\n\t%s\n\nOr enter the secret manually: %s\n\n",
+		enrollment.ProvisioningURI,
+		pretty.Sprint(cliui.DefaultStyles.Code, enrollment.Secret),
+	)
+
+	var codes codersdk.TOTPRecoveryCodes
+	_, err = cliui.Prompt(inv, cliui.PromptOptions{
+		Text: "Enter the code from your authenticator app:",
+		Validate: func(code string) error {
+			codes, err = client.VerifyTOTP(ctx, codersdk.Me, codersdk.VerifyTOTPRequest{Code: code})
+			if err != nil {
+				return xerrors.New("That code is not valid!")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return xerrors.Errorf("verify totp prompt: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(inv.Stdout, "\nStore these recovery codes somewhere safe. Each can be used once in place of a code if you lose access to your authenticator app:\n\n")
+	for _, code := range codes.RecoveryCodes {
+		_, _ = fmt.Fprintf(inv.Stdout, "\t%s\n", code)
+	}
+	_, _ = fmt.Fprintln(inv.Stdout)
+	return nil
+}
+
 func (r *RootCmd) login() *serpent.Command {
 	const firstUserTrialEnv = "CODER_FIRST_USER_TRIAL"
 
@@ -2,11 +2,15 @@ package cli
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/xerrors"
 
 	"github.com/coder/serpent"
 
+	"github.com/coder/coder/v2/cli/cliui"
 	"github.com/coder/coder/v2/codersdk"
 )
 
@@ -14,7 +18,8 @@ func (r *RootCmd) notifications() *serpent.Command {
 	cmd := &serpent.Command{
 		Use:   "notifications",
 		Short: "Manage Coder notifications",
-		Long: "Administrators can use these commands to change notification settings.\n" + FormatExamples(
+		Long: "Administrators can use these commands to change notification settings, and users can use them to manage " +
+			"which notifications they receive.\n" + FormatExamples(
 			Example{
 				Description: "Pause Coder notifications. Administrators can temporarily stop notifiers from dispatching messages in case of the target outage (for example: unavailable SMTP server or Webhook not responding).",
 				Command:     "coder notifications pause",
@@ -23,6 +28,18 @@ func (r *RootCmd) notifications() *serpent.Command {
 				Description: "Resume Coder notifications",
 				Command:     "coder notifications resume",
 			},
+			Example{
+				Description: "Stop receiving notifications when your workspace is deleted",
+				Command:     `coder notifications preferences disable "Workspace Deleted"`,
+			},
+			Example{
+				Description: "List your unread inbox notifications",
+				Command:     "coder notifications inbox list --unread",
+			},
+			Example{
+				Description: "Change the title of the notification sent when a workspace is deleted",
+				Command:     `coder notifications templates edit "Workspace Deleted" --title 'Your workspace "{{.Labels.name}}" was deleted'`,
+			},
 		),
 		Aliases: []string{"notification"},
 		Handler: func(inv *serpent.Invocation) error {
@@ -31,6 +48,9 @@ func (r *RootCmd) notifications() *serpent.Command {
 		Children: []*serpent.Command{
 			r.pauseNotifications(),
 			r.resumeNotifications(),
+			r.notificationPreferences(),
+			r.notificationInbox(),
+			r.notificationTemplates(),
 		},
 	}
 	return cmd
@@ -83,3 +103,731 @@ func (r *RootCmd) resumeNotifications() *serpent.Command {
 	}
 	return cmd
 }
+
+func (r *RootCmd) notificationPreferences() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "preferences",
+		Short: "Manage which notifications you receive, and how",
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.listNotificationPreferences(),
+			r.toggleNotificationPreferences(false),
+			r.toggleNotificationPreferences(true),
+			r.setNotificationPreferenceMethod(),
+		},
+	}
+	return cmd
+}
+
+type notificationPreferenceRow struct {
+	// For JSON format:
+	codersdk.NotificationPreference `table:"-"`
+
+	// For table format:
+	ID      string `json:"-" table:"id"`
+	Name    string `json:"-" table:"name,default_sort"`
+	Group   string `json:"-" table:"group"`
+	Enabled bool   `json:"-" table:"enabled"`
+	Method  string `json:"-" table:"method"`
+}
+
+func (r *RootCmd) listNotificationPreferences() *serpent.Command {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]notificationPreferenceRow{}, []string{"name", "group", "enabled", "method"}),
+		cliui.JSONFormat(),
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List your notification preferences",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			prefs, err := client.GetUserNotificationPreferences(inv.Context(), codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get notification preferences: %w", err)
+			}
+
+			rows := make([]notificationPreferenceRow, 0, len(prefs))
+			for _, pref := range prefs {
+				method := pref.Method
+				if method == "" {
+					method = "default"
+				}
+				rows = append(rows, notificationPreferenceRow{
+					NotificationPreference: pref,
+					ID:                     pref.NotificationTemplateID.String(),
+					Name:                   pref.NotificationTemplateName,
+					Group:                  pref.Group,
+					Enabled:                !pref.Disabled,
+					Method:                 method,
+				})
+			}
+
+			out, err := formatter.Format(inv.Context(), rows)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) toggleNotificationPreferences(enable bool) *serpent.Command {
+	use, short, verb := "disable", "Stop receiving the given notifications", "disabled"
+	if enable {
+		use, short, verb = "enable", "Start receiving the given notifications again", "enabled"
+	}
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   use + " <notification...>",
+		Short: short,
+		Long:  "Notifications can be referenced by their name or ID, as shown by \"coder notifications preferences list\".",
+		Middleware: serpent.Chain(
+			serpent.RequireRangeArgs(1, -1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			prefs, err := client.GetUserNotificationPreferences(inv.Context(), codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get notification preferences: %w", err)
+			}
+
+			var req codersdk.UpdateUserNotificationPreferences
+			for _, arg := range inv.Args {
+				pref, err := findNotificationPreference(prefs, arg)
+				if err != nil {
+					return err
+				}
+				req.Preferences = append(req.Preferences, codersdk.UpdateNotificationPreference{
+					NotificationTemplateID: pref.NotificationTemplateID,
+					Disabled:               !enable,
+					Method:                 pref.Method,
+				})
+			}
+
+			_, err = client.UpdateUserNotificationPreferences(inv.Context(), codersdk.Me, req)
+			if err != nil {
+				return xerrors.Errorf("update notification preferences: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "Notifications %s: %s\n", verb, strings.Join(inv.Args, ", "))
+			return nil
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) setNotificationPreferenceMethod() *serpent.Command {
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "set-method <notification> <method>",
+		Short: "Choose how the given notification is delivered to you",
+		Long: "The method must be one of the delivery methods supported by the deployment (for example: smtp, webhook, " +
+			"slack or teams). Use \"default\" to revert to the deployment's default method.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			prefs, err := client.GetUserNotificationPreferences(inv.Context(), codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get notification preferences: %w", err)
+			}
+
+			pref, err := findNotificationPreference(prefs, inv.Args[0])
+			if err != nil {
+				return err
+			}
+
+			method := inv.Args[1]
+			if method == "default" {
+				method = ""
+			}
+
+			_, err = client.UpdateUserNotificationPreferences(inv.Context(), codersdk.Me, codersdk.UpdateUserNotificationPreferences{
+				Preferences: []codersdk.UpdateNotificationPreference{{
+					NotificationTemplateID: pref.NotificationTemplateID,
+					Disabled:               pref.Disabled,
+					Method:                 method,
+				}},
+			})
+			if err != nil {
+				return xerrors.Errorf("update notification preferences: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "Notification %q will be delivered via %s.\n", pref.NotificationTemplateName, inv.Args[1])
+			return nil
+		},
+	}
+	return cmd
+}
+
+// findNotificationPreference finds the preference for the notification template with the given name or ID.
+func findNotificationPreference(prefs []codersdk.NotificationPreference, nameOrID string) (codersdk.NotificationPreference, error) {
+	id, err := uuid.Parse(nameOrID)
+	for _, pref := range prefs {
+		if (err == nil && pref.NotificationTemplateID == id) || strings.EqualFold(pref.NotificationTemplateName, nameOrID) {
+			return pref, nil
+		}
+	}
+	return codersdk.NotificationPreference{}, xerrors.Errorf("notification %q not found", nameOrID)
+}
+
+func (r *RootCmd) notificationInbox() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "inbox",
+		Short: "Manage the notifications delivered to your inbox",
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.listInboxNotifications(),
+			r.readInboxNotifications(),
+			r.readAllInboxNotifications(),
+			r.watchInboxNotifications(),
+		},
+	}
+	return cmd
+}
+
+type inboxNotificationRow struct {
+	// For JSON format:
+	codersdk.InboxNotification `table:"-"`
+
+	// For table format:
+	ID        string    `json:"-" table:"id,nosort"`
+	Title     string    `json:"-" table:"title"`
+	Read      bool      `json:"-" table:"read"`
+	CreatedAt time.Time `json:"-" table:"created at"`
+}
+
+func inboxNotificationRows(notifs []codersdk.InboxNotification) []inboxNotificationRow {
+	rows := make([]inboxNotificationRow, 0, len(notifs))
+	for _, notif := range notifs {
+		rows = append(rows, inboxNotificationRow{
+			InboxNotification: notif,
+			ID:                notif.ID.String(),
+			Title:             notif.Title,
+			Read:              notif.ReadAt != nil,
+			CreatedAt:         notif.CreatedAt,
+		})
+	}
+	return rows
+}
+
+func (r *RootCmd) listInboxNotifications() *serpent.Command {
+	var (
+		unreadOnly bool
+		limit      int64
+		formatter  = cliui.NewOutputFormatter(
+			cliui.TableFormat([]inboxNotificationRow{}, []string{"id", "title", "read", "created at"}),
+			cliui.JSONFormat(),
+		)
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the notifications in your inbox, newest first",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Options: serpent.OptionSet{
+			{
+				Flag:        "unread",
+				Description: "Only list notifications which have not been read.",
+				Value:       serpent.BoolOf(&unreadOnly),
+			},
+			{
+				Flag:          "limit",
+				FlagShorthand: "n",
+				Description:   "The maximum number of notifications to list.",
+				Default:       "25",
+				Value:         serpent.Int64Of(&limit),
+			},
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			resp, err := client.GetInboxNotifications(inv.Context(), codersdk.Me, codersdk.InboxNotificationsRequest{
+				UnreadOnly: unreadOnly,
+				Limit:      int(limit),
+			})
+			if err != nil {
+				return xerrors.Errorf("get inbox notifications: %w", err)
+			}
+
+			if len(resp.Notifications) == 0 {
+				_, _ = fmt.Fprintln(inv.Stderr, "No notifications found.")
+				return nil
+			}
+
+			out, err := formatter.Format(inv.Context(), inboxNotificationRows(resp.Notifications))
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) readInboxNotifications() *serpent.Command {
+	var unread bool
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "read <id...>",
+		Short: "Mark the given inbox notifications as read",
+		Middleware: serpent.Chain(
+			serpent.RequireRangeArgs(1, -1),
+			r.InitClient(client),
+		),
+		Options: serpent.OptionSet{
+			{
+				Flag:        "unread",
+				Description: "Mark the notifications as unread instead.",
+				Value:       serpent.BoolOf(&unread),
+			},
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			for _, arg := range inv.Args {
+				id, err := uuid.Parse(arg)
+				if err != nil {
+					return xerrors.Errorf("invalid notification ID %q: %w", arg, err)
+				}
+
+				notif, err := client.UpdateInboxNotificationReadStatus(inv.Context(), codersdk.Me, id, codersdk.UpdateInboxNotificationReadStatusRequest{
+					IsRead: !unread,
+				})
+				if err != nil {
+					return xerrors.Errorf("update notification %s: %w", id, err)
+				}
+
+				if unread {
+					_, _ = fmt.Fprintf(inv.Stdout, "Marked %q as unread.\n", notif.Title)
+				} else {
+					_, _ = fmt.Fprintf(inv.Stdout, "Marked %q as read.\n", notif.Title)
+				}
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) readAllInboxNotifications() *serpent.Command {
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "read-all",
+		Short: "Mark all notifications in your inbox as read",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			err := client.MarkAllInboxNotificationsAsRead(inv.Context(), codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("mark all notifications as read: %w", err)
+			}
+
+			_, _ = fmt.Fprintln(inv.Stdout, "All notifications have been marked as read.")
+			return nil
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) watchInboxNotifications() *serpent.Command {
+	formatter := cliui.NewOutputFormatter(
+		cliui.ChangeFormatterData(cliui.TextFormat(), func(data any) (any, error) {
+			notif, ok := data.(codersdk.InboxNotification)
+			if !ok {
+				return nil, xerrors.Errorf("expected type %T, got %T", codersdk.InboxNotification{}, data)
+			}
+			out := cliui.Bold(notif.Title) + "\n" + notif.Content
+			for _, action := range notif.Actions {
+				out += fmt.Sprintf("\n  %s: %s", action.Label, action.URL)
+			}
+			return out + "\n", nil
+		}),
+		cliui.JSONFormat(),
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "watch",
+		Short: "Print notifications as they are delivered to your inbox",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			notifs, err := client.WatchInboxNotifications(ctx, codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("watch inbox notifications: %w", err)
+			}
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case notif, ok := <-notifs:
+					if !ok {
+						return xerrors.New("lost connection to the server")
+					}
+					out, err := formatter.Format(ctx, notif)
+					if err != nil {
+						return err
+					}
+					_, _ = fmt.Fprintln(inv.Stdout, out)
+				}
+			}
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) notificationTemplates() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:     "templates",
+		Short:   "Manage the templates from which notifications are created",
+		Aliases: []string{"template"},
+		Long: "Templates use Go's templating syntax. Besides the labels listed for each template (for example: " +
+			"{{ .Labels.name }}), templates can reference the recipient's {{ .UserName }}, {{ .UserUsername }} and " +
+			"{{ .UserEmail }}.",
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.listNotificationTemplates(),
+			r.editNotificationTemplate(),
+			r.resetNotificationTemplate(),
+			r.previewNotificationTemplate(),
+			r.digestNotificationTemplate(),
+		},
+	}
+	return cmd
+}
+
+type notificationTemplateRow struct {
+	// For JSON format:
+	codersdk.NotificationTemplate `table:"-"`
+
+	// For table format:
+	ID           string `json:"-" table:"id"`
+	Name         string `json:"-" table:"name,default_sort"`
+	Group        string `json:"-" table:"group"`
+	Labels       string `json:"-" table:"labels"`
+	Customized   bool   `json:"-" table:"customized"`
+	DigestWindow string `json:"-" table:"digest window"`
+}
+
+func (r *RootCmd) listNotificationTemplates() *serpent.Command {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]notificationTemplateRow{}, []string{"name", "group", "labels", "customized"}),
+		cliui.JSONFormat(),
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List notification templates",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			templates, err := client.GetNotificationTemplates(inv.Context())
+			if err != nil {
+				return xerrors.Errorf("get notification templates: %w", err)
+			}
+
+			rows := make([]notificationTemplateRow, 0, len(templates))
+			for _, tmpl := range templates {
+				var digestWindow string
+				if tmpl.DigestWindowSeconds > 0 {
+					digestWindow = (time.Duration(tmpl.DigestWindowSeconds) * time.Second).String()
+				}
+				rows = append(rows, notificationTemplateRow{
+					NotificationTemplate: tmpl,
+					ID:                   tmpl.ID.String(),
+					Name:                 tmpl.Name,
+					Group:                tmpl.Group,
+					Labels:               strings.Join(tmpl.Labels, ", "),
+					Customized:           tmpl.Customized,
+					DigestWindow:         digestWindow,
+				})
+			}
+
+			out, err := formatter.Format(inv.Context(), rows)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) editNotificationTemplate() *serpent.Command {
+	var title, body string
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "edit <template>",
+		Short: "Change the title or body of a notification template",
+		Long:  "Templates can be referenced by their name or ID, as shown by \"coder notifications templates list\".",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Options: serpent.OptionSet{
+			{
+				Flag:        "title",
+				Description: "The new title template. The current title is kept if unset.",
+				Value:       serpent.StringOf(&title),
+			},
+			{
+				Flag:        "body",
+				Description: "The new body template, in markdown. The current body is kept if unset.",
+				Value:       serpent.StringOf(&body),
+			},
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			if title == "" && body == "" {
+				return xerrors.New("at least one of --title or --body must be set")
+			}
+
+			tmpl, err := findNotificationTemplate(inv, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+
+			req := codersdk.UpdateNotificationTemplateRequest{
+				TitleTemplate: tmpl.TitleTemplate,
+				BodyTemplate:  tmpl.BodyTemplate,
+			}
+			if title != "" {
+				req.TitleTemplate = title
+			}
+			if body != "" {
+				req.BodyTemplate = body
+			}
+
+			_, err = client.UpdateNotificationTemplate(inv.Context(), tmpl.ID, req)
+			if err != nil {
+				return xerrors.Errorf("update notification template: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "Notification template %q has been updated.\n", tmpl.Name)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) resetNotificationTemplate() *serpent.Command {
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "reset <template>",
+		Short: "Restore the default title and body of a notification template",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Options: serpent.OptionSet{
+			cliui.SkipPromptOption(),
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			tmpl, err := findNotificationTemplate(inv, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+
+			_, err = cliui.Prompt(inv, cliui.PromptOptions{
+				Text:      fmt.Sprintf("Discard all changes to notification template %q?", tmpl.Name),
+				IsConfirm: true,
+				Default:   cliui.ConfirmNo,
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = client.ResetNotificationTemplate(inv.Context(), tmpl.ID)
+			if err != nil {
+				return xerrors.Errorf("reset notification template: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "Notification template %q has been reset to its default.\n", tmpl.Name)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) previewNotificationTemplate() *serpent.Command {
+	var (
+		title, body string
+		labels      []string
+		formatter   = cliui.NewOutputFormatter(
+			cliui.ChangeFormatterData(cliui.TextFormat(), func(data any) (any, error) {
+				preview, ok := data.(codersdk.NotificationTemplatePreview)
+				if !ok {
+					return nil, xerrors.Errorf("expected type %T, got %T", codersdk.NotificationTemplatePreview{}, data)
+				}
+				return cliui.Bold(preview.Title) + "\n\n" + preview.Body, nil
+			}),
+			cliui.JSONFormat(),
+		)
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "preview <template>",
+		Short: "Render a notification template with example values",
+		Long: "Unless --title or --body are given, the template's current title and body are rendered. This can be " +
+			"used to check changes before applying them with \"coder notifications templates edit\".",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Options: serpent.OptionSet{
+			{
+				Flag:        "title",
+				Description: "A title template to render instead of the current one.",
+				Value:       serpent.StringOf(&title),
+			},
+			{
+				Flag:        "body",
+				Description: "A body template to render instead of the current one.",
+				Value:       serpent.StringOf(&body),
+			},
+			{
+				Flag:        "label",
+				Description: "Override the example value of a label, in the format key=value.",
+				Value:       serpent.StringArrayOf(&labels),
+			},
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			tmpl, err := findNotificationTemplate(inv, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+
+			req := codersdk.PreviewNotificationTemplateRequest{
+				TitleTemplate: title,
+				BodyTemplate:  body,
+				Labels:        make(map[string]string, len(labels)),
+			}
+			for _, label := range labels {
+				key, value, ok := strings.Cut(label, "=")
+				if !ok {
+					return xerrors.Errorf("invalid label %q, expected the format key=value", label)
+				}
+				req.Labels[key] = value
+			}
+
+			preview, err := client.PreviewNotificationTemplate(inv.Context(), tmpl.ID, req)
+			if err != nil {
+				return xerrors.Errorf("preview notification template: %w", err)
+			}
+
+			out, err := formatter.Format(inv.Context(), preview)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) digestNotificationTemplate() *serpent.Command {
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "digest <template> <window>",
+		Short: "Deliver the messages of a notification template as periodic digests",
+		Long: "Once a message of the template is created for a user, it and any that follow are held for the given " +
+			"window (for example: 1h), and are then delivered to that user as a single message which lists all of " +
+			"them. A window of 0 delivers every message as soon as possible again.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			window, err := time.ParseDuration(inv.Args[1])
+			if err != nil {
+				return xerrors.Errorf("invalid digest window %q: %w", inv.Args[1], err)
+			}
+			if window < 0 {
+				return xerrors.Errorf("digest window must not be negative")
+			}
+
+			tmpl, err := findNotificationTemplate(inv, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+
+			_, err = client.UpdateNotificationTemplateDigest(inv.Context(), tmpl.ID, codersdk.UpdateNotificationTemplateDigestRequest{
+				DigestWindowSeconds: int32(window.Seconds()),
+			})
+			if err != nil {
+				return xerrors.Errorf("update notification template digest window: %w", err)
+			}
+
+			if window == 0 {
+				_, _ = fmt.Fprintf(inv.Stdout, "Notifications of template %q will no longer be delivered as digests.\n", tmpl.Name)
+			} else {
+				_, _ = fmt.Fprintf(inv.Stdout, "Notifications of template %q will be delivered as digests every %s.\n", tmpl.Name, window)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// findNotificationTemplate finds the notification template with the given name or ID.
+func findNotificationTemplate(inv *serpent.Invocation, client *codersdk.Client, nameOrID string) (codersdk.NotificationTemplate, error) {
+	templates, err := client.GetNotificationTemplates(inv.Context())
+	if err != nil {
+		return codersdk.NotificationTemplate{}, xerrors.Errorf("get notification templates: %w", err)
+	}
+
+	id, err := uuid.Parse(nameOrID)
+	for _, tmpl := range templates {
+		if (err == nil && tmpl.ID == id) || strings.EqualFold(tmpl.Name, nameOrID) {
+			return tmpl, nil
+		}
+	}
+	return codersdk.NotificationTemplate{}, xerrors.Errorf("notification template %q not found", nameOrID)
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"golang.org/x/xerrors"
 
@@ -93,6 +94,7 @@ func (r *RootCmd) addOrganizationMember(orgContext *OrganizationContext) *serpen
 }
 
 func (r *RootCmd) assignOrganizationRoles(orgContext *OrganizationContext) *serpent.Command {
+	var roleExpiry temporaryRoleFlags
 	client := new(codersdk.Client)
 
 	cmd := &serpent.Command{
@@ -113,11 +115,12 @@ func (r *RootCmd) assignOrganizationRoles(orgContext *OrganizationContext) *serp
 				return xerrors.Errorf("user_id or username is required as the first argument")
 			}
 			userIdentifier := inv.Args[0]
-			roles := inv.Args[1:]
+			params, err := roleExpiry.updateRoles(inv.Args[1:])
+			if err != nil {
+				return err
+			}
 
-			member, err := client.UpdateOrganizationMemberRoles(ctx, organization.ID, userIdentifier, codersdk.UpdateRoles{
-				Roles: roles,
-			})
+			member, err := client.UpdateOrganizationMemberRoles(ctx, organization.ID, userIdentifier, params)
 			if err != nil {
 				return xerrors.Errorf("update member roles: %w", err)
 			}
@@ -128,9 +131,13 @@ func (r *RootCmd) assignOrganizationRoles(orgContext *OrganizationContext) *serp
 			}
 
 			_, _ = fmt.Fprintf(inv.Stdout, "Member roles updated to [%s]\n", strings.Join(updatedTo, ", "))
+			if params.ExpiresAt != nil {
+				_, _ = fmt.Fprintf(inv.Stdout, "Added roles expire at %s\n", params.ExpiresAt.Format(time.RFC3339))
+			}
 			return nil
 		},
 	}
+	cmd.Options = roleExpiry.options()
 
 	return cmd
 }
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/google/uuid"
 	"golang.org/x/xerrors"
 
 	"github.com/coder/pretty"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/coder/coder/v2/cli/cliui"
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/database/migrations"
 	"github.com/coder/coder/v2/coderd/userpassword"
 )
@@ -77,12 +79,28 @@ func (*RootCmd) resetPassword() *serpent.Command {
 				return xerrors.Errorf("hash password: %w", err)
 			}
 
-			err = db.UpdateUserHashedPassword(inv.Context(), database.UpdateUserHashedPasswordParams{
-				ID:             user.ID,
-				HashedPassword: []byte(hashedPassword),
-			})
+			err = db.InTx(func(tx database.Store) error {
+				err := tx.UpdateUserHashedPassword(inv.Context(), database.UpdateUserHashedPasswordParams{
+					ID:             user.ID,
+					HashedPassword: []byte(hashedPassword),
+				})
+				if err != nil {
+					return xerrors.Errorf("updating password: %w", err)
+				}
+				// The password history tells when the password expires.
+				err = tx.InsertUserPasswordHistory(inv.Context(), database.InsertUserPasswordHistoryParams{
+					ID:             uuid.New(),
+					UserID:         user.ID,
+					HashedPassword: []byte(hashedPassword),
+					CreatedAt:      dbtime.Now(),
+				})
+				if err != nil {
+					return xerrors.Errorf("insert password history: %w", err)
+				}
+				return nil
+			}, nil)
 			if err != nil {
-				return xerrors.Errorf("updating password: %w", err)
+				return err
 			}
 
 			_, _ = fmt.Fprintf(inv.Stdout, "\nPassword has been reset for user %s!\n", pretty.Sprint(cliui.DefaultStyles.Keyword, user.Username))
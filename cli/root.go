@@ -92,6 +92,7 @@ func (r *RootCmd) CoreSubcommands() []*serpent.Command {
 		r.portForward(),
 		r.publickey(),
 		r.resetPassword(),
+		r.sessions(),
 		r.state(),
 		r.templates(),
 		r.tokens(),
@@ -116,9 +117,11 @@ func (r *RootCmd) CoreSubcommands() []*serpent.Command {
 		r.start(),
 		r.stat(),
 		r.stop(),
+		r.transfer(),
 		r.unfavorite(),
 		r.update(),
 		r.whoami(),
+		r.workspaces(),
 
 		// Hidden
 		r.expCmd(),
@@ -81,11 +81,13 @@ import (
 	"github.com/coder/coder/v2/coderd/httpmw"
 	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/oauthpki"
+	"github.com/coder/coder/v2/coderd/prebuilds"
 	"github.com/coder/coder/v2/coderd/prometheusmetrics"
 	"github.com/coder/coder/v2/coderd/prometheusmetrics/insights"
 	"github.com/coder/coder/v2/coderd/promoauth"
 	"github.com/coder/coder/v2/coderd/schedule"
 	"github.com/coder/coder/v2/coderd/telemetry"
+	"github.com/coder/coder/v2/coderd/temporaryroles"
 	"github.com/coder/coder/v2/coderd/tracing"
 	"github.com/coder/coder/v2/coderd/unhanger"
 	"github.com/coder/coder/v2/coderd/updatecheck"
@@ -199,6 +201,74 @@ func createOIDCConfig(ctx context.Context, logger slog.Logger, vals *codersdk.De
 	}, nil
 }
 
+func createLDAPConfig(vals *codersdk.DeploymentValues) (*coderd.LDAPConfig, error) {
+	ldapURL, err := url.Parse(vals.LDAP.URL.String())
+	if err != nil {
+		return nil, xerrors.Errorf("parse ldap url: %w", err)
+	}
+	if ldapURL.Scheme != "ldap" && ldapURL.Scheme != "ldaps" {
+		return nil, xerrors.Errorf("ldap url scheme must be 'ldap' or 'ldaps', got %q", ldapURL.Scheme)
+	}
+	if vals.LDAP.UserSearchBaseDN == "" {
+		return nil, xerrors.Errorf("'ldap-user-search-base-dn' must be set if 'ldap-url' is set")
+	}
+	if !strings.Contains(vals.LDAP.UserSearchFilter.String(), "{username}") {
+		return nil, xerrors.Errorf("'ldap-user-search-filter' must contain '{username}'")
+	}
+	if (len(vals.LDAP.GroupAllowList) > 0 || len(vals.LDAP.UserRoleMapping.Value) > 0) && vals.LDAP.GroupAttribute == "" {
+		return nil, xerrors.Errorf("'ldap-group-attribute' must be set if 'ldap-allowed-groups' or 'ldap-user-role-mapping' is set")
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName: ldapURL.Hostname(),
+		MinVersion: tls.VersionTLS12,
+	}
+	if vals.LDAP.CAFile != "" {
+		data, err := os.ReadFile(vals.LDAP.CAFile.String())
+		if err != nil {
+			return nil, xerrors.Errorf("read ldap ca file: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(data) {
+			return nil, xerrors.Errorf("no certificates found in ldap ca file %q", vals.LDAP.CAFile.String())
+		}
+	}
+
+	cfg := &coderd.LDAPConfig{
+		URL:                 ldapURL.String(),
+		StartTLS:            vals.LDAP.StartTLS.Value(),
+		TLSConfig:           tlsConfig,
+		BindDN:              vals.LDAP.BindDN.String(),
+		BindPassword:        vals.LDAP.BindPassword.String(),
+		UserSearchBaseDN:    vals.LDAP.UserSearchBaseDN.String(),
+		UserSearchFilter:    vals.LDAP.UserSearchFilter.String(),
+		UsernameAttribute:   vals.LDAP.UsernameAttribute.String(),
+		EmailAttribute:      vals.LDAP.EmailAttribute.String(),
+		NameAttribute:       vals.LDAP.NameAttribute.String(),
+		GroupAttribute:      vals.LDAP.GroupAttribute.String(),
+		GroupNameAttribute:  vals.LDAP.GroupNameAttribute.String(),
+		CreateMissingGroups: vals.LDAP.GroupAutoCreate.Value(),
+		GroupFilter:         vals.LDAP.GroupRegexFilter.Value(),
+		GroupAllowList:      make(map[string]bool),
+		GroupMapping:        make(map[string]string),
+		UserRoleMapping:     make(map[string][]string),
+		UserRolesDefault:    vals.LDAP.UserRolesDefault.GetSlice(),
+		AllowSignups:        vals.LDAP.AllowSignups.Value(),
+		SignInText:          vals.LDAP.SignInText.String(),
+	}
+	// Groups of users are normalized, so the configured groups must be too.
+	for _, group := range vals.LDAP.GroupAllowList.Value() {
+		cfg.GroupAllowList[cfg.GroupName(group)] = true
+	}
+	for group, coderGroup := range vals.LDAP.GroupMapping.Value {
+		cfg.GroupMapping[cfg.GroupName(group)] = coderGroup
+	}
+	for group, roles := range vals.LDAP.UserRoleMapping.Value {
+		cfg.UserRoleMapping[cfg.GroupName(group)] = roles
+	}
+	return cfg, nil
+}
+
 func afterCtx(ctx context.Context, fn func()) {
 	go func() {
 		<-ctx.Done()
@@ -673,6 +743,14 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 				options.OIDCConfig = oc
 			}
 
+			if vals.LDAP.URL != "" {
+				lc, err := createLDAPConfig(vals)
+				if err != nil {
+					return xerrors.Errorf("create ldap config: %w", err)
+				}
+				options.LDAPConfig = lc
+			}
+
 			experiments := coderd.ReadExperiments(
 				options.Logger, options.DeploymentValues.Experiments.Value(),
 			)
@@ -976,9 +1054,13 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 			defer shutdownConns()
 
 			// Ensures that old database entries are cleaned up over time!
-			purger := dbpurge.New(ctx, logger.Named("dbpurge"), options.Database)
+			purger := dbpurge.New(ctx, logger.Named("dbpurge"), options.Database, vals.AuditLogging.Retention.Value())
 			defer purger.Close()
 
+			// Removes roles that were granted for a limited time once they expire.
+			roleExpirer := temporaryroles.New(ctx, logger.Named("temporaryroles"), options.Database, &coderAPI.Auditor)
+			defer roleExpirer.Close()
+
 			// Updates workspace usage
 			tracker := workspacestats.NewTracker(options.Database,
 				workspacestats.TrackerWithLogger(logger.Named("workspace_usage_tracker")),
@@ -993,9 +1075,11 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 			if experiments.Enabled(codersdk.ExperimentNotifications) {
 				cfg := options.DeploymentValues.Notifications
 				metrics := notifications.NewMetrics(options.PrometheusRegistry)
+				helpers := templateHelpers(options)
+				options.NotificationsTemplateHelpers = helpers
 
 				// The enqueuer is responsible for enqueueing notifications to the given store.
-				enqueuer, err := notifications.NewStoreEnqueuer(cfg, options.Database, templateHelpers(options), logger.Named("notifications.enqueuer"))
+				enqueuer, err := notifications.NewStoreEnqueuer(cfg, options.Database, helpers, logger.Named("notifications.enqueuer"))
 				if err != nil {
 					return xerrors.Errorf("failed to instantiate notification store enqueuer: %w", err)
 				}
@@ -1004,7 +1088,7 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 				// The notification manager is responsible for:
 				//   - creating notifiers and managing their lifecycles (notifiers are responsible for dequeueing/sending notifications)
 				//   - keeping the store updated with status updates
-				notificationsManager, err = notifications.NewManager(cfg, options.Database, metrics, logger.Named("notifications.manager"))
+				notificationsManager, err = notifications.NewManager(cfg, options.Database, helpers, options.Pubsub, metrics, logger.Named("notifications.manager"))
 				if err != nil {
 					return xerrors.Errorf("failed to instantiate notification manager: %w", err)
 				}
@@ -1084,6 +1168,12 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 			hangDetector.Start()
 			defer hangDetector.Close()
 
+			prebuildsTicker := time.NewTicker(prebuilds.ReconcileInterval)
+			defer prebuildsTicker.Stop()
+			prebuildsReconciler := prebuilds.New(ctx, options.Database, options.Pubsub, logger.Named("prebuilds"), prebuildsTicker.C)
+			prebuildsReconciler.Start()
+			defer prebuildsReconciler.Close()
+
 			waitForProvisionerJobs := false
 			// Currently there is no way to ask the server to shut
 			// itself down, so any exit signal will result in a non-zero
@@ -201,6 +201,15 @@ func (r *RootCmd) newCreateAdminUserCommand() *serpent.Command {
 				if err != nil {
 					return xerrors.Errorf("insert user: %w", err)
 				}
+				err = tx.InsertUserPasswordHistory(ctx, database.InsertUserPasswordHistoryParams{
+					ID:             uuid.New(),
+					UserID:         newUser.ID,
+					HashedPassword: []byte(hashedPassword),
+					CreatedAt:      dbtime.Now(),
+				})
+				if err != nil {
+					return xerrors.Errorf("insert password history: %w", err)
+				}
 
 				_, _ = fmt.Fprintln(inv.Stderr, "Generating user SSH key...")
 				privateKey, publicKey, err := gitsshkey.Generate(sshKeygenAlgorithm)
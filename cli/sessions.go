@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) sessions() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "sessions",
+		Short: "Manage login sessions",
+		Long: "Sessions are created by logging in to the dashboard or the CLI. Revoking a session logs out the browser or CLI that uses it.\n" + FormatExamples(
+			Example{
+				Description: "List your sessions",
+				Command:     "coder sessions ls",
+			},
+			Example{
+				Description: "Log out a session by ID",
+				Command:     "coder sessions revoke WuoWs4ZsMX",
+			},
+			Example{
+				Description: "Sign out a user everywhere (requires the Owner role)",
+				Command:     "coder sessions revoke --all --user alice",
+			},
+		),
+		Aliases: []string{"session"},
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.listSessions(),
+			r.revokeSessions(),
+		},
+	}
+	return cmd
+}
+
+// sessionListRow is the type provided to the OutputFormatter.
+type sessionListRow struct {
+	// For JSON format:
+	codersdk.UserSession `table:"-"`
+
+	// For table format:
+	ID        string    `json:"-" table:"id"`
+	LoginType string    `json:"-" table:"login type"`
+	IPAddress string    `json:"-" table:"ip address"`
+	UserAgent string    `json:"-" table:"user agent"`
+	LastUsed  time.Time `json:"-" table:"last used,default_sort"`
+	CreatedAt time.Time `json:"-" table:"created at"`
+	ExpiresAt time.Time `json:"-" table:"expires at"`
+	Current   bool      `json:"-" table:"current"`
+}
+
+func sessionListRowFromSession(session codersdk.UserSession) sessionListRow {
+	return sessionListRow{
+		UserSession: session,
+		ID:          session.ID,
+		LoginType:   string(session.LoginType),
+		IPAddress:   session.IPAddress,
+		UserAgent:   session.UserAgent,
+		LastUsed:    session.LastUsed,
+		CreatedAt:   session.CreatedAt,
+		ExpiresAt:   session.ExpiresAt,
+		Current:     session.Current,
+	}
+}
+
+func (r *RootCmd) listSessions() *serpent.Command {
+	var (
+		user      string
+		formatter = cliui.NewOutputFormatter(
+			cliui.TableFormat([]sessionListRow{}, []string{"id", "login type", "ip address", "user agent", "last used", "created at", "current"}),
+			cliui.JSONFormat(),
+		)
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List sessions",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			sessions, err := client.UserSessions(inv.Context(), user)
+			if err != nil {
+				return xerrors.Errorf("list sessions: %w", err)
+			}
+
+			if len(sessions) == 0 {
+				cliui.Infof(
+					inv.Stdout,
+					"No sessions found.\n",
+				)
+			}
+
+			rows := make([]sessionListRow, len(sessions))
+			for i, session := range sessions {
+				rows[i] = sessionListRowFromSession(session)
+			}
+
+			out, err := formatter.Format(inv.Context(), rows)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		sessionUserOption(&user),
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) revokeSessions() *serpent.Command {
+	var (
+		user string
+		all  bool
+	)
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "revoke [id]",
+		Aliases: []string{"rm"},
+		Short:   "Log out a session, or every session of a user with --all",
+		Middleware: serpent.Chain(
+			serpent.RequireRangeArgs(0, 1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			switch {
+			case all && len(inv.Args) > 0:
+				return xerrors.New("cannot specify a session ID with --all")
+			case all:
+				_, err := cliui.Prompt(inv, cliui.PromptOptions{
+					Text:      fmt.Sprintf("Log out every session of %s? Tokens will keep working.", cliui.Keyword(user)),
+					IsConfirm: true,
+					Default:   cliui.ConfirmNo,
+				})
+				if err != nil {
+					return err
+				}
+				err = client.RevokeUserSessions(inv.Context(), user)
+				if err != nil {
+					return xerrors.Errorf("revoke sessions: %w", err)
+				}
+				cliui.Infof(inv.Stdout, "All sessions have been revoked.")
+			case len(inv.Args) == 1:
+				err := client.RevokeUserSession(inv.Context(), user, inv.Args[0])
+				if err != nil {
+					return xerrors.Errorf("revoke session %s: %w", inv.Args[0], err)
+				}
+				cliui.Infof(inv.Stdout, "Session has been revoked.")
+			default:
+				return xerrors.New("specify a session ID or --all")
+			}
+			return nil
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		sessionUserOption(&user),
+		{
+			Flag:        "all",
+			Description: "Revoke every session of the user, signing them out everywhere.",
+			Value:       serpent.BoolOf(&all),
+		},
+		cliui.SkipPromptOption(),
+	}
+
+	return cmd
+}
+
+func sessionUserOption(user *string) serpent.Option {
+	return serpent.Option{
+		Flag:        "user",
+		Description: "The user whose sessions to manage. Managing the sessions of other users requires the Owner role.",
+		Default:     codersdk.Me,
+		Value:       serpent.StringOf(user),
+	}
+}
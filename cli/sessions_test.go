@@ -0,0 +1,87 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSessions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ListAndRevoke", func(t *testing.T) {
+		t.Parallel()
+		owner := coderdtest.New(t, nil)
+		first := coderdtest.CreateFirstUser(t, owner)
+		client, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		other := codersdk.New(owner.URL)
+		res, err := other.LoginWithPassword(ctx, codersdk.LoginWithPasswordRequest{
+			Email:    user.Email,
+			Password: "SomeSecurePassword!",
+		})
+		require.NoError(t, err)
+		other.SetSessionToken(res.SessionToken)
+		id := res.SessionToken[:10]
+
+		inv, root := clitest.New(t, "sessions", "ls")
+		clitest.SetupConfig(t, client, root)
+		buf := new(bytes.Buffer)
+		inv.Stdout = buf
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "IP ADDRESS")
+		require.Contains(t, buf.String(), "USER AGENT")
+		require.Contains(t, buf.String(), id)
+
+		inv, root = clitest.New(t, "sessions", "ls", "--output=json")
+		clitest.SetupConfig(t, client, root)
+		buf = new(bytes.Buffer)
+		inv.Stdout = buf
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		var sessions []codersdk.UserSession
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &sessions))
+		require.Len(t, sessions, 2)
+
+		inv, root = clitest.New(t, "sessions", "revoke", id)
+		clitest.SetupConfig(t, client, root)
+		buf = new(bytes.Buffer)
+		inv.Stdout = buf
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "revoked")
+
+		var apiErr *codersdk.Error
+		_, err = other.User(ctx, codersdk.Me)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode())
+	})
+
+	t.Run("RevokeAll", func(t *testing.T) {
+		t.Parallel()
+		owner := coderdtest.New(t, nil)
+		first := coderdtest.CreateFirstUser(t, owner)
+		client, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "sessions", "revoke", "--all", "--user", user.Username, "--yes")
+		clitest.SetupConfig(t, owner, root)
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		var apiErr *codersdk.Error
+		_, err = client.User(ctx, codersdk.Me)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode())
+	})
+}
@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"fmt"
+
 	"golang.org/x/xerrors"
 
 	"github.com/coder/coder/v2/cli/cliui"
@@ -26,10 +28,46 @@ func (r *RootCmd) show() *serpent.Command {
 			if err != nil {
 				return xerrors.Errorf("get workspace: %w", err)
 			}
-			return cliui.WorkspaceResources(inv.Stdout, workspace.LatestBuild.Resources, cliui.WorkspaceResourcesOptions{
+			err = cliui.WorkspaceResources(inv.Stdout, workspace.LatestBuild.Resources, cliui.WorkspaceResourcesOptions{
 				WorkspaceName: workspace.Name,
 				ServerVersion: buildInfo.Version,
 			})
+			if err != nil {
+				return err
+			}
+
+			acl, err := client.WorkspaceACL(inv.Context(), workspace.ID)
+			if err != nil {
+				return xerrors.Errorf("get workspace acl: %w", err)
+			}
+			return displayWorkspaceACL(inv, acl)
 		},
 	}
 }
+
+type workspaceACLRow struct {
+	Type string                 `table:"type"`
+	Name string                 `table:"name,default_sort"`
+	Role codersdk.WorkspaceRole `table:"role"`
+}
+
+func displayWorkspaceACL(inv *serpent.Invocation, acl codersdk.WorkspaceACL) error {
+	if len(acl.Users) == 0 && len(acl.Groups) == 0 {
+		return nil
+	}
+
+	rows := make([]workspaceACLRow, 0, len(acl.Users)+len(acl.Groups))
+	for _, user := range acl.Users {
+		rows = append(rows, workspaceACLRow{Type: "user", Name: user.Username, Role: user.Role})
+	}
+	for _, group := range acl.Groups {
+		rows = append(rows, workspaceACLRow{Type: "group", Name: group.Name, Role: group.Role})
+	}
+	out, err := cliui.DisplayTable(rows, "", nil)
+	if err != nil {
+		return xerrors.Errorf("render workspace acl: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(inv.Stdout, "\n%s\n%s\n", cliui.Bold("Shared with"), out)
+	return nil
+}
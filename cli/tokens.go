@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"golang.org/x/exp/slices"
@@ -22,6 +23,10 @@ func (r *RootCmd) tokens() *serpent.Command {
 				Description: "Create a token for automation",
 				Command:     "coder tokens create",
 			},
+			Example{
+				Description: "Create a token that can only read and SSH into workspaces",
+				Command:     "coder tokens create --scope workspace:read --scope workspace:ssh",
+			},
 			Example{
 				Description: "List your tokens",
 				Command:     "coder tokens ls",
@@ -48,6 +53,7 @@ func (r *RootCmd) createToken() *serpent.Command {
 	var (
 		tokenLifetime time.Duration
 		name          string
+		scopes        []string
 	)
 	client := new(codersdk.Client)
 	cmd := &serpent.Command{
@@ -61,6 +67,7 @@ func (r *RootCmd) createToken() *serpent.Command {
 			res, err := client.CreateToken(inv.Context(), codersdk.Me, codersdk.CreateTokenRequest{
 				Lifetime:  tokenLifetime,
 				TokenName: name,
+				Scopes:    scopes,
 			})
 			if err != nil {
 				return xerrors.Errorf("create tokens: %w", err)
@@ -87,6 +94,12 @@ func (r *RootCmd) createToken() *serpent.Command {
 			Description:   "Specify a human-readable name.",
 			Value:         serpent.StringOf(&name),
 		},
+		{
+			Flag:        "scope",
+			Env:         "CODER_TOKEN_SCOPE",
+			Description: "Limit the token to a permission in the format resource:action, e.g. workspace:read. Can be repeated. By default the token has all permissions of your user.",
+			Value:       serpent.StringArrayOf(&scopes),
+		},
 	}
 
 	return cmd
@@ -104,6 +117,7 @@ type tokenListRow struct {
 	ExpiresAt time.Time `json:"-" table:"expires at"`
 	CreatedAt time.Time `json:"-" table:"created at"`
 	Owner     string    `json:"-" table:"owner"`
+	Scopes    string    `json:"-" table:"scopes"`
 }
 
 func tokenListRowFromToken(token codersdk.APIKeyWithOwner) tokenListRow {
@@ -115,6 +129,7 @@ func tokenListRowFromToken(token codersdk.APIKeyWithOwner) tokenListRow {
 		ExpiresAt: token.ExpiresAt,
 		CreatedAt: token.CreatedAt,
 		Owner:     token.Username,
+		Scopes:    strings.Join(token.Scopes, ","),
 	}
 }
 
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/serpent"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+func (r *RootCmd) transfer() *serpent.Command {
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Annotations: workspaceCommand,
+		Use:         "transfer <workspace> <new owner>",
+		Short:       "Transfer a workspace to another user",
+		Long: "The workspace is rebuilt for the new owner and keeps its persistent volumes. " +
+			"The previous owner loses access to the workspace.\n" + FormatExamples(
+			Example{
+				Description: "Transfer the workspace of a user who changed teams",
+				Command:     "coder transfer alice/dev bob",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Options: serpent.OptionSet{
+			cliui.SkipPromptOption(),
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			workspace, err := namedWorkspace(inv.Context(), client, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("get workspace: %w", err)
+			}
+			newOwner, err := client.User(inv.Context(), inv.Args[1])
+			if err != nil {
+				return xerrors.Errorf("get user %q: %w", inv.Args[1], err)
+			}
+
+			_, err = cliui.Prompt(inv, cliui.PromptOptions{
+				Text:      fmt.Sprintf("Transfer %s from %s to %s?", cliui.Keyword(workspace.Name), cliui.Keyword(workspace.OwnerName), cliui.Keyword(newOwner.Username)),
+				IsConfirm: true,
+			})
+			if err != nil {
+				return err
+			}
+
+			build, err := client.TransferWorkspace(inv.Context(), workspace.ID, codersdk.TransferWorkspaceRequest{
+				OwnerID: newOwner.ID,
+			})
+			if err != nil {
+				return xerrors.Errorf("transfer workspace: %w", err)
+			}
+
+			err = cliui.WorkspaceBuild(inv.Context(), inv.Stdout, client, build.ID)
+			if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "\nThe %s workspace has been transferred to %s!\n", cliui.Keyword(workspace.Name), cliui.Keyword(newOwner.Username))
+			return nil
+		},
+	}
+	return cmd
+}
@@ -18,13 +18,14 @@ import (
 
 func (r *RootCmd) userCreate() *serpent.Command {
 	var (
-		email        string
-		username     string
-		name         string
-		password     string
-		disableLogin bool
-		loginType    string
-		orgContext   = NewOrganizationContext()
+		email          string
+		username       string
+		name           string
+		password       string
+		disableLogin   bool
+		loginType      string
+		serviceAccount bool
+		orgContext     = NewOrganizationContext()
 	)
 	client := new(codersdk.Client)
 	cmd := &serpent.Command{
@@ -38,6 +39,9 @@ func (r *RootCmd) userCreate() *serpent.Command {
 			if err != nil {
 				return err
 			}
+			if serviceAccount {
+				return createServiceAccount(inv, client, organization, username, name, email != "" || password != "" || disableLogin || loginType != "")
+			}
 			// We only prompt for the full name if both username and email have not
 			// been set. This is to avoid breaking existing non-interactive usage.
 			shouldPromptName := username == "" && email == ""
@@ -116,6 +120,8 @@ func (r *RootCmd) userCreate() *serpent.Command {
 				authenticationMethod = `Login is authenticated through GitHub.`
 			case codersdk.LoginTypeOIDC:
 				authenticationMethod = `Login is authenticated through the configured OIDC provider.`
+			case codersdk.LoginTypeLDAP:
+				authenticationMethod = `Login is authenticated through the configured LDAP directory.`
 			}
 
 			_, _ = fmt.Fprintln(inv.Stderr, `A new user has been created!
@@ -165,12 +171,18 @@ Create a workspace  `+pretty.Sprint(cliui.DefaultStyles.Code, "coder create")+`!
 				"Be careful when using this flag as it can lock the user out of their account.",
 			Value: serpent.BoolOf(&disableLogin),
 		},
+		{
+			Flag: "service-account",
+			Description: "Create a service account owned by the organization. Service accounts cannot log in " +
+				"and can only authenticate with scoped tokens.",
+			Value: serpent.BoolOf(&serviceAccount),
+		},
 		{
 			Flag: "login-type",
 			Description: fmt.Sprintf("Optionally specify the login type for the user. Valid values are: %s. "+
 				"Using 'none' prevents the user from authenticating and requires an API key/token to be generated by an admin.",
 				strings.Join([]string{
-					string(codersdk.LoginTypePassword), string(codersdk.LoginTypeNone), string(codersdk.LoginTypeGithub), string(codersdk.LoginTypeOIDC),
+					string(codersdk.LoginTypePassword), string(codersdk.LoginTypeNone), string(codersdk.LoginTypeGithub), string(codersdk.LoginTypeOIDC), string(codersdk.LoginTypeLDAP),
 				}, ", ",
 				)),
 			Value: serpent.StringOf(&loginType),
@@ -180,3 +192,32 @@ Create a workspace  `+pretty.Sprint(cliui.DefaultStyles.Code, "coder create")+`!
 	orgContext.AttachOptions(cmd)
 	return cmd
 }
+
+func createServiceAccount(inv *serpent.Invocation, client *codersdk.Client, organization codersdk.Organization, username, name string, loginFlags bool) error {
+	if loginFlags {
+		return xerrors.New("--service-account cannot be combined with --email, --password, --login-type or --disable-login")
+	}
+	var err error
+	if username == "" {
+		username, err = cliui.Prompt(inv, cliui.PromptOptions{
+			Text: "Username:",
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	account, err := client.CreateServiceAccount(inv.Context(), organization.ID, codersdk.CreateServiceAccountRequest{
+		Username: username,
+		Name:     name,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(inv.Stderr, `A new service account has been created in `+pretty.Sprint(cliui.DefaultStyles.Field, organization.Name)+`!
+Service accounts cannot log in. Create a scoped token for it with:
+
+`+pretty.Sprint(cliui.DefaultStyles.Code, fmt.Sprintf("POST /api/v2/organizations/%s/service-accounts/%s/tokens", organization.ID, account.Username)))
+	return nil
+}
@@ -124,4 +124,24 @@ func TestUserCreate(t *testing.T) {
 		assert.Equal(t, args[5], created.Username)
 		assert.Empty(t, created.Name)
 	})
+	t.Run("ServiceAccount", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		first := coderdtest.CreateFirstUser(t, client)
+		inv, root := clitest.New(t, "users", "create", "--service-account", "-u", "ci-bot", "-n", "CI Bot")
+		clitest.SetupConfig(t, client, root)
+		err := inv.Run()
+		require.NoError(t, err)
+		ctx := testutil.Context(t, testutil.WaitShort)
+		accounts, err := client.ServiceAccounts(ctx, first.OrganizationID)
+		require.NoError(t, err)
+		require.Len(t, accounts, 1)
+		assert.Equal(t, "ci-bot", accounts[0].Username)
+		assert.Equal(t, "CI Bot", accounts[0].Name)
+
+		inv, root = clitest.New(t, "users", "create", "--service-account", "-u", "other-bot", "-e", "bot@coder.com")
+		clitest.SetupConfig(t, client, root)
+		err = inv.Run()
+		require.ErrorContains(t, err, "cannot be combined")
+	})
 }
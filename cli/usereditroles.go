@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) userEditRoles() *serpent.Command {
+	var roleExpiry temporaryRoleFlags
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "edit-roles <username|user_id> [roles...]",
+		Aliases: []string{"edit-role"},
+		Short:   "Edit a user's site-wide roles.",
+		Long: FormatExamples(
+			Example{
+				Description: "Make a user a template admin",
+				Command:     "coder users edit-roles alice template-admin",
+			},
+			Example{
+				Description: "Grant the template admin role for four hours",
+				Command:     `coder users edit-roles alice template-admin --expires-in 4h --justification "Fixing the docker template"`,
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireRangeArgs(1, -1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			params, err := roleExpiry.updateRoles(inv.Args[1:])
+			if err != nil {
+				return err
+			}
+
+			roles, err := client.UpdateUserRoles(ctx, inv.Args[0], params)
+			if err != nil {
+				return xerrors.Errorf("update user roles: %w", err)
+			}
+
+			updatedTo := make([]string, 0)
+			for _, role := range roles.Roles {
+				updatedTo = append(updatedTo, role.Name)
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "User roles updated to [%s]\n", strings.Join(updatedTo, ", "))
+			if params.ExpiresAt != nil {
+				_, _ = fmt.Fprintf(inv.Stdout, "Added roles expire at %s\n", params.ExpiresAt.Format(time.RFC3339))
+			}
+			return nil
+		},
+	}
+	cmd.Options = roleExpiry.options()
+	return cmd
+}
+
+// temporaryRoleFlags are the flags for granting roles for a limited time.
+type temporaryRoleFlags struct {
+	expiresIn     time.Duration
+	justification string
+}
+
+func (f *temporaryRoleFlags) options() serpent.OptionSet {
+	return serpent.OptionSet{
+		{
+			Flag:        "expires-in",
+			Description: "Add the roles to the current roles, and remove them again after this duration (e.g. 4h). By default, the roles replace the current roles permanently.",
+			Value:       serpent.DurationOf(&f.expiresIn),
+		},
+		{
+			Flag:        "justification",
+			Description: "Explain why the roles are needed. Recorded in the audit log, requires --expires-in.",
+			Value:       serpent.StringOf(&f.justification),
+		},
+	}
+}
+
+func (f *temporaryRoleFlags) updateRoles(roles []string) (codersdk.UpdateRoles, error) {
+	params := codersdk.UpdateRoles{
+		Roles: roles,
+	}
+	if f.expiresIn < 0 {
+		return params, xerrors.New("--expires-in must be positive")
+	}
+	if f.expiresIn == 0 {
+		if f.justification != "" {
+			return params, xerrors.New("--justification requires --expires-in")
+		}
+		return params, nil
+	}
+	expiresAt := time.Now().Add(f.expiresIn)
+	params.ExpiresAt = &expiresAt
+	params.Justification = f.justification
+	return params, nil
+}
@@ -0,0 +1,83 @@
+package cli_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestUserEditRoles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Permanent", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		_, user := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		inv, root := clitest.New(t, "users", "edit-roles", user.Username, codersdk.RoleTemplateAdmin)
+		clitest.SetupConfig(t, client, root)
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		roles, err := client.UserRoles(ctx, user.Username)
+		require.NoError(t, err)
+		require.Equal(t, []string{codersdk.RoleTemplateAdmin}, roles.Roles)
+		require.Empty(t, roles.TemporaryRoles)
+	})
+
+	t.Run("ExpiresIn", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		_, user := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID, rbac.RoleAuditor())
+
+		inv, root := clitest.New(t, "users", "edit-roles", user.Username, codersdk.RoleTemplateAdmin,
+			"--expires-in", "4h", "--justification", "Fixing the docker template")
+		clitest.SetupConfig(t, client, root)
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		// The role is added to the current roles.
+		roles, err := client.UserRoles(ctx, user.Username)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{codersdk.RoleAuditor, codersdk.RoleTemplateAdmin}, roles.Roles)
+		require.Len(t, roles.TemporaryRoles, 1)
+		require.Equal(t, codersdk.RoleTemplateAdmin, roles.TemporaryRoles[0].RoleName)
+		require.Equal(t, "Fixing the docker template", roles.TemporaryRoles[0].Justification)
+		require.WithinDuration(t, time.Now().Add(4*time.Hour), roles.TemporaryRoles[0].ExpiresAt, time.Minute)
+
+		// Editing the roles without an expiry makes the role permanent.
+		inv, root = clitest.New(t, "users", "edit-roles", user.Username, codersdk.RoleTemplateAdmin)
+		clitest.SetupConfig(t, client, root)
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		roles, err = client.UserRoles(ctx, user.Username)
+		require.NoError(t, err)
+		require.Equal(t, []string{codersdk.RoleTemplateAdmin}, roles.Roles)
+		require.Empty(t, roles.TemporaryRoles)
+	})
+
+	t.Run("JustificationWithoutExpiry", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		_, user := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		inv, root := clitest.New(t, "users", "edit-roles", user.Username, codersdk.RoleTemplateAdmin,
+			"--justification", "Because")
+		clitest.SetupConfig(t, client, root)
+		err := inv.Run()
+		require.ErrorContains(t, err, "--justification requires --expires-in")
+	})
+}
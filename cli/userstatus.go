@@ -69,8 +69,10 @@ func (r *RootCmd) createUserStatusCommand(sdkStatus codersdk.UserStatus) *serpen
 			}
 			_, _ = fmt.Fprintln(inv.Stdout, table)
 
-			// User status is already set to this
-			if user.Status == sdkStatus {
+			// User status is already set to this. Active users are still
+			// activated, as that unlocks users who are locked out after too
+			// many failed logins.
+			if user.Status == sdkStatus && sdkStatus != codersdk.UserStatusActive {
 				_, _ = fmt.Fprintf(inv.Stdout, "User status is already %q\n", sdkStatus)
 				return nil
 			}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -11,6 +12,8 @@ import (
 	"github.com/coder/coder/v2/coderd/coderdtest"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+	"github.com/coder/serpent"
 )
 
 func TestUserStatus(t *testing.T) {
@@ -67,4 +70,36 @@ func TestUserStatus(t *testing.T) {
 		require.NoError(t, err, "fetch active user")
 		require.Equal(t, codersdk.UserStatusActive, otherUser.Status, "active user")
 	})
+
+	t.Run("UnlockLocked", func(t *testing.T) {
+		t.Parallel()
+
+		dv := coderdtest.DeploymentValues(t)
+		dv.PasswordPolicy.LockoutThreshold = 1
+		dv.PasswordPolicy.LockoutDuration = serpent.Duration(time.Hour)
+		client := coderdtest.New(t, &coderdtest.Options{DeploymentValues: dv})
+		owner := coderdtest.CreateFirstUser(t, client)
+		_, otherUser := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		login := func(password string) error {
+			_, err := codersdk.New(client.URL).LoginWithPassword(ctx, codersdk.LoginWithPasswordRequest{
+				Email:    otherUser.Email,
+				Password: password,
+			})
+			return err
+		}
+		require.Error(t, login("WrongPassword!"))
+		// Locked users keep the active status.
+		require.Error(t, login("SomeSecurePassword!"))
+
+		inv, root := clitest.New(t, "users", "activate", otherUser.Username)
+		clitest.SetupConfig(t, client, root)
+		// Yes to the prompt
+		inv.Stdin = bytes.NewReader([]byte("yes\n"))
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err, "activate user")
+
+		require.NoError(t, login("SomeSecurePassword!"))
+	})
 }
@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/pretty"
+	"github.com/coder/serpent"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+func (r *RootCmd) workspaces() *serpent.Command {
+	cmd := &serpent.Command{
+		Annotations: workspaceCommand,
+		Use:         "workspaces",
+		Short:       "Manage many workspaces at once",
+		Aliases:     []string{"workspace"},
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.workspacesBulk(),
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) workspacesBulk() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "bulk",
+		Short: "Apply an action to every workspace matching a search query",
+		Long: "The action runs on the server as a background job. Workspaces that are already in the requested " +
+			"state are skipped.\n" + FormatExamples(
+			Example{
+				Description: "Update all outdated workspaces of a template after a security fix",
+				Command:     `coder workspaces bulk update --search "template:docker outdated:true"`,
+			},
+			Example{
+				Description: "Stop the running workspaces of a user",
+				Command:     `coder workspaces bulk stop --search "owner:alice status:running"`,
+			},
+			Example{
+				Description: "Enable automatic updates for all workspaces of a template",
+				Command:     `coder workspaces bulk autoupdate always --search "template:docker"`,
+			},
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.workspacesBulkAction("start", "Start workspaces", codersdk.WorkspaceBulkActionStart),
+			r.workspacesBulkAction("stop", "Stop workspaces", codersdk.WorkspaceBulkActionStop),
+			r.workspacesBulkAction("update", "Update workspaces to the active version of their template", codersdk.WorkspaceBulkActionUpdate),
+			r.workspacesBulkAction("delete", "Delete workspaces", codersdk.WorkspaceBulkActionDelete),
+			r.workspacesBulkAction("autoupdate <always|never>", "Set the auto-update policy of workspaces", codersdk.WorkspaceBulkActionAutomaticUpdates),
+			r.workspacesBulkAction("dormant <true|false>", "Mark workspaces as dormant, or make dormant workspaces active again", codersdk.WorkspaceBulkActionDormant),
+		},
+	}
+	return cmd
+}
+
+type workspaceBulkResultRow struct {
+	codersdk.WorkspaceBulkJobResult `table:"-"`
+
+	Workspace string `json:"-" table:"workspace,default_sort"`
+	Status    string `json:"-" table:"status"`
+	Build     string `json:"-" table:"build"`
+	Error     string `json:"-" table:"error"`
+}
+
+func workspaceBulkResultRowFromResult(result codersdk.WorkspaceBulkJobResult) workspaceBulkResultRow {
+	row := workspaceBulkResultRow{
+		WorkspaceBulkJobResult: result,
+		Workspace:              result.WorkspaceOwnerName + "/" + result.WorkspaceName,
+		Status:                 string(result.Status),
+		Error:                  result.Error,
+	}
+	if result.BuildID != nil {
+		row.Build = result.BuildID.String()
+	}
+	return row
+}
+
+func (r *RootCmd) workspacesBulkAction(use, short string, action codersdk.WorkspaceBulkAction) *serpent.Command {
+	var (
+		search      string
+		concurrency int64
+		formatter   = cliui.NewOutputFormatter(
+			cliui.TableFormat(
+				[]workspaceBulkResultRow{},
+				[]string{"workspace", "status", "build", "error"},
+			),
+			cliui.JSONFormat(),
+		)
+	)
+	nArgs := 0
+	if strings.Contains(use, " ") {
+		nArgs = 1
+	}
+	searchOption := serpent.Option{
+		Flag:        "search",
+		Description: "Search for the workspaces with a query. An empty query matches every workspace you can access.",
+		Default:     "owner:me",
+		Value:       serpent.StringOf(&search),
+	}
+	if action == codersdk.WorkspaceBulkActionDelete {
+		// Deleting cannot be undone, so the workspaces must be chosen
+		// explicitly.
+		searchOption.Description = "Search for the workspaces with a query."
+		searchOption.Default = ""
+		searchOption.Required = true
+	}
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   use,
+		Short: short,
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(nArgs),
+			r.InitClient(client),
+		),
+		Options: serpent.OptionSet{
+			searchOption,
+			{
+				Flag:        "concurrency",
+				Description: "The maximum number of workspaces acted on at the same time.",
+				Default:     "5",
+				Value:       serpent.Int64Of(&concurrency),
+			},
+			cliui.SkipPromptOption(),
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			req := codersdk.CreateWorkspaceBulkJobRequest{
+				Action:      action,
+				Query:       strings.TrimSpace(search),
+				Concurrency: int(concurrency),
+			}
+			if action == codersdk.WorkspaceBulkActionDelete && req.Query == "" {
+				return xerrors.New("--search must not be empty when deleting workspaces")
+			}
+			switch action {
+			case codersdk.WorkspaceBulkActionAutomaticUpdates:
+				policy := strings.ToLower(inv.Args[0])
+				err := validateAutoUpdatePolicy(policy)
+				if err != nil {
+					return xerrors.Errorf("validate policy: %w", err)
+				}
+				req.AutomaticUpdates = codersdk.AutomaticUpdates(policy)
+			case codersdk.WorkspaceBulkActionDormant:
+				dormant, err := strconv.ParseBool(inv.Args[0])
+				if err != nil {
+					return xerrors.Errorf("invalid option %q must be either of \"true\" or \"false\"", inv.Args[0])
+				}
+				req.Dormant = dormant
+			}
+
+			matched, err := client.Workspaces(inv.Context(), codersdk.WorkspaceFilter{FilterQuery: req.Query})
+			if err != nil {
+				return xerrors.Errorf("query workspaces: %w", err)
+			}
+			if matched.Count == 0 {
+				return xerrors.Errorf("no workspaces match %q", req.Query)
+			}
+			_, err = cliui.Prompt(inv, cliui.PromptOptions{
+				Text:      fmt.Sprintf("Run %s on %d workspaces matching %s?", cliui.Keyword(string(action)), matched.Count, cliui.Keyword(req.Query)),
+				IsConfirm: true,
+				Default:   cliui.ConfirmNo,
+			})
+			if err != nil {
+				return err
+			}
+
+			job, err := client.CreateWorkspaceBulkJob(inv.Context(), req)
+			if err != nil {
+				return xerrors.Errorf("create workspace bulk job: %w", err)
+			}
+
+			ticker := time.NewTicker(time.Second)
+			defer ticker.Stop()
+			done := -1
+			for job.Status != codersdk.WorkspaceBulkJobStatusCompleted {
+				if finished := job.Succeeded + job.Failed + job.Skipped; finished != done {
+					done = finished
+					_, _ = fmt.Fprintf(inv.Stderr, "%d/%d workspaces done (%d failed, %d skipped)\n", done, job.Total, job.Failed, job.Skipped)
+				}
+				select {
+				case <-inv.Context().Done():
+					return inv.Context().Err()
+				case <-ticker.C:
+				}
+				job, err = client.WorkspaceBulkJob(inv.Context(), job.ID)
+				if err != nil {
+					return xerrors.Errorf("get workspace bulk job: %w", err)
+				}
+			}
+
+			rows := make([]workspaceBulkResultRow, 0, len(job.Results))
+			for _, result := range job.Results {
+				rows = append(rows, workspaceBulkResultRowFromResult(result))
+			}
+			out, err := formatter.Format(inv.Context(), rows)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(inv.Stdout, out)
+
+			if job.Failed > 0 {
+				return xerrors.Errorf("%d of %d workspaces failed", job.Failed, job.Total)
+			}
+			_, _ = fmt.Fprintln(inv.Stderr, pretty.Sprint(cliui.DefaultStyles.Keyword, fmt.Sprintf("All %d workspaces done!", job.Total)))
+			return nil
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
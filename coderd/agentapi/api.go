@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"net"
+	"net/netip"
 	"net/url"
 	"sync"
 	"sync/atomic"
@@ -18,6 +19,7 @@ import (
 	"cdr.dev/slog"
 	agentproto "github.com/coder/coder/v2/agent/proto"
 	"github.com/coder/coder/v2/coderd/appearance"
+	"github.com/coder/coder/v2/coderd/audit"
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/database/pubsub"
 	"github.com/coder/coder/v2/coderd/externalauth"
@@ -42,6 +44,7 @@ type API struct {
 	*AppsAPI
 	*MetadataAPI
 	*LogsAPI
+	*AuditAPI
 	*tailnet.DRPCService
 
 	mu                sync.Mutex
@@ -61,6 +64,7 @@ type Options struct {
 	TailnetCoordinator                *atomic.Pointer[tailnet.Coordinator]
 	StatsReporter                     *workspacestats.Reporter
 	AppearanceFetcher                 *atomic.Pointer[appearance.Fetcher]
+	Auditor                           *atomic.Pointer[audit.Auditor]
 	PublishWorkspaceUpdateFn          func(ctx context.Context, workspaceID uuid.UUID)
 	PublishWorkspaceAgentLogsUpdateFn func(ctx context.Context, workspaceAgentID uuid.UUID, msg agentsdk.LogsNotifyMessage)
 	NetworkTelemetryHandler           func(batch []*tailnetproto.TelemetryEvent)
@@ -79,6 +83,9 @@ type Options struct {
 	// the cache in advance.
 	WorkspaceID          uuid.UUID
 	UpdateAgentMetricsFn func(ctx context.Context, labels prometheusmetrics.AgentMetricLabels, metrics []*agentproto.Stats_Metric)
+	// PeerUserFn returns the user of the tailnet client with the given address
+	// that connects to the agent, to attribute connection audit logs.
+	PeerUserFn func(agentID uuid.UUID, addr netip.Addr) (uuid.UUID, bool)
 }
 
 func New(opts Options) *API {
@@ -152,6 +159,14 @@ func New(opts Options) *API {
 		PublishWorkspaceAgentLogsUpdateFn: opts.PublishWorkspaceAgentLogsUpdateFn,
 	}
 
+	api.AuditAPI = &AuditAPI{
+		AgentFn:    api.agent,
+		Auditor:    opts.Auditor,
+		Database:   opts.Database,
+		Log:        opts.Log,
+		PeerUserFn: opts.PeerUserFn,
+	}
+
 	api.DRPCService = &tailnet.DRPCService{
 		CoordPtr:                opts.TailnetCoordinator,
 		Logger:                  opts.Log,
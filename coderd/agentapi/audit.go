@@ -0,0 +1,133 @@
+package agentapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	agentproto "github.com/coder/coder/v2/agent/proto"
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+)
+
+type AuditAPI struct {
+	AgentFn  func(context.Context) (database.WorkspaceAgent, error)
+	Auditor  *atomic.Pointer[audit.Auditor]
+	Database database.Store
+	Log      slog.Logger
+	// PeerUserFn returns the user of the tailnet client with the given
+	// address, if it is known.
+	PeerUserFn func(agentID uuid.UUID, addr netip.Addr) (uuid.UUID, bool)
+}
+
+// connectionAdditionalFields is stored in the additional fields of
+// connection audit logs.
+type connectionAdditionalFields struct {
+	audit.AdditionalFields
+	AgentName      string `json:"agent_name"`
+	ConnectionType string `json:"connection_type"`
+	// ExitCode, Reason and Duration are only set for disconnects.
+	ExitCode *int32 `json:"exit_code,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+func (a *AuditAPI) ReportConnection(ctx context.Context, req *agentproto.ReportConnectionRequest) (*agentproto.ReportConnectionResponse, error) {
+	conn := req.GetConnection()
+	if conn == nil {
+		return nil, xerrors.New("connection is required")
+	}
+	connectionID, err := uuid.FromBytes(conn.GetId())
+	if err != nil {
+		return nil, xerrors.Errorf("connection id from bytes: %w", err)
+	}
+
+	var action database.AuditAction
+	switch conn.GetAction() {
+	case agentproto.Connection_CONNECT:
+		action = database.AuditActionConnect
+	case agentproto.Connection_DISCONNECT:
+		action = database.AuditActionDisconnect
+	default:
+		return nil, xerrors.Errorf("unknown connection action %q", conn.GetAction())
+	}
+	if conn.GetType() == agentproto.Connection_TYPE_UNSPECIFIED {
+		return nil, xerrors.New("connection type is required")
+	}
+
+	workspaceAgent, err := a.AgentFn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row, err := a.Database.GetWorkspaceByAgentID(ctx, workspaceAgent.ID)
+	if err != nil {
+		return nil, xerrors.Errorf("get workspace by agent id %q: %w", workspaceAgent.ID, err)
+	}
+	workspace := row.Workspace
+	owner, err := a.Database.GetUserByID(ctx, workspace.OwnerID)
+	if err != nil {
+		return nil, xerrors.Errorf("get workspace owner %q: %w", workspace.OwnerID, err)
+	}
+
+	fields := connectionAdditionalFields{
+		AdditionalFields: audit.AdditionalFields{
+			WorkspaceName:  workspace.Name,
+			WorkspaceOwner: owner.Username,
+			WorkspaceID:    workspace.ID,
+		},
+		AgentName:      workspaceAgent.Name,
+		ConnectionType: strings.ToLower(conn.GetType().String()),
+	}
+	if action == database.AuditActionDisconnect {
+		exitCode := conn.GetStatusCode()
+		fields.ExitCode = &exitCode
+		fields.Reason = conn.GetReason()
+		if conn.GetDuration() != nil {
+			fields.Duration = conn.GetDuration().AsDuration().String()
+		}
+	}
+	var at time.Time
+	if conn.GetTimestamp() != nil {
+		at = conn.GetTimestamp().AsTime()
+	}
+	additionalFields, err := json.Marshal(fields)
+	if err != nil {
+		return nil, xerrors.Errorf("marshal additional fields: %w", err)
+	}
+	// The agent only knows the address of the peer. Connections that are not
+	// coordinated by a user through this replica, e.g. web terminals proxied
+	// by coderd, are not attributed to a user, the IP identifies the peer.
+	userID := uuid.Nil
+	if addr, err := netip.ParseAddr(conn.GetIp()); err == nil && a.PeerUserFn != nil {
+		if peerUserID, ok := a.PeerUserFn(workspaceAgent.ID, addr); ok {
+			userID = peerUserID
+		}
+	}
+
+	audit.BackgroundAudit(ctx, &audit.BackgroundAuditParams[database.Workspace]{
+		Audit:     *a.Auditor.Load(),
+		Log:       a.Log,
+		UserID:    userID,
+		RequestID: connectionID,
+		// The connection itself succeeded, how it ended is recorded in
+		// the additional fields.
+		Status:           http.StatusOK,
+		Action:           action,
+		OrganizationID:   workspace.OrganizationID,
+		IP:               conn.GetIp(),
+		AdditionalFields: additionalFields,
+		Time:             at,
+		Old:              workspace,
+		New:              workspace,
+	})
+
+	return &agentproto.ReportConnectionResponse{}, nil
+}
@@ -0,0 +1,176 @@
+package agentapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"cdr.dev/slog/sloggers/slogtest"
+	agentproto "github.com/coder/coder/v2/agent/proto"
+	"github.com/coder/coder/v2/coderd/agentapi"
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbmock"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+)
+
+func TestReportConnection(t *testing.T) {
+	t.Parallel()
+
+	var (
+		owner = database.User{
+			ID:       uuid.New(),
+			Username: "cool-user",
+		}
+		workspace = database.Workspace{
+			ID:             uuid.New(),
+			OwnerID:        owner.ID,
+			OrganizationID: uuid.New(),
+			Name:           "cool-workspace",
+		}
+		agent = database.WorkspaceAgent{
+			ID:   uuid.New(),
+			Name: "cool-agent",
+		}
+		// A user the workspace is shared with, connecting over tailnet.
+		peerUserID = uuid.New()
+		peerAddr   = netip.MustParseAddr("fd7a:115c:a1e0::1")
+	)
+
+	tests := []struct {
+		name       string
+		connection *agentproto.Connection
+		action     database.AuditAction
+		userID     uuid.UUID
+		fields     map[string]any
+	}{
+		{
+			name: "Connect",
+			connection: &agentproto.Connection{
+				Action: agentproto.Connection_CONNECT,
+				Type:   agentproto.Connection_SSH,
+				Ip:     "127.0.0.1",
+			},
+			action: database.AuditActionConnect,
+			// The peer is not known, e.g. a web terminal proxied by coderd.
+			userID: uuid.Nil,
+			fields: map[string]any{
+				"connection_type": "ssh",
+			},
+		},
+		{
+			name: "Disconnect",
+			connection: &agentproto.Connection{
+				Action:     agentproto.Connection_DISCONNECT,
+				Type:       agentproto.Connection_RECONNECTING_PTY,
+				Ip:         "fd7a:115c:a1e0::1",
+				StatusCode: 1,
+				Reason:     "process exited",
+				Duration:   durationpb.New(90 * time.Second),
+			},
+			action: database.AuditActionDisconnect,
+			userID: peerUserID,
+			fields: map[string]any{
+				"connection_type": "reconnecting_pty",
+				"exit_code":       float64(1),
+				"reason":          "process exited",
+				"duration":        "1m30s",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			id := uuid.New()
+			now := dbtime.Now().Add(-time.Minute)
+			tt.connection.Id = id[:]
+			tt.connection.Timestamp = timestamppb.New(now)
+
+			dbM := dbmock.NewMockStore(gomock.NewController(t))
+			dbM.EXPECT().GetWorkspaceByAgentID(gomock.Any(), agent.ID).Return(database.GetWorkspaceByAgentIDRow{
+				Workspace: workspace,
+			}, nil)
+			dbM.EXPECT().GetUserByID(gomock.Any(), owner.ID).Return(owner, nil)
+
+			mockAuditor := audit.NewMock()
+			var auditor audit.Auditor = mockAuditor
+			auditorPtr := &atomic.Pointer[audit.Auditor]{}
+			auditorPtr.Store(&auditor)
+
+			api := &agentapi.AuditAPI{
+				AgentFn: func(context.Context) (database.WorkspaceAgent, error) {
+					return agent, nil
+				},
+				Auditor:  auditorPtr,
+				Database: dbM,
+				Log:      slogtest.Make(t, nil),
+				PeerUserFn: func(agentID uuid.UUID, addr netip.Addr) (uuid.UUID, bool) {
+					if agentID == agent.ID && addr == peerAddr {
+						return peerUserID, true
+					}
+					return uuid.Nil, false
+				},
+			}
+			_, err := api.ReportConnection(context.Background(), &agentproto.ReportConnectionRequest{
+				Connection: tt.connection,
+			})
+			require.NoError(t, err)
+
+			logs := mockAuditor.AuditLogs()
+			require.Len(t, logs, 1)
+			alog := logs[0]
+			require.Equal(t, tt.action, alog.Action)
+			require.Equal(t, database.ResourceTypeWorkspace, alog.ResourceType)
+			require.Equal(t, workspace.ID, alog.ResourceID)
+			require.Equal(t, workspace.Name, alog.ResourceTarget)
+			require.Equal(t, workspace.OrganizationID, alog.OrganizationID)
+			require.Equal(t, tt.userID, alog.UserID)
+			require.Equal(t, id, alog.RequestID)
+			require.Equal(t, tt.connection.Ip, alog.Ip.IPNet.IP.String())
+			require.Equal(t, int32(http.StatusOK), alog.StatusCode)
+			require.True(t, now.Equal(alog.Time), "expected %s, got %s", now, alog.Time)
+
+			var fields map[string]any
+			require.NoError(t, json.Unmarshal(alog.AdditionalFields, &fields))
+			require.Equal(t, workspace.Name, fields["workspace_name"])
+			require.Equal(t, owner.Username, fields["workspace_owner"])
+			require.Equal(t, agent.Name, fields["agent_name"])
+			for k, v := range tt.fields {
+				require.Equal(t, v, fields[k], k)
+			}
+		})
+	}
+
+	t.Run("UnknownAction", func(t *testing.T) {
+		t.Parallel()
+
+		id := uuid.New()
+		api := &agentapi.AuditAPI{
+			AgentFn: func(context.Context) (database.WorkspaceAgent, error) {
+				return agent, nil
+			},
+			Database: dbmock.NewMockStore(gomock.NewController(t)),
+			Log:      slogtest.Make(t, nil),
+		}
+		_, err := api.ReportConnection(context.Background(), &agentproto.ReportConnectionRequest{
+			Connection: &agentproto.Connection{
+				Id:   id[:],
+				Type: agentproto.Connection_SSH,
+			},
+		})
+		require.ErrorContains(t, err, "unknown connection action")
+	})
+}
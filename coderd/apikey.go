@@ -18,6 +18,7 @@ import (
 	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/coderd/telemetry"
 	"github.com/coder/coder/v2/codersdk"
@@ -55,6 +56,27 @@ func (api *API) postToken(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	params, err := api.tokenCreateParams(user, createToken)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Failed to validate create API key request.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	cookie, key, err := api.createAPIKey(ctx, params)
+	if err != nil {
+		writeCreateTokenError(ctx, rw, params.TokenName, err)
+		return
+	}
+	aReq.New = *key
+	httpapi.Write(ctx, rw, http.StatusCreated, codersdk.GenerateAPIKeyResponse{Key: cookie.Value})
+}
+
+// tokenCreateParams validates a token request for the given user and
+// returns the parameters to create the token with.
+func (api *API) tokenCreateParams(user database.User, createToken codersdk.CreateTokenRequest) (apikey.CreateParams, error) {
 	scope := database.APIKeyScopeAll
 	if scope != "" {
 		scope = database.APIKeyScope(createToken.Scope)
@@ -74,41 +96,49 @@ func (api *API) postToken(rw http.ResponseWriter, r *http.Request) {
 
 	err := api.validateAPIKeyLifetime(lifeTime)
 	if err != nil {
-		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
-			Message: "Failed to validate create API key request.",
-			Detail:  err.Error(),
-		})
-		return
+		return apikey.CreateParams{}, err
+	}
+
+	if user.IsServiceAccount && len(createToken.Scopes) == 0 {
+		return apikey.CreateParams{}, xerrors.New("tokens for service accounts must be limited to at least one scope")
+	}
+	if len(createToken.Scopes) > 0 {
+		if scope == database.APIKeyScopeApplicationConnect {
+			return apikey.CreateParams{}, xerrors.Errorf("scopes cannot be combined with the %q scope", scope)
+		}
+		_, err = rbac.ParseScopePermissions(createToken.Scopes)
+		if err != nil {
+			return apikey.CreateParams{}, err
+		}
 	}
 
-	cookie, key, err := api.createAPIKey(ctx, apikey.CreateParams{
+	return apikey.CreateParams{
 		UserID:          user.ID,
 		LoginType:       database.LoginTypeToken,
 		DefaultLifetime: api.DeploymentValues.Sessions.DefaultDuration.Value(),
 		ExpiresAt:       dbtime.Now().Add(lifeTime),
 		Scope:           scope,
+		Scopes:          createToken.Scopes,
 		LifetimeSeconds: int64(lifeTime.Seconds()),
 		TokenName:       tokenName,
-	})
-	if err != nil {
-		if database.IsUniqueViolation(err, database.UniqueIndexAPIKeyName) {
-			httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
-				Message: fmt.Sprintf("A token with name %q already exists.", tokenName),
-				Validations: []codersdk.ValidationError{{
-					Field:  "name",
-					Detail: "This value is already in use and should be unique.",
-				}},
-			})
-			return
-		}
-		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
-			Message: "Failed to create API key.",
-			Detail:  err.Error(),
+	}, nil
+}
+
+func writeCreateTokenError(ctx context.Context, rw http.ResponseWriter, tokenName string, err error) {
+	if database.IsUniqueViolation(err, database.UniqueIndexAPIKeyName) {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: fmt.Sprintf("A token with name %q already exists.", tokenName),
+			Validations: []codersdk.ValidationError{{
+				Field:  "name",
+				Detail: "This value is already in use and should be unique.",
+			}},
 		})
 		return
 	}
-	aReq.New = *key
-	httpapi.Write(ctx, rw, http.StatusCreated, codersdk.GenerateAPIKeyResponse{Key: cookie.Value})
+	httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+		Message: "Failed to create API key.",
+		Detail:  err.Error(),
+	})
 }
 
 // Creates a new session key, used for logging in via the CLI.
@@ -125,12 +155,20 @@ func (api *API) postAPIKey(rw http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	user := httpmw.UserParam(r)
 
+	if user.IsServiceAccount {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Service accounts can only authenticate with scoped tokens.",
+		})
+		return
+	}
+
 	lifeTime := time.Hour * 24 * 7
 	cookie, _, err := api.createAPIKey(ctx, apikey.CreateParams{
 		UserID:          user.ID,
 		DefaultLifetime: api.DeploymentValues.Sessions.DefaultDuration.Value(),
 		LoginType:       database.LoginTypePassword,
 		RemoteAddr:      r.RemoteAddr,
+		UserAgent:       r.UserAgent(),
 		// All api generated keys will last 1 week. Browser login tokens have
 		// a shorter life.
 		ExpiresAt:       dbtime.Now().Add(lifeTime),
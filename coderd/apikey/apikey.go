@@ -12,6 +12,7 @@ import (
 
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/cryptorand"
 )
 
@@ -26,8 +27,13 @@ type CreateParams struct {
 	ExpiresAt       time.Time
 	LifetimeSeconds int64
 	Scope           database.APIKeyScope
-	TokenName       string
-	RemoteAddr      string
+	// Scopes further limits the key to the listed "<resource>:<action>"
+	// permissions. It can only be combined with the "all" scope.
+	Scopes     []string
+	TokenName  string
+	RemoteAddr string
+	// UserAgent is recorded to identify the session of the key.
+	UserAgent string
 }
 
 // Generate generates an API key, returning the key as a string as well as the
@@ -72,6 +78,18 @@ func Generate(params CreateParams) (database.InsertAPIKeyParams, string, error)
 		return database.InsertAPIKeyParams{}, "", xerrors.Errorf("invalid API key scope: %q", scope)
 	}
 
+	scopes := []string{}
+	if len(params.Scopes) > 0 {
+		if scope != database.APIKeyScopeAll {
+			return database.InsertAPIKeyParams{}, "", xerrors.Errorf("scopes cannot be combined with the %q scope", scope)
+		}
+		perms, err := rbac.ParseScopePermissions(params.Scopes)
+		if err != nil {
+			return database.InsertAPIKeyParams{}, "", xerrors.Errorf("invalid API key scopes: %w", err)
+		}
+		scopes = perms
+	}
+
 	token := fmt.Sprintf("%s-%s", keyID, keySecret)
 
 	return database.InsertAPIKeyParams{
@@ -93,7 +111,9 @@ func Generate(params CreateParams) (database.InsertAPIKeyParams, string, error)
 		HashedSecret: hashed[:],
 		LoginType:    params.LoginType,
 		Scope:        scope,
+		Scopes:       scopes,
 		TokenName:    params.TokenName,
+		UserAgent:    params.UserAgent,
 	}, token, nil
 }
 
@@ -104,6 +104,48 @@ func TestGenerate(t *testing.T) {
 				Scope:           "",
 			},
 		},
+		{
+			name: "PermissionScopes",
+			params: apikey.CreateParams{
+				UserID:          uuid.New(),
+				LoginType:       database.LoginTypeToken,
+				DefaultLifetime: time.Duration(0),
+				ExpiresAt:       time.Now().Add(time.Hour),
+				LifetimeSeconds: int64(time.Hour.Seconds()),
+				TokenName:       "hello",
+				RemoteAddr:      "1.2.3.4",
+				Scopes:          []string{"workspace:ssh", "template:update"},
+			},
+		},
+		{
+			name: "InvalidPermissionScope",
+			params: apikey.CreateParams{
+				UserID:          uuid.New(),
+				LoginType:       database.LoginTypeToken,
+				DefaultLifetime: time.Duration(0),
+				ExpiresAt:       time.Now().Add(time.Hour),
+				LifetimeSeconds: int64(time.Hour.Seconds()),
+				TokenName:       "hello",
+				RemoteAddr:      "1.2.3.4",
+				Scopes:          []string{"workspace:fly"},
+			},
+			fail: true,
+		},
+		{
+			name: "PermissionScopesWithApplicationConnect",
+			params: apikey.CreateParams{
+				UserID:          uuid.New(),
+				LoginType:       database.LoginTypeToken,
+				DefaultLifetime: time.Duration(0),
+				ExpiresAt:       time.Now().Add(time.Hour),
+				LifetimeSeconds: int64(time.Hour.Seconds()),
+				TokenName:       "hello",
+				RemoteAddr:      "1.2.3.4",
+				Scope:           database.APIKeyScopeApplicationConnect,
+				Scopes:          []string{"workspace:read"},
+			},
+			fail: true,
+		},
 	}
 
 	for _, tc := range cases {
@@ -162,6 +204,7 @@ func TestGenerate(t *testing.T) {
 			} else {
 				assert.Equal(t, database.APIKeyScopeAll, key.Scope)
 			}
+			assert.ElementsMatch(t, tc.params.Scopes, key.Scopes)
 
 			if tc.params.TokenName != "" {
 				assert.Equal(t, tc.params.TokenName, key.TokenName)
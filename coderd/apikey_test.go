@@ -85,6 +85,65 @@ func TestTokenScoped(t *testing.T) {
 	require.Equal(t, keys[0].Scope, codersdk.APIKeyScopeApplicationConnect)
 }
 
+func TestTokenPermissionScopes(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+	defer cancel()
+	client := coderdtest.New(t, nil)
+	_ = coderdtest.CreateFirstUser(t, client)
+
+	res, err := client.CreateToken(ctx, codersdk.Me, codersdk.CreateTokenRequest{
+		Scopes: []string{"user:read", "deployment_config:read", "user:read"},
+	})
+	require.NoError(t, err)
+
+	keys, err := client.Tokens(ctx, codersdk.Me, codersdk.TokensFilter{})
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, codersdk.APIKeyScopeAll, keys[0].Scope)
+	require.Equal(t, []string{"deployment_config:read", "user:read"}, keys[0].Scopes)
+
+	scopedClient := codersdk.New(client.URL)
+	scopedClient.SetSessionToken(res.Key)
+	_, err = scopedClient.User(ctx, codersdk.Me)
+	require.NoError(t, err)
+	_, err = scopedClient.DeploymentConfig(ctx)
+	require.NoError(t, err)
+
+	res, err = client.CreateToken(ctx, codersdk.Me, codersdk.CreateTokenRequest{
+		Scopes: []string{"user:read"},
+	})
+	require.NoError(t, err)
+	scopedClient.SetSessionToken(res.Key)
+	_, err = scopedClient.User(ctx, codersdk.Me)
+	require.NoError(t, err)
+	// The owner can read the deployment config, but the token cannot.
+	_, err = scopedClient.DeploymentConfig(ctx)
+	var apiErr *codersdk.Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+
+	for _, scopes := range [][]string{
+		{"workspace"},
+		{"workspace:fly"},
+		{"unknown:read"},
+	} {
+		_, err = client.CreateToken(ctx, codersdk.Me, codersdk.CreateTokenRequest{
+			Scopes: scopes,
+		})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode(), scopes)
+	}
+
+	_, err = client.CreateToken(ctx, codersdk.Me, codersdk.CreateTokenRequest{
+		Scope:  codersdk.APIKeyScopeApplicationConnect,
+		Scopes: []string{"workspace:read"},
+	})
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+}
+
 func TestUserSetTokenDuration(t *testing.T) {
 	t.Parallel()
 
@@ -3,11 +3,14 @@ package coderd
 import (
 	"context"
 	"database/sql"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/netip"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,6 +23,7 @@ import (
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/database/db2sdk"
 	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
 	"github.com/coder/coder/v2/coderd/searchquery"
@@ -87,6 +91,224 @@ func (api *API) auditLogs(rw http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// auditLogExportBatchSize is the number of audit logs fetched from the
+// database at a time while exporting.
+const auditLogExportBatchSize = 500
+
+// @Summary Export audit logs
+// @ID export-audit-logs
+// @Security CoderSessionToken
+// @Tags Audit
+// @Param q query string false "Search query"
+// @Param format query string true "Export format" Enums(csv,jsonl)
+// @Success 200
+// @Router /audit/export [get]
+func (api *API) exportAuditLogs(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	apiKey := httpmw.APIKey(r)
+
+	format := codersdk.AuditLogExportFormat(r.URL.Query().Get("format"))
+	var contentType string
+	switch format {
+	case codersdk.AuditLogExportFormatCSV:
+		contentType = "text/csv"
+	case codersdk.AuditLogExportFormatJSONL:
+		contentType = "application/x-ndjson"
+	default:
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Unsupported export format.",
+			Detail:  fmt.Sprintf("Format must be %q or %q.", codersdk.AuditLogExportFormatCSV, codersdk.AuditLogExportFormatJSONL),
+		})
+		return
+	}
+
+	queryStr := r.URL.Query().Get("q")
+	filter, errs := searchquery.AuditLogs(ctx, api.Database, queryStr)
+	if len(errs) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid audit search query.",
+			Validations: errs,
+		})
+		return
+	}
+	if filter.Username == "me" {
+		filter.UserID = apiKey.UserID
+		filter.Username = ""
+	}
+
+	// Audit logs created after the export starts are excluded, so that they
+	// don't shift the pages which are yet to be fetched.
+	now := dbtime.Now()
+	if filter.DateTo.IsZero() || filter.DateTo.After(now) {
+		filter.DateTo = now
+	}
+	filter.LimitOpt = auditLogExportBatchSize
+
+	// The first batch is fetched before the response is started, so that
+	// errors can still be reported with the right status.
+	dblogs, err := api.Database.GetAuditLogsOffset(ctx, filter)
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	filename := fmt.Sprintf("audit-logs-%s.%s", now.UTC().Format("20060102T150405Z"), format)
+	rw.Header().Set("Content-Type", contentType)
+	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	rw.WriteHeader(http.StatusOK)
+
+	w := newAuditLogExportWriter(rw, format)
+	for {
+		for _, dblog := range dblogs {
+			err = w.Write(api.convertAuditLog(ctx, dblog))
+			if err != nil {
+				api.Logger.Debug(ctx, "write audit log export", slog.Error(err))
+				return
+			}
+		}
+		err = w.Flush()
+		if err != nil {
+			api.Logger.Debug(ctx, "flush audit log export", slog.Error(err))
+			return
+		}
+		if f, ok := rw.(http.Flusher); ok {
+			f.Flush()
+		}
+
+		if len(dblogs) < auditLogExportBatchSize {
+			return
+		}
+		filter.OffsetOpt += auditLogExportBatchSize
+		dblogs, err = api.Database.GetAuditLogsOffset(ctx, filter)
+		if err != nil {
+			// The response has already started, so the export can only be cut
+			// short.
+			api.Logger.Error(ctx, "fetch audit logs for export", slog.Error(err))
+			return
+		}
+	}
+}
+
+// auditLogExportCSVHeader names the columns of audit logs exported as CSV.
+var auditLogExportCSVHeader = []string{
+	"id",
+	"time",
+	"organization_id",
+	"organization_name",
+	"user_id",
+	"username",
+	"user_email",
+	"ip",
+	"user_agent",
+	"action",
+	"resource_type",
+	"resource_id",
+	"resource_target",
+	"status_code",
+	"request_id",
+	"description",
+	"additional_fields",
+	"diff",
+}
+
+type auditLogExportWriter interface {
+	Write(alog codersdk.AuditLog) error
+	Flush() error
+}
+
+func newAuditLogExportWriter(w io.Writer, format codersdk.AuditLogExportFormat) auditLogExportWriter {
+	if format == codersdk.AuditLogExportFormatCSV {
+		return &auditLogCSVWriter{w: csv.NewWriter(w)}
+	}
+	return auditLogJSONLWriter{enc: json.NewEncoder(w)}
+}
+
+type auditLogJSONLWriter struct {
+	enc *json.Encoder
+}
+
+func (w auditLogJSONLWriter) Write(alog codersdk.AuditLog) error {
+	return w.enc.Encode(alog)
+}
+
+func (auditLogJSONLWriter) Flush() error {
+	return nil
+}
+
+type auditLogCSVWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func (w *auditLogCSVWriter) Write(alog codersdk.AuditLog) error {
+	err := w.writeHeader()
+	if err != nil {
+		return err
+	}
+
+	diff, err := json.Marshal(alog.Diff)
+	if err != nil {
+		return xerrors.Errorf("marshal diff: %w", err)
+	}
+	var ip string
+	if alog.IP.IsValid() {
+		ip = alog.IP.String()
+	}
+	var organizationName string
+	if alog.Organization != nil {
+		organizationName = alog.Organization.Name
+	}
+	var userID, username, email string
+	if alog.User != nil {
+		userID = alog.User.ID.String()
+		username = alog.User.Username
+		email = alog.User.Email
+	}
+
+	return w.w.Write([]string{
+		alog.ID.String(),
+		alog.Time.UTC().Format(time.RFC3339Nano),
+		alog.OrganizationID.String(),
+		organizationName,
+		userID,
+		username,
+		email,
+		ip,
+		alog.UserAgent,
+		string(alog.Action),
+		string(alog.ResourceType),
+		alog.ResourceID.String(),
+		alog.ResourceTarget,
+		strconv.Itoa(int(alog.StatusCode)),
+		alog.RequestID.String(),
+		alog.Description,
+		string(alog.AdditionalFields),
+		string(diff),
+	})
+}
+
+func (w *auditLogCSVWriter) Flush() error {
+	// The header is written even if no audit logs matched.
+	err := w.writeHeader()
+	if err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *auditLogCSVWriter) writeHeader() error {
+	if w.wroteHeader {
+		return nil
+	}
+	w.wroteHeader = true
+	return w.w.Write(auditLogExportCSVHeader)
+}
+
 // @Summary Generate fake audit log
 // @ID generate-fake-audit-log
 // @Security CoderSessionToken
@@ -21,6 +21,7 @@ type Auditable interface {
 		database.AuditOAuthConvertState |
 		database.HealthSettings |
 		database.NotificationsSettings |
+		database.NotificationTemplate |
 		database.OAuth2ProviderApp |
 		database.OAuth2ProviderAppSecret |
 		database.CustomRole |
@@ -9,6 +9,7 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
@@ -62,6 +63,8 @@ type BackgroundAuditParams[T Auditable] struct {
 	OrganizationID   uuid.UUID
 	IP               string
 	AdditionalFields json.RawMessage
+	// Time is when the event occurred. Defaults to the current time.
+	Time time.Time
 
 	New T
 	Old T
@@ -101,6 +104,8 @@ func ResourceTarget[T Auditable](tgt T) string {
 		return "" // no target?
 	case database.NotificationsSettings:
 		return "" // no target?
+	case database.NotificationTemplate:
+		return typed.Name
 	case database.OAuth2ProviderApp:
 		return typed.Name
 	case database.OAuth2ProviderAppSecret:
@@ -147,6 +152,8 @@ func ResourceID[T Auditable](tgt T) uuid.UUID {
 	case database.NotificationsSettings:
 		// Artificial ID for auditing purposes
 		return typed.ID
+	case database.NotificationTemplate:
+		return typed.ID
 	case database.OAuth2ProviderApp:
 		return typed.ID
 	case database.OAuth2ProviderAppSecret:
@@ -190,6 +197,8 @@ func ResourceType[T Auditable](tgt T) database.ResourceType {
 		return database.ResourceTypeHealthSettings
 	case database.NotificationsSettings:
 		return database.ResourceTypeNotificationsSettings
+	case database.NotificationTemplate:
+		return database.ResourceTypeNotificationTemplate
 	case database.OAuth2ProviderApp:
 		return database.ResourceTypeOauth2ProviderApp
 	case database.OAuth2ProviderAppSecret:
@@ -235,6 +244,8 @@ func ResourceRequiresOrgID[T Auditable]() bool {
 	case database.NotificationsSettings:
 		// Artificial ID for auditing purposes
 		return false
+	case database.NotificationTemplate:
+		return false
 	case database.OAuth2ProviderApp:
 		return false
 	case database.OAuth2ProviderAppSecret:
@@ -403,9 +414,15 @@ func BackgroundAudit[T Auditable](ctx context.Context, p *BackgroundAuditParams[
 		p.AdditionalFields = json.RawMessage("{}")
 	}
 
+	if p.Time.IsZero() {
+		p.Time = dbtime.Now()
+	} else {
+		p.Time = dbtime.Time(p.Time)
+	}
+
 	auditLog := database.AuditLog{
 		ID:               uuid.New(),
-		Time:             dbtime.Now(),
+		Time:             p.Time,
 		UserID:           p.UserID,
 		OrganizationID:   requireOrgID[T](ctx, p.OrganizationID, p.Log),
 		Ip:               ip,
@@ -2,6 +2,7 @@ package coderd_test
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
 
 	"cdr.dev/slog/sloggers/slogtest"
 	"github.com/coder/coder/v2/coderd/audit"
@@ -18,6 +20,7 @@ import (
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
 )
 
 func TestAuditLogs(t *testing.T) {
@@ -389,3 +392,92 @@ func TestAuditLogsFilter(t *testing.T) {
 		}
 	})
 }
+
+func TestExportAuditLogs(t *testing.T) {
+	t.Parallel()
+
+	setupCtx := testutil.Context(t, testutil.WaitMedium)
+	client := coderdtest.New(t, nil)
+	user := coderdtest.CreateFirstUser(t, client)
+
+	actions := []codersdk.AuditAction{codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete}
+	for i, action := range actions {
+		err := client.CreateTestAuditLog(setupCtx, codersdk.CreateTestAuditLogRequest{
+			Action:     action,
+			ResourceID: user.UserID,
+			Time:       time.Date(2022, 8, 15+i, 14, 30, 45, 100, time.UTC),
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("JSONL", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		export, err := client.ExportAuditLogs(ctx, codersdk.ExportAuditLogsRequest{
+			SearchQuery: "action:write",
+			Format:      codersdk.AuditLogExportFormatJSONL,
+		})
+		require.NoError(t, err)
+		defer export.Close()
+
+		var alogs []codersdk.AuditLog
+		dec := json.NewDecoder(export)
+		for dec.More() {
+			var alog codersdk.AuditLog
+			require.NoError(t, dec.Decode(&alog))
+			alogs = append(alogs, alog)
+		}
+		require.Len(t, alogs, 1)
+		require.Equal(t, codersdk.AuditActionWrite, alogs[0].Action)
+		require.Equal(t, coderdtest.FirstUserParams.Username, alogs[0].User.Username)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		export, err := client.ExportAuditLogs(ctx, codersdk.ExportAuditLogsRequest{
+			Format: codersdk.AuditLogExportFormatCSV,
+		})
+		require.NoError(t, err)
+		defer export.Close()
+
+		records, err := csv.NewReader(export).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, len(actions)+1)
+		require.Equal(t, []string{"id", "time"}, records[0][:2])
+
+		// Audit logs are exported newest first.
+		action := slices.Index(records[0], "action")
+		require.NotEqual(t, -1, action)
+		for i, record := range records[1:] {
+			require.Equal(t, string(actions[len(actions)-1-i]), record[action])
+		}
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		_, err := client.ExportAuditLogs(ctx, codersdk.ExportAuditLogsRequest{
+			Format: "xml",
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+
+	t.Run("InvalidQuery", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		_, err := client.ExportAuditLogs(ctx, codersdk.ExportAuditLogsRequest{
+			SearchQuery: "action:invalid",
+			Format:      codersdk.AuditLogExportFormatCSV,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+}
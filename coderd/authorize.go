@@ -1,6 +1,7 @@
 package coderd
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -8,6 +9,7 @@ import (
 	"golang.org/x/xerrors"
 
 	"cdr.dev/slog"
+	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
 	"github.com/coder/coder/v2/coderd/rbac"
@@ -186,19 +188,8 @@ func (api *API) checkAuthorization(rw http.ResponseWriter, r *http.Request) {
 				return
 			}
 
-			var dbObj rbac.Objecter
-			var dbErr error
-			// Only support referencing some resources by ID.
-			switch string(v.Object.ResourceType) {
-			case rbac.ResourceWorkspace.Type:
-				dbObj, dbErr = api.Database.GetWorkspaceByID(ctx, id)
-			case rbac.ResourceTemplate.Type:
-				dbObj, dbErr = api.Database.GetTemplateByID(ctx, id)
-			case rbac.ResourceUser.Type:
-				dbObj, dbErr = api.Database.GetUserByID(ctx, id)
-			case rbac.ResourceGroup.Type:
-				dbObj, dbErr = api.Database.GetGroupByID(ctx, id)
-			default:
+			dbObj, ok, dbErr := api.authorizationObjectByID(ctx, v.Object.ResourceType, id)
+			if !ok {
 				msg := fmt.Sprintf("Object type %q does not support \"resource_id\" field.", v.Object.ResourceType)
 				httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
 					Message:     msg,
@@ -220,3 +211,173 @@ func (api *API) checkAuthorization(rw http.ResponseWriter, r *http.Request) {
 
 	httpapi.Write(ctx, rw, http.StatusOK, response)
 }
+
+// authorizationObjectByID fetches an object referenced by ID in an
+// authorization request. Only some resource types can be referenced by ID, ok
+// is false for the others.
+func (api *API) authorizationObjectByID(ctx context.Context, resourceType codersdk.RBACResource, id uuid.UUID) (obj rbac.Objecter, ok bool, err error) {
+	switch string(resourceType) {
+	case rbac.ResourceWorkspace.Type:
+		obj, err = api.Database.GetWorkspaceByID(ctx, id)
+	case rbac.ResourceTemplate.Type:
+		obj, err = api.Database.GetTemplateByID(ctx, id)
+	case rbac.ResourceUser.Type:
+		obj, err = api.Database.GetUserByID(ctx, id)
+	case rbac.ResourceGroup.Type:
+		obj, err = api.Database.GetGroupByID(ctx, id)
+	default:
+		return nil, false, nil
+	}
+	return obj, true, err
+}
+
+// debugExplainAuthorization evaluates an authorization request for a user and
+// explains which roles, scopes and ACL entries allowed or denied it.
+//
+// @Summary Explain authorization for a user
+// @ID explain-authorization-for-a-user
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Debug
+// @Param user path string true "User ID, name, or me"
+// @Param request body codersdk.AuthorizationExplainRequest true "Explain request"
+// @Success 200 {object} codersdk.AuthorizationExplanation
+// @Router /debug/{user}/authz-explain [post]
+func (api *API) debugExplainAuthorization(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := httpmw.UserParam(r)
+
+	var params codersdk.AuthorizationExplainRequest
+	if !httpapi.Read(ctx, rw, r, &params) {
+		return
+	}
+	if params.Object.ResourceType == "" || params.Action == "" {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Both \"action\" and the object's \"resource_type\" field must be defined.",
+		})
+		return
+	}
+	scope := database.APIKeyScopeAll
+	if params.Scope != "" {
+		scope = database.APIKeyScope(params.Scope)
+		if !scope.Valid() {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message:     fmt.Sprintf("Invalid scope %q.", params.Scope),
+				Validations: []codersdk.ValidationError{{Field: "scope", Detail: "must be all or application_connect"}},
+			})
+			return
+		}
+	}
+
+	subject, _, err := httpmw.UserRBACSubject(ctx, api.Database, user.ID, scope.ToRBAC())
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching user roles.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	obj := rbac.Object{
+		Owner: params.Object.OwnerID,
+		OrgID: params.Object.OrganizationID,
+		Type:  string(params.Object.ResourceType),
+	}
+	if obj.Owner == "me" {
+		obj.Owner = user.ID.String()
+	}
+	if params.Object.ResourceID != "" {
+		id, err := uuid.Parse(params.Object.ResourceID)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message:     fmt.Sprintf("Object %q id is not a valid uuid.", params.Object.ResourceID),
+				Validations: []codersdk.ValidationError{{Field: "resource_id", Detail: err.Error()}},
+			})
+			return
+		}
+		dbObj, ok, err := api.authorizationObjectByID(ctx, params.Object.ResourceType, id)
+		if !ok {
+			// Other objects are evaluated with the owner and organization
+			// given in the request.
+			obj.ID = id.String()
+		} else {
+			if httpapi.Is404Error(err) {
+				httpapi.ResourceNotFound(rw)
+				return
+			}
+			if err != nil {
+				httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+					Message: "Internal error fetching object.",
+					Detail:  err.Error(),
+				})
+				return
+			}
+			obj = dbObj.RBACObject()
+		}
+	}
+
+	explanation, err := rbac.Explain(ctx, subject, policy.Action(params.Action), obj)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error evaluating authorization.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	resp := codersdk.AuthorizationExplanation{
+		Object: codersdk.AuthorizationObject{
+			ResourceType:   codersdk.RBACResource(obj.Type),
+			OwnerID:        obj.Owner,
+			OrganizationID: obj.OrgID,
+			ResourceID:     obj.ID,
+		},
+		SubjectRoles:       make([]string, 0, len(subject.SafeRoleNames())),
+		SubjectGroups:      subject.Groups,
+		Scope:              subject.SafeScopeName(),
+		Allowed:            explanation.Allowed,
+		RoleAllowed:        explanation.RoleAllowed,
+		ACLAllowed:         explanation.ACLAllowed,
+		ScopeAllowed:       explanation.ScopeAllowed,
+		OrganizationMember: explanation.OrgMember,
+		RoleDecisions:      convertAuthorizationLevelDecisions(explanation.Roles),
+		ScopeDecisions:     convertAuthorizationLevelDecisions(explanation.Scope),
+		Permissions:        make([]codersdk.AuthorizationExplainedPermission, 0, len(explanation.Permissions)),
+		ACL:                make([]codersdk.AuthorizationExplainedACLEntry, 0, len(explanation.ACL)),
+	}
+	for _, role := range subject.SafeRoleNames() {
+		resp.SubjectRoles = append(resp.SubjectRoles, role.String())
+	}
+	for _, perm := range explanation.Permissions {
+		resp.Permissions = append(resp.Permissions, codersdk.AuthorizationExplainedPermission{
+			Role:         perm.Role.String(),
+			Scope:        perm.Scope,
+			Level:        string(perm.Level),
+			Negate:       perm.Permission.Negate,
+			ResourceType: codersdk.RBACResource(perm.Permission.ResourceType),
+			Action:       codersdk.RBACAction(perm.Permission.Action),
+		})
+	}
+	for _, entry := range explanation.ACL {
+		actions := make([]codersdk.RBACAction, 0, len(entry.Actions))
+		for _, action := range entry.Actions {
+			actions = append(actions, codersdk.RBACAction(action))
+		}
+		resp.ACL = append(resp.ACL, codersdk.AuthorizationExplainedACLEntry{
+			UserID:  entry.UserID,
+			GroupID: entry.GroupID,
+			Actions: actions,
+		})
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, resp)
+}
+
+func convertAuthorizationLevelDecisions(decisions rbac.LevelDecisions) codersdk.AuthorizationLevelDecisions {
+	return codersdk.AuthorizationLevelDecisions{
+		Site:         codersdk.AuthorizationDecision(decisions.Site.String()),
+		Organization: codersdk.AuthorizationDecision(decisions.Org.String()),
+		User:         codersdk.AuthorizationDecision(decisions.User.String()),
+	}
+}
@@ -2,12 +2,15 @@ package coderd_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
 	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/testutil"
@@ -139,3 +142,125 @@ func TestCheckPermissions(t *testing.T) {
 		})
 	}
 }
+
+func TestExplainAuthorization(t *testing.T) {
+	t.Parallel()
+
+	owner, db := coderdtest.NewWithDatabase(t, nil)
+	first := coderdtest.CreateFirstUser(t, owner)
+	memberClient, member := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+	template := dbgen.Template(t, db, database.Template{
+		OrganizationID: first.OrganizationID,
+		CreatedBy:      first.UserID,
+	})
+
+	t.Run("Denied", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitMedium)
+
+		explanation, err := owner.ExplainAuthorization(ctx, member.Username, codersdk.AuthorizationExplainRequest{
+			Object: codersdk.AuthorizationObject{
+				ResourceType: codersdk.ResourceTemplate,
+				ResourceID:   template.ID.String(),
+			},
+			Action: codersdk.ActionUpdate,
+		})
+		require.NoError(t, err)
+		require.False(t, explanation.Allowed)
+		require.False(t, explanation.RoleAllowed)
+		require.False(t, explanation.ACLAllowed)
+		require.True(t, explanation.ScopeAllowed)
+		require.True(t, explanation.OrganizationMember)
+		// The object is looked up by ID.
+		require.Equal(t, first.OrganizationID.String(), explanation.Object.OrganizationID)
+		require.Equal(t, template.ID.String(), explanation.Object.ResourceID)
+		require.Contains(t, explanation.SubjectRoles, "member")
+		require.Equal(t, "all", explanation.Scope)
+	})
+
+	t.Run("AllowedByACL", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitMedium)
+
+		explanation, err := owner.ExplainAuthorization(ctx, member.ID.String(), codersdk.AuthorizationExplainRequest{
+			Object: codersdk.AuthorizationObject{
+				ResourceType: codersdk.ResourceTemplate,
+				ResourceID:   template.ID.String(),
+			},
+			Action: codersdk.ActionRead,
+		})
+		require.NoError(t, err)
+		require.True(t, explanation.Allowed)
+		require.True(t, explanation.ACLAllowed)
+		require.Equal(t, []codersdk.AuthorizationExplainedACLEntry{{
+			GroupID: first.OrganizationID.String(),
+			Actions: []codersdk.RBACAction{codersdk.ActionRead},
+		}}, explanation.ACL)
+	})
+
+	t.Run("AllowedByRole", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitMedium)
+
+		explanation, err := owner.ExplainAuthorization(ctx, codersdk.Me, codersdk.AuthorizationExplainRequest{
+			Object: codersdk.AuthorizationObject{
+				ResourceType: codersdk.ResourceWorkspace,
+				OwnerID:      "me",
+			},
+			Action: codersdk.ActionDelete,
+		})
+		require.NoError(t, err)
+		require.True(t, explanation.Allowed)
+		require.True(t, explanation.RoleAllowed)
+		require.Equal(t, codersdk.AuthorizationDecisionAllow, explanation.RoleDecisions.Site)
+		require.Equal(t, first.UserID.String(), explanation.Object.OwnerID)
+		require.NotEmpty(t, explanation.Permissions)
+	})
+
+	t.Run("Scope", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitMedium)
+
+		explanation, err := owner.ExplainAuthorization(ctx, codersdk.Me, codersdk.AuthorizationExplainRequest{
+			Object: codersdk.AuthorizationObject{
+				ResourceType: codersdk.ResourceTemplate,
+				ResourceID:   template.ID.String(),
+			},
+			Action: codersdk.ActionUpdate,
+			Scope:  codersdk.APIKeyScopeApplicationConnect,
+		})
+		require.NoError(t, err)
+		require.False(t, explanation.Allowed)
+		require.True(t, explanation.RoleAllowed)
+		require.False(t, explanation.ScopeAllowed)
+	})
+
+	t.Run("OwnerOnly", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitMedium)
+
+		_, err := memberClient.ExplainAuthorization(ctx, codersdk.Me, codersdk.AuthorizationExplainRequest{
+			Object: codersdk.AuthorizationObject{ResourceType: codersdk.ResourceTemplate},
+			Action: codersdk.ActionRead,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitMedium)
+
+		_, err := owner.ExplainAuthorization(ctx, codersdk.Me, codersdk.AuthorizationExplainRequest{
+			Object: codersdk.AuthorizationObject{
+				ResourceType: codersdk.ResourceTemplate,
+				ResourceID:   uuid.NewString(),
+			},
+			Action: codersdk.ActionRead,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+}
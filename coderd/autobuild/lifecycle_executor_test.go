@@ -1106,6 +1106,9 @@ func TestNotifications(t *testing.T) {
 		workspace = coderdtest.MustTransitionWorkspace(t, client, workspace.ID, database.WorkspaceTransitionStart, database.WorkspaceTransitionStop)
 		_ = coderdtest.AwaitWorkspaceBuildJobCompleted(t, userClient, workspace.LatestBuild.ID)
 
+		// Clear the notification sent to the admin when the user was created
+		notifyEnq.Clear()
+
 		// Wait for workspace to become dormant
 		ticker <- workspace.LastUsedAt.Add(timeTilDormant * 3)
 		_ = testutil.RequireRecvCtx(testutil.Context(t, testutil.WaitShort), t, statCh)
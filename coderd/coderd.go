@@ -146,6 +146,7 @@ type Options struct {
 	GoogleTokenValidator           *idtoken.Validator
 	GithubOAuth2Config             *GithubOAuth2Config
 	OIDCConfig                     *OIDCConfig
+	LDAPConfig                     *LDAPConfig
 	PrometheusRegistry             *prometheus.Registry
 	SecureAuthCookie               bool
 	StrictTransportSecurityCfg     httpmw.HSTSConfig
@@ -236,6 +237,9 @@ type Options struct {
 	WorkspaceUsageTracker *workspacestats.UsageTracker
 	// NotificationsEnqueuer handles enqueueing notifications for delivery by SMTP, webhook, etc.
 	NotificationsEnqueuer notifications.Enqueuer
+	// NotificationsTemplateHelpers are the funcs which notification templates are rendered with. Customized templates
+	// are validated and previewed with these.
+	NotificationsTemplateHelpers map[string]any
 }
 
 // @title Coder API
@@ -473,7 +477,9 @@ func New(options *Options) *API {
 			options.Database,
 			options.Pubsub,
 		),
-		dbRolluper: options.DatabaseRolluper,
+		dbRolluper:               options.DatabaseRolluper,
+		workspaceBulkJobsAcquire: make(chan struct{}, 1),
+		tailnetPeerUsers:         newTailnetPeerUsers(),
 	}
 
 	var customRoleHandler CustomRoleHandler = &agplCustomRoleHandler{}
@@ -768,17 +774,16 @@ func New(options *Options) *API {
 	// for an external application to use Coder as an OAuth2 provider, not for
 	// logging into Coder with an external OAuth2 provider.
 	r.Route("/oauth2", func(r chi.Router) {
-		r.Use(
-			api.oAuth2ProviderMiddleware,
-			// Fetch the app as system because in the /tokens route there will be no
-			// authenticated user.
-			httpmw.AsAuthzSystem(httpmw.ExtractOAuth2ProviderApp(options.Database)),
-		)
+		r.Use(api.oAuth2ProviderMiddleware)
+		// Fetch the app as system because in the /tokens route there will be no
+		// authenticated user.
+		extractOAuth2ProviderApp := httpmw.AsAuthzSystem(httpmw.ExtractOAuth2ProviderApp(options.Database))
 		r.Route("/authorize", func(r chi.Router) {
-			r.Use(apiKeyMiddlewareRedirect)
+			r.Use(extractOAuth2ProviderApp, apiKeyMiddlewareRedirect)
 			r.Get("/", api.getOAuth2ProviderAppAuthorize())
 		})
 		r.Route("/tokens", func(r chi.Router) {
+			r.Use(extractOAuth2ProviderApp)
 			r.Group(func(r chi.Router) {
 				r.Use(apiKeyMiddleware)
 				// DELETE on /tokens is not part of the OAuth2 spec.  It is our own
@@ -790,7 +795,32 @@ func New(options *Options) *API {
 			// we cannot require an API key.
 			r.Post("/", api.postOAuth2ProviderAppToken())
 		})
+		r.Route("/device", func(r chi.Router) {
+			// Devices start the flow without a user, and every call stores a
+			// new device code, so it is rate limited like logins.
+			r.With(
+				httpmw.RateLimit(options.LoginRateLimit, time.Minute),
+				extractOAuth2ProviderApp,
+			).Post("/", api.postOAuth2ProviderAppDeviceAuthorization())
+			// The app is looked up from the user code the user enters.
+			r.With(apiKeyMiddlewareRedirect).Get("/verify", api.getOAuth2ProviderAppDeviceVerify())
+		})
+		r.With(extractOAuth2ProviderApp).Post("/introspect", api.postOAuth2ProviderAppIntrospect())
+		r.Route("/userinfo", func(r chi.Router) {
+			r.Use(httpmw.ExtractAPIKeyMW(httpmw.ExtractAPIKeyConfig{
+				DB:                          options.Database,
+				OAuth2Configs:               oauthConfigs,
+				RedirectToLogin:             false,
+				DisableSessionExpiryRefresh: options.DeploymentValues.Sessions.DisableExpiryRefresh.Value(),
+				Optional:                    false,
+				// OAuth2 clients send their access token as a bearer token.
+				SessionTokenFunc:              httpmw.OAuth2BearerTokenFromRequest,
+				PostAuthAdditionalHeadersFunc: options.PostAuthAdditionalHeadersFunc,
+			}))
+			r.Get("/", api.getOAuth2ProviderUserInfo())
+		})
 	})
+	r.With(api.oAuth2ProviderMiddleware).Get("/.well-known/oauth-authorization-server", api.getOAuth2ProviderDiscovery())
 
 	r.Route("/api/v2", func(r chi.Router) {
 		api.APIHandler = r
@@ -834,6 +864,7 @@ func New(options *Options) *API {
 			)
 
 			r.Get("/", api.auditLogs)
+			r.Get("/export", api.exportAuditLogs)
 			r.Post("/testgenerate", api.generateFakeAuditLog)
 		})
 		r.Route("/files", func(r chi.Router) {
@@ -913,6 +944,21 @@ func New(options *Options) *API {
 						})
 					})
 				})
+				r.Route("/service-accounts", func(r chi.Router) {
+					r.Get("/", api.serviceAccounts)
+					r.Post("/", api.postServiceAccount)
+					r.Route("/{user}", func(r chi.Router) {
+						r.Use(
+							httpmw.ExtractOrganizationMemberParam(options.Database),
+						)
+						r.Delete("/", api.deleteServiceAccount)
+						r.Route("/tokens", func(r chi.Router) {
+							r.Get("/", api.serviceAccountTokens)
+							r.Post("/", api.postServiceAccountToken)
+							r.Delete("/{keyid}", api.deleteServiceAccountToken)
+						})
+					})
+				})
 			})
 		})
 		r.Route("/templates", func(r chi.Router) {
@@ -925,6 +971,10 @@ func New(options *Options) *API {
 					httpmw.ExtractTemplateParam(options.Database),
 				)
 				r.Get("/daus", api.templateDAUs)
+				r.Route("/prebuilds", func(r chi.Router) {
+					r.Get("/", api.templatePrebuildPools)
+					r.Put("/", api.putTemplatePrebuildPools)
+				})
 				r.Get("/", api.template)
 				r.Delete("/", api.deleteTemplate)
 				r.Patch("/", api.patchTemplateMeta)
@@ -976,6 +1026,7 @@ func New(options *Options) *API {
 				// This value is intentionally increased during tests.
 				r.Use(httpmw.RateLimit(options.LoginRateLimit, time.Minute))
 				r.Post("/login", api.postLogin)
+				r.Post("/ldap/login", api.postLDAPLogin)
 				r.Route("/oauth2", func(r chi.Router) {
 					r.Route("/github", func(r chi.Router) {
 						r.Use(
@@ -1038,6 +1089,11 @@ func New(options *Options) *API {
 							r.Delete("/", api.deleteAPIKey)
 						})
 					})
+					r.Route("/sessions", func(r chi.Router) {
+						r.Get("/", api.userSessions)
+						r.Delete("/", api.deleteUserSessions)
+						r.Delete("/{sessionid}", api.deleteUserSession)
+					})
 
 					r.Route("/organizations", func(r chi.Router) {
 						r.Get("/", api.organizationsByUser)
@@ -1049,6 +1105,27 @@ func New(options *Options) *API {
 					})
 					r.Get("/gitsshkey", api.gitSSHKey)
 					r.Put("/gitsshkey", api.regenerateGitSSHKey)
+					r.Route("/mfa", func(r chi.Router) {
+						r.Get("/", api.userMFAStatus)
+						r.Route("/totp", func(r chi.Router) {
+							r.Post("/", api.postUserTOTP)
+							r.Delete("/", api.deleteUserTOTP)
+							r.Route("/verify", func(r chi.Router) {
+								r.Use(httpmw.RateLimit(options.LoginRateLimit, time.Minute))
+								r.Post("/", api.postUserTOTPVerify)
+							})
+						})
+					})
+					r.Route("/notifications", func(r chi.Router) {
+						r.Get("/preferences", api.userNotificationPreferences)
+						r.Put("/preferences", api.putUserNotificationPreferences)
+						r.Route("/inbox", func(r chi.Router) {
+							r.Get("/", api.userInboxNotifications)
+							r.Get("/watch", api.watchUserInboxNotifications)
+							r.Put("/mark-all-as-read", api.putUserInboxNotificationsMarkAllAsRead)
+							r.Put("/{id}/read-status", api.putUserInboxNotificationReadStatus)
+						})
+					})
 				})
 			})
 		})
@@ -1106,6 +1183,10 @@ func New(options *Options) *API {
 				apiKeyMiddleware,
 			)
 			r.Get("/", api.workspaces)
+			r.Route("/bulk", func(r chi.Router) {
+				r.Post("/", api.postWorkspaceBulkJob)
+				r.Get("/{workspacebulkjob}", api.workspaceBulkJob)
+			})
 			r.Route("/{workspace}", func(r chi.Router) {
 				r.Use(
 					httpmw.ExtractWorkspaceParam(options.Database),
@@ -1129,6 +1210,11 @@ func New(options *Options) *API {
 				r.Put("/favorite", api.putFavoriteWorkspace)
 				r.Delete("/favorite", api.deleteFavoriteWorkspace)
 				r.Put("/autoupdates", api.putWorkspaceAutoupdates)
+				r.Post("/transfer", api.postWorkspaceTransfer)
+				r.Route("/acl", func(r chi.Router) {
+					r.Get("/", api.workspaceACL)
+					r.Patch("/", api.patchWorkspaceACL)
+				})
 				r.Get("/resolve-autostart", api.resolveAutostart)
 				r.Route("/port-share", func(r chi.Router) {
 					r.Get("/", api.workspaceAgentPortShares)
@@ -1205,6 +1291,7 @@ func New(options *Options) *API {
 			r.Route("/{user}", func(r chi.Router) {
 				r.Use(httpmw.ExtractUserParam(options.Database))
 				r.Get("/debug-link", api.userDebugOIDC)
+				r.Post("/authz-explain", api.debugExplainAuthorization)
 			})
 			if options.DERPServer != nil {
 				r.Route("/derp", func(r chi.Router) {
@@ -1245,6 +1332,15 @@ func New(options *Options) *API {
 			r.Use(apiKeyMiddleware)
 			r.Get("/settings", api.notificationsSettings)
 			r.Put("/settings", api.putNotificationsSettings)
+			r.Route("/templates", func(r chi.Router) {
+				r.Get("/", api.notificationTemplates)
+				r.Route("/{notification_template}", func(r chi.Router) {
+					r.Put("/", api.putNotificationTemplate)
+					r.Post("/reset", api.resetNotificationTemplate)
+					r.Post("/preview", api.previewNotificationTemplate)
+					r.Put("/digest", api.putNotificationTemplateDigest)
+				})
+			})
 		})
 	})
 
@@ -1286,6 +1382,12 @@ func New(options *Options) *API {
 
 	api.RootHandler = r
 
+	api.workspaceBulkJobsWaitGroup.Add(1)
+	go func() {
+		defer api.workspaceBulkJobsWaitGroup.Done()
+		api.runWorkspaceBulkJobWorker(api.ctx)
+	}()
+
 	return api
 }
 
@@ -1343,6 +1445,15 @@ type API struct {
 	WebsocketWaitGroup sync.WaitGroup
 	derpCloseFunc      func()
 
+	// workspaceBulkJobsWaitGroup tracks the worker running bulk jobs in the
+	// background.
+	workspaceBulkJobsWaitGroup sync.WaitGroup
+	// workspaceBulkJobsAcquire wakes up the worker when a job is created.
+	workspaceBulkJobsAcquire chan struct{}
+	// tailnetPeerUsers attributes the connections agents report to the
+	// users that coordinate with them through this replica.
+	tailnetPeerUsers *tailnetPeerUsers
+
 	metricsCache          *metricscache.Cache
 	updateChecker         *updatecheck.Checker
 	WorkspaceAppsProvider workspaceapps.SignedTokenProvider
@@ -1393,6 +1504,9 @@ func (api *API) Close() error {
 	case <-timer.C:
 		api.Logger.Warn(api.ctx, "websocket shutdown timed out after 10 seconds")
 	}
+	// Bulk jobs stop at the next workspace once the API context is canceled,
+	// the remaining workspaces are left to the next worker that acquires it.
+	api.workspaceBulkJobsWaitGroup.Wait()
 
 	api.dbRolluper.Close()
 	api.metricsCache.Close()
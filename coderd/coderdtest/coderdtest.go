@@ -65,6 +65,7 @@ import (
 	"github.com/coder/coder/v2/coderd/gitsshkey"
 	"github.com/coder/coder/v2/coderd/httpmw"
 	"github.com/coder/coder/v2/coderd/notifications"
+	"github.com/coder/coder/v2/coderd/prebuilds"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/schedule"
 	"github.com/coder/coder/v2/coderd/telemetry"
@@ -104,10 +105,13 @@ type Options struct {
 	GithubOAuth2Config    *coderd.GithubOAuth2Config
 	RealIPConfig          *httpmw.RealIPConfig
 	OIDCConfig            *coderd.OIDCConfig
+	LDAPConfig            *coderd.LDAPConfig
 	GoogleTokenValidator  *idtoken.Validator
 	SSHKeygenAlgorithm    gitsshkey.Algorithm
 	AutobuildTicker       <-chan time.Time
 	AutobuildStats        chan<- autobuild.Stats
+	PrebuildsTicker       <-chan time.Time
+	PrebuildsStats        chan<- prebuilds.Stats
 	Auditor               audit.Auditor
 	TLSCertificates       []tls.Certificate
 	ExternalAuthConfigs   []*externalauth.Config
@@ -156,7 +160,8 @@ type Options struct {
 	WorkspaceUsageTrackerFlush         chan int
 	WorkspaceUsageTrackerTick          chan time.Time
 
-	NotificationsEnqueuer notifications.Enqueuer
+	NotificationsEnqueuer        notifications.Enqueuer
+	NotificationsTemplateHelpers map[string]any
 }
 
 // New constructs a codersdk client connected to an in-memory API instance.
@@ -224,6 +229,16 @@ func NewOptions(t testing.TB, options *Options) (func(http.Handler), context.Can
 			close(options.AutobuildStats)
 		})
 	}
+	if options.PrebuildsTicker == nil {
+		ticker := make(chan time.Time)
+		options.PrebuildsTicker = ticker
+		t.Cleanup(func() { close(ticker) })
+	}
+	if options.PrebuildsStats != nil {
+		t.Cleanup(func() {
+			close(options.PrebuildsStats)
+		})
+	}
 
 	if options.Authorizer == nil {
 		defAuth := rbac.NewStrictCachingAuthorizer(prometheus.NewRegistry())
@@ -325,6 +340,11 @@ func NewOptions(t testing.TB, options *Options) (func(http.Handler), context.Can
 	hangDetector.Start()
 	t.Cleanup(hangDetector.Close)
 
+	prebuildsReconciler := prebuilds.New(ctx, options.Database, options.Pubsub, options.Logger.Named("prebuilds.reconciler"), options.PrebuildsTicker).
+		WithStatsChannel(options.PrebuildsStats)
+	prebuildsReconciler.Start()
+	t.Cleanup(prebuildsReconciler.Close)
+
 	// Did last_used_at not update? Scratching your noggin? Here's why.
 	// Workspace usage tracking must be triggered manually in tests.
 	// The vast majority of existing tests do not depend on last_used_at
@@ -476,6 +496,7 @@ func NewOptions(t testing.TB, options *Options) (func(http.Handler), context.Can
 			GithubOAuth2Config:                 options.GithubOAuth2Config,
 			RealIPConfig:                       options.RealIPConfig,
 			OIDCConfig:                         options.OIDCConfig,
+			LDAPConfig:                         options.LDAPConfig,
 			GoogleTokenValidator:               options.GoogleTokenValidator,
 			SSHKeygenAlgorithm:                 options.SSHKeygenAlgorithm,
 			DERPServer:                         derpServer,
@@ -510,6 +531,7 @@ func NewOptions(t testing.TB, options *Options) (func(http.Handler), context.Can
 			DatabaseRolluper:                   options.DatabaseRolluper,
 			WorkspaceUsageTracker:              wuTracker,
 			NotificationsEnqueuer:              options.NotificationsEnqueuer,
+			NotificationsTemplateHelpers:       options.NotificationsTemplateHelpers,
 		}
 }
 
@@ -0,0 +1,334 @@
+// Package ldaptest provides a stand-in LDAP server for testing Login with
+// LDAP.
+package ldaptest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd"
+)
+
+const (
+	// BaseDN is the root of the directory of the fake server.
+	BaseDN = "dc=example,dc=com"
+	// BindDN and BindPassword are the credentials of the account that coderd
+	// uses to search for users.
+	BindDN       = "cn=admin,dc=example,dc=com"
+	BindPassword = "admin-password"
+)
+
+// Entry is an entry in the directory of the fake server.
+type Entry struct {
+	DN string
+	// Password is the password to bind as the entry. Entries without a
+	// password cannot bind.
+	Password   string
+	Attributes map[string][]string
+}
+
+// FakeLDAP is a minimal LDAP server. It supports simple binds and subtree
+// searches with equality, presence, and, or, and not filters. Attribute names
+// and values are compared case-insensitively. It does not support TLS.
+type FakeLDAP struct {
+	t        testing.TB
+	listener net.Listener
+
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// New starts a fake LDAP server with only the bind account in its directory.
+func New(t testing.TB) *FakeLDAP {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	f := &FakeLDAP{
+		t:        t,
+		listener: listener,
+	}
+	f.AddEntry(Entry{DN: BindDN, Password: BindPassword})
+
+	var wg sync.WaitGroup
+	t.Cleanup(func() {
+		_ = listener.Close()
+		wg.Wait()
+	})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				f.serve(conn)
+			}()
+		}
+	}()
+	return f
+}
+
+// URL returns the ldap:// URL of the server.
+func (f *FakeLDAP) URL() string {
+	return "ldap://" + f.listener.Addr().String()
+}
+
+// AddEntry adds an entry to the directory, replacing any entry with the same
+// DN.
+func (f *FakeLDAP) AddEntry(entry Entry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, existing := range f.entries {
+		if strings.EqualFold(existing.DN, entry.DN) {
+			f.entries[i] = entry
+			return
+		}
+	}
+	f.entries = append(f.entries, entry)
+}
+
+// GroupDN returns the DN of the group with the given common name in the
+// groups organizational unit.
+func GroupDN(name string) string {
+	return fmt.Sprintf("cn=%s,ou=groups,%s", name, BaseDN)
+}
+
+// AddUser adds a person to the directory that is found by the default search
+// filter of LDAPConfig. The user is a member of the given groups through the
+// memberOf attribute. Groups are DNs, or common names of groups in the groups
+// organizational unit.
+func (f *FakeLDAP) AddUser(username, password, email string, groups ...string) Entry {
+	memberOf := make([]string, 0, len(groups))
+	for _, group := range groups {
+		if !strings.Contains(group, "=") {
+			group = GroupDN(group)
+		}
+		memberOf = append(memberOf, group)
+	}
+	entry := Entry{
+		DN:       fmt.Sprintf("uid=%s,ou=people,%s", username, BaseDN),
+		Password: password,
+		Attributes: map[string][]string{
+			"objectClass": {"person"},
+			"uid":         {username},
+			"mail":        {email},
+			"cn":          {username},
+			"memberOf":    memberOf,
+		},
+	}
+	f.AddEntry(entry)
+	return entry
+}
+
+// LDAPConfig returns the config to use for coderd to authenticate users
+// against the server.
+func (f *FakeLDAP) LDAPConfig(opts ...func(cfg *coderd.LDAPConfig)) *coderd.LDAPConfig {
+	cfg := &coderd.LDAPConfig{
+		URL:               f.URL(),
+		BindDN:            BindDN,
+		BindPassword:      BindPassword,
+		UserSearchBaseDN:  BaseDN,
+		UserSearchFilter:  "(&(objectClass=person)(uid={username}))",
+		UsernameAttribute: "uid",
+		EmailAttribute:    "mail",
+		NameAttribute:     "cn",
+		AllowSignups:      true,
+		SignInText:        "LDAP",
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+func (f *FakeLDAP) serve(conn net.Conn) {
+	defer conn.Close()
+
+	bound := false
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				f.t.Logf("ldaptest: read packet: %v", err)
+			}
+			return
+		}
+		if len(packet.Children) < 2 {
+			f.t.Logf("ldaptest: malformed message")
+			return
+		}
+		messageID := packet.Children[0].Value
+		op := packet.Children[1]
+
+		var responses []*ber.Packet
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			var code uint16
+			code, bound = f.bind(op)
+			responses = append(responses, result(ldap.ApplicationBindResponse, code))
+		case ldap.ApplicationUnbindRequest:
+			return
+		case ldap.ApplicationSearchRequest:
+			if !bound {
+				responses = append(responses, result(ldap.ApplicationSearchResultDone, ldap.LDAPResultInsufficientAccessRights))
+				break
+			}
+			responses = append(responses, f.search(op)...)
+			responses = append(responses, result(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+		case ldap.ApplicationExtendedRequest:
+			responses = append(responses, result(ldap.ApplicationExtendedResponse, ldap.LDAPResultProtocolError))
+		default:
+			f.t.Logf("ldaptest: unsupported operation %d", op.Tag)
+			return
+		}
+
+		for _, response := range responses {
+			message := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+			message.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "Message ID"))
+			message.AppendChild(response)
+			_, err = conn.Write(message.Bytes())
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// bind verifies a simple bind. It returns the LDAP result code and whether
+// the connection is now authenticated as an entry.
+func (f *FakeLDAP) bind(op *ber.Packet) (uint16, bool) {
+	if len(op.Children) < 3 || op.Children[2].Tag != 0 {
+		return ldap.LDAPResultAuthMethodNotSupported, false
+	}
+	dn, _ := op.Children[1].Value.(string)
+	password := op.Children[2].Data.String()
+	// Anonymous binds succeed, but do not permit searching.
+	if dn == "" && password == "" {
+		return ldap.LDAPResultSuccess, false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, entry := range f.entries {
+		if strings.EqualFold(entry.DN, dn) && entry.Password != "" && entry.Password == password {
+			return ldap.LDAPResultSuccess, true
+		}
+	}
+	return ldap.LDAPResultInvalidCredentials, false
+}
+
+// search returns a search result entry for every entry below the base DN
+// that matches the filter.
+func (f *FakeLDAP) search(op *ber.Packet) []*ber.Packet {
+	if len(op.Children) < 8 {
+		return nil
+	}
+	baseDN, _ := op.Children[0].Value.(string)
+	filter := op.Children[6]
+	var requested []string
+	for _, attribute := range op.Children[7].Children {
+		name, _ := attribute.Value.(string)
+		requested = append(requested, name)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var responses []*ber.Packet
+	for _, entry := range f.entries {
+		if !strings.HasSuffix(strings.ToLower(entry.DN), strings.ToLower(baseDN)) || !matches(entry, filter) {
+			continue
+		}
+		response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+		response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.DN, "Object Name"))
+		attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+		for _, name := range requested {
+			values, ok := attributeValues(entry, name)
+			if !ok {
+				continue
+			}
+			attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+			attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+			set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+			for _, value := range values {
+				set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+			}
+			attribute.AppendChild(set)
+			attributes.AppendChild(attribute)
+		}
+		response.AppendChild(attributes)
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+// matches evaluates a search filter against an entry.
+func matches(entry Entry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !matches(entry, child) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, child := range filter.Children {
+			if matches(entry, child) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return len(filter.Children) == 1 && !matches(entry, filter.Children[0])
+	case ldap.FilterEqualityMatch:
+		if len(filter.Children) != 2 {
+			return false
+		}
+		name, _ := filter.Children[0].Value.(string)
+		want, _ := filter.Children[1].Value.(string)
+		values, _ := attributeValues(entry, name)
+		for _, value := range values {
+			if strings.EqualFold(value, want) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		_, ok := attributeValues(entry, filter.Data.String())
+		return ok
+	default:
+		return false
+	}
+}
+
+func attributeValues(entry Entry, name string) ([]string, bool) {
+	for key, values := range entry.Attributes {
+		if strings.EqualFold(key, name) {
+			return values, len(values) > 0
+		}
+	}
+	return nil, false
+}
+
+func result(tag ber.Tag, code uint16) *ber.Packet {
+	packet := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return packet
+}
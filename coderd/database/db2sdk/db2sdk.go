@@ -272,11 +272,16 @@ func templateVersionParameterOptions(rawOptions json.RawMessage) ([]codersdk.Tem
 }
 
 func OAuth2ProviderApp(accessURL *url.URL, dbApp database.OAuth2ProviderApp) codersdk.OAuth2ProviderApp {
+	var serviceAccountID *uuid.UUID
+	if dbApp.ServiceAccountID.Valid {
+		serviceAccountID = &dbApp.ServiceAccountID.UUID
+	}
 	return codersdk.OAuth2ProviderApp{
-		ID:          dbApp.ID,
-		Name:        dbApp.Name,
-		CallbackURL: dbApp.CallbackURL,
-		Icon:        dbApp.Icon,
+		ID:               dbApp.ID,
+		Name:             dbApp.Name,
+		CallbackURL:      dbApp.CallbackURL,
+		Icon:             dbApp.Icon,
+		ServiceAccountID: serviceAccountID,
 		Endpoints: codersdk.OAuth2AppEndpoints{
 			Authorization: accessURL.ResolveReference(&url.URL{
 				Path: "/oauth2/authorize",
@@ -284,8 +289,9 @@ func OAuth2ProviderApp(accessURL *url.URL, dbApp database.OAuth2ProviderApp) cod
 			Token: accessURL.ResolveReference(&url.URL{
 				Path: "/oauth2/tokens",
 			}).String(),
-			// We do not currently support DeviceAuth.
-			DeviceAuth: "",
+			DeviceAuth: accessURL.ResolveReference(&url.URL{
+				Path: "/oauth2/device",
+			}).String(),
 		},
 	}
 }
@@ -601,3 +607,49 @@ func Organization(organization database.Organization) codersdk.Organization {
 		IsDefault:   organization.IsDefault,
 	}
 }
+
+func NotificationPreference(pref database.GetUserNotificationPreferencesRow) codersdk.NotificationPreference {
+	p := codersdk.NotificationPreference{
+		NotificationTemplateID:   pref.NotificationTemplateID,
+		NotificationTemplateName: pref.NotificationTemplateName,
+		Group:                    pref.Group.String,
+		Disabled:                 pref.Disabled,
+	}
+	if pref.Method.Valid {
+		p.Method = string(pref.Method.NotificationMethod)
+	}
+	if pref.UpdatedAt.Valid {
+		p.UpdatedAt = &pref.UpdatedAt.Time
+	}
+	return p
+}
+
+func InboxNotification(notif database.InboxNotification) codersdk.InboxNotification {
+	n := codersdk.InboxNotification{
+		ID:        notif.ID,
+		UserID:    notif.UserID,
+		Title:     notif.Title,
+		Content:   notif.Content,
+		Actions:   []codersdk.InboxNotificationAction{},
+		CreatedAt: notif.CreatedAt,
+	}
+	// Actions are always encoded by the inbox dispatcher, so they're known to be valid.
+	_ = json.Unmarshal(notif.Actions, &n.Actions)
+	if notif.ReadAt.Valid {
+		n.ReadAt = &notif.ReadAt.Time
+	}
+	return n
+}
+
+func TemporaryRoleGrant(grant database.TemporaryRoleGrant) codersdk.TemporaryRoleGrant {
+	g := codersdk.TemporaryRoleGrant{
+		RoleName:      grant.RoleName,
+		Justification: grant.Justification,
+		CreatedAt:     grant.CreatedAt,
+		ExpiresAt:     grant.ExpiresAt,
+	}
+	if grant.OrganizationID.Valid {
+		g.OrganizationID = &grant.OrganizationID.UUID
+	}
+	return g
+}
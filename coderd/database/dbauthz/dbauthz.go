@@ -228,6 +228,55 @@ var (
 		Scope: rbac.ScopeAll,
 	}.WithCachedASTValue()
 
+	// See prebuilds package.
+	subjectPrebuildsReconciler = rbac.Subject{
+		FriendlyName: "Prebuilds Reconciler",
+		ID:           uuid.Nil.String(),
+		Roles: rbac.Roles([]rbac.Role{
+			{
+				Identifier:  rbac.RoleIdentifier{Name: "prebuildsreconciler"},
+				DisplayName: "Prebuilds Reconciler Daemon",
+				Site: rbac.Permissions(map[string][]policy.Action{
+					rbac.ResourceSystem.Type:           {policy.WildcardSymbol},
+					rbac.ResourceTemplate.Type:         {policy.ActionRead},
+					rbac.ResourceUser.Type:             {policy.ActionRead},
+					rbac.ResourceWorkspaceDormant.Type: {policy.ActionDelete, policy.ActionRead, policy.ActionUpdate},
+					rbac.ResourceWorkspace.Type:        {policy.ActionCreate, policy.ActionDelete, policy.ActionRead, policy.ActionUpdate, policy.ActionWorkspaceStart, policy.ActionWorkspaceStop},
+				}),
+				Org:  map[string][]rbac.Permission{},
+				User: []rbac.Permission{},
+			},
+		}),
+		Scope: rbac.ScopeAll,
+	}.WithCachedASTValue()
+
+	// subjectSCIM provisions users and groups on behalf of the identity
+	// provider. Deleting groups is only allowed for this subject.
+	subjectSCIM = rbac.Subject{
+		FriendlyName: "SCIM",
+		ID:           uuid.Nil.String(),
+		Roles: rbac.Roles([]rbac.Role{
+			{
+				Identifier:  rbac.RoleIdentifier{Name: "scim"},
+				DisplayName: "SCIM Provisioning",
+				Site: rbac.Permissions(map[string][]policy.Action{
+					rbac.ResourceSystem.Type:             {policy.WildcardSymbol},
+					rbac.ResourceAssignRole.Type:         {policy.ActionAssign, policy.ActionRead},
+					rbac.ResourceAssignOrgRole.Type:      {policy.ActionAssign, policy.ActionRead},
+					rbac.ResourceOrganization.Type:       {policy.ActionRead},
+					rbac.ResourceOrganizationMember.Type: {policy.ActionCreate, policy.ActionRead},
+					rbac.ResourceGroup.Type:              {policy.ActionCreate, policy.ActionRead, policy.ActionUpdate, policy.ActionDelete},
+					rbac.ResourceUser.Type:               rbac.ResourceUser.AvailableActions(),
+					rbac.ResourceWorkspace.Type:          {policy.ActionRead},
+					rbac.ResourceWorkspaceDormant.Type:   {policy.ActionRead},
+				}),
+				Org:  map[string][]rbac.Permission{},
+				User: []rbac.Permission{},
+			},
+		}),
+		Scope: rbac.ScopeAll,
+	}.WithCachedASTValue()
+
 	subjectSystemRestricted = rbac.Subject{
 		FriendlyName: "System",
 		ID:           uuid.Nil.String(),
@@ -277,6 +326,18 @@ func AsHangDetector(ctx context.Context) context.Context {
 	return context.WithValue(ctx, authContextKey{}, subjectHangDetector)
 }
 
+// AsPrebuildsReconciler returns a context with an actor that has permissions
+// required for prebuilds.Reconciler to function.
+func AsPrebuildsReconciler(ctx context.Context) context.Context {
+	return context.WithValue(ctx, authContextKey{}, subjectPrebuildsReconciler)
+}
+
+// AsSCIM returns a context with an actor that has permissions required to
+// provision users and groups with SCIM.
+func AsSCIM(ctx context.Context) context.Context {
+	return context.WithValue(ctx, authContextKey{}, subjectSCIM)
+}
+
 // AsSystemRestricted returns a context with an actor that has permissions
 // required for various system operations (login, logout, metrics cache).
 func AsSystemRestricted(ctx context.Context) context.Context {
@@ -614,6 +675,65 @@ func (*querier) convertToDeploymentRoles(names []string) []rbac.RoleIdentifier {
 	return uniques
 }
 
+// convertToTemporaryGrantRoles converts the role names of temporary role
+// grants, which are organization roles if the organization is set.
+func (q *querier) convertToTemporaryGrantRoles(orgID uuid.NullUUID, names []string) (*uuid.UUID, []rbac.RoleIdentifier, error) {
+	if !orgID.Valid {
+		return nil, q.convertToDeploymentRoles(names), nil
+	}
+	roles, err := q.convertToOrganizationRoles(orgID.UUID, names)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &orgID.UUID, roles, nil
+}
+
+// authorizeTemplatePrebuilds authorizes reading the prebuild pools and
+// prebuilt workspaces of a template. A nil template ID selects all templates,
+// which only the system can read.
+func (q *querier) authorizeTemplatePrebuilds(ctx context.Context, templateID uuid.UUID) error {
+	if templateID == uuid.Nil {
+		return q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem)
+	}
+	template, err := q.db.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		return err
+	}
+	return q.authorizeContext(ctx, policy.ActionRead, template)
+}
+
+// authorizeTemplatePrebuildPool authorizes the action on the template of a
+// prebuild pool.
+func (q *querier) authorizeTemplatePrebuildPool(ctx context.Context, action policy.Action, id uuid.UUID) error {
+	pool, err := q.db.GetTemplatePrebuildPoolByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	template, err := q.db.GetTemplateByID(ctx, pool.TemplateID)
+	if err != nil {
+		return err
+	}
+	return q.authorizeContext(ctx, action, template)
+}
+
+// authorizeTemplateVersion authorizes the action on the template of a template
+// version, or on the templates of its organization if the version does not
+// belong to a template yet.
+func (q *querier) authorizeTemplateVersion(ctx context.Context, action policy.Action, templateVersionID uuid.UUID) error {
+	tv, err := q.db.GetTemplateVersionByID(ctx, templateVersionID)
+	if err != nil {
+		return err
+	}
+	if !tv.TemplateID.Valid {
+		return q.authorizeContext(ctx, action, tv.RBACObjectNoTemplate())
+	}
+	template, err := q.db.GetTemplateByID(ctx, tv.TemplateID.UUID)
+	if err != nil {
+		return err
+	}
+	return q.authorizeContext(ctx, action, tv.RBACObject(template))
+}
+
 // canAssignRoles handles assigning built in and custom roles.
 func (q *querier) canAssignRoles(ctx context.Context, orgID *uuid.UUID, added, removed []rbac.RoleIdentifier) error {
 	actor, ok := ActorFromContext(ctx)
@@ -790,6 +910,16 @@ func (q *querier) authorizeTemplateInsights(ctx context.Context, templateIDs []u
 	return nil
 }
 
+// authorizeWorkspaceBulkJob authorizes the action on the initiator of a
+// workspace bulk job.
+func (q *querier) authorizeWorkspaceBulkJob(ctx context.Context, action policy.Action, id uuid.UUID) error {
+	job, err := q.db.GetWorkspaceBulkJobByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return q.authorizeContext(ctx, action, job)
+}
+
 // customRoleEscalationCheck checks to make sure the caller has every permission they are adding
 // to a custom role. This prevents permission escalation.
 func (q *querier) customRoleEscalationCheck(ctx context.Context, actor rbac.Subject, perm rbac.Permission, object rbac.Object) error {
@@ -834,6 +964,13 @@ func (q *querier) AcquireProvisionerJob(ctx context.Context, arg database.Acquir
 	return q.db.AcquireProvisionerJob(ctx, arg)
 }
 
+func (q *querier) AcquireWorkspaceBulkJob(ctx context.Context, arg database.AcquireWorkspaceBulkJobParams) (database.WorkspaceBulkJob, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
+		return database.WorkspaceBulkJob{}, err
+	}
+	return q.db.AcquireWorkspaceBulkJob(ctx, arg)
+}
+
 func (q *querier) ActivityBumpWorkspace(ctx context.Context, arg database.ActivityBumpWorkspaceParams) error {
 	fetch := func(ctx context.Context, arg database.ActivityBumpWorkspaceParams) (database.Workspace, error) {
 		return q.db.GetWorkspaceByID(ctx, arg.WorkspaceID)
@@ -884,6 +1021,16 @@ func (q *querier) BulkMarkNotificationMessagesSent(ctx context.Context, arg data
 	return q.db.BulkMarkNotificationMessagesSent(ctx, arg)
 }
 
+func (q *querier) ClaimPrebuiltWorkspace(ctx context.Context, arg database.ClaimPrebuiltWorkspaceParams) (database.Workspace, error) {
+	// Claiming a prebuilt workspace is the same as creating a workspace for
+	// the new owner.
+	obj := rbac.ResourceWorkspace.WithOwner(arg.NewOwnerID.String()).InOrg(arg.OrganizationID)
+	if err := q.authorizeContext(ctx, policy.ActionCreate, obj); err != nil {
+		return database.Workspace{}, err
+	}
+	return q.db.ClaimPrebuiltWorkspace(ctx, arg)
+}
+
 func (q *querier) CleanTailnetCoordinators(ctx context.Context) error {
 	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceTailnetCoordinator); err != nil {
 		return err
@@ -905,6 +1052,13 @@ func (q *querier) CleanTailnetTunnels(ctx context.Context) error {
 	return q.db.CleanTailnetTunnels(ctx)
 }
 
+func (q *querier) CountUnreadInboxNotificationsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if err := q.authorizeContext(ctx, policy.ActionReadPersonal, rbac.ResourceUserObject(userID)); err != nil {
+		return 0, err
+	}
+	return q.db.CountUnreadInboxNotificationsByUserID(ctx, userID)
+}
+
 // TODO: Handle org scoped lookups
 func (q *querier) CustomRoles(ctx context.Context, arg database.CustomRolesParams) ([]database.CustomRole, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceAssignRole); err != nil {
@@ -958,6 +1112,13 @@ func (q *querier) DeleteCoordinator(ctx context.Context, id uuid.UUID) error {
 	return q.db.DeleteCoordinator(ctx, id)
 }
 
+func (q *querier) DeleteExpiredOAuth2ProviderAppDeviceCodes(ctx context.Context, beforeTime time.Time) error {
+	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.DeleteExpiredOAuth2ProviderAppDeviceCodes(ctx, beforeTime)
+}
+
 func (q *querier) DeleteExternalAuthLink(ctx context.Context, arg database.DeleteExternalAuthLinkParams) error {
 	return fetchAndExec(q.log, q.auth, policy.ActionUpdatePersonal, func(ctx context.Context, arg database.DeleteExternalAuthLinkParams) (database.ExternalAuthLink, error) {
 		//nolint:gosimple
@@ -1018,6 +1179,17 @@ func (q *querier) DeleteOAuth2ProviderAppCodesByAppAndUserID(ctx context.Context
 	return q.db.DeleteOAuth2ProviderAppCodesByAppAndUserID(ctx, arg)
 }
 
+func (q *querier) DeleteOAuth2ProviderAppDeviceCodeByID(ctx context.Context, id uuid.UUID) error {
+	code, err := q.db.GetOAuth2ProviderAppDeviceCodeByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionDelete, code); err != nil {
+		return err
+	}
+	return q.db.DeleteOAuth2ProviderAppDeviceCodeByID(ctx, id)
+}
+
 func (q *querier) DeleteOAuth2ProviderAppSecretByID(ctx context.Context, id uuid.UUID) error {
 	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceOauth2AppSecret); err != nil {
 		return err
@@ -1033,6 +1205,13 @@ func (q *querier) DeleteOAuth2ProviderAppTokensByAppAndUserID(ctx context.Contex
 	return q.db.DeleteOAuth2ProviderAppTokensByAppAndUserID(ctx, arg)
 }
 
+func (q *querier) DeleteOldAuditLogs(ctx context.Context, beforeTime time.Time) error {
+	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.DeleteOldAuditLogs(ctx, beforeTime)
+}
+
 func (q *querier) DeleteOldNotificationMessages(ctx context.Context) error {
 	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceSystem); err != nil {
 		return err
@@ -1047,6 +1226,18 @@ func (q *querier) DeleteOldProvisionerDaemons(ctx context.Context) error {
 	return q.db.DeleteOldProvisionerDaemons(ctx)
 }
 
+func (q *querier) DeleteOldUserPasswordHistory(ctx context.Context, arg database.DeleteOldUserPasswordHistoryParams) error {
+	err := q.authorizeContext(ctx, policy.ActionUpdatePersonal, rbac.ResourceUserObject(arg.UserID))
+	if err != nil {
+		// Admins can update passwords for other users.
+		err = q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceUserObject(arg.UserID))
+		if err != nil {
+			return err
+		}
+	}
+	return q.db.DeleteOldUserPasswordHistory(ctx, arg)
+}
+
 func (q *querier) DeleteOldWorkspaceAgentLogs(ctx context.Context) error {
 	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceSystem); err != nil {
 		return err
@@ -1121,6 +1312,66 @@ func (q *querier) DeleteTailnetTunnel(ctx context.Context, arg database.DeleteTa
 	return q.db.DeleteTailnetTunnel(ctx, arg)
 }
 
+func (q *querier) DeleteTemplatePrebuildPoolByID(ctx context.Context, id uuid.UUID) error {
+	if err := q.authorizeTemplatePrebuildPool(ctx, policy.ActionUpdate, id); err != nil {
+		return err
+	}
+	return q.db.DeleteTemplatePrebuildPoolByID(ctx, id)
+}
+
+func (q *querier) DeleteTemplateVersionPresetsByTemplateVersionID(ctx context.Context, templateVersionID uuid.UUID) error {
+	if err := q.authorizeTemplateVersion(ctx, policy.ActionUpdate, templateVersionID); err != nil {
+		return err
+	}
+	return q.db.DeleteTemplateVersionPresetsByTemplateVersionID(ctx, templateVersionID)
+}
+
+func (q *querier) DeleteTemporaryRoleGrantByID(ctx context.Context, id uuid.UUID) error {
+	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.DeleteTemporaryRoleGrantByID(ctx, id)
+}
+
+func (q *querier) DeleteTemporaryRoleGrants(ctx context.Context, arg database.DeleteTemporaryRoleGrantsParams) error {
+	orgID, roles, err := q.convertToTemporaryGrantRoles(arg.OrganizationID, arg.RoleNames)
+	if err != nil {
+		return err
+	}
+	// Removing the expiry of a role is like assigning it permanently.
+	if err := q.canAssignRoles(ctx, orgID, roles, nil); err != nil {
+		return err
+	}
+	return q.db.DeleteTemporaryRoleGrants(ctx, arg)
+}
+
+func (q *querier) DeleteUserLoginAttempts(ctx context.Context, userID uuid.UUID) error {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceUserObject(userID)); err != nil {
+		return err
+	}
+	return q.db.DeleteUserLoginAttempts(ctx, userID)
+}
+
+func (q *querier) DeleteUserTOTPRecoveryCode(ctx context.Context, arg database.DeleteUserTOTPRecoveryCodeParams) (int64, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdatePersonal, rbac.ResourceUserObject(arg.UserID)); err != nil {
+		return 0, err
+	}
+	return q.db.DeleteUserTOTPRecoveryCode(ctx, arg)
+}
+
+func (q *querier) DeleteUserTOTPSecret(ctx context.Context, userID uuid.UUID) error {
+	err := q.authorizeContext(ctx, policy.ActionUpdatePersonal, rbac.ResourceUserObject(userID))
+	if err != nil {
+		// Admins can reset multi-factor authentication for users that lost
+		// their device.
+		err = q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceUserObject(userID))
+		if err != nil {
+			return err
+		}
+	}
+	return q.db.DeleteUserTOTPSecret(ctx, userID)
+}
+
 func (q *querier) DeleteWorkspaceAgentPortShare(ctx context.Context, arg database.DeleteWorkspaceAgentPortShareParams) error {
 	w, err := q.db.GetWorkspaceByID(ctx, arg.WorkspaceID)
 	if err != nil {
@@ -1319,6 +1570,13 @@ func (q *querier) GetDeploymentWorkspaceStats(ctx context.Context) (database.Get
 	return q.db.GetDeploymentWorkspaceStats(ctx)
 }
 
+func (q *querier) GetExpiredTemporaryRoleGrants(ctx context.Context, now time.Time) ([]database.TemporaryRoleGrant, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetExpiredTemporaryRoleGrants(ctx, now)
+}
+
 func (q *querier) GetExternalAuthLink(ctx context.Context, arg database.GetExternalAuthLinkParams) (database.ExternalAuthLink, error) {
 	return fetchWithAction(q.log, q.auth, policy.ActionReadPersonal, q.db.GetExternalAuthLink)(ctx, arg)
 }
@@ -1420,6 +1678,17 @@ func (q *querier) GetHungProvisionerJobs(ctx context.Context, hungSince time.Tim
 	return q.db.GetHungProvisionerJobs(ctx, hungSince)
 }
 
+func (q *querier) GetInboxNotificationByID(ctx context.Context, id uuid.UUID) (database.InboxNotification, error) {
+	return fetchWithAction(q.log, q.auth, policy.ActionReadPersonal, q.db.GetInboxNotificationByID)(ctx, id)
+}
+
+func (q *querier) GetInboxNotificationsByUserID(ctx context.Context, arg database.GetInboxNotificationsByUserIDParams) ([]database.InboxNotification, error) {
+	if err := q.authorizeContext(ctx, policy.ActionReadPersonal, rbac.ResourceUserObject(arg.UserID)); err != nil {
+		return nil, err
+	}
+	return q.db.GetInboxNotificationsByUserID(ctx, arg)
+}
+
 func (q *querier) GetJFrogXrayScanByWorkspaceAndAgentID(ctx context.Context, arg database.GetJFrogXrayScanByWorkspaceAndAgentIDParams) (database.JfrogXrayScan, error) {
 	if _, err := fetch(q.log, q.auth, q.db.GetWorkspaceByID)(ctx, arg.WorkspaceID); err != nil {
 		return database.JfrogXrayScan{}, err
@@ -1484,6 +1753,20 @@ func (q *querier) GetNotificationMessagesByStatus(ctx context.Context, arg datab
 	return q.db.GetNotificationMessagesByStatus(ctx, arg)
 }
 
+func (q *querier) GetNotificationTemplateByID(ctx context.Context, id uuid.UUID) (database.NotificationTemplate, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceDeploymentConfig); err != nil {
+		return database.NotificationTemplate{}, err
+	}
+	return q.db.GetNotificationTemplateByID(ctx, id)
+}
+
+func (q *querier) GetNotificationTemplates(ctx context.Context) ([]database.NotificationTemplate, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceDeploymentConfig); err != nil {
+		return nil, err
+	}
+	return q.db.GetNotificationTemplates(ctx)
+}
+
 func (q *querier) GetNotificationsSettings(ctx context.Context) (string, error) {
 	// No authz checks
 	return q.db.GetNotificationsSettings(ctx)
@@ -1504,6 +1787,18 @@ func (q *querier) GetOAuth2ProviderAppCodeByPrefix(ctx context.Context, secretPr
 	return fetch(q.log, q.auth, q.db.GetOAuth2ProviderAppCodeByPrefix)(ctx, secretPrefix)
 }
 
+func (q *querier) GetOAuth2ProviderAppDeviceCodeByID(ctx context.Context, id uuid.UUID) (database.OAuth2ProviderAppDeviceCode, error) {
+	return fetch(q.log, q.auth, q.db.GetOAuth2ProviderAppDeviceCodeByID)(ctx, id)
+}
+
+func (q *querier) GetOAuth2ProviderAppDeviceCodeByPrefix(ctx context.Context, secretPrefix []byte) (database.OAuth2ProviderAppDeviceCode, error) {
+	return fetch(q.log, q.auth, q.db.GetOAuth2ProviderAppDeviceCodeByPrefix)(ctx, secretPrefix)
+}
+
+func (q *querier) GetOAuth2ProviderAppDeviceCodeByUserCode(ctx context.Context, userCode string) (database.OAuth2ProviderAppDeviceCode, error) {
+	return fetch(q.log, q.auth, q.db.GetOAuth2ProviderAppDeviceCodeByUserCode)(ctx, userCode)
+}
+
 func (q *querier) GetOAuth2ProviderAppSecretByID(ctx context.Context, id uuid.UUID) (database.OAuth2ProviderAppSecret, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceOauth2AppSecret); err != nil {
 		return database.OAuth2ProviderAppSecret{}, err
@@ -1522,6 +1817,22 @@ func (q *querier) GetOAuth2ProviderAppSecretsByAppID(ctx context.Context, appID
 	return q.db.GetOAuth2ProviderAppSecretsByAppID(ctx, appID)
 }
 
+func (q *querier) GetOAuth2ProviderAppTokenByAPIKeyID(ctx context.Context, apiKeyID string) (database.OAuth2ProviderAppToken, error) {
+	token, err := q.db.GetOAuth2ProviderAppTokenByAPIKeyID(ctx, apiKeyID)
+	if err != nil {
+		return database.OAuth2ProviderAppToken{}, err
+	}
+	// The user ID is on the API key so that has to be fetched.
+	key, err := q.db.GetAPIKeyByID(ctx, token.APIKeyID)
+	if err != nil {
+		return database.OAuth2ProviderAppToken{}, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceOauth2AppCodeToken.WithOwner(key.UserID.String())); err != nil {
+		return database.OAuth2ProviderAppToken{}, err
+	}
+	return token, nil
+}
+
 func (q *querier) GetOAuth2ProviderAppTokenByPrefix(ctx context.Context, hashPrefix []byte) (database.OAuth2ProviderAppToken, error) {
 	token, err := q.db.GetOAuth2ProviderAppTokenByPrefix(ctx, hashPrefix)
 	if err != nil {
@@ -1607,6 +1918,13 @@ func (q *querier) GetParameterSchemasByJobID(ctx context.Context, jobID uuid.UUI
 	return q.db.GetParameterSchemasByJobID(ctx, jobID)
 }
 
+func (q *querier) GetPrebuiltWorkspaces(ctx context.Context, templateID uuid.UUID) ([]database.GetPrebuiltWorkspacesRow, error) {
+	if err := q.authorizeTemplatePrebuilds(ctx, templateID); err != nil {
+		return nil, err
+	}
+	return q.db.GetPrebuiltWorkspaces(ctx, templateID)
+}
+
 func (q *querier) GetPreviousTemplateVersion(ctx context.Context, arg database.GetPreviousTemplateVersionParams) (database.TemplateVersion, error) {
 	// An actor can read the previous template version if they can read the related template.
 	// If no linked template exists, we check if the actor can read *a* template.
@@ -1731,6 +2049,10 @@ func (q *querier) GetReplicasUpdatedAfter(ctx context.Context, updatedAt time.Ti
 	return q.db.GetReplicasUpdatedAfter(ctx, updatedAt)
 }
 
+func (q *querier) GetSessionAPIKeysByUserID(ctx context.Context, userID uuid.UUID) ([]database.APIKey, error) {
+	return fetchWithPostFilter(q.auth, policy.ActionRead, q.db.GetSessionAPIKeysByUserID)(ctx, userID)
+}
+
 func (q *querier) GetTailnetAgents(ctx context.Context, id uuid.UUID) ([]database.TailnetAgent, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceTailnetCoordinator); err != nil {
 		return nil, err
@@ -1834,6 +2156,20 @@ func (q *querier) GetTemplateParameterInsights(ctx context.Context, arg database
 	return q.db.GetTemplateParameterInsights(ctx, arg)
 }
 
+func (q *querier) GetTemplatePrebuildPoolByID(ctx context.Context, id uuid.UUID) (database.TemplatePrebuildPool, error) {
+	if err := q.authorizeTemplatePrebuildPool(ctx, policy.ActionRead, id); err != nil {
+		return database.TemplatePrebuildPool{}, err
+	}
+	return q.db.GetTemplatePrebuildPoolByID(ctx, id)
+}
+
+func (q *querier) GetTemplatePrebuildPools(ctx context.Context, templateID uuid.UUID) ([]database.TemplatePrebuildPool, error) {
+	if err := q.authorizeTemplatePrebuilds(ctx, templateID); err != nil {
+		return nil, err
+	}
+	return q.db.GetTemplatePrebuildPools(ctx, templateID)
+}
+
 func (q *querier) GetTemplateUsageStats(ctx context.Context, arg database.GetTemplateUsageStatsParams) ([]database.TemplateUsageStat, error) {
 	if err := q.authorizeTemplateInsights(ctx, arg.TemplateIDs); err != nil {
 		return nil, err
@@ -1916,6 +2252,28 @@ func (q *querier) GetTemplateVersionParameters(ctx context.Context, templateVers
 	return q.db.GetTemplateVersionParameters(ctx, templateVersionID)
 }
 
+func (q *querier) GetTemplateVersionPresetByID(ctx context.Context, id uuid.UUID) (database.TemplateVersionPreset, error) {
+	preset, err := q.db.GetTemplateVersionPresetByID(ctx, id)
+	if err != nil {
+		return database.TemplateVersionPreset{}, err
+	}
+	if err := q.authorizeTemplateVersion(ctx, policy.ActionRead, preset.TemplateVersionID); err != nil {
+		return database.TemplateVersionPreset{}, err
+	}
+	return preset, nil
+}
+
+func (q *querier) GetTemplateVersionPresetsByTemplateVersionIDs(ctx context.Context, ids []uuid.UUID) ([]database.TemplateVersionPreset, error) {
+	// An actor can read the presets if they can read all of the template
+	// versions.
+	for _, id := range slice.Unique(ids) {
+		if err := q.authorizeTemplateVersion(ctx, policy.ActionRead, id); err != nil {
+			return nil, err
+		}
+	}
+	return q.db.GetTemplateVersionPresetsByTemplateVersionIDs(ctx, ids)
+}
+
 func (q *querier) GetTemplateVersionVariables(ctx context.Context, templateVersionID uuid.UUID) ([]database.TemplateVersionVariable, error) {
 	tv, err := q.db.GetTemplateVersionByID(ctx, templateVersionID)
 	if err != nil {
@@ -2008,6 +2366,13 @@ func (q *querier) GetTemplatesWithFilter(ctx context.Context, arg database.GetTe
 	return q.db.GetAuthorizedTemplates(ctx, arg, prep)
 }
 
+func (q *querier) GetTemporaryRoleGrantsByUserID(ctx context.Context, userID uuid.UUID) ([]database.TemporaryRoleGrant, error) {
+	if err := q.authorizeContext(ctx, policy.ActionReadPersonal, rbac.ResourceUserObject(userID)); err != nil {
+		return nil, err
+	}
+	return q.db.GetTemporaryRoleGrantsByUserID(ctx, userID)
+}
+
 func (q *querier) GetUnexpiredLicenses(ctx context.Context) ([]database.License, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
 		return nil, err
@@ -2095,6 +2460,43 @@ func (q *querier) GetUserLinksByUserID(ctx context.Context, userID uuid.UUID) ([
 	return q.db.GetUserLinksByUserID(ctx, userID)
 }
 
+func (q *querier) GetUserLoginAttempts(ctx context.Context, userID uuid.UUID) (database.UserLoginAttempt, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceUserObject(userID)); err != nil {
+		return database.UserLoginAttempt{}, err
+	}
+	return q.db.GetUserLoginAttempts(ctx, userID)
+}
+
+func (q *querier) GetUserNotificationPreference(ctx context.Context, arg database.GetUserNotificationPreferenceParams) (database.NotificationPreference, error) {
+	return fetchWithAction(q.log, q.auth, policy.ActionReadPersonal, q.db.GetUserNotificationPreference)(ctx, arg)
+}
+
+func (q *querier) GetUserNotificationPreferences(ctx context.Context, userID uuid.UUID) ([]database.GetUserNotificationPreferencesRow, error) {
+	if err := q.authorizeContext(ctx, policy.ActionReadPersonal, rbac.ResourceUserObject(userID)); err != nil {
+		return nil, err
+	}
+	return q.db.GetUserNotificationPreferences(ctx, userID)
+}
+
+func (q *querier) GetUserPasswordHistory(ctx context.Context, arg database.GetUserPasswordHistoryParams) ([]database.UserPasswordHistory, error) {
+	err := q.authorizeContext(ctx, policy.ActionReadPersonal, rbac.ResourceUserObject(arg.UserID))
+	if err != nil {
+		// Admins check the history when updating passwords for other users.
+		err = q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceUserObject(arg.UserID))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q.db.GetUserPasswordHistory(ctx, arg)
+}
+
+func (q *querier) GetUserTOTPSecret(ctx context.Context, userID uuid.UUID) (database.UserTOTPSecret, error) {
+	if err := q.authorizeContext(ctx, policy.ActionReadPersonal, rbac.ResourceUserObject(userID)); err != nil {
+		return database.UserTOTPSecret{}, err
+	}
+	return q.db.GetUserTOTPSecret(ctx, userID)
+}
+
 func (q *querier) GetUserWorkspaceBuildParameters(ctx context.Context, params database.GetUserWorkspaceBuildParametersParams) ([]database.GetUserWorkspaceBuildParametersRow, error) {
 	u, err := q.db.GetUserByID(ctx, params.OwnerID)
 	if err != nil {
@@ -2344,6 +2746,17 @@ func (q *querier) GetWorkspaceBuildsCreatedAfter(ctx context.Context, createdAt
 	return q.db.GetWorkspaceBuildsCreatedAfter(ctx, createdAt)
 }
 
+func (q *querier) GetWorkspaceBulkJobByID(ctx context.Context, id uuid.UUID) (database.WorkspaceBulkJob, error) {
+	return fetchWithAction(q.log, q.auth, policy.ActionReadPersonal, q.db.GetWorkspaceBulkJobByID)(ctx, id)
+}
+
+func (q *querier) GetWorkspaceBulkJobResultsByJobID(ctx context.Context, jobID uuid.UUID) ([]database.GetWorkspaceBulkJobResultsByJobIDRow, error) {
+	if err := q.authorizeWorkspaceBulkJob(ctx, policy.ActionReadPersonal, jobID); err != nil {
+		return nil, err
+	}
+	return q.db.GetWorkspaceBulkJobResultsByJobID(ctx, jobID)
+}
+
 func (q *querier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUID) (database.GetWorkspaceByAgentIDRow, error) {
 	return fetch(q.log, q.auth, q.db.GetWorkspaceByAgentID)(ctx, agentID)
 }
@@ -2552,6 +2965,13 @@ func (q *querier) InsertGroupMember(ctx context.Context, arg database.InsertGrou
 	return update(q.log, q.auth, fetch, q.db.InsertGroupMember)(ctx, arg)
 }
 
+func (q *querier) InsertInboxNotification(ctx context.Context, arg database.InsertInboxNotificationParams) error {
+	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.InsertInboxNotification(ctx, arg)
+}
+
 func (q *querier) InsertLicense(ctx context.Context, arg database.InsertLicenseParams) (database.License, error) {
 	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceLicense); err != nil {
 		return database.License{}, err
@@ -2581,6 +3001,14 @@ func (q *querier) InsertOAuth2ProviderAppCode(ctx context.Context, arg database.
 	return q.db.InsertOAuth2ProviderAppCode(ctx, arg)
 }
 
+func (q *querier) InsertOAuth2ProviderAppDeviceCode(ctx context.Context, arg database.InsertOAuth2ProviderAppDeviceCodeParams) (database.OAuth2ProviderAppDeviceCode, error) {
+	// Device codes are requested by the device before any user is involved.
+	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
+		return database.OAuth2ProviderAppDeviceCode{}, err
+	}
+	return q.db.InsertOAuth2ProviderAppDeviceCode(ctx, arg)
+}
+
 func (q *querier) InsertOAuth2ProviderAppSecret(ctx context.Context, arg database.InsertOAuth2ProviderAppSecretParams) (database.OAuth2ProviderAppSecret, error) {
 	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceOauth2AppSecret); err != nil {
 		return database.OAuth2ProviderAppSecret{}, err
@@ -2620,6 +3048,13 @@ func (q *querier) InsertOrganizationMember(ctx context.Context, arg database.Ins
 	return insert(q.log, q.auth, obj, q.db.InsertOrganizationMember)(ctx, arg)
 }
 
+func (q *querier) InsertPrebuiltWorkspace(ctx context.Context, arg database.InsertPrebuiltWorkspaceParams) error {
+	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.InsertPrebuiltWorkspace(ctx, arg)
+}
+
 // TODO: We need to create a ProvisionerJob resource type
 func (q *querier) InsertProvisionerJob(ctx context.Context, arg database.InsertProvisionerJobParams) (database.ProvisionerJob, error) {
 	// if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
@@ -2655,6 +3090,17 @@ func (q *querier) InsertTemplate(ctx context.Context, arg database.InsertTemplat
 	return q.db.InsertTemplate(ctx, arg)
 }
 
+func (q *querier) InsertTemplatePrebuildPool(ctx context.Context, arg database.InsertTemplatePrebuildPoolParams) (database.TemplatePrebuildPool, error) {
+	template, err := q.db.GetTemplateByID(ctx, arg.TemplateID)
+	if err != nil {
+		return database.TemplatePrebuildPool{}, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, template); err != nil {
+		return database.TemplatePrebuildPool{}, err
+	}
+	return q.db.InsertTemplatePrebuildPool(ctx, arg)
+}
+
 func (q *querier) InsertTemplateVersion(ctx context.Context, arg database.InsertTemplateVersionParams) error {
 	if !arg.TemplateID.Valid {
 		// Making a new template version is the same permission as creating a new template.
@@ -2685,6 +3131,13 @@ func (q *querier) InsertTemplateVersionParameter(ctx context.Context, arg databa
 	return q.db.InsertTemplateVersionParameter(ctx, arg)
 }
 
+func (q *querier) InsertTemplateVersionPreset(ctx context.Context, arg database.InsertTemplateVersionPresetParams) (database.TemplateVersionPreset, error) {
+	if err := q.authorizeTemplateVersion(ctx, policy.ActionUpdate, arg.TemplateVersionID); err != nil {
+		return database.TemplateVersionPreset{}, err
+	}
+	return q.db.InsertTemplateVersionPreset(ctx, arg)
+}
+
 func (q *querier) InsertTemplateVersionVariable(ctx context.Context, arg database.InsertTemplateVersionVariableParams) (database.TemplateVersionVariable, error) {
 	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
 		return database.TemplateVersionVariable{}, err
@@ -2728,6 +3181,18 @@ func (q *querier) InsertUserLink(ctx context.Context, arg database.InsertUserLin
 	return q.db.InsertUserLink(ctx, arg)
 }
 
+func (q *querier) InsertUserPasswordHistory(ctx context.Context, arg database.InsertUserPasswordHistoryParams) error {
+	err := q.authorizeContext(ctx, policy.ActionUpdatePersonal, rbac.ResourceUserObject(arg.UserID))
+	if err != nil {
+		// Admins can update passwords for other users.
+		err = q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceUserObject(arg.UserID))
+		if err != nil {
+			return err
+		}
+	}
+	return q.db.InsertUserPasswordHistory(ctx, arg)
+}
+
 func (q *querier) InsertWorkspace(ctx context.Context, arg database.InsertWorkspaceParams) (database.Workspace, error) {
 	obj := rbac.ResourceWorkspace.WithOwner(arg.OwnerID.String()).InOrg(arg.OrganizationID)
 	return insert(q.log, q.auth, obj, q.db.InsertWorkspace)(ctx, arg)
@@ -2850,6 +3315,20 @@ func (q *querier) InsertWorkspaceBuildParameters(ctx context.Context, arg databa
 	return q.db.InsertWorkspaceBuildParameters(ctx, arg)
 }
 
+func (q *querier) InsertWorkspaceBulkJob(ctx context.Context, arg database.InsertWorkspaceBulkJobParams) (database.WorkspaceBulkJob, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdatePersonal, rbac.ResourceUserObject(arg.InitiatorID)); err != nil {
+		return database.WorkspaceBulkJob{}, err
+	}
+	return q.db.InsertWorkspaceBulkJob(ctx, arg)
+}
+
+func (q *querier) InsertWorkspaceBulkJobResults(ctx context.Context, arg database.InsertWorkspaceBulkJobResultsParams) error {
+	if err := q.authorizeWorkspaceBulkJob(ctx, policy.ActionUpdatePersonal, arg.JobID); err != nil {
+		return err
+	}
+	return q.db.InsertWorkspaceBulkJobResults(ctx, arg)
+}
+
 func (q *querier) InsertWorkspaceProxy(ctx context.Context, arg database.InsertWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	return insert(q.log, q.auth, rbac.ResourceWorkspaceProxy, q.db.InsertWorkspaceProxy)(ctx, arg)
 }
@@ -2886,6 +3365,13 @@ func (q *querier) ListWorkspaceAgentPortShares(ctx context.Context, workspaceID
 	return q.db.ListWorkspaceAgentPortShares(ctx, workspaceID)
 }
 
+func (q *querier) MarkAllInboxNotificationsAsRead(ctx context.Context, arg database.MarkAllInboxNotificationsAsReadParams) (int64, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdatePersonal, rbac.ResourceUserObject(arg.UserID)); err != nil {
+		return 0, err
+	}
+	return q.db.MarkAllInboxNotificationsAsRead(ctx, arg)
+}
+
 func (q *querier) OrganizationMembers(ctx context.Context, arg database.OrganizationMembersParams) ([]database.OrganizationMembersRow, error) {
 	return fetchWithPostFilter(q.auth, policy.ActionRead, q.db.OrganizationMembers)(ctx, arg)
 }
@@ -2918,6 +3404,13 @@ func (q *querier) RemoveUserFromAllGroups(ctx context.Context, userID uuid.UUID)
 	return q.db.RemoveUserFromAllGroups(ctx, userID)
 }
 
+func (q *querier) ResetNotificationTemplateByID(ctx context.Context, id uuid.UUID) (database.NotificationTemplate, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceDeploymentConfig); err != nil {
+		return database.NotificationTemplate{}, err
+	}
+	return q.db.ResetNotificationTemplateByID(ctx, id)
+}
+
 func (q *querier) RevokeDBCryptKey(ctx context.Context, activeKeyDigest string) error {
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
 		return err
@@ -2987,6 +3480,17 @@ func (q *querier) UpdateInactiveUsersToDormant(ctx context.Context, lastSeenAfte
 	return q.db.UpdateInactiveUsersToDormant(ctx, lastSeenAfter)
 }
 
+func (q *querier) UpdateInboxNotificationReadStatus(ctx context.Context, arg database.UpdateInboxNotificationReadStatusParams) (database.InboxNotification, error) {
+	notif, err := q.db.GetInboxNotificationByID(ctx, arg.ID)
+	if err != nil {
+		return database.InboxNotification{}, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionUpdatePersonal, notif); err != nil {
+		return database.InboxNotification{}, err
+	}
+	return q.db.UpdateInboxNotificationReadStatus(ctx, arg)
+}
+
 func (q *querier) UpdateMemberRoles(ctx context.Context, arg database.UpdateMemberRolesParams) (database.OrganizationMember, error) {
 	// Authorized fetch will check that the actor has read access to the org member since the org member is returned.
 	member, err := database.ExpectOne(q.OrganizationMembers(ctx, database.OrganizationMembersParams{
@@ -3021,6 +3525,20 @@ func (q *querier) UpdateMemberRoles(ctx context.Context, arg database.UpdateMemb
 	return q.db.UpdateMemberRoles(ctx, arg)
 }
 
+func (q *querier) UpdateNotificationTemplateByID(ctx context.Context, arg database.UpdateNotificationTemplateByIDParams) (database.NotificationTemplate, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceDeploymentConfig); err != nil {
+		return database.NotificationTemplate{}, err
+	}
+	return q.db.UpdateNotificationTemplateByID(ctx, arg)
+}
+
+func (q *querier) UpdateNotificationTemplateDigestWindowByID(ctx context.Context, arg database.UpdateNotificationTemplateDigestWindowByIDParams) (database.NotificationTemplate, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceDeploymentConfig); err != nil {
+		return database.NotificationTemplate{}, err
+	}
+	return q.db.UpdateNotificationTemplateDigestWindowByID(ctx, arg)
+}
+
 func (q *querier) UpdateOAuth2ProviderAppByID(ctx context.Context, arg database.UpdateOAuth2ProviderAppByIDParams) (database.OAuth2ProviderApp, error) {
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceOauth2App); err != nil {
 		return database.OAuth2ProviderApp{}, err
@@ -3028,6 +3546,16 @@ func (q *querier) UpdateOAuth2ProviderAppByID(ctx context.Context, arg database.
 	return q.db.UpdateOAuth2ProviderAppByID(ctx, arg)
 }
 
+func (q *querier) UpdateOAuth2ProviderAppDeviceCodeByID(ctx context.Context, arg database.UpdateOAuth2ProviderAppDeviceCodeByIDParams) (database.OAuth2ProviderAppDeviceCode, error) {
+	// Approving a device code creates a grant for the approving user, the same
+	// way inserting an authorization code does.
+	if err := q.authorizeContext(ctx, policy.ActionCreate,
+		rbac.ResourceOauth2AppCodeToken.WithOwner(arg.UserID.UUID.String())); err != nil {
+		return database.OAuth2ProviderAppDeviceCode{}, err
+	}
+	return q.db.UpdateOAuth2ProviderAppDeviceCodeByID(ctx, arg)
+}
+
 func (q *querier) UpdateOAuth2ProviderAppSecretByID(ctx context.Context, arg database.UpdateOAuth2ProviderAppSecretByIDParams) (database.OAuth2ProviderAppSecret, error) {
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceOauth2AppSecret); err != nil {
 		return database.OAuth2ProviderAppSecret{}, err
@@ -3174,6 +3702,13 @@ func (q *querier) UpdateTemplateMetaByID(ctx context.Context, arg database.Updat
 	return update(q.log, q.auth, fetch, q.db.UpdateTemplateMetaByID)(ctx, arg)
 }
 
+func (q *querier) UpdateTemplatePrebuildPoolByID(ctx context.Context, arg database.UpdateTemplatePrebuildPoolByIDParams) (database.TemplatePrebuildPool, error) {
+	if err := q.authorizeTemplatePrebuildPool(ctx, policy.ActionUpdate, arg.ID); err != nil {
+		return database.TemplatePrebuildPool{}, err
+	}
+	return q.db.UpdateTemplatePrebuildPoolByID(ctx, arg)
+}
+
 func (q *querier) UpdateTemplateScheduleByID(ctx context.Context, arg database.UpdateTemplateScheduleByIDParams) error {
 	fetch := func(ctx context.Context, arg database.UpdateTemplateScheduleByIDParams) (database.Template, error) {
 		return q.db.GetTemplateByID(ctx, arg.ID)
@@ -3371,6 +3906,20 @@ func (q *querier) UpdateUserStatus(ctx context.Context, arg database.UpdateUserS
 	return updateWithReturn(q.log, q.auth, fetch, q.db.UpdateUserStatus)(ctx, arg)
 }
 
+func (q *querier) UpdateUserTOTPSecretConfirmed(ctx context.Context, arg database.UpdateUserTOTPSecretConfirmedParams) (database.UserTOTPSecret, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdatePersonal, rbac.ResourceUserObject(arg.UserID)); err != nil {
+		return database.UserTOTPSecret{}, err
+	}
+	return q.db.UpdateUserTOTPSecretConfirmed(ctx, arg)
+}
+
+func (q *querier) UpdateUserTOTPSecretLastUsedCounter(ctx context.Context, arg database.UpdateUserTOTPSecretLastUsedCounterParams) (int64, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdatePersonal, rbac.ResourceUserObject(arg.UserID)); err != nil {
+		return 0, err
+	}
+	return q.db.UpdateUserTOTPSecretLastUsedCounter(ctx, arg)
+}
+
 func (q *querier) UpdateWorkspace(ctx context.Context, arg database.UpdateWorkspaceParams) (database.Workspace, error) {
 	fetch := func(ctx context.Context, arg database.UpdateWorkspaceParams) (database.Workspace, error) {
 		return q.db.GetWorkspaceByID(ctx, arg.ID)
@@ -3378,6 +3927,15 @@ func (q *querier) UpdateWorkspace(ctx context.Context, arg database.UpdateWorksp
 	return updateWithReturn(q.log, q.auth, fetch, q.db.UpdateWorkspace)(ctx, arg)
 }
 
+func (q *querier) UpdateWorkspaceACLByID(ctx context.Context, arg database.UpdateWorkspaceACLByIDParams) error {
+	fetch := func(ctx context.Context, arg database.UpdateWorkspaceACLByIDParams) (database.Workspace, error) {
+		return q.db.GetWorkspaceByID(ctx, arg.ID)
+	}
+	// UpdateWorkspaceACL uses the ActionCreate action. Only users that can create
+	// the workspace may share it, so users it is shared with cannot share it further.
+	return fetchAndExec(q.log, q.auth, policy.ActionCreate, fetch, q.db.UpdateWorkspaceACLByID)(ctx, arg)
+}
+
 func (q *querier) UpdateWorkspaceAgentConnectionByID(ctx context.Context, arg database.UpdateWorkspaceAgentConnectionByIDParams) error {
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
 		return err
@@ -3515,6 +4073,27 @@ func (q *querier) UpdateWorkspaceBuildProvisionerStateByID(ctx context.Context,
 	return q.db.UpdateWorkspaceBuildProvisionerStateByID(ctx, arg)
 }
 
+func (q *querier) UpdateWorkspaceBulkJobCompletedAtByID(ctx context.Context, arg database.UpdateWorkspaceBulkJobCompletedAtByIDParams) error {
+	if err := q.authorizeWorkspaceBulkJob(ctx, policy.ActionUpdatePersonal, arg.ID); err != nil {
+		return err
+	}
+	return q.db.UpdateWorkspaceBulkJobCompletedAtByID(ctx, arg)
+}
+
+func (q *querier) UpdateWorkspaceBulkJobHeartbeat(ctx context.Context, arg database.UpdateWorkspaceBulkJobHeartbeatParams) (database.WorkspaceBulkJob, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
+		return database.WorkspaceBulkJob{}, err
+	}
+	return q.db.UpdateWorkspaceBulkJobHeartbeat(ctx, arg)
+}
+
+func (q *querier) UpdateWorkspaceBulkJobResult(ctx context.Context, arg database.UpdateWorkspaceBulkJobResultParams) error {
+	if err := q.authorizeWorkspaceBulkJob(ctx, policy.ActionUpdatePersonal, arg.JobID); err != nil {
+		return err
+	}
+	return q.db.UpdateWorkspaceBulkJobResult(ctx, arg)
+}
+
 // Deprecated: Use SoftDeleteWorkspaceByID
 func (q *querier) UpdateWorkspaceDeletedByID(ctx context.Context, arg database.UpdateWorkspaceDeletedByIDParams) error {
 	// TODO deleteQ me, placeholder for database.Store
@@ -3539,6 +4118,18 @@ func (q *querier) UpdateWorkspaceLastUsedAt(ctx context.Context, arg database.Up
 	return update(q.log, q.auth, fetch, q.db.UpdateWorkspaceLastUsedAt)(ctx, arg)
 }
 
+func (q *querier) UpdateWorkspaceOwner(ctx context.Context, arg database.UpdateWorkspaceOwnerParams) (database.Workspace, error) {
+	workspace, err := q.db.GetWorkspaceByID(ctx, arg.ID)
+	if err != nil {
+		return database.Workspace{}, err
+	}
+	// Whether the new owner may have the workspace is checked by the caller.
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, workspace); err != nil {
+		return database.Workspace{}, err
+	}
+	return q.db.UpdateWorkspaceOwner(ctx, arg)
+}
+
 func (q *querier) UpdateWorkspaceProxy(ctx context.Context, arg database.UpdateWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	fetch := func(ctx context.Context, arg database.UpdateWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 		return q.db.GetWorkspaceProxyByID(ctx, arg.ID)
@@ -3798,6 +4389,38 @@ func (q *querier) UpsertTemplateUsageStats(ctx context.Context) error {
 	return q.db.UpsertTemplateUsageStats(ctx)
 }
 
+func (q *querier) UpsertTemporaryRoleGrant(ctx context.Context, arg database.UpsertTemporaryRoleGrantParams) (database.TemporaryRoleGrant, error) {
+	orgID, roles, err := q.convertToTemporaryGrantRoles(arg.OrganizationID, []string{arg.RoleName})
+	if err != nil {
+		return database.TemporaryRoleGrant{}, err
+	}
+	if err := q.canAssignRoles(ctx, orgID, roles, nil); err != nil {
+		return database.TemporaryRoleGrant{}, err
+	}
+	return q.db.UpsertTemporaryRoleGrant(ctx, arg)
+}
+
+func (q *querier) UpsertUserFailedLoginAttempts(ctx context.Context, arg database.UpsertUserFailedLoginAttemptsParams) (int32, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceUserObject(arg.UserID)); err != nil {
+		return 0, err
+	}
+	return q.db.UpsertUserFailedLoginAttempts(ctx, arg)
+}
+
+func (q *querier) UpsertUserNotificationPreferences(ctx context.Context, arg database.UpsertUserNotificationPreferencesParams) (int64, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdatePersonal, rbac.ResourceUserObject(arg.UserID)); err != nil {
+		return 0, err
+	}
+	return q.db.UpsertUserNotificationPreferences(ctx, arg)
+}
+
+func (q *querier) UpsertUserTOTPSecret(ctx context.Context, arg database.UpsertUserTOTPSecretParams) (database.UserTOTPSecret, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdatePersonal, rbac.ResourceUserObject(arg.UserID)); err != nil {
+		return database.UserTOTPSecret{}, err
+	}
+	return q.db.UpsertUserTOTPSecret(ctx, arg)
+}
+
 func (q *querier) UpsertWorkspaceAgentPortShare(ctx context.Context, arg database.UpsertWorkspaceAgentPortShareParams) (database.WorkspaceAgentPortShare, error) {
 	workspace, err := q.db.GetWorkspaceByID(ctx, arg.WorkspaceID)
 	if err != nil {
@@ -3852,3 +4475,12 @@ func (q *querier) GetAuthorizedUsers(ctx context.Context, arg database.GetUsersP
 	// GetUsers is authenticated.
 	return q.GetUsers(ctx, arg)
 }
+
+func (q *querier) GetFilteredUsers(ctx context.Context, arg database.GetUsersParams, filter database.UserFilter, _ rbac.PreparedAuthorized) ([]database.GetUsersRow, error) {
+	// This does the filtering in SQL.
+	prep, err := prepareSQLFilter(ctx, q.auth, policy.ActionRead, rbac.ResourceUser.Type)
+	if err != nil {
+		return nil, xerrors.Errorf("(dev error) prepare sql filter: %w", err)
+	}
+	return q.db.GetFilteredUsers(ctx, arg, filter, prep)
+}
@@ -216,6 +216,15 @@ func (s *MethodTestSuite) TestAPIKey() {
 			Asserts(a, policy.ActionRead, b, policy.ActionRead).
 			Returns(slice.New(a, b))
 	}))
+	s.Run("GetSessionAPIKeysByUserID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		a, _ := dbgen.APIKey(s.T(), db, database.APIKey{UserID: u.ID, LastUsed: time.Now().Add(-time.Minute)})
+		b, _ := dbgen.APIKey(s.T(), db, database.APIKey{UserID: u.ID, LastUsed: time.Now().Add(-time.Hour)})
+		_, _ = dbgen.APIKey(s.T(), db, database.APIKey{UserID: u.ID, LoginType: database.LoginTypeToken, TokenName: "token"})
+		check.Args(u.ID).
+			Asserts(a, policy.ActionRead, b, policy.ActionRead).
+			Returns(slice.New(a, b))
+	}))
 	s.Run("InsertAPIKey", s.Subtest(func(db database.Store, check *expects) {
 		u := dbgen.User(s.T(), db, database.User{})
 		check.Args(database.InsertAPIKeyParams{
@@ -268,6 +277,10 @@ func (s *MethodTestSuite) TestAuditLogs() {
 			LimitOpt: 10,
 		}).Asserts(rbac.ResourceAuditLog, policy.ActionRead)
 	}))
+	s.Run("DeleteOldAuditLogs", s.Subtest(func(db database.Store, check *expects) {
+		_ = dbgen.AuditLog(s.T(), db, database.AuditLog{})
+		check.Args(dbtime.Now()).Asserts(rbac.ResourceSystem, policy.ActionDelete)
+	}))
 }
 
 func (s *MethodTestSuite) TestFile() {
@@ -820,6 +833,22 @@ func (s *MethodTestSuite) TestTemplate() {
 		})
 		check.Args(tv.ID).Asserts(t1, policy.ActionRead).Returns([]database.TemplateVersionParameter{})
 	}))
+	s.Run("GetTemplateVersionPresetByID", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		tv := dbgen.TemplateVersion(s.T(), db, database.TemplateVersion{
+			TemplateID: uuid.NullUUID{UUID: t1.ID, Valid: true},
+		})
+		preset := dbgen.TemplateVersionPreset(s.T(), db, database.TemplateVersionPreset{TemplateVersionID: tv.ID})
+		check.Args(preset.ID).Asserts(t1, policy.ActionRead).Returns(preset)
+	}))
+	s.Run("GetTemplateVersionPresetsByTemplateVersionIDs", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		tv := dbgen.TemplateVersion(s.T(), db, database.TemplateVersion{
+			TemplateID: uuid.NullUUID{UUID: t1.ID, Valid: true},
+		})
+		preset := dbgen.TemplateVersionPreset(s.T(), db, database.TemplateVersionPreset{TemplateVersionID: tv.ID})
+		check.Args([]uuid.UUID{tv.ID}).Asserts(t1, policy.ActionRead).Returns([]database.TemplateVersionPreset{preset})
+	}))
 	s.Run("GetTemplateVersionVariables", s.Subtest(func(db database.Store, check *expects) {
 		t1 := dbgen.Template(s.T(), db, database.Template{})
 		tv := dbgen.TemplateVersion(s.T(), db, database.TemplateVersion{
@@ -982,6 +1011,26 @@ func (s *MethodTestSuite) TestTemplate() {
 			UpdatedAt:  tv.UpdatedAt,
 		}).Asserts(t1, policy.ActionUpdate)
 	}))
+	s.Run("InsertTemplateVersionPreset", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		tv := dbgen.TemplateVersion(s.T(), db, database.TemplateVersion{
+			TemplateID: uuid.NullUUID{UUID: t1.ID, Valid: true},
+		})
+		check.Args(database.InsertTemplateVersionPresetParams{
+			ID:                uuid.New(),
+			TemplateVersionID: tv.ID,
+			Name:              "large",
+			Parameters:        database.StringMap{"size": "large"},
+		}).Asserts(t1, policy.ActionUpdate)
+	}))
+	s.Run("DeleteTemplateVersionPresetsByTemplateVersionID", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		tv := dbgen.TemplateVersion(s.T(), db, database.TemplateVersion{
+			TemplateID: uuid.NullUUID{UUID: t1.ID, Valid: true},
+		})
+		_ = dbgen.TemplateVersionPreset(s.T(), db, database.TemplateVersionPreset{TemplateVersionID: tv.ID})
+		check.Args(tv.ID).Asserts(t1, policy.ActionUpdate).Returns()
+	}))
 	s.Run("UpdateTemplateVersionDescriptionByJobID", s.Subtest(func(db database.Store, check *expects) {
 		jobID := uuid.New()
 		t1 := dbgen.Template(s.T(), db, database.Template{})
@@ -1035,6 +1084,43 @@ func (s *MethodTestSuite) TestTemplate() {
 	s.Run("UpsertTemplateUsageStats", s.Subtest(func(db database.Store, check *expects) {
 		check.Asserts(rbac.ResourceSystem, policy.ActionUpdate)
 	}))
+	s.Run("GetTemplatePrebuildPools", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		pool := dbgen.TemplatePrebuildPool(s.T(), db, database.TemplatePrebuildPool{TemplateID: t1.ID})
+		check.Args(t1.ID).Asserts(t1, policy.ActionRead).Returns([]database.TemplatePrebuildPool{pool})
+	}))
+	s.Run("GetTemplatePrebuildPoolByID", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		pool := dbgen.TemplatePrebuildPool(s.T(), db, database.TemplatePrebuildPool{TemplateID: t1.ID})
+		check.Args(pool.ID).Asserts(t1, policy.ActionRead).Returns(pool)
+	}))
+	s.Run("InsertTemplatePrebuildPool", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		check.Args(database.InsertTemplatePrebuildPoolParams{
+			ID:               uuid.New(),
+			TemplateID:       t1.ID,
+			Name:             "default",
+			DesiredInstances: 1,
+			Parameters:       database.StringMap{},
+		}).Asserts(t1, policy.ActionUpdate)
+	}))
+	s.Run("UpdateTemplatePrebuildPoolByID", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		pool := dbgen.TemplatePrebuildPool(s.T(), db, database.TemplatePrebuildPool{TemplateID: t1.ID})
+		check.Args(database.UpdateTemplatePrebuildPoolByIDParams{
+			ID:               pool.ID,
+			DesiredInstances: 3,
+		}).Asserts(t1, policy.ActionUpdate)
+	}))
+	s.Run("DeleteTemplatePrebuildPoolByID", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		pool := dbgen.TemplatePrebuildPool(s.T(), db, database.TemplatePrebuildPool{TemplateID: t1.ID})
+		check.Args(pool.ID).Asserts(t1, policy.ActionUpdate).Returns()
+	}))
+	s.Run("GetPrebuiltWorkspaces", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		check.Args(t1.ID).Asserts(t1, policy.ActionRead).Returns([]database.GetPrebuiltWorkspacesRow{})
+	}))
 }
 
 func (s *MethodTestSuite) TestUser() {
@@ -1044,6 +1130,12 @@ func (s *MethodTestSuite) TestUser() {
 		check.Args(database.GetUsersParams{}, emptyPreparedAuthorized{}).
 			Asserts()
 	}))
+	s.Run("GetFilteredUsers", s.Subtest(func(db database.Store, check *expects) {
+		dbgen.User(s.T(), db, database.User{})
+		// No asserts because SQLFilter.
+		check.Args(database.GetUsersParams{}, emptyUserFilter{}, emptyPreparedAuthorized{}).
+			Asserts()
+	}))
 	s.Run("DeleteAPIKeysByUserID", s.Subtest(func(db database.Store, check *expects) {
 		u := dbgen.User(s.T(), db, database.User{})
 		check.Args(u.ID).Asserts(rbac.ResourceApiKey.WithOwner(u.ID.String()), policy.ActionDelete).Returns()
@@ -1176,6 +1268,149 @@ func (s *MethodTestSuite) TestUser() {
 			UpdatedAt: key.UpdatedAt,
 		}).Asserts(rbac.ResourceUserObject(key.UserID), policy.ActionUpdatePersonal).Returns(key)
 	}))
+	s.Run("GetUserTOTPSecret", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		secret, err := db.UpsertUserTOTPSecret(context.Background(), database.UpsertUserTOTPSecretParams{
+			UserID:    u.ID,
+			Secret:    "secret",
+			CreatedAt: dbtime.Now(),
+		})
+		require.NoError(s.T(), err)
+		check.Args(u.ID).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionReadPersonal).Returns(secret)
+	}))
+	s.Run("UpsertUserTOTPSecret", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.UpsertUserTOTPSecretParams{
+			UserID:    u.ID,
+			Secret:    "secret",
+			CreatedAt: dbtime.Now(),
+		}).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionUpdatePersonal)
+	}))
+	s.Run("UpdateUserTOTPSecretConfirmed", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		_, err := db.UpsertUserTOTPSecret(context.Background(), database.UpsertUserTOTPSecretParams{
+			UserID:    u.ID,
+			Secret:    "secret",
+			CreatedAt: dbtime.Now(),
+		})
+		require.NoError(s.T(), err)
+		check.Args(database.UpdateUserTOTPSecretConfirmedParams{
+			UserID:              u.ID,
+			ConfirmedAt:         sql.NullTime{Time: dbtime.Now(), Valid: true},
+			HashedRecoveryCodes: []string{"code"},
+			LastUsedCounter:     1,
+		}).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionUpdatePersonal)
+	}))
+	s.Run("UpdateUserTOTPSecretLastUsedCounter", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		_, err := db.UpsertUserTOTPSecret(context.Background(), database.UpsertUserTOTPSecretParams{
+			UserID:    u.ID,
+			Secret:    "secret",
+			CreatedAt: dbtime.Now(),
+		})
+		require.NoError(s.T(), err)
+		check.Args(database.UpdateUserTOTPSecretLastUsedCounterParams{
+			UserID:          u.ID,
+			LastUsedCounter: 1,
+		}).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionUpdatePersonal).Returns(int64(1))
+	}))
+	s.Run("DeleteUserTOTPRecoveryCode", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.DeleteUserTOTPRecoveryCodeParams{
+			UserID:             u.ID,
+			HashedRecoveryCode: "code",
+		}).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionUpdatePersonal).Returns(int64(0))
+	}))
+	s.Run("DeleteUserTOTPSecret", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(u.ID).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionUpdatePersonal).Returns()
+	}))
+	s.Run("GetUserPasswordHistory", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		entry := database.UserPasswordHistory{
+			ID:             uuid.New(),
+			UserID:         u.ID,
+			HashedPassword: []byte("hash"),
+			CreatedAt:      dbtime.Now(),
+		}
+		err := db.InsertUserPasswordHistory(context.Background(), database.InsertUserPasswordHistoryParams(entry))
+		require.NoError(s.T(), err)
+		check.Args(database.GetUserPasswordHistoryParams{
+			UserID: u.ID,
+		}).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionReadPersonal).Returns([]database.UserPasswordHistory{entry})
+	}))
+	s.Run("InsertUserPasswordHistory", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.InsertUserPasswordHistoryParams{
+			ID:             uuid.New(),
+			UserID:         u.ID,
+			HashedPassword: []byte("hash"),
+			CreatedAt:      dbtime.Now(),
+		}).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionUpdatePersonal).Returns()
+	}))
+	s.Run("DeleteOldUserPasswordHistory", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.DeleteOldUserPasswordHistoryParams{
+			UserID: u.ID,
+			Keep:   1,
+		}).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionUpdatePersonal).Returns()
+	}))
+	s.Run("UpsertUserFailedLoginAttempts", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.UpsertUserFailedLoginAttemptsParams{
+			UserID:       u.ID,
+			LastFailedAt: dbtime.Now(),
+		}).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionUpdate).Returns(int32(1))
+	}))
+	s.Run("DeleteUserLoginAttempts", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(u.ID).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionUpdate).Returns()
+	}))
+	s.Run("GetUserLoginAttempts", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		_, err := db.UpsertUserFailedLoginAttempts(context.Background(), database.UpsertUserFailedLoginAttemptsParams{
+			UserID:       u.ID,
+			LastFailedAt: dbtime.Now(),
+		})
+		require.NoError(s.T(), err)
+		attempts, err := db.GetUserLoginAttempts(context.Background(), u.ID)
+		require.NoError(s.T(), err)
+		check.Args(u.ID).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionRead).Returns(attempts)
+	}))
+	s.Run("GetTemporaryRoleGrantsByUserID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		grant := dbgen.TemporaryRoleGrant(s.T(), db, database.TemporaryRoleGrant{UserID: u.ID})
+		check.Args(u.ID).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionReadPersonal).Returns([]database.TemporaryRoleGrant{grant})
+	}))
+	s.Run("UpsertTemporaryRoleGrant", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.UpsertTemporaryRoleGrantParams{
+			ID:        uuid.New(),
+			UserID:    u.ID,
+			RoleName:  codersdk.RoleTemplateAdmin,
+			CreatedAt: dbtime.Now(),
+			ExpiresAt: dbtime.Now().Add(time.Hour),
+		}).Asserts(rbac.ResourceAssignRole, policy.ActionAssign)
+	}))
+	s.Run("Org/UpsertTemporaryRoleGrant", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.Organization(s.T(), db, database.Organization{})
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.UpsertTemporaryRoleGrantParams{
+			ID:             uuid.New(),
+			UserID:         u.ID,
+			OrganizationID: uuid.NullUUID{UUID: o.ID, Valid: true},
+			RoleName:       codersdk.RoleOrganizationAdmin,
+			CreatedAt:      dbtime.Now(),
+			ExpiresAt:      dbtime.Now().Add(time.Hour),
+		}).Asserts(rbac.ResourceAssignOrgRole.InOrg(o.ID), policy.ActionAssign)
+	}))
+	s.Run("DeleteTemporaryRoleGrants", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.DeleteTemporaryRoleGrantsParams{
+			UserID:    u.ID,
+			RoleNames: []string{codersdk.RoleTemplateAdmin},
+		}).Asserts(rbac.ResourceAssignRole, policy.ActionAssign).Returns()
+	}))
 	s.Run("GetExternalAuthLink", s.Subtest(func(db database.Store, check *expects) {
 		link := dbgen.ExternalAuthLink(s.T(), db, database.ExternalAuthLink{})
 		check.Args(database.GetExternalAuthLinkParams{
@@ -1296,6 +1531,17 @@ func (s *MethodTestSuite) TestUser() {
 }
 
 func (s *MethodTestSuite) TestWorkspace() {
+	s.Run("ClaimPrebuiltWorkspace", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		o := dbgen.Organization(s.T(), db, database.Organization{})
+		check.Args(database.ClaimPrebuiltWorkspaceParams{
+			PoolID:           uuid.New(),
+			OrganizationID:   o.ID,
+			NewOwnerID:       u.ID,
+			NewName:          "claimed",
+			AutomaticUpdates: database.AutomaticUpdatesNever,
+		}).Asserts(rbac.ResourceWorkspace.WithOwner(u.ID.String()).InOrg(o.ID), policy.ActionCreate).Errors(sql.ErrNoRows)
+	}))
 	s.Run("GetWorkspaceByID", s.Subtest(func(db database.Store, check *expects) {
 		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
 		check.Args(ws.ID).Asserts(ws, policy.ActionRead)
@@ -1704,6 +1950,23 @@ func (s *MethodTestSuite) TestWorkspace() {
 			ID: ws.ID,
 		}).Asserts(ws, policy.ActionUpdate).Returns()
 	}))
+	s.Run("UpdateWorkspaceOwner", s.Subtest(func(db database.Store, check *expects) {
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
+		u := dbgen.User(s.T(), db, database.User{})
+		transferred := ws
+		transferred.OwnerID = u.ID
+		check.Args(database.UpdateWorkspaceOwnerParams{
+			ID:        ws.ID,
+			OwnerID:   u.ID,
+			UpdatedAt: ws.UpdatedAt,
+		}).Asserts(ws, policy.ActionUpdate).Returns(transferred)
+	}))
+	s.Run("UpdateWorkspaceACLByID", s.Subtest(func(db database.Store, check *expects) {
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
+		check.Args(database.UpdateWorkspaceACLByIDParams{
+			ID: ws.ID,
+		}).Asserts(ws, policy.ActionCreate)
+	}))
 	s.Run("BatchUpdateWorkspaceLastUsedAt", s.Subtest(func(db database.Store, check *expects) {
 		ws1 := dbgen.Workspace(s.T(), db, database.Workspace{})
 		ws2 := dbgen.Workspace(s.T(), db, database.Workspace{})
@@ -1743,6 +2006,88 @@ func (s *MethodTestSuite) TestWorkspace() {
 		ws := dbgen.Workspace(s.T(), db, database.Workspace{OwnerID: u.ID})
 		check.Args(ws.ID).Asserts(ws, policy.ActionUpdate).Returns()
 	}))
+	s.Run("InsertWorkspaceBulkJob", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.InsertWorkspaceBulkJobParams{
+			ID:               uuid.New(),
+			InitiatorID:      u.ID,
+			InitiatorScope:   database.APIKeyScopeAll,
+			Action:           database.WorkspaceBulkActionStop,
+			AutomaticUpdates: database.AutomaticUpdatesNever,
+			Concurrency:      1,
+			CreatedAt:        dbtime.Now(),
+		}).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionUpdatePersonal)
+	}))
+	s.Run("GetWorkspaceBulkJobByID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		job := dbgen.WorkspaceBulkJob(s.T(), db, database.WorkspaceBulkJob{InitiatorID: u.ID})
+		check.Args(job.ID).Asserts(job, policy.ActionReadPersonal).Returns(job)
+	}))
+	s.Run("InsertWorkspaceBulkJobResults", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{OwnerID: u.ID})
+		job := dbgen.WorkspaceBulkJob(s.T(), db, database.WorkspaceBulkJob{InitiatorID: u.ID})
+		check.Args(database.InsertWorkspaceBulkJobResultsParams{
+			JobID:        job.ID,
+			WorkspaceIds: []uuid.UUID{ws.ID},
+			UpdatedAt:    dbtime.Now(),
+		}).Asserts(job, policy.ActionUpdatePersonal)
+	}))
+	s.Run("GetWorkspaceBulkJobResultsByJobID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		job := dbgen.WorkspaceBulkJob(s.T(), db, database.WorkspaceBulkJob{InitiatorID: u.ID})
+		check.Args(job.ID).Asserts(job, policy.ActionReadPersonal)
+	}))
+	s.Run("UpdateWorkspaceBulkJobResult", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{OwnerID: u.ID})
+		job := dbgen.WorkspaceBulkJob(s.T(), db, database.WorkspaceBulkJob{InitiatorID: u.ID})
+		err := db.InsertWorkspaceBulkJobResults(context.Background(), database.InsertWorkspaceBulkJobResultsParams{
+			JobID:        job.ID,
+			WorkspaceIds: []uuid.UUID{ws.ID},
+			UpdatedAt:    dbtime.Now(),
+		})
+		require.NoError(s.T(), err)
+		check.Args(database.UpdateWorkspaceBulkJobResultParams{
+			JobID:       job.ID,
+			WorkspaceID: ws.ID,
+			Status:      database.WorkspaceBulkResultStatusSucceeded,
+			UpdatedAt:   dbtime.Now(),
+		}).Asserts(job, policy.ActionUpdatePersonal)
+	}))
+	s.Run("UpdateWorkspaceBulkJobCompletedAtByID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		job := dbgen.WorkspaceBulkJob(s.T(), db, database.WorkspaceBulkJob{InitiatorID: u.ID})
+		check.Args(database.UpdateWorkspaceBulkJobCompletedAtByIDParams{
+			ID:          job.ID,
+			CompletedAt: sql.NullTime{Time: dbtime.Now(), Valid: true},
+		}).Asserts(job, policy.ActionUpdatePersonal)
+	}))
+	s.Run("AcquireWorkspaceBulkJob", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		_ = dbgen.WorkspaceBulkJob(s.T(), db, database.WorkspaceBulkJob{InitiatorID: u.ID})
+		check.Args(database.AcquireWorkspaceBulkJobParams{
+			WorkerID:    uuid.New(),
+			HeartbeatAt: dbtime.Now(),
+			StaleBefore: dbtime.Now(),
+		}).Asserts(rbac.ResourceSystem, policy.ActionUpdate)
+	}))
+	s.Run("UpdateWorkspaceBulkJobHeartbeat", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		_ = dbgen.WorkspaceBulkJob(s.T(), db, database.WorkspaceBulkJob{InitiatorID: u.ID})
+		workerID := uuid.New()
+		job, err := db.AcquireWorkspaceBulkJob(context.Background(), database.AcquireWorkspaceBulkJobParams{
+			WorkerID:    workerID,
+			HeartbeatAt: dbtime.Now(),
+			StaleBefore: dbtime.Now(),
+		})
+		require.NoError(s.T(), err)
+		check.Args(database.UpdateWorkspaceBulkJobHeartbeatParams{
+			HeartbeatAt: dbtime.Now(),
+			ID:          job.ID,
+			WorkerID:    workerID,
+		}).Asserts(rbac.ResourceSystem, policy.ActionUpdate)
+	}))
 }
 
 func (s *MethodTestSuite) TestWorkspacePortSharing() {
@@ -2195,6 +2540,31 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 	s.Run("DeleteOldWorkspaceAgentStats", s.Subtest(func(db database.Store, check *expects) {
 		check.Args().Asserts(rbac.ResourceSystem, policy.ActionDelete)
 	}))
+	s.Run("InsertPrebuiltWorkspace", s.Subtest(func(db database.Store, check *expects) {
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
+		check.Args(database.InsertPrebuiltWorkspaceParams{
+			WorkspaceID: ws.ID,
+		}).Asserts(rbac.ResourceSystem, policy.ActionCreate)
+	}))
+	s.Run("System/GetPrebuiltWorkspaces", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(uuid.Nil).Asserts(rbac.ResourceSystem, policy.ActionRead).Returns([]database.GetPrebuiltWorkspacesRow{})
+	}))
+	s.Run("System/GetTemplatePrebuildPools", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(uuid.Nil).Asserts(rbac.ResourceSystem, policy.ActionRead).Returns([]database.TemplatePrebuildPool{})
+	}))
+	s.Run("GetExpiredTemporaryRoleGrants", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		grant := dbgen.TemporaryRoleGrant(s.T(), db, database.TemporaryRoleGrant{
+			UserID:    u.ID,
+			ExpiresAt: dbtime.Now().Add(-time.Minute),
+		})
+		check.Args(dbtime.Now()).Asserts(rbac.ResourceSystem, policy.ActionRead).Returns([]database.TemporaryRoleGrant{grant})
+	}))
+	s.Run("DeleteTemporaryRoleGrantByID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		grant := dbgen.TemporaryRoleGrant(s.T(), db, database.TemporaryRoleGrant{UserID: u.ID})
+		check.Args(grant.ID).Asserts(rbac.ResourceSystem, policy.ActionDelete).Returns()
+	}))
 	s.Run("GetProvisionerJobsCreatedAfter", s.Subtest(func(db database.Store, check *expects) {
 		// TODO: add provisioner job resource type
 		_ = dbgen.ProvisionerJob(s.T(), db, nil, database.ProvisionerJob{CreatedAt: time.Now().Add(-time.Hour)})
@@ -2583,6 +2953,129 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 			Limit:  10,
 		}).Asserts(rbac.ResourceSystem, policy.ActionRead)
 	}))
+	s.Run("GetUserNotificationPreference", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		templateID := uuid.New()
+		_, err := db.UpsertUserNotificationPreferences(context.Background(), database.UpsertUserNotificationPreferencesParams{
+			UserID:                  u.ID,
+			NotificationTemplateIDs: []uuid.UUID{templateID},
+			Disabled:                []bool{true},
+			Methods:                 []string{""},
+		})
+		require.NoError(s.T(), err)
+		pref, err := db.GetUserNotificationPreference(context.Background(), database.GetUserNotificationPreferenceParams{
+			UserID:                 u.ID,
+			NotificationTemplateID: templateID,
+		})
+		require.NoError(s.T(), err)
+		check.Args(database.GetUserNotificationPreferenceParams{
+			UserID:                 u.ID,
+			NotificationTemplateID: templateID,
+		}).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionReadPersonal).Returns(pref)
+	}))
+	s.Run("GetUserNotificationPreferences", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(u.ID).
+			Asserts(rbac.ResourceUserObject(u.ID), policy.ActionReadPersonal).
+			Errors(dbmem.ErrUnimplemented)
+	}))
+	s.Run("UpsertUserNotificationPreferences", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.UpsertUserNotificationPreferencesParams{
+			UserID:                  u.ID,
+			NotificationTemplateIDs: []uuid.UUID{uuid.New()},
+			Disabled:                []bool{false},
+			Methods:                 []string{string(database.NotificationMethodWebhook)},
+		}).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionUpdatePersonal).Returns(int64(1))
+	}))
+
+	// Templates
+	s.Run("GetNotificationTemplates", s.Subtest(func(db database.Store, check *expects) {
+		check.Args().
+			Asserts(rbac.ResourceDeploymentConfig, policy.ActionRead).
+			Errors(dbmem.ErrUnimplemented)
+	}))
+	s.Run("GetNotificationTemplateByID", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(uuid.New()).
+			Asserts(rbac.ResourceDeploymentConfig, policy.ActionRead).
+			Errors(dbmem.ErrUnimplemented)
+	}))
+	s.Run("UpdateNotificationTemplateByID", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.UpdateNotificationTemplateByIDParams{
+			ID:            uuid.New(),
+			TitleTemplate: "title",
+			BodyTemplate:  "body",
+		}).Asserts(rbac.ResourceDeploymentConfig, policy.ActionUpdate).Errors(dbmem.ErrUnimplemented)
+	}))
+	s.Run("UpdateNotificationTemplateDigestWindowByID", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.UpdateNotificationTemplateDigestWindowByIDParams{
+			ID:                  uuid.New(),
+			DigestWindowSeconds: 3600,
+		}).Asserts(rbac.ResourceDeploymentConfig, policy.ActionUpdate).Errors(dbmem.ErrUnimplemented)
+	}))
+	s.Run("ResetNotificationTemplateByID", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(uuid.New()).
+			Asserts(rbac.ResourceDeploymentConfig, policy.ActionUpdate).
+			Errors(dbmem.ErrUnimplemented)
+	}))
+
+	// Inbox
+	insertInboxNotification := func(db database.Store, userID uuid.UUID) database.InboxNotification {
+		id := uuid.New()
+		err := db.InsertInboxNotification(context.Background(), database.InsertInboxNotificationParams{
+			ID:        id,
+			UserID:    userID,
+			Title:     "title",
+			Content:   "content",
+			Actions:   json.RawMessage("[]"),
+			CreatedAt: dbtime.Now(),
+		})
+		require.NoError(s.T(), err)
+		notif, err := db.GetInboxNotificationByID(context.Background(), id)
+		require.NoError(s.T(), err)
+		return notif
+	}
+	s.Run("InsertInboxNotification", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.InsertInboxNotificationParams{
+			ID:        uuid.New(),
+			UserID:    u.ID,
+			Actions:   json.RawMessage("[]"),
+			CreatedAt: dbtime.Now(),
+		}).Asserts(rbac.ResourceSystem, policy.ActionCreate)
+	}))
+	s.Run("GetInboxNotificationByID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		notif := insertInboxNotification(db, u.ID)
+		check.Args(notif.ID).Asserts(notif, policy.ActionReadPersonal).Returns(notif)
+	}))
+	s.Run("GetInboxNotificationsByUserID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		notif := insertInboxNotification(db, u.ID)
+		check.Args(database.GetInboxNotificationsByUserIDParams{
+			UserID: u.ID,
+		}).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionReadPersonal).Returns([]database.InboxNotification{notif})
+	}))
+	s.Run("CountUnreadInboxNotificationsByUserID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		_ = insertInboxNotification(db, u.ID)
+		check.Args(u.ID).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionReadPersonal).Returns(int64(1))
+	}))
+	s.Run("UpdateInboxNotificationReadStatus", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		notif := insertInboxNotification(db, u.ID)
+		check.Args(database.UpdateInboxNotificationReadStatusParams{
+			ID: notif.ID,
+		}).Asserts(notif, policy.ActionUpdatePersonal).Returns(notif)
+	}))
+	s.Run("MarkAllInboxNotificationsAsRead", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		_ = insertInboxNotification(db, u.ID)
+		check.Args(database.MarkAllInboxNotificationsAsReadParams{
+			ReadAt: dbtime.Now(),
+			UserID: u.ID,
+		}).Asserts(rbac.ResourceUserObject(u.ID), policy.ActionUpdatePersonal).Returns(int64(1))
+	}))
 }
 
 func (s *MethodTestSuite) TestOAuth2ProviderApps() {
@@ -2609,8 +3102,9 @@ func (s *MethodTestSuite) TestOAuth2ProviderApps() {
 		})
 		for i := 0; i < 5; i++ {
 			_ = dbgen.OAuth2ProviderAppToken(s.T(), db, database.OAuth2ProviderAppToken{
-				AppSecretID: secret.ID,
+				AppSecretID: uuid.NullUUID{UUID: secret.ID, Valid: true},
 				APIKeyID:    key.ID,
+				AppID:       app.ID,
 			})
 		}
 		check.Args(user.ID).Asserts(rbac.ResourceOauth2AppCodeToken.WithOwner(user.ID.String()), policy.ActionRead).Returns([]database.GetOAuth2ProviderAppsByUserIDRow{
@@ -2755,6 +3249,62 @@ func (s *MethodTestSuite) TestOAuth2ProviderAppCodes() {
 	}))
 }
 
+func (s *MethodTestSuite) TestOAuth2ProviderAppDeviceCodes() {
+	s.Run("GetOAuth2ProviderAppDeviceCodeByID", s.Subtest(func(db database.Store, check *expects) {
+		app := dbgen.OAuth2ProviderApp(s.T(), db, database.OAuth2ProviderApp{})
+		code := dbgen.OAuth2ProviderAppDeviceCode(s.T(), db, database.OAuth2ProviderAppDeviceCode{
+			AppID: app.ID,
+		})
+		check.Args(code.ID).Asserts(code, policy.ActionRead).Returns(code)
+	}))
+	s.Run("GetOAuth2ProviderAppDeviceCodeByPrefix", s.Subtest(func(db database.Store, check *expects) {
+		app := dbgen.OAuth2ProviderApp(s.T(), db, database.OAuth2ProviderApp{})
+		code := dbgen.OAuth2ProviderAppDeviceCode(s.T(), db, database.OAuth2ProviderAppDeviceCode{
+			AppID: app.ID,
+		})
+		check.Args(code.SecretPrefix).Asserts(code, policy.ActionRead).Returns(code)
+	}))
+	s.Run("GetOAuth2ProviderAppDeviceCodeByUserCode", s.Subtest(func(db database.Store, check *expects) {
+		app := dbgen.OAuth2ProviderApp(s.T(), db, database.OAuth2ProviderApp{})
+		code := dbgen.OAuth2ProviderAppDeviceCode(s.T(), db, database.OAuth2ProviderAppDeviceCode{
+			AppID: app.ID,
+		})
+		check.Args(code.UserCode).Asserts(code, policy.ActionRead).Returns(code)
+	}))
+	s.Run("InsertOAuth2ProviderAppDeviceCode", s.Subtest(func(db database.Store, check *expects) {
+		app := dbgen.OAuth2ProviderApp(s.T(), db, database.OAuth2ProviderApp{})
+		check.Args(database.InsertOAuth2ProviderAppDeviceCodeParams{
+			AppID: app.ID,
+		}).Asserts(rbac.ResourceSystem, policy.ActionCreate)
+	}))
+	s.Run("UpdateOAuth2ProviderAppDeviceCodeByID", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		app := dbgen.OAuth2ProviderApp(s.T(), db, database.OAuth2ProviderApp{})
+		code := dbgen.OAuth2ProviderAppDeviceCode(s.T(), db, database.OAuth2ProviderAppDeviceCode{
+			AppID: app.ID,
+		})
+		code.UserID = uuid.NullUUID{UUID: user.ID, Valid: true}
+		check.Args(database.UpdateOAuth2ProviderAppDeviceCodeByIDParams{
+			ID:     code.ID,
+			UserID: code.UserID,
+		}).Asserts(rbac.ResourceOauth2AppCodeToken.WithOwner(user.ID.String()), policy.ActionCreate).Returns(code)
+	}))
+	s.Run("DeleteOAuth2ProviderAppDeviceCodeByID", s.Subtest(func(db database.Store, check *expects) {
+		app := dbgen.OAuth2ProviderApp(s.T(), db, database.OAuth2ProviderApp{})
+		code := dbgen.OAuth2ProviderAppDeviceCode(s.T(), db, database.OAuth2ProviderAppDeviceCode{
+			AppID: app.ID,
+		})
+		check.Args(code.ID).Asserts(code, policy.ActionDelete)
+	}))
+	s.Run("DeleteExpiredOAuth2ProviderAppDeviceCodes", s.Subtest(func(db database.Store, check *expects) {
+		app := dbgen.OAuth2ProviderApp(s.T(), db, database.OAuth2ProviderApp{})
+		_ = dbgen.OAuth2ProviderAppDeviceCode(s.T(), db, database.OAuth2ProviderAppDeviceCode{
+			AppID: app.ID,
+		})
+		check.Args(dbtime.Now()).Asserts(rbac.ResourceSystem, policy.ActionDelete)
+	}))
+}
+
 func (s *MethodTestSuite) TestOAuth2ProviderAppTokens() {
 	s.Run("InsertOAuth2ProviderAppToken", s.Subtest(func(db database.Store, check *expects) {
 		user := dbgen.User(s.T(), db, database.User{})
@@ -2766,8 +3316,9 @@ func (s *MethodTestSuite) TestOAuth2ProviderAppTokens() {
 			AppID: app.ID,
 		})
 		check.Args(database.InsertOAuth2ProviderAppTokenParams{
-			AppSecretID: secret.ID,
+			AppSecretID: uuid.NullUUID{UUID: secret.ID, Valid: true},
 			APIKeyID:    key.ID,
+			AppID:       app.ID,
 		}).Asserts(rbac.ResourceOauth2AppCodeToken.WithOwner(user.ID.String()), policy.ActionCreate)
 	}))
 	s.Run("GetOAuth2ProviderAppTokenByPrefix", s.Subtest(func(db database.Store, check *expects) {
@@ -2780,11 +3331,24 @@ func (s *MethodTestSuite) TestOAuth2ProviderAppTokens() {
 			AppID: app.ID,
 		})
 		token := dbgen.OAuth2ProviderAppToken(s.T(), db, database.OAuth2ProviderAppToken{
-			AppSecretID: secret.ID,
+			AppSecretID: uuid.NullUUID{UUID: secret.ID, Valid: true},
 			APIKeyID:    key.ID,
+			AppID:       app.ID,
 		})
 		check.Args(token.HashPrefix).Asserts(rbac.ResourceOauth2AppCodeToken.WithOwner(user.ID.String()), policy.ActionRead)
 	}))
+	s.Run("GetOAuth2ProviderAppTokenByAPIKeyID", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		key, _ := dbgen.APIKey(s.T(), db, database.APIKey{
+			UserID: user.ID,
+		})
+		app := dbgen.OAuth2ProviderApp(s.T(), db, database.OAuth2ProviderApp{})
+		token := dbgen.OAuth2ProviderAppToken(s.T(), db, database.OAuth2ProviderAppToken{
+			APIKeyID: key.ID,
+			AppID:    app.ID,
+		})
+		check.Args(key.ID).Asserts(rbac.ResourceOauth2AppCodeToken.WithOwner(user.ID.String()), policy.ActionRead).Returns(token)
+	}))
 	s.Run("DeleteOAuth2ProviderAppTokensByAppAndUserID", s.Subtest(func(db database.Store, check *expects) {
 		user := dbgen.User(s.T(), db, database.User{})
 		key, _ := dbgen.APIKey(s.T(), db, database.APIKey{
@@ -2796,8 +3360,9 @@ func (s *MethodTestSuite) TestOAuth2ProviderAppTokens() {
 		})
 		for i := 0; i < 5; i++ {
 			_ = dbgen.OAuth2ProviderAppToken(s.T(), db, database.OAuth2ProviderAppToken{
-				AppSecretID: secret.ID,
+				AppSecretID: uuid.NullUUID{UUID: secret.ID, Valid: true},
 				APIKeyID:    key.ID,
+				AppID:       app.ID,
 			})
 		}
 		check.Args(database.DeleteOAuth2ProviderAppTokensByAppAndUserIDParams{
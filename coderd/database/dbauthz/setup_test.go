@@ -450,3 +450,8 @@ func (emptyPreparedAuthorized) Authorize(_ context.Context, _ rbac.Object) error
 func (emptyPreparedAuthorized) CompileToSQL(_ context.Context, _ regosql.ConvertConfig) (string, error) {
 	return "", nil
 }
+
+type emptyUserFilter struct{}
+
+func (emptyUserFilter) CompileToSQL(_ int) (string, []any) { return "TRUE", nil }
+func (emptyUserFilter) Match(_ database.User) bool         { return true }
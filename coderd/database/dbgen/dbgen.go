@@ -131,6 +131,8 @@ func APIKey(t testing.TB, db database.Store, seed database.APIKey) (key database
 		LoginType:       takeFirst(seed.LoginType, database.LoginTypePassword),
 		Scope:           takeFirst(seed.Scope, database.APIKeyScopeAll),
 		TokenName:       takeFirst(seed.TokenName),
+		Scopes:          seed.Scopes,
+		UserAgent:       seed.UserAgent,
 	})
 	require.NoError(t, err, "insert api key")
 	return key, fmt.Sprintf("%s-%s", key.ID, secret)
@@ -286,15 +288,16 @@ func WorkspaceBuildParameters(t testing.TB, db database.Store, orig []database.W
 
 func User(t testing.TB, db database.Store, orig database.User) database.User {
 	user, err := db.InsertUser(genCtx, database.InsertUserParams{
-		ID:             takeFirst(orig.ID, uuid.New()),
-		Email:          takeFirst(orig.Email, namesgenerator.GetRandomName(1)),
-		Username:       takeFirst(orig.Username, namesgenerator.GetRandomName(1)),
-		Name:           takeFirst(orig.Name, namesgenerator.GetRandomName(1)),
-		HashedPassword: takeFirstSlice(orig.HashedPassword, []byte(must(cryptorand.String(32)))),
-		CreatedAt:      takeFirst(orig.CreatedAt, dbtime.Now()),
-		UpdatedAt:      takeFirst(orig.UpdatedAt, dbtime.Now()),
-		RBACRoles:      takeFirstSlice(orig.RBACRoles, []string{}),
-		LoginType:      takeFirst(orig.LoginType, database.LoginTypePassword),
+		ID:               takeFirst(orig.ID, uuid.New()),
+		Email:            takeFirst(orig.Email, namesgenerator.GetRandomName(1)),
+		Username:         takeFirst(orig.Username, namesgenerator.GetRandomName(1)),
+		Name:             takeFirst(orig.Name, namesgenerator.GetRandomName(1)),
+		HashedPassword:   takeFirstSlice(orig.HashedPassword, []byte(must(cryptorand.String(32)))),
+		CreatedAt:        takeFirst(orig.CreatedAt, dbtime.Now()),
+		UpdatedAt:        takeFirst(orig.UpdatedAt, dbtime.Now()),
+		RBACRoles:        takeFirstSlice(orig.RBACRoles, []string{}),
+		LoginType:        takeFirst(orig.LoginType, database.LoginTypePassword),
+		IsServiceAccount: orig.IsServiceAccount,
 	})
 	require.NoError(t, err, "insert user")
 
@@ -788,6 +791,8 @@ func OAuth2ProviderApp(t testing.TB, db database.Store, seed database.OAuth2Prov
 		UpdatedAt:   takeFirst(seed.UpdatedAt, dbtime.Now()),
 		Icon:        takeFirst(seed.Icon, ""),
 		CallbackURL: takeFirst(seed.CallbackURL, "http://localhost"),
+		// Only set when the test needs the client credentials grant.
+		ServiceAccountID: seed.ServiceAccountID,
 	})
 	require.NoError(t, err, "insert oauth2 app")
 	return app
@@ -815,11 +820,28 @@ func OAuth2ProviderAppCode(t testing.TB, db database.Store, seed database.OAuth2
 		HashedSecret: takeFirstSlice(seed.HashedSecret, []byte("hashed-secret")),
 		AppID:        takeFirst(seed.AppID, uuid.New()),
 		UserID:       takeFirst(seed.UserID, uuid.New()),
+		// PKCE is optional for confidential clients.
+		CodeChallenge:       seed.CodeChallenge,
+		CodeChallengeMethod: seed.CodeChallengeMethod,
 	})
 	require.NoError(t, err, "insert oauth2 app code")
 	return code
 }
 
+func OAuth2ProviderAppDeviceCode(t testing.TB, db database.Store, seed database.OAuth2ProviderAppDeviceCode) database.OAuth2ProviderAppDeviceCode {
+	code, err := db.InsertOAuth2ProviderAppDeviceCode(genCtx, database.InsertOAuth2ProviderAppDeviceCodeParams{
+		ID:           takeFirst(seed.ID, uuid.New()),
+		CreatedAt:    takeFirst(seed.CreatedAt, dbtime.Now()),
+		ExpiresAt:    takeFirst(seed.ExpiresAt, dbtime.Now().Add(10*time.Minute)),
+		SecretPrefix: takeFirstSlice(seed.SecretPrefix, []byte("prefix")),
+		HashedSecret: takeFirstSlice(seed.HashedSecret, []byte("hashed-secret")),
+		UserCode:     takeFirst(seed.UserCode, "BCDFGHJK"),
+		AppID:        takeFirst(seed.AppID, uuid.New()),
+	})
+	require.NoError(t, err, "insert oauth2 app device code")
+	return code
+}
+
 func OAuth2ProviderAppToken(t testing.TB, db database.Store, seed database.OAuth2ProviderAppToken) database.OAuth2ProviderAppToken {
 	token, err := db.InsertOAuth2ProviderAppToken(genCtx, database.InsertOAuth2ProviderAppTokenParams{
 		ID:          takeFirst(seed.ID, uuid.New()),
@@ -827,8 +849,9 @@ func OAuth2ProviderAppToken(t testing.TB, db database.Store, seed database.OAuth
 		ExpiresAt:   takeFirst(seed.CreatedAt, dbtime.Now()),
 		HashPrefix:  takeFirstSlice(seed.HashPrefix, []byte("prefix")),
 		RefreshHash: takeFirstSlice(seed.RefreshHash, []byte("hashed-secret")),
-		AppSecretID: takeFirst(seed.AppSecretID, uuid.New()),
+		AppSecretID: seed.AppSecretID,
 		APIKeyID:    takeFirst(seed.APIKeyID, uuid.New().String()),
+		AppID:       takeFirst(seed.AppID, uuid.New()),
 	})
 	require.NoError(t, err, "insert oauth2 app token")
 	return token
@@ -847,6 +870,70 @@ func CustomRole(t testing.TB, db database.Store, seed database.CustomRole) datab
 	return role
 }
 
+func TemporaryRoleGrant(t testing.TB, db database.Store, seed database.TemporaryRoleGrant) database.TemporaryRoleGrant {
+	grant, err := db.UpsertTemporaryRoleGrant(genCtx, database.UpsertTemporaryRoleGrantParams{
+		ID:             takeFirst(seed.ID, uuid.New()),
+		UserID:         takeFirst(seed.UserID, uuid.New()),
+		OrganizationID: seed.OrganizationID,
+		RoleName:       takeFirst(seed.RoleName, rbac.RoleTemplateAdmin().Name),
+		Justification:  seed.Justification,
+		CreatedAt:      takeFirst(seed.CreatedAt, dbtime.Now()),
+		ExpiresAt:      takeFirst(seed.ExpiresAt, dbtime.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err, "insert temporary role grant")
+	return grant
+}
+
+func TemplatePrebuildPool(t testing.TB, db database.Store, seed database.TemplatePrebuildPool) database.TemplatePrebuildPool {
+	if seed.Parameters == nil {
+		seed.Parameters = database.StringMap{}
+	}
+	pool, err := db.InsertTemplatePrebuildPool(genCtx, database.InsertTemplatePrebuildPoolParams{
+		ID:               takeFirst(seed.ID, uuid.New()),
+		TemplateID:       takeFirst(seed.TemplateID, uuid.New()),
+		Name:             takeFirst(seed.Name, namesgenerator.GetRandomName(1)),
+		DesiredInstances: takeFirst(seed.DesiredInstances, 1),
+		Parameters:       seed.Parameters,
+		CreatedAt:        takeFirst(seed.CreatedAt, dbtime.Now()),
+		UpdatedAt:        takeFirst(seed.UpdatedAt, dbtime.Now()),
+	})
+	require.NoError(t, err, "insert template prebuild pool")
+	return pool
+}
+
+func TemplateVersionPreset(t testing.TB, db database.Store, seed database.TemplateVersionPreset) database.TemplateVersionPreset {
+	if seed.Parameters == nil {
+		seed.Parameters = database.StringMap{}
+	}
+	preset, err := db.InsertTemplateVersionPreset(genCtx, database.InsertTemplateVersionPresetParams{
+		ID:                takeFirst(seed.ID, uuid.New()),
+		TemplateVersionID: takeFirst(seed.TemplateVersionID, uuid.New()),
+		Name:              takeFirst(seed.Name, namesgenerator.GetRandomName(1)),
+		Parameters:        seed.Parameters,
+		CreatedAt:         takeFirst(seed.CreatedAt, dbtime.Now()),
+	})
+	require.NoError(t, err, "insert template version preset")
+	return preset
+}
+
+func WorkspaceBulkJob(t testing.TB, db database.Store, seed database.WorkspaceBulkJob) database.WorkspaceBulkJob {
+	job, err := db.InsertWorkspaceBulkJob(genCtx, database.InsertWorkspaceBulkJobParams{
+		ID:               takeFirst(seed.ID, uuid.New()),
+		InitiatorID:      takeFirst(seed.InitiatorID, uuid.New()),
+		InitiatorIP:      seed.InitiatorIP,
+		InitiatorScope:   takeFirst(seed.InitiatorScope, database.APIKeyScopeAll),
+		InitiatorScopes:  takeFirstSlice(seed.InitiatorScopes, []string{}),
+		Action:           takeFirst(seed.Action, database.WorkspaceBulkActionStop),
+		Query:            seed.Query,
+		AutomaticUpdates: takeFirst(seed.AutomaticUpdates, database.AutomaticUpdatesNever),
+		Dormant:          seed.Dormant,
+		Concurrency:      takeFirst(seed.Concurrency, 1),
+		CreatedAt:        takeFirst(seed.CreatedAt, dbtime.Now()),
+	})
+	require.NoError(t, err, "insert workspace bulk job")
+	return job
+}
+
 func must[V any](v V, err error) V {
 	if err != nil {
 		panic(err)
@@ -157,14 +157,18 @@ type data struct {
 	gitSSHKey                     []database.GitSSHKey
 	groupMembers                  []database.GroupMember
 	groups                        []database.Group
+	inboxNotifications            []database.InboxNotification
 	jfrogXRayScans                []database.JfrogXrayScan
 	licenses                      []database.License
 	notificationMessages          []database.NotificationMessage
+	notificationPreferences       []database.NotificationPreference
 	oauth2ProviderApps            []database.OAuth2ProviderApp
 	oauth2ProviderAppSecrets      []database.OAuth2ProviderAppSecret
 	oauth2ProviderAppCodes        []database.OAuth2ProviderAppCode
+	oauth2ProviderAppDeviceCodes  []database.OAuth2ProviderAppDeviceCode
 	oauth2ProviderAppTokens       []database.OAuth2ProviderAppToken
 	parameterSchemas              []database.ParameterSchema
+	prebuiltWorkspaces            []database.PrebuiltWorkspace
 	provisionerDaemons            []database.ProvisionerDaemon
 	provisionerJobLogs            []database.ProvisionerJobLog
 	provisionerJobs               []database.ProvisionerJob
@@ -172,10 +176,16 @@ type data struct {
 	replicas                      []database.Replica
 	templateVersions              []database.TemplateVersionTable
 	templateVersionParameters     []database.TemplateVersionParameter
+	templateVersionPresets        []database.TemplateVersionPreset
 	templateVersionVariables      []database.TemplateVersionVariable
 	templateVersionWorkspaceTags  []database.TemplateVersionWorkspaceTag
 	templates                     []database.TemplateTable
+	templatePrebuildPools         []database.TemplatePrebuildPool
 	templateUsageStats            []database.TemplateUsageStat
+	temporaryRoleGrants           []database.TemporaryRoleGrant
+	userLoginAttempts             []database.UserLoginAttempt
+	userPasswordHistory           []database.UserPasswordHistory
+	userTOTPSecrets               []database.UserTOTPSecret
 	workspaceAgents               []database.WorkspaceAgent
 	workspaceAgentMetadata        []database.WorkspaceAgentMetadatum
 	workspaceAgentLogs            []database.WorkspaceAgentLog
@@ -187,6 +197,8 @@ type data struct {
 	workspaceAppStats             []database.WorkspaceAppStat
 	workspaceBuilds               []database.WorkspaceBuild
 	workspaceBuildParameters      []database.WorkspaceBuildParameter
+	workspaceBulkJobs             []database.WorkspaceBulkJob
+	workspaceBulkJobResults       []database.WorkspaceBulkJobResult
 	workspaceResourceMetadata     []database.WorkspaceResourceMetadatum
 	workspaceResources            []database.WorkspaceResource
 	workspaces                    []database.Workspace
@@ -328,6 +340,32 @@ func (q *FakeQuerier) getUserByIDNoLock(id uuid.UUID) (database.User, error) {
 	return database.User{}, sql.ErrNoRows
 }
 
+// getUserPasswordHistoryNoLock returns the password history of a user, newest
+// first.
+func (q *FakeQuerier) getUserPasswordHistoryNoLock(userID uuid.UUID) []database.UserPasswordHistory {
+	history := make([]database.UserPasswordHistory, 0)
+	for _, entry := range q.userPasswordHistory {
+		if entry.UserID == userID {
+			history = append(history, entry)
+		}
+	}
+	slices.SortStableFunc(history, func(a, b database.UserPasswordHistory) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return history
+}
+
+// temporaryRoleGrantExpiredNoLock returns true if the role was granted to the
+// user for a limited time which has passed.
+func (q *FakeQuerier) temporaryRoleGrantExpiredNoLock(userID uuid.UUID, orgID uuid.NullUUID, role string) bool {
+	for _, grant := range q.temporaryRoleGrants {
+		if grant.UserID == userID && grant.OrganizationID == orgID && grant.RoleName == role {
+			return !grant.ExpiresAt.After(dbtime.Now())
+		}
+	}
+	return false
+}
+
 func convertUsers(users []database.User, count int64) []database.GetUsersRow {
 	rows := make([]database.GetUsersRow, len(users))
 	for i, u := range users {
@@ -406,6 +444,8 @@ func (q *FakeQuerier) convertToWorkspaceRowsNoLock(ctx context.Context, workspac
 			Count:             count,
 			AutomaticUpdates:  w.AutomaticUpdates,
 			Favorite:          w.Favorite,
+			UserACL:           w.UserACL,
+			GroupACL:          w.GroupACL,
 		}
 
 		for _, t := range q.templates {
@@ -721,6 +761,47 @@ func (q *FakeQuerier) getOrganizationMemberNoLock(orgID uuid.UUID) []database.Or
 	return members
 }
 
+func (q *FakeQuerier) getPrebuiltWorkspacesNoLock(ctx context.Context, templateID uuid.UUID) []database.GetPrebuiltWorkspacesRow {
+	rows := make([]database.GetPrebuiltWorkspacesRow, 0)
+	for _, pw := range q.prebuiltWorkspaces {
+		workspace, err := q.getWorkspaceByIDNoLock(ctx, pw.WorkspaceID)
+		if err != nil || workspace.Deleted {
+			continue
+		}
+		if templateID != uuid.Nil && workspace.TemplateID != templateID {
+			continue
+		}
+		template, err := q.getTemplateByIDNoLock(ctx, workspace.TemplateID)
+		if err != nil {
+			continue
+		}
+		build, err := q.getLatestWorkspaceBuildByWorkspaceIDNoLock(ctx, workspace.ID)
+		if err != nil {
+			continue
+		}
+		job, err := q.getProvisionerJobByIDNoLock(ctx, build.JobID)
+		if err != nil {
+			continue
+		}
+		rows = append(rows, database.GetPrebuiltWorkspacesRow{
+			WorkspaceID:       pw.WorkspaceID,
+			PoolID:            pw.PoolID,
+			CreatedAt:         pw.CreatedAt,
+			TemplateID:        workspace.TemplateID,
+			DormantAt:         workspace.DormantAt,
+			ActiveVersionID:   template.ActiveVersionID,
+			TemplateVersionID: build.TemplateVersionID,
+			Transition:        build.Transition,
+			JobStatus:         job.JobStatus,
+			CompletedAt:       job.CompletedAt,
+		})
+	}
+	slices.SortFunc(rows, func(a, b database.GetPrebuiltWorkspacesRow) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return rows
+}
+
 // getEveryoneGroupMembersNoLock fetches all the users in an organization.
 func (q *FakeQuerier) getEveryoneGroupMembersNoLock(orgID uuid.UUID) []database.User {
 	var (
@@ -979,6 +1060,7 @@ func (q *FakeQuerier) AcquireNotificationMessages(_ context.Context, arg databas
 			ID:            nm.ID,
 			Payload:       nm.Payload,
 			Method:        nm.Method,
+			UserID:        nm.UserID,
 			TitleTemplate: "This is a title with {{.Labels.variable}}",
 			BodyTemplate:  "This is a body with {{.Labels.variable}}",
 			TemplateID:    nm.NotificationTemplateID,
@@ -1047,6 +1129,35 @@ func (q *FakeQuerier) AcquireProvisionerJob(_ context.Context, arg database.Acqu
 	return database.ProvisionerJob{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) AcquireWorkspaceBulkJob(_ context.Context, arg database.AcquireWorkspaceBulkJobParams) (database.WorkspaceBulkJob, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.WorkspaceBulkJob{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	index := -1
+	for i, job := range q.workspaceBulkJobs {
+		if job.CompletedAt.Valid {
+			continue
+		}
+		if job.WorkerID.Valid && !job.HeartbeatAt.Time.Before(arg.StaleBefore) {
+			continue
+		}
+		if index == -1 || job.CreatedAt.Before(q.workspaceBulkJobs[index].CreatedAt) {
+			index = i
+		}
+	}
+	if index == -1 {
+		return database.WorkspaceBulkJob{}, sql.ErrNoRows
+	}
+	q.workspaceBulkJobs[index].WorkerID = uuid.NullUUID{UUID: arg.WorkerID, Valid: true}
+	q.workspaceBulkJobs[index].HeartbeatAt = sql.NullTime{Time: arg.HeartbeatAt, Valid: true}
+	return q.workspaceBulkJobs[index], nil
+}
+
 func (q *FakeQuerier) ActivityBumpWorkspace(ctx context.Context, arg database.ActivityBumpWorkspaceParams) error {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -1250,6 +1361,42 @@ func (q *FakeQuerier) BatchUpdateWorkspaceLastUsedAt(_ context.Context, arg data
 	return nil
 }
 
+func (q *FakeQuerier) ClaimPrebuiltWorkspace(ctx context.Context, arg database.ClaimPrebuiltWorkspaceParams) (database.Workspace, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return database.Workspace{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, row := range q.getPrebuiltWorkspacesNoLock(ctx, uuid.Nil) {
+		if !row.PoolID.Valid || row.PoolID.UUID != arg.PoolID || row.DormantAt.Valid ||
+			row.Transition != database.WorkspaceTransitionStart ||
+			row.TemplateVersionID != row.ActiveVersionID ||
+			row.JobStatus != database.ProvisionerJobStatusSucceeded {
+			continue
+		}
+		for i, workspace := range q.workspaces {
+			if workspace.ID != row.WorkspaceID || workspace.OrganizationID != arg.OrganizationID {
+				continue
+			}
+			q.prebuiltWorkspaces = slices.DeleteFunc(q.prebuiltWorkspaces, func(pw database.PrebuiltWorkspace) bool {
+				return pw.WorkspaceID == workspace.ID
+			})
+			workspace.OwnerID = arg.NewOwnerID
+			workspace.Name = arg.NewName
+			workspace.AutostartSchedule = arg.AutostartSchedule
+			workspace.Ttl = arg.Ttl
+			workspace.AutomaticUpdates = arg.AutomaticUpdates
+			workspace.LastUsedAt = arg.Now
+			workspace.UpdatedAt = arg.Now
+			q.workspaces[i] = workspace
+			return workspace, nil
+		}
+	}
+	return database.Workspace{}, sql.ErrNoRows
+}
+
 func (*FakeQuerier) BulkMarkNotificationMessagesFailed(_ context.Context, arg database.BulkMarkNotificationMessagesFailedParams) (int64, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -1278,6 +1425,19 @@ func (*FakeQuerier) CleanTailnetTunnels(context.Context) error {
 	return ErrUnimplemented
 }
 
+func (q *FakeQuerier) CountUnreadInboxNotificationsByUserID(_ context.Context, userID uuid.UUID) (int64, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	var count int64
+	for _, notif := range q.inboxNotifications {
+		if notif.UserID == userID && !notif.ReadAt.Valid {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (q *FakeQuerier) CustomRoles(_ context.Context, arg database.CustomRolesParams) ([]database.CustomRole, error) {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -1379,6 +1539,16 @@ func (*FakeQuerier) DeleteCoordinator(context.Context, uuid.UUID) error {
 	return ErrUnimplemented
 }
 
+func (q *FakeQuerier) DeleteExpiredOAuth2ProviderAppDeviceCodes(_ context.Context, beforeTime time.Time) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.oauth2ProviderAppDeviceCodes = slices.DeleteFunc(q.oauth2ProviderAppDeviceCodes, func(code database.OAuth2ProviderAppDeviceCode) bool {
+		return code.ExpiresAt.Before(beforeTime)
+	})
+	return nil
+}
+
 func (q *FakeQuerier) DeleteExternalAuthLink(_ context.Context, arg database.DeleteExternalAuthLinkParams) error {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -1472,20 +1642,18 @@ func (q *FakeQuerier) DeleteOAuth2ProviderAppByID(_ context.Context, id uuid.UUI
 	q.oauth2ProviderApps[index] = q.oauth2ProviderApps[len(q.oauth2ProviderApps)-1]
 	q.oauth2ProviderApps = q.oauth2ProviderApps[:len(q.oauth2ProviderApps)-1]
 
-	// Cascade delete secrets associated with the deleted app.
-	var deletedSecretIDs []uuid.UUID
+	// Cascade delete secrets and device codes associated with the deleted app.
 	q.oauth2ProviderAppSecrets = slices.DeleteFunc(q.oauth2ProviderAppSecrets, func(secret database.OAuth2ProviderAppSecret) bool {
-		matches := secret.AppID == id
-		if matches {
-			deletedSecretIDs = append(deletedSecretIDs, secret.ID)
-		}
-		return matches
+		return secret.AppID == id
+	})
+	q.oauth2ProviderAppDeviceCodes = slices.DeleteFunc(q.oauth2ProviderAppDeviceCodes, func(code database.OAuth2ProviderAppDeviceCode) bool {
+		return code.AppID == id
 	})
 
-	// Cascade delete tokens through the deleted secrets.
+	// Cascade delete tokens associated with the deleted app.
 	var keyIDsToDelete []string
 	q.oauth2ProviderAppTokens = slices.DeleteFunc(q.oauth2ProviderAppTokens, func(token database.OAuth2ProviderAppToken) bool {
-		matches := slice.Contains(deletedSecretIDs, token.AppSecretID)
+		matches := token.AppID == id
 		if matches {
 			keyIDsToDelete = append(keyIDsToDelete, token.APIKeyID)
 		}
@@ -1533,6 +1701,20 @@ func (q *FakeQuerier) DeleteOAuth2ProviderAppCodesByAppAndUserID(_ context.Conte
 	return sql.ErrNoRows
 }
 
+func (q *FakeQuerier) DeleteOAuth2ProviderAppDeviceCodeByID(_ context.Context, id uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for index, code := range q.oauth2ProviderAppDeviceCodes {
+		if code.ID == id {
+			q.oauth2ProviderAppDeviceCodes[index] = q.oauth2ProviderAppDeviceCodes[len(q.oauth2ProviderAppDeviceCodes)-1]
+			q.oauth2ProviderAppDeviceCodes = q.oauth2ProviderAppDeviceCodes[:len(q.oauth2ProviderAppDeviceCodes)-1]
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
 func (q *FakeQuerier) DeleteOAuth2ProviderAppSecretByID(_ context.Context, id uuid.UUID) error {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -1551,7 +1733,7 @@ func (q *FakeQuerier) DeleteOAuth2ProviderAppSecretByID(_ context.Context, id uu
 	// Cascade delete tokens created through the deleted secret.
 	var keyIDsToDelete []string
 	q.oauth2ProviderAppTokens = slices.DeleteFunc(q.oauth2ProviderAppTokens, func(token database.OAuth2ProviderAppToken) bool {
-		matches := token.AppSecretID == id
+		matches := token.AppSecretID.Valid && token.AppSecretID.UUID == id
 		if matches {
 			keyIDsToDelete = append(keyIDsToDelete, token.APIKeyID)
 		}
@@ -1577,15 +1759,11 @@ func (q *FakeQuerier) DeleteOAuth2ProviderAppTokensByAppAndUserID(_ context.Cont
 
 	var keyIDsToDelete []string
 	q.oauth2ProviderAppTokens = slices.DeleteFunc(q.oauth2ProviderAppTokens, func(token database.OAuth2ProviderAppToken) bool {
-		// Join secrets and keys to see if the token matches.
-		secretIdx := slices.IndexFunc(q.oauth2ProviderAppSecrets, func(secret database.OAuth2ProviderAppSecret) bool {
-			return secret.ID == token.AppSecretID
-		})
+		// Join keys to see if the token matches.
 		keyIdx := slices.IndexFunc(q.apiKeys, func(key database.APIKey) bool {
 			return key.ID == token.APIKeyID
 		})
-		matches := secretIdx != -1 &&
-			q.oauth2ProviderAppSecrets[secretIdx].AppID == arg.AppID &&
+		matches := token.AppID == arg.AppID &&
 			keyIdx != -1 && q.apiKeys[keyIdx].UserID == arg.UserID
 		if matches {
 			keyIDsToDelete = append(keyIDsToDelete, token.APIKeyID)
@@ -1601,6 +1779,21 @@ func (q *FakeQuerier) DeleteOAuth2ProviderAppTokensByAppAndUserID(_ context.Cont
 	return nil
 }
 
+func (q *FakeQuerier) DeleteOldAuditLogs(_ context.Context, beforeTime time.Time) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	validLogs := make([]database.AuditLog, 0, len(q.auditLogs))
+	for _, alog := range q.auditLogs {
+		if alog.Time.Before(beforeTime) {
+			continue
+		}
+		validLogs = append(validLogs, alog)
+	}
+	q.auditLogs = validLogs
+	return nil
+}
+
 func (*FakeQuerier) DeleteOldNotificationMessages(_ context.Context) error {
 	return nil
 }
@@ -1624,6 +1817,30 @@ func (q *FakeQuerier) DeleteOldProvisionerDaemons(_ context.Context) error {
 	return nil
 }
 
+func (q *FakeQuerier) DeleteOldUserPasswordHistory(_ context.Context, arg database.DeleteOldUserPasswordHistoryParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	history := q.getUserPasswordHistoryNoLock(arg.UserID)
+	if len(history) <= int(arg.Keep) {
+		return nil
+	}
+	keep := make(map[uuid.UUID]struct{}, arg.Keep)
+	for _, entry := range history[:arg.Keep] {
+		keep[entry.ID] = struct{}{}
+	}
+	q.userPasswordHistory = slices.DeleteFunc(q.userPasswordHistory, func(entry database.UserPasswordHistory) bool {
+		_, ok := keep[entry.ID]
+		return entry.UserID == arg.UserID && !ok
+	})
+	return nil
+}
+
 func (q *FakeQuerier) DeleteOldWorkspaceAgentLogs(_ context.Context) error {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -1749,6 +1966,18 @@ func (q *FakeQuerier) DeleteOrganizationMember(_ context.Context, arg database.D
 	if len(deleted) == 0 {
 		return sql.ErrNoRows
 	}
+
+	for i, workspace := range q.workspaces {
+		if workspace.OrganizationID != arg.OrganizationID {
+			continue
+		}
+		if _, ok := workspace.UserACL[arg.UserID.String()]; !ok {
+			continue
+		}
+		userACL := maps.Clone(workspace.UserACL)
+		delete(userACL, arg.UserID.String())
+		q.workspaces[i].UserACL = userACL
+	}
 	return nil
 }
 
@@ -1779,6 +2008,102 @@ func (q *FakeQuerier) DeleteReplicasUpdatedBefore(_ context.Context, before time
 	return nil
 }
 
+func (q *FakeQuerier) DeleteTemplatePrebuildPoolByID(_ context.Context, id uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.templatePrebuildPools = slices.DeleteFunc(q.templatePrebuildPools, func(pool database.TemplatePrebuildPool) bool {
+		return pool.ID == id
+	})
+	for i, pw := range q.prebuiltWorkspaces {
+		if pw.PoolID.Valid && pw.PoolID.UUID == id {
+			q.prebuiltWorkspaces[i].PoolID = uuid.NullUUID{}
+		}
+	}
+	return nil
+}
+
+func (q *FakeQuerier) DeleteTemplateVersionPresetsByTemplateVersionID(_ context.Context, templateVersionID uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.templateVersionPresets = slices.DeleteFunc(q.templateVersionPresets, func(preset database.TemplateVersionPreset) bool {
+		return preset.TemplateVersionID == templateVersionID
+	})
+	return nil
+}
+
+func (q *FakeQuerier) DeleteTemporaryRoleGrantByID(_ context.Context, id uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.temporaryRoleGrants = slices.DeleteFunc(q.temporaryRoleGrants, func(grant database.TemporaryRoleGrant) bool {
+		return grant.ID == id
+	})
+	return nil
+}
+
+func (q *FakeQuerier) DeleteTemporaryRoleGrants(_ context.Context, arg database.DeleteTemporaryRoleGrantsParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.temporaryRoleGrants = slices.DeleteFunc(q.temporaryRoleGrants, func(grant database.TemporaryRoleGrant) bool {
+		return grant.UserID == arg.UserID &&
+			grant.OrganizationID == arg.OrganizationID &&
+			slices.Contains(arg.RoleNames, grant.RoleName)
+	})
+	return nil
+}
+
+func (q *FakeQuerier) DeleteUserLoginAttempts(_ context.Context, userID uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.userLoginAttempts = slices.DeleteFunc(q.userLoginAttempts, func(attempt database.UserLoginAttempt) bool {
+		return attempt.UserID == userID
+	})
+	return nil
+}
+
+func (q *FakeQuerier) DeleteUserTOTPRecoveryCode(_ context.Context, arg database.DeleteUserTOTPRecoveryCodeParams) (int64, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, secret := range q.userTOTPSecrets {
+		if secret.UserID != arg.UserID {
+			continue
+		}
+		if !slices.Contains(secret.HashedRecoveryCodes, arg.HashedRecoveryCode) {
+			return 0, nil
+		}
+		q.userTOTPSecrets[i].HashedRecoveryCodes = slices.DeleteFunc(slices.Clone(secret.HashedRecoveryCodes), func(c string) bool {
+			return c == arg.HashedRecoveryCode
+		})
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (q *FakeQuerier) DeleteUserTOTPSecret(_ context.Context, userID uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.userTOTPSecrets = slices.DeleteFunc(q.userTOTPSecrets, func(secret database.UserTOTPSecret) bool {
+		return secret.UserID == userID
+	})
+	return nil
+}
+
 func (*FakeQuerier) DeleteTailnetAgent(context.Context, database.DeleteTailnetAgentParams) (database.DeleteTailnetAgentRow, error) {
 	return database.DeleteTailnetAgentRow{}, ErrUnimplemented
 }
@@ -2012,7 +2337,7 @@ func (q *FakeQuerier) GetActiveUserCount(_ context.Context) (int64, error) {
 
 	active := int64(0)
 	for _, u := range q.users {
-		if u.Status == database.UserStatusActive && !u.Deleted {
+		if u.Status == database.UserStatusActive && !u.Deleted && !u.IsServiceAccount {
 			active++
 		}
 	}
@@ -2214,7 +2539,12 @@ func (q *FakeQuerier) GetAuthorizationUserRoles(_ context.Context, userID uuid.U
 	for _, u := range q.users {
 		if u.ID == userID {
 			u := u
-			roles = append(roles, u.RBACRoles...)
+			for _, role := range u.RBACRoles {
+				if q.temporaryRoleGrantExpiredNoLock(userID, uuid.NullUUID{}, role) {
+					continue
+				}
+				roles = append(roles, role)
+			}
 			roles = append(roles, "member")
 			user = &u
 			break
@@ -2224,6 +2554,9 @@ func (q *FakeQuerier) GetAuthorizationUserRoles(_ context.Context, userID uuid.U
 	for _, mem := range q.organizationMembers {
 		if mem.UserID == userID {
 			for _, orgRole := range mem.Roles {
+				if q.temporaryRoleGrantExpiredNoLock(userID, uuid.NullUUID{UUID: mem.OrganizationID, Valid: true}, orgRole) {
+					continue
+				}
 				roles = append(roles, orgRole+":"+mem.OrganizationID.String())
 			}
 			roles = append(roles, "organization-member:"+mem.OrganizationID.String())
@@ -2427,6 +2760,22 @@ func (q *FakeQuerier) GetDeploymentWorkspaceStats(ctx context.Context) (database
 	return stat, nil
 }
 
+func (q *FakeQuerier) GetExpiredTemporaryRoleGrants(_ context.Context, now time.Time) ([]database.TemporaryRoleGrant, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	grants := make([]database.TemporaryRoleGrant, 0)
+	for _, grant := range q.temporaryRoleGrants {
+		if !grant.ExpiresAt.After(now) {
+			grants = append(grants, grant)
+		}
+	}
+	slices.SortFunc(grants, func(a, b database.TemporaryRoleGrant) int {
+		return a.ExpiresAt.Compare(b.ExpiresAt)
+	})
+	return grants, nil
+}
+
 func (q *FakeQuerier) GetExternalAuthLink(_ context.Context, arg database.GetExternalAuthLinkParams) (database.ExternalAuthLink, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.ExternalAuthLink{}, err
@@ -2677,6 +3026,68 @@ func (q *FakeQuerier) GetHungProvisionerJobs(_ context.Context, hungSince time.T
 	return hungJobs, nil
 }
 
+func (q *FakeQuerier) GetInboxNotificationByID(_ context.Context, id uuid.UUID) (database.InboxNotification, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, notif := range q.inboxNotifications {
+		if notif.ID == id {
+			return notif, nil
+		}
+	}
+	return database.InboxNotification{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetInboxNotificationsByUserID(_ context.Context, arg database.GetInboxNotificationsByUserIDParams) ([]database.InboxNotification, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	var before time.Time
+	if arg.StartingBefore != uuid.Nil {
+		for _, notif := range q.inboxNotifications {
+			if notif.ID == arg.StartingBefore {
+				before = notif.CreatedAt
+				break
+			}
+		}
+	}
+
+	notifs := make([]database.InboxNotification, 0)
+	for _, notif := range q.inboxNotifications {
+		if notif.UserID != arg.UserID {
+			continue
+		}
+		if arg.UnreadOnly && notif.ReadAt.Valid {
+			continue
+		}
+		if arg.StartingBefore != uuid.Nil && !notif.CreatedAt.Before(before) {
+			continue
+		}
+		notifs = append(notifs, notif)
+	}
+
+	slices.SortFunc(notifs, func(a, b database.InboxNotification) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return slice.Descending(a.ID.String(), b.ID.String())
+	})
+
+	limit := 25
+	if arg.LimitOpt > 0 {
+		limit = int(arg.LimitOpt)
+	}
+	if len(notifs) > limit {
+		notifs = notifs[:limit]
+	}
+	return notifs, nil
+}
+
 func (q *FakeQuerier) GetJFrogXrayScanByWorkspaceAndAgentID(_ context.Context, arg database.GetJFrogXrayScanByWorkspaceAndAgentIDParams) (database.JfrogXrayScan, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -2817,6 +3228,16 @@ func (q *FakeQuerier) GetNotificationMessagesByStatus(_ context.Context, arg dat
 	return out, nil
 }
 
+func (*FakeQuerier) GetNotificationTemplateByID(_ context.Context, _ uuid.UUID) (database.NotificationTemplate, error) {
+	// Notification templates are only stored in postgres.
+	return database.NotificationTemplate{}, ErrUnimplemented
+}
+
+func (*FakeQuerier) GetNotificationTemplates(_ context.Context) ([]database.NotificationTemplate, error) {
+	// Notification templates are only stored in postgres.
+	return nil, ErrUnimplemented
+}
+
 func (q *FakeQuerier) GetNotificationsSettings(_ context.Context) (string, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -2864,6 +3285,42 @@ func (q *FakeQuerier) GetOAuth2ProviderAppCodeByPrefix(_ context.Context, secret
 	return database.OAuth2ProviderAppCode{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) GetOAuth2ProviderAppDeviceCodeByID(_ context.Context, id uuid.UUID) (database.OAuth2ProviderAppDeviceCode, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, code := range q.oauth2ProviderAppDeviceCodes {
+		if code.ID == id {
+			return code, nil
+		}
+	}
+	return database.OAuth2ProviderAppDeviceCode{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetOAuth2ProviderAppDeviceCodeByPrefix(_ context.Context, secretPrefix []byte) (database.OAuth2ProviderAppDeviceCode, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, code := range q.oauth2ProviderAppDeviceCodes {
+		if bytes.Equal(code.SecretPrefix, secretPrefix) {
+			return code, nil
+		}
+	}
+	return database.OAuth2ProviderAppDeviceCode{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetOAuth2ProviderAppDeviceCodeByUserCode(_ context.Context, userCode string) (database.OAuth2ProviderAppDeviceCode, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, code := range q.oauth2ProviderAppDeviceCodes {
+		if code.UserCode == userCode {
+			return code, nil
+		}
+	}
+	return database.OAuth2ProviderAppDeviceCode{}, sql.ErrNoRows
+}
+
 func (q *FakeQuerier) GetOAuth2ProviderAppSecretByID(_ context.Context, id uuid.UUID) (database.OAuth2ProviderAppSecret, error) {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -2916,6 +3373,18 @@ func (q *FakeQuerier) GetOAuth2ProviderAppSecretsByAppID(_ context.Context, appI
 	return []database.OAuth2ProviderAppSecret{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) GetOAuth2ProviderAppTokenByAPIKeyID(_ context.Context, apiKeyID string) (database.OAuth2ProviderAppToken, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, token := range q.oauth2ProviderAppTokens {
+		if token.APIKeyID == apiKeyID {
+			return token, nil
+		}
+	}
+	return database.OAuth2ProviderAppToken{}, sql.ErrNoRows
+}
+
 func (q *FakeQuerier) GetOAuth2ProviderAppTokenByPrefix(_ context.Context, hashPrefix []byte) (database.OAuth2ProviderAppToken, error) {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -2945,27 +3414,24 @@ func (q *FakeQuerier) GetOAuth2ProviderAppsByUserID(_ context.Context, userID uu
 	rows := []database.GetOAuth2ProviderAppsByUserIDRow{}
 	for _, app := range q.oauth2ProviderApps {
 		tokens := []database.OAuth2ProviderAppToken{}
-		for _, secret := range q.oauth2ProviderAppSecrets {
-			if secret.AppID == app.ID {
-				for _, token := range q.oauth2ProviderAppTokens {
-					if token.AppSecretID == secret.ID {
-						keyIdx := slices.IndexFunc(q.apiKeys, func(key database.APIKey) bool {
-							return key.ID == token.APIKeyID
-						})
-						if keyIdx != -1 && q.apiKeys[keyIdx].UserID == userID {
-							tokens = append(tokens, token)
-						}
-					}
+		for _, token := range q.oauth2ProviderAppTokens {
+			if token.AppID == app.ID {
+				keyIdx := slices.IndexFunc(q.apiKeys, func(key database.APIKey) bool {
+					return key.ID == token.APIKeyID
+				})
+				if keyIdx != -1 && q.apiKeys[keyIdx].UserID == userID {
+					tokens = append(tokens, token)
 				}
 			}
 		}
 		if len(tokens) > 0 {
 			rows = append(rows, database.GetOAuth2ProviderAppsByUserIDRow{
 				OAuth2ProviderApp: database.OAuth2ProviderApp{
-					CallbackURL: app.CallbackURL,
-					ID:          app.ID,
-					Icon:        app.Icon,
-					Name:        app.Name,
+					CallbackURL:      app.CallbackURL,
+					ID:               app.ID,
+					Icon:             app.Icon,
+					Name:             app.Name,
+					ServiceAccountID: app.ServiceAccountID,
 				},
 				TokenCount: int64(len(tokens)),
 			})
@@ -3072,6 +3538,13 @@ func (q *FakeQuerier) GetParameterSchemasByJobID(_ context.Context, jobID uuid.U
 	return parameters, nil
 }
 
+func (q *FakeQuerier) GetPrebuiltWorkspaces(ctx context.Context, templateID uuid.UUID) ([]database.GetPrebuiltWorkspacesRow, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	return q.getPrebuiltWorkspacesNoLock(ctx, templateID), nil
+}
+
 func (q *FakeQuerier) GetPreviousTemplateVersion(_ context.Context, arg database.GetPreviousTemplateVersionParams) (database.TemplateVersion, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.TemplateVersion{}, err
@@ -3378,6 +3851,31 @@ func (q *FakeQuerier) GetReplicasUpdatedAfter(_ context.Context, updatedAt time.
 	return replicas, nil
 }
 
+func (q *FakeQuerier) GetSessionAPIKeysByUserID(_ context.Context, userID uuid.UUID) ([]database.APIKey, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	now := dbtime.Now()
+	apiKeys := make([]database.APIKey, 0)
+	for _, key := range q.apiKeys {
+		if key.UserID != userID ||
+			key.LoginType == database.LoginTypeToken ||
+			key.Scope != database.APIKeyScopeAll ||
+			key.TokenName != "" ||
+			!key.ExpiresAt.After(now) {
+			continue
+		}
+		apiKeys = append(apiKeys, key)
+	}
+	slices.SortFunc(apiKeys, func(a, b database.APIKey) int {
+		if c := b.LastUsed.Compare(a.LastUsed); c != 0 {
+			return c
+		}
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return apiKeys, nil
+}
+
 func (*FakeQuerier) GetTailnetAgents(context.Context, uuid.UUID) ([]database.TailnetAgent, error) {
 	return nil, ErrUnimplemented
 }
@@ -4390,10 +4888,46 @@ func (q *FakeQuerier) GetTemplateParameterInsights(ctx context.Context, arg data
 			})
 		}
 	}
-
-	// NOTE(mafredri): Add sorting if we decide on how to handle PostgreSQL collations.
-	// ORDER BY utp.name, utp.type, utp.display_name, utp.description, utp.options, wbp.value
-	return rows, nil
+
+	// NOTE(mafredri): Add sorting if we decide on how to handle PostgreSQL collations.
+	// ORDER BY utp.name, utp.type, utp.display_name, utp.description, utp.options, wbp.value
+	return rows, nil
+}
+
+func (q *FakeQuerier) GetTemplatePrebuildPoolByID(_ context.Context, id uuid.UUID) (database.TemplatePrebuildPool, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, pool := range q.templatePrebuildPools {
+		if pool.ID == id {
+			return pool, nil
+		}
+	}
+	return database.TemplatePrebuildPool{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetTemplatePrebuildPools(ctx context.Context, templateID uuid.UUID) ([]database.TemplatePrebuildPool, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	pools := make([]database.TemplatePrebuildPool, 0)
+	for _, pool := range q.templatePrebuildPools {
+		if templateID != uuid.Nil && pool.TemplateID != templateID {
+			continue
+		}
+		template, err := q.getTemplateByIDNoLock(ctx, pool.TemplateID)
+		if err != nil || template.Deleted {
+			continue
+		}
+		pools = append(pools, pool)
+	}
+	slices.SortFunc(pools, func(a, b database.TemplatePrebuildPool) int {
+		if c := slices.Compare(a.TemplateID[:], b.TemplateID[:]); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return pools, nil
 }
 
 func (q *FakeQuerier) GetTemplateUsageStats(_ context.Context, arg database.GetTemplateUsageStatsParams) ([]database.TemplateUsageStat, error) {
@@ -4484,6 +5018,37 @@ func (q *FakeQuerier) GetTemplateVersionParameters(_ context.Context, templateVe
 	return parameters, nil
 }
 
+func (q *FakeQuerier) GetTemplateVersionPresetByID(_ context.Context, id uuid.UUID) (database.TemplateVersionPreset, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, preset := range q.templateVersionPresets {
+		if preset.ID == id {
+			return preset, nil
+		}
+	}
+	return database.TemplateVersionPreset{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetTemplateVersionPresetsByTemplateVersionIDs(_ context.Context, ids []uuid.UUID) ([]database.TemplateVersionPreset, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	presets := make([]database.TemplateVersionPreset, 0)
+	for _, preset := range q.templateVersionPresets {
+		if slices.Contains(ids, preset.TemplateVersionID) {
+			presets = append(presets, preset)
+		}
+	}
+	slices.SortFunc(presets, func(a, b database.TemplateVersionPreset) int {
+		if c := slices.Compare(a.TemplateVersionID[:], b.TemplateVersionID[:]); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return presets, nil
+}
+
 func (q *FakeQuerier) GetTemplateVersionVariables(_ context.Context, templateVersionID uuid.UUID) ([]database.TemplateVersionVariable, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -4641,6 +5206,25 @@ func (q *FakeQuerier) GetTemplatesWithFilter(ctx context.Context, arg database.G
 	return q.GetAuthorizedTemplates(ctx, arg, nil)
 }
 
+func (q *FakeQuerier) GetTemporaryRoleGrantsByUserID(_ context.Context, userID uuid.UUID) ([]database.TemporaryRoleGrant, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	grants := make([]database.TemporaryRoleGrant, 0)
+	for _, grant := range q.temporaryRoleGrants {
+		if grant.UserID == userID {
+			grants = append(grants, grant)
+		}
+	}
+	slices.SortFunc(grants, func(a, b database.TemporaryRoleGrant) int {
+		if c := a.ExpiresAt.Compare(b.ExpiresAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.RoleName, b.RoleName)
+	})
+	return grants, nil
+}
+
 func (q *FakeQuerier) GetUnexpiredLicenses(_ context.Context) ([]database.License, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -4962,6 +5546,68 @@ func (q *FakeQuerier) GetUserLinksByUserID(_ context.Context, userID uuid.UUID)
 	return uls, nil
 }
 
+func (q *FakeQuerier) GetUserLoginAttempts(_ context.Context, userID uuid.UUID) (database.UserLoginAttempt, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, attempt := range q.userLoginAttempts {
+		if attempt.UserID == userID {
+			return attempt, nil
+		}
+	}
+	return database.UserLoginAttempt{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetUserNotificationPreference(_ context.Context, arg database.GetUserNotificationPreferenceParams) (database.NotificationPreference, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.NotificationPreference{}, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, np := range q.notificationPreferences {
+		if np.UserID == arg.UserID && np.NotificationTemplateID == arg.NotificationTemplateID {
+			return np, nil
+		}
+	}
+	return database.NotificationPreference{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetUserPasswordHistory(_ context.Context, arg database.GetUserPasswordHistoryParams) ([]database.UserPasswordHistory, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	history := q.getUserPasswordHistoryNoLock(arg.UserID)
+	if arg.LimitOpt > 0 && len(history) > int(arg.LimitOpt) {
+		history = history[:arg.LimitOpt]
+	}
+	return history, nil
+}
+
+func (q *FakeQuerier) GetUserTOTPSecret(_ context.Context, userID uuid.UUID) (database.UserTOTPSecret, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, secret := range q.userTOTPSecrets {
+		if secret.UserID == userID {
+			return secret, nil
+		}
+	}
+	return database.UserTOTPSecret{}, sql.ErrNoRows
+}
+
+func (*FakeQuerier) GetUserNotificationPreferences(_ context.Context, _ uuid.UUID) ([]database.GetUserNotificationPreferencesRow, error) {
+	// Notification templates are only stored in postgres.
+	return nil, ErrUnimplemented
+}
+
 func (q *FakeQuerier) GetUserWorkspaceBuildParameters(_ context.Context, params database.GetUserWorkspaceBuildParametersParams) ([]database.GetUserWorkspaceBuildParametersRow, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -5038,10 +5684,11 @@ func (q *FakeQuerier) GetUsers(_ context.Context, params database.GetUsersParams
 		return slice.Ascending(strings.ToLower(a.Username), strings.ToLower(b.Username))
 	})
 
-	// Filter out deleted since they should never be returned..
+	// Filter out deleted users and service accounts since they should never
+	// be returned.
 	tmp := make([]database.User, 0, len(users))
 	for _, user := range users {
-		if !user.Deleted {
+		if !user.Deleted && !user.IsServiceAccount {
 			tmp = append(tmp, user)
 		}
 	}
@@ -5724,6 +6371,55 @@ func (q *FakeQuerier) GetWorkspaceBuildsCreatedAfter(_ context.Context, after ti
 	return workspaceBuilds, nil
 }
 
+func (q *FakeQuerier) GetWorkspaceBulkJobByID(_ context.Context, id uuid.UUID) (database.WorkspaceBulkJob, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, job := range q.workspaceBulkJobs {
+		if job.ID == id {
+			return job, nil
+		}
+	}
+	return database.WorkspaceBulkJob{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetWorkspaceBulkJobResultsByJobID(ctx context.Context, jobID uuid.UUID) ([]database.GetWorkspaceBulkJobResultsByJobIDRow, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	rows := make([]database.GetWorkspaceBulkJobResultsByJobIDRow, 0)
+	for _, result := range q.workspaceBulkJobResults {
+		if result.JobID != jobID {
+			continue
+		}
+		workspace, err := q.getWorkspaceByIDNoLock(ctx, result.WorkspaceID)
+		if err != nil {
+			continue
+		}
+		owner, err := q.getUserByIDNoLock(workspace.OwnerID)
+		if err != nil {
+			continue
+		}
+		rows = append(rows, database.GetWorkspaceBulkJobResultsByJobIDRow{
+			JobID:                  result.JobID,
+			WorkspaceID:            result.WorkspaceID,
+			Status:                 result.Status,
+			Error:                  result.Error,
+			BuildID:                result.BuildID,
+			UpdatedAt:              result.UpdatedAt,
+			WorkspaceName:          workspace.Name,
+			WorkspaceOwnerUsername: owner.Username,
+		})
+	}
+	slices.SortFunc(rows, func(a, b database.GetWorkspaceBulkJobResultsByJobIDRow) int {
+		if c := strings.Compare(a.WorkspaceOwnerUsername, b.WorkspaceOwnerUsername); c != 0 {
+			return c
+		}
+		return strings.Compare(a.WorkspaceName, b.WorkspaceName)
+	})
+	return rows, nil
+}
+
 func (q *FakeQuerier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUID) (database.GetWorkspaceByAgentIDRow, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -6097,6 +6793,11 @@ func (q *FakeQuerier) InsertAPIKey(_ context.Context, arg database.InsertAPIKeyP
 		LoginType:       arg.LoginType,
 		Scope:           arg.Scope,
 		TokenName:       arg.TokenName,
+		Scopes:          arg.Scopes,
+		UserAgent:       arg.UserAgent,
+	}
+	if key.Scopes == nil {
+		key.Scopes = []string{}
 	}
 	q.apiKeys = append(q.apiKeys, key)
 	return key, nil
@@ -6124,13 +6825,10 @@ func (q *FakeQuerier) InsertAuditLog(_ context.Context, arg database.InsertAudit
 	alog := database.AuditLog(arg)
 
 	q.auditLogs = append(q.auditLogs, alog)
-	slices.SortFunc(q.auditLogs, func(a, b database.AuditLog) int {
-		if a.Time.Before(b.Time) {
-			return -1
-		} else if a.Time.Equal(b.Time) {
-			return 0
-		}
-		return 1
+	// Audit logs are kept sorted by time DESC, as they are returned by
+	// GetAuditLogsOffset.
+	slices.SortStableFunc(q.auditLogs, func(a, b database.AuditLog) int {
+		return b.Time.Compare(a.Time)
 	})
 
 	return alog, nil
@@ -6292,6 +6990,36 @@ func (q *FakeQuerier) InsertGroupMember(_ context.Context, arg database.InsertGr
 	return nil
 }
 
+func (q *FakeQuerier) InsertInboxNotification(_ context.Context, arg database.InsertInboxNotificationParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, notif := range q.inboxNotifications {
+		if notif.ID == arg.ID {
+			return nil
+		}
+	}
+
+	actions := arg.Actions
+	if len(actions) == 0 {
+		actions = json.RawMessage("[]")
+	}
+	q.inboxNotifications = append(q.inboxNotifications, database.InboxNotification{
+		ID:        arg.ID,
+		UserID:    arg.UserID,
+		Title:     arg.Title,
+		Content:   arg.Content,
+		Actions:   actions,
+		CreatedAt: arg.CreatedAt,
+	})
+	return nil
+}
+
 func (q *FakeQuerier) InsertLicense(
 	_ context.Context, arg database.InsertLicenseParams,
 ) (database.License, error) {
@@ -6369,12 +7097,13 @@ func (q *FakeQuerier) InsertOAuth2ProviderApp(_ context.Context, arg database.In
 
 	//nolint:gosimple // Go wants database.OAuth2ProviderApp(arg), but we cannot be sure the structs will remain identical.
 	app := database.OAuth2ProviderApp{
-		ID:          arg.ID,
-		CreatedAt:   arg.CreatedAt,
-		UpdatedAt:   arg.UpdatedAt,
-		Name:        arg.Name,
-		Icon:        arg.Icon,
-		CallbackURL: arg.CallbackURL,
+		ID:               arg.ID,
+		CreatedAt:        arg.CreatedAt,
+		UpdatedAt:        arg.UpdatedAt,
+		Name:             arg.Name,
+		Icon:             arg.Icon,
+		CallbackURL:      arg.CallbackURL,
+		ServiceAccountID: arg.ServiceAccountID,
 	}
 	q.oauth2ProviderApps = append(q.oauth2ProviderApps, app)
 
@@ -6393,20 +7122,56 @@ func (q *FakeQuerier) InsertOAuth2ProviderAppCode(_ context.Context, arg databas
 	for _, app := range q.oauth2ProviderApps {
 		if app.ID == arg.AppID {
 			code := database.OAuth2ProviderAppCode{
+				ID:                  arg.ID,
+				CreatedAt:           arg.CreatedAt,
+				ExpiresAt:           arg.ExpiresAt,
+				SecretPrefix:        arg.SecretPrefix,
+				HashedSecret:        arg.HashedSecret,
+				UserID:              arg.UserID,
+				AppID:               arg.AppID,
+				CodeChallenge:       arg.CodeChallenge,
+				CodeChallengeMethod: arg.CodeChallengeMethod,
+			}
+			q.oauth2ProviderAppCodes = append(q.oauth2ProviderAppCodes, code)
+			return code, nil
+		}
+	}
+
+	return database.OAuth2ProviderAppCode{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) InsertOAuth2ProviderAppDeviceCode(_ context.Context, arg database.InsertOAuth2ProviderAppDeviceCodeParams) (database.OAuth2ProviderAppDeviceCode, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.OAuth2ProviderAppDeviceCode{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, code := range q.oauth2ProviderAppDeviceCodes {
+		if bytes.Equal(code.SecretPrefix, arg.SecretPrefix) || code.UserCode == arg.UserCode {
+			return database.OAuth2ProviderAppDeviceCode{}, errUniqueConstraint
+		}
+	}
+
+	for _, app := range q.oauth2ProviderApps {
+		if app.ID == arg.AppID {
+			code := database.OAuth2ProviderAppDeviceCode{
 				ID:           arg.ID,
 				CreatedAt:    arg.CreatedAt,
 				ExpiresAt:    arg.ExpiresAt,
 				SecretPrefix: arg.SecretPrefix,
 				HashedSecret: arg.HashedSecret,
-				UserID:       arg.UserID,
+				UserCode:     arg.UserCode,
 				AppID:        arg.AppID,
 			}
-			q.oauth2ProviderAppCodes = append(q.oauth2ProviderAppCodes, code)
+			q.oauth2ProviderAppDeviceCodes = append(q.oauth2ProviderAppDeviceCodes, code)
 			return code, nil
 		}
 	}
 
-	return database.OAuth2ProviderAppCode{}, sql.ErrNoRows
+	return database.OAuth2ProviderAppDeviceCode{}, sql.ErrNoRows
 }
 
 func (q *FakeQuerier) InsertOAuth2ProviderAppSecret(_ context.Context, arg database.InsertOAuth2ProviderAppSecretParams) (database.OAuth2ProviderAppSecret, error) {
@@ -6445,8 +7210,14 @@ func (q *FakeQuerier) InsertOAuth2ProviderAppToken(_ context.Context, arg databa
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
 
-	for _, secret := range q.oauth2ProviderAppSecrets {
-		if secret.ID == arg.AppSecretID {
+	if arg.AppSecretID.Valid && !slices.ContainsFunc(q.oauth2ProviderAppSecrets, func(secret database.OAuth2ProviderAppSecret) bool {
+		return secret.ID == arg.AppSecretID.UUID
+	}) {
+		return database.OAuth2ProviderAppToken{}, sql.ErrNoRows
+	}
+
+	for _, app := range q.oauth2ProviderApps {
+		if app.ID == arg.AppID {
 			//nolint:gosimple // Go wants database.OAuth2ProviderAppToken(arg), but we cannot be sure the structs will remain identical.
 			token := database.OAuth2ProviderAppToken{
 				ID:          arg.ID,
@@ -6456,6 +7227,7 @@ func (q *FakeQuerier) InsertOAuth2ProviderAppToken(_ context.Context, arg databa
 				RefreshHash: arg.RefreshHash,
 				APIKeyID:    arg.APIKeyID,
 				AppSecretID: arg.AppSecretID,
+				AppID:       arg.AppID,
 			}
 			q.oauth2ProviderAppTokens = append(q.oauth2ProviderAppTokens, token)
 			return token, nil
@@ -6521,6 +7293,24 @@ func (q *FakeQuerier) InsertOrganizationMember(_ context.Context, arg database.I
 	return organizationMember, nil
 }
 
+func (q *FakeQuerier) InsertPrebuiltWorkspace(_ context.Context, arg database.InsertPrebuiltWorkspaceParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, pw := range q.prebuiltWorkspaces {
+		if pw.WorkspaceID == arg.WorkspaceID {
+			return errUniqueConstraint
+		}
+	}
+	q.prebuiltWorkspaces = append(q.prebuiltWorkspaces, database.PrebuiltWorkspace(arg))
+	return nil
+}
+
 func (q *FakeQuerier) InsertProvisionerJob(_ context.Context, arg database.InsertProvisionerJobParams) (database.ProvisionerJob, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.ProvisionerJob{}, err
@@ -6662,6 +7452,28 @@ func (q *FakeQuerier) InsertTemplate(_ context.Context, arg database.InsertTempl
 	return nil
 }
 
+func (q *FakeQuerier) InsertTemplatePrebuildPool(_ context.Context, arg database.InsertTemplatePrebuildPoolParams) (database.TemplatePrebuildPool, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.TemplatePrebuildPool{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, pool := range q.templatePrebuildPools {
+		if pool.TemplateID == arg.TemplateID && pool.Name == arg.Name {
+			return database.TemplatePrebuildPool{}, errUniqueConstraint
+		}
+	}
+	if arg.Parameters == nil {
+		arg.Parameters = database.StringMap{}
+	}
+	pool := database.TemplatePrebuildPool(arg)
+	q.templatePrebuildPools = append(q.templatePrebuildPools, pool)
+	return pool, nil
+}
+
 func (q *FakeQuerier) InsertTemplateVersion(_ context.Context, arg database.InsertTemplateVersionParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -6723,6 +7535,28 @@ func (q *FakeQuerier) InsertTemplateVersionParameter(_ context.Context, arg data
 	return param, nil
 }
 
+func (q *FakeQuerier) InsertTemplateVersionPreset(_ context.Context, arg database.InsertTemplateVersionPresetParams) (database.TemplateVersionPreset, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.TemplateVersionPreset{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, preset := range q.templateVersionPresets {
+		if preset.TemplateVersionID == arg.TemplateVersionID && preset.Name == arg.Name {
+			return database.TemplateVersionPreset{}, errUniqueConstraint
+		}
+	}
+	if arg.Parameters == nil {
+		arg.Parameters = database.StringMap{}
+	}
+	preset := database.TemplateVersionPreset(arg)
+	q.templateVersionPresets = append(q.templateVersionPresets, preset)
+	return preset, nil
+}
+
 func (q *FakeQuerier) InsertTemplateVersionVariable(_ context.Context, arg database.InsertTemplateVersionVariableParams) (database.TemplateVersionVariable, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.TemplateVersionVariable{}, err
@@ -6795,16 +7629,17 @@ func (q *FakeQuerier) InsertUser(_ context.Context, arg database.InsertUserParam
 	}
 
 	user := database.User{
-		ID:             arg.ID,
-		Email:          arg.Email,
-		HashedPassword: arg.HashedPassword,
-		CreatedAt:      arg.CreatedAt,
-		UpdatedAt:      arg.UpdatedAt,
-		Username:       arg.Username,
-		Name:           arg.Name,
-		Status:         database.UserStatusDormant,
-		RBACRoles:      arg.RBACRoles,
-		LoginType:      arg.LoginType,
+		ID:               arg.ID,
+		Email:            arg.Email,
+		HashedPassword:   arg.HashedPassword,
+		CreatedAt:        arg.CreatedAt,
+		UpdatedAt:        arg.UpdatedAt,
+		Username:         arg.Username,
+		Name:             arg.Name,
+		Status:           database.UserStatusDormant,
+		RBACRoles:        arg.RBACRoles,
+		LoginType:        arg.LoginType,
+		IsServiceAccount: arg.IsServiceAccount,
 	}
 	q.users = append(q.users, user)
 	return user, nil
@@ -6859,6 +7694,19 @@ func (q *FakeQuerier) InsertUserLink(_ context.Context, args database.InsertUser
 	return link, nil
 }
 
+func (q *FakeQuerier) InsertUserPasswordHistory(_ context.Context, arg database.InsertUserPasswordHistoryParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.userPasswordHistory = append(q.userPasswordHistory, database.UserPasswordHistory(arg))
+	return nil
+}
+
 func (q *FakeQuerier) InsertWorkspace(_ context.Context, arg database.InsertWorkspaceParams) (database.Workspace, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.Workspace{}, err
@@ -6880,6 +7728,8 @@ func (q *FakeQuerier) InsertWorkspace(_ context.Context, arg database.InsertWork
 		Ttl:               arg.Ttl,
 		LastUsedAt:        arg.LastUsedAt,
 		AutomaticUpdates:  arg.AutomaticUpdates,
+		UserACL:           database.WorkspaceACL{},
+		GroupACL:          database.WorkspaceACL{},
 	}
 	q.workspaces = append(q.workspaces, workspace)
 	return workspace, nil
@@ -7197,6 +8047,52 @@ func (q *FakeQuerier) InsertWorkspaceBuildParameters(_ context.Context, arg data
 	return nil
 }
 
+func (q *FakeQuerier) InsertWorkspaceBulkJob(_ context.Context, arg database.InsertWorkspaceBulkJobParams) (database.WorkspaceBulkJob, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.WorkspaceBulkJob{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	job := database.WorkspaceBulkJob{
+		ID:               arg.ID,
+		InitiatorID:      arg.InitiatorID,
+		InitiatorIP:      arg.InitiatorIP,
+		InitiatorScope:   arg.InitiatorScope,
+		InitiatorScopes:  arg.InitiatorScopes,
+		Action:           arg.Action,
+		Query:            arg.Query,
+		AutomaticUpdates: arg.AutomaticUpdates,
+		Dormant:          arg.Dormant,
+		Concurrency:      arg.Concurrency,
+		CreatedAt:        arg.CreatedAt,
+	}
+	q.workspaceBulkJobs = append(q.workspaceBulkJobs, job)
+	return job, nil
+}
+
+func (q *FakeQuerier) InsertWorkspaceBulkJobResults(_ context.Context, arg database.InsertWorkspaceBulkJobResultsParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, workspaceID := range arg.WorkspaceIds {
+		q.workspaceBulkJobResults = append(q.workspaceBulkJobResults, database.WorkspaceBulkJobResult{
+			JobID:       arg.JobID,
+			WorkspaceID: workspaceID,
+			Status:      database.WorkspaceBulkResultStatusPending,
+			UpdatedAt:   arg.UpdatedAt,
+		})
+	}
+	return nil
+}
+
 func (q *FakeQuerier) InsertWorkspaceProxy(_ context.Context, arg database.InsertWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -7294,21 +8190,41 @@ func (q *FakeQuerier) ListProvisionerKeysByOrganization(_ context.Context, organ
 		}
 	}
 
-	return keys, nil
-}
-
-func (q *FakeQuerier) ListWorkspaceAgentPortShares(_ context.Context, workspaceID uuid.UUID) ([]database.WorkspaceAgentPortShare, error) {
+	return keys, nil
+}
+
+func (q *FakeQuerier) ListWorkspaceAgentPortShares(_ context.Context, workspaceID uuid.UUID) ([]database.WorkspaceAgentPortShare, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	shares := []database.WorkspaceAgentPortShare{}
+	for _, share := range q.workspaceAgentPortShares {
+		if share.WorkspaceID == workspaceID {
+			shares = append(shares, share)
+		}
+	}
+
+	return shares, nil
+}
+
+func (q *FakeQuerier) MarkAllInboxNotificationsAsRead(_ context.Context, arg database.MarkAllInboxNotificationsAsReadParams) (int64, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return 0, err
+	}
+
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
 
-	shares := []database.WorkspaceAgentPortShare{}
-	for _, share := range q.workspaceAgentPortShares {
-		if share.WorkspaceID == workspaceID {
-			shares = append(shares, share)
+	var updated int64
+	for i, notif := range q.inboxNotifications {
+		if notif.UserID != arg.UserID || notif.ReadAt.Valid {
+			continue
 		}
+		q.inboxNotifications[i].ReadAt = sql.NullTime{Time: arg.ReadAt, Valid: true}
+		updated++
 	}
-
-	return shares, nil
+	return updated, nil
 }
 
 func (q *FakeQuerier) OrganizationMembers(_ context.Context, arg database.OrganizationMembersParams) ([]database.OrganizationMembersRow, error) {
@@ -7334,6 +8250,11 @@ func (q *FakeQuerier) OrganizationMembers(_ context.Context, arg database.Organi
 		tmp = append(tmp, database.OrganizationMembersRow{
 			OrganizationMember: organizationMember,
 			Username:           user.Username,
+			AvatarURL:          user.AvatarURL,
+			Name:               user.Name,
+			Email:              user.Email,
+			GlobalRoles:        user.RBACRoles,
+			IsServiceAccount:   user.IsServiceAccount,
 		})
 	}
 	return tmp, nil
@@ -7401,6 +8322,11 @@ func (q *FakeQuerier) RemoveUserFromAllGroups(_ context.Context, userID uuid.UUI
 	return nil
 }
 
+func (*FakeQuerier) ResetNotificationTemplateByID(_ context.Context, _ uuid.UUID) (database.NotificationTemplate, error) {
+	// Notification templates are only stored in postgres.
+	return database.NotificationTemplate{}, ErrUnimplemented
+}
+
 func (q *FakeQuerier) RevokeDBCryptKey(_ context.Context, activeKeyDigest string) error {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -7599,6 +8525,25 @@ func (q *FakeQuerier) UpdateInactiveUsersToDormant(_ context.Context, params dat
 	return updated, nil
 }
 
+func (q *FakeQuerier) UpdateInboxNotificationReadStatus(_ context.Context, arg database.UpdateInboxNotificationReadStatusParams) (database.InboxNotification, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.InboxNotification{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, notif := range q.inboxNotifications {
+		if notif.ID != arg.ID {
+			continue
+		}
+		q.inboxNotifications[i].ReadAt = arg.ReadAt
+		return q.inboxNotifications[i], nil
+	}
+	return database.InboxNotification{}, sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateMemberRoles(_ context.Context, arg database.UpdateMemberRolesParams) (database.OrganizationMember, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.OrganizationMember{}, err
@@ -7629,6 +8574,26 @@ func (q *FakeQuerier) UpdateMemberRoles(_ context.Context, arg database.UpdateMe
 	return database.OrganizationMember{}, sql.ErrNoRows
 }
 
+func (*FakeQuerier) UpdateNotificationTemplateByID(_ context.Context, arg database.UpdateNotificationTemplateByIDParams) (database.NotificationTemplate, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.NotificationTemplate{}, err
+	}
+
+	// Notification templates are only stored in postgres.
+	return database.NotificationTemplate{}, ErrUnimplemented
+}
+
+func (*FakeQuerier) UpdateNotificationTemplateDigestWindowByID(_ context.Context, arg database.UpdateNotificationTemplateDigestWindowByIDParams) (database.NotificationTemplate, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.NotificationTemplate{}, err
+	}
+
+	// Notification templates are only stored in postgres.
+	return database.NotificationTemplate{}, ErrUnimplemented
+}
+
 func (q *FakeQuerier) UpdateOAuth2ProviderAppByID(_ context.Context, arg database.UpdateOAuth2ProviderAppByIDParams) (database.OAuth2ProviderApp, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -7647,12 +8612,13 @@ func (q *FakeQuerier) UpdateOAuth2ProviderAppByID(_ context.Context, arg databas
 	for index, app := range q.oauth2ProviderApps {
 		if app.ID == arg.ID {
 			newApp := database.OAuth2ProviderApp{
-				ID:          arg.ID,
-				CreatedAt:   app.CreatedAt,
-				UpdatedAt:   arg.UpdatedAt,
-				Name:        arg.Name,
-				Icon:        arg.Icon,
-				CallbackURL: arg.CallbackURL,
+				ID:               arg.ID,
+				CreatedAt:        app.CreatedAt,
+				UpdatedAt:        arg.UpdatedAt,
+				Name:             arg.Name,
+				Icon:             arg.Icon,
+				CallbackURL:      arg.CallbackURL,
+				ServiceAccountID: arg.ServiceAccountID,
 			}
 			q.oauth2ProviderApps[index] = newApp
 			return newApp, nil
@@ -7661,6 +8627,25 @@ func (q *FakeQuerier) UpdateOAuth2ProviderAppByID(_ context.Context, arg databas
 	return database.OAuth2ProviderApp{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateOAuth2ProviderAppDeviceCodeByID(_ context.Context, arg database.UpdateOAuth2ProviderAppDeviceCodeByIDParams) (database.OAuth2ProviderAppDeviceCode, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.OAuth2ProviderAppDeviceCode{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for index, code := range q.oauth2ProviderAppDeviceCodes {
+		if code.ID == arg.ID {
+			code.UserID = arg.UserID
+			q.oauth2ProviderAppDeviceCodes[index] = code
+			return code, nil
+		}
+	}
+	return database.OAuth2ProviderAppDeviceCode{}, sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateOAuth2ProviderAppSecretByID(_ context.Context, arg database.UpdateOAuth2ProviderAppSecretByIDParams) (database.OAuth2ProviderAppSecret, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -7940,6 +8925,25 @@ func (q *FakeQuerier) UpdateTemplateMetaByID(_ context.Context, arg database.Upd
 	return sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateTemplatePrebuildPoolByID(_ context.Context, arg database.UpdateTemplatePrebuildPoolByIDParams) (database.TemplatePrebuildPool, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.TemplatePrebuildPool{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, pool := range q.templatePrebuildPools {
+		if pool.ID == arg.ID {
+			q.templatePrebuildPools[i].DesiredInstances = arg.DesiredInstances
+			q.templatePrebuildPools[i].UpdatedAt = arg.UpdatedAt
+			return q.templatePrebuildPools[i], nil
+		}
+	}
+	return database.TemplatePrebuildPool{}, sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateTemplateScheduleByID(_ context.Context, arg database.UpdateTemplateScheduleByIDParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -8298,6 +9302,47 @@ func (q *FakeQuerier) UpdateUserStatus(_ context.Context, arg database.UpdateUse
 	return database.User{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateUserTOTPSecretConfirmed(_ context.Context, arg database.UpdateUserTOTPSecretConfirmedParams) (database.UserTOTPSecret, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.UserTOTPSecret{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, secret := range q.userTOTPSecrets {
+		if secret.UserID != arg.UserID {
+			continue
+		}
+		secret.ConfirmedAt = arg.ConfirmedAt
+		secret.HashedRecoveryCodes = arg.HashedRecoveryCodes
+		secret.LastUsedCounter = arg.LastUsedCounter
+		q.userTOTPSecrets[i] = secret
+		return secret, nil
+	}
+	return database.UserTOTPSecret{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) UpdateUserTOTPSecretLastUsedCounter(_ context.Context, arg database.UpdateUserTOTPSecretLastUsedCounterParams) (int64, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, secret := range q.userTOTPSecrets {
+		if secret.UserID != arg.UserID || secret.LastUsedCounter >= arg.LastUsedCounter {
+			continue
+		}
+		q.userTOTPSecrets[i].LastUsedCounter = arg.LastUsedCounter
+		return 1, nil
+	}
+	return 0, nil
+}
+
 func (q *FakeQuerier) UpdateWorkspace(_ context.Context, arg database.UpdateWorkspaceParams) (database.Workspace, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.Workspace{}, err
@@ -8328,6 +9373,27 @@ func (q *FakeQuerier) UpdateWorkspace(_ context.Context, arg database.UpdateWork
 	return database.Workspace{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateWorkspaceACLByID(_ context.Context, arg database.UpdateWorkspaceACLByIDParams) error {
+	if err := validateDatabaseType(arg); err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, workspace := range q.workspaces {
+		if workspace.ID == arg.ID {
+			workspace.GroupACL = arg.GroupACL
+			workspace.UserACL = arg.UserACL
+
+			q.workspaces[i] = workspace
+			return nil
+		}
+	}
+
+	return sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateWorkspaceAgentConnectionByID(_ context.Context, arg database.UpdateWorkspaceAgentConnectionByIDParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -8571,6 +9637,63 @@ func (q *FakeQuerier) UpdateWorkspaceBuildProvisionerStateByID(_ context.Context
 	return sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateWorkspaceBulkJobCompletedAtByID(_ context.Context, arg database.UpdateWorkspaceBulkJobCompletedAtByIDParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, job := range q.workspaceBulkJobs {
+		if job.ID == arg.ID {
+			q.workspaceBulkJobs[i].CompletedAt = arg.CompletedAt
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (q *FakeQuerier) UpdateWorkspaceBulkJobHeartbeat(_ context.Context, arg database.UpdateWorkspaceBulkJobHeartbeatParams) (database.WorkspaceBulkJob, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.WorkspaceBulkJob{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, job := range q.workspaceBulkJobs {
+		if job.ID == arg.ID && job.WorkerID.Valid && job.WorkerID.UUID == arg.WorkerID {
+			q.workspaceBulkJobs[i].HeartbeatAt = sql.NullTime{Time: arg.HeartbeatAt, Valid: true}
+			return q.workspaceBulkJobs[i], nil
+		}
+	}
+	return database.WorkspaceBulkJob{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) UpdateWorkspaceBulkJobResult(_ context.Context, arg database.UpdateWorkspaceBulkJobResultParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, result := range q.workspaceBulkJobResults {
+		if result.JobID == arg.JobID && result.WorkspaceID == arg.WorkspaceID {
+			q.workspaceBulkJobResults[i].Status = arg.Status
+			q.workspaceBulkJobResults[i].Error = arg.Error
+			q.workspaceBulkJobResults[i].BuildID = arg.BuildID
+			q.workspaceBulkJobResults[i].UpdatedAt = arg.UpdatedAt
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateWorkspaceDeletedByID(_ context.Context, arg database.UpdateWorkspaceDeletedByIDParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -8649,6 +9772,40 @@ func (q *FakeQuerier) UpdateWorkspaceLastUsedAt(_ context.Context, arg database.
 	return sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateWorkspaceOwner(_ context.Context, arg database.UpdateWorkspaceOwnerParams) (database.Workspace, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return database.Workspace{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, workspace := range q.workspaces {
+		if workspace.Deleted || workspace.ID != arg.ID {
+			continue
+		}
+		for _, other := range q.workspaces {
+			if other.Deleted || other.ID == workspace.ID || other.OwnerID != arg.OwnerID {
+				continue
+			}
+			if strings.EqualFold(other.Name, workspace.Name) {
+				return database.Workspace{}, errUniqueConstraint
+			}
+		}
+
+		workspace.OwnerID = arg.OwnerID
+		workspace.Favorite = false
+		workspace.UserACL = database.WorkspaceACL{}
+		workspace.GroupACL = database.WorkspaceACL{}
+		workspace.UpdatedAt = arg.UpdatedAt
+		q.workspaces[i] = workspace
+
+		return workspace, nil
+	}
+
+	return database.Workspace{}, sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateWorkspaceProxy(_ context.Context, arg database.UpdateWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -9553,6 +10710,127 @@ TemplateUsageStatsInsertLoop:
 	return nil
 }
 
+func (q *FakeQuerier) UpsertTemporaryRoleGrant(_ context.Context, arg database.UpsertTemporaryRoleGrantParams) (database.TemporaryRoleGrant, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.TemporaryRoleGrant{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, grant := range q.temporaryRoleGrants {
+		if grant.UserID == arg.UserID && grant.OrganizationID == arg.OrganizationID && grant.RoleName == arg.RoleName {
+			q.temporaryRoleGrants[i].Justification = arg.Justification
+			q.temporaryRoleGrants[i].CreatedAt = arg.CreatedAt
+			q.temporaryRoleGrants[i].ExpiresAt = arg.ExpiresAt
+			return q.temporaryRoleGrants[i], nil
+		}
+	}
+	grant := database.TemporaryRoleGrant(arg)
+	q.temporaryRoleGrants = append(q.temporaryRoleGrants, grant)
+	return grant, nil
+}
+
+func (q *FakeQuerier) UpsertUserFailedLoginAttempts(_ context.Context, arg database.UpsertUserFailedLoginAttemptsParams) (int32, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, attempt := range q.userLoginAttempts {
+		if attempt.UserID == arg.UserID {
+			q.userLoginAttempts[i].FailedAttempts++
+			q.userLoginAttempts[i].LastFailedAt = arg.LastFailedAt
+			return q.userLoginAttempts[i].FailedAttempts, nil
+		}
+	}
+	q.userLoginAttempts = append(q.userLoginAttempts, database.UserLoginAttempt{
+		UserID:         arg.UserID,
+		FailedAttempts: 1,
+		LastFailedAt:   arg.LastFailedAt,
+	})
+	return 1, nil
+}
+
+func (q *FakeQuerier) UpsertUserNotificationPreferences(_ context.Context, arg database.UpsertUserNotificationPreferencesParams) (int64, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(arg.NotificationTemplateIDs) != len(arg.Disabled) || len(arg.NotificationTemplateIDs) != len(arg.Methods) {
+		return 0, xerrors.Errorf("mismatched number of template IDs, disabled flags and methods")
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var upserted int64
+	for i, templateID := range arg.NotificationTemplateIDs {
+		var method database.NullNotificationMethod
+		if arg.Methods[i] != "" {
+			method = database.NullNotificationMethod{NotificationMethod: database.NotificationMethod(arg.Methods[i]), Valid: true}
+			if !method.NotificationMethod.Valid() {
+				return 0, xerrors.Errorf("invalid notification method %q", arg.Methods[i])
+			}
+		}
+
+		var found bool
+		for j, np := range q.notificationPreferences {
+			if np.UserID != arg.UserID || np.NotificationTemplateID != templateID {
+				continue
+			}
+			np.Disabled = arg.Disabled[i]
+			np.Method = method
+			np.UpdatedAt = dbtime.Now()
+			q.notificationPreferences[j] = np
+			found = true
+			break
+		}
+		if !found {
+			q.notificationPreferences = append(q.notificationPreferences, database.NotificationPreference{
+				UserID:                 arg.UserID,
+				NotificationTemplateID: templateID,
+				Disabled:               arg.Disabled[i],
+				Method:                 method,
+				CreatedAt:              dbtime.Now(),
+				UpdatedAt:              dbtime.Now(),
+			})
+		}
+		upserted++
+	}
+	return upserted, nil
+}
+
+func (q *FakeQuerier) UpsertUserTOTPSecret(_ context.Context, arg database.UpsertUserTOTPSecretParams) (database.UserTOTPSecret, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.UserTOTPSecret{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	secret := database.UserTOTPSecret{
+		UserID:              arg.UserID,
+		Secret:              arg.Secret,
+		HashedRecoveryCodes: []string{},
+		CreatedAt:           arg.CreatedAt,
+	}
+	for i, existing := range q.userTOTPSecrets {
+		if existing.UserID == arg.UserID {
+			q.userTOTPSecrets[i] = secret
+			return secret, nil
+		}
+	}
+	q.userTOTPSecrets = append(q.userTOTPSecrets, secret)
+	return secret, nil
+}
+
 func (q *FakeQuerier) UpsertWorkspaceAgentPortShare(_ context.Context, arg database.UpsertWorkspaceAgentPortShareParams) (database.WorkspaceAgentPortShare, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -9736,7 +11014,7 @@ func (q *FakeQuerier) GetAuthorizedWorkspaces(ctx context.Context, arg database.
 
 	if prepared != nil {
 		// Call this to match the same function calls as the SQL implementation.
-		_, err := prepared.CompileToSQL(ctx, rbac.ConfigWithoutACL())
+		_, err := prepared.CompileToSQL(ctx, rbac.ConfigWorkspaces())
 		if err != nil {
 			return nil, err
 		}
@@ -10080,3 +11358,41 @@ func (q *FakeQuerier) GetAuthorizedUsers(ctx context.Context, arg database.GetUs
 	}
 	return filteredUsers, nil
 }
+
+func (q *FakeQuerier) GetFilteredUsers(ctx context.Context, arg database.GetUsersParams, filter database.UserFilter, prepared rbac.PreparedAuthorized) ([]database.GetUsersRow, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return nil, err
+	}
+
+	// The filter applies before the page is selected.
+	unpaged := arg
+	unpaged.OffsetOpt = 0
+	unpaged.LimitOpt = 0
+	users, err := q.GetAuthorizedUsers(ctx, unpaged, prepared)
+	if err != nil {
+		return nil, err
+	}
+
+	filteredUsers := make([]database.GetUsersRow, 0, len(users))
+	for _, user := range users {
+		if filter != nil && !filter.Match(database.ConvertUserRows([]database.GetUsersRow{user})[0]) {
+			continue
+		}
+		filteredUsers = append(filteredUsers, user)
+	}
+
+	count := int64(len(filteredUsers))
+	if arg.OffsetOpt > 0 {
+		if int(arg.OffsetOpt) > len(filteredUsers)-1 {
+			return []database.GetUsersRow{}, nil
+		}
+		filteredUsers = filteredUsers[arg.OffsetOpt:]
+	}
+	if arg.LimitOpt > 0 && int(arg.LimitOpt) < len(filteredUsers) {
+		filteredUsers = filteredUsers[:arg.LimitOpt]
+	}
+	for i := range filteredUsers {
+		filteredUsers[i].Count = count
+	}
+	return filteredUsers, nil
+}
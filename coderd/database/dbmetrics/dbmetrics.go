@@ -102,6 +102,13 @@ func (m metricsStore) AcquireProvisionerJob(ctx context.Context, arg database.Ac
 	return provisionerJob, err
 }
 
+func (m metricsStore) AcquireWorkspaceBulkJob(ctx context.Context, arg database.AcquireWorkspaceBulkJobParams) (database.WorkspaceBulkJob, error) {
+	start := time.Now()
+	job, err := m.s.AcquireWorkspaceBulkJob(ctx, arg)
+	m.queryLatencies.WithLabelValues("AcquireWorkspaceBulkJob").Observe(time.Since(start).Seconds())
+	return job, err
+}
+
 func (m metricsStore) ActivityBumpWorkspace(ctx context.Context, arg database.ActivityBumpWorkspaceParams) error {
 	start := time.Now()
 	r0 := m.s.ActivityBumpWorkspace(ctx, arg)
@@ -144,6 +151,13 @@ func (m metricsStore) BulkMarkNotificationMessagesSent(ctx context.Context, arg
 	return r0, r1
 }
 
+func (m metricsStore) ClaimPrebuiltWorkspace(ctx context.Context, arg database.ClaimPrebuiltWorkspaceParams) (database.Workspace, error) {
+	start := time.Now()
+	r0, r1 := m.s.ClaimPrebuiltWorkspace(ctx, arg)
+	m.queryLatencies.WithLabelValues("ClaimPrebuiltWorkspace").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) CleanTailnetCoordinators(ctx context.Context) error {
 	start := time.Now()
 	err := m.s.CleanTailnetCoordinators(ctx)
@@ -165,6 +179,13 @@ func (m metricsStore) CleanTailnetTunnels(ctx context.Context) error {
 	return r0
 }
 
+func (m metricsStore) CountUnreadInboxNotificationsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.CountUnreadInboxNotificationsByUserID(ctx, userID)
+	m.queryLatencies.WithLabelValues("CountUnreadInboxNotificationsByUserID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) CustomRoles(ctx context.Context, arg database.CustomRolesParams) ([]database.CustomRole, error) {
 	start := time.Now()
 	r0, r1 := m.s.CustomRoles(ctx, arg)
@@ -214,6 +235,13 @@ func (m metricsStore) DeleteCoordinator(ctx context.Context, id uuid.UUID) error
 	return r0
 }
 
+func (m metricsStore) DeleteExpiredOAuth2ProviderAppDeviceCodes(ctx context.Context, beforeTime time.Time) error {
+	start := time.Now()
+	r0 := m.s.DeleteExpiredOAuth2ProviderAppDeviceCodes(ctx, beforeTime)
+	m.queryLatencies.WithLabelValues("DeleteExpiredOAuth2ProviderAppDeviceCodes").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) DeleteExternalAuthLink(ctx context.Context, arg database.DeleteExternalAuthLinkParams) error {
 	start := time.Now()
 	r0 := m.s.DeleteExternalAuthLink(ctx, arg)
@@ -270,6 +298,13 @@ func (m metricsStore) DeleteOAuth2ProviderAppCodesByAppAndUserID(ctx context.Con
 	return r0
 }
 
+func (m metricsStore) DeleteOAuth2ProviderAppDeviceCodeByID(ctx context.Context, id uuid.UUID) error {
+	start := time.Now()
+	err := m.s.DeleteOAuth2ProviderAppDeviceCodeByID(ctx, id)
+	m.queryLatencies.WithLabelValues("DeleteOAuth2ProviderAppDeviceCodeByID").Observe(time.Since(start).Seconds())
+	return err
+}
+
 func (m metricsStore) DeleteOAuth2ProviderAppSecretByID(ctx context.Context, id uuid.UUID) error {
 	start := time.Now()
 	r0 := m.s.DeleteOAuth2ProviderAppSecretByID(ctx, id)
@@ -284,6 +319,13 @@ func (m metricsStore) DeleteOAuth2ProviderAppTokensByAppAndUserID(ctx context.Co
 	return r0
 }
 
+func (m metricsStore) DeleteOldAuditLogs(ctx context.Context, beforeTime time.Time) error {
+	start := time.Now()
+	r0 := m.s.DeleteOldAuditLogs(ctx, beforeTime)
+	m.queryLatencies.WithLabelValues("DeleteOldAuditLogs").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) DeleteOldNotificationMessages(ctx context.Context) error {
 	start := time.Now()
 	r0 := m.s.DeleteOldNotificationMessages(ctx)
@@ -298,6 +340,13 @@ func (m metricsStore) DeleteOldProvisionerDaemons(ctx context.Context) error {
 	return r0
 }
 
+func (m metricsStore) DeleteOldUserPasswordHistory(ctx context.Context, arg database.DeleteOldUserPasswordHistoryParams) error {
+	start := time.Now()
+	r0 := m.s.DeleteOldUserPasswordHistory(ctx, arg)
+	m.queryLatencies.WithLabelValues("DeleteOldUserPasswordHistory").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) DeleteOldWorkspaceAgentLogs(ctx context.Context) error {
 	start := time.Now()
 	r0 := m.s.DeleteOldWorkspaceAgentLogs(ctx)
@@ -375,6 +424,55 @@ func (m metricsStore) DeleteTailnetTunnel(ctx context.Context, arg database.Dele
 	return r0, r1
 }
 
+func (m metricsStore) DeleteTemplatePrebuildPoolByID(ctx context.Context, id uuid.UUID) error {
+	start := time.Now()
+	err := m.s.DeleteTemplatePrebuildPoolByID(ctx, id)
+	m.queryLatencies.WithLabelValues("DeleteTemplatePrebuildPoolByID").Observe(time.Since(start).Seconds())
+	return err
+}
+
+func (m metricsStore) DeleteTemplateVersionPresetsByTemplateVersionID(ctx context.Context, templateVersionID uuid.UUID) error {
+	start := time.Now()
+	err := m.s.DeleteTemplateVersionPresetsByTemplateVersionID(ctx, templateVersionID)
+	m.queryLatencies.WithLabelValues("DeleteTemplateVersionPresetsByTemplateVersionID").Observe(time.Since(start).Seconds())
+	return err
+}
+
+func (m metricsStore) DeleteTemporaryRoleGrantByID(ctx context.Context, id uuid.UUID) error {
+	start := time.Now()
+	err := m.s.DeleteTemporaryRoleGrantByID(ctx, id)
+	m.queryLatencies.WithLabelValues("DeleteTemporaryRoleGrantByID").Observe(time.Since(start).Seconds())
+	return err
+}
+
+func (m metricsStore) DeleteTemporaryRoleGrants(ctx context.Context, arg database.DeleteTemporaryRoleGrantsParams) error {
+	start := time.Now()
+	err := m.s.DeleteTemporaryRoleGrants(ctx, arg)
+	m.queryLatencies.WithLabelValues("DeleteTemporaryRoleGrants").Observe(time.Since(start).Seconds())
+	return err
+}
+
+func (m metricsStore) DeleteUserLoginAttempts(ctx context.Context, userID uuid.UUID) error {
+	start := time.Now()
+	r0 := m.s.DeleteUserLoginAttempts(ctx, userID)
+	m.queryLatencies.WithLabelValues("DeleteUserLoginAttempts").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) DeleteUserTOTPRecoveryCode(ctx context.Context, arg database.DeleteUserTOTPRecoveryCodeParams) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.DeleteUserTOTPRecoveryCode(ctx, arg)
+	m.queryLatencies.WithLabelValues("DeleteUserTOTPRecoveryCode").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) DeleteUserTOTPSecret(ctx context.Context, userID uuid.UUID) error {
+	start := time.Now()
+	err := m.s.DeleteUserTOTPSecret(ctx, userID)
+	m.queryLatencies.WithLabelValues("DeleteUserTOTPSecret").Observe(time.Since(start).Seconds())
+	return err
+}
+
 func (m metricsStore) DeleteWorkspaceAgentPortShare(ctx context.Context, arg database.DeleteWorkspaceAgentPortShareParams) error {
 	start := time.Now()
 	r0 := m.s.DeleteWorkspaceAgentPortShare(ctx, arg)
@@ -578,6 +676,13 @@ func (m metricsStore) GetDeploymentWorkspaceStats(ctx context.Context) (database
 	return row, err
 }
 
+func (m metricsStore) GetExpiredTemporaryRoleGrants(ctx context.Context, now time.Time) ([]database.TemporaryRoleGrant, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetExpiredTemporaryRoleGrants(ctx, now)
+	m.queryLatencies.WithLabelValues("GetExpiredTemporaryRoleGrants").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetExternalAuthLink(ctx context.Context, arg database.GetExternalAuthLinkParams) (database.ExternalAuthLink, error) {
 	start := time.Now()
 	link, err := m.s.GetExternalAuthLink(ctx, arg)
@@ -683,6 +788,20 @@ func (m metricsStore) GetHungProvisionerJobs(ctx context.Context, hungSince time
 	return jobs, err
 }
 
+func (m metricsStore) GetInboxNotificationByID(ctx context.Context, id uuid.UUID) (database.InboxNotification, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetInboxNotificationByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetInboxNotificationByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetInboxNotificationsByUserID(ctx context.Context, arg database.GetInboxNotificationsByUserIDParams) ([]database.InboxNotification, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetInboxNotificationsByUserID(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetInboxNotificationsByUserID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetJFrogXrayScanByWorkspaceAndAgentID(ctx context.Context, arg database.GetJFrogXrayScanByWorkspaceAndAgentIDParams) (database.JfrogXrayScan, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetJFrogXrayScanByWorkspaceAndAgentID(ctx, arg)
@@ -746,6 +865,20 @@ func (m metricsStore) GetNotificationMessagesByStatus(ctx context.Context, arg d
 	return r0, r1
 }
 
+func (m metricsStore) GetNotificationTemplateByID(ctx context.Context, id uuid.UUID) (database.NotificationTemplate, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetNotificationTemplateByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetNotificationTemplateByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetNotificationTemplates(ctx context.Context) ([]database.NotificationTemplate, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetNotificationTemplates(ctx)
+	m.queryLatencies.WithLabelValues("GetNotificationTemplates").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetNotificationsSettings(ctx context.Context) (string, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetNotificationsSettings(ctx)
@@ -774,6 +907,27 @@ func (m metricsStore) GetOAuth2ProviderAppCodeByPrefix(ctx context.Context, secr
 	return r0, r1
 }
 
+func (m metricsStore) GetOAuth2ProviderAppDeviceCodeByID(ctx context.Context, id uuid.UUID) (database.OAuth2ProviderAppDeviceCode, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetOAuth2ProviderAppDeviceCodeByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetOAuth2ProviderAppDeviceCodeByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetOAuth2ProviderAppDeviceCodeByPrefix(ctx context.Context, secretPrefix []byte) (database.OAuth2ProviderAppDeviceCode, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetOAuth2ProviderAppDeviceCodeByPrefix(ctx, secretPrefix)
+	m.queryLatencies.WithLabelValues("GetOAuth2ProviderAppDeviceCodeByPrefix").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetOAuth2ProviderAppDeviceCodeByUserCode(ctx context.Context, userCode string) (database.OAuth2ProviderAppDeviceCode, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetOAuth2ProviderAppDeviceCodeByUserCode(ctx, userCode)
+	m.queryLatencies.WithLabelValues("GetOAuth2ProviderAppDeviceCodeByUserCode").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetOAuth2ProviderAppSecretByID(ctx context.Context, id uuid.UUID) (database.OAuth2ProviderAppSecret, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetOAuth2ProviderAppSecretByID(ctx, id)
@@ -795,6 +949,13 @@ func (m metricsStore) GetOAuth2ProviderAppSecretsByAppID(ctx context.Context, ap
 	return r0, r1
 }
 
+func (m metricsStore) GetOAuth2ProviderAppTokenByAPIKeyID(ctx context.Context, apiKeyID string) (database.OAuth2ProviderAppToken, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetOAuth2ProviderAppTokenByAPIKeyID(ctx, apiKeyID)
+	m.queryLatencies.WithLabelValues("GetOAuth2ProviderAppTokenByAPIKeyID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetOAuth2ProviderAppTokenByPrefix(ctx context.Context, hashPrefix []byte) (database.OAuth2ProviderAppToken, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetOAuth2ProviderAppTokenByPrefix(ctx, hashPrefix)
@@ -865,6 +1026,13 @@ func (m metricsStore) GetParameterSchemasByJobID(ctx context.Context, jobID uuid
 	return schemas, err
 }
 
+func (m metricsStore) GetPrebuiltWorkspaces(ctx context.Context, templateID uuid.UUID) ([]database.GetPrebuiltWorkspacesRow, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetPrebuiltWorkspaces(ctx, templateID)
+	m.queryLatencies.WithLabelValues("GetPrebuiltWorkspaces").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetPreviousTemplateVersion(ctx context.Context, arg database.GetPreviousTemplateVersionParams) (database.TemplateVersion, error) {
 	start := time.Now()
 	version, err := m.s.GetPreviousTemplateVersion(ctx, arg)
@@ -970,6 +1138,13 @@ func (m metricsStore) GetReplicasUpdatedAfter(ctx context.Context, updatedAt tim
 	return replicas, err
 }
 
+func (m metricsStore) GetSessionAPIKeysByUserID(ctx context.Context, userID uuid.UUID) ([]database.APIKey, error) {
+	start := time.Now()
+	apiKeys, err := m.s.GetSessionAPIKeysByUserID(ctx, userID)
+	m.queryLatencies.WithLabelValues("GetSessionAPIKeysByUserID").Observe(time.Since(start).Seconds())
+	return apiKeys, err
+}
+
 func (m metricsStore) GetTailnetAgents(ctx context.Context, id uuid.UUID) ([]database.TailnetAgent, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetTailnetAgents(ctx, id)
@@ -1075,6 +1250,20 @@ func (m metricsStore) GetTemplateParameterInsights(ctx context.Context, arg data
 	return r0, r1
 }
 
+func (m metricsStore) GetTemplatePrebuildPoolByID(ctx context.Context, id uuid.UUID) (database.TemplatePrebuildPool, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplatePrebuildPoolByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetTemplatePrebuildPoolByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetTemplatePrebuildPools(ctx context.Context, templateID uuid.UUID) ([]database.TemplatePrebuildPool, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplatePrebuildPools(ctx, templateID)
+	m.queryLatencies.WithLabelValues("GetTemplatePrebuildPools").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetTemplateUsageStats(ctx context.Context, arg database.GetTemplateUsageStatsParams) ([]database.TemplateUsageStat, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetTemplateUsageStats(ctx, arg)
@@ -1110,6 +1299,20 @@ func (m metricsStore) GetTemplateVersionParameters(ctx context.Context, template
 	return parameters, err
 }
 
+func (m metricsStore) GetTemplateVersionPresetByID(ctx context.Context, id uuid.UUID) (database.TemplateVersionPreset, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplateVersionPresetByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetTemplateVersionPresetByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetTemplateVersionPresetsByTemplateVersionIDs(ctx context.Context, ids []uuid.UUID) ([]database.TemplateVersionPreset, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplateVersionPresetsByTemplateVersionIDs(ctx, ids)
+	m.queryLatencies.WithLabelValues("GetTemplateVersionPresetsByTemplateVersionIDs").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetTemplateVersionVariables(ctx context.Context, templateVersionID uuid.UUID) ([]database.TemplateVersionVariable, error) {
 	start := time.Now()
 	variables, err := m.s.GetTemplateVersionVariables(ctx, templateVersionID)
@@ -1159,6 +1362,13 @@ func (m metricsStore) GetTemplatesWithFilter(ctx context.Context, arg database.G
 	return templates, err
 }
 
+func (m metricsStore) GetTemporaryRoleGrantsByUserID(ctx context.Context, userID uuid.UUID) ([]database.TemporaryRoleGrant, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemporaryRoleGrantsByUserID(ctx, userID)
+	m.queryLatencies.WithLabelValues("GetTemporaryRoleGrantsByUserID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetUnexpiredLicenses(ctx context.Context) ([]database.License, error) {
 	start := time.Now()
 	licenses, err := m.s.GetUnexpiredLicenses(ctx)
@@ -1222,6 +1432,41 @@ func (m metricsStore) GetUserLinksByUserID(ctx context.Context, userID uuid.UUID
 	return r0, r1
 }
 
+func (m metricsStore) GetUserLoginAttempts(ctx context.Context, userID uuid.UUID) (database.UserLoginAttempt, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetUserLoginAttempts(ctx, userID)
+	m.queryLatencies.WithLabelValues("GetUserLoginAttempts").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetUserNotificationPreference(ctx context.Context, arg database.GetUserNotificationPreferenceParams) (database.NotificationPreference, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetUserNotificationPreference(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetUserNotificationPreference").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetUserNotificationPreferences(ctx context.Context, userID uuid.UUID) ([]database.GetUserNotificationPreferencesRow, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetUserNotificationPreferences(ctx, userID)
+	m.queryLatencies.WithLabelValues("GetUserNotificationPreferences").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetUserPasswordHistory(ctx context.Context, arg database.GetUserPasswordHistoryParams) ([]database.UserPasswordHistory, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetUserPasswordHistory(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetUserPasswordHistory").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetUserTOTPSecret(ctx context.Context, userID uuid.UUID) (database.UserTOTPSecret, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetUserTOTPSecret(ctx, userID)
+	m.queryLatencies.WithLabelValues("GetUserTOTPSecret").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetUserWorkspaceBuildParameters(ctx context.Context, ownerID database.GetUserWorkspaceBuildParametersParams) ([]database.GetUserWorkspaceBuildParametersRow, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetUserWorkspaceBuildParameters(ctx, ownerID)
@@ -1411,6 +1656,20 @@ func (m metricsStore) GetWorkspaceBuildsCreatedAfter(ctx context.Context, create
 	return builds, err
 }
 
+func (m metricsStore) GetWorkspaceBulkJobByID(ctx context.Context, id uuid.UUID) (database.WorkspaceBulkJob, error) {
+	start := time.Now()
+	job, err := m.s.GetWorkspaceBulkJobByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetWorkspaceBulkJobByID").Observe(time.Since(start).Seconds())
+	return job, err
+}
+
+func (m metricsStore) GetWorkspaceBulkJobResultsByJobID(ctx context.Context, jobID uuid.UUID) ([]database.GetWorkspaceBulkJobResultsByJobIDRow, error) {
+	start := time.Now()
+	results, err := m.s.GetWorkspaceBulkJobResultsByJobID(ctx, jobID)
+	m.queryLatencies.WithLabelValues("GetWorkspaceBulkJobResultsByJobID").Observe(time.Since(start).Seconds())
+	return results, err
+}
+
 func (m metricsStore) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUID) (database.GetWorkspaceByAgentIDRow, error) {
 	start := time.Now()
 	workspace, err := m.s.GetWorkspaceByAgentID(ctx, agentID)
@@ -1607,6 +1866,13 @@ func (m metricsStore) InsertGroupMember(ctx context.Context, arg database.Insert
 	return err
 }
 
+func (m metricsStore) InsertInboxNotification(ctx context.Context, arg database.InsertInboxNotificationParams) error {
+	start := time.Now()
+	r0 := m.s.InsertInboxNotification(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertInboxNotification").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) InsertLicense(ctx context.Context, arg database.InsertLicenseParams) (database.License, error) {
 	start := time.Now()
 	license, err := m.s.InsertLicense(ctx, arg)
@@ -1635,6 +1901,13 @@ func (m metricsStore) InsertOAuth2ProviderAppCode(ctx context.Context, arg datab
 	return r0, r1
 }
 
+func (m metricsStore) InsertOAuth2ProviderAppDeviceCode(ctx context.Context, arg database.InsertOAuth2ProviderAppDeviceCodeParams) (database.OAuth2ProviderAppDeviceCode, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertOAuth2ProviderAppDeviceCode(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertOAuth2ProviderAppDeviceCode").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) InsertOAuth2ProviderAppSecret(ctx context.Context, arg database.InsertOAuth2ProviderAppSecretParams) (database.OAuth2ProviderAppSecret, error) {
 	start := time.Now()
 	r0, r1 := m.s.InsertOAuth2ProviderAppSecret(ctx, arg)
@@ -1663,6 +1936,13 @@ func (m metricsStore) InsertOrganizationMember(ctx context.Context, arg database
 	return member, err
 }
 
+func (m metricsStore) InsertPrebuiltWorkspace(ctx context.Context, arg database.InsertPrebuiltWorkspaceParams) error {
+	start := time.Now()
+	err := m.s.InsertPrebuiltWorkspace(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertPrebuiltWorkspace").Observe(time.Since(start).Seconds())
+	return err
+}
+
 func (m metricsStore) InsertProvisionerJob(ctx context.Context, arg database.InsertProvisionerJobParams) (database.ProvisionerJob, error) {
 	start := time.Now()
 	job, err := m.s.InsertProvisionerJob(ctx, arg)
@@ -1698,6 +1978,13 @@ func (m metricsStore) InsertTemplate(ctx context.Context, arg database.InsertTem
 	return err
 }
 
+func (m metricsStore) InsertTemplatePrebuildPool(ctx context.Context, arg database.InsertTemplatePrebuildPoolParams) (database.TemplatePrebuildPool, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertTemplatePrebuildPool(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertTemplatePrebuildPool").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) InsertTemplateVersion(ctx context.Context, arg database.InsertTemplateVersionParams) error {
 	start := time.Now()
 	err := m.s.InsertTemplateVersion(ctx, arg)
@@ -1712,6 +1999,13 @@ func (m metricsStore) InsertTemplateVersionParameter(ctx context.Context, arg da
 	return parameter, err
 }
 
+func (m metricsStore) InsertTemplateVersionPreset(ctx context.Context, arg database.InsertTemplateVersionPresetParams) (database.TemplateVersionPreset, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertTemplateVersionPreset(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertTemplateVersionPreset").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) InsertTemplateVersionVariable(ctx context.Context, arg database.InsertTemplateVersionVariableParams) (database.TemplateVersionVariable, error) {
 	start := time.Now()
 	variable, err := m.s.InsertTemplateVersionVariable(ctx, arg)
@@ -1747,6 +2041,13 @@ func (m metricsStore) InsertUserLink(ctx context.Context, arg database.InsertUse
 	return link, err
 }
 
+func (m metricsStore) InsertUserPasswordHistory(ctx context.Context, arg database.InsertUserPasswordHistoryParams) error {
+	start := time.Now()
+	r0 := m.s.InsertUserPasswordHistory(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertUserPasswordHistory").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) InsertWorkspace(ctx context.Context, arg database.InsertWorkspaceParams) (database.Workspace, error) {
 	start := time.Now()
 	workspace, err := m.s.InsertWorkspace(ctx, arg)
@@ -1824,6 +2125,20 @@ func (m metricsStore) InsertWorkspaceBuildParameters(ctx context.Context, arg da
 	return err
 }
 
+func (m metricsStore) InsertWorkspaceBulkJob(ctx context.Context, arg database.InsertWorkspaceBulkJobParams) (database.WorkspaceBulkJob, error) {
+	start := time.Now()
+	job, err := m.s.InsertWorkspaceBulkJob(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertWorkspaceBulkJob").Observe(time.Since(start).Seconds())
+	return job, err
+}
+
+func (m metricsStore) InsertWorkspaceBulkJobResults(ctx context.Context, arg database.InsertWorkspaceBulkJobResultsParams) error {
+	start := time.Now()
+	r0 := m.s.InsertWorkspaceBulkJobResults(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertWorkspaceBulkJobResults").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) InsertWorkspaceProxy(ctx context.Context, arg database.InsertWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	start := time.Now()
 	proxy, err := m.s.InsertWorkspaceProxy(ctx, arg)
@@ -1859,6 +2174,13 @@ func (m metricsStore) ListWorkspaceAgentPortShares(ctx context.Context, workspac
 	return r0, r1
 }
 
+func (m metricsStore) MarkAllInboxNotificationsAsRead(ctx context.Context, arg database.MarkAllInboxNotificationsAsReadParams) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.MarkAllInboxNotificationsAsRead(ctx, arg)
+	m.queryLatencies.WithLabelValues("MarkAllInboxNotificationsAsRead").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) OrganizationMembers(ctx context.Context, arg database.OrganizationMembersParams) ([]database.OrganizationMembersRow, error) {
 	start := time.Now()
 	r0, r1 := m.s.OrganizationMembers(ctx, arg)
@@ -1887,6 +2209,13 @@ func (m metricsStore) RemoveUserFromAllGroups(ctx context.Context, userID uuid.U
 	return r0
 }
 
+func (m metricsStore) ResetNotificationTemplateByID(ctx context.Context, id uuid.UUID) (database.NotificationTemplate, error) {
+	start := time.Now()
+	r0, r1 := m.s.ResetNotificationTemplateByID(ctx, id)
+	m.queryLatencies.WithLabelValues("ResetNotificationTemplateByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) RevokeDBCryptKey(ctx context.Context, activeKeyDigest string) error {
 	start := time.Now()
 	r0 := m.s.RevokeDBCryptKey(ctx, activeKeyDigest)
@@ -1950,6 +2279,13 @@ func (m metricsStore) UpdateInactiveUsersToDormant(ctx context.Context, lastSeen
 	return r0, r1
 }
 
+func (m metricsStore) UpdateInboxNotificationReadStatus(ctx context.Context, arg database.UpdateInboxNotificationReadStatusParams) (database.InboxNotification, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateInboxNotificationReadStatus(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateInboxNotificationReadStatus").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) UpdateMemberRoles(ctx context.Context, arg database.UpdateMemberRolesParams) (database.OrganizationMember, error) {
 	start := time.Now()
 	member, err := m.s.UpdateMemberRoles(ctx, arg)
@@ -1957,6 +2293,20 @@ func (m metricsStore) UpdateMemberRoles(ctx context.Context, arg database.Update
 	return member, err
 }
 
+func (m metricsStore) UpdateNotificationTemplateByID(ctx context.Context, arg database.UpdateNotificationTemplateByIDParams) (database.NotificationTemplate, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateNotificationTemplateByID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateNotificationTemplateByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) UpdateNotificationTemplateDigestWindowByID(ctx context.Context, arg database.UpdateNotificationTemplateDigestWindowByIDParams) (database.NotificationTemplate, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateNotificationTemplateDigestWindowByID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateNotificationTemplateDigestWindowByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) UpdateOAuth2ProviderAppByID(ctx context.Context, arg database.UpdateOAuth2ProviderAppByIDParams) (database.OAuth2ProviderApp, error) {
 	start := time.Now()
 	r0, r1 := m.s.UpdateOAuth2ProviderAppByID(ctx, arg)
@@ -1964,6 +2314,13 @@ func (m metricsStore) UpdateOAuth2ProviderAppByID(ctx context.Context, arg datab
 	return r0, r1
 }
 
+func (m metricsStore) UpdateOAuth2ProviderAppDeviceCodeByID(ctx context.Context, arg database.UpdateOAuth2ProviderAppDeviceCodeByIDParams) (database.OAuth2ProviderAppDeviceCode, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateOAuth2ProviderAppDeviceCodeByID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateOAuth2ProviderAppDeviceCodeByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) UpdateOAuth2ProviderAppSecretByID(ctx context.Context, arg database.UpdateOAuth2ProviderAppSecretByIDParams) (database.OAuth2ProviderAppSecret, error) {
 	start := time.Now()
 	r0, r1 := m.s.UpdateOAuth2ProviderAppSecretByID(ctx, arg)
@@ -2048,6 +2405,13 @@ func (m metricsStore) UpdateTemplateMetaByID(ctx context.Context, arg database.U
 	return err
 }
 
+func (m metricsStore) UpdateTemplatePrebuildPoolByID(ctx context.Context, arg database.UpdateTemplatePrebuildPoolByIDParams) (database.TemplatePrebuildPool, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateTemplatePrebuildPoolByID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateTemplatePrebuildPoolByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) UpdateTemplateScheduleByID(ctx context.Context, arg database.UpdateTemplateScheduleByIDParams) error {
 	start := time.Now()
 	err := m.s.UpdateTemplateScheduleByID(ctx, arg)
@@ -2160,6 +2524,20 @@ func (m metricsStore) UpdateUserStatus(ctx context.Context, arg database.UpdateU
 	return user, err
 }
 
+func (m metricsStore) UpdateUserTOTPSecretConfirmed(ctx context.Context, arg database.UpdateUserTOTPSecretConfirmedParams) (database.UserTOTPSecret, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateUserTOTPSecretConfirmed(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateUserTOTPSecretConfirmed").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) UpdateUserTOTPSecretLastUsedCounter(ctx context.Context, arg database.UpdateUserTOTPSecretLastUsedCounterParams) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateUserTOTPSecretLastUsedCounter(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateUserTOTPSecretLastUsedCounter").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) UpdateWorkspace(ctx context.Context, arg database.UpdateWorkspaceParams) (database.Workspace, error) {
 	start := time.Now()
 	workspace, err := m.s.UpdateWorkspace(ctx, arg)
@@ -2167,6 +2545,13 @@ func (m metricsStore) UpdateWorkspace(ctx context.Context, arg database.UpdateWo
 	return workspace, err
 }
 
+func (m metricsStore) UpdateWorkspaceACLByID(ctx context.Context, arg database.UpdateWorkspaceACLByIDParams) error {
+	start := time.Now()
+	r0 := m.s.UpdateWorkspaceACLByID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceACLByID").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) UpdateWorkspaceAgentConnectionByID(ctx context.Context, arg database.UpdateWorkspaceAgentConnectionByIDParams) error {
 	start := time.Now()
 	err := m.s.UpdateWorkspaceAgentConnectionByID(ctx, arg)
@@ -2244,6 +2629,27 @@ func (m metricsStore) UpdateWorkspaceBuildProvisionerStateByID(ctx context.Conte
 	return r0
 }
 
+func (m metricsStore) UpdateWorkspaceBulkJobCompletedAtByID(ctx context.Context, arg database.UpdateWorkspaceBulkJobCompletedAtByIDParams) error {
+	start := time.Now()
+	r0 := m.s.UpdateWorkspaceBulkJobCompletedAtByID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceBulkJobCompletedAtByID").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) UpdateWorkspaceBulkJobHeartbeat(ctx context.Context, arg database.UpdateWorkspaceBulkJobHeartbeatParams) (database.WorkspaceBulkJob, error) {
+	start := time.Now()
+	job, err := m.s.UpdateWorkspaceBulkJobHeartbeat(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceBulkJobHeartbeat").Observe(time.Since(start).Seconds())
+	return job, err
+}
+
+func (m metricsStore) UpdateWorkspaceBulkJobResult(ctx context.Context, arg database.UpdateWorkspaceBulkJobResultParams) error {
+	start := time.Now()
+	r0 := m.s.UpdateWorkspaceBulkJobResult(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceBulkJobResult").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) UpdateWorkspaceDeletedByID(ctx context.Context, arg database.UpdateWorkspaceDeletedByIDParams) error {
 	start := time.Now()
 	err := m.s.UpdateWorkspaceDeletedByID(ctx, arg)
@@ -2265,6 +2671,13 @@ func (m metricsStore) UpdateWorkspaceLastUsedAt(ctx context.Context, arg databas
 	return err
 }
 
+func (m metricsStore) UpdateWorkspaceOwner(ctx context.Context, arg database.UpdateWorkspaceOwnerParams) (database.Workspace, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateWorkspaceOwner(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceOwner").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) UpdateWorkspaceProxy(ctx context.Context, arg database.UpdateWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	start := time.Now()
 	proxy, err := m.s.UpdateWorkspaceProxy(ctx, arg)
@@ -2426,6 +2839,34 @@ func (m metricsStore) UpsertTemplateUsageStats(ctx context.Context) error {
 	return r0
 }
 
+func (m metricsStore) UpsertTemporaryRoleGrant(ctx context.Context, arg database.UpsertTemporaryRoleGrantParams) (database.TemporaryRoleGrant, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpsertTemporaryRoleGrant(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpsertTemporaryRoleGrant").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) UpsertUserFailedLoginAttempts(ctx context.Context, arg database.UpsertUserFailedLoginAttemptsParams) (int32, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpsertUserFailedLoginAttempts(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpsertUserFailedLoginAttempts").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) UpsertUserNotificationPreferences(ctx context.Context, arg database.UpsertUserNotificationPreferencesParams) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpsertUserNotificationPreferences(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpsertUserNotificationPreferences").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) UpsertUserTOTPSecret(ctx context.Context, arg database.UpsertUserTOTPSecretParams) (database.UserTOTPSecret, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpsertUserTOTPSecret(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpsertUserTOTPSecret").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) UpsertWorkspaceAgentPortShare(ctx context.Context, arg database.UpsertWorkspaceAgentPortShareParams) (database.WorkspaceAgentPortShare, error) {
 	start := time.Now()
 	r0, r1 := m.s.UpsertWorkspaceAgentPortShare(ctx, arg)
@@ -2467,3 +2908,10 @@ func (m metricsStore) GetAuthorizedUsers(ctx context.Context, arg database.GetUs
 	m.queryLatencies.WithLabelValues("GetAuthorizedUsers").Observe(time.Since(start).Seconds())
 	return r0, r1
 }
+
+func (m metricsStore) GetFilteredUsers(ctx context.Context, arg database.GetUsersParams, filter database.UserFilter, prepared rbac.PreparedAuthorized) ([]database.GetUsersRow, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetFilteredUsers(ctx, arg, filter, prepared)
+	m.queryLatencies.WithLabelValues("GetFilteredUsers").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
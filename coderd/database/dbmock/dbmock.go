@@ -88,6 +88,21 @@ func (mr *MockStoreMockRecorder) AcquireProvisionerJob(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireProvisionerJob", reflect.TypeOf((*MockStore)(nil).AcquireProvisionerJob), arg0, arg1)
 }
 
+// AcquireWorkspaceBulkJob mocks base method.
+func (m *MockStore) AcquireWorkspaceBulkJob(arg0 context.Context, arg1 database.AcquireWorkspaceBulkJobParams) (database.WorkspaceBulkJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireWorkspaceBulkJob", arg0, arg1)
+	ret0, _ := ret[0].(database.WorkspaceBulkJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireWorkspaceBulkJob indicates an expected call of AcquireWorkspaceBulkJob.
+func (mr *MockStoreMockRecorder) AcquireWorkspaceBulkJob(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireWorkspaceBulkJob", reflect.TypeOf((*MockStore)(nil).AcquireWorkspaceBulkJob), arg0, arg1)
+}
+
 // ActivityBumpWorkspace mocks base method.
 func (m *MockStore) ActivityBumpWorkspace(arg0 context.Context, arg1 database.ActivityBumpWorkspaceParams) error {
 	m.ctrl.T.Helper()
@@ -176,6 +191,21 @@ func (mr *MockStoreMockRecorder) BulkMarkNotificationMessagesSent(arg0, arg1 any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMarkNotificationMessagesSent", reflect.TypeOf((*MockStore)(nil).BulkMarkNotificationMessagesSent), arg0, arg1)
 }
 
+// ClaimPrebuiltWorkspace mocks base method.
+func (m *MockStore) ClaimPrebuiltWorkspace(arg0 context.Context, arg1 database.ClaimPrebuiltWorkspaceParams) (database.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPrebuiltWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(database.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPrebuiltWorkspace indicates an expected call of ClaimPrebuiltWorkspace.
+func (mr *MockStoreMockRecorder) ClaimPrebuiltWorkspace(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPrebuiltWorkspace", reflect.TypeOf((*MockStore)(nil).ClaimPrebuiltWorkspace), arg0, arg1)
+}
+
 // CleanTailnetCoordinators mocks base method.
 func (m *MockStore) CleanTailnetCoordinators(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -218,6 +248,21 @@ func (mr *MockStoreMockRecorder) CleanTailnetTunnels(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanTailnetTunnels", reflect.TypeOf((*MockStore)(nil).CleanTailnetTunnels), arg0)
 }
 
+// CountUnreadInboxNotificationsByUserID mocks base method.
+func (m *MockStore) CountUnreadInboxNotificationsByUserID(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadInboxNotificationsByUserID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadInboxNotificationsByUserID indicates an expected call of CountUnreadInboxNotificationsByUserID.
+func (mr *MockStoreMockRecorder) CountUnreadInboxNotificationsByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadInboxNotificationsByUserID", reflect.TypeOf((*MockStore)(nil).CountUnreadInboxNotificationsByUserID), arg0, arg1)
+}
+
 // CustomRoles mocks base method.
 func (m *MockStore) CustomRoles(arg0 context.Context, arg1 database.CustomRolesParams) ([]database.CustomRole, error) {
 	m.ctrl.T.Helper()
@@ -317,6 +362,20 @@ func (mr *MockStoreMockRecorder) DeleteCoordinator(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCoordinator", reflect.TypeOf((*MockStore)(nil).DeleteCoordinator), arg0, arg1)
 }
 
+// DeleteExpiredOAuth2ProviderAppDeviceCodes mocks base method.
+func (m *MockStore) DeleteExpiredOAuth2ProviderAppDeviceCodes(arg0 context.Context, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredOAuth2ProviderAppDeviceCodes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredOAuth2ProviderAppDeviceCodes indicates an expected call of DeleteExpiredOAuth2ProviderAppDeviceCodes.
+func (mr *MockStoreMockRecorder) DeleteExpiredOAuth2ProviderAppDeviceCodes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredOAuth2ProviderAppDeviceCodes", reflect.TypeOf((*MockStore)(nil).DeleteExpiredOAuth2ProviderAppDeviceCodes), arg0, arg1)
+}
+
 // DeleteExternalAuthLink mocks base method.
 func (m *MockStore) DeleteExternalAuthLink(arg0 context.Context, arg1 database.DeleteExternalAuthLinkParams) error {
 	m.ctrl.T.Helper()
@@ -430,6 +489,20 @@ func (mr *MockStoreMockRecorder) DeleteOAuth2ProviderAppCodesByAppAndUserID(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuth2ProviderAppCodesByAppAndUserID", reflect.TypeOf((*MockStore)(nil).DeleteOAuth2ProviderAppCodesByAppAndUserID), arg0, arg1)
 }
 
+// DeleteOAuth2ProviderAppDeviceCodeByID mocks base method.
+func (m *MockStore) DeleteOAuth2ProviderAppDeviceCodeByID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOAuth2ProviderAppDeviceCodeByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOAuth2ProviderAppDeviceCodeByID indicates an expected call of DeleteOAuth2ProviderAppDeviceCodeByID.
+func (mr *MockStoreMockRecorder) DeleteOAuth2ProviderAppDeviceCodeByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuth2ProviderAppDeviceCodeByID", reflect.TypeOf((*MockStore)(nil).DeleteOAuth2ProviderAppDeviceCodeByID), arg0, arg1)
+}
+
 // DeleteOAuth2ProviderAppSecretByID mocks base method.
 func (m *MockStore) DeleteOAuth2ProviderAppSecretByID(arg0 context.Context, arg1 uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -458,6 +531,20 @@ func (mr *MockStoreMockRecorder) DeleteOAuth2ProviderAppTokensByAppAndUserID(arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuth2ProviderAppTokensByAppAndUserID", reflect.TypeOf((*MockStore)(nil).DeleteOAuth2ProviderAppTokensByAppAndUserID), arg0, arg1)
 }
 
+// DeleteOldAuditLogs mocks base method.
+func (m *MockStore) DeleteOldAuditLogs(arg0 context.Context, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOldAuditLogs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOldAuditLogs indicates an expected call of DeleteOldAuditLogs.
+func (mr *MockStoreMockRecorder) DeleteOldAuditLogs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldAuditLogs", reflect.TypeOf((*MockStore)(nil).DeleteOldAuditLogs), arg0, arg1)
+}
+
 // DeleteOldNotificationMessages mocks base method.
 func (m *MockStore) DeleteOldNotificationMessages(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -486,6 +573,20 @@ func (mr *MockStoreMockRecorder) DeleteOldProvisionerDaemons(arg0 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldProvisionerDaemons", reflect.TypeOf((*MockStore)(nil).DeleteOldProvisionerDaemons), arg0)
 }
 
+// DeleteOldUserPasswordHistory mocks base method.
+func (m *MockStore) DeleteOldUserPasswordHistory(arg0 context.Context, arg1 database.DeleteOldUserPasswordHistoryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOldUserPasswordHistory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOldUserPasswordHistory indicates an expected call of DeleteOldUserPasswordHistory.
+func (mr *MockStoreMockRecorder) DeleteOldUserPasswordHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldUserPasswordHistory", reflect.TypeOf((*MockStore)(nil).DeleteOldUserPasswordHistory), arg0, arg1)
+}
+
 // DeleteOldWorkspaceAgentLogs mocks base method.
 func (m *MockStore) DeleteOldWorkspaceAgentLogs(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -644,6 +745,105 @@ func (mr *MockStoreMockRecorder) DeleteTailnetTunnel(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTailnetTunnel", reflect.TypeOf((*MockStore)(nil).DeleteTailnetTunnel), arg0, arg1)
 }
 
+// DeleteTemplatePrebuildPoolByID mocks base method.
+func (m *MockStore) DeleteTemplatePrebuildPoolByID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplatePrebuildPoolByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplatePrebuildPoolByID indicates an expected call of DeleteTemplatePrebuildPoolByID.
+func (mr *MockStoreMockRecorder) DeleteTemplatePrebuildPoolByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplatePrebuildPoolByID", reflect.TypeOf((*MockStore)(nil).DeleteTemplatePrebuildPoolByID), arg0, arg1)
+}
+
+// DeleteTemplateVersionPresetsByTemplateVersionID mocks base method.
+func (m *MockStore) DeleteTemplateVersionPresetsByTemplateVersionID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplateVersionPresetsByTemplateVersionID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplateVersionPresetsByTemplateVersionID indicates an expected call of DeleteTemplateVersionPresetsByTemplateVersionID.
+func (mr *MockStoreMockRecorder) DeleteTemplateVersionPresetsByTemplateVersionID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplateVersionPresetsByTemplateVersionID", reflect.TypeOf((*MockStore)(nil).DeleteTemplateVersionPresetsByTemplateVersionID), arg0, arg1)
+}
+
+// DeleteTemporaryRoleGrantByID mocks base method.
+func (m *MockStore) DeleteTemporaryRoleGrantByID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemporaryRoleGrantByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemporaryRoleGrantByID indicates an expected call of DeleteTemporaryRoleGrantByID.
+func (mr *MockStoreMockRecorder) DeleteTemporaryRoleGrantByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemporaryRoleGrantByID", reflect.TypeOf((*MockStore)(nil).DeleteTemporaryRoleGrantByID), arg0, arg1)
+}
+
+// DeleteTemporaryRoleGrants mocks base method.
+func (m *MockStore) DeleteTemporaryRoleGrants(arg0 context.Context, arg1 database.DeleteTemporaryRoleGrantsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemporaryRoleGrants", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemporaryRoleGrants indicates an expected call of DeleteTemporaryRoleGrants.
+func (mr *MockStoreMockRecorder) DeleteTemporaryRoleGrants(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemporaryRoleGrants", reflect.TypeOf((*MockStore)(nil).DeleteTemporaryRoleGrants), arg0, arg1)
+}
+
+// DeleteUserLoginAttempts mocks base method.
+func (m *MockStore) DeleteUserLoginAttempts(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserLoginAttempts", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserLoginAttempts indicates an expected call of DeleteUserLoginAttempts.
+func (mr *MockStoreMockRecorder) DeleteUserLoginAttempts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserLoginAttempts", reflect.TypeOf((*MockStore)(nil).DeleteUserLoginAttempts), arg0, arg1)
+}
+
+// DeleteUserTOTPRecoveryCode mocks base method.
+func (m *MockStore) DeleteUserTOTPRecoveryCode(arg0 context.Context, arg1 database.DeleteUserTOTPRecoveryCodeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserTOTPRecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserTOTPRecoveryCode indicates an expected call of DeleteUserTOTPRecoveryCode.
+func (mr *MockStoreMockRecorder) DeleteUserTOTPRecoveryCode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTOTPRecoveryCode", reflect.TypeOf((*MockStore)(nil).DeleteUserTOTPRecoveryCode), arg0, arg1)
+}
+
+// DeleteUserTOTPSecret mocks base method.
+func (m *MockStore) DeleteUserTOTPSecret(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserTOTPSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserTOTPSecret indicates an expected call of DeleteUserTOTPSecret.
+func (mr *MockStoreMockRecorder) DeleteUserTOTPSecret(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTOTPSecret", reflect.TypeOf((*MockStore)(nil).DeleteUserTOTPSecret), arg0, arg1)
+}
+
 // DeleteWorkspaceAgentPortShare mocks base method.
 func (m *MockStore) DeleteWorkspaceAgentPortShare(arg0 context.Context, arg1 database.DeleteWorkspaceAgentPortShareParams) error {
 	m.ctrl.T.Helper()
@@ -1120,6 +1320,21 @@ func (mr *MockStoreMockRecorder) GetDeploymentWorkspaceStats(arg0 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeploymentWorkspaceStats", reflect.TypeOf((*MockStore)(nil).GetDeploymentWorkspaceStats), arg0)
 }
 
+// GetExpiredTemporaryRoleGrants mocks base method.
+func (m *MockStore) GetExpiredTemporaryRoleGrants(arg0 context.Context, arg1 time.Time) ([]database.TemporaryRoleGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredTemporaryRoleGrants", arg0, arg1)
+	ret0, _ := ret[0].([]database.TemporaryRoleGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredTemporaryRoleGrants indicates an expected call of GetExpiredTemporaryRoleGrants.
+func (mr *MockStoreMockRecorder) GetExpiredTemporaryRoleGrants(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredTemporaryRoleGrants", reflect.TypeOf((*MockStore)(nil).GetExpiredTemporaryRoleGrants), arg0, arg1)
+}
+
 // GetExternalAuthLink mocks base method.
 func (m *MockStore) GetExternalAuthLink(arg0 context.Context, arg1 database.GetExternalAuthLinkParams) (database.ExternalAuthLink, error) {
 	m.ctrl.T.Helper()
@@ -1195,6 +1410,21 @@ func (mr *MockStoreMockRecorder) GetFileTemplates(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileTemplates", reflect.TypeOf((*MockStore)(nil).GetFileTemplates), arg0, arg1)
 }
 
+// GetFilteredUsers mocks base method.
+func (m *MockStore) GetFilteredUsers(arg0 context.Context, arg1 database.GetUsersParams, arg2 database.UserFilter, arg3 rbac.PreparedAuthorized) ([]database.GetUsersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilteredUsers", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]database.GetUsersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilteredUsers indicates an expected call of GetFilteredUsers.
+func (mr *MockStoreMockRecorder) GetFilteredUsers(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilteredUsers", reflect.TypeOf((*MockStore)(nil).GetFilteredUsers), arg0, arg1, arg2, arg3)
+}
+
 // GetGitSSHKey mocks base method.
 func (m *MockStore) GetGitSSHKey(arg0 context.Context, arg1 uuid.UUID) (database.GitSSHKey, error) {
 	m.ctrl.T.Helper()
@@ -1345,6 +1575,36 @@ func (mr *MockStoreMockRecorder) GetHungProvisionerJobs(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHungProvisionerJobs", reflect.TypeOf((*MockStore)(nil).GetHungProvisionerJobs), arg0, arg1)
 }
 
+// GetInboxNotificationByID mocks base method.
+func (m *MockStore) GetInboxNotificationByID(arg0 context.Context, arg1 uuid.UUID) (database.InboxNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboxNotificationByID", arg0, arg1)
+	ret0, _ := ret[0].(database.InboxNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInboxNotificationByID indicates an expected call of GetInboxNotificationByID.
+func (mr *MockStoreMockRecorder) GetInboxNotificationByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboxNotificationByID", reflect.TypeOf((*MockStore)(nil).GetInboxNotificationByID), arg0, arg1)
+}
+
+// GetInboxNotificationsByUserID mocks base method.
+func (m *MockStore) GetInboxNotificationsByUserID(arg0 context.Context, arg1 database.GetInboxNotificationsByUserIDParams) ([]database.InboxNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboxNotificationsByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]database.InboxNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInboxNotificationsByUserID indicates an expected call of GetInboxNotificationsByUserID.
+func (mr *MockStoreMockRecorder) GetInboxNotificationsByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboxNotificationsByUserID", reflect.TypeOf((*MockStore)(nil).GetInboxNotificationsByUserID), arg0, arg1)
+}
+
 // GetJFrogXrayScanByWorkspaceAndAgentID mocks base method.
 func (m *MockStore) GetJFrogXrayScanByWorkspaceAndAgentID(arg0 context.Context, arg1 database.GetJFrogXrayScanByWorkspaceAndAgentIDParams) (database.JfrogXrayScan, error) {
 	m.ctrl.T.Helper()
@@ -1480,6 +1740,36 @@ func (mr *MockStoreMockRecorder) GetNotificationMessagesByStatus(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationMessagesByStatus", reflect.TypeOf((*MockStore)(nil).GetNotificationMessagesByStatus), arg0, arg1)
 }
 
+// GetNotificationTemplateByID mocks base method.
+func (m *MockStore) GetNotificationTemplateByID(arg0 context.Context, arg1 uuid.UUID) (database.NotificationTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationTemplateByID", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationTemplateByID indicates an expected call of GetNotificationTemplateByID.
+func (mr *MockStoreMockRecorder) GetNotificationTemplateByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationTemplateByID", reflect.TypeOf((*MockStore)(nil).GetNotificationTemplateByID), arg0, arg1)
+}
+
+// GetNotificationTemplates mocks base method.
+func (m *MockStore) GetNotificationTemplates(arg0 context.Context) ([]database.NotificationTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationTemplates", arg0)
+	ret0, _ := ret[0].([]database.NotificationTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationTemplates indicates an expected call of GetNotificationTemplates.
+func (mr *MockStoreMockRecorder) GetNotificationTemplates(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationTemplates", reflect.TypeOf((*MockStore)(nil).GetNotificationTemplates), arg0)
+}
+
 // GetNotificationsSettings mocks base method.
 func (m *MockStore) GetNotificationsSettings(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -1540,6 +1830,51 @@ func (mr *MockStoreMockRecorder) GetOAuth2ProviderAppCodeByPrefix(arg0, arg1 any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuth2ProviderAppCodeByPrefix", reflect.TypeOf((*MockStore)(nil).GetOAuth2ProviderAppCodeByPrefix), arg0, arg1)
 }
 
+// GetOAuth2ProviderAppDeviceCodeByID mocks base method.
+func (m *MockStore) GetOAuth2ProviderAppDeviceCodeByID(arg0 context.Context, arg1 uuid.UUID) (database.OAuth2ProviderAppDeviceCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuth2ProviderAppDeviceCodeByID", arg0, arg1)
+	ret0, _ := ret[0].(database.OAuth2ProviderAppDeviceCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuth2ProviderAppDeviceCodeByID indicates an expected call of GetOAuth2ProviderAppDeviceCodeByID.
+func (mr *MockStoreMockRecorder) GetOAuth2ProviderAppDeviceCodeByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuth2ProviderAppDeviceCodeByID", reflect.TypeOf((*MockStore)(nil).GetOAuth2ProviderAppDeviceCodeByID), arg0, arg1)
+}
+
+// GetOAuth2ProviderAppDeviceCodeByPrefix mocks base method.
+func (m *MockStore) GetOAuth2ProviderAppDeviceCodeByPrefix(arg0 context.Context, arg1 []byte) (database.OAuth2ProviderAppDeviceCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuth2ProviderAppDeviceCodeByPrefix", arg0, arg1)
+	ret0, _ := ret[0].(database.OAuth2ProviderAppDeviceCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuth2ProviderAppDeviceCodeByPrefix indicates an expected call of GetOAuth2ProviderAppDeviceCodeByPrefix.
+func (mr *MockStoreMockRecorder) GetOAuth2ProviderAppDeviceCodeByPrefix(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuth2ProviderAppDeviceCodeByPrefix", reflect.TypeOf((*MockStore)(nil).GetOAuth2ProviderAppDeviceCodeByPrefix), arg0, arg1)
+}
+
+// GetOAuth2ProviderAppDeviceCodeByUserCode mocks base method.
+func (m *MockStore) GetOAuth2ProviderAppDeviceCodeByUserCode(arg0 context.Context, arg1 string) (database.OAuth2ProviderAppDeviceCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuth2ProviderAppDeviceCodeByUserCode", arg0, arg1)
+	ret0, _ := ret[0].(database.OAuth2ProviderAppDeviceCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuth2ProviderAppDeviceCodeByUserCode indicates an expected call of GetOAuth2ProviderAppDeviceCodeByUserCode.
+func (mr *MockStoreMockRecorder) GetOAuth2ProviderAppDeviceCodeByUserCode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuth2ProviderAppDeviceCodeByUserCode", reflect.TypeOf((*MockStore)(nil).GetOAuth2ProviderAppDeviceCodeByUserCode), arg0, arg1)
+}
+
 // GetOAuth2ProviderAppSecretByID mocks base method.
 func (m *MockStore) GetOAuth2ProviderAppSecretByID(arg0 context.Context, arg1 uuid.UUID) (database.OAuth2ProviderAppSecret, error) {
 	m.ctrl.T.Helper()
@@ -1585,6 +1920,21 @@ func (mr *MockStoreMockRecorder) GetOAuth2ProviderAppSecretsByAppID(arg0, arg1 a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuth2ProviderAppSecretsByAppID", reflect.TypeOf((*MockStore)(nil).GetOAuth2ProviderAppSecretsByAppID), arg0, arg1)
 }
 
+// GetOAuth2ProviderAppTokenByAPIKeyID mocks base method.
+func (m *MockStore) GetOAuth2ProviderAppTokenByAPIKeyID(arg0 context.Context, arg1 string) (database.OAuth2ProviderAppToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuth2ProviderAppTokenByAPIKeyID", arg0, arg1)
+	ret0, _ := ret[0].(database.OAuth2ProviderAppToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuth2ProviderAppTokenByAPIKeyID indicates an expected call of GetOAuth2ProviderAppTokenByAPIKeyID.
+func (mr *MockStoreMockRecorder) GetOAuth2ProviderAppTokenByAPIKeyID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuth2ProviderAppTokenByAPIKeyID", reflect.TypeOf((*MockStore)(nil).GetOAuth2ProviderAppTokenByAPIKeyID), arg0, arg1)
+}
+
 // GetOAuth2ProviderAppTokenByPrefix mocks base method.
 func (m *MockStore) GetOAuth2ProviderAppTokenByPrefix(arg0 context.Context, arg1 []byte) (database.OAuth2ProviderAppToken, error) {
 	m.ctrl.T.Helper()
@@ -1735,6 +2085,21 @@ func (mr *MockStoreMockRecorder) GetParameterSchemasByJobID(arg0, arg1 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParameterSchemasByJobID", reflect.TypeOf((*MockStore)(nil).GetParameterSchemasByJobID), arg0, arg1)
 }
 
+// GetPrebuiltWorkspaces mocks base method.
+func (m *MockStore) GetPrebuiltWorkspaces(arg0 context.Context, arg1 uuid.UUID) ([]database.GetPrebuiltWorkspacesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrebuiltWorkspaces", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetPrebuiltWorkspacesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrebuiltWorkspaces indicates an expected call of GetPrebuiltWorkspaces.
+func (mr *MockStoreMockRecorder) GetPrebuiltWorkspaces(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrebuiltWorkspaces", reflect.TypeOf((*MockStore)(nil).GetPrebuiltWorkspaces), arg0, arg1)
+}
+
 // GetPreviousTemplateVersion mocks base method.
 func (m *MockStore) GetPreviousTemplateVersion(arg0 context.Context, arg1 database.GetPreviousTemplateVersionParams) (database.TemplateVersion, error) {
 	m.ctrl.T.Helper()
@@ -1960,6 +2325,21 @@ func (mr *MockStoreMockRecorder) GetReplicasUpdatedAfter(arg0, arg1 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicasUpdatedAfter", reflect.TypeOf((*MockStore)(nil).GetReplicasUpdatedAfter), arg0, arg1)
 }
 
+// GetSessionAPIKeysByUserID mocks base method.
+func (m *MockStore) GetSessionAPIKeysByUserID(arg0 context.Context, arg1 uuid.UUID) ([]database.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionAPIKeysByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]database.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionAPIKeysByUserID indicates an expected call of GetSessionAPIKeysByUserID.
+func (mr *MockStoreMockRecorder) GetSessionAPIKeysByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionAPIKeysByUserID", reflect.TypeOf((*MockStore)(nil).GetSessionAPIKeysByUserID), arg0, arg1)
+}
+
 // GetTailnetAgents mocks base method.
 func (m *MockStore) GetTailnetAgents(arg0 context.Context, arg1 uuid.UUID) ([]database.TailnetAgent, error) {
 	m.ctrl.T.Helper()
@@ -2200,6 +2580,36 @@ func (mr *MockStoreMockRecorder) GetTemplateParameterInsights(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateParameterInsights", reflect.TypeOf((*MockStore)(nil).GetTemplateParameterInsights), arg0, arg1)
 }
 
+// GetTemplatePrebuildPoolByID mocks base method.
+func (m *MockStore) GetTemplatePrebuildPoolByID(arg0 context.Context, arg1 uuid.UUID) (database.TemplatePrebuildPool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplatePrebuildPoolByID", arg0, arg1)
+	ret0, _ := ret[0].(database.TemplatePrebuildPool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplatePrebuildPoolByID indicates an expected call of GetTemplatePrebuildPoolByID.
+func (mr *MockStoreMockRecorder) GetTemplatePrebuildPoolByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplatePrebuildPoolByID", reflect.TypeOf((*MockStore)(nil).GetTemplatePrebuildPoolByID), arg0, arg1)
+}
+
+// GetTemplatePrebuildPools mocks base method.
+func (m *MockStore) GetTemplatePrebuildPools(arg0 context.Context, arg1 uuid.UUID) ([]database.TemplatePrebuildPool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplatePrebuildPools", arg0, arg1)
+	ret0, _ := ret[0].([]database.TemplatePrebuildPool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplatePrebuildPools indicates an expected call of GetTemplatePrebuildPools.
+func (mr *MockStoreMockRecorder) GetTemplatePrebuildPools(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplatePrebuildPools", reflect.TypeOf((*MockStore)(nil).GetTemplatePrebuildPools), arg0, arg1)
+}
+
 // GetTemplateUsageStats mocks base method.
 func (m *MockStore) GetTemplateUsageStats(arg0 context.Context, arg1 database.GetTemplateUsageStatsParams) ([]database.TemplateUsageStat, error) {
 	m.ctrl.T.Helper()
@@ -2290,6 +2700,36 @@ func (mr *MockStoreMockRecorder) GetTemplateVersionParameters(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateVersionParameters", reflect.TypeOf((*MockStore)(nil).GetTemplateVersionParameters), arg0, arg1)
 }
 
+// GetTemplateVersionPresetByID mocks base method.
+func (m *MockStore) GetTemplateVersionPresetByID(arg0 context.Context, arg1 uuid.UUID) (database.TemplateVersionPreset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateVersionPresetByID", arg0, arg1)
+	ret0, _ := ret[0].(database.TemplateVersionPreset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateVersionPresetByID indicates an expected call of GetTemplateVersionPresetByID.
+func (mr *MockStoreMockRecorder) GetTemplateVersionPresetByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateVersionPresetByID", reflect.TypeOf((*MockStore)(nil).GetTemplateVersionPresetByID), arg0, arg1)
+}
+
+// GetTemplateVersionPresetsByTemplateVersionIDs mocks base method.
+func (m *MockStore) GetTemplateVersionPresetsByTemplateVersionIDs(arg0 context.Context, arg1 []uuid.UUID) ([]database.TemplateVersionPreset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateVersionPresetsByTemplateVersionIDs", arg0, arg1)
+	ret0, _ := ret[0].([]database.TemplateVersionPreset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateVersionPresetsByTemplateVersionIDs indicates an expected call of GetTemplateVersionPresetsByTemplateVersionIDs.
+func (mr *MockStoreMockRecorder) GetTemplateVersionPresetsByTemplateVersionIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateVersionPresetsByTemplateVersionIDs", reflect.TypeOf((*MockStore)(nil).GetTemplateVersionPresetsByTemplateVersionIDs), arg0, arg1)
+}
+
 // GetTemplateVersionVariables mocks base method.
 func (m *MockStore) GetTemplateVersionVariables(arg0 context.Context, arg1 uuid.UUID) ([]database.TemplateVersionVariable, error) {
 	m.ctrl.T.Helper()
@@ -2395,6 +2835,21 @@ func (mr *MockStoreMockRecorder) GetTemplatesWithFilter(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplatesWithFilter", reflect.TypeOf((*MockStore)(nil).GetTemplatesWithFilter), arg0, arg1)
 }
 
+// GetTemporaryRoleGrantsByUserID mocks base method.
+func (m *MockStore) GetTemporaryRoleGrantsByUserID(arg0 context.Context, arg1 uuid.UUID) ([]database.TemporaryRoleGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemporaryRoleGrantsByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]database.TemporaryRoleGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemporaryRoleGrantsByUserID indicates an expected call of GetTemporaryRoleGrantsByUserID.
+func (mr *MockStoreMockRecorder) GetTemporaryRoleGrantsByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemporaryRoleGrantsByUserID", reflect.TypeOf((*MockStore)(nil).GetTemporaryRoleGrantsByUserID), arg0, arg1)
+}
+
 // GetUnexpiredLicenses mocks base method.
 func (m *MockStore) GetUnexpiredLicenses(arg0 context.Context) ([]database.License, error) {
 	m.ctrl.T.Helper()
@@ -2473,61 +2928,136 @@ func (mr *MockStoreMockRecorder) GetUserCount(arg0 any) *gomock.Call {
 // GetUserLatencyInsights mocks base method.
 func (m *MockStore) GetUserLatencyInsights(arg0 context.Context, arg1 database.GetUserLatencyInsightsParams) ([]database.GetUserLatencyInsightsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserLatencyInsights", arg0, arg1)
-	ret0, _ := ret[0].([]database.GetUserLatencyInsightsRow)
+	ret := m.ctrl.Call(m, "GetUserLatencyInsights", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetUserLatencyInsightsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLatencyInsights indicates an expected call of GetUserLatencyInsights.
+func (mr *MockStoreMockRecorder) GetUserLatencyInsights(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLatencyInsights", reflect.TypeOf((*MockStore)(nil).GetUserLatencyInsights), arg0, arg1)
+}
+
+// GetUserLinkByLinkedID mocks base method.
+func (m *MockStore) GetUserLinkByLinkedID(arg0 context.Context, arg1 string) (database.UserLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLinkByLinkedID", arg0, arg1)
+	ret0, _ := ret[0].(database.UserLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLinkByLinkedID indicates an expected call of GetUserLinkByLinkedID.
+func (mr *MockStoreMockRecorder) GetUserLinkByLinkedID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLinkByLinkedID", reflect.TypeOf((*MockStore)(nil).GetUserLinkByLinkedID), arg0, arg1)
+}
+
+// GetUserLinkByUserIDLoginType mocks base method.
+func (m *MockStore) GetUserLinkByUserIDLoginType(arg0 context.Context, arg1 database.GetUserLinkByUserIDLoginTypeParams) (database.UserLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLinkByUserIDLoginType", arg0, arg1)
+	ret0, _ := ret[0].(database.UserLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLinkByUserIDLoginType indicates an expected call of GetUserLinkByUserIDLoginType.
+func (mr *MockStoreMockRecorder) GetUserLinkByUserIDLoginType(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLinkByUserIDLoginType", reflect.TypeOf((*MockStore)(nil).GetUserLinkByUserIDLoginType), arg0, arg1)
+}
+
+// GetUserLinksByUserID mocks base method.
+func (m *MockStore) GetUserLinksByUserID(arg0 context.Context, arg1 uuid.UUID) ([]database.UserLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLinksByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]database.UserLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLinksByUserID indicates an expected call of GetUserLinksByUserID.
+func (mr *MockStoreMockRecorder) GetUserLinksByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLinksByUserID", reflect.TypeOf((*MockStore)(nil).GetUserLinksByUserID), arg0, arg1)
+}
+
+// GetUserLoginAttempts mocks base method.
+func (m *MockStore) GetUserLoginAttempts(arg0 context.Context, arg1 uuid.UUID) (database.UserLoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLoginAttempts", arg0, arg1)
+	ret0, _ := ret[0].(database.UserLoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLoginAttempts indicates an expected call of GetUserLoginAttempts.
+func (mr *MockStoreMockRecorder) GetUserLoginAttempts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLoginAttempts", reflect.TypeOf((*MockStore)(nil).GetUserLoginAttempts), arg0, arg1)
+}
+
+// GetUserNotificationPreference mocks base method.
+func (m *MockStore) GetUserNotificationPreference(arg0 context.Context, arg1 database.GetUserNotificationPreferenceParams) (database.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationPreference)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserLatencyInsights indicates an expected call of GetUserLatencyInsights.
-func (mr *MockStoreMockRecorder) GetUserLatencyInsights(arg0, arg1 any) *gomock.Call {
+// GetUserNotificationPreference indicates an expected call of GetUserNotificationPreference.
+func (mr *MockStoreMockRecorder) GetUserNotificationPreference(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLatencyInsights", reflect.TypeOf((*MockStore)(nil).GetUserLatencyInsights), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserNotificationPreference", reflect.TypeOf((*MockStore)(nil).GetUserNotificationPreference), arg0, arg1)
 }
 
-// GetUserLinkByLinkedID mocks base method.
-func (m *MockStore) GetUserLinkByLinkedID(arg0 context.Context, arg1 string) (database.UserLink, error) {
+// GetUserNotificationPreferences mocks base method.
+func (m *MockStore) GetUserNotificationPreferences(arg0 context.Context, arg1 uuid.UUID) ([]database.GetUserNotificationPreferencesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserLinkByLinkedID", arg0, arg1)
-	ret0, _ := ret[0].(database.UserLink)
+	ret := m.ctrl.Call(m, "GetUserNotificationPreferences", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetUserNotificationPreferencesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserLinkByLinkedID indicates an expected call of GetUserLinkByLinkedID.
-func (mr *MockStoreMockRecorder) GetUserLinkByLinkedID(arg0, arg1 any) *gomock.Call {
+// GetUserNotificationPreferences indicates an expected call of GetUserNotificationPreferences.
+func (mr *MockStoreMockRecorder) GetUserNotificationPreferences(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLinkByLinkedID", reflect.TypeOf((*MockStore)(nil).GetUserLinkByLinkedID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserNotificationPreferences", reflect.TypeOf((*MockStore)(nil).GetUserNotificationPreferences), arg0, arg1)
 }
 
-// GetUserLinkByUserIDLoginType mocks base method.
-func (m *MockStore) GetUserLinkByUserIDLoginType(arg0 context.Context, arg1 database.GetUserLinkByUserIDLoginTypeParams) (database.UserLink, error) {
+// GetUserPasswordHistory mocks base method.
+func (m *MockStore) GetUserPasswordHistory(arg0 context.Context, arg1 database.GetUserPasswordHistoryParams) ([]database.UserPasswordHistory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserLinkByUserIDLoginType", arg0, arg1)
-	ret0, _ := ret[0].(database.UserLink)
+	ret := m.ctrl.Call(m, "GetUserPasswordHistory", arg0, arg1)
+	ret0, _ := ret[0].([]database.UserPasswordHistory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserLinkByUserIDLoginType indicates an expected call of GetUserLinkByUserIDLoginType.
-func (mr *MockStoreMockRecorder) GetUserLinkByUserIDLoginType(arg0, arg1 any) *gomock.Call {
+// GetUserPasswordHistory indicates an expected call of GetUserPasswordHistory.
+func (mr *MockStoreMockRecorder) GetUserPasswordHistory(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLinkByUserIDLoginType", reflect.TypeOf((*MockStore)(nil).GetUserLinkByUserIDLoginType), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPasswordHistory", reflect.TypeOf((*MockStore)(nil).GetUserPasswordHistory), arg0, arg1)
 }
 
-// GetUserLinksByUserID mocks base method.
-func (m *MockStore) GetUserLinksByUserID(arg0 context.Context, arg1 uuid.UUID) ([]database.UserLink, error) {
+// GetUserTOTPSecret mocks base method.
+func (m *MockStore) GetUserTOTPSecret(arg0 context.Context, arg1 uuid.UUID) (database.UserTOTPSecret, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserLinksByUserID", arg0, arg1)
-	ret0, _ := ret[0].([]database.UserLink)
+	ret := m.ctrl.Call(m, "GetUserTOTPSecret", arg0, arg1)
+	ret0, _ := ret[0].(database.UserTOTPSecret)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserLinksByUserID indicates an expected call of GetUserLinksByUserID.
-func (mr *MockStoreMockRecorder) GetUserLinksByUserID(arg0, arg1 any) *gomock.Call {
+// GetUserTOTPSecret indicates an expected call of GetUserTOTPSecret.
+func (mr *MockStoreMockRecorder) GetUserTOTPSecret(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLinksByUserID", reflect.TypeOf((*MockStore)(nil).GetUserLinksByUserID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTOTPSecret", reflect.TypeOf((*MockStore)(nil).GetUserTOTPSecret), arg0, arg1)
 }
 
 // GetUserWorkspaceBuildParameters mocks base method.
@@ -2935,6 +3465,36 @@ func (mr *MockStoreMockRecorder) GetWorkspaceBuildsCreatedAfter(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceBuildsCreatedAfter", reflect.TypeOf((*MockStore)(nil).GetWorkspaceBuildsCreatedAfter), arg0, arg1)
 }
 
+// GetWorkspaceBulkJobByID mocks base method.
+func (m *MockStore) GetWorkspaceBulkJobByID(arg0 context.Context, arg1 uuid.UUID) (database.WorkspaceBulkJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceBulkJobByID", arg0, arg1)
+	ret0, _ := ret[0].(database.WorkspaceBulkJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceBulkJobByID indicates an expected call of GetWorkspaceBulkJobByID.
+func (mr *MockStoreMockRecorder) GetWorkspaceBulkJobByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceBulkJobByID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceBulkJobByID), arg0, arg1)
+}
+
+// GetWorkspaceBulkJobResultsByJobID mocks base method.
+func (m *MockStore) GetWorkspaceBulkJobResultsByJobID(arg0 context.Context, arg1 uuid.UUID) ([]database.GetWorkspaceBulkJobResultsByJobIDRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceBulkJobResultsByJobID", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetWorkspaceBulkJobResultsByJobIDRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceBulkJobResultsByJobID indicates an expected call of GetWorkspaceBulkJobResultsByJobID.
+func (mr *MockStoreMockRecorder) GetWorkspaceBulkJobResultsByJobID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceBulkJobResultsByJobID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceBulkJobResultsByJobID), arg0, arg1)
+}
+
 // GetWorkspaceByAgentID mocks base method.
 func (m *MockStore) GetWorkspaceByAgentID(arg0 context.Context, arg1 uuid.UUID) (database.GetWorkspaceByAgentIDRow, error) {
 	m.ctrl.T.Helper()
@@ -3365,6 +3925,20 @@ func (mr *MockStoreMockRecorder) InsertGroupMember(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertGroupMember", reflect.TypeOf((*MockStore)(nil).InsertGroupMember), arg0, arg1)
 }
 
+// InsertInboxNotification mocks base method.
+func (m *MockStore) InsertInboxNotification(arg0 context.Context, arg1 database.InsertInboxNotificationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertInboxNotification", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertInboxNotification indicates an expected call of InsertInboxNotification.
+func (mr *MockStoreMockRecorder) InsertInboxNotification(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertInboxNotification", reflect.TypeOf((*MockStore)(nil).InsertInboxNotification), arg0, arg1)
+}
+
 // InsertLicense mocks base method.
 func (m *MockStore) InsertLicense(arg0 context.Context, arg1 database.InsertLicenseParams) (database.License, error) {
 	m.ctrl.T.Helper()
@@ -3425,6 +3999,21 @@ func (mr *MockStoreMockRecorder) InsertOAuth2ProviderAppCode(arg0, arg1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOAuth2ProviderAppCode", reflect.TypeOf((*MockStore)(nil).InsertOAuth2ProviderAppCode), arg0, arg1)
 }
 
+// InsertOAuth2ProviderAppDeviceCode mocks base method.
+func (m *MockStore) InsertOAuth2ProviderAppDeviceCode(arg0 context.Context, arg1 database.InsertOAuth2ProviderAppDeviceCodeParams) (database.OAuth2ProviderAppDeviceCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertOAuth2ProviderAppDeviceCode", arg0, arg1)
+	ret0, _ := ret[0].(database.OAuth2ProviderAppDeviceCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertOAuth2ProviderAppDeviceCode indicates an expected call of InsertOAuth2ProviderAppDeviceCode.
+func (mr *MockStoreMockRecorder) InsertOAuth2ProviderAppDeviceCode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOAuth2ProviderAppDeviceCode", reflect.TypeOf((*MockStore)(nil).InsertOAuth2ProviderAppDeviceCode), arg0, arg1)
+}
+
 // InsertOAuth2ProviderAppSecret mocks base method.
 func (m *MockStore) InsertOAuth2ProviderAppSecret(arg0 context.Context, arg1 database.InsertOAuth2ProviderAppSecretParams) (database.OAuth2ProviderAppSecret, error) {
 	m.ctrl.T.Helper()
@@ -3485,6 +4074,20 @@ func (mr *MockStoreMockRecorder) InsertOrganizationMember(arg0, arg1 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrganizationMember", reflect.TypeOf((*MockStore)(nil).InsertOrganizationMember), arg0, arg1)
 }
 
+// InsertPrebuiltWorkspace mocks base method.
+func (m *MockStore) InsertPrebuiltWorkspace(arg0 context.Context, arg1 database.InsertPrebuiltWorkspaceParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPrebuiltWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertPrebuiltWorkspace indicates an expected call of InsertPrebuiltWorkspace.
+func (mr *MockStoreMockRecorder) InsertPrebuiltWorkspace(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPrebuiltWorkspace", reflect.TypeOf((*MockStore)(nil).InsertPrebuiltWorkspace), arg0, arg1)
+}
+
 // InsertProvisionerJob mocks base method.
 func (m *MockStore) InsertProvisionerJob(arg0 context.Context, arg1 database.InsertProvisionerJobParams) (database.ProvisionerJob, error) {
 	m.ctrl.T.Helper()
@@ -3559,6 +4162,21 @@ func (mr *MockStoreMockRecorder) InsertTemplate(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTemplate", reflect.TypeOf((*MockStore)(nil).InsertTemplate), arg0, arg1)
 }
 
+// InsertTemplatePrebuildPool mocks base method.
+func (m *MockStore) InsertTemplatePrebuildPool(arg0 context.Context, arg1 database.InsertTemplatePrebuildPoolParams) (database.TemplatePrebuildPool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertTemplatePrebuildPool", arg0, arg1)
+	ret0, _ := ret[0].(database.TemplatePrebuildPool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertTemplatePrebuildPool indicates an expected call of InsertTemplatePrebuildPool.
+func (mr *MockStoreMockRecorder) InsertTemplatePrebuildPool(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTemplatePrebuildPool", reflect.TypeOf((*MockStore)(nil).InsertTemplatePrebuildPool), arg0, arg1)
+}
+
 // InsertTemplateVersion mocks base method.
 func (m *MockStore) InsertTemplateVersion(arg0 context.Context, arg1 database.InsertTemplateVersionParams) error {
 	m.ctrl.T.Helper()
@@ -3588,6 +4206,21 @@ func (mr *MockStoreMockRecorder) InsertTemplateVersionParameter(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTemplateVersionParameter", reflect.TypeOf((*MockStore)(nil).InsertTemplateVersionParameter), arg0, arg1)
 }
 
+// InsertTemplateVersionPreset mocks base method.
+func (m *MockStore) InsertTemplateVersionPreset(arg0 context.Context, arg1 database.InsertTemplateVersionPresetParams) (database.TemplateVersionPreset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertTemplateVersionPreset", arg0, arg1)
+	ret0, _ := ret[0].(database.TemplateVersionPreset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertTemplateVersionPreset indicates an expected call of InsertTemplateVersionPreset.
+func (mr *MockStoreMockRecorder) InsertTemplateVersionPreset(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTemplateVersionPreset", reflect.TypeOf((*MockStore)(nil).InsertTemplateVersionPreset), arg0, arg1)
+}
+
 // InsertTemplateVersionVariable mocks base method.
 func (m *MockStore) InsertTemplateVersionVariable(arg0 context.Context, arg1 database.InsertTemplateVersionVariableParams) (database.TemplateVersionVariable, error) {
 	m.ctrl.T.Helper()
@@ -3662,6 +4295,20 @@ func (mr *MockStoreMockRecorder) InsertUserLink(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserLink", reflect.TypeOf((*MockStore)(nil).InsertUserLink), arg0, arg1)
 }
 
+// InsertUserPasswordHistory mocks base method.
+func (m *MockStore) InsertUserPasswordHistory(arg0 context.Context, arg1 database.InsertUserPasswordHistoryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUserPasswordHistory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUserPasswordHistory indicates an expected call of InsertUserPasswordHistory.
+func (mr *MockStoreMockRecorder) InsertUserPasswordHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserPasswordHistory", reflect.TypeOf((*MockStore)(nil).InsertUserPasswordHistory), arg0, arg1)
+}
+
 // InsertWorkspace mocks base method.
 func (m *MockStore) InsertWorkspace(arg0 context.Context, arg1 database.InsertWorkspaceParams) (database.Workspace, error) {
 	m.ctrl.T.Helper()
@@ -3822,6 +4469,35 @@ func (mr *MockStoreMockRecorder) InsertWorkspaceBuildParameters(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceBuildParameters", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceBuildParameters), arg0, arg1)
 }
 
+// InsertWorkspaceBulkJob mocks base method.
+func (m *MockStore) InsertWorkspaceBulkJob(arg0 context.Context, arg1 database.InsertWorkspaceBulkJobParams) (database.WorkspaceBulkJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWorkspaceBulkJob", arg0, arg1)
+	ret0, _ := ret[0].(database.WorkspaceBulkJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertWorkspaceBulkJob indicates an expected call of InsertWorkspaceBulkJob.
+func (mr *MockStoreMockRecorder) InsertWorkspaceBulkJob(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceBulkJob", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceBulkJob), arg0, arg1)
+}
+
+// InsertWorkspaceBulkJobResults mocks base method.
+func (m *MockStore) InsertWorkspaceBulkJobResults(arg0 context.Context, arg1 database.InsertWorkspaceBulkJobResultsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWorkspaceBulkJobResults", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertWorkspaceBulkJobResults indicates an expected call of InsertWorkspaceBulkJobResults.
+func (mr *MockStoreMockRecorder) InsertWorkspaceBulkJobResults(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceBulkJobResults", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceBulkJobResults), arg0, arg1)
+}
+
 // InsertWorkspaceProxy mocks base method.
 func (m *MockStore) InsertWorkspaceProxy(arg0 context.Context, arg1 database.InsertWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	m.ctrl.T.Helper()
@@ -3897,6 +4573,21 @@ func (mr *MockStoreMockRecorder) ListWorkspaceAgentPortShares(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceAgentPortShares", reflect.TypeOf((*MockStore)(nil).ListWorkspaceAgentPortShares), arg0, arg1)
 }
 
+// MarkAllInboxNotificationsAsRead mocks base method.
+func (m *MockStore) MarkAllInboxNotificationsAsRead(arg0 context.Context, arg1 database.MarkAllInboxNotificationsAsReadParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllInboxNotificationsAsRead", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllInboxNotificationsAsRead indicates an expected call of MarkAllInboxNotificationsAsRead.
+func (mr *MockStoreMockRecorder) MarkAllInboxNotificationsAsRead(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllInboxNotificationsAsRead", reflect.TypeOf((*MockStore)(nil).MarkAllInboxNotificationsAsRead), arg0, arg1)
+}
+
 // OrganizationMembers mocks base method.
 func (m *MockStore) OrganizationMembers(arg0 context.Context, arg1 database.OrganizationMembersParams) ([]database.OrganizationMembersRow, error) {
 	m.ctrl.T.Helper()
@@ -3970,6 +4661,21 @@ func (mr *MockStoreMockRecorder) RemoveUserFromAllGroups(arg0, arg1 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromAllGroups", reflect.TypeOf((*MockStore)(nil).RemoveUserFromAllGroups), arg0, arg1)
 }
 
+// ResetNotificationTemplateByID mocks base method.
+func (m *MockStore) ResetNotificationTemplateByID(arg0 context.Context, arg1 uuid.UUID) (database.NotificationTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetNotificationTemplateByID", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetNotificationTemplateByID indicates an expected call of ResetNotificationTemplateByID.
+func (mr *MockStoreMockRecorder) ResetNotificationTemplateByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetNotificationTemplateByID", reflect.TypeOf((*MockStore)(nil).ResetNotificationTemplateByID), arg0, arg1)
+}
+
 // RevokeDBCryptKey mocks base method.
 func (m *MockStore) RevokeDBCryptKey(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -4101,6 +4807,21 @@ func (mr *MockStoreMockRecorder) UpdateInactiveUsersToDormant(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInactiveUsersToDormant", reflect.TypeOf((*MockStore)(nil).UpdateInactiveUsersToDormant), arg0, arg1)
 }
 
+// UpdateInboxNotificationReadStatus mocks base method.
+func (m *MockStore) UpdateInboxNotificationReadStatus(arg0 context.Context, arg1 database.UpdateInboxNotificationReadStatusParams) (database.InboxNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInboxNotificationReadStatus", arg0, arg1)
+	ret0, _ := ret[0].(database.InboxNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInboxNotificationReadStatus indicates an expected call of UpdateInboxNotificationReadStatus.
+func (mr *MockStoreMockRecorder) UpdateInboxNotificationReadStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInboxNotificationReadStatus", reflect.TypeOf((*MockStore)(nil).UpdateInboxNotificationReadStatus), arg0, arg1)
+}
+
 // UpdateMemberRoles mocks base method.
 func (m *MockStore) UpdateMemberRoles(arg0 context.Context, arg1 database.UpdateMemberRolesParams) (database.OrganizationMember, error) {
 	m.ctrl.T.Helper()
@@ -4116,6 +4837,36 @@ func (mr *MockStoreMockRecorder) UpdateMemberRoles(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRoles", reflect.TypeOf((*MockStore)(nil).UpdateMemberRoles), arg0, arg1)
 }
 
+// UpdateNotificationTemplateByID mocks base method.
+func (m *MockStore) UpdateNotificationTemplateByID(arg0 context.Context, arg1 database.UpdateNotificationTemplateByIDParams) (database.NotificationTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationTemplateByID", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNotificationTemplateByID indicates an expected call of UpdateNotificationTemplateByID.
+func (mr *MockStoreMockRecorder) UpdateNotificationTemplateByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationTemplateByID", reflect.TypeOf((*MockStore)(nil).UpdateNotificationTemplateByID), arg0, arg1)
+}
+
+// UpdateNotificationTemplateDigestWindowByID mocks base method.
+func (m *MockStore) UpdateNotificationTemplateDigestWindowByID(arg0 context.Context, arg1 database.UpdateNotificationTemplateDigestWindowByIDParams) (database.NotificationTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationTemplateDigestWindowByID", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNotificationTemplateDigestWindowByID indicates an expected call of UpdateNotificationTemplateDigestWindowByID.
+func (mr *MockStoreMockRecorder) UpdateNotificationTemplateDigestWindowByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationTemplateDigestWindowByID", reflect.TypeOf((*MockStore)(nil).UpdateNotificationTemplateDigestWindowByID), arg0, arg1)
+}
+
 // UpdateOAuth2ProviderAppByID mocks base method.
 func (m *MockStore) UpdateOAuth2ProviderAppByID(arg0 context.Context, arg1 database.UpdateOAuth2ProviderAppByIDParams) (database.OAuth2ProviderApp, error) {
 	m.ctrl.T.Helper()
@@ -4131,6 +4882,21 @@ func (mr *MockStoreMockRecorder) UpdateOAuth2ProviderAppByID(arg0, arg1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2ProviderAppByID", reflect.TypeOf((*MockStore)(nil).UpdateOAuth2ProviderAppByID), arg0, arg1)
 }
 
+// UpdateOAuth2ProviderAppDeviceCodeByID mocks base method.
+func (m *MockStore) UpdateOAuth2ProviderAppDeviceCodeByID(arg0 context.Context, arg1 database.UpdateOAuth2ProviderAppDeviceCodeByIDParams) (database.OAuth2ProviderAppDeviceCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOAuth2ProviderAppDeviceCodeByID", arg0, arg1)
+	ret0, _ := ret[0].(database.OAuth2ProviderAppDeviceCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOAuth2ProviderAppDeviceCodeByID indicates an expected call of UpdateOAuth2ProviderAppDeviceCodeByID.
+func (mr *MockStoreMockRecorder) UpdateOAuth2ProviderAppDeviceCodeByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2ProviderAppDeviceCodeByID", reflect.TypeOf((*MockStore)(nil).UpdateOAuth2ProviderAppDeviceCodeByID), arg0, arg1)
+}
+
 // UpdateOAuth2ProviderAppSecretByID mocks base method.
 func (m *MockStore) UpdateOAuth2ProviderAppSecretByID(arg0 context.Context, arg1 database.UpdateOAuth2ProviderAppSecretByIDParams) (database.OAuth2ProviderAppSecret, error) {
 	m.ctrl.T.Helper()
@@ -4302,6 +5068,21 @@ func (mr *MockStoreMockRecorder) UpdateTemplateMetaByID(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplateMetaByID", reflect.TypeOf((*MockStore)(nil).UpdateTemplateMetaByID), arg0, arg1)
 }
 
+// UpdateTemplatePrebuildPoolByID mocks base method.
+func (m *MockStore) UpdateTemplatePrebuildPoolByID(arg0 context.Context, arg1 database.UpdateTemplatePrebuildPoolByIDParams) (database.TemplatePrebuildPool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplatePrebuildPoolByID", arg0, arg1)
+	ret0, _ := ret[0].(database.TemplatePrebuildPool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTemplatePrebuildPoolByID indicates an expected call of UpdateTemplatePrebuildPoolByID.
+func (mr *MockStoreMockRecorder) UpdateTemplatePrebuildPoolByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplatePrebuildPoolByID", reflect.TypeOf((*MockStore)(nil).UpdateTemplatePrebuildPoolByID), arg0, arg1)
+}
+
 // UpdateTemplateScheduleByID mocks base method.
 func (m *MockStore) UpdateTemplateScheduleByID(arg0 context.Context, arg1 database.UpdateTemplateScheduleByIDParams) error {
 	m.ctrl.T.Helper()
@@ -4535,6 +5316,36 @@ func (mr *MockStoreMockRecorder) UpdateUserStatus(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserStatus", reflect.TypeOf((*MockStore)(nil).UpdateUserStatus), arg0, arg1)
 }
 
+// UpdateUserTOTPSecretConfirmed mocks base method.
+func (m *MockStore) UpdateUserTOTPSecretConfirmed(arg0 context.Context, arg1 database.UpdateUserTOTPSecretConfirmedParams) (database.UserTOTPSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserTOTPSecretConfirmed", arg0, arg1)
+	ret0, _ := ret[0].(database.UserTOTPSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserTOTPSecretConfirmed indicates an expected call of UpdateUserTOTPSecretConfirmed.
+func (mr *MockStoreMockRecorder) UpdateUserTOTPSecretConfirmed(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserTOTPSecretConfirmed", reflect.TypeOf((*MockStore)(nil).UpdateUserTOTPSecretConfirmed), arg0, arg1)
+}
+
+// UpdateUserTOTPSecretLastUsedCounter mocks base method.
+func (m *MockStore) UpdateUserTOTPSecretLastUsedCounter(arg0 context.Context, arg1 database.UpdateUserTOTPSecretLastUsedCounterParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserTOTPSecretLastUsedCounter", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserTOTPSecretLastUsedCounter indicates an expected call of UpdateUserTOTPSecretLastUsedCounter.
+func (mr *MockStoreMockRecorder) UpdateUserTOTPSecretLastUsedCounter(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserTOTPSecretLastUsedCounter", reflect.TypeOf((*MockStore)(nil).UpdateUserTOTPSecretLastUsedCounter), arg0, arg1)
+}
+
 // UpdateWorkspace mocks base method.
 func (m *MockStore) UpdateWorkspace(arg0 context.Context, arg1 database.UpdateWorkspaceParams) (database.Workspace, error) {
 	m.ctrl.T.Helper()
@@ -4550,6 +5361,20 @@ func (mr *MockStoreMockRecorder) UpdateWorkspace(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspace", reflect.TypeOf((*MockStore)(nil).UpdateWorkspace), arg0, arg1)
 }
 
+// UpdateWorkspaceACLByID mocks base method.
+func (m *MockStore) UpdateWorkspaceACLByID(arg0 context.Context, arg1 database.UpdateWorkspaceACLByIDParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceACLByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspaceACLByID indicates an expected call of UpdateWorkspaceACLByID.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceACLByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceACLByID", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceACLByID), arg0, arg1)
+}
+
 // UpdateWorkspaceAgentConnectionByID mocks base method.
 func (m *MockStore) UpdateWorkspaceAgentConnectionByID(arg0 context.Context, arg1 database.UpdateWorkspaceAgentConnectionByIDParams) error {
 	m.ctrl.T.Helper()
@@ -4704,6 +5529,49 @@ func (mr *MockStoreMockRecorder) UpdateWorkspaceBuildProvisionerStateByID(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceBuildProvisionerStateByID", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceBuildProvisionerStateByID), arg0, arg1)
 }
 
+// UpdateWorkspaceBulkJobCompletedAtByID mocks base method.
+func (m *MockStore) UpdateWorkspaceBulkJobCompletedAtByID(arg0 context.Context, arg1 database.UpdateWorkspaceBulkJobCompletedAtByIDParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceBulkJobCompletedAtByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspaceBulkJobCompletedAtByID indicates an expected call of UpdateWorkspaceBulkJobCompletedAtByID.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceBulkJobCompletedAtByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceBulkJobCompletedAtByID", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceBulkJobCompletedAtByID), arg0, arg1)
+}
+
+// UpdateWorkspaceBulkJobHeartbeat mocks base method.
+func (m *MockStore) UpdateWorkspaceBulkJobHeartbeat(arg0 context.Context, arg1 database.UpdateWorkspaceBulkJobHeartbeatParams) (database.WorkspaceBulkJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceBulkJobHeartbeat", arg0, arg1)
+	ret0, _ := ret[0].(database.WorkspaceBulkJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWorkspaceBulkJobHeartbeat indicates an expected call of UpdateWorkspaceBulkJobHeartbeat.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceBulkJobHeartbeat(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceBulkJobHeartbeat", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceBulkJobHeartbeat), arg0, arg1)
+}
+
+// UpdateWorkspaceBulkJobResult mocks base method.
+func (m *MockStore) UpdateWorkspaceBulkJobResult(arg0 context.Context, arg1 database.UpdateWorkspaceBulkJobResultParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceBulkJobResult", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspaceBulkJobResult indicates an expected call of UpdateWorkspaceBulkJobResult.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceBulkJobResult(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceBulkJobResult", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceBulkJobResult), arg0, arg1)
+}
+
 // UpdateWorkspaceDeletedByID mocks base method.
 func (m *MockStore) UpdateWorkspaceDeletedByID(arg0 context.Context, arg1 database.UpdateWorkspaceDeletedByIDParams) error {
 	m.ctrl.T.Helper()
@@ -4747,6 +5615,21 @@ func (mr *MockStoreMockRecorder) UpdateWorkspaceLastUsedAt(arg0, arg1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceLastUsedAt", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceLastUsedAt), arg0, arg1)
 }
 
+// UpdateWorkspaceOwner mocks base method.
+func (m *MockStore) UpdateWorkspaceOwner(arg0 context.Context, arg1 database.UpdateWorkspaceOwnerParams) (database.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceOwner", arg0, arg1)
+	ret0, _ := ret[0].(database.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWorkspaceOwner indicates an expected call of UpdateWorkspaceOwner.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceOwner(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceOwner", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceOwner), arg0, arg1)
+}
+
 // UpdateWorkspaceProxy mocks base method.
 func (m *MockStore) UpdateWorkspaceProxy(arg0 context.Context, arg1 database.UpdateWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	m.ctrl.T.Helper()
@@ -5078,6 +5961,66 @@ func (mr *MockStoreMockRecorder) UpsertTemplateUsageStats(arg0 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTemplateUsageStats", reflect.TypeOf((*MockStore)(nil).UpsertTemplateUsageStats), arg0)
 }
 
+// UpsertTemporaryRoleGrant mocks base method.
+func (m *MockStore) UpsertTemporaryRoleGrant(arg0 context.Context, arg1 database.UpsertTemporaryRoleGrantParams) (database.TemporaryRoleGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTemporaryRoleGrant", arg0, arg1)
+	ret0, _ := ret[0].(database.TemporaryRoleGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTemporaryRoleGrant indicates an expected call of UpsertTemporaryRoleGrant.
+func (mr *MockStoreMockRecorder) UpsertTemporaryRoleGrant(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTemporaryRoleGrant", reflect.TypeOf((*MockStore)(nil).UpsertTemporaryRoleGrant), arg0, arg1)
+}
+
+// UpsertUserFailedLoginAttempts mocks base method.
+func (m *MockStore) UpsertUserFailedLoginAttempts(arg0 context.Context, arg1 database.UpsertUserFailedLoginAttemptsParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserFailedLoginAttempts", arg0, arg1)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertUserFailedLoginAttempts indicates an expected call of UpsertUserFailedLoginAttempts.
+func (mr *MockStoreMockRecorder) UpsertUserFailedLoginAttempts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserFailedLoginAttempts", reflect.TypeOf((*MockStore)(nil).UpsertUserFailedLoginAttempts), arg0, arg1)
+}
+
+// UpsertUserNotificationPreferences mocks base method.
+func (m *MockStore) UpsertUserNotificationPreferences(arg0 context.Context, arg1 database.UpsertUserNotificationPreferencesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserNotificationPreferences", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertUserNotificationPreferences indicates an expected call of UpsertUserNotificationPreferences.
+func (mr *MockStoreMockRecorder) UpsertUserNotificationPreferences(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserNotificationPreferences", reflect.TypeOf((*MockStore)(nil).UpsertUserNotificationPreferences), arg0, arg1)
+}
+
+// UpsertUserTOTPSecret mocks base method.
+func (m *MockStore) UpsertUserTOTPSecret(arg0 context.Context, arg1 database.UpsertUserTOTPSecretParams) (database.UserTOTPSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserTOTPSecret", arg0, arg1)
+	ret0, _ := ret[0].(database.UserTOTPSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertUserTOTPSecret indicates an expected call of UpsertUserTOTPSecret.
+func (mr *MockStoreMockRecorder) UpsertUserTOTPSecret(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserTOTPSecret", reflect.TypeOf((*MockStore)(nil).UpsertUserTOTPSecret), arg0, arg1)
+}
+
 // UpsertWorkspaceAgentPortShare mocks base method.
 func (m *MockStore) UpsertWorkspaceAgentPortShare(arg0 context.Context, arg1 database.UpsertWorkspaceAgentPortShareParams) (database.WorkspaceAgentPortShare, error) {
 	m.ctrl.T.Helper()
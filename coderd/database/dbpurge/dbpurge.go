@@ -21,7 +21,9 @@ const (
 // It is the caller's responsibility to call Close on the returned instance.
 //
 // This is for cleaning up old, unused resources from the database that take up space.
-func New(ctx context.Context, logger slog.Logger, db database.Store) io.Closer {
+// Audit logs older than auditLogRetention are deleted; if it is 0, audit logs are
+// kept forever.
+func New(ctx context.Context, logger slog.Logger, db database.Store, auditLogRetention time.Duration) io.Closer {
 	closed := make(chan struct{})
 
 	ctx, cancelFunc := context.WithCancel(ctx)
@@ -61,6 +63,14 @@ func New(ctx context.Context, logger slog.Logger, db database.Store) io.Closer {
 			if err := tx.DeleteOldNotificationMessages(ctx); err != nil {
 				return xerrors.Errorf("failed to delete old notification messages: %w", err)
 			}
+			if err := tx.DeleteExpiredOAuth2ProviderAppDeviceCodes(ctx, start); err != nil {
+				return xerrors.Errorf("failed to delete expired oauth2 device codes: %w", err)
+			}
+			if auditLogRetention > 0 {
+				if err := tx.DeleteOldAuditLogs(ctx, start.Add(-auditLogRetention)); err != nil {
+					return xerrors.Errorf("failed to delete old audit logs: %w", err)
+				}
+			}
 
 			logger.Info(ctx, "purged old database entries", slog.F("duration", time.Since(start)))
 
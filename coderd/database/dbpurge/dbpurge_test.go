@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
 	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
 
 	"cdr.dev/slog"
 	"cdr.dev/slog/sloggers/slogtest"
@@ -38,7 +39,7 @@ func TestMain(m *testing.M) {
 // Ensures no goroutines leak.
 func TestPurge(t *testing.T) {
 	t.Parallel()
-	purger := dbpurge.New(context.Background(), slogtest.Make(t, nil), dbmem.New())
+	purger := dbpurge.New(context.Background(), slogtest.Make(t, nil), dbmem.New(), 0)
 	err := purger.Close()
 	require.NoError(t, err)
 }
@@ -114,7 +115,7 @@ func TestDeleteOldWorkspaceAgentStats(t *testing.T) {
 	})
 
 	// when
-	closer := dbpurge.New(ctx, logger, db)
+	closer := dbpurge.New(ctx, logger, db, 0)
 	defer closer.Close()
 
 	// then
@@ -139,7 +140,7 @@ func TestDeleteOldWorkspaceAgentStats(t *testing.T) {
 
 	// Start a new purger to immediately trigger delete after rollup.
 	_ = closer.Close()
-	closer = dbpurge.New(ctx, logger, db)
+	closer = dbpurge.New(ctx, logger, db, 0)
 	defer closer.Close()
 
 	// then
@@ -189,7 +190,7 @@ func TestDeleteOldWorkspaceAgentLogs(t *testing.T) {
 		require.NotZero(t, agentLogs, "agent logs must be present")
 
 		// when
-		closer := dbpurge.New(ctx, logger, db)
+		closer := dbpurge.New(ctx, logger, db, 0)
 		defer closer.Close()
 
 		// then
@@ -215,7 +216,7 @@ func TestDeleteOldWorkspaceAgentLogs(t *testing.T) {
 		agent := mustCreateAgentWithLogs(ctx, t, db, user, org, tmpl, tv, now.Add(-6*24*time.Hour), t.Name())
 
 		// when
-		closer := dbpurge.New(ctx, logger, db)
+		closer := dbpurge.New(ctx, logger, db, 0)
 		defer closer.Close()
 
 		// then
@@ -347,7 +348,7 @@ func TestDeleteOldProvisionerDaemons(t *testing.T) {
 	require.NoError(t, err)
 
 	// when
-	closer := dbpurge.New(ctx, logger, db)
+	closer := dbpurge.New(ctx, logger, db, 0)
 	defer closer.Close()
 
 	// then
@@ -375,3 +376,75 @@ func containsProvisionerDaemon(daemons []database.ProvisionerDaemon, name string
 		return d.Name == name
 	})
 }
+
+//nolint:paralleltest // It uses LockIDDBPurge.
+func TestDeleteOldAuditLogs(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitShort)
+	defer cancel()
+
+	db, _ := dbtestutil.NewDB(t)
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true})
+	now := dbtime.Now()
+
+	// given
+	oldLog := dbgen.AuditLog(t, db, database.AuditLog{Time: now.AddDate(0, 0, -31)})
+	newLog := dbgen.AuditLog(t, db, database.AuditLog{Time: now.AddDate(0, 0, -29)})
+
+	// when
+	closer := dbpurge.New(ctx, logger, db, 30*24*time.Hour)
+	defer closer.Close()
+
+	// then
+	require.Eventually(t, func() bool {
+		logs, err := db.GetAuditLogsOffset(ctx, database.GetAuditLogsOffsetParams{})
+		if err != nil {
+			return false
+		}
+		t.Logf("found %d audit logs", len(logs))
+
+		return !containsAuditLog(logs, oldLog.ID) && containsAuditLog(logs, newLog.ID)
+	}, testutil.WaitShort, testutil.IntervalFast)
+}
+
+func containsAuditLog(logs []database.GetAuditLogsOffsetRow, id uuid.UUID) bool {
+	return slices.ContainsFunc(logs, func(l database.GetAuditLogsOffsetRow) bool {
+		return l.ID == id
+	})
+}
+
+//nolint:paralleltest // It uses LockIDDBPurge.
+func TestDeleteExpiredOAuth2ProviderAppDeviceCodes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitShort)
+	defer cancel()
+
+	db, _ := dbtestutil.NewDB(t)
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true})
+	now := dbtime.Now()
+
+	// given
+	app := dbgen.OAuth2ProviderApp(t, db, database.OAuth2ProviderApp{})
+	expiredCode := dbgen.OAuth2ProviderAppDeviceCode(t, db, database.OAuth2ProviderAppDeviceCode{
+		AppID:        app.ID,
+		ExpiresAt:    now.Add(-time.Minute),
+		SecretPrefix: []byte("expired"),
+		UserCode:     "EXPIRED1",
+	})
+	validCode := dbgen.OAuth2ProviderAppDeviceCode(t, db, database.OAuth2ProviderAppDeviceCode{
+		AppID:        app.ID,
+		ExpiresAt:    now.Add(time.Hour),
+		SecretPrefix: []byte("valid"),
+		UserCode:     "VALID123",
+	})
+
+	// when
+	closer := dbpurge.New(ctx, logger, db, 0)
+	defer closer.Close()
+
+	// then
+	require.Eventually(t, func() bool {
+		_, err := db.GetOAuth2ProviderAppDeviceCodeByID(ctx, expiredCode.ID)
+		return xerrors.Is(err, sql.ErrNoRows)
+	}, testutil.WaitShort, testutil.IntervalFast)
+	_, err := db.GetOAuth2ProviderAppDeviceCodeByID(ctx, validCode.ID)
+	require.NoError(t, err)
+}
@@ -19,7 +19,9 @@ CREATE TYPE audit_action AS ENUM (
     'stop',
     'login',
     'logout',
-    'register'
+    'register',
+    'connect',
+    'disconnect'
 );
 
 CREATE TYPE automatic_updates AS ENUM (
@@ -68,7 +70,8 @@ CREATE TYPE login_type AS ENUM (
     'oidc',
     'token',
     'none',
-    'oauth2_provider_app'
+    'oauth2_provider_app',
+    'ldap'
 );
 
 COMMENT ON TYPE login_type IS 'Specifies the method of authentication. "none" is a special case in which no authentication method is allowed.';
@@ -89,7 +92,10 @@ CREATE TYPE notification_message_status AS ENUM (
 
 CREATE TYPE notification_method AS ENUM (
     'smtp',
-    'webhook'
+    'webhook',
+    'slack',
+    'teams',
+    'inbox'
 );
 
 CREATE TYPE parameter_destination_scheme AS ENUM (
@@ -164,7 +170,8 @@ CREATE TYPE resource_type AS ENUM (
     'oauth2_provider_app_secret',
     'custom_role',
     'organization_member',
-    'notifications_settings'
+    'notifications_settings',
+    'notification_template'
 );
 
 CREATE TYPE startup_script_behavior AS ENUM (
@@ -211,6 +218,22 @@ CREATE TYPE workspace_app_health AS ENUM (
     'unhealthy'
 );
 
+CREATE TYPE workspace_bulk_action AS ENUM (
+    'start',
+    'stop',
+    'update',
+    'delete',
+    'automatic_updates',
+    'dormant'
+);
+
+CREATE TYPE workspace_bulk_result_status AS ENUM (
+    'pending',
+    'succeeded',
+    'failed',
+    'skipped'
+);
+
 CREATE TYPE workspace_transition AS ENUM (
     'start',
     'stop',
@@ -403,11 +426,17 @@ CREATE TABLE api_keys (
     lifetime_seconds bigint DEFAULT 86400 NOT NULL,
     ip_address inet DEFAULT '0.0.0.0'::inet NOT NULL,
     scope api_key_scope DEFAULT 'all'::api_key_scope NOT NULL,
-    token_name text DEFAULT ''::text NOT NULL
+    token_name text DEFAULT ''::text NOT NULL,
+    scopes text[] DEFAULT '{}'::text[] NOT NULL,
+    user_agent text DEFAULT ''::text NOT NULL
 );
 
 COMMENT ON COLUMN api_keys.hashed_secret IS 'hashed_secret contains a SHA256 hash of the key secret. This is considered a secret and MUST NOT be returned from the API as it is used for API key encryption in app proxying code.';
 
+COMMENT ON COLUMN api_keys.scopes IS 'scopes restricts the API key to the listed "<resource>:<action>" permissions, on top of scope. Empty means no additional restriction.';
+
+COMMENT ON COLUMN api_keys.user_agent IS 'user_agent is the User-Agent header of the request that created the API key.';
+
 CREATE TABLE audit_logs (
     id uuid NOT NULL,
     "time" timestamp with time zone NOT NULL,
@@ -520,6 +549,22 @@ COMMENT ON COLUMN groups.display_name IS 'Display name is a custom, human-friend
 
 COMMENT ON COLUMN groups.source IS 'Source indicates how the group was created. It can be created by a user manually, or through some system process like OIDC group sync.';
 
+CREATE TABLE inbox_notifications (
+    id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    title text NOT NULL,
+    content text NOT NULL,
+    actions jsonb DEFAULT '[]'::jsonb NOT NULL,
+    read_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+COMMENT ON TABLE inbox_notifications IS 'Notifications delivered to users'' in-app inbox.';
+
+COMMENT ON COLUMN inbox_notifications.id IS 'The ID of the notification message which was delivered to the inbox.';
+
+COMMENT ON COLUMN inbox_notifications.read_at IS 'NULL until the user has marked the notification as read.';
+
 CREATE TABLE jfrog_xray_scans (
     agent_id uuid NOT NULL,
     workspace_id uuid NOT NULL,
@@ -567,17 +612,41 @@ CREATE TABLE notification_messages (
     queued_seconds double precision
 );
 
+CREATE TABLE notification_preferences (
+    user_id uuid NOT NULL,
+    notification_template_id uuid NOT NULL,
+    disabled boolean DEFAULT false NOT NULL,
+    method notification_method,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+COMMENT ON TABLE notification_preferences IS 'Per-user overrides of how (or whether) notifications of each template are delivered.';
+
+COMMENT ON COLUMN notification_preferences.disabled IS 'If true, notifications of this template will not be enqueued for the user.';
+
+COMMENT ON COLUMN notification_preferences.method IS 'The preferred delivery method; NULL means the deployment''s default method is used.';
+
 CREATE TABLE notification_templates (
     id uuid NOT NULL,
     name text NOT NULL,
     title_template text NOT NULL,
     body_template text NOT NULL,
     actions jsonb,
-    "group" text
+    "group" text,
+    default_title_template text,
+    default_body_template text,
+    digest_window_seconds integer DEFAULT 0 NOT NULL
 );
 
 COMMENT ON TABLE notification_templates IS 'Templates from which to create notification messages.';
 
+COMMENT ON COLUMN notification_templates.default_title_template IS 'The title template shipped with Coder; NULL unless the title template has been customized by an administrator.';
+
+COMMENT ON COLUMN notification_templates.default_body_template IS 'The body template shipped with Coder; NULL unless the body template has been customized by an administrator.';
+
+COMMENT ON COLUMN notification_templates.digest_window_seconds IS 'If greater than zero, messages of this template are held for this many seconds and then delivered to each user as a single digest; zero delivers every message as soon as possible.';
+
 CREATE TABLE oauth2_provider_app_codes (
     id uuid NOT NULL,
     created_at timestamp with time zone NOT NULL,
@@ -585,11 +654,30 @@ CREATE TABLE oauth2_provider_app_codes (
     secret_prefix bytea NOT NULL,
     hashed_secret bytea NOT NULL,
     user_id uuid NOT NULL,
-    app_id uuid NOT NULL
+    app_id uuid NOT NULL,
+    code_challenge text DEFAULT ''::text NOT NULL,
+    code_challenge_method text DEFAULT ''::text NOT NULL
 );
 
 COMMENT ON TABLE oauth2_provider_app_codes IS 'Codes are meant to be exchanged for access tokens.';
 
+COMMENT ON COLUMN oauth2_provider_app_codes.code_challenge IS 'PKCE code challenge (RFC 7636). When set, the code can only be exchanged with the matching code verifier.';
+
+CREATE TABLE oauth2_provider_app_device_codes (
+    id uuid NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    secret_prefix bytea NOT NULL,
+    hashed_secret bytea NOT NULL,
+    user_code text NOT NULL,
+    app_id uuid NOT NULL,
+    user_id uuid
+);
+
+COMMENT ON TABLE oauth2_provider_app_device_codes IS 'Device codes are exchanged for access tokens once a user approves the matching user code (RFC 8628).';
+
+COMMENT ON COLUMN oauth2_provider_app_device_codes.user_id IS 'The user that approved the device, null while authorization is pending.';
+
 CREATE TABLE oauth2_provider_app_secrets (
     id uuid NOT NULL,
     created_at timestamp with time zone NOT NULL,
@@ -608,8 +696,9 @@ CREATE TABLE oauth2_provider_app_tokens (
     expires_at timestamp with time zone NOT NULL,
     hash_prefix bytea NOT NULL,
     refresh_hash bytea NOT NULL,
-    app_secret_id uuid NOT NULL,
-    api_key_id text NOT NULL
+    app_secret_id uuid,
+    api_key_id text NOT NULL,
+    app_id uuid NOT NULL
 );
 
 COMMENT ON COLUMN oauth2_provider_app_tokens.refresh_hash IS 'Refresh tokens provide a way to refresh an access token (API key). An expired API key can be refreshed if this token is not yet expired, meaning this expiry can outlive an API key.';
@@ -620,11 +709,14 @@ CREATE TABLE oauth2_provider_apps (
     updated_at timestamp with time zone NOT NULL,
     name character varying(64) NOT NULL,
     icon character varying(256) NOT NULL,
-    callback_url text NOT NULL
+    callback_url text NOT NULL,
+    service_account_id uuid
 );
 
 COMMENT ON TABLE oauth2_provider_apps IS 'A table used to configure apps that can use Coder as an OAuth2 provider, the reverse of what we are calling external authentication.';
 
+COMMENT ON COLUMN oauth2_provider_apps.service_account_id IS 'The service account the app acts as when using the client credentials grant. The grant is disabled when null.';
+
 CREATE TABLE organization_members (
     user_id uuid NOT NULL,
     organization_id uuid NOT NULL,
@@ -676,6 +768,16 @@ CREATE TABLE parameter_values (
     destination_scheme parameter_destination_scheme NOT NULL
 );
 
+CREATE TABLE prebuilt_workspaces (
+    workspace_id uuid NOT NULL,
+    pool_id uuid,
+    created_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE prebuilt_workspaces IS 'Workspaces that were provisioned for a prebuild pool and have not been claimed yet. They are owned by the prebuilds system user.';
+
+COMMENT ON COLUMN prebuilt_workspaces.pool_id IS 'NULL if the pool was removed, the workspace is then deleted by the reconciler.';
+
 CREATE TABLE provisioner_daemons (
     id uuid NOT NULL,
     created_at timestamp with time zone NOT NULL,
@@ -821,6 +923,21 @@ CREATE TABLE tailnet_tunnels (
     updated_at timestamp with time zone NOT NULL
 );
 
+CREATE TABLE template_prebuild_pools (
+    id uuid NOT NULL,
+    template_id uuid NOT NULL,
+    name text NOT NULL,
+    desired_instances integer NOT NULL,
+    parameters jsonb DEFAULT '{}'::jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    CONSTRAINT template_prebuild_pools_desired_instances_check CHECK ((desired_instances >= 0))
+);
+
+COMMENT ON TABLE template_prebuild_pools IS 'Pools of workspaces that are provisioned ahead of time, so that creating a workspace from the template with matching parameters only has to claim one.';
+
+COMMENT ON COLUMN template_prebuild_pools.parameters IS 'Rich parameter values of the prebuilt workspaces. Parameters that are not set use the default value of the active template version.';
+
 CREATE TABLE template_usage_stats (
     start_time timestamp with time zone NOT NULL,
     end_time timestamp with time zone NOT NULL,
@@ -915,6 +1032,18 @@ COMMENT ON COLUMN template_version_parameters.display_order IS 'Specifies the or
 
 COMMENT ON COLUMN template_version_parameters.ephemeral IS 'The value of an ephemeral parameter will not be preserved between consecutive workspace builds.';
 
+CREATE TABLE template_version_presets (
+    id uuid NOT NULL,
+    template_version_id uuid NOT NULL,
+    name text NOT NULL,
+    parameters jsonb DEFAULT '{}'::jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE template_version_presets IS 'Named sets of rich parameter values that users can pick when creating a workspace from the template version.';
+
+COMMENT ON COLUMN template_version_presets.parameters IS 'Rich parameter values of the preset. They are validated like values provided by the user when a workspace is built.';
+
 CREATE TABLE template_version_variables (
     template_version_id uuid NOT NULL,
     name text NOT NULL,
@@ -974,7 +1103,8 @@ CREATE TABLE users (
     last_seen_at timestamp without time zone DEFAULT '0001-01-01 00:00:00'::timestamp without time zone NOT NULL,
     quiet_hours_schedule text DEFAULT ''::text NOT NULL,
     theme_preference text DEFAULT ''::text NOT NULL,
-    name text DEFAULT ''::text NOT NULL
+    name text DEFAULT ''::text NOT NULL,
+    is_service_account boolean DEFAULT false NOT NULL
 );
 
 COMMENT ON COLUMN users.quiet_hours_schedule IS 'Daily (!) cron schedule (with optional CRON_TZ) signifying the start of the user''s quiet hours. If empty, the default quiet hours on the instance is used instead.';
@@ -983,6 +1113,8 @@ COMMENT ON COLUMN users.theme_preference IS '"" can be interpreted as "the user
 
 COMMENT ON COLUMN users.name IS 'Name of the Coder user';
 
+COMMENT ON COLUMN users.is_service_account IS 'Service accounts are non-human users owned by an organization. They cannot log in and can only authenticate with scoped API tokens.';
+
 CREATE VIEW visible_users AS
  SELECT users.id,
     users.username,
@@ -1106,6 +1238,20 @@ CREATE VIEW template_with_names AS
 
 COMMENT ON VIEW template_with_names IS 'Joins in the display name information such as username, avatar, and organization name.';
 
+CREATE TABLE temporary_role_grants (
+    id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    organization_id uuid,
+    role_name text NOT NULL,
+    justification text DEFAULT ''::text NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    expires_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE temporary_role_grants IS 'Expiry of roles that were granted for a limited time. The roles themselves are stored with the user or organization member, expired roles are ignored for authorization and removed by a background job.';
+
+COMMENT ON COLUMN temporary_role_grants.organization_id IS 'The organization of an organization role, NULL for site wide roles.';
+
 CREATE TABLE user_links (
     user_id uuid NOT NULL,
     login_type login_type NOT NULL,
@@ -1124,6 +1270,40 @@ COMMENT ON COLUMN user_links.oauth_refresh_token_key_id IS 'The ID of the key us
 
 COMMENT ON COLUMN user_links.debug_context IS 'Debug information includes information like id_token and userinfo claims.';
 
+CREATE TABLE user_login_attempts (
+    user_id uuid NOT NULL,
+    failed_attempts integer DEFAULT 0 NOT NULL,
+    last_failed_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE user_login_attempts IS 'Consecutive failed password logins of users. Accounts are locked after too many failed attempts.';
+
+CREATE TABLE user_password_history (
+    id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    hashed_password bytea NOT NULL,
+    created_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE user_password_history IS 'Recent password hashes of users, used to prevent password reuse and to expire passwords. The newest entry is the current password.';
+
+CREATE TABLE user_totp_secrets (
+    user_id uuid NOT NULL,
+    secret text NOT NULL,
+    hashed_recovery_codes text[] DEFAULT '{}'::text[] NOT NULL,
+    last_used_counter bigint DEFAULT 0 NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    confirmed_at timestamp with time zone
+);
+
+COMMENT ON TABLE user_totp_secrets IS 'Time-based one-time password secrets used for multi-factor authentication of password logins.';
+
+COMMENT ON COLUMN user_totp_secrets.hashed_recovery_codes IS 'SHA256 hashes of the unused single-use recovery codes.';
+
+COMMENT ON COLUMN user_totp_secrets.last_used_counter IS 'The time step of the last accepted code. Codes for this or earlier time steps are rejected to prevent replays.';
+
+COMMENT ON COLUMN user_totp_secrets.confirmed_at IS 'NULL until the user has confirmed enrollment with a valid code. Unconfirmed secrets are not required on login.';
+
 CREATE TABLE workspace_agent_log_sources (
     workspace_agent_id uuid NOT NULL,
     id uuid NOT NULL,
@@ -1378,6 +1558,51 @@ CREATE VIEW workspace_build_with_user AS
 
 COMMENT ON VIEW workspace_build_with_user IS 'Joins in the username + avatar url of the initiated by user.';
 
+CREATE TABLE workspace_bulk_job_results (
+    job_id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    status workspace_bulk_result_status DEFAULT 'pending'::workspace_bulk_result_status NOT NULL,
+    error text DEFAULT ''::text NOT NULL,
+    build_id uuid,
+    updated_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON COLUMN workspace_bulk_job_results.build_id IS 'Workspace build started by the start, stop, update and delete actions.';
+
+CREATE TABLE workspace_bulk_jobs (
+    id uuid NOT NULL,
+    initiator_id uuid NOT NULL,
+    initiator_ip text DEFAULT ''::text NOT NULL,
+    initiator_scope api_key_scope DEFAULT 'all'::api_key_scope NOT NULL,
+    initiator_scopes text[] DEFAULT '{}'::text[] NOT NULL,
+    action workspace_bulk_action NOT NULL,
+    query text NOT NULL,
+    automatic_updates automatic_updates DEFAULT 'never'::automatic_updates NOT NULL,
+    dormant boolean DEFAULT false NOT NULL,
+    concurrency integer NOT NULL,
+    worker_id uuid,
+    heartbeat_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL,
+    completed_at timestamp with time zone,
+    CONSTRAINT workspace_bulk_jobs_concurrency_check CHECK ((concurrency > 0))
+);
+
+COMMENT ON TABLE workspace_bulk_jobs IS 'Actions applied to every workspace matched by a workspace search query. Jobs are acquired and run in the background by any replica.';
+
+COMMENT ON COLUMN workspace_bulk_jobs.initiator_ip IS 'IP address of the request that created the job, recorded in audit logs.';
+
+COMMENT ON COLUMN workspace_bulk_jobs.initiator_scope IS 'Scope of the API key that created the job. The job acts with the same scope.';
+
+COMMENT ON COLUMN workspace_bulk_jobs.initiator_scopes IS 'Permissions the API key that created the job was limited to, see api_keys.scopes.';
+
+COMMENT ON COLUMN workspace_bulk_jobs.automatic_updates IS 'Automatic updates setting applied by the automatic_updates action.';
+
+COMMENT ON COLUMN workspace_bulk_jobs.dormant IS 'Dormancy applied by the dormant action.';
+
+COMMENT ON COLUMN workspace_bulk_jobs.concurrency IS 'Maximum number of workspaces the job acts on at the same time.';
+
+COMMENT ON COLUMN workspace_bulk_jobs.worker_id IS 'Replica running the job. Another replica takes the job over when heartbeat_at gets stale.';
+
 CREATE TABLE workspace_proxies (
     id uuid NOT NULL,
     name text NOT NULL,
@@ -1462,11 +1687,17 @@ CREATE TABLE workspaces (
     dormant_at timestamp with time zone,
     deleting_at timestamp with time zone,
     automatic_updates automatic_updates DEFAULT 'never'::automatic_updates NOT NULL,
-    favorite boolean DEFAULT false NOT NULL
+    favorite boolean DEFAULT false NOT NULL,
+    user_acl jsonb DEFAULT '{}'::jsonb NOT NULL,
+    group_acl jsonb DEFAULT '{}'::jsonb NOT NULL
 );
 
 COMMENT ON COLUMN workspaces.favorite IS 'Favorite is true if the workspace owner has favorited the workspace.';
 
+COMMENT ON COLUMN workspaces.user_acl IS 'Users the workspace is shared with, keyed by user ID.';
+
+COMMENT ON COLUMN workspaces.group_acl IS 'Groups the workspace is shared with, keyed by group ID.';
+
 ALTER TABLE ONLY licenses ALTER COLUMN id SET DEFAULT nextval('licenses_id_seq'::regclass);
 
 ALTER TABLE ONLY provisioner_job_logs ALTER COLUMN id SET DEFAULT nextval('provisioner_job_logs_id_seq'::regclass);
@@ -1521,6 +1752,9 @@ ALTER TABLE ONLY groups
 ALTER TABLE ONLY groups
     ADD CONSTRAINT groups_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY inbox_notifications
+    ADD CONSTRAINT inbox_notifications_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY jfrog_xray_scans
     ADD CONSTRAINT jfrog_xray_scans_pkey PRIMARY KEY (agent_id, workspace_id);
 
@@ -1533,6 +1767,9 @@ ALTER TABLE ONLY licenses
 ALTER TABLE ONLY notification_messages
     ADD CONSTRAINT notification_messages_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY notification_preferences
+    ADD CONSTRAINT notification_preferences_pkey PRIMARY KEY (user_id, notification_template_id);
+
 ALTER TABLE ONLY notification_templates
     ADD CONSTRAINT notification_templates_name_key UNIQUE (name);
 
@@ -1545,6 +1782,15 @@ ALTER TABLE ONLY oauth2_provider_app_codes
 ALTER TABLE ONLY oauth2_provider_app_codes
     ADD CONSTRAINT oauth2_provider_app_codes_secret_prefix_key UNIQUE (secret_prefix);
 
+ALTER TABLE ONLY oauth2_provider_app_device_codes
+    ADD CONSTRAINT oauth2_provider_app_device_codes_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY oauth2_provider_app_device_codes
+    ADD CONSTRAINT oauth2_provider_app_device_codes_secret_prefix_key UNIQUE (secret_prefix);
+
+ALTER TABLE ONLY oauth2_provider_app_device_codes
+    ADD CONSTRAINT oauth2_provider_app_device_codes_user_code_key UNIQUE (user_code);
+
 ALTER TABLE ONLY oauth2_provider_app_secrets
     ADD CONSTRAINT oauth2_provider_app_secrets_pkey PRIMARY KEY (id);
 
@@ -1584,6 +1830,9 @@ ALTER TABLE ONLY parameter_values
 ALTER TABLE ONLY parameter_values
     ADD CONSTRAINT parameter_values_scope_id_name_key UNIQUE (scope_id, name);
 
+ALTER TABLE ONLY prebuilt_workspaces
+    ADD CONSTRAINT prebuilt_workspaces_pkey PRIMARY KEY (workspace_id);
+
 ALTER TABLE ONLY provisioner_daemons
     ADD CONSTRAINT provisioner_daemons_pkey PRIMARY KEY (id);
 
@@ -1617,12 +1866,24 @@ ALTER TABLE ONLY tailnet_peers
 ALTER TABLE ONLY tailnet_tunnels
     ADD CONSTRAINT tailnet_tunnels_pkey PRIMARY KEY (coordinator_id, src_id, dst_id);
 
+ALTER TABLE ONLY template_prebuild_pools
+    ADD CONSTRAINT template_prebuild_pools_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY template_prebuild_pools
+    ADD CONSTRAINT template_prebuild_pools_template_id_name_key UNIQUE (template_id, name);
+
 ALTER TABLE ONLY template_usage_stats
     ADD CONSTRAINT template_usage_stats_pkey PRIMARY KEY (start_time, template_id, user_id);
 
 ALTER TABLE ONLY template_version_parameters
     ADD CONSTRAINT template_version_parameters_template_version_id_name_key UNIQUE (template_version_id, name);
 
+ALTER TABLE ONLY template_version_presets
+    ADD CONSTRAINT template_version_presets_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY template_version_presets
+    ADD CONSTRAINT template_version_presets_template_version_id_name_key UNIQUE (template_version_id, name);
+
 ALTER TABLE ONLY template_version_variables
     ADD CONSTRAINT template_version_variables_template_version_id_name_key UNIQUE (template_version_id, name);
 
@@ -1638,9 +1899,21 @@ ALTER TABLE ONLY template_versions
 ALTER TABLE ONLY templates
     ADD CONSTRAINT templates_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY temporary_role_grants
+    ADD CONSTRAINT temporary_role_grants_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY user_links
     ADD CONSTRAINT user_links_pkey PRIMARY KEY (user_id, login_type);
 
+ALTER TABLE ONLY user_login_attempts
+    ADD CONSTRAINT user_login_attempts_pkey PRIMARY KEY (user_id);
+
+ALTER TABLE ONLY user_password_history
+    ADD CONSTRAINT user_password_history_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY user_totp_secrets
+    ADD CONSTRAINT user_totp_secrets_pkey PRIMARY KEY (user_id);
+
 ALTER TABLE ONLY users
     ADD CONSTRAINT users_pkey PRIMARY KEY (id);
 
@@ -1683,6 +1956,12 @@ ALTER TABLE ONLY workspace_builds
 ALTER TABLE ONLY workspace_builds
     ADD CONSTRAINT workspace_builds_workspace_id_build_number_key UNIQUE (workspace_id, build_number);
 
+ALTER TABLE ONLY workspace_bulk_job_results
+    ADD CONSTRAINT workspace_bulk_job_results_pkey PRIMARY KEY (job_id, workspace_id);
+
+ALTER TABLE ONLY workspace_bulk_jobs
+    ADD CONSTRAINT workspace_bulk_jobs_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY workspace_proxies
     ADD CONSTRAINT workspace_proxies_pkey PRIMARY KEY (id);
 
@@ -1721,6 +2000,8 @@ CREATE INDEX idx_custom_roles_id ON custom_roles USING btree (id);
 
 CREATE UNIQUE INDEX idx_custom_roles_name_lower ON custom_roles USING btree (lower(name));
 
+CREATE INDEX idx_inbox_notifications_user_id_read_at ON inbox_notifications USING btree (user_id, read_at);
+
 CREATE INDEX idx_notification_messages_status ON notification_messages USING btree (status);
 
 CREATE INDEX idx_organization_member_organization_id_uuid ON organization_members USING btree (organization_id);
@@ -1731,6 +2012,8 @@ CREATE UNIQUE INDEX idx_organization_name ON organizations USING btree (name);
 
 CREATE UNIQUE INDEX idx_organization_name_lower ON organizations USING btree (lower(name));
 
+CREATE INDEX idx_prebuilt_workspaces_pool_id ON prebuilt_workspaces USING btree (pool_id);
+
 CREATE UNIQUE INDEX idx_provisioner_daemons_name_owner_key ON provisioner_daemons USING btree (name, lower(COALESCE((tags ->> 'owner'::text), ''::text)));
 
 COMMENT ON INDEX idx_provisioner_daemons_name_owner_key IS 'Allow unique provisioner daemon names by user';
@@ -1745,10 +2028,20 @@ CREATE INDEX idx_tailnet_tunnels_dst_id ON tailnet_tunnels USING hash (dst_id);
 
 CREATE INDEX idx_tailnet_tunnels_src_id ON tailnet_tunnels USING hash (src_id);
 
+CREATE INDEX idx_temporary_role_grants_expires_at ON temporary_role_grants USING btree (expires_at);
+
+CREATE UNIQUE INDEX idx_temporary_role_grants_user_role ON temporary_role_grants USING btree (user_id, COALESCE(organization_id, '00000000-0000-0000-0000-000000000000'::uuid), role_name);
+
+CREATE INDEX idx_user_password_history_user_id ON user_password_history USING btree (user_id, created_at DESC);
+
 CREATE UNIQUE INDEX idx_users_email ON users USING btree (email) WHERE (deleted = false);
 
 CREATE UNIQUE INDEX idx_users_username ON users USING btree (username) WHERE (deleted = false);
 
+CREATE INDEX idx_workspace_bulk_jobs_initiator_id ON workspace_bulk_jobs USING btree (initiator_id);
+
+CREATE INDEX idx_workspace_bulk_jobs_uncompleted ON workspace_bulk_jobs USING btree (created_at) WHERE (completed_at IS NULL);
+
 CREATE UNIQUE INDEX organizations_single_default_org ON organizations USING btree (is_default) WHERE (is_default = true);
 
 CREATE INDEX provisioner_job_logs_id_job_id_idx ON provisioner_job_logs USING btree (job_id, id);
@@ -1836,6 +2129,9 @@ ALTER TABLE ONLY group_members
 ALTER TABLE ONLY groups
     ADD CONSTRAINT groups_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY inbox_notifications
+    ADD CONSTRAINT inbox_notifications_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY jfrog_xray_scans
     ADD CONSTRAINT jfrog_xray_scans_agent_id_fkey FOREIGN KEY (agent_id) REFERENCES workspace_agents(id) ON DELETE CASCADE;
 
@@ -1848,21 +2144,39 @@ ALTER TABLE ONLY notification_messages
 ALTER TABLE ONLY notification_messages
     ADD CONSTRAINT notification_messages_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY notification_preferences
+    ADD CONSTRAINT notification_preferences_notification_template_id_fkey FOREIGN KEY (notification_template_id) REFERENCES notification_templates(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY notification_preferences
+    ADD CONSTRAINT notification_preferences_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY oauth2_provider_app_codes
     ADD CONSTRAINT oauth2_provider_app_codes_app_id_fkey FOREIGN KEY (app_id) REFERENCES oauth2_provider_apps(id) ON DELETE CASCADE;
 
 ALTER TABLE ONLY oauth2_provider_app_codes
     ADD CONSTRAINT oauth2_provider_app_codes_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY oauth2_provider_app_device_codes
+    ADD CONSTRAINT oauth2_provider_app_device_codes_app_id_fkey FOREIGN KEY (app_id) REFERENCES oauth2_provider_apps(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY oauth2_provider_app_device_codes
+    ADD CONSTRAINT oauth2_provider_app_device_codes_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY oauth2_provider_app_secrets
     ADD CONSTRAINT oauth2_provider_app_secrets_app_id_fkey FOREIGN KEY (app_id) REFERENCES oauth2_provider_apps(id) ON DELETE CASCADE;
 
 ALTER TABLE ONLY oauth2_provider_app_tokens
     ADD CONSTRAINT oauth2_provider_app_tokens_api_key_id_fkey FOREIGN KEY (api_key_id) REFERENCES api_keys(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY oauth2_provider_app_tokens
+    ADD CONSTRAINT oauth2_provider_app_tokens_app_id_fkey FOREIGN KEY (app_id) REFERENCES oauth2_provider_apps(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY oauth2_provider_app_tokens
     ADD CONSTRAINT oauth2_provider_app_tokens_app_secret_id_fkey FOREIGN KEY (app_secret_id) REFERENCES oauth2_provider_app_secrets(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY oauth2_provider_apps
+    ADD CONSTRAINT oauth2_provider_apps_service_account_id_fkey FOREIGN KEY (service_account_id) REFERENCES users(id) ON DELETE SET NULL;
+
 ALTER TABLE ONLY organization_members
     ADD CONSTRAINT organization_members_organization_id_uuid_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
 
@@ -1872,6 +2186,12 @@ ALTER TABLE ONLY organization_members
 ALTER TABLE ONLY parameter_schemas
     ADD CONSTRAINT parameter_schemas_job_id_fkey FOREIGN KEY (job_id) REFERENCES provisioner_jobs(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY prebuilt_workspaces
+    ADD CONSTRAINT prebuilt_workspaces_pool_id_fkey FOREIGN KEY (pool_id) REFERENCES template_prebuild_pools(id) ON DELETE SET NULL;
+
+ALTER TABLE ONLY prebuilt_workspaces
+    ADD CONSTRAINT prebuilt_workspaces_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY provisioner_daemons
     ADD CONSTRAINT provisioner_daemons_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
 
@@ -1899,9 +2219,15 @@ ALTER TABLE ONLY tailnet_peers
 ALTER TABLE ONLY tailnet_tunnels
     ADD CONSTRAINT tailnet_tunnels_coordinator_id_fkey FOREIGN KEY (coordinator_id) REFERENCES tailnet_coordinators(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY template_prebuild_pools
+    ADD CONSTRAINT template_prebuild_pools_template_id_fkey FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY template_version_parameters
     ADD CONSTRAINT template_version_parameters_template_version_id_fkey FOREIGN KEY (template_version_id) REFERENCES template_versions(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY template_version_presets
+    ADD CONSTRAINT template_version_presets_template_version_id_fkey FOREIGN KEY (template_version_id) REFERENCES template_versions(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY template_version_variables
     ADD CONSTRAINT template_version_variables_template_version_id_fkey FOREIGN KEY (template_version_id) REFERENCES template_versions(id) ON DELETE CASCADE;
 
@@ -1923,6 +2249,12 @@ ALTER TABLE ONLY templates
 ALTER TABLE ONLY templates
     ADD CONSTRAINT templates_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY temporary_role_grants
+    ADD CONSTRAINT temporary_role_grants_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY temporary_role_grants
+    ADD CONSTRAINT temporary_role_grants_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY user_links
     ADD CONSTRAINT user_links_oauth_access_token_key_id_fkey FOREIGN KEY (oauth_access_token_key_id) REFERENCES dbcrypt_keys(active_key_digest);
 
@@ -1932,6 +2264,15 @@ ALTER TABLE ONLY user_links
 ALTER TABLE ONLY user_links
     ADD CONSTRAINT user_links_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY user_login_attempts
+    ADD CONSTRAINT user_login_attempts_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY user_password_history
+    ADD CONSTRAINT user_password_history_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY user_totp_secrets
+    ADD CONSTRAINT user_totp_secrets_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY workspace_agent_log_sources
     ADD CONSTRAINT workspace_agent_log_sources_workspace_agent_id_fkey FOREIGN KEY (workspace_agent_id) REFERENCES workspace_agents(id) ON DELETE CASCADE;
 
@@ -1974,6 +2315,15 @@ ALTER TABLE ONLY workspace_builds
 ALTER TABLE ONLY workspace_builds
     ADD CONSTRAINT workspace_builds_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY workspace_bulk_job_results
+    ADD CONSTRAINT workspace_bulk_job_results_job_id_fkey FOREIGN KEY (job_id) REFERENCES workspace_bulk_jobs(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_bulk_job_results
+    ADD CONSTRAINT workspace_bulk_job_results_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_bulk_jobs
+    ADD CONSTRAINT workspace_bulk_jobs_initiator_id_fkey FOREIGN KEY (initiator_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY workspace_resource_metadata
     ADD CONSTRAINT workspace_resource_metadata_workspace_resource_id_fkey FOREIGN KEY (workspace_resource_id) REFERENCES workspace_resources(id) ON DELETE CASCADE;
 
@@ -0,0 +1,14 @@
+CREATE TABLE notification_preferences
+(
+    user_id                  uuid REFERENCES users ON DELETE CASCADE                  NOT NULL,
+    notification_template_id uuid REFERENCES notification_templates ON DELETE CASCADE NOT NULL,
+    disabled                 bool                                                     NOT NULL DEFAULT FALSE,
+    method                   notification_method,
+    created_at               TIMESTAMP WITH TIME ZONE                                 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at               TIMESTAMP WITH TIME ZONE                                 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, notification_template_id)
+);
+
+COMMENT ON TABLE notification_preferences IS 'Per-user overrides of how (or whether) notifications of each template are delivered.';
+COMMENT ON COLUMN notification_preferences.disabled IS 'If true, notifications of this template will not be enqueued for the user.';
+COMMENT ON COLUMN notification_preferences.method IS 'The preferred delivery method; NULL means the deployment''s default method is used.';
@@ -0,0 +1,2 @@
+-- The "inbox" notification_method value cannot be dropped, see the up migration.
+DROP TABLE IF EXISTS inbox_notifications;
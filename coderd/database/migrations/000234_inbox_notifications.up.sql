@@ -0,0 +1,19 @@
+-- It's not possible to drop enum values from enum types, so the up migration has "IF NOT EXISTS".
+ALTER TYPE notification_method ADD VALUE IF NOT EXISTS 'inbox';
+
+CREATE TABLE inbox_notifications
+(
+    id         uuid                                   NOT NULL PRIMARY KEY,
+    user_id    uuid REFERENCES users ON DELETE CASCADE NOT NULL,
+    title      text                                   NOT NULL,
+    content    text                                   NOT NULL,
+    actions    jsonb                                  NOT NULL DEFAULT '[]'::jsonb,
+    read_at    TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE               NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE inbox_notifications IS 'Notifications delivered to users'' in-app inbox.';
+COMMENT ON COLUMN inbox_notifications.id IS 'The ID of the notification message which was delivered to the inbox.';
+COMMENT ON COLUMN inbox_notifications.read_at IS 'NULL until the user has marked the notification as read.';
+
+CREATE INDEX idx_inbox_notifications_user_id_read_at ON inbox_notifications (user_id, read_at);
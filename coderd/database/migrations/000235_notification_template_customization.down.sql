@@ -0,0 +1,10 @@
+-- Restore the default templates before discarding them.
+UPDATE notification_templates
+SET title_template = COALESCE(default_title_template, title_template),
+    body_template  = COALESCE(default_body_template, body_template);
+
+ALTER TABLE notification_templates
+    DROP COLUMN IF EXISTS default_title_template,
+    DROP COLUMN IF EXISTS default_body_template;
+
+-- The "notification_template" resource_type value cannot be dropped, see the up migration.
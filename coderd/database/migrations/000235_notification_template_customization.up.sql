@@ -0,0 +1,9 @@
+-- It's not possible to drop enum values from enum types, so the up migration has "IF NOT EXISTS".
+ALTER TYPE resource_type ADD VALUE IF NOT EXISTS 'notification_template';
+
+ALTER TABLE notification_templates
+    ADD COLUMN default_title_template text,
+    ADD COLUMN default_body_template  text;
+
+COMMENT ON COLUMN notification_templates.default_title_template IS 'The title template shipped with Coder; NULL unless the title template has been customized by an administrator.';
+COMMENT ON COLUMN notification_templates.default_body_template IS 'The body template shipped with Coder; NULL unless the body template has been customized by an administrator.';
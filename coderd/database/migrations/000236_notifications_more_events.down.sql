@@ -0,0 +1,9 @@
+DELETE FROM notification_templates
+WHERE
+    id IN (
+        '2d1e9f4c-7f1b-4a7e-9d6e-35b1a8c6c0b1',
+        '6a2c8a31-0c4e-4f4e-8d8e-8f4f2b0e2a77',
+        'b5f1e0c2-3a9d-4c8b-9e47-1d2f6a7c8e90',
+        'e1a3d5f7-9b2c-4d6e-8f01-23456789abcd',
+        'f3c7a9b1-5d2e-4f8a-b6c4-0e9d8c7b6a51'
+    );
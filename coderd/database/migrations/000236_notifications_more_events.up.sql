@@ -0,0 +1,74 @@
+INSERT INTO
+    notification_templates (
+        id,
+        name,
+        title_template,
+        body_template,
+        "group",
+        actions
+    )
+VALUES (
+        '2d1e9f4c-7f1b-4a7e-9d6e-35b1a8c6c0b1',
+        'Template Version Promoted',
+        E'Template "{{.Labels.name}}" has a new active version',
+        E'Hi {{.UserName}}\n\n' || E'Version **{{.Labels.version_name}}** of template **{{.Labels.name}}** was promoted to the active version by **{{.Labels.initiator}}**.\n' || E'New workspaces will be created from this version.',
+        'Template Events',
+        '[
+        {
+			"label": "View template version",
+			"url": "{{ base_url }}/templates/{{.Labels.name}}/versions/{{.Labels.version_name}}"
+		}
+    ]'::jsonb
+    ),
+    (
+        '6a2c8a31-0c4e-4f4e-8d8e-8f4f2b0e2a77',
+        'Workspace Manual Build Failed',
+        E'Workspace "{{.Labels.name}}" build failed',
+        E'Hi {{.UserName}}\n\n' || E'A build of workspace **{{.Labels.name}}**, owned by **{{.Labels.workspace_owner_username}}**, failed.\n' || E'The build was a **{{.Labels.transition}}** of template **{{.Labels.template_name}}** (version **{{.Labels.template_version_name}}**), initiated by **{{.Labels.initiator}}**.',
+        'Template Events',
+        '[
+        {
+			"label": "View build",
+			"url": "{{ base_url }}/@{{.Labels.workspace_owner_username}}/{{.Labels.name}}/builds/{{.Labels.workspace_build_number}}"
+		}
+    ]'::jsonb
+    ),
+    (
+        'b5f1e0c2-3a9d-4c8b-9e47-1d2f6a7c8e90',
+        'Workspace Quota Reached',
+        E'Your workspace quota has been reached',
+        E'Hi {{.UserName}}\n\n' || E'A build of your workspace **{{.Labels.name}}** was rejected because it would cost {{.Labels.daily_cost}} credits per day, ' || E'and you have already consumed {{.Labels.credits_consumed}} of your {{.Labels.budget}} credits.\n' || E'To build this workspace, stop or delete other workspaces, or ask an administrator to increase your quota.',
+        'Workspace Events',
+        '[
+        {
+			"label": "View workspaces",
+			"url": "{{ base_url }}/workspaces"
+		}
+    ]'::jsonb
+    ),
+    (
+        'e1a3d5f7-9b2c-4d6e-8f01-23456789abcd',
+        'User Account Created',
+        E'User account "{{.Labels.created_account_name}}" created',
+        E'Hi {{.UserName}}\n\n' || E'A new user account **{{.Labels.created_account_name}}** has been created.',
+        'User Events',
+        '[
+        {
+			"label": "View accounts",
+			"url": "{{ base_url }}/deployment/users?filter=status%3Aactive"
+		}
+    ]'::jsonb
+    ),
+    (
+        'f3c7a9b1-5d2e-4f8a-b6c4-0e9d8c7b6a51',
+        'User Account Suspended',
+        E'User account "{{.Labels.suspended_account_name}}" suspended',
+        E'Hi {{.UserName}}\n\n' || E'User account **{{.Labels.suspended_account_name}}** has been suspended by **{{.Labels.initiator}}**.',
+        'User Events',
+        '[
+        {
+			"label": "View suspended accounts",
+			"url": "{{ base_url }}/deployment/users?filter=status%3Asuspended"
+		}
+    ]'::jsonb
+    );
@@ -0,0 +1,2 @@
+ALTER TABLE notification_templates
+    DROP COLUMN IF EXISTS digest_window_seconds;
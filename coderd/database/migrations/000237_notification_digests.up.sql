@@ -0,0 +1,4 @@
+ALTER TABLE notification_templates
+    ADD COLUMN digest_window_seconds integer NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN notification_templates.digest_window_seconds IS 'If greater than zero, messages of this template are held for this many seconds and then delivered to each user as a single digest; zero delivers every message as soon as possible.';
@@ -0,0 +1,5 @@
+ALTER TYPE audit_action
+  ADD VALUE IF NOT EXISTS 'connect';
+
+ALTER TYPE audit_action
+  ADD VALUE IF NOT EXISTS 'disconnect';
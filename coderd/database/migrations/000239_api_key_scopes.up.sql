@@ -0,0 +1,3 @@
+ALTER TABLE api_keys ADD COLUMN scopes text[] NOT NULL DEFAULT '{}'::text[];
+
+COMMENT ON COLUMN api_keys.scopes IS 'scopes restricts the API key to the listed "<resource>:<action>" permissions, on top of scope. Empty means no additional restriction.';
@@ -0,0 +1,14 @@
+CREATE TABLE user_totp_secrets
+(
+    user_id               uuid REFERENCES users ON DELETE CASCADE NOT NULL PRIMARY KEY,
+    secret                text                                   NOT NULL,
+    hashed_recovery_codes text[]                                 NOT NULL DEFAULT '{}',
+    last_used_counter     bigint                                 NOT NULL DEFAULT 0,
+    created_at            TIMESTAMP WITH TIME ZONE               NOT NULL,
+    confirmed_at          TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE user_totp_secrets IS 'Time-based one-time password secrets used for multi-factor authentication of password logins.';
+COMMENT ON COLUMN user_totp_secrets.hashed_recovery_codes IS 'SHA256 hashes of the unused single-use recovery codes.';
+COMMENT ON COLUMN user_totp_secrets.last_used_counter IS 'The time step of the last accepted code. Codes for this or earlier time steps are rejected to prevent replays.';
+COMMENT ON COLUMN user_totp_secrets.confirmed_at IS 'NULL until the user has confirmed enrollment with a valid code. Unconfirmed secrets are not required on login.';
@@ -0,0 +1,3 @@
+ALTER TABLE api_keys ADD COLUMN user_agent text NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN api_keys.user_agent IS 'user_agent is the User-Agent header of the request that created the API key.';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_login_attempts;
+DROP TABLE IF EXISTS user_password_history;
@@ -0,0 +1,27 @@
+CREATE TABLE user_password_history
+(
+    id              uuid                                   NOT NULL PRIMARY KEY,
+    user_id         uuid REFERENCES users ON DELETE CASCADE NOT NULL,
+    hashed_password bytea                                  NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE               NOT NULL
+);
+
+COMMENT ON TABLE user_password_history IS 'Recent password hashes of users, used to prevent password reuse and to expire passwords. The newest entry is the current password.';
+
+CREATE INDEX idx_user_password_history_user_id ON user_password_history USING btree (user_id, created_at DESC);
+
+-- Existing passwords are considered set now, so that they do not expire
+-- immediately when a maximum password age is configured.
+INSERT INTO user_password_history (id, user_id, hashed_password, created_at)
+SELECT gen_random_uuid(), id, hashed_password, NOW()
+FROM users
+WHERE login_type = 'password' AND hashed_password != ''::bytea AND NOT deleted;
+
+CREATE TABLE user_login_attempts
+(
+    user_id         uuid REFERENCES users ON DELETE CASCADE NOT NULL PRIMARY KEY,
+    failed_attempts integer                                NOT NULL DEFAULT 0,
+    last_failed_at  TIMESTAMP WITH TIME ZONE               NOT NULL
+);
+
+COMMENT ON TABLE user_login_attempts IS 'Consecutive failed password logins of users. Accounts are locked after too many failed attempts.';
@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS oauth2_provider_app_device_codes;
+
+-- Tokens without a secret cannot be represented anymore.
+DELETE FROM oauth2_provider_app_tokens WHERE app_secret_id IS NULL;
+
+ALTER TABLE oauth2_provider_app_tokens
+    ALTER COLUMN app_secret_id SET NOT NULL,
+    DROP COLUMN IF EXISTS app_id;
+
+ALTER TABLE oauth2_provider_app_codes
+    DROP COLUMN IF EXISTS code_challenge,
+    DROP COLUMN IF EXISTS code_challenge_method;
+
+ALTER TABLE oauth2_provider_apps
+    DROP COLUMN IF EXISTS service_account_id;
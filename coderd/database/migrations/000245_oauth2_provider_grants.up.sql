@@ -0,0 +1,42 @@
+ALTER TABLE oauth2_provider_apps
+    ADD COLUMN service_account_id uuid REFERENCES users (id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN oauth2_provider_apps.service_account_id IS 'The service account the app acts as when using the client credentials grant. The grant is disabled when null.';
+
+ALTER TABLE oauth2_provider_app_codes
+    ADD COLUMN code_challenge text NOT NULL DEFAULT '',
+    ADD COLUMN code_challenge_method text NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN oauth2_provider_app_codes.code_challenge IS 'PKCE code challenge (RFC 7636). When set, the code can only be exchanged with the matching code verifier.';
+
+-- Public clients (and device flow clients) have no secret, so tokens now
+-- reference the app directly and the secret only when one was used.
+ALTER TABLE oauth2_provider_app_tokens
+    ADD COLUMN app_id uuid REFERENCES oauth2_provider_apps (id) ON DELETE CASCADE;
+
+UPDATE oauth2_provider_app_tokens
+SET app_id = oauth2_provider_app_secrets.app_id
+FROM oauth2_provider_app_secrets
+WHERE oauth2_provider_app_secrets.id = oauth2_provider_app_tokens.app_secret_id;
+
+ALTER TABLE oauth2_provider_app_tokens
+    ALTER COLUMN app_id SET NOT NULL,
+    ALTER COLUMN app_secret_id DROP NOT NULL;
+
+CREATE TABLE oauth2_provider_app_device_codes (
+    id uuid NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    secret_prefix bytea NOT NULL,
+    hashed_secret bytea NOT NULL,
+    user_code text NOT NULL,
+    app_id uuid NOT NULL REFERENCES oauth2_provider_apps (id) ON DELETE CASCADE,
+    user_id uuid REFERENCES users (id) ON DELETE CASCADE,
+    PRIMARY KEY (id),
+    UNIQUE(secret_prefix),
+    UNIQUE(user_code)
+);
+
+COMMENT ON TABLE oauth2_provider_app_device_codes IS 'Device codes are exchanged for access tokens once a user approves the matching user code (RFC 8628).';
+
+COMMENT ON COLUMN oauth2_provider_app_device_codes.user_id IS 'The user that approved the device, null while authorization is pending.';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS temporary_role_grants;
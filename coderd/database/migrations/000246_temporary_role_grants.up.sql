@@ -0,0 +1,18 @@
+CREATE TABLE temporary_role_grants
+(
+    id              uuid                                           NOT NULL PRIMARY KEY,
+    user_id         uuid REFERENCES users ON DELETE CASCADE        NOT NULL,
+    organization_id uuid REFERENCES organizations ON DELETE CASCADE,
+    role_name       text                                           NOT NULL,
+    justification   text                                           NOT NULL DEFAULT '',
+    created_at      TIMESTAMP WITH TIME ZONE                       NOT NULL,
+    expires_at      TIMESTAMP WITH TIME ZONE                       NOT NULL
+);
+
+COMMENT ON TABLE temporary_role_grants IS 'Expiry of roles that were granted for a limited time. The roles themselves are stored with the user or organization member, expired roles are ignored for authorization and removed by a background job.';
+
+COMMENT ON COLUMN temporary_role_grants.organization_id IS 'The organization of an organization role, NULL for site wide roles.';
+
+CREATE UNIQUE INDEX idx_temporary_role_grants_user_role ON temporary_role_grants USING btree (user_id, COALESCE(organization_id, '00000000-0000-0000-0000-000000000000'::uuid), role_name);
+
+CREATE INDEX idx_temporary_role_grants_expires_at ON temporary_role_grants USING btree (expires_at);
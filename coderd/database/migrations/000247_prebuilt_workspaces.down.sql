@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS prebuilt_workspaces;
+DROP TABLE IF EXISTS template_prebuild_pools;
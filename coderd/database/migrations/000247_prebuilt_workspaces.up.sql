@@ -0,0 +1,28 @@
+CREATE TABLE template_prebuild_pools
+(
+    id                uuid                                        NOT NULL PRIMARY KEY,
+    template_id       uuid REFERENCES templates ON DELETE CASCADE NOT NULL,
+    name              text                                        NOT NULL,
+    desired_instances integer                                     NOT NULL CHECK (desired_instances >= 0),
+    parameters        jsonb                                       NOT NULL DEFAULT '{}'::jsonb,
+    created_at        TIMESTAMP WITH TIME ZONE                    NOT NULL,
+    updated_at        TIMESTAMP WITH TIME ZONE                    NOT NULL,
+    UNIQUE (template_id, name)
+);
+
+COMMENT ON TABLE template_prebuild_pools IS 'Pools of workspaces that are provisioned ahead of time, so that creating a workspace from the template with matching parameters only has to claim one.';
+
+COMMENT ON COLUMN template_prebuild_pools.parameters IS 'Rich parameter values of the prebuilt workspaces. Parameters that are not set use the default value of the active template version.';
+
+CREATE TABLE prebuilt_workspaces
+(
+    workspace_id uuid REFERENCES workspaces ON DELETE CASCADE              NOT NULL PRIMARY KEY,
+    pool_id      uuid REFERENCES template_prebuild_pools ON DELETE SET NULL,
+    created_at   TIMESTAMP WITH TIME ZONE                                  NOT NULL
+);
+
+COMMENT ON TABLE prebuilt_workspaces IS 'Workspaces that were provisioned for a prebuild pool and have not been claimed yet. They are owned by the prebuilds system user.';
+
+COMMENT ON COLUMN prebuilt_workspaces.pool_id IS 'NULL if the pool was removed, the workspace is then deleted by the reconciler.';
+
+CREATE INDEX idx_prebuilt_workspaces_pool_id ON prebuilt_workspaces USING btree (pool_id);
@@ -0,0 +1,13 @@
+CREATE TABLE template_version_presets
+(
+    id                  uuid                                                NOT NULL PRIMARY KEY,
+    template_version_id uuid REFERENCES template_versions ON DELETE CASCADE NOT NULL,
+    name                text                                                NOT NULL,
+    parameters          jsonb                                               NOT NULL DEFAULT '{}'::jsonb,
+    created_at          TIMESTAMP WITH TIME ZONE                            NOT NULL,
+    UNIQUE (template_version_id, name)
+);
+
+COMMENT ON TABLE template_version_presets IS 'Named sets of rich parameter values that users can pick when creating a workspace from the template version.';
+
+COMMENT ON COLUMN template_version_presets.parameters IS 'Rich parameter values of the preset. They are validated like values provided by the user when a workspace is built.';
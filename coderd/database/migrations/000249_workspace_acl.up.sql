@@ -0,0 +1,6 @@
+ALTER TABLE workspaces
+	ADD COLUMN user_acl jsonb DEFAULT '{}'::jsonb NOT NULL,
+	ADD COLUMN group_acl jsonb DEFAULT '{}'::jsonb NOT NULL;
+
+COMMENT ON COLUMN workspaces.user_acl IS 'Users the workspace is shared with, keyed by user ID.';
+COMMENT ON COLUMN workspaces.group_acl IS 'Groups the workspace is shared with, keyed by group ID.';
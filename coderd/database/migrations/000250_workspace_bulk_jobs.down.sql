@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS workspace_bulk_job_results;
+DROP TABLE IF EXISTS workspace_bulk_jobs;
+DROP TYPE IF EXISTS workspace_bulk_result_status;
+DROP TYPE IF EXISTS workspace_bulk_action;
@@ -0,0 +1,66 @@
+CREATE TYPE workspace_bulk_action AS ENUM (
+    'start',
+    'stop',
+    'update',
+    'delete',
+    'automatic_updates',
+    'dormant'
+);
+
+CREATE TYPE workspace_bulk_result_status AS ENUM (
+    'pending',
+    'succeeded',
+    'failed',
+    'skipped'
+);
+
+CREATE TABLE workspace_bulk_jobs
+(
+    id                uuid                                    NOT NULL PRIMARY KEY,
+    initiator_id      uuid REFERENCES users ON DELETE CASCADE NOT NULL,
+    initiator_ip      text                                    NOT NULL DEFAULT ''::text,
+    initiator_scope   api_key_scope                           NOT NULL DEFAULT 'all'::api_key_scope,
+    initiator_scopes  text[]                                  NOT NULL DEFAULT '{}'::text[],
+    action            workspace_bulk_action                   NOT NULL,
+    query             text                                    NOT NULL,
+    automatic_updates automatic_updates                       NOT NULL DEFAULT 'never'::automatic_updates,
+    dormant           boolean                                 NOT NULL DEFAULT false,
+    concurrency       integer                                 NOT NULL CHECK (concurrency > 0),
+    worker_id         uuid,
+    heartbeat_at      TIMESTAMP WITH TIME ZONE,
+    created_at        TIMESTAMP WITH TIME ZONE                NOT NULL,
+    completed_at      TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE workspace_bulk_jobs IS 'Actions applied to every workspace matched by a workspace search query. Jobs are acquired and run in the background by any replica.';
+
+COMMENT ON COLUMN workspace_bulk_jobs.initiator_ip IS 'IP address of the request that created the job, recorded in audit logs.';
+
+COMMENT ON COLUMN workspace_bulk_jobs.initiator_scope IS 'Scope of the API key that created the job. The job acts with the same scope.';
+
+COMMENT ON COLUMN workspace_bulk_jobs.initiator_scopes IS 'Permissions the API key that created the job was limited to, see api_keys.scopes.';
+
+COMMENT ON COLUMN workspace_bulk_jobs.automatic_updates IS 'Automatic updates setting applied by the automatic_updates action.';
+
+COMMENT ON COLUMN workspace_bulk_jobs.dormant IS 'Dormancy applied by the dormant action.';
+
+COMMENT ON COLUMN workspace_bulk_jobs.concurrency IS 'Maximum number of workspaces the job acts on at the same time.';
+
+COMMENT ON COLUMN workspace_bulk_jobs.worker_id IS 'Replica running the job. Another replica takes the job over when heartbeat_at gets stale.';
+
+CREATE TABLE workspace_bulk_job_results
+(
+    job_id       uuid REFERENCES workspace_bulk_jobs ON DELETE CASCADE NOT NULL,
+    workspace_id uuid REFERENCES workspaces ON DELETE CASCADE          NOT NULL,
+    status       workspace_bulk_result_status                          NOT NULL DEFAULT 'pending'::workspace_bulk_result_status,
+    error        text                                                  NOT NULL DEFAULT ''::text,
+    build_id     uuid,
+    updated_at   TIMESTAMP WITH TIME ZONE                              NOT NULL,
+    PRIMARY KEY (job_id, workspace_id)
+);
+
+COMMENT ON COLUMN workspace_bulk_job_results.build_id IS 'Workspace build started by the start, stop, update and delete actions.';
+
+CREATE INDEX idx_workspace_bulk_jobs_initiator_id ON workspace_bulk_jobs USING btree (initiator_id);
+
+CREATE INDEX idx_workspace_bulk_jobs_uncompleted ON workspace_bulk_jobs USING btree (created_at) WHERE (completed_at IS NULL);
@@ -0,0 +1,2 @@
+INSERT INTO notification_preferences (user_id, notification_template_id, disabled, method, created_at, updated_at)
+VALUES ('a0061a8e-7db7-4585-838c-3116a003dd21', 'a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11', TRUE, NULL, '2024-07-15 10:30:00+00', '2024-07-15 10:30:00+00');
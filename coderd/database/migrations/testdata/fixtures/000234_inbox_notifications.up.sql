@@ -0,0 +1,4 @@
+INSERT INTO inbox_notifications (id, user_id, title, content, actions, read_at, created_at)
+VALUES ('8bd4ab17-7f49-4df5-8e4a-3b0d1fd82dc5', 'a0061a8e-7db7-4585-838c-3116a003dd21', 'Workspace "dev" deleted',
+        'Your workspace **dev** was deleted.', '[{"label": "View workspaces", "url": "http://localhost:3000/workspaces"}]',
+        NULL, '2024-07-15 10:30:00+00');
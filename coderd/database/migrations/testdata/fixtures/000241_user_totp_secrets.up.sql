@@ -0,0 +1,4 @@
+INSERT INTO user_totp_secrets (user_id, secret, hashed_recovery_codes, last_used_counter, created_at, confirmed_at)
+VALUES ('a0061a8e-7db7-4585-838c-3116a003dd21', 'GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ',
+        ARRAY['9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08'], 57240000,
+        '2024-07-15 10:30:00+00', '2024-07-15 10:31:00+00');
@@ -0,0 +1,6 @@
+INSERT INTO user_password_history (id, user_id, hashed_password, created_at)
+VALUES ('c8a6f1b2-3d4e-4f5a-8b9c-0d1e2f3a4b5c', 'a0061a8e-7db7-4585-838c-3116a003dd21',
+        '\x2470626b6466322d7368613235362431303030302473616c742468617368', '2024-07-15 10:30:00+00');
+
+INSERT INTO user_login_attempts (user_id, failed_attempts, last_failed_at)
+VALUES ('a0061a8e-7db7-4585-838c-3116a003dd21', 2, '2024-07-15 10:32:00+00');
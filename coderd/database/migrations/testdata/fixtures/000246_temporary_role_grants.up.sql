@@ -0,0 +1,3 @@
+INSERT INTO temporary_role_grants (id, user_id, organization_id, role_name, justification, created_at, expires_at)
+VALUES ('5d7d0f3e-4c1a-4b8e-9f2d-6a3b1c0e8f71', 'a0061a8e-7db7-4585-838c-3116a003dd21', NULL,
+        'template-admin', 'Investigating a broken template', '2024-07-15 10:30:00+00', '2024-07-15 14:30:00+00');
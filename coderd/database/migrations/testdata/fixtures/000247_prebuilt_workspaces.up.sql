@@ -0,0 +1,6 @@
+INSERT INTO template_prebuild_pools (id, template_id, name, desired_instances, parameters, created_at, updated_at)
+VALUES ('0b5e4a1c-8d3f-4f6e-9a2b-7c1d0e9f8a63', '4cc1f466-f326-477e-8762-9d0c6781fc56', 'default', 2,
+        '{"region": "eu-west"}', '2024-07-15 10:30:00+00', '2024-07-15 10:30:00+00');
+
+INSERT INTO prebuilt_workspaces (workspace_id, pool_id, created_at)
+VALUES ('b90547be-8870-4d68-8184-e8b2242b7c01', '0b5e4a1c-8d3f-4f6e-9a2b-7c1d0e9f8a63', '2024-07-15 10:35:00+00');
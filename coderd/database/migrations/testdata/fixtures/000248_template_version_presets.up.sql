@@ -0,0 +1,3 @@
+INSERT INTO template_version_presets (id, template_version_id, name, parameters, created_at)
+VALUES ('6a0f2b4e-3c1d-4e8f-9b7a-5d2c8e1f0a94', '920baba5-4c64-4686-8b7d-d1bef5683eae', 'large',
+        '{"region": "eu-west", "instance_size": "large"}', '2024-07-20 09:00:00+00');
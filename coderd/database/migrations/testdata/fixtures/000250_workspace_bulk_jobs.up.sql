@@ -0,0 +1,7 @@
+INSERT INTO workspace_bulk_jobs (id, initiator_id, action, query, concurrency, created_at, completed_at)
+VALUES ('5a0e7c3b-2f4d-4b8e-9c1a-6d3f8e2b7a41', '30095c71-380b-457a-8995-97b8ee6e5307', 'stop', 'owner:oauthuser1', 5,
+        '2024-07-15 10:30:00+00', '2024-07-15 10:31:00+00');
+
+INSERT INTO workspace_bulk_job_results (job_id, workspace_id, status, updated_at)
+VALUES ('5a0e7c3b-2f4d-4b8e-9c1a-6d3f8e2b7a41', 'b90547be-8870-4d68-8184-e8b2242b7c01', 'succeeded',
+        '2024-07-15 10:31:00+00');
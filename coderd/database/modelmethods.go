@@ -112,6 +112,15 @@ func (s APIKeyScope) ToRBAC() rbac.ScopeName {
 	}
 }
 
+// RBACScope returns the scope to authorize requests made with the key. Keys
+// created with a list of permissions are limited to exactly those.
+func (k APIKey) RBACScope() rbac.ExpandableScope {
+	if len(k.Scopes) > 0 {
+		return rbac.ScopePermissions(k.Scopes)
+	}
+	return rbac.ScopeName(k.Scope)
+}
+
 func (k APIKey) RBACObject() rbac.Object {
 	return rbac.ResourceApiKey.WithIDString(k.ID).
 		WithOwner(k.UserID.String())
@@ -174,7 +183,9 @@ func (w Workspace) RBACObject() rbac.Object {
 
 	return rbac.ResourceWorkspace.WithID(w.ID).
 		InOrg(w.OrganizationID).
-		WithOwner(w.OwnerID.String())
+		WithOwner(w.OwnerID.String()).
+		WithACLUserList(w.UserACL).
+		WithGroupACL(w.GroupACL)
 }
 
 func (w Workspace) DormantRBAC() rbac.Object {
@@ -244,9 +255,18 @@ func (u GetUsersRow) RBACObject() rbac.Object {
 	return rbac.ResourceUserObject(u.ID)
 }
 
-func (u GitSSHKey) RBACObject() rbac.Object        { return rbac.ResourceUserObject(u.UserID) }
-func (u ExternalAuthLink) RBACObject() rbac.Object { return rbac.ResourceUserObject(u.UserID) }
-func (u UserLink) RBACObject() rbac.Object         { return rbac.ResourceUserObject(u.UserID) }
+func (u GitSSHKey) RBACObject() rbac.Object              { return rbac.ResourceUserObject(u.UserID) }
+func (u ExternalAuthLink) RBACObject() rbac.Object       { return rbac.ResourceUserObject(u.UserID) }
+func (u UserLink) RBACObject() rbac.Object               { return rbac.ResourceUserObject(u.UserID) }
+func (p NotificationPreference) RBACObject() rbac.Object { return rbac.ResourceUserObject(p.UserID) }
+func (n InboxNotification) RBACObject() rbac.Object      { return rbac.ResourceUserObject(n.UserID) }
+func (j WorkspaceBulkJob) RBACObject() rbac.Object       { return rbac.ResourceUserObject(j.InitiatorID) }
+
+// RBACScope returns the scope of the API key that created the job, so the job
+// can never do more than that key could.
+func (j WorkspaceBulkJob) RBACScope() rbac.ExpandableScope {
+	return APIKey{Scope: j.InitiatorScope, Scopes: j.InitiatorScopes}.RBACScope()
+}
 
 func (u ExternalAuthLink) OAuthToken() *oauth2.Token {
 	return &oauth2.Token{
@@ -264,6 +284,11 @@ func (c OAuth2ProviderAppCode) RBACObject() rbac.Object {
 	return rbac.ResourceOauth2AppCodeToken.WithOwner(c.UserID.String())
 }
 
+func (c OAuth2ProviderAppDeviceCode) RBACObject() rbac.Object {
+	// Pending device codes have no owner, so only the system can access them.
+	return rbac.ResourceOauth2AppCodeToken.WithOwner(c.UserID.UUID.String())
+}
+
 func (OAuth2ProviderAppSecret) RBACObject() rbac.Object {
 	return rbac.ResourceOauth2AppSecret
 }
@@ -374,6 +399,8 @@ func ConvertWorkspaceRows(rows []GetWorkspacesRow) []Workspace {
 			DeletingAt:        r.DeletingAt,
 			AutomaticUpdates:  r.AutomaticUpdates,
 			Favorite:          r.Favorite,
+			UserACL:           r.UserACL,
+			GroupACL:          r.GroupACL,
 		}
 	}
 
@@ -285,6 +285,8 @@ func (q *sqlQuerier) GetAuthorizedWorkspaces(ctx context.Context, arg GetWorkspa
 			&i.DeletingAt,
 			&i.AutomaticUpdates,
 			&i.Favorite,
+			&i.UserACL,
+			&i.GroupACL,
 			&i.TemplateName,
 			&i.TemplateVersionID,
 			&i.TemplateVersionName,
@@ -311,23 +313,31 @@ func (q *sqlQuerier) GetAuthorizedWorkspaces(ctx context.Context, arg GetWorkspa
 
 type userQuerier interface {
 	GetAuthorizedUsers(ctx context.Context, arg GetUsersParams, prepared rbac.PreparedAuthorized) ([]GetUsersRow, error)
+	GetFilteredUsers(ctx context.Context, arg GetUsersParams, filter UserFilter, prepared rbac.PreparedAuthorized) ([]GetUsersRow, error)
+}
+
+// UserFilter is an additional filter on the users returned by GetUsers that
+// cannot be expressed with GetUsersParams, such as a SCIM filter.
+type UserFilter interface {
+	// CompileToSQL returns a boolean SQL expression over the columns of the
+	// "users" table, and the arguments it references. Arguments are numbered
+	// starting at argStart.
+	CompileToSQL(argStart int) (string, []any)
+	// Match reports whether the user matches the filter. It must agree with
+	// the SQL expression, and is used by the in-memory database.
+	Match(user User) bool
 }
 
 func (q *sqlQuerier) GetAuthorizedUsers(ctx context.Context, arg GetUsersParams, prepared rbac.PreparedAuthorized) ([]GetUsersRow, error) {
-	authorizedFilter, err := prepared.CompileToSQL(ctx, regosql.ConvertConfig{
-		VariableConverter: regosql.UserConverter(),
-	})
-	if err != nil {
-		return nil, xerrors.Errorf("compile authorized filter: %w", err)
-	}
+	return q.getFilteredUsers(ctx, "GetAuthorizedUsers", arg, nil, prepared)
+}
 
-	filtered, err := insertAuthorizedFilter(getUsers, fmt.Sprintf(" AND %s", authorizedFilter))
-	if err != nil {
-		return nil, xerrors.Errorf("insert authorized filter: %w", err)
-	}
+func (q *sqlQuerier) GetFilteredUsers(ctx context.Context, arg GetUsersParams, filter UserFilter, prepared rbac.PreparedAuthorized) ([]GetUsersRow, error) {
+	return q.getFilteredUsers(ctx, "GetFilteredUsers", arg, filter, prepared)
+}
 
-	query := fmt.Sprintf("-- name: GetAuthorizedUsers :many\n%s", filtered)
-	rows, err := q.db.QueryContext(ctx, query,
+func (q *sqlQuerier) getFilteredUsers(ctx context.Context, name string, arg GetUsersParams, filter UserFilter, prepared rbac.PreparedAuthorized) ([]GetUsersRow, error) {
+	args := []any{
 		arg.AfterID,
 		arg.Search,
 		pq.Array(arg.Status),
@@ -336,7 +346,31 @@ func (q *sqlQuerier) GetAuthorizedUsers(ctx context.Context, arg GetUsersParams,
 		arg.LastSeenAfter,
 		arg.OffsetOpt,
 		arg.LimitOpt,
-	)
+	}
+
+	var where string
+	if prepared != nil {
+		authorizedFilter, err := prepared.CompileToSQL(ctx, regosql.ConvertConfig{
+			VariableConverter: regosql.UserConverter(),
+		})
+		if err != nil {
+			return nil, xerrors.Errorf("compile authorized filter: %w", err)
+		}
+		where += fmt.Sprintf(" AND %s", authorizedFilter)
+	}
+	if filter != nil {
+		userFilter, filterArgs := filter.CompileToSQL(len(args) + 1)
+		where += fmt.Sprintf(" AND (%s)", userFilter)
+		args = append(args, filterArgs...)
+	}
+
+	filtered, err := insertAuthorizedFilter(getUsers, where)
+	if err != nil {
+		return nil, xerrors.Errorf("insert authorized filter: %w", err)
+	}
+
+	query := fmt.Sprintf("-- name: %s :many\n%s", name, filtered)
+	rows, err := q.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -360,6 +394,7 @@ func (q *sqlQuerier) GetAuthorizedUsers(ctx context.Context, arg GetUsersParams,
 			&i.QuietHoursSchedule,
 			&i.ThemePreference,
 			&i.Name,
+			&i.IsServiceAccount,
 			&i.Count,
 		); err != nil {
 			return nil, err
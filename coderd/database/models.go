@@ -138,14 +138,16 @@ func AllAppSharingLevelValues() []AppSharingLevel {
 type AuditAction string
 
 const (
-	AuditActionCreate   AuditAction = "create"
-	AuditActionWrite    AuditAction = "write"
-	AuditActionDelete   AuditAction = "delete"
-	AuditActionStart    AuditAction = "start"
-	AuditActionStop     AuditAction = "stop"
-	AuditActionLogin    AuditAction = "login"
-	AuditActionLogout   AuditAction = "logout"
-	AuditActionRegister AuditAction = "register"
+	AuditActionCreate     AuditAction = "create"
+	AuditActionWrite      AuditAction = "write"
+	AuditActionDelete     AuditAction = "delete"
+	AuditActionStart      AuditAction = "start"
+	AuditActionStop       AuditAction = "stop"
+	AuditActionLogin      AuditAction = "login"
+	AuditActionLogout     AuditAction = "logout"
+	AuditActionRegister   AuditAction = "register"
+	AuditActionConnect    AuditAction = "connect"
+	AuditActionDisconnect AuditAction = "disconnect"
 )
 
 func (e *AuditAction) Scan(src interface{}) error {
@@ -192,7 +194,9 @@ func (e AuditAction) Valid() bool {
 		AuditActionStop,
 		AuditActionLogin,
 		AuditActionLogout,
-		AuditActionRegister:
+		AuditActionRegister,
+		AuditActionConnect,
+		AuditActionDisconnect:
 		return true
 	}
 	return false
@@ -208,6 +212,8 @@ func AllAuditActionValues() []AuditAction {
 		AuditActionLogin,
 		AuditActionLogout,
 		AuditActionRegister,
+		AuditActionConnect,
+		AuditActionDisconnect,
 	}
 }
 
@@ -599,6 +605,7 @@ const (
 	LoginTypeToken             LoginType = "token"
 	LoginTypeNone              LoginType = "none"
 	LoginTypeOAuth2ProviderApp LoginType = "oauth2_provider_app"
+	LoginTypeLDAP              LoginType = "ldap"
 )
 
 func (e *LoginType) Scan(src interface{}) error {
@@ -643,7 +650,8 @@ func (e LoginType) Valid() bool {
 		LoginTypeOIDC,
 		LoginTypeToken,
 		LoginTypeNone,
-		LoginTypeOAuth2ProviderApp:
+		LoginTypeOAuth2ProviderApp,
+		LoginTypeLDAP:
 		return true
 	}
 	return false
@@ -657,6 +665,7 @@ func AllLoginTypeValues() []LoginType {
 		LoginTypeToken,
 		LoginTypeNone,
 		LoginTypeOAuth2ProviderApp,
+		LoginTypeLDAP,
 	}
 }
 
@@ -735,6 +744,9 @@ type NotificationMethod string
 const (
 	NotificationMethodSmtp    NotificationMethod = "smtp"
 	NotificationMethodWebhook NotificationMethod = "webhook"
+	NotificationMethodSlack   NotificationMethod = "slack"
+	NotificationMethodTeams   NotificationMethod = "teams"
+	NotificationMethodInbox   NotificationMethod = "inbox"
 )
 
 func (e *NotificationMethod) Scan(src interface{}) error {
@@ -775,7 +787,10 @@ func (ns NullNotificationMethod) Value() (driver.Value, error) {
 func (e NotificationMethod) Valid() bool {
 	switch e {
 	case NotificationMethodSmtp,
-		NotificationMethodWebhook:
+		NotificationMethodWebhook,
+		NotificationMethodSlack,
+		NotificationMethodTeams,
+		NotificationMethodInbox:
 		return true
 	}
 	return false
@@ -785,6 +800,9 @@ func AllNotificationMethodValues() []NotificationMethod {
 	return []NotificationMethod{
 		NotificationMethodSmtp,
 		NotificationMethodWebhook,
+		NotificationMethodSlack,
+		NotificationMethodTeams,
+		NotificationMethodInbox,
 	}
 }
 
@@ -1353,6 +1371,7 @@ const (
 	ResourceTypeCustomRole              ResourceType = "custom_role"
 	ResourceTypeOrganizationMember      ResourceType = "organization_member"
 	ResourceTypeNotificationsSettings   ResourceType = "notifications_settings"
+	ResourceTypeNotificationTemplate    ResourceType = "notification_template"
 )
 
 func (e *ResourceType) Scan(src interface{}) error {
@@ -1409,7 +1428,8 @@ func (e ResourceType) Valid() bool {
 		ResourceTypeOauth2ProviderAppSecret,
 		ResourceTypeCustomRole,
 		ResourceTypeOrganizationMember,
-		ResourceTypeNotificationsSettings:
+		ResourceTypeNotificationsSettings,
+		ResourceTypeNotificationTemplate:
 		return true
 	}
 	return false
@@ -1435,6 +1455,7 @@ func AllResourceTypeValues() []ResourceType {
 		ResourceTypeCustomRole,
 		ResourceTypeOrganizationMember,
 		ResourceTypeNotificationsSettings,
+		ResourceTypeNotificationTemplate,
 	}
 }
 
@@ -1823,6 +1844,140 @@ func AllWorkspaceAppHealthValues() []WorkspaceAppHealth {
 	}
 }
 
+type WorkspaceBulkAction string
+
+const (
+	WorkspaceBulkActionStart            WorkspaceBulkAction = "start"
+	WorkspaceBulkActionStop             WorkspaceBulkAction = "stop"
+	WorkspaceBulkActionUpdate           WorkspaceBulkAction = "update"
+	WorkspaceBulkActionDelete           WorkspaceBulkAction = "delete"
+	WorkspaceBulkActionAutomaticUpdates WorkspaceBulkAction = "automatic_updates"
+	WorkspaceBulkActionDormant          WorkspaceBulkAction = "dormant"
+)
+
+func (e *WorkspaceBulkAction) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WorkspaceBulkAction(s)
+	case string:
+		*e = WorkspaceBulkAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WorkspaceBulkAction: %T", src)
+	}
+	return nil
+}
+
+type NullWorkspaceBulkAction struct {
+	WorkspaceBulkAction WorkspaceBulkAction `json:"workspace_bulk_action"`
+	Valid               bool                `json:"valid"` // Valid is true if WorkspaceBulkAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWorkspaceBulkAction) Scan(value interface{}) error {
+	if value == nil {
+		ns.WorkspaceBulkAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WorkspaceBulkAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWorkspaceBulkAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WorkspaceBulkAction), nil
+}
+
+func (e WorkspaceBulkAction) Valid() bool {
+	switch e {
+	case WorkspaceBulkActionStart,
+		WorkspaceBulkActionStop,
+		WorkspaceBulkActionUpdate,
+		WorkspaceBulkActionDelete,
+		WorkspaceBulkActionAutomaticUpdates,
+		WorkspaceBulkActionDormant:
+		return true
+	}
+	return false
+}
+
+func AllWorkspaceBulkActionValues() []WorkspaceBulkAction {
+	return []WorkspaceBulkAction{
+		WorkspaceBulkActionStart,
+		WorkspaceBulkActionStop,
+		WorkspaceBulkActionUpdate,
+		WorkspaceBulkActionDelete,
+		WorkspaceBulkActionAutomaticUpdates,
+		WorkspaceBulkActionDormant,
+	}
+}
+
+type WorkspaceBulkResultStatus string
+
+const (
+	WorkspaceBulkResultStatusPending   WorkspaceBulkResultStatus = "pending"
+	WorkspaceBulkResultStatusSucceeded WorkspaceBulkResultStatus = "succeeded"
+	WorkspaceBulkResultStatusFailed    WorkspaceBulkResultStatus = "failed"
+	WorkspaceBulkResultStatusSkipped   WorkspaceBulkResultStatus = "skipped"
+)
+
+func (e *WorkspaceBulkResultStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WorkspaceBulkResultStatus(s)
+	case string:
+		*e = WorkspaceBulkResultStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WorkspaceBulkResultStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWorkspaceBulkResultStatus struct {
+	WorkspaceBulkResultStatus WorkspaceBulkResultStatus `json:"workspace_bulk_result_status"`
+	Valid                     bool                      `json:"valid"` // Valid is true if WorkspaceBulkResultStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWorkspaceBulkResultStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WorkspaceBulkResultStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WorkspaceBulkResultStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWorkspaceBulkResultStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WorkspaceBulkResultStatus), nil
+}
+
+func (e WorkspaceBulkResultStatus) Valid() bool {
+	switch e {
+	case WorkspaceBulkResultStatusPending,
+		WorkspaceBulkResultStatusSucceeded,
+		WorkspaceBulkResultStatusFailed,
+		WorkspaceBulkResultStatusSkipped:
+		return true
+	}
+	return false
+}
+
+func AllWorkspaceBulkResultStatusValues() []WorkspaceBulkResultStatus {
+	return []WorkspaceBulkResultStatus{
+		WorkspaceBulkResultStatusPending,
+		WorkspaceBulkResultStatusSucceeded,
+		WorkspaceBulkResultStatusFailed,
+		WorkspaceBulkResultStatusSkipped,
+	}
+}
+
 type WorkspaceTransition string
 
 const (
@@ -1898,6 +2053,10 @@ type APIKey struct {
 	IPAddress       pqtype.Inet `db:"ip_address" json:"ip_address"`
 	Scope           APIKeyScope `db:"scope" json:"scope"`
 	TokenName       string      `db:"token_name" json:"token_name"`
+	// scopes restricts the API key to the listed "<resource>:<action>" permissions, on top of scope. Empty means no additional restriction.
+	Scopes []string `db:"scopes" json:"scopes"`
+	// user_agent is the User-Agent header of the request that created the API key.
+	UserAgent string `db:"user_agent" json:"user_agent"`
 }
 
 type AuditLog struct {
@@ -1998,6 +2157,19 @@ type GroupMember struct {
 	GroupID uuid.UUID `db:"group_id" json:"group_id"`
 }
 
+// Notifications delivered to users' in-app inbox.
+type InboxNotification struct {
+	// The ID of the notification message which was delivered to the inbox.
+	ID      uuid.UUID       `db:"id" json:"id"`
+	UserID  uuid.UUID       `db:"user_id" json:"user_id"`
+	Title   string          `db:"title" json:"title"`
+	Content string          `db:"content" json:"content"`
+	Actions json.RawMessage `db:"actions" json:"actions"`
+	// NULL until the user has marked the notification as read.
+	ReadAt    sql.NullTime `db:"read_at" json:"read_at"`
+	CreatedAt time.Time    `db:"created_at" json:"created_at"`
+}
+
 type JfrogXrayScan struct {
 	AgentID     uuid.UUID `db:"agent_id" json:"agent_id"`
 	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
@@ -2034,6 +2206,18 @@ type NotificationMessage struct {
 	QueuedSeconds          sql.NullFloat64           `db:"queued_seconds" json:"queued_seconds"`
 }
 
+// Per-user overrides of how (or whether) notifications of each template are delivered.
+type NotificationPreference struct {
+	UserID                 uuid.UUID `db:"user_id" json:"user_id"`
+	NotificationTemplateID uuid.UUID `db:"notification_template_id" json:"notification_template_id"`
+	// If true, notifications of this template will not be enqueued for the user.
+	Disabled bool `db:"disabled" json:"disabled"`
+	// The preferred delivery method; NULL means the deployment's default method is used.
+	Method    NullNotificationMethod `db:"method" json:"method"`
+	CreatedAt time.Time              `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time              `db:"updated_at" json:"updated_at"`
+}
+
 // Templates from which to create notification messages.
 type NotificationTemplate struct {
 	ID            uuid.UUID      `db:"id" json:"id"`
@@ -2042,6 +2226,12 @@ type NotificationTemplate struct {
 	BodyTemplate  string         `db:"body_template" json:"body_template"`
 	Actions       []byte         `db:"actions" json:"actions"`
 	Group         sql.NullString `db:"group" json:"group"`
+	// The title template shipped with Coder; NULL unless the title template has been customized by an administrator.
+	DefaultTitleTemplate sql.NullString `db:"default_title_template" json:"default_title_template"`
+	// The body template shipped with Coder; NULL unless the body template has been customized by an administrator.
+	DefaultBodyTemplate sql.NullString `db:"default_body_template" json:"default_body_template"`
+	// If greater than zero, messages of this template are held for this many seconds and then delivered to each user as a single digest; zero delivers every message as soon as possible.
+	DigestWindowSeconds int32 `db:"digest_window_seconds" json:"digest_window_seconds"`
 }
 
 // A table used to configure apps that can use Coder as an OAuth2 provider, the reverse of what we are calling external authentication.
@@ -2052,6 +2242,8 @@ type OAuth2ProviderApp struct {
 	Name        string    `db:"name" json:"name"`
 	Icon        string    `db:"icon" json:"icon"`
 	CallbackURL string    `db:"callback_url" json:"callback_url"`
+	// The service account the app acts as when using the client credentials grant. The grant is disabled when null.
+	ServiceAccountID uuid.NullUUID `db:"service_account_id" json:"service_account_id"`
 }
 
 // Codes are meant to be exchanged for access tokens.
@@ -2063,6 +2255,22 @@ type OAuth2ProviderAppCode struct {
 	HashedSecret []byte    `db:"hashed_secret" json:"hashed_secret"`
 	UserID       uuid.UUID `db:"user_id" json:"user_id"`
 	AppID        uuid.UUID `db:"app_id" json:"app_id"`
+	// PKCE code challenge (RFC 7636). When set, the code can only be exchanged with the matching code verifier.
+	CodeChallenge       string `db:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string `db:"code_challenge_method" json:"code_challenge_method"`
+}
+
+// Device codes are exchanged for access tokens once a user approves the matching user code (RFC 8628).
+type OAuth2ProviderAppDeviceCode struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+	ExpiresAt    time.Time `db:"expires_at" json:"expires_at"`
+	SecretPrefix []byte    `db:"secret_prefix" json:"secret_prefix"`
+	HashedSecret []byte    `db:"hashed_secret" json:"hashed_secret"`
+	UserCode     string    `db:"user_code" json:"user_code"`
+	AppID        uuid.UUID `db:"app_id" json:"app_id"`
+	// The user that approved the device, null while authorization is pending.
+	UserID uuid.NullUUID `db:"user_id" json:"user_id"`
 }
 
 type OAuth2ProviderAppSecret struct {
@@ -2082,9 +2290,10 @@ type OAuth2ProviderAppToken struct {
 	ExpiresAt  time.Time `db:"expires_at" json:"expires_at"`
 	HashPrefix []byte    `db:"hash_prefix" json:"hash_prefix"`
 	// Refresh tokens provide a way to refresh an access token (API key). An expired API key can be refreshed if this token is not yet expired, meaning this expiry can outlive an API key.
-	RefreshHash []byte    `db:"refresh_hash" json:"refresh_hash"`
-	AppSecretID uuid.UUID `db:"app_secret_id" json:"app_secret_id"`
-	APIKeyID    string    `db:"api_key_id" json:"api_key_id"`
+	RefreshHash []byte        `db:"refresh_hash" json:"refresh_hash"`
+	AppSecretID uuid.NullUUID `db:"app_secret_id" json:"app_secret_id"`
+	APIKeyID    string        `db:"api_key_id" json:"api_key_id"`
+	AppID       uuid.UUID     `db:"app_id" json:"app_id"`
 }
 
 type Organization struct {
@@ -2138,6 +2347,14 @@ type ParameterValue struct {
 	DestinationScheme ParameterDestinationScheme `db:"destination_scheme" json:"destination_scheme"`
 }
 
+// Workspaces that were provisioned for a prebuild pool and have not been claimed yet. They are owned by the prebuilds system user.
+type PrebuiltWorkspace struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	// NULL if the pool was removed, the workspace is then deleted by the reconciler.
+	PoolID    uuid.NullUUID `db:"pool_id" json:"pool_id"`
+	CreatedAt time.Time     `db:"created_at" json:"created_at"`
+}
+
 type ProvisionerDaemon struct {
 	ID           uuid.UUID         `db:"id" json:"id"`
 	CreatedAt    time.Time         `db:"created_at" json:"created_at"`
@@ -2333,6 +2550,18 @@ type TemplateTable struct {
 	MaxPortSharingLevel AppSharingLevel `db:"max_port_sharing_level" json:"max_port_sharing_level"`
 }
 
+// Pools of workspaces that are provisioned ahead of time, so that creating a workspace from the template with matching parameters only has to claim one.
+type TemplatePrebuildPool struct {
+	ID               uuid.UUID `db:"id" json:"id"`
+	TemplateID       uuid.UUID `db:"template_id" json:"template_id"`
+	Name             string    `db:"name" json:"name"`
+	DesiredInstances int32     `db:"desired_instances" json:"desired_instances"`
+	// Rich parameter values of the prebuilt workspaces. Parameters that are not set use the default value of the active template version.
+	Parameters StringMap `db:"parameters" json:"parameters"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updated_at"`
+}
+
 // Records aggregated usage statistics for templates/users. All usage is rounded up to the nearest minute.
 type TemplateUsageStat struct {
 	// Start time of the usage period.
@@ -2415,6 +2644,16 @@ type TemplateVersionParameter struct {
 	Ephemeral bool `db:"ephemeral" json:"ephemeral"`
 }
 
+// Named sets of rich parameter values that users can pick when creating a workspace from the template version.
+type TemplateVersionPreset struct {
+	ID                uuid.UUID `db:"id" json:"id"`
+	TemplateVersionID uuid.UUID `db:"template_version_id" json:"template_version_id"`
+	Name              string    `db:"name" json:"name"`
+	// Rich parameter values of the preset. They are validated like values provided by the user when a workspace is built.
+	Parameters StringMap `db:"parameters" json:"parameters"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
+
 type TemplateVersionTable struct {
 	ID             uuid.UUID     `db:"id" json:"id"`
 	TemplateID     uuid.NullUUID `db:"template_id" json:"template_id"`
@@ -2456,6 +2695,18 @@ type TemplateVersionWorkspaceTag struct {
 	Value             string    `db:"value" json:"value"`
 }
 
+// Expiry of roles that were granted for a limited time. The roles themselves are stored with the user or organization member, expired roles are ignored for authorization and removed by a background job.
+type TemporaryRoleGrant struct {
+	ID     uuid.UUID `db:"id" json:"id"`
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+	// The organization of an organization role, NULL for site wide roles.
+	OrganizationID uuid.NullUUID `db:"organization_id" json:"organization_id"`
+	RoleName       string        `db:"role_name" json:"role_name"`
+	Justification  string        `db:"justification" json:"justification"`
+	CreatedAt      time.Time     `db:"created_at" json:"created_at"`
+	ExpiresAt      time.Time     `db:"expires_at" json:"expires_at"`
+}
+
 type User struct {
 	ID             uuid.UUID      `db:"id" json:"id"`
 	Email          string         `db:"email" json:"email"`
@@ -2475,6 +2726,8 @@ type User struct {
 	ThemePreference string `db:"theme_preference" json:"theme_preference"`
 	// Name of the Coder user
 	Name string `db:"name" json:"name"`
+	// Service accounts are non-human users owned by an organization. They cannot log in and can only authenticate with scoped API tokens.
+	IsServiceAccount bool `db:"is_service_account" json:"is_service_account"`
 }
 
 type UserLink struct {
@@ -2492,6 +2745,34 @@ type UserLink struct {
 	DebugContext json.RawMessage `db:"debug_context" json:"debug_context"`
 }
 
+// Consecutive failed password logins of users. Accounts are locked after too many failed attempts.
+type UserLoginAttempt struct {
+	UserID         uuid.UUID `db:"user_id" json:"user_id"`
+	FailedAttempts int32     `db:"failed_attempts" json:"failed_attempts"`
+	LastFailedAt   time.Time `db:"last_failed_at" json:"last_failed_at"`
+}
+
+// Recent password hashes of users, used to prevent password reuse and to expire passwords. The newest entry is the current password.
+type UserPasswordHistory struct {
+	ID             uuid.UUID `db:"id" json:"id"`
+	UserID         uuid.UUID `db:"user_id" json:"user_id"`
+	HashedPassword []byte    `db:"hashed_password" json:"hashed_password"`
+	CreatedAt      time.Time `db:"created_at" json:"created_at"`
+}
+
+// Time-based one-time password secrets used for multi-factor authentication of password logins.
+type UserTOTPSecret struct {
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+	Secret string    `db:"secret" json:"secret"`
+	// SHA256 hashes of the unused single-use recovery codes.
+	HashedRecoveryCodes []string `db:"hashed_recovery_codes" json:"hashed_recovery_codes"`
+	// The time step of the last accepted code. Codes for this or earlier time steps are rejected to prevent replays.
+	LastUsedCounter int64     `db:"last_used_counter" json:"last_used_counter"`
+	CreatedAt       time.Time `db:"created_at" json:"created_at"`
+	// NULL until the user has confirmed enrollment with a valid code. Unconfirmed secrets are not required on login.
+	ConfirmedAt sql.NullTime `db:"confirmed_at" json:"confirmed_at"`
+}
+
 // Visible fields of users are allowed to be joined with other tables for including context of other resources.
 type VisibleUser struct {
 	ID        uuid.UUID `db:"id" json:"id"`
@@ -2516,6 +2797,10 @@ type Workspace struct {
 	AutomaticUpdates  AutomaticUpdates `db:"automatic_updates" json:"automatic_updates"`
 	// Favorite is true if the workspace owner has favorited the workspace.
 	Favorite bool `db:"favorite" json:"favorite"`
+	// Users the workspace is shared with, keyed by user ID.
+	UserACL WorkspaceACL `db:"user_acl" json:"user_acl"`
+	// Groups the workspace is shared with, keyed by group ID.
+	GroupACL WorkspaceACL `db:"group_acl" json:"group_acl"`
 }
 
 type WorkspaceAgent struct {
@@ -2724,6 +3009,41 @@ type WorkspaceBuildTable struct {
 	MaxDeadline       time.Time           `db:"max_deadline" json:"max_deadline"`
 }
 
+// Actions applied to every workspace matched by a workspace search query. Jobs run in the background on the replica that accepted them.
+type WorkspaceBulkJob struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	InitiatorID uuid.UUID `db:"initiator_id" json:"initiator_id"`
+	// IP address of the request that created the job, recorded in audit logs.
+	InitiatorIP string `db:"initiator_ip" json:"initiator_ip"`
+	// Scope of the API key that created the job. The job acts with the same scope.
+	InitiatorScope APIKeyScope `db:"initiator_scope" json:"initiator_scope"`
+	// Permissions the API key that created the job was limited to, see api_keys.scopes.
+	InitiatorScopes []string            `db:"initiator_scopes" json:"initiator_scopes"`
+	Action          WorkspaceBulkAction `db:"action" json:"action"`
+	Query           string              `db:"query" json:"query"`
+	// Automatic updates setting applied by the automatic_updates action.
+	AutomaticUpdates AutomaticUpdates `db:"automatic_updates" json:"automatic_updates"`
+	// Dormancy applied by the dormant action.
+	Dormant bool `db:"dormant" json:"dormant"`
+	// Maximum number of workspaces the job acts on at the same time.
+	Concurrency int32 `db:"concurrency" json:"concurrency"`
+	// Replica running the job. Another replica takes the job over when heartbeat_at gets stale.
+	WorkerID    uuid.NullUUID `db:"worker_id" json:"worker_id"`
+	HeartbeatAt sql.NullTime  `db:"heartbeat_at" json:"heartbeat_at"`
+	CreatedAt   time.Time     `db:"created_at" json:"created_at"`
+	CompletedAt sql.NullTime  `db:"completed_at" json:"completed_at"`
+}
+
+type WorkspaceBulkJobResult struct {
+	JobID       uuid.UUID                 `db:"job_id" json:"job_id"`
+	WorkspaceID uuid.UUID                 `db:"workspace_id" json:"workspace_id"`
+	Status      WorkspaceBulkResultStatus `db:"status" json:"status"`
+	Error       string                    `db:"error" json:"error"`
+	// Workspace build started by the start, stop, update and delete actions.
+	BuildID   uuid.NullUUID `db:"build_id" json:"build_id"`
+	UpdatedAt time.Time     `db:"updated_at" json:"updated_at"`
+}
+
 type WorkspaceProxy struct {
 	ID          uuid.UUID `db:"id" json:"id"`
 	Name        string    `db:"name" json:"name"`
@@ -146,7 +146,7 @@ func (q *sqlQuerier) DeleteApplicationConnectAPIKeysByUserID(ctx context.Context
 
 const getAPIKeyByID = `-- name: GetAPIKeyByID :one
 SELECT
-	id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name
+	id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name, scopes, user_agent
 FROM
 	api_keys
 WHERE
@@ -171,13 +171,15 @@ func (q *sqlQuerier) GetAPIKeyByID(ctx context.Context, id string) (APIKey, erro
 		&i.IPAddress,
 		&i.Scope,
 		&i.TokenName,
+		pq.Array(&i.Scopes),
+		&i.UserAgent,
 	)
 	return i, err
 }
 
 const getAPIKeyByName = `-- name: GetAPIKeyByName :one
 SELECT
-	id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name
+	id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name, scopes, user_agent
 FROM
 	api_keys
 WHERE
@@ -210,12 +212,14 @@ func (q *sqlQuerier) GetAPIKeyByName(ctx context.Context, arg GetAPIKeyByNamePar
 		&i.IPAddress,
 		&i.Scope,
 		&i.TokenName,
+		pq.Array(&i.Scopes),
+		&i.UserAgent,
 	)
 	return i, err
 }
 
 const getAPIKeysByLoginType = `-- name: GetAPIKeysByLoginType :many
-SELECT id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name FROM api_keys WHERE login_type = $1
+SELECT id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name, scopes, user_agent FROM api_keys WHERE login_type = $1
 `
 
 func (q *sqlQuerier) GetAPIKeysByLoginType(ctx context.Context, loginType LoginType) ([]APIKey, error) {
@@ -240,6 +244,8 @@ func (q *sqlQuerier) GetAPIKeysByLoginType(ctx context.Context, loginType LoginT
 			&i.IPAddress,
 			&i.Scope,
 			&i.TokenName,
+			pq.Array(&i.Scopes),
+			&i.UserAgent,
 		); err != nil {
 			return nil, err
 		}
@@ -255,7 +261,7 @@ func (q *sqlQuerier) GetAPIKeysByLoginType(ctx context.Context, loginType LoginT
 }
 
 const getAPIKeysByUserID = `-- name: GetAPIKeysByUserID :many
-SELECT id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name FROM api_keys WHERE login_type = $1 AND user_id = $2
+SELECT id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name, scopes, user_agent FROM api_keys WHERE login_type = $1 AND user_id = $2
 `
 
 type GetAPIKeysByUserIDParams struct {
@@ -285,6 +291,8 @@ func (q *sqlQuerier) GetAPIKeysByUserID(ctx context.Context, arg GetAPIKeysByUse
 			&i.IPAddress,
 			&i.Scope,
 			&i.TokenName,
+			pq.Array(&i.Scopes),
+			&i.UserAgent,
 		); err != nil {
 			return nil, err
 		}
@@ -300,7 +308,7 @@ func (q *sqlQuerier) GetAPIKeysByUserID(ctx context.Context, arg GetAPIKeysByUse
 }
 
 const getAPIKeysLastUsedAfter = `-- name: GetAPIKeysLastUsedAfter :many
-SELECT id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name FROM api_keys WHERE last_used > $1
+SELECT id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name, scopes, user_agent FROM api_keys WHERE last_used > $1
 `
 
 func (q *sqlQuerier) GetAPIKeysLastUsedAfter(ctx context.Context, lastUsed time.Time) ([]APIKey, error) {
@@ -325,6 +333,64 @@ func (q *sqlQuerier) GetAPIKeysLastUsedAfter(ctx context.Context, lastUsed time.
 			&i.IPAddress,
 			&i.Scope,
 			&i.TokenName,
+			pq.Array(&i.Scopes),
+			&i.UserAgent,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSessionAPIKeysByUserID = `-- name: GetSessionAPIKeysByUserID :many
+SELECT
+	id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name, scopes, user_agent
+FROM
+	api_keys
+WHERE
+	user_id = $1 AND
+	login_type != 'token'::login_type AND
+	scope = 'all'::api_key_scope AND
+	token_name = '' AND
+	expires_at > now()
+ORDER BY
+	last_used DESC, created_at DESC
+`
+
+// Sessions are the API keys that users get by logging in. Tokens, workspace
+// app keys and keys issued to OAuth2 applications are not sessions. Expired
+// sessions can no longer be used, so they are left out.
+func (q *sqlQuerier) GetSessionAPIKeysByUserID(ctx context.Context, userID uuid.UUID) ([]APIKey, error) {
+	rows, err := q.db.QueryContext(ctx, getSessionAPIKeysByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []APIKey
+	for rows.Next() {
+		var i APIKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.HashedSecret,
+			&i.UserID,
+			&i.LastUsed,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LoginType,
+			&i.LifetimeSeconds,
+			&i.IPAddress,
+			&i.Scope,
+			&i.TokenName,
+			pq.Array(&i.Scopes),
+			&i.UserAgent,
 		); err != nil {
 			return nil, err
 		}
@@ -353,7 +419,9 @@ INSERT INTO
 		updated_at,
 		login_type,
 		scope,
-		token_name
+		token_name,
+		scopes,
+		user_agent
 	)
 VALUES
 	($1,
@@ -362,7 +430,7 @@ VALUES
 	     WHEN 0 THEN 86400
 		 ELSE $2::bigint
 	 END
-	 , $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name
+	 , $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, COALESCE($13::text[], '{}'::text[]), $14) RETURNING id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name, scopes, user_agent
 `
 
 type InsertAPIKeyParams struct {
@@ -378,6 +446,8 @@ type InsertAPIKeyParams struct {
 	LoginType       LoginType   `db:"login_type" json:"login_type"`
 	Scope           APIKeyScope `db:"scope" json:"scope"`
 	TokenName       string      `db:"token_name" json:"token_name"`
+	Scopes          []string    `db:"scopes" json:"scopes"`
+	UserAgent       string      `db:"user_agent" json:"user_agent"`
 }
 
 func (q *sqlQuerier) InsertAPIKey(ctx context.Context, arg InsertAPIKeyParams) (APIKey, error) {
@@ -394,6 +464,8 @@ func (q *sqlQuerier) InsertAPIKey(ctx context.Context, arg InsertAPIKeyParams) (
 		arg.LoginType,
 		arg.Scope,
 		arg.TokenName,
+		pq.Array(arg.Scopes),
+		arg.UserAgent,
 	)
 	var i APIKey
 	err := row.Scan(
@@ -409,6 +481,8 @@ func (q *sqlQuerier) InsertAPIKey(ctx context.Context, arg InsertAPIKeyParams) (
 		&i.IPAddress,
 		&i.Scope,
 		&i.TokenName,
+		pq.Array(&i.Scopes),
+		&i.UserAgent,
 	)
 	return i, err
 }
@@ -441,6 +515,16 @@ func (q *sqlQuerier) UpdateAPIKeyByID(ctx context.Context, arg UpdateAPIKeyByIDP
 	return err
 }
 
+const deleteOldAuditLogs = `-- name: DeleteOldAuditLogs :exec
+DELETE FROM audit_logs WHERE "time" < $1 :: timestamptz
+`
+
+// Delete all audit logs which were created before the given time.
+func (q *sqlQuerier) DeleteOldAuditLogs(ctx context.Context, beforeTime time.Time) error {
+	_, err := q.db.ExecContext(ctx, deleteOldAuditLogs, beforeTime)
+	return err
+}
+
 const getAuditLogsOffset = `-- name: GetAuditLogsOffset :many
 SELECT
     audit_logs.id, audit_logs.time, audit_logs.user_id, audit_logs.organization_id, audit_logs.ip, audit_logs.user_agent, audit_logs.resource_type, audit_logs.resource_id, audit_logs.resource_target, audit_logs.action, audit_logs.diff, audit_logs.status_code, audit_logs.additional_fields, audit_logs.request_id, audit_logs.resource_icon,
@@ -1361,7 +1445,7 @@ func (q *sqlQuerier) GetGroupMembers(ctx context.Context) ([]GroupMember, error)
 
 const getGroupMembersByGroupID = `-- name: GetGroupMembersByGroupID :many
 SELECT
-	users.id, users.email, users.username, users.hashed_password, users.created_at, users.updated_at, users.status, users.rbac_roles, users.login_type, users.avatar_url, users.deleted, users.last_seen_at, users.quiet_hours_schedule, users.theme_preference, users.name
+	users.id, users.email, users.username, users.hashed_password, users.created_at, users.updated_at, users.status, users.rbac_roles, users.login_type, users.avatar_url, users.deleted, users.last_seen_at, users.quiet_hours_schedule, users.theme_preference, users.name, users.is_service_account
 FROM
 	users
 LEFT JOIN
@@ -1410,6 +1494,7 @@ func (q *sqlQuerier) GetGroupMembersByGroupID(ctx context.Context, groupID uuid.
 			&i.QuietHoursSchedule,
 			&i.ThemePreference,
 			&i.Name,
+			&i.IsServiceAccount,
 		); err != nil {
 			return nil, err
 		}
@@ -3309,6 +3394,7 @@ WITH acquired AS (
                 leased_until = NOW() + CONCAT($2::int, ' seconds')::interval
             WHERE id IN (SELECT nm.id
                          FROM notification_messages AS nm
+                                  JOIN notification_templates AS nt ON nm.notification_template_id = nt.id
                          WHERE (
                              (
                                  -- message is in acquirable states
@@ -3334,6 +3420,22 @@ WITH acquired AS (
                                  ELSE true
                                  END
                              )
+                           -- messages of templates with a digest window are held until the oldest of the user's
+                           -- undelivered messages of that template has waited for the window, at which point they are
+                           -- all acquired together to be delivered as a single digest
+                           AND (
+                             nt.digest_window_seconds = 0
+                                 OR EXISTS (SELECT 1
+                                            FROM notification_messages AS oldest
+                                            WHERE oldest.user_id = nm.user_id
+                                              AND oldest.notification_template_id = nm.notification_template_id
+                                              AND oldest.status IN (
+                                                                    'pending'::notification_message_status,
+                                                                    'temporary_failure'::notification_message_status
+                                                )
+                                              AND oldest.created_at <=
+                                                  NOW() - CONCAT(nt.digest_window_seconds, ' seconds')::interval)
+                             )
                          ORDER BY nm.created_at ASC
                                   -- Ensure that multiple concurrent readers cannot retrieve the same rows
                              FOR UPDATE OF nm
@@ -3347,10 +3449,12 @@ SELECT
     nm.method,
     nm.attempt_count::int AS attempt_count,
     nm.queued_seconds::float AS queued_seconds,
+    nm.user_id,
     -- template
     nt.id AS template_id,
     nt.title_template,
-    nt.body_template
+    nt.body_template,
+    nt.digest_window_seconds
 FROM acquired nm
          JOIN notification_templates nt ON nm.notification_template_id = nt.id
 `
@@ -3363,14 +3467,16 @@ type AcquireNotificationMessagesParams struct {
 }
 
 type AcquireNotificationMessagesRow struct {
-	ID            uuid.UUID          `db:"id" json:"id"`
-	Payload       json.RawMessage    `db:"payload" json:"payload"`
-	Method        NotificationMethod `db:"method" json:"method"`
-	AttemptCount  int32              `db:"attempt_count" json:"attempt_count"`
-	QueuedSeconds float64            `db:"queued_seconds" json:"queued_seconds"`
-	TemplateID    uuid.UUID          `db:"template_id" json:"template_id"`
-	TitleTemplate string             `db:"title_template" json:"title_template"`
-	BodyTemplate  string             `db:"body_template" json:"body_template"`
+	ID                  uuid.UUID          `db:"id" json:"id"`
+	Payload             json.RawMessage    `db:"payload" json:"payload"`
+	Method              NotificationMethod `db:"method" json:"method"`
+	AttemptCount        int32              `db:"attempt_count" json:"attempt_count"`
+	QueuedSeconds       float64            `db:"queued_seconds" json:"queued_seconds"`
+	UserID              uuid.UUID          `db:"user_id" json:"user_id"`
+	TemplateID          uuid.UUID          `db:"template_id" json:"template_id"`
+	TitleTemplate       string             `db:"title_template" json:"title_template"`
+	BodyTemplate        string             `db:"body_template" json:"body_template"`
+	DigestWindowSeconds int32              `db:"digest_window_seconds" json:"digest_window_seconds"`
 }
 
 // Acquires the lease for a given count of notification messages, to enable concurrent dequeuing and subsequent sending.
@@ -3403,9 +3509,11 @@ func (q *sqlQuerier) AcquireNotificationMessages(ctx context.Context, arg Acquir
 			&i.Method,
 			&i.AttemptCount,
 			&i.QueuedSeconds,
+			&i.UserID,
 			&i.TemplateID,
 			&i.TitleTemplate,
 			&i.BodyTemplate,
+			&i.DigestWindowSeconds,
 		); err != nil {
 			return nil, err
 		}
@@ -3492,6 +3600,20 @@ func (q *sqlQuerier) BulkMarkNotificationMessagesSent(ctx context.Context, arg B
 	return result.RowsAffected()
 }
 
+const countUnreadInboxNotificationsByUserID = `-- name: CountUnreadInboxNotificationsByUserID :one
+SELECT COUNT(*)
+FROM inbox_notifications
+WHERE user_id = $1
+  AND read_at IS NULL
+`
+
+func (q *sqlQuerier) CountUnreadInboxNotificationsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUnreadInboxNotificationsByUserID, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const deleteOldNotificationMessages = `-- name: DeleteOldNotificationMessages :exec
 DELETE
 FROM notification_messages
@@ -3583,6 +3705,86 @@ func (q *sqlQuerier) FetchNewMessageMetadata(ctx context.Context, arg FetchNewMe
 	return i, err
 }
 
+const getInboxNotificationByID = `-- name: GetInboxNotificationByID :one
+SELECT id, user_id, title, content, actions, read_at, created_at
+FROM inbox_notifications
+WHERE id = $1
+`
+
+func (q *sqlQuerier) GetInboxNotificationByID(ctx context.Context, id uuid.UUID) (InboxNotification, error) {
+	row := q.db.QueryRowContext(ctx, getInboxNotificationByID, id)
+	var i InboxNotification
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Content,
+		&i.Actions,
+		&i.ReadAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getInboxNotificationsByUserID = `-- name: GetInboxNotificationsByUserID :many
+SELECT id, user_id, title, content, actions, read_at, created_at
+FROM inbox_notifications
+WHERE user_id = $1
+  AND CASE WHEN $2::bool THEN read_at IS NULL ELSE TRUE END
+  AND CASE
+          WHEN $3::uuid != '00000000-0000-0000-0000-000000000000'::uuid
+              THEN created_at < (SELECT created_at FROM inbox_notifications WHERE id = $3)
+          ELSE TRUE
+    END
+ORDER BY created_at DESC, id DESC
+LIMIT COALESCE(NULLIF($4::int, 0), 25)
+`
+
+type GetInboxNotificationsByUserIDParams struct {
+	UserID         uuid.UUID `db:"user_id" json:"user_id"`
+	UnreadOnly     bool      `db:"unread_only" json:"unread_only"`
+	StartingBefore uuid.UUID `db:"starting_before" json:"starting_before"`
+	LimitOpt       int32     `db:"limit_opt" json:"limit_opt"`
+}
+
+// Returns the user's inbox notifications, newest first. If starting_before is set, only notifications created before
+// the given notification are returned, which allows the inbox to be paginated.
+func (q *sqlQuerier) GetInboxNotificationsByUserID(ctx context.Context, arg GetInboxNotificationsByUserIDParams) ([]InboxNotification, error) {
+	rows, err := q.db.QueryContext(ctx, getInboxNotificationsByUserID,
+		arg.UserID,
+		arg.UnreadOnly,
+		arg.StartingBefore,
+		arg.LimitOpt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InboxNotification
+	for rows.Next() {
+		var i InboxNotification
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Content,
+			&i.Actions,
+			&i.ReadAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getNotificationMessagesByStatus = `-- name: GetNotificationMessagesByStatus :many
 SELECT id, notification_template_id, user_id, method, status, status_reason, created_by, payload, attempt_count, targets, created_at, updated_at, leased_until, next_retry_after, queued_seconds FROM notification_messages WHERE status = $1 LIMIT $2::int
 `
@@ -3631,127 +3833,554 @@ func (q *sqlQuerier) GetNotificationMessagesByStatus(ctx context.Context, arg Ge
 	return items, nil
 }
 
-const deleteOAuth2ProviderAppByID = `-- name: DeleteOAuth2ProviderAppByID :exec
-DELETE FROM oauth2_provider_apps WHERE id = $1
+const getNotificationTemplateByID = `-- name: GetNotificationTemplateByID :one
+SELECT id, name, title_template, body_template, actions, "group", default_title_template, default_body_template, digest_window_seconds
+FROM notification_templates
+WHERE id = $1::uuid
 `
 
-func (q *sqlQuerier) DeleteOAuth2ProviderAppByID(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppByID, id)
-	return err
+func (q *sqlQuerier) GetNotificationTemplateByID(ctx context.Context, id uuid.UUID) (NotificationTemplate, error) {
+	row := q.db.QueryRowContext(ctx, getNotificationTemplateByID, id)
+	var i NotificationTemplate
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.TitleTemplate,
+		&i.BodyTemplate,
+		&i.Actions,
+		&i.Group,
+		&i.DefaultTitleTemplate,
+		&i.DefaultBodyTemplate,
+		&i.DigestWindowSeconds,
+	)
+	return i, err
 }
 
-const deleteOAuth2ProviderAppCodeByID = `-- name: DeleteOAuth2ProviderAppCodeByID :exec
-DELETE FROM oauth2_provider_app_codes WHERE id = $1
+const getNotificationTemplates = `-- name: GetNotificationTemplates :many
+SELECT id, name, title_template, body_template, actions, "group", default_title_template, default_body_template, digest_window_seconds
+FROM notification_templates
+ORDER BY "group", name
 `
 
-func (q *sqlQuerier) DeleteOAuth2ProviderAppCodeByID(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppCodeByID, id)
-	return err
+func (q *sqlQuerier) GetNotificationTemplates(ctx context.Context) ([]NotificationTemplate, error) {
+	rows, err := q.db.QueryContext(ctx, getNotificationTemplates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NotificationTemplate
+	for rows.Next() {
+		var i NotificationTemplate
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.TitleTemplate,
+			&i.BodyTemplate,
+			&i.Actions,
+			&i.Group,
+			&i.DefaultTitleTemplate,
+			&i.DefaultBodyTemplate,
+			&i.DigestWindowSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const deleteOAuth2ProviderAppCodesByAppAndUserID = `-- name: DeleteOAuth2ProviderAppCodesByAppAndUserID :exec
-DELETE FROM oauth2_provider_app_codes WHERE app_id = $1 AND user_id = $2
+const getUserNotificationPreference = `-- name: GetUserNotificationPreference :one
+SELECT user_id, notification_template_id, disabled, method, created_at, updated_at
+FROM notification_preferences
+WHERE user_id = $1::uuid
+  AND notification_template_id = $2::uuid
 `
 
-type DeleteOAuth2ProviderAppCodesByAppAndUserIDParams struct {
-	AppID  uuid.UUID `db:"app_id" json:"app_id"`
-	UserID uuid.UUID `db:"user_id" json:"user_id"`
+type GetUserNotificationPreferenceParams struct {
+	UserID                 uuid.UUID `db:"user_id" json:"user_id"`
+	NotificationTemplateID uuid.UUID `db:"notification_template_id" json:"notification_template_id"`
 }
 
-func (q *sqlQuerier) DeleteOAuth2ProviderAppCodesByAppAndUserID(ctx context.Context, arg DeleteOAuth2ProviderAppCodesByAppAndUserIDParams) error {
-	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppCodesByAppAndUserID, arg.AppID, arg.UserID)
-	return err
+func (q *sqlQuerier) GetUserNotificationPreference(ctx context.Context, arg GetUserNotificationPreferenceParams) (NotificationPreference, error) {
+	row := q.db.QueryRowContext(ctx, getUserNotificationPreference, arg.UserID, arg.NotificationTemplateID)
+	var i NotificationPreference
+	err := row.Scan(
+		&i.UserID,
+		&i.NotificationTemplateID,
+		&i.Disabled,
+		&i.Method,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
 }
 
-const deleteOAuth2ProviderAppSecretByID = `-- name: DeleteOAuth2ProviderAppSecretByID :exec
-DELETE FROM oauth2_provider_app_secrets WHERE id = $1
+const getUserNotificationPreferences = `-- name: GetUserNotificationPreferences :many
+SELECT nt.id                              AS notification_template_id,
+       nt.name                            AS notification_template_name,
+       nt."group",
+       COALESCE(np.disabled, FALSE)::bool AS disabled,
+       np.method,
+       np.updated_at
+FROM notification_templates nt
+         LEFT JOIN notification_preferences np
+                   ON np.notification_template_id = nt.id AND np.user_id = $1::uuid
+ORDER BY nt."group", nt.name
 `
 
-func (q *sqlQuerier) DeleteOAuth2ProviderAppSecretByID(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppSecretByID, id)
+type GetUserNotificationPreferencesRow struct {
+	NotificationTemplateID   uuid.UUID              `db:"notification_template_id" json:"notification_template_id"`
+	NotificationTemplateName string                 `db:"notification_template_name" json:"notification_template_name"`
+	Group                    sql.NullString         `db:"group" json:"group"`
+	Disabled                 bool                   `db:"disabled" json:"disabled"`
+	Method                   NullNotificationMethod `db:"method" json:"method"`
+	UpdatedAt                sql.NullTime           `db:"updated_at" json:"updated_at"`
+}
+
+// Returns the user's preference for every notification template, using the defaults where no preference has been set.
+func (q *sqlQuerier) GetUserNotificationPreferences(ctx context.Context, userID uuid.UUID) ([]GetUserNotificationPreferencesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserNotificationPreferences, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserNotificationPreferencesRow
+	for rows.Next() {
+		var i GetUserNotificationPreferencesRow
+		if err := rows.Scan(
+			&i.NotificationTemplateID,
+			&i.NotificationTemplateName,
+			&i.Group,
+			&i.Disabled,
+			&i.Method,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertInboxNotification = `-- name: InsertInboxNotification :exec
+INSERT INTO inbox_notifications (id, user_id, title, content, actions, created_at)
+VALUES ($1, $2, $3, $4, $5::jsonb, $6)
+ON CONFLICT (id) DO NOTHING
+`
+
+type InsertInboxNotificationParams struct {
+	ID        uuid.UUID       `db:"id" json:"id"`
+	UserID    uuid.UUID       `db:"user_id" json:"user_id"`
+	Title     string          `db:"title" json:"title"`
+	Content   string          `db:"content" json:"content"`
+	Actions   json.RawMessage `db:"actions" json:"actions"`
+	CreatedAt time.Time       `db:"created_at" json:"created_at"`
+}
+
+// Messages may be redelivered if a notifier fails to record the dispatch result, so conflicting inserts are ignored.
+func (q *sqlQuerier) InsertInboxNotification(ctx context.Context, arg InsertInboxNotificationParams) error {
+	_, err := q.db.ExecContext(ctx, insertInboxNotification,
+		arg.ID,
+		arg.UserID,
+		arg.Title,
+		arg.Content,
+		arg.Actions,
+		arg.CreatedAt,
+	)
 	return err
 }
 
-const deleteOAuth2ProviderAppTokensByAppAndUserID = `-- name: DeleteOAuth2ProviderAppTokensByAppAndUserID :exec
-DELETE FROM
-  oauth2_provider_app_tokens
-USING
-  oauth2_provider_app_secrets, api_keys
-WHERE
-  oauth2_provider_app_secrets.id = oauth2_provider_app_tokens.app_secret_id
-  AND api_keys.id = oauth2_provider_app_tokens.api_key_id
-  AND oauth2_provider_app_secrets.app_id = $1
-	AND api_keys.user_id = $2
+const markAllInboxNotificationsAsRead = `-- name: MarkAllInboxNotificationsAsRead :execrows
+UPDATE inbox_notifications
+SET read_at = $1::timestamptz
+WHERE user_id = $2
+  AND read_at IS NULL
 `
 
-type DeleteOAuth2ProviderAppTokensByAppAndUserIDParams struct {
-	AppID  uuid.UUID `db:"app_id" json:"app_id"`
+type MarkAllInboxNotificationsAsReadParams struct {
+	ReadAt time.Time `db:"read_at" json:"read_at"`
 	UserID uuid.UUID `db:"user_id" json:"user_id"`
 }
 
-func (q *sqlQuerier) DeleteOAuth2ProviderAppTokensByAppAndUserID(ctx context.Context, arg DeleteOAuth2ProviderAppTokensByAppAndUserIDParams) error {
-	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppTokensByAppAndUserID, arg.AppID, arg.UserID)
-	return err
+func (q *sqlQuerier) MarkAllInboxNotificationsAsRead(ctx context.Context, arg MarkAllInboxNotificationsAsReadParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markAllInboxNotificationsAsRead, arg.ReadAt, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const getOAuth2ProviderAppByID = `-- name: GetOAuth2ProviderAppByID :one
-SELECT id, created_at, updated_at, name, icon, callback_url FROM oauth2_provider_apps WHERE id = $1
+const resetNotificationTemplateByID = `-- name: ResetNotificationTemplateByID :one
+UPDATE notification_templates
+SET title_template         = COALESCE(default_title_template, title_template),
+    body_template          = COALESCE(default_body_template, body_template),
+    default_title_template = NULL,
+    default_body_template  = NULL
+WHERE id = $1::uuid
+RETURNING id, name, title_template, body_template, actions, "group", default_title_template, default_body_template, digest_window_seconds
 `
 
-func (q *sqlQuerier) GetOAuth2ProviderAppByID(ctx context.Context, id uuid.UUID) (OAuth2ProviderApp, error) {
-	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppByID, id)
-	var i OAuth2ProviderApp
+func (q *sqlQuerier) ResetNotificationTemplateByID(ctx context.Context, id uuid.UUID) (NotificationTemplate, error) {
+	row := q.db.QueryRowContext(ctx, resetNotificationTemplateByID, id)
+	var i NotificationTemplate
 	err := row.Scan(
 		&i.ID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
 		&i.Name,
-		&i.Icon,
-		&i.CallbackURL,
+		&i.TitleTemplate,
+		&i.BodyTemplate,
+		&i.Actions,
+		&i.Group,
+		&i.DefaultTitleTemplate,
+		&i.DefaultBodyTemplate,
+		&i.DigestWindowSeconds,
 	)
 	return i, err
 }
 
-const getOAuth2ProviderAppCodeByID = `-- name: GetOAuth2ProviderAppCodeByID :one
-SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id FROM oauth2_provider_app_codes WHERE id = $1
+const updateInboxNotificationReadStatus = `-- name: UpdateInboxNotificationReadStatus :one
+UPDATE inbox_notifications
+SET read_at = $1
+WHERE id = $2
+RETURNING id, user_id, title, content, actions, read_at, created_at
 `
 
-func (q *sqlQuerier) GetOAuth2ProviderAppCodeByID(ctx context.Context, id uuid.UUID) (OAuth2ProviderAppCode, error) {
-	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppCodeByID, id)
-	var i OAuth2ProviderAppCode
+type UpdateInboxNotificationReadStatusParams struct {
+	ReadAt sql.NullTime `db:"read_at" json:"read_at"`
+	ID     uuid.UUID    `db:"id" json:"id"`
+}
+
+func (q *sqlQuerier) UpdateInboxNotificationReadStatus(ctx context.Context, arg UpdateInboxNotificationReadStatusParams) (InboxNotification, error) {
+	row := q.db.QueryRowContext(ctx, updateInboxNotificationReadStatus, arg.ReadAt, arg.ID)
+	var i InboxNotification
 	err := row.Scan(
 		&i.ID,
-		&i.CreatedAt,
-		&i.ExpiresAt,
-		&i.SecretPrefix,
-		&i.HashedSecret,
 		&i.UserID,
-		&i.AppID,
+		&i.Title,
+		&i.Content,
+		&i.Actions,
+		&i.ReadAt,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
-const getOAuth2ProviderAppCodeByPrefix = `-- name: GetOAuth2ProviderAppCodeByPrefix :one
-SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id FROM oauth2_provider_app_codes WHERE secret_prefix = $1
+const updateNotificationTemplateByID = `-- name: UpdateNotificationTemplateByID :one
+UPDATE notification_templates
+SET default_title_template = COALESCE(default_title_template, title_template),
+    default_body_template  = COALESCE(default_body_template, body_template),
+    title_template         = $1::text,
+    body_template          = $2::text
+WHERE id = $3::uuid
+RETURNING id, name, title_template, body_template, actions, "group", default_title_template, default_body_template, digest_window_seconds
 `
 
-func (q *sqlQuerier) GetOAuth2ProviderAppCodeByPrefix(ctx context.Context, secretPrefix []byte) (OAuth2ProviderAppCode, error) {
-	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppCodeByPrefix, secretPrefix)
-	var i OAuth2ProviderAppCode
+type UpdateNotificationTemplateByIDParams struct {
+	TitleTemplate string    `db:"title_template" json:"title_template"`
+	BodyTemplate  string    `db:"body_template" json:"body_template"`
+	ID            uuid.UUID `db:"id" json:"id"`
+}
+
+// Replaces the title and body templates, retaining the defaults the first time a template is customized so that it can
+// later be reset.
+func (q *sqlQuerier) UpdateNotificationTemplateByID(ctx context.Context, arg UpdateNotificationTemplateByIDParams) (NotificationTemplate, error) {
+	row := q.db.QueryRowContext(ctx, updateNotificationTemplateByID, arg.TitleTemplate, arg.BodyTemplate, arg.ID)
+	var i NotificationTemplate
 	err := row.Scan(
 		&i.ID,
-		&i.CreatedAt,
-		&i.ExpiresAt,
-		&i.SecretPrefix,
-		&i.HashedSecret,
-		&i.UserID,
-		&i.AppID,
+		&i.Name,
+		&i.TitleTemplate,
+		&i.BodyTemplate,
+		&i.Actions,
+		&i.Group,
+		&i.DefaultTitleTemplate,
+		&i.DefaultBodyTemplate,
+		&i.DigestWindowSeconds,
 	)
 	return i, err
 }
 
-const getOAuth2ProviderAppSecretByID = `-- name: GetOAuth2ProviderAppSecretByID :one
-SELECT id, created_at, last_used_at, hashed_secret, display_secret, app_id, secret_prefix FROM oauth2_provider_app_secrets WHERE id = $1
+const updateNotificationTemplateDigestWindowByID = `-- name: UpdateNotificationTemplateDigestWindowByID :one
+UPDATE notification_templates
+SET digest_window_seconds = $1::int
+WHERE id = $2::uuid
+RETURNING id, name, title_template, body_template, actions, "group", default_title_template, default_body_template, digest_window_seconds
+`
+
+type UpdateNotificationTemplateDigestWindowByIDParams struct {
+	DigestWindowSeconds int32     `db:"digest_window_seconds" json:"digest_window_seconds"`
+	ID                  uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *sqlQuerier) UpdateNotificationTemplateDigestWindowByID(ctx context.Context, arg UpdateNotificationTemplateDigestWindowByIDParams) (NotificationTemplate, error) {
+	row := q.db.QueryRowContext(ctx, updateNotificationTemplateDigestWindowByID, arg.DigestWindowSeconds, arg.ID)
+	var i NotificationTemplate
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.TitleTemplate,
+		&i.BodyTemplate,
+		&i.Actions,
+		&i.Group,
+		&i.DefaultTitleTemplate,
+		&i.DefaultBodyTemplate,
+		&i.DigestWindowSeconds,
+	)
+	return i, err
+}
+
+const upsertUserNotificationPreferences = `-- name: UpsertUserNotificationPreferences :execrows
+INSERT INTO notification_preferences (user_id, notification_template_id, disabled, method, created_at, updated_at)
+SELECT $1::uuid,
+       new_values.notification_template_id,
+       new_values.disabled,
+       NULLIF(new_values.method, '')::notification_method,
+       NOW(),
+       NOW()
+FROM (SELECT UNNEST($2::uuid[]) AS notification_template_id,
+             UNNEST($3::bool[])                  AS disabled,
+             UNNEST($4::text[])                   AS method) AS new_values
+ON CONFLICT (user_id, notification_template_id) DO UPDATE
+    SET disabled   = EXCLUDED.disabled,
+        method     = EXCLUDED.method,
+        updated_at = NOW()
+`
+
+type UpsertUserNotificationPreferencesParams struct {
+	UserID                  uuid.UUID   `db:"user_id" json:"user_id"`
+	NotificationTemplateIDs []uuid.UUID `db:"notification_template_ids" json:"notification_template_ids"`
+	Disabled                []bool      `db:"disabled" json:"disabled"`
+	Methods                 []string    `db:"methods" json:"methods"`
+}
+
+func (q *sqlQuerier) UpsertUserNotificationPreferences(ctx context.Context, arg UpsertUserNotificationPreferencesParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, upsertUserNotificationPreferences,
+		arg.UserID,
+		pq.Array(arg.NotificationTemplateIDs),
+		pq.Array(arg.Disabled),
+		pq.Array(arg.Methods),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteExpiredOAuth2ProviderAppDeviceCodes = `-- name: DeleteExpiredOAuth2ProviderAppDeviceCodes :exec
+DELETE FROM oauth2_provider_app_device_codes WHERE expires_at < $1 :: timestamptz
+`
+
+// Delete the device codes that expired before the given time.
+func (q *sqlQuerier) DeleteExpiredOAuth2ProviderAppDeviceCodes(ctx context.Context, beforeTime time.Time) error {
+	_, err := q.db.ExecContext(ctx, deleteExpiredOAuth2ProviderAppDeviceCodes, beforeTime)
+	return err
+}
+
+const deleteOAuth2ProviderAppByID = `-- name: DeleteOAuth2ProviderAppByID :exec
+DELETE FROM oauth2_provider_apps WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteOAuth2ProviderAppByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppByID, id)
+	return err
+}
+
+const deleteOAuth2ProviderAppCodeByID = `-- name: DeleteOAuth2ProviderAppCodeByID :exec
+DELETE FROM oauth2_provider_app_codes WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteOAuth2ProviderAppCodeByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppCodeByID, id)
+	return err
+}
+
+const deleteOAuth2ProviderAppCodesByAppAndUserID = `-- name: DeleteOAuth2ProviderAppCodesByAppAndUserID :exec
+DELETE FROM oauth2_provider_app_codes WHERE app_id = $1 AND user_id = $2
+`
+
+type DeleteOAuth2ProviderAppCodesByAppAndUserIDParams struct {
+	AppID  uuid.UUID `db:"app_id" json:"app_id"`
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+func (q *sqlQuerier) DeleteOAuth2ProviderAppCodesByAppAndUserID(ctx context.Context, arg DeleteOAuth2ProviderAppCodesByAppAndUserIDParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppCodesByAppAndUserID, arg.AppID, arg.UserID)
+	return err
+}
+
+const deleteOAuth2ProviderAppDeviceCodeByID = `-- name: DeleteOAuth2ProviderAppDeviceCodeByID :exec
+DELETE FROM oauth2_provider_app_device_codes WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteOAuth2ProviderAppDeviceCodeByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppDeviceCodeByID, id)
+	return err
+}
+
+const deleteOAuth2ProviderAppSecretByID = `-- name: DeleteOAuth2ProviderAppSecretByID :exec
+DELETE FROM oauth2_provider_app_secrets WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteOAuth2ProviderAppSecretByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppSecretByID, id)
+	return err
+}
+
+const deleteOAuth2ProviderAppTokensByAppAndUserID = `-- name: DeleteOAuth2ProviderAppTokensByAppAndUserID :exec
+DELETE FROM
+  oauth2_provider_app_tokens
+USING
+  api_keys
+WHERE
+  api_keys.id = oauth2_provider_app_tokens.api_key_id
+  AND oauth2_provider_app_tokens.app_id = $1
+	AND api_keys.user_id = $2
+`
+
+type DeleteOAuth2ProviderAppTokensByAppAndUserIDParams struct {
+	AppID  uuid.UUID `db:"app_id" json:"app_id"`
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+func (q *sqlQuerier) DeleteOAuth2ProviderAppTokensByAppAndUserID(ctx context.Context, arg DeleteOAuth2ProviderAppTokensByAppAndUserIDParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppTokensByAppAndUserID, arg.AppID, arg.UserID)
+	return err
+}
+
+const getOAuth2ProviderAppByID = `-- name: GetOAuth2ProviderAppByID :one
+SELECT id, created_at, updated_at, name, icon, callback_url, service_account_id FROM oauth2_provider_apps WHERE id = $1
+`
+
+func (q *sqlQuerier) GetOAuth2ProviderAppByID(ctx context.Context, id uuid.UUID) (OAuth2ProviderApp, error) {
+	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppByID, id)
+	var i OAuth2ProviderApp
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Icon,
+		&i.CallbackURL,
+		&i.ServiceAccountID,
+	)
+	return i, err
+}
+
+const getOAuth2ProviderAppCodeByID = `-- name: GetOAuth2ProviderAppCodeByID :one
+SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id, code_challenge, code_challenge_method FROM oauth2_provider_app_codes WHERE id = $1
+`
+
+func (q *sqlQuerier) GetOAuth2ProviderAppCodeByID(ctx context.Context, id uuid.UUID) (OAuth2ProviderAppCode, error) {
+	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppCodeByID, id)
+	var i OAuth2ProviderAppCode
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.SecretPrefix,
+		&i.HashedSecret,
+		&i.UserID,
+		&i.AppID,
+		&i.CodeChallenge,
+		&i.CodeChallengeMethod,
+	)
+	return i, err
+}
+
+const getOAuth2ProviderAppCodeByPrefix = `-- name: GetOAuth2ProviderAppCodeByPrefix :one
+SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id, code_challenge, code_challenge_method FROM oauth2_provider_app_codes WHERE secret_prefix = $1
+`
+
+func (q *sqlQuerier) GetOAuth2ProviderAppCodeByPrefix(ctx context.Context, secretPrefix []byte) (OAuth2ProviderAppCode, error) {
+	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppCodeByPrefix, secretPrefix)
+	var i OAuth2ProviderAppCode
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.SecretPrefix,
+		&i.HashedSecret,
+		&i.UserID,
+		&i.AppID,
+		&i.CodeChallenge,
+		&i.CodeChallengeMethod,
+	)
+	return i, err
+}
+
+const getOAuth2ProviderAppDeviceCodeByID = `-- name: GetOAuth2ProviderAppDeviceCodeByID :one
+SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_code, app_id, user_id FROM oauth2_provider_app_device_codes WHERE id = $1
+`
+
+func (q *sqlQuerier) GetOAuth2ProviderAppDeviceCodeByID(ctx context.Context, id uuid.UUID) (OAuth2ProviderAppDeviceCode, error) {
+	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppDeviceCodeByID, id)
+	var i OAuth2ProviderAppDeviceCode
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.SecretPrefix,
+		&i.HashedSecret,
+		&i.UserCode,
+		&i.AppID,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const getOAuth2ProviderAppDeviceCodeByPrefix = `-- name: GetOAuth2ProviderAppDeviceCodeByPrefix :one
+SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_code, app_id, user_id FROM oauth2_provider_app_device_codes WHERE secret_prefix = $1
+`
+
+func (q *sqlQuerier) GetOAuth2ProviderAppDeviceCodeByPrefix(ctx context.Context, secretPrefix []byte) (OAuth2ProviderAppDeviceCode, error) {
+	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppDeviceCodeByPrefix, secretPrefix)
+	var i OAuth2ProviderAppDeviceCode
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.SecretPrefix,
+		&i.HashedSecret,
+		&i.UserCode,
+		&i.AppID,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const getOAuth2ProviderAppDeviceCodeByUserCode = `-- name: GetOAuth2ProviderAppDeviceCodeByUserCode :one
+SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_code, app_id, user_id FROM oauth2_provider_app_device_codes WHERE user_code = $1
+`
+
+func (q *sqlQuerier) GetOAuth2ProviderAppDeviceCodeByUserCode(ctx context.Context, userCode string) (OAuth2ProviderAppDeviceCode, error) {
+	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppDeviceCodeByUserCode, userCode)
+	var i OAuth2ProviderAppDeviceCode
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.SecretPrefix,
+		&i.HashedSecret,
+		&i.UserCode,
+		&i.AppID,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const getOAuth2ProviderAppSecretByID = `-- name: GetOAuth2ProviderAppSecretByID :one
+SELECT id, created_at, last_used_at, hashed_secret, display_secret, app_id, secret_prefix FROM oauth2_provider_app_secrets WHERE id = $1
 `
 
 func (q *sqlQuerier) GetOAuth2ProviderAppSecretByID(ctx context.Context, id uuid.UUID) (OAuth2ProviderAppSecret, error) {
@@ -3823,8 +4452,28 @@ func (q *sqlQuerier) GetOAuth2ProviderAppSecretsByAppID(ctx context.Context, app
 	return items, nil
 }
 
+const getOAuth2ProviderAppTokenByAPIKeyID = `-- name: GetOAuth2ProviderAppTokenByAPIKeyID :one
+SELECT id, created_at, expires_at, hash_prefix, refresh_hash, app_secret_id, api_key_id, app_id FROM oauth2_provider_app_tokens WHERE api_key_id = $1
+`
+
+func (q *sqlQuerier) GetOAuth2ProviderAppTokenByAPIKeyID(ctx context.Context, apiKeyID string) (OAuth2ProviderAppToken, error) {
+	row := q.db.QueryRowContext(ctx, getOAuth2ProviderAppTokenByAPIKeyID, apiKeyID)
+	var i OAuth2ProviderAppToken
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.HashPrefix,
+		&i.RefreshHash,
+		&i.AppSecretID,
+		&i.APIKeyID,
+		&i.AppID,
+	)
+	return i, err
+}
+
 const getOAuth2ProviderAppTokenByPrefix = `-- name: GetOAuth2ProviderAppTokenByPrefix :one
-SELECT id, created_at, expires_at, hash_prefix, refresh_hash, app_secret_id, api_key_id FROM oauth2_provider_app_tokens WHERE hash_prefix = $1
+SELECT id, created_at, expires_at, hash_prefix, refresh_hash, app_secret_id, api_key_id, app_id FROM oauth2_provider_app_tokens WHERE hash_prefix = $1
 `
 
 func (q *sqlQuerier) GetOAuth2ProviderAppTokenByPrefix(ctx context.Context, hashPrefix []byte) (OAuth2ProviderAppToken, error) {
@@ -3838,12 +4487,13 @@ func (q *sqlQuerier) GetOAuth2ProviderAppTokenByPrefix(ctx context.Context, hash
 		&i.RefreshHash,
 		&i.AppSecretID,
 		&i.APIKeyID,
+		&i.AppID,
 	)
 	return i, err
 }
 
 const getOAuth2ProviderApps = `-- name: GetOAuth2ProviderApps :many
-SELECT id, created_at, updated_at, name, icon, callback_url FROM oauth2_provider_apps ORDER BY (name, id) ASC
+SELECT id, created_at, updated_at, name, icon, callback_url, service_account_id FROM oauth2_provider_apps ORDER BY (name, id) ASC
 `
 
 func (q *sqlQuerier) GetOAuth2ProviderApps(ctx context.Context) ([]OAuth2ProviderApp, error) {
@@ -3862,6 +4512,7 @@ func (q *sqlQuerier) GetOAuth2ProviderApps(ctx context.Context) ([]OAuth2Provide
 			&i.Name,
 			&i.Icon,
 			&i.CallbackURL,
+			&i.ServiceAccountID,
 		); err != nil {
 			return nil, err
 		}
@@ -3879,12 +4530,10 @@ func (q *sqlQuerier) GetOAuth2ProviderApps(ctx context.Context) ([]OAuth2Provide
 const getOAuth2ProviderAppsByUserID = `-- name: GetOAuth2ProviderAppsByUserID :many
 SELECT
   COUNT(DISTINCT oauth2_provider_app_tokens.id) as token_count,
-  oauth2_provider_apps.id, oauth2_provider_apps.created_at, oauth2_provider_apps.updated_at, oauth2_provider_apps.name, oauth2_provider_apps.icon, oauth2_provider_apps.callback_url
+  oauth2_provider_apps.id, oauth2_provider_apps.created_at, oauth2_provider_apps.updated_at, oauth2_provider_apps.name, oauth2_provider_apps.icon, oauth2_provider_apps.callback_url, oauth2_provider_apps.service_account_id
 FROM oauth2_provider_app_tokens
-  INNER JOIN oauth2_provider_app_secrets
-    ON oauth2_provider_app_secrets.id = oauth2_provider_app_tokens.app_secret_id
   INNER JOIN oauth2_provider_apps
-    ON oauth2_provider_apps.id = oauth2_provider_app_secrets.app_id
+    ON oauth2_provider_apps.id = oauth2_provider_app_tokens.app_id
   INNER JOIN api_keys
     ON api_keys.id = oauth2_provider_app_tokens.api_key_id
 WHERE
@@ -3915,6 +4564,7 @@ func (q *sqlQuerier) GetOAuth2ProviderAppsByUserID(ctx context.Context, userID u
 			&i.OAuth2ProviderApp.Name,
 			&i.OAuth2ProviderApp.Icon,
 			&i.OAuth2ProviderApp.CallbackURL,
+			&i.OAuth2ProviderApp.ServiceAccountID,
 		); err != nil {
 			return nil, err
 		}
@@ -3936,24 +4586,27 @@ INSERT INTO oauth2_provider_apps (
     updated_at,
     name,
     icon,
-    callback_url
+    callback_url,
+    service_account_id
 ) VALUES(
     $1,
     $2,
     $3,
     $4,
     $5,
-    $6
-) RETURNING id, created_at, updated_at, name, icon, callback_url
+    $6,
+    $7
+) RETURNING id, created_at, updated_at, name, icon, callback_url, service_account_id
 `
 
 type InsertOAuth2ProviderAppParams struct {
-	ID          uuid.UUID `db:"id" json:"id"`
-	CreatedAt   time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
-	Name        string    `db:"name" json:"name"`
-	Icon        string    `db:"icon" json:"icon"`
-	CallbackURL string    `db:"callback_url" json:"callback_url"`
+	ID               uuid.UUID     `db:"id" json:"id"`
+	CreatedAt        time.Time     `db:"created_at" json:"created_at"`
+	UpdatedAt        time.Time     `db:"updated_at" json:"updated_at"`
+	Name             string        `db:"name" json:"name"`
+	Icon             string        `db:"icon" json:"icon"`
+	CallbackURL      string        `db:"callback_url" json:"callback_url"`
+	ServiceAccountID uuid.NullUUID `db:"service_account_id" json:"service_account_id"`
 }
 
 func (q *sqlQuerier) InsertOAuth2ProviderApp(ctx context.Context, arg InsertOAuth2ProviderAppParams) (OAuth2ProviderApp, error) {
@@ -3964,6 +4617,7 @@ func (q *sqlQuerier) InsertOAuth2ProviderApp(ctx context.Context, arg InsertOAut
 		arg.Name,
 		arg.Icon,
 		arg.CallbackURL,
+		arg.ServiceAccountID,
 	)
 	var i OAuth2ProviderApp
 	err := row.Scan(
@@ -3973,6 +4627,7 @@ func (q *sqlQuerier) InsertOAuth2ProviderApp(ctx context.Context, arg InsertOAut
 		&i.Name,
 		&i.Icon,
 		&i.CallbackURL,
+		&i.ServiceAccountID,
 	)
 	return i, err
 }
@@ -3985,7 +4640,9 @@ INSERT INTO oauth2_provider_app_codes (
     secret_prefix,
     hashed_secret,
     app_id,
-    user_id
+    user_id,
+    code_challenge,
+    code_challenge_method
 ) VALUES(
     $1,
     $2,
@@ -3993,18 +4650,22 @@ INSERT INTO oauth2_provider_app_codes (
     $4,
     $5,
     $6,
-    $7
-) RETURNING id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id
+    $7,
+    $8,
+    $9
+) RETURNING id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id, code_challenge, code_challenge_method
 `
 
 type InsertOAuth2ProviderAppCodeParams struct {
-	ID           uuid.UUID `db:"id" json:"id"`
-	CreatedAt    time.Time `db:"created_at" json:"created_at"`
-	ExpiresAt    time.Time `db:"expires_at" json:"expires_at"`
-	SecretPrefix []byte    `db:"secret_prefix" json:"secret_prefix"`
-	HashedSecret []byte    `db:"hashed_secret" json:"hashed_secret"`
-	AppID        uuid.UUID `db:"app_id" json:"app_id"`
-	UserID       uuid.UUID `db:"user_id" json:"user_id"`
+	ID                  uuid.UUID `db:"id" json:"id"`
+	CreatedAt           time.Time `db:"created_at" json:"created_at"`
+	ExpiresAt           time.Time `db:"expires_at" json:"expires_at"`
+	SecretPrefix        []byte    `db:"secret_prefix" json:"secret_prefix"`
+	HashedSecret        []byte    `db:"hashed_secret" json:"hashed_secret"`
+	AppID               uuid.UUID `db:"app_id" json:"app_id"`
+	UserID              uuid.UUID `db:"user_id" json:"user_id"`
+	CodeChallenge       string    `db:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string    `db:"code_challenge_method" json:"code_challenge_method"`
 }
 
 func (q *sqlQuerier) InsertOAuth2ProviderAppCode(ctx context.Context, arg InsertOAuth2ProviderAppCodeParams) (OAuth2ProviderAppCode, error) {
@@ -4016,6 +4677,8 @@ func (q *sqlQuerier) InsertOAuth2ProviderAppCode(ctx context.Context, arg Insert
 		arg.HashedSecret,
 		arg.AppID,
 		arg.UserID,
+		arg.CodeChallenge,
+		arg.CodeChallengeMethod,
 	)
 	var i OAuth2ProviderAppCode
 	err := row.Scan(
@@ -4026,17 +4689,20 @@ func (q *sqlQuerier) InsertOAuth2ProviderAppCode(ctx context.Context, arg Insert
 		&i.HashedSecret,
 		&i.UserID,
 		&i.AppID,
+		&i.CodeChallenge,
+		&i.CodeChallengeMethod,
 	)
 	return i, err
 }
 
-const insertOAuth2ProviderAppSecret = `-- name: InsertOAuth2ProviderAppSecret :one
-INSERT INTO oauth2_provider_app_secrets (
+const insertOAuth2ProviderAppDeviceCode = `-- name: InsertOAuth2ProviderAppDeviceCode :one
+INSERT INTO oauth2_provider_app_device_codes (
     id,
     created_at,
+    expires_at,
     secret_prefix,
     hashed_secret,
-    display_secret,
+    user_code,
     app_id
 ) VALUES(
     $1,
@@ -4044,42 +4710,95 @@ INSERT INTO oauth2_provider_app_secrets (
     $3,
     $4,
     $5,
-    $6
-) RETURNING id, created_at, last_used_at, hashed_secret, display_secret, app_id, secret_prefix
+    $6,
+    $7
+) RETURNING id, created_at, expires_at, secret_prefix, hashed_secret, user_code, app_id, user_id
 `
 
-type InsertOAuth2ProviderAppSecretParams struct {
-	ID            uuid.UUID `db:"id" json:"id"`
-	CreatedAt     time.Time `db:"created_at" json:"created_at"`
-	SecretPrefix  []byte    `db:"secret_prefix" json:"secret_prefix"`
-	HashedSecret  []byte    `db:"hashed_secret" json:"hashed_secret"`
-	DisplaySecret string    `db:"display_secret" json:"display_secret"`
-	AppID         uuid.UUID `db:"app_id" json:"app_id"`
+type InsertOAuth2ProviderAppDeviceCodeParams struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+	ExpiresAt    time.Time `db:"expires_at" json:"expires_at"`
+	SecretPrefix []byte    `db:"secret_prefix" json:"secret_prefix"`
+	HashedSecret []byte    `db:"hashed_secret" json:"hashed_secret"`
+	UserCode     string    `db:"user_code" json:"user_code"`
+	AppID        uuid.UUID `db:"app_id" json:"app_id"`
 }
 
-func (q *sqlQuerier) InsertOAuth2ProviderAppSecret(ctx context.Context, arg InsertOAuth2ProviderAppSecretParams) (OAuth2ProviderAppSecret, error) {
-	row := q.db.QueryRowContext(ctx, insertOAuth2ProviderAppSecret,
+func (q *sqlQuerier) InsertOAuth2ProviderAppDeviceCode(ctx context.Context, arg InsertOAuth2ProviderAppDeviceCodeParams) (OAuth2ProviderAppDeviceCode, error) {
+	row := q.db.QueryRowContext(ctx, insertOAuth2ProviderAppDeviceCode,
 		arg.ID,
 		arg.CreatedAt,
+		arg.ExpiresAt,
 		arg.SecretPrefix,
 		arg.HashedSecret,
-		arg.DisplaySecret,
+		arg.UserCode,
 		arg.AppID,
 	)
-	var i OAuth2ProviderAppSecret
+	var i OAuth2ProviderAppDeviceCode
 	err := row.Scan(
 		&i.ID,
 		&i.CreatedAt,
-		&i.LastUsedAt,
+		&i.ExpiresAt,
+		&i.SecretPrefix,
 		&i.HashedSecret,
-		&i.DisplaySecret,
+		&i.UserCode,
 		&i.AppID,
-		&i.SecretPrefix,
+		&i.UserID,
 	)
 	return i, err
 }
 
-const insertOAuth2ProviderAppToken = `-- name: InsertOAuth2ProviderAppToken :one
+const insertOAuth2ProviderAppSecret = `-- name: InsertOAuth2ProviderAppSecret :one
+INSERT INTO oauth2_provider_app_secrets (
+    id,
+    created_at,
+    secret_prefix,
+    hashed_secret,
+    display_secret,
+    app_id
+) VALUES(
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+) RETURNING id, created_at, last_used_at, hashed_secret, display_secret, app_id, secret_prefix
+`
+
+type InsertOAuth2ProviderAppSecretParams struct {
+	ID            uuid.UUID `db:"id" json:"id"`
+	CreatedAt     time.Time `db:"created_at" json:"created_at"`
+	SecretPrefix  []byte    `db:"secret_prefix" json:"secret_prefix"`
+	HashedSecret  []byte    `db:"hashed_secret" json:"hashed_secret"`
+	DisplaySecret string    `db:"display_secret" json:"display_secret"`
+	AppID         uuid.UUID `db:"app_id" json:"app_id"`
+}
+
+func (q *sqlQuerier) InsertOAuth2ProviderAppSecret(ctx context.Context, arg InsertOAuth2ProviderAppSecretParams) (OAuth2ProviderAppSecret, error) {
+	row := q.db.QueryRowContext(ctx, insertOAuth2ProviderAppSecret,
+		arg.ID,
+		arg.CreatedAt,
+		arg.SecretPrefix,
+		arg.HashedSecret,
+		arg.DisplaySecret,
+		arg.AppID,
+	)
+	var i OAuth2ProviderAppSecret
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.HashedSecret,
+		&i.DisplaySecret,
+		&i.AppID,
+		&i.SecretPrefix,
+	)
+	return i, err
+}
+
+const insertOAuth2ProviderAppToken = `-- name: InsertOAuth2ProviderAppToken :one
 INSERT INTO oauth2_provider_app_tokens (
     id,
     created_at,
@@ -4087,7 +4806,8 @@ INSERT INTO oauth2_provider_app_tokens (
     hash_prefix,
     refresh_hash,
     app_secret_id,
-    api_key_id
+    api_key_id,
+    app_id
 ) VALUES(
     $1,
     $2,
@@ -4095,18 +4815,20 @@ INSERT INTO oauth2_provider_app_tokens (
     $4,
     $5,
     $6,
-    $7
-) RETURNING id, created_at, expires_at, hash_prefix, refresh_hash, app_secret_id, api_key_id
+    $7,
+    $8
+) RETURNING id, created_at, expires_at, hash_prefix, refresh_hash, app_secret_id, api_key_id, app_id
 `
 
 type InsertOAuth2ProviderAppTokenParams struct {
-	ID          uuid.UUID `db:"id" json:"id"`
-	CreatedAt   time.Time `db:"created_at" json:"created_at"`
-	ExpiresAt   time.Time `db:"expires_at" json:"expires_at"`
-	HashPrefix  []byte    `db:"hash_prefix" json:"hash_prefix"`
-	RefreshHash []byte    `db:"refresh_hash" json:"refresh_hash"`
-	AppSecretID uuid.UUID `db:"app_secret_id" json:"app_secret_id"`
-	APIKeyID    string    `db:"api_key_id" json:"api_key_id"`
+	ID          uuid.UUID     `db:"id" json:"id"`
+	CreatedAt   time.Time     `db:"created_at" json:"created_at"`
+	ExpiresAt   time.Time     `db:"expires_at" json:"expires_at"`
+	HashPrefix  []byte        `db:"hash_prefix" json:"hash_prefix"`
+	RefreshHash []byte        `db:"refresh_hash" json:"refresh_hash"`
+	AppSecretID uuid.NullUUID `db:"app_secret_id" json:"app_secret_id"`
+	APIKeyID    string        `db:"api_key_id" json:"api_key_id"`
+	AppID       uuid.UUID     `db:"app_id" json:"app_id"`
 }
 
 func (q *sqlQuerier) InsertOAuth2ProviderAppToken(ctx context.Context, arg InsertOAuth2ProviderAppTokenParams) (OAuth2ProviderAppToken, error) {
@@ -4118,6 +4840,7 @@ func (q *sqlQuerier) InsertOAuth2ProviderAppToken(ctx context.Context, arg Inser
 		arg.RefreshHash,
 		arg.AppSecretID,
 		arg.APIKeyID,
+		arg.AppID,
 	)
 	var i OAuth2ProviderAppToken
 	err := row.Scan(
@@ -4128,6 +4851,7 @@ func (q *sqlQuerier) InsertOAuth2ProviderAppToken(ctx context.Context, arg Inser
 		&i.RefreshHash,
 		&i.AppSecretID,
 		&i.APIKeyID,
+		&i.AppID,
 	)
 	return i, err
 }
@@ -4137,16 +4861,18 @@ UPDATE oauth2_provider_apps SET
     updated_at = $2,
     name = $3,
     icon = $4,
-    callback_url = $5
-WHERE id = $1 RETURNING id, created_at, updated_at, name, icon, callback_url
+    callback_url = $5,
+    service_account_id = $6
+WHERE id = $1 RETURNING id, created_at, updated_at, name, icon, callback_url, service_account_id
 `
 
 type UpdateOAuth2ProviderAppByIDParams struct {
-	ID          uuid.UUID `db:"id" json:"id"`
-	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
-	Name        string    `db:"name" json:"name"`
-	Icon        string    `db:"icon" json:"icon"`
-	CallbackURL string    `db:"callback_url" json:"callback_url"`
+	ID               uuid.UUID     `db:"id" json:"id"`
+	UpdatedAt        time.Time     `db:"updated_at" json:"updated_at"`
+	Name             string        `db:"name" json:"name"`
+	Icon             string        `db:"icon" json:"icon"`
+	CallbackURL      string        `db:"callback_url" json:"callback_url"`
+	ServiceAccountID uuid.NullUUID `db:"service_account_id" json:"service_account_id"`
 }
 
 func (q *sqlQuerier) UpdateOAuth2ProviderAppByID(ctx context.Context, arg UpdateOAuth2ProviderAppByIDParams) (OAuth2ProviderApp, error) {
@@ -4156,6 +4882,7 @@ func (q *sqlQuerier) UpdateOAuth2ProviderAppByID(ctx context.Context, arg Update
 		arg.Name,
 		arg.Icon,
 		arg.CallbackURL,
+		arg.ServiceAccountID,
 	)
 	var i OAuth2ProviderApp
 	err := row.Scan(
@@ -4165,6 +4892,34 @@ func (q *sqlQuerier) UpdateOAuth2ProviderAppByID(ctx context.Context, arg Update
 		&i.Name,
 		&i.Icon,
 		&i.CallbackURL,
+		&i.ServiceAccountID,
+	)
+	return i, err
+}
+
+const updateOAuth2ProviderAppDeviceCodeByID = `-- name: UpdateOAuth2ProviderAppDeviceCodeByID :one
+UPDATE oauth2_provider_app_device_codes SET
+    user_id = $2
+WHERE id = $1 RETURNING id, created_at, expires_at, secret_prefix, hashed_secret, user_code, app_id, user_id
+`
+
+type UpdateOAuth2ProviderAppDeviceCodeByIDParams struct {
+	ID     uuid.UUID     `db:"id" json:"id"`
+	UserID uuid.NullUUID `db:"user_id" json:"user_id"`
+}
+
+func (q *sqlQuerier) UpdateOAuth2ProviderAppDeviceCodeByID(ctx context.Context, arg UpdateOAuth2ProviderAppDeviceCodeByIDParams) (OAuth2ProviderAppDeviceCode, error) {
+	row := q.db.QueryRowContext(ctx, updateOAuth2ProviderAppDeviceCodeByID, arg.ID, arg.UserID)
+	var i OAuth2ProviderAppDeviceCode
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.SecretPrefix,
+		&i.HashedSecret,
+		&i.UserCode,
+		&i.AppID,
+		&i.UserID,
 	)
 	return i, err
 }
@@ -4196,6 +4951,15 @@ func (q *sqlQuerier) UpdateOAuth2ProviderAppSecretByID(ctx context.Context, arg
 }
 
 const deleteOrganizationMember = `-- name: DeleteOrganizationMember :exec
+WITH removed_workspace_acls AS (
+	UPDATE
+		workspaces
+	SET
+		user_acl = user_acl - $2 :: text
+	WHERE
+		organization_id = $1 AND
+		user_acl ? $2 :: text
+)
 DELETE
 	FROM
 		organization_members
@@ -4209,6 +4973,8 @@ type DeleteOrganizationMemberParams struct {
 	UserID         uuid.UUID `db:"user_id" json:"user_id"`
 }
 
+// Workspaces can only be shared within their organization, so the user is
+// removed from the workspace ACLs of the organization too.
 func (q *sqlQuerier) DeleteOrganizationMember(ctx context.Context, arg DeleteOrganizationMemberParams) error {
 	_, err := q.db.ExecContext(ctx, deleteOrganizationMember, arg.OrganizationID, arg.UserID)
 	return err
@@ -4296,7 +5062,7 @@ func (q *sqlQuerier) InsertOrganizationMember(ctx context.Context, arg InsertOrg
 const organizationMembers = `-- name: OrganizationMembers :many
 SELECT
 	organization_members.user_id, organization_members.organization_id, organization_members.created_at, organization_members.updated_at, organization_members.roles,
-	users.username, users.avatar_url, users.name, users.email, users.rbac_roles as "global_roles"
+	users.username, users.avatar_url, users.name, users.email, users.rbac_roles as "global_roles", users.is_service_account
 FROM
 	organization_members
 		INNER JOIN
@@ -4328,6 +5094,7 @@ type OrganizationMembersRow struct {
 	Name               string             `db:"name" json:"name"`
 	Email              string             `db:"email" json:"email"`
 	GlobalRoles        pq.StringArray     `db:"global_roles" json:"global_roles"`
+	IsServiceAccount   bool               `db:"is_service_account" json:"is_service_account"`
 }
 
 // Arguments are optional with uuid.Nil to ignore.
@@ -4354,6 +5121,7 @@ func (q *sqlQuerier) OrganizationMembers(ctx context.Context, arg OrganizationMe
 			&i.Name,
 			&i.Email,
 			&i.GlobalRoles,
+			&i.IsServiceAccount,
 		); err != nil {
 			return nil, err
 		}
@@ -4718,49 +5486,203 @@ func (q *sqlQuerier) GetParameterSchemasByJobID(ctx context.Context, jobID uuid.
 	return items, nil
 }
 
-const deleteOldProvisionerDaemons = `-- name: DeleteOldProvisionerDaemons :exec
-DELETE FROM provisioner_daemons WHERE (
-	(created_at < (NOW() - INTERVAL '7 days') AND last_seen_at IS NULL) OR
-	(last_seen_at IS NOT NULL AND last_seen_at < (NOW() - INTERVAL '7 days'))
+const claimPrebuiltWorkspace = `-- name: ClaimPrebuiltWorkspace :one
+WITH claimed AS (
+	DELETE FROM
+		prebuilt_workspaces
+	WHERE
+		workspace_id = (
+			SELECT
+				prebuilt_workspaces.workspace_id
+			FROM
+				prebuilt_workspaces
+				JOIN workspaces ON workspaces.id = prebuilt_workspaces.workspace_id
+				JOIN templates ON templates.id = workspaces.template_id
+				JOIN LATERAL (
+					SELECT
+						workspace_builds.template_version_id,
+						workspace_builds.transition,
+						workspace_builds.job_id
+					FROM
+						workspace_builds
+					WHERE
+						workspace_builds.workspace_id = workspaces.id
+					ORDER BY
+						workspace_builds.build_number DESC
+					LIMIT
+						1
+				) latest_build ON true
+				JOIN provisioner_jobs ON provisioner_jobs.id = latest_build.job_id
+			WHERE
+				prebuilt_workspaces.pool_id = $1 :: uuid
+				AND workspaces.organization_id = $2
+				AND workspaces.deleted = false
+				AND workspaces.dormant_at IS NULL
+				AND latest_build.transition = 'start'::workspace_transition
+				AND latest_build.template_version_id = templates.active_version_id
+				AND provisioner_jobs.job_status = 'succeeded'::provisioner_job_status
+			ORDER BY
+				prebuilt_workspaces.created_at
+			LIMIT
+				1
+			FOR UPDATE OF prebuilt_workspaces SKIP LOCKED
+		)
+	RETURNING
+		workspace_id
 )
+UPDATE
+	workspaces
+SET
+	owner_id = $3,
+	name = $4,
+	autostart_schedule = $5,
+	ttl = $6,
+	automatic_updates = $7,
+	last_used_at = $8,
+	updated_at = $8
+FROM
+	claimed
+WHERE
+	workspaces.id = claimed.workspace_id
+RETURNING
+	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.user_acl, workspaces.group_acl
 `
 
-// Delete provisioner daemons that have been created at least a week ago
-// and have not connected to coderd since a week.
-// A provisioner daemon with "zeroed" last_seen_at column indicates possible
-// connectivity issues (no provisioner daemon activity since registration).
-func (q *sqlQuerier) DeleteOldProvisionerDaemons(ctx context.Context) error {
-	_, err := q.db.ExecContext(ctx, deleteOldProvisionerDaemons)
+type ClaimPrebuiltWorkspaceParams struct {
+	PoolID            uuid.UUID        `db:"pool_id" json:"pool_id"`
+	OrganizationID    uuid.UUID        `db:"organization_id" json:"organization_id"`
+	NewOwnerID        uuid.UUID        `db:"new_owner_id" json:"new_owner_id"`
+	NewName           string           `db:"new_name" json:"new_name"`
+	AutostartSchedule sql.NullString   `db:"autostart_schedule" json:"autostart_schedule"`
+	Ttl               sql.NullInt64    `db:"ttl" json:"ttl"`
+	AutomaticUpdates  AutomaticUpdates `db:"automatic_updates" json:"automatic_updates"`
+	Now               time.Time        `db:"now" json:"now"`
+}
+
+// Transfers the oldest workspace of the pool that is running the active
+// version of the template to the new owner, and removes it from the pool.
+// Workspaces that are being claimed concurrently are skipped.
+func (q *sqlQuerier) ClaimPrebuiltWorkspace(ctx context.Context, arg ClaimPrebuiltWorkspaceParams) (Workspace, error) {
+	row := q.db.QueryRowContext(ctx, claimPrebuiltWorkspace,
+		arg.PoolID,
+		arg.OrganizationID,
+		arg.NewOwnerID,
+		arg.NewName,
+		arg.AutostartSchedule,
+		arg.Ttl,
+		arg.AutomaticUpdates,
+		arg.Now,
+	)
+	var i Workspace
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OwnerID,
+		&i.OrganizationID,
+		&i.TemplateID,
+		&i.Deleted,
+		&i.Name,
+		&i.AutostartSchedule,
+		&i.Ttl,
+		&i.LastUsedAt,
+		&i.DormantAt,
+		&i.DeletingAt,
+		&i.AutomaticUpdates,
+		&i.Favorite,
+		&i.UserACL,
+		&i.GroupACL,
+	)
+	return i, err
+}
+
+const deleteTemplatePrebuildPoolByID = `-- name: DeleteTemplatePrebuildPoolByID :exec
+DELETE FROM template_prebuild_pools WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteTemplatePrebuildPoolByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteTemplatePrebuildPoolByID, id)
 	return err
 }
 
-const getProvisionerDaemons = `-- name: GetProvisionerDaemons :many
+const getPrebuiltWorkspaces = `-- name: GetPrebuiltWorkspaces :many
 SELECT
-	id, created_at, name, provisioners, replica_id, tags, last_seen_at, version, api_version, organization_id
+	prebuilt_workspaces.workspace_id,
+	prebuilt_workspaces.pool_id,
+	prebuilt_workspaces.created_at,
+	workspaces.template_id,
+	workspaces.dormant_at,
+	templates.active_version_id,
+	latest_build.template_version_id,
+	latest_build.transition,
+	provisioner_jobs.job_status,
+	provisioner_jobs.completed_at
 FROM
-	provisioner_daemons
-`
-
-func (q *sqlQuerier) GetProvisionerDaemons(ctx context.Context) ([]ProvisionerDaemon, error) {
-	rows, err := q.db.QueryContext(ctx, getProvisionerDaemons)
+	prebuilt_workspaces
+	JOIN workspaces ON workspaces.id = prebuilt_workspaces.workspace_id
+	JOIN templates ON templates.id = workspaces.template_id
+	JOIN LATERAL (
+		SELECT
+			workspace_builds.template_version_id,
+			workspace_builds.transition,
+			workspace_builds.job_id
+		FROM
+			workspace_builds
+		WHERE
+			workspace_builds.workspace_id = workspaces.id
+		ORDER BY
+			workspace_builds.build_number DESC
+		LIMIT
+			1
+	) latest_build ON true
+	JOIN provisioner_jobs ON provisioner_jobs.id = latest_build.job_id
+WHERE
+	workspaces.deleted = false
+	AND CASE
+		WHEN $1 :: uuid != '00000000-0000-0000-0000-000000000000'::uuid THEN
+			workspaces.template_id = $1
+		ELSE true
+	END
+ORDER BY
+	prebuilt_workspaces.created_at
+`
+
+type GetPrebuiltWorkspacesRow struct {
+	WorkspaceID       uuid.UUID            `db:"workspace_id" json:"workspace_id"`
+	PoolID            uuid.NullUUID        `db:"pool_id" json:"pool_id"`
+	CreatedAt         time.Time            `db:"created_at" json:"created_at"`
+	TemplateID        uuid.UUID            `db:"template_id" json:"template_id"`
+	DormantAt         sql.NullTime         `db:"dormant_at" json:"dormant_at"`
+	ActiveVersionID   uuid.UUID            `db:"active_version_id" json:"active_version_id"`
+	TemplateVersionID uuid.UUID            `db:"template_version_id" json:"template_version_id"`
+	Transition        WorkspaceTransition  `db:"transition" json:"transition"`
+	JobStatus         ProvisionerJobStatus `db:"job_status" json:"job_status"`
+	CompletedAt       sql.NullTime         `db:"completed_at" json:"completed_at"`
+}
+
+// Returns the prebuilt workspaces that are not deleted, along with the state
+// of their latest build. A nil template_id returns the prebuilt workspaces of
+// all templates.
+func (q *sqlQuerier) GetPrebuiltWorkspaces(ctx context.Context, templateID uuid.UUID) ([]GetPrebuiltWorkspacesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPrebuiltWorkspaces, templateID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ProvisionerDaemon
+	var items []GetPrebuiltWorkspacesRow
 	for rows.Next() {
-		var i ProvisionerDaemon
+		var i GetPrebuiltWorkspacesRow
 		if err := rows.Scan(
-			&i.ID,
+			&i.WorkspaceID,
+			&i.PoolID,
 			&i.CreatedAt,
-			&i.Name,
-			pq.Array(&i.Provisioners),
-			&i.ReplicaID,
-			&i.Tags,
-			&i.LastSeenAt,
-			&i.Version,
-			&i.APIVersion,
-			&i.OrganizationID,
+			&i.TemplateID,
+			&i.DormantAt,
+			&i.ActiveVersionID,
+			&i.TemplateVersionID,
+			&i.Transition,
+			&i.JobStatus,
+			&i.CompletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -4775,35 +5697,66 @@ func (q *sqlQuerier) GetProvisionerDaemons(ctx context.Context) ([]ProvisionerDa
 	return items, nil
 }
 
-const getProvisionerDaemonsByOrganization = `-- name: GetProvisionerDaemonsByOrganization :many
+const getTemplatePrebuildPoolByID = `-- name: GetTemplatePrebuildPoolByID :one
 SELECT
-	id, created_at, name, provisioners, replica_id, tags, last_seen_at, version, api_version, organization_id
+	id, template_id, name, desired_instances, parameters, created_at, updated_at
 FROM
-	provisioner_daemons
+	template_prebuild_pools
 WHERE
-	organization_id = $1
+	id = $1
 `
 
-func (q *sqlQuerier) GetProvisionerDaemonsByOrganization(ctx context.Context, organizationID uuid.UUID) ([]ProvisionerDaemon, error) {
-	rows, err := q.db.QueryContext(ctx, getProvisionerDaemonsByOrganization, organizationID)
+func (q *sqlQuerier) GetTemplatePrebuildPoolByID(ctx context.Context, id uuid.UUID) (TemplatePrebuildPool, error) {
+	row := q.db.QueryRowContext(ctx, getTemplatePrebuildPoolByID, id)
+	var i TemplatePrebuildPool
+	err := row.Scan(
+		&i.ID,
+		&i.TemplateID,
+		&i.Name,
+		&i.DesiredInstances,
+		&i.Parameters,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTemplatePrebuildPools = `-- name: GetTemplatePrebuildPools :many
+SELECT
+	template_prebuild_pools.id, template_prebuild_pools.template_id, template_prebuild_pools.name, template_prebuild_pools.desired_instances, template_prebuild_pools.parameters, template_prebuild_pools.created_at, template_prebuild_pools.updated_at
+FROM
+	template_prebuild_pools
+	JOIN templates ON templates.id = template_prebuild_pools.template_id
+WHERE
+	templates.deleted = false
+	AND CASE
+		WHEN $1 :: uuid != '00000000-0000-0000-0000-000000000000'::uuid THEN
+			template_prebuild_pools.template_id = $1
+		ELSE true
+	END
+ORDER BY
+	template_prebuild_pools.template_id, template_prebuild_pools.name
+`
+
+// Returns the prebuild pools of templates that are not deleted. A nil
+// template_id returns the pools of all templates.
+func (q *sqlQuerier) GetTemplatePrebuildPools(ctx context.Context, templateID uuid.UUID) ([]TemplatePrebuildPool, error) {
+	rows, err := q.db.QueryContext(ctx, getTemplatePrebuildPools, templateID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ProvisionerDaemon
+	var items []TemplatePrebuildPool
 	for rows.Next() {
-		var i ProvisionerDaemon
+		var i TemplatePrebuildPool
 		if err := rows.Scan(
 			&i.ID,
-			&i.CreatedAt,
+			&i.TemplateID,
 			&i.Name,
-			pq.Array(&i.Provisioners),
-			&i.ReplicaID,
-			&i.Tags,
-			&i.LastSeenAt,
-			&i.Version,
-			&i.APIVersion,
-			&i.OrganizationID,
+			&i.DesiredInstances,
+			&i.Parameters,
+			&i.CreatedAt,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -4818,44 +5771,246 @@ func (q *sqlQuerier) GetProvisionerDaemonsByOrganization(ctx context.Context, or
 	return items, nil
 }
 
-const updateProvisionerDaemonLastSeenAt = `-- name: UpdateProvisionerDaemonLastSeenAt :exec
-UPDATE provisioner_daemons
-SET
-	last_seen_at = $1
-WHERE
-	id = $2
-AND
-	last_seen_at <= $1
+const insertPrebuiltWorkspace = `-- name: InsertPrebuiltWorkspace :exec
+INSERT INTO
+	prebuilt_workspaces (
+		workspace_id,
+		pool_id,
+		created_at
+	)
+VALUES
+	($1, $2, $3)
 `
 
-type UpdateProvisionerDaemonLastSeenAtParams struct {
-	LastSeenAt sql.NullTime `db:"last_seen_at" json:"last_seen_at"`
-	ID         uuid.UUID    `db:"id" json:"id"`
+type InsertPrebuiltWorkspaceParams struct {
+	WorkspaceID uuid.UUID     `db:"workspace_id" json:"workspace_id"`
+	PoolID      uuid.NullUUID `db:"pool_id" json:"pool_id"`
+	CreatedAt   time.Time     `db:"created_at" json:"created_at"`
 }
 
-func (q *sqlQuerier) UpdateProvisionerDaemonLastSeenAt(ctx context.Context, arg UpdateProvisionerDaemonLastSeenAtParams) error {
-	_, err := q.db.ExecContext(ctx, updateProvisionerDaemonLastSeenAt, arg.LastSeenAt, arg.ID)
+func (q *sqlQuerier) InsertPrebuiltWorkspace(ctx context.Context, arg InsertPrebuiltWorkspaceParams) error {
+	_, err := q.db.ExecContext(ctx, insertPrebuiltWorkspace, arg.WorkspaceID, arg.PoolID, arg.CreatedAt)
 	return err
 }
 
-const upsertProvisionerDaemon = `-- name: UpsertProvisionerDaemon :one
+const insertTemplatePrebuildPool = `-- name: InsertTemplatePrebuildPool :one
 INSERT INTO
-	provisioner_daemons (
+	template_prebuild_pools (
 		id,
+		template_id,
+		name,
+		desired_instances,
+		parameters,
 		created_at,
-		"name",
-		provisioners,
-		tags,
-		last_seen_at,
-		"version",
-		organization_id,
-		api_version
+		updated_at
 	)
-VALUES (
-	gen_random_uuid(),
-	$1,
-	$2,
-	$3,
+VALUES
+	($1, $2, $3, $4, $5, $6, $7) RETURNING id, template_id, name, desired_instances, parameters, created_at, updated_at
+`
+
+type InsertTemplatePrebuildPoolParams struct {
+	ID               uuid.UUID `db:"id" json:"id"`
+	TemplateID       uuid.UUID `db:"template_id" json:"template_id"`
+	Name             string    `db:"name" json:"name"`
+	DesiredInstances int32     `db:"desired_instances" json:"desired_instances"`
+	Parameters       StringMap `db:"parameters" json:"parameters"`
+	CreatedAt        time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) InsertTemplatePrebuildPool(ctx context.Context, arg InsertTemplatePrebuildPoolParams) (TemplatePrebuildPool, error) {
+	row := q.db.QueryRowContext(ctx, insertTemplatePrebuildPool,
+		arg.ID,
+		arg.TemplateID,
+		arg.Name,
+		arg.DesiredInstances,
+		arg.Parameters,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i TemplatePrebuildPool
+	err := row.Scan(
+		&i.ID,
+		&i.TemplateID,
+		&i.Name,
+		&i.DesiredInstances,
+		&i.Parameters,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateTemplatePrebuildPoolByID = `-- name: UpdateTemplatePrebuildPoolByID :one
+UPDATE
+	template_prebuild_pools
+SET
+	desired_instances = $2,
+	updated_at = $3
+WHERE
+	id = $1
+RETURNING id, template_id, name, desired_instances, parameters, created_at, updated_at
+`
+
+type UpdateTemplatePrebuildPoolByIDParams struct {
+	ID               uuid.UUID `db:"id" json:"id"`
+	DesiredInstances int32     `db:"desired_instances" json:"desired_instances"`
+	UpdatedAt        time.Time `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) UpdateTemplatePrebuildPoolByID(ctx context.Context, arg UpdateTemplatePrebuildPoolByIDParams) (TemplatePrebuildPool, error) {
+	row := q.db.QueryRowContext(ctx, updateTemplatePrebuildPoolByID, arg.ID, arg.DesiredInstances, arg.UpdatedAt)
+	var i TemplatePrebuildPool
+	err := row.Scan(
+		&i.ID,
+		&i.TemplateID,
+		&i.Name,
+		&i.DesiredInstances,
+		&i.Parameters,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteOldProvisionerDaemons = `-- name: DeleteOldProvisionerDaemons :exec
+DELETE FROM provisioner_daemons WHERE (
+	(created_at < (NOW() - INTERVAL '7 days') AND last_seen_at IS NULL) OR
+	(last_seen_at IS NOT NULL AND last_seen_at < (NOW() - INTERVAL '7 days'))
+)
+`
+
+// Delete provisioner daemons that have been created at least a week ago
+// and have not connected to coderd since a week.
+// A provisioner daemon with "zeroed" last_seen_at column indicates possible
+// connectivity issues (no provisioner daemon activity since registration).
+func (q *sqlQuerier) DeleteOldProvisionerDaemons(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteOldProvisionerDaemons)
+	return err
+}
+
+const getProvisionerDaemons = `-- name: GetProvisionerDaemons :many
+SELECT
+	id, created_at, name, provisioners, replica_id, tags, last_seen_at, version, api_version, organization_id
+FROM
+	provisioner_daemons
+`
+
+func (q *sqlQuerier) GetProvisionerDaemons(ctx context.Context) ([]ProvisionerDaemon, error) {
+	rows, err := q.db.QueryContext(ctx, getProvisionerDaemons)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProvisionerDaemon
+	for rows.Next() {
+		var i ProvisionerDaemon
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.Name,
+			pq.Array(&i.Provisioners),
+			&i.ReplicaID,
+			&i.Tags,
+			&i.LastSeenAt,
+			&i.Version,
+			&i.APIVersion,
+			&i.OrganizationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProvisionerDaemonsByOrganization = `-- name: GetProvisionerDaemonsByOrganization :many
+SELECT
+	id, created_at, name, provisioners, replica_id, tags, last_seen_at, version, api_version, organization_id
+FROM
+	provisioner_daemons
+WHERE
+	organization_id = $1
+`
+
+func (q *sqlQuerier) GetProvisionerDaemonsByOrganization(ctx context.Context, organizationID uuid.UUID) ([]ProvisionerDaemon, error) {
+	rows, err := q.db.QueryContext(ctx, getProvisionerDaemonsByOrganization, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProvisionerDaemon
+	for rows.Next() {
+		var i ProvisionerDaemon
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.Name,
+			pq.Array(&i.Provisioners),
+			&i.ReplicaID,
+			&i.Tags,
+			&i.LastSeenAt,
+			&i.Version,
+			&i.APIVersion,
+			&i.OrganizationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateProvisionerDaemonLastSeenAt = `-- name: UpdateProvisionerDaemonLastSeenAt :exec
+UPDATE provisioner_daemons
+SET
+	last_seen_at = $1
+WHERE
+	id = $2
+AND
+	last_seen_at <= $1
+`
+
+type UpdateProvisionerDaemonLastSeenAtParams struct {
+	LastSeenAt sql.NullTime `db:"last_seen_at" json:"last_seen_at"`
+	ID         uuid.UUID    `db:"id" json:"id"`
+}
+
+func (q *sqlQuerier) UpdateProvisionerDaemonLastSeenAt(ctx context.Context, arg UpdateProvisionerDaemonLastSeenAtParams) error {
+	_, err := q.db.ExecContext(ctx, updateProvisionerDaemonLastSeenAt, arg.LastSeenAt, arg.ID)
+	return err
+}
+
+const upsertProvisionerDaemon = `-- name: UpsertProvisionerDaemon :one
+INSERT INTO
+	provisioner_daemons (
+		id,
+		created_at,
+		"name",
+		provisioners,
+		tags,
+		last_seen_at,
+		"version",
+		organization_id,
+		api_version
+	)
+VALUES (
+	gen_random_uuid(),
+	$1,
+	$2,
+	$3,
 	$4,
 	$5,
 	$6,
@@ -8151,82 +9306,196 @@ func (q *sqlQuerier) InsertTemplateVersionParameter(ctx context.Context, arg Ins
 	return i, err
 }
 
-const archiveUnusedTemplateVersions = `-- name: ArchiveUnusedTemplateVersions :many
-UPDATE
-	template_versions
-SET
-	archived = true,
-	updated_at = $1
-FROM
-	-- Archive all versions that are returned from this query.
-	(
-		SELECT
-			scoped_template_versions.id
-		FROM
-			-- Scope an archive to a single template and ignore already archived template versions
-			(
-				SELECT
-					id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, external_auth_providers, message, archived
-				FROM
-					template_versions
-				WHERE
-					template_versions.template_id = $2 :: uuid
-					AND
-					archived = false
-					AND
-					-- This allows archiving a specific template version.
-					CASE
-						WHEN $3::uuid  != '00000000-0000-0000-0000-000000000000'::uuid THEN
-							template_versions.id = $3 :: uuid
-						ELSE
-							true
-						END
-			) AS scoped_template_versions
-			LEFT JOIN
-				provisioner_jobs ON scoped_template_versions.job_id = provisioner_jobs.id
-			LEFT JOIN
-				templates ON scoped_template_versions.template_id = templates.id
-		WHERE
-		  -- Actively used template versions (meaning the latest build is using
-		  -- the version) are never archived. A "restart" command on the workspace,
-		  -- even if failed, would use the version. So it cannot be archived until
-		  -- the build is outdated.
-		NOT EXISTS (
-			-- Return all "used" versions, where "used" is defined as being
-			-- used by a latest workspace build.
-			SELECT template_version_id FROM (
-				SELECT
-					DISTINCT ON (workspace_id) template_version_id, transition
-				FROM
-					workspace_builds
-				ORDER BY workspace_id, build_number DESC
-				) AS used_versions
-			WHERE
-				used_versions.transition != 'delete'
-				AND
-				scoped_template_versions.id = used_versions.template_version_id
-		)
-		-- Also never archive the active template version
-		AND active_version_id != scoped_template_versions.id
-		AND CASE
-			-- Optionally, only archive versions that match a given
-			-- job status like 'failed'.
-			WHEN $4 :: provisioner_job_status IS NOT NULL THEN
-				provisioner_jobs.job_status = $4 :: provisioner_job_status
-			ELSE
-				true
-		END
-		-- Pending or running jobs should not be archived, as they are "in progress"
-		AND provisioner_jobs.job_status != 'running'
-		AND provisioner_jobs.job_status != 'pending'
-	) AS archived_versions
+const deleteTemplateVersionPresetsByTemplateVersionID = `-- name: DeleteTemplateVersionPresetsByTemplateVersionID :exec
+DELETE FROM
+	template_version_presets
 WHERE
-	template_versions.id IN (archived_versions.id)
-RETURNING template_versions.id
+	template_version_id = $1
 `
 
-type ArchiveUnusedTemplateVersionsParams struct {
-	UpdatedAt         time.Time                `db:"updated_at" json:"updated_at"`
+func (q *sqlQuerier) DeleteTemplateVersionPresetsByTemplateVersionID(ctx context.Context, templateVersionID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteTemplateVersionPresetsByTemplateVersionID, templateVersionID)
+	return err
+}
+
+const getTemplateVersionPresetByID = `-- name: GetTemplateVersionPresetByID :one
+SELECT
+	id, template_version_id, name, parameters, created_at
+FROM
+	template_version_presets
+WHERE
+	id = $1
+`
+
+func (q *sqlQuerier) GetTemplateVersionPresetByID(ctx context.Context, id uuid.UUID) (TemplateVersionPreset, error) {
+	row := q.db.QueryRowContext(ctx, getTemplateVersionPresetByID, id)
+	var i TemplateVersionPreset
+	err := row.Scan(
+		&i.ID,
+		&i.TemplateVersionID,
+		&i.Name,
+		&i.Parameters,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTemplateVersionPresetsByTemplateVersionIDs = `-- name: GetTemplateVersionPresetsByTemplateVersionIDs :many
+SELECT
+	id, template_version_id, name, parameters, created_at
+FROM
+	template_version_presets
+WHERE
+	template_version_id = ANY($1 :: uuid [ ])
+ORDER BY
+	template_version_id, name
+`
+
+func (q *sqlQuerier) GetTemplateVersionPresetsByTemplateVersionIDs(ctx context.Context, ids []uuid.UUID) ([]TemplateVersionPreset, error) {
+	rows, err := q.db.QueryContext(ctx, getTemplateVersionPresetsByTemplateVersionIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TemplateVersionPreset
+	for rows.Next() {
+		var i TemplateVersionPreset
+		if err := rows.Scan(
+			&i.ID,
+			&i.TemplateVersionID,
+			&i.Name,
+			&i.Parameters,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertTemplateVersionPreset = `-- name: InsertTemplateVersionPreset :one
+INSERT INTO
+	template_version_presets (
+		id,
+		template_version_id,
+		name,
+		parameters,
+		created_at
+	)
+VALUES
+	($1, $2, $3, $4, $5) RETURNING id, template_version_id, name, parameters, created_at
+`
+
+type InsertTemplateVersionPresetParams struct {
+	ID                uuid.UUID `db:"id" json:"id"`
+	TemplateVersionID uuid.UUID `db:"template_version_id" json:"template_version_id"`
+	Name              string    `db:"name" json:"name"`
+	Parameters        StringMap `db:"parameters" json:"parameters"`
+	CreatedAt         time.Time `db:"created_at" json:"created_at"`
+}
+
+func (q *sqlQuerier) InsertTemplateVersionPreset(ctx context.Context, arg InsertTemplateVersionPresetParams) (TemplateVersionPreset, error) {
+	row := q.db.QueryRowContext(ctx, insertTemplateVersionPreset,
+		arg.ID,
+		arg.TemplateVersionID,
+		arg.Name,
+		arg.Parameters,
+		arg.CreatedAt,
+	)
+	var i TemplateVersionPreset
+	err := row.Scan(
+		&i.ID,
+		&i.TemplateVersionID,
+		&i.Name,
+		&i.Parameters,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const archiveUnusedTemplateVersions = `-- name: ArchiveUnusedTemplateVersions :many
+UPDATE
+	template_versions
+SET
+	archived = true,
+	updated_at = $1
+FROM
+	-- Archive all versions that are returned from this query.
+	(
+		SELECT
+			scoped_template_versions.id
+		FROM
+			-- Scope an archive to a single template and ignore already archived template versions
+			(
+				SELECT
+					id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, external_auth_providers, message, archived
+				FROM
+					template_versions
+				WHERE
+					template_versions.template_id = $2 :: uuid
+					AND
+					archived = false
+					AND
+					-- This allows archiving a specific template version.
+					CASE
+						WHEN $3::uuid  != '00000000-0000-0000-0000-000000000000'::uuid THEN
+							template_versions.id = $3 :: uuid
+						ELSE
+							true
+						END
+			) AS scoped_template_versions
+			LEFT JOIN
+				provisioner_jobs ON scoped_template_versions.job_id = provisioner_jobs.id
+			LEFT JOIN
+				templates ON scoped_template_versions.template_id = templates.id
+		WHERE
+		  -- Actively used template versions (meaning the latest build is using
+		  -- the version) are never archived. A "restart" command on the workspace,
+		  -- even if failed, would use the version. So it cannot be archived until
+		  -- the build is outdated.
+		NOT EXISTS (
+			-- Return all "used" versions, where "used" is defined as being
+			-- used by a latest workspace build.
+			SELECT template_version_id FROM (
+				SELECT
+					DISTINCT ON (workspace_id) template_version_id, transition
+				FROM
+					workspace_builds
+				ORDER BY workspace_id, build_number DESC
+				) AS used_versions
+			WHERE
+				used_versions.transition != 'delete'
+				AND
+				scoped_template_versions.id = used_versions.template_version_id
+		)
+		-- Also never archive the active template version
+		AND active_version_id != scoped_template_versions.id
+		AND CASE
+			-- Optionally, only archive versions that match a given
+			-- job status like 'failed'.
+			WHEN $4 :: provisioner_job_status IS NOT NULL THEN
+				provisioner_jobs.job_status = $4 :: provisioner_job_status
+			ELSE
+				true
+		END
+		-- Pending or running jobs should not be archived, as they are "in progress"
+		AND provisioner_jobs.job_status != 'running'
+		AND provisioner_jobs.job_status != 'pending'
+	) AS archived_versions
+WHERE
+	template_versions.id IN (archived_versions.id)
+RETURNING template_versions.id
+`
+
+type ArchiveUnusedTemplateVersionsParams struct {
+	UpdatedAt         time.Time                `db:"updated_at" json:"updated_at"`
 	TemplateID        uuid.UUID                `db:"template_id" json:"template_id"`
 	TemplateVersionID uuid.UUID                `db:"template_version_id" json:"template_version_id"`
 	JobStatus         NullProvisionerJobStatus `db:"job_status" json:"job_status"`
@@ -8885,6 +10154,174 @@ func (q *sqlQuerier) InsertTemplateVersionWorkspaceTag(ctx context.Context, arg
 	return i, err
 }
 
+const deleteTemporaryRoleGrantByID = `-- name: DeleteTemporaryRoleGrantByID :exec
+DELETE FROM temporary_role_grants WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteTemporaryRoleGrantByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteTemporaryRoleGrantByID, id)
+	return err
+}
+
+const deleteTemporaryRoleGrants = `-- name: DeleteTemporaryRoleGrants :exec
+DELETE FROM
+	temporary_role_grants
+WHERE
+	user_id = $1
+	AND organization_id IS NOT DISTINCT FROM $2 :: uuid
+	AND role_name = ANY($3 :: text[])
+`
+
+type DeleteTemporaryRoleGrantsParams struct {
+	UserID         uuid.UUID     `db:"user_id" json:"user_id"`
+	OrganizationID uuid.NullUUID `db:"organization_id" json:"organization_id"`
+	RoleNames      []string      `db:"role_names" json:"role_names"`
+}
+
+// Deletes the grants of the given roles of a user, for example because the
+// roles were removed. A NULL organization_id selects site wide roles.
+func (q *sqlQuerier) DeleteTemporaryRoleGrants(ctx context.Context, arg DeleteTemporaryRoleGrantsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteTemporaryRoleGrants, arg.UserID, arg.OrganizationID, pq.Array(arg.RoleNames))
+	return err
+}
+
+const getExpiredTemporaryRoleGrants = `-- name: GetExpiredTemporaryRoleGrants :many
+SELECT
+	id, user_id, organization_id, role_name, justification, created_at, expires_at
+FROM
+	temporary_role_grants
+WHERE
+	expires_at <= $1 :: timestamptz
+ORDER BY
+	expires_at
+`
+
+func (q *sqlQuerier) GetExpiredTemporaryRoleGrants(ctx context.Context, now time.Time) ([]TemporaryRoleGrant, error) {
+	rows, err := q.db.QueryContext(ctx, getExpiredTemporaryRoleGrants, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TemporaryRoleGrant
+	for rows.Next() {
+		var i TemporaryRoleGrant
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.OrganizationID,
+			&i.RoleName,
+			&i.Justification,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTemporaryRoleGrantsByUserID = `-- name: GetTemporaryRoleGrantsByUserID :many
+SELECT
+	id, user_id, organization_id, role_name, justification, created_at, expires_at
+FROM
+	temporary_role_grants
+WHERE
+	user_id = $1
+ORDER BY
+	expires_at, role_name
+`
+
+func (q *sqlQuerier) GetTemporaryRoleGrantsByUserID(ctx context.Context, userID uuid.UUID) ([]TemporaryRoleGrant, error) {
+	rows, err := q.db.QueryContext(ctx, getTemporaryRoleGrantsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TemporaryRoleGrant
+	for rows.Next() {
+		var i TemporaryRoleGrant
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.OrganizationID,
+			&i.RoleName,
+			&i.Justification,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertTemporaryRoleGrant = `-- name: UpsertTemporaryRoleGrant :one
+INSERT INTO
+	temporary_role_grants (
+		id,
+		user_id,
+		organization_id,
+		role_name,
+		justification,
+		created_at,
+		expires_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (user_id, COALESCE(organization_id, '00000000-0000-0000-0000-000000000000'::uuid), role_name) DO UPDATE SET
+	justification = $5,
+	created_at = $6,
+	expires_at = $7
+RETURNING id, user_id, organization_id, role_name, justification, created_at, expires_at
+`
+
+type UpsertTemporaryRoleGrantParams struct {
+	ID             uuid.UUID     `db:"id" json:"id"`
+	UserID         uuid.UUID     `db:"user_id" json:"user_id"`
+	OrganizationID uuid.NullUUID `db:"organization_id" json:"organization_id"`
+	RoleName       string        `db:"role_name" json:"role_name"`
+	Justification  string        `db:"justification" json:"justification"`
+	CreatedAt      time.Time     `db:"created_at" json:"created_at"`
+	ExpiresAt      time.Time     `db:"expires_at" json:"expires_at"`
+}
+
+func (q *sqlQuerier) UpsertTemporaryRoleGrant(ctx context.Context, arg UpsertTemporaryRoleGrantParams) (TemporaryRoleGrant, error) {
+	row := q.db.QueryRowContext(ctx, upsertTemporaryRoleGrant,
+		arg.ID,
+		arg.UserID,
+		arg.OrganizationID,
+		arg.RoleName,
+		arg.Justification,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+	)
+	var i TemporaryRoleGrant
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.OrganizationID,
+		&i.RoleName,
+		&i.Justification,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
 const getUserLinkByLinkedID = `-- name: GetUserLinkByLinkedID :one
 SELECT
 	user_links.user_id, user_links.login_type, user_links.linked_id, user_links.oauth_access_token, user_links.oauth_refresh_token, user_links.oauth_expiry, user_links.oauth_access_token_key_id, user_links.oauth_refresh_token_key_id, user_links.debug_context
@@ -9122,6 +10559,298 @@ func (q *sqlQuerier) UpdateUserLinkedID(ctx context.Context, arg UpdateUserLinke
 	return i, err
 }
 
+const deleteUserLoginAttempts = `-- name: DeleteUserLoginAttempts :exec
+DELETE FROM user_login_attempts WHERE user_id = $1
+`
+
+func (q *sqlQuerier) DeleteUserLoginAttempts(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteUserLoginAttempts, userID)
+	return err
+}
+
+const getUserLoginAttempts = `-- name: GetUserLoginAttempts :one
+SELECT user_id, failed_attempts, last_failed_at FROM user_login_attempts WHERE user_id = $1
+`
+
+func (q *sqlQuerier) GetUserLoginAttempts(ctx context.Context, userID uuid.UUID) (UserLoginAttempt, error) {
+	row := q.db.QueryRowContext(ctx, getUserLoginAttempts, userID)
+	var i UserLoginAttempt
+	err := row.Scan(&i.UserID, &i.FailedAttempts, &i.LastFailedAt)
+	return i, err
+}
+
+const upsertUserFailedLoginAttempts = `-- name: UpsertUserFailedLoginAttempts :one
+INSERT INTO
+	user_login_attempts (user_id, failed_attempts, last_failed_at)
+VALUES
+	($1, 1, $2)
+ON CONFLICT (user_id) DO UPDATE SET
+	failed_attempts = user_login_attempts.failed_attempts + 1,
+	last_failed_at = $2
+RETURNING failed_attempts
+`
+
+type UpsertUserFailedLoginAttemptsParams struct {
+	UserID       uuid.UUID `db:"user_id" json:"user_id"`
+	LastFailedAt time.Time `db:"last_failed_at" json:"last_failed_at"`
+}
+
+// Records a failed login and returns the number of consecutive failed logins
+// of the user.
+func (q *sqlQuerier) UpsertUserFailedLoginAttempts(ctx context.Context, arg UpsertUserFailedLoginAttemptsParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, upsertUserFailedLoginAttempts, arg.UserID, arg.LastFailedAt)
+	var failed_attempts int32
+	err := row.Scan(&failed_attempts)
+	return failed_attempts, err
+}
+
+const deleteOldUserPasswordHistory = `-- name: DeleteOldUserPasswordHistory :exec
+DELETE FROM
+	user_password_history
+WHERE
+	user_id = $1
+	AND id NOT IN (
+		SELECT id FROM user_password_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 :: int
+	)
+`
+
+type DeleteOldUserPasswordHistoryParams struct {
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+	Keep   int32     `db:"keep" json:"keep"`
+}
+
+// Keeps only the newest entries of the password history of a user.
+func (q *sqlQuerier) DeleteOldUserPasswordHistory(ctx context.Context, arg DeleteOldUserPasswordHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOldUserPasswordHistory, arg.UserID, arg.Keep)
+	return err
+}
+
+const getUserPasswordHistory = `-- name: GetUserPasswordHistory :many
+SELECT
+	id, user_id, hashed_password, created_at
+FROM
+	user_password_history
+WHERE
+	user_id = $1
+ORDER BY
+	created_at DESC
+LIMIT
+	NULLIF($2 :: int, 0)
+`
+
+type GetUserPasswordHistoryParams struct {
+	UserID   uuid.UUID `db:"user_id" json:"user_id"`
+	LimitOpt int32     `db:"limit_opt" json:"limit_opt"`
+}
+
+// Returns the newest entries of the password history of a user, starting with
+// the current password.
+func (q *sqlQuerier) GetUserPasswordHistory(ctx context.Context, arg GetUserPasswordHistoryParams) ([]UserPasswordHistory, error) {
+	rows, err := q.db.QueryContext(ctx, getUserPasswordHistory, arg.UserID, arg.LimitOpt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UserPasswordHistory
+	for rows.Next() {
+		var i UserPasswordHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.HashedPassword,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertUserPasswordHistory = `-- name: InsertUserPasswordHistory :exec
+INSERT INTO
+	user_password_history (id, user_id, hashed_password, created_at)
+VALUES
+	($1, $2, $3, $4)
+`
+
+type InsertUserPasswordHistoryParams struct {
+	ID             uuid.UUID `db:"id" json:"id"`
+	UserID         uuid.UUID `db:"user_id" json:"user_id"`
+	HashedPassword []byte    `db:"hashed_password" json:"hashed_password"`
+	CreatedAt      time.Time `db:"created_at" json:"created_at"`
+}
+
+func (q *sqlQuerier) InsertUserPasswordHistory(ctx context.Context, arg InsertUserPasswordHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, insertUserPasswordHistory,
+		arg.ID,
+		arg.UserID,
+		arg.HashedPassword,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const deleteUserTOTPRecoveryCode = `-- name: DeleteUserTOTPRecoveryCode :execrows
+UPDATE
+	user_totp_secrets
+SET
+	hashed_recovery_codes = array_remove(hashed_recovery_codes, $1 :: text)
+WHERE
+	user_id = $2
+	AND $1 :: text = ANY(hashed_recovery_codes)
+`
+
+type DeleteUserTOTPRecoveryCodeParams struct {
+	HashedRecoveryCode string    `db:"hashed_recovery_code" json:"hashed_recovery_code"`
+	UserID             uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+// Removes a recovery code so that it cannot be used again. No rows are
+// affected if the code is not valid for the user.
+func (q *sqlQuerier) DeleteUserTOTPRecoveryCode(ctx context.Context, arg DeleteUserTOTPRecoveryCodeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteUserTOTPRecoveryCode, arg.HashedRecoveryCode, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteUserTOTPSecret = `-- name: DeleteUserTOTPSecret :exec
+DELETE FROM user_totp_secrets WHERE user_id = $1
+`
+
+func (q *sqlQuerier) DeleteUserTOTPSecret(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteUserTOTPSecret, userID)
+	return err
+}
+
+const getUserTOTPSecret = `-- name: GetUserTOTPSecret :one
+SELECT user_id, secret, hashed_recovery_codes, last_used_counter, created_at, confirmed_at FROM user_totp_secrets WHERE user_id = $1
+`
+
+func (q *sqlQuerier) GetUserTOTPSecret(ctx context.Context, userID uuid.UUID) (UserTOTPSecret, error) {
+	row := q.db.QueryRowContext(ctx, getUserTOTPSecret, userID)
+	var i UserTOTPSecret
+	err := row.Scan(
+		&i.UserID,
+		&i.Secret,
+		pq.Array(&i.HashedRecoveryCodes),
+		&i.LastUsedCounter,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+	)
+	return i, err
+}
+
+const updateUserTOTPSecretConfirmed = `-- name: UpdateUserTOTPSecretConfirmed :one
+UPDATE
+	user_totp_secrets
+SET
+	confirmed_at = $1,
+	hashed_recovery_codes = $2 :: text[],
+	last_used_counter = $3
+WHERE
+	user_id = $4
+RETURNING user_id, secret, hashed_recovery_codes, last_used_counter, created_at, confirmed_at
+`
+
+type UpdateUserTOTPSecretConfirmedParams struct {
+	ConfirmedAt         sql.NullTime `db:"confirmed_at" json:"confirmed_at"`
+	HashedRecoveryCodes []string     `db:"hashed_recovery_codes" json:"hashed_recovery_codes"`
+	LastUsedCounter     int64        `db:"last_used_counter" json:"last_used_counter"`
+	UserID              uuid.UUID    `db:"user_id" json:"user_id"`
+}
+
+func (q *sqlQuerier) UpdateUserTOTPSecretConfirmed(ctx context.Context, arg UpdateUserTOTPSecretConfirmedParams) (UserTOTPSecret, error) {
+	row := q.db.QueryRowContext(ctx, updateUserTOTPSecretConfirmed,
+		arg.ConfirmedAt,
+		pq.Array(arg.HashedRecoveryCodes),
+		arg.LastUsedCounter,
+		arg.UserID,
+	)
+	var i UserTOTPSecret
+	err := row.Scan(
+		&i.UserID,
+		&i.Secret,
+		pq.Array(&i.HashedRecoveryCodes),
+		&i.LastUsedCounter,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+	)
+	return i, err
+}
+
+const updateUserTOTPSecretLastUsedCounter = `-- name: UpdateUserTOTPSecretLastUsedCounter :execrows
+UPDATE
+	user_totp_secrets
+SET
+	last_used_counter = $1
+WHERE
+	user_id = $2
+	AND last_used_counter < $1
+`
+
+type UpdateUserTOTPSecretLastUsedCounterParams struct {
+	LastUsedCounter int64     `db:"last_used_counter" json:"last_used_counter"`
+	UserID          uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+// Records the time step of an accepted code. No rows are affected if a code
+// for the same or a later time step was already used.
+func (q *sqlQuerier) UpdateUserTOTPSecretLastUsedCounter(ctx context.Context, arg UpdateUserTOTPSecretLastUsedCounterParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUserTOTPSecretLastUsedCounter, arg.LastUsedCounter, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertUserTOTPSecret = `-- name: UpsertUserTOTPSecret :one
+INSERT INTO
+	user_totp_secrets (user_id, secret, created_at)
+VALUES
+	($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE SET
+	secret = $2,
+	hashed_recovery_codes = '{}',
+	last_used_counter = 0,
+	created_at = $3,
+	confirmed_at = NULL
+RETURNING user_id, secret, hashed_recovery_codes, last_used_counter, created_at, confirmed_at
+`
+
+type UpsertUserTOTPSecretParams struct {
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	Secret    string    `db:"secret" json:"secret"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// Starts a new enrollment. An existing secret is replaced and has to be
+// confirmed again.
+func (q *sqlQuerier) UpsertUserTOTPSecret(ctx context.Context, arg UpsertUserTOTPSecretParams) (UserTOTPSecret, error) {
+	row := q.db.QueryRowContext(ctx, upsertUserTOTPSecret, arg.UserID, arg.Secret, arg.CreatedAt)
+	var i UserTOTPSecret
+	err := row.Scan(
+		&i.UserID,
+		&i.Secret,
+		pq.Array(&i.HashedRecoveryCodes),
+		&i.LastUsedCounter,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+	)
+	return i, err
+}
+
 const allUserIDs = `-- name: AllUserIDs :many
 SELECT DISTINCT id FROM USERS
 `
@@ -9157,6 +10886,8 @@ FROM
 	users
 WHERE
 	status = 'active'::user_status AND deleted = false
+	-- Service accounts do not count towards seats.
+	AND is_service_account = false
 `
 
 func (q *sqlQuerier) GetActiveUserCount(ctx context.Context) (int64, error) {
@@ -9175,7 +10906,29 @@ SELECT
 	-- All user roles, including their org roles.
 	array_cat(
 		-- All users are members
-		array_append(users.rbac_roles, 'member'),
+		array_append(
+			ARRAY(
+				SELECT
+					site_roles
+				FROM
+					unnest(users.rbac_roles) AS site_roles
+				WHERE
+					-- Roles granted for a limited time are ignored once they
+					-- expire, even before they are removed.
+					NOT EXISTS (
+						SELECT
+							1
+						FROM
+							temporary_role_grants
+						WHERE
+							temporary_role_grants.user_id = users.id
+							AND temporary_role_grants.organization_id IS NULL
+							AND temporary_role_grants.role_name = site_roles
+							AND temporary_role_grants.expires_at <= NOW()
+					)
+			),
+			'member'
+		),
 		(
 			SELECT
 				-- The roles are returned as a flat array, org scoped and site side.
@@ -9189,6 +10942,17 @@ SELECT
 				) AS org_roles
 			WHERE
 				user_id = users.id
+				AND NOT EXISTS (
+					SELECT
+						1
+					FROM
+						temporary_role_grants
+					WHERE
+						temporary_role_grants.user_id = users.id
+						AND temporary_role_grants.organization_id = organization_members.organization_id
+						AND temporary_role_grants.role_name = org_roles
+						AND temporary_role_grants.expires_at <= NOW()
+				)
 		)
 	) :: text[] AS roles,
 	-- All groups the user is in.
@@ -9233,7 +10997,7 @@ func (q *sqlQuerier) GetAuthorizationUserRoles(ctx context.Context, userID uuid.
 
 const getUserByEmailOrUsername = `-- name: GetUserByEmailOrUsername :one
 SELECT
-	id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name
+	id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name, is_service_account
 FROM
 	users
 WHERE
@@ -9267,13 +11031,14 @@ func (q *sqlQuerier) GetUserByEmailOrUsername(ctx context.Context, arg GetUserBy
 		&i.QuietHoursSchedule,
 		&i.ThemePreference,
 		&i.Name,
+		&i.IsServiceAccount,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
 SELECT
-	id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name
+	id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name, is_service_account
 FROM
 	users
 WHERE
@@ -9301,6 +11066,7 @@ func (q *sqlQuerier) GetUserByID(ctx context.Context, id uuid.UUID) (User, error
 		&i.QuietHoursSchedule,
 		&i.ThemePreference,
 		&i.Name,
+		&i.IsServiceAccount,
 	)
 	return i, err
 }
@@ -9323,11 +11089,12 @@ func (q *sqlQuerier) GetUserCount(ctx context.Context) (int64, error) {
 
 const getUsers = `-- name: GetUsers :many
 SELECT
-	id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name, COUNT(*) OVER() AS count
+	id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name, is_service_account, COUNT(*) OVER() AS count
 FROM
 	users
 WHERE
 	users.deleted = false
+	AND users.is_service_account = false
 	AND CASE
 		-- This allows using the last element on a page as effectively a cursor.
 		-- This is an important option for scripts that need to paginate without
@@ -9422,10 +11189,11 @@ type GetUsersRow struct {
 	QuietHoursSchedule string         `db:"quiet_hours_schedule" json:"quiet_hours_schedule"`
 	ThemePreference    string         `db:"theme_preference" json:"theme_preference"`
 	Name               string         `db:"name" json:"name"`
+	IsServiceAccount   bool           `db:"is_service_account" json:"is_service_account"`
 	Count              int64          `db:"count" json:"count"`
 }
 
-// This will never return deleted users.
+// This will never return deleted users or service accounts.
 func (q *sqlQuerier) GetUsers(ctx context.Context, arg GetUsersParams) ([]GetUsersRow, error) {
 	rows, err := q.db.QueryContext(ctx, getUsers,
 		arg.AfterID,
@@ -9460,6 +11228,7 @@ func (q *sqlQuerier) GetUsers(ctx context.Context, arg GetUsersParams) ([]GetUse
 			&i.QuietHoursSchedule,
 			&i.ThemePreference,
 			&i.Name,
+			&i.IsServiceAccount,
 			&i.Count,
 		); err != nil {
 			return nil, err
@@ -9476,7 +11245,7 @@ func (q *sqlQuerier) GetUsers(ctx context.Context, arg GetUsersParams) ([]GetUse
 }
 
 const getUsersByIDs = `-- name: GetUsersByIDs :many
-SELECT id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name FROM users WHERE id = ANY($1 :: uuid [ ])
+SELECT id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name, is_service_account FROM users WHERE id = ANY($1 :: uuid [ ])
 `
 
 // This shouldn't check for deleted, because it's frequently used
@@ -9507,6 +11276,7 @@ func (q *sqlQuerier) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]User
 			&i.QuietHoursSchedule,
 			&i.ThemePreference,
 			&i.Name,
+			&i.IsServiceAccount,
 		); err != nil {
 			return nil, err
 		}
@@ -9532,22 +11302,24 @@ INSERT INTO
 		created_at,
 		updated_at,
 		rbac_roles,
-		login_type
+		login_type,
+		is_service_account
 	)
 VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name, is_service_account
 `
 
 type InsertUserParams struct {
-	ID             uuid.UUID      `db:"id" json:"id"`
-	Email          string         `db:"email" json:"email"`
-	Username       string         `db:"username" json:"username"`
-	Name           string         `db:"name" json:"name"`
-	HashedPassword []byte         `db:"hashed_password" json:"hashed_password"`
-	CreatedAt      time.Time      `db:"created_at" json:"created_at"`
-	UpdatedAt      time.Time      `db:"updated_at" json:"updated_at"`
-	RBACRoles      pq.StringArray `db:"rbac_roles" json:"rbac_roles"`
-	LoginType      LoginType      `db:"login_type" json:"login_type"`
+	ID               uuid.UUID      `db:"id" json:"id"`
+	Email            string         `db:"email" json:"email"`
+	Username         string         `db:"username" json:"username"`
+	Name             string         `db:"name" json:"name"`
+	HashedPassword   []byte         `db:"hashed_password" json:"hashed_password"`
+	CreatedAt        time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt        time.Time      `db:"updated_at" json:"updated_at"`
+	RBACRoles        pq.StringArray `db:"rbac_roles" json:"rbac_roles"`
+	LoginType        LoginType      `db:"login_type" json:"login_type"`
+	IsServiceAccount bool           `db:"is_service_account" json:"is_service_account"`
 }
 
 func (q *sqlQuerier) InsertUser(ctx context.Context, arg InsertUserParams) (User, error) {
@@ -9561,6 +11333,7 @@ func (q *sqlQuerier) InsertUser(ctx context.Context, arg InsertUserParams) (User
 		arg.UpdatedAt,
 		arg.RBACRoles,
 		arg.LoginType,
+		arg.IsServiceAccount,
 	)
 	var i User
 	err := row.Scan(
@@ -9579,6 +11352,7 @@ func (q *sqlQuerier) InsertUser(ctx context.Context, arg InsertUserParams) (User
 		&i.QuietHoursSchedule,
 		&i.ThemePreference,
 		&i.Name,
+		&i.IsServiceAccount,
 	)
 	return i, err
 }
@@ -9637,7 +11411,7 @@ SET
 	updated_at = $3
 WHERE
 	id = $1
-RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name
+RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name, is_service_account
 `
 
 type UpdateUserAppearanceSettingsParams struct {
@@ -9665,6 +11439,7 @@ func (q *sqlQuerier) UpdateUserAppearanceSettings(ctx context.Context, arg Updat
 		&i.QuietHoursSchedule,
 		&i.ThemePreference,
 		&i.Name,
+		&i.IsServiceAccount,
 	)
 	return i, err
 }
@@ -9709,7 +11484,7 @@ SET
 	last_seen_at = $2,
 	updated_at = $3
 WHERE
-	id = $1 RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name
+	id = $1 RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name, is_service_account
 `
 
 type UpdateUserLastSeenAtParams struct {
@@ -9737,6 +11512,7 @@ func (q *sqlQuerier) UpdateUserLastSeenAt(ctx context.Context, arg UpdateUserLas
 		&i.QuietHoursSchedule,
 		&i.ThemePreference,
 		&i.Name,
+		&i.IsServiceAccount,
 	)
 	return i, err
 }
@@ -9754,7 +11530,7 @@ SET
 		'':: bytea
 	END
 WHERE
-	id = $2 RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name
+	id = $2 RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name, is_service_account
 `
 
 type UpdateUserLoginTypeParams struct {
@@ -9781,6 +11557,7 @@ func (q *sqlQuerier) UpdateUserLoginType(ctx context.Context, arg UpdateUserLogi
 		&i.QuietHoursSchedule,
 		&i.ThemePreference,
 		&i.Name,
+		&i.IsServiceAccount,
 	)
 	return i, err
 }
@@ -9796,7 +11573,7 @@ SET
 	name = $6
 WHERE
 	id = $1
-RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name
+RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name, is_service_account
 `
 
 type UpdateUserProfileParams struct {
@@ -9834,6 +11611,7 @@ func (q *sqlQuerier) UpdateUserProfile(ctx context.Context, arg UpdateUserProfil
 		&i.QuietHoursSchedule,
 		&i.ThemePreference,
 		&i.Name,
+		&i.IsServiceAccount,
 	)
 	return i, err
 }
@@ -9845,7 +11623,7 @@ SET
 	quiet_hours_schedule = $2
 WHERE
 	id = $1
-RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name
+RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name, is_service_account
 `
 
 type UpdateUserQuietHoursScheduleParams struct {
@@ -9872,6 +11650,7 @@ func (q *sqlQuerier) UpdateUserQuietHoursSchedule(ctx context.Context, arg Updat
 		&i.QuietHoursSchedule,
 		&i.ThemePreference,
 		&i.Name,
+		&i.IsServiceAccount,
 	)
 	return i, err
 }
@@ -9884,7 +11663,7 @@ SET
 	rbac_roles = ARRAY(SELECT DISTINCT UNNEST($1 :: text[]))
 WHERE
 	id = $2
-RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name
+RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name, is_service_account
 `
 
 type UpdateUserRolesParams struct {
@@ -9911,6 +11690,7 @@ func (q *sqlQuerier) UpdateUserRoles(ctx context.Context, arg UpdateUserRolesPar
 		&i.QuietHoursSchedule,
 		&i.ThemePreference,
 		&i.Name,
+		&i.IsServiceAccount,
 	)
 	return i, err
 }
@@ -9922,7 +11702,7 @@ SET
 	status = $2,
 	updated_at = $3
 WHERE
-	id = $1 RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name
+	id = $1 RETURNING id, email, username, hashed_password, created_at, updated_at, status, rbac_roles, login_type, avatar_url, deleted, last_seen_at, quiet_hours_schedule, theme_preference, name, is_service_account
 `
 
 type UpdateUserStatusParams struct {
@@ -9950,6 +11730,7 @@ func (q *sqlQuerier) UpdateUserStatus(ctx context.Context, arg UpdateUserStatusP
 		&i.QuietHoursSchedule,
 		&i.ThemePreference,
 		&i.Name,
+		&i.IsServiceAccount,
 	)
 	return i, err
 }
@@ -10152,7 +11933,7 @@ func (q *sqlQuerier) DeleteOldWorkspaceAgentLogs(ctx context.Context) error {
 
 const getWorkspaceAgentAndLatestBuildByAuthToken = `-- name: GetWorkspaceAgentAndLatestBuildByAuthToken :one
 SELECT
-	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite,
+	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.user_acl, workspaces.group_acl,
 	workspace_agents.id, workspace_agents.created_at, workspace_agents.updated_at, workspace_agents.name, workspace_agents.first_connected_at, workspace_agents.last_connected_at, workspace_agents.disconnected_at, workspace_agents.resource_id, workspace_agents.auth_token, workspace_agents.auth_instance_id, workspace_agents.architecture, workspace_agents.environment_variables, workspace_agents.operating_system, workspace_agents.instance_metadata, workspace_agents.resource_metadata, workspace_agents.directory, workspace_agents.version, workspace_agents.last_connected_replica_id, workspace_agents.connection_timeout_seconds, workspace_agents.troubleshooting_url, workspace_agents.motd_file, workspace_agents.lifecycle_state, workspace_agents.expanded_directory, workspace_agents.logs_length, workspace_agents.logs_overflowed, workspace_agents.started_at, workspace_agents.ready_at, workspace_agents.subsystems, workspace_agents.display_apps, workspace_agents.api_version, workspace_agents.display_order,
 	workspace_build_with_user.id, workspace_build_with_user.created_at, workspace_build_with_user.updated_at, workspace_build_with_user.workspace_id, workspace_build_with_user.template_version_id, workspace_build_with_user.build_number, workspace_build_with_user.transition, workspace_build_with_user.initiator_id, workspace_build_with_user.provisioner_state, workspace_build_with_user.job_id, workspace_build_with_user.deadline, workspace_build_with_user.reason, workspace_build_with_user.daily_cost, workspace_build_with_user.max_deadline, workspace_build_with_user.initiator_by_avatar_url, workspace_build_with_user.initiator_by_username
 FROM
@@ -10211,6 +11992,8 @@ func (q *sqlQuerier) GetWorkspaceAgentAndLatestBuildByAuthToken(ctx context.Cont
 		&i.Workspace.DeletingAt,
 		&i.Workspace.AutomaticUpdates,
 		&i.Workspace.Favorite,
+		&i.Workspace.UserACL,
+		&i.Workspace.GroupACL,
 		&i.WorkspaceAgent.ID,
 		&i.WorkspaceAgent.CreatedAt,
 		&i.WorkspaceAgent.UpdatedAt,
@@ -12604,6 +14387,341 @@ func (q *sqlQuerier) UpdateWorkspaceBuildProvisionerStateByID(ctx context.Contex
 	return err
 }
 
+const acquireWorkspaceBulkJob = `-- name: AcquireWorkspaceBulkJob :one
+UPDATE
+	workspace_bulk_jobs
+SET
+	worker_id = $1 :: uuid,
+	heartbeat_at = $2 :: timestamptz
+WHERE
+	id = (
+		SELECT
+			id
+		FROM
+			workspace_bulk_jobs AS nested
+		WHERE
+			nested.completed_at IS NULL
+			AND (
+				nested.worker_id IS NULL
+				OR nested.heartbeat_at < $3 :: timestamptz
+			)
+		ORDER BY
+			nested.created_at
+		FOR UPDATE
+		SKIP LOCKED
+		LIMIT
+			1
+	) RETURNING id, initiator_id, initiator_ip, initiator_scope, initiator_scopes, action, query, automatic_updates, dormant, concurrency, worker_id, heartbeat_at, created_at, completed_at
+`
+
+type AcquireWorkspaceBulkJobParams struct {
+	WorkerID    uuid.UUID `db:"worker_id" json:"worker_id"`
+	HeartbeatAt time.Time `db:"heartbeat_at" json:"heartbeat_at"`
+	StaleBefore time.Time `db:"stale_before" json:"stale_before"`
+}
+
+// Acquires the oldest job that is not completed and either not running, or
+// running on a worker that stopped sending heartbeats.
+func (q *sqlQuerier) AcquireWorkspaceBulkJob(ctx context.Context, arg AcquireWorkspaceBulkJobParams) (WorkspaceBulkJob, error) {
+	row := q.db.QueryRowContext(ctx, acquireWorkspaceBulkJob, arg.WorkerID, arg.HeartbeatAt, arg.StaleBefore)
+	var i WorkspaceBulkJob
+	err := row.Scan(
+		&i.ID,
+		&i.InitiatorID,
+		&i.InitiatorIP,
+		&i.InitiatorScope,
+		pq.Array(&i.InitiatorScopes),
+		&i.Action,
+		&i.Query,
+		&i.AutomaticUpdates,
+		&i.Dormant,
+		&i.Concurrency,
+		&i.WorkerID,
+		&i.HeartbeatAt,
+		&i.CreatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const getWorkspaceBulkJobByID = `-- name: GetWorkspaceBulkJobByID :one
+SELECT
+	id, initiator_id, initiator_ip, initiator_scope, initiator_scopes, action, query, automatic_updates, dormant, concurrency, worker_id, heartbeat_at, created_at, completed_at
+FROM
+	workspace_bulk_jobs
+WHERE
+	id = $1
+`
+
+func (q *sqlQuerier) GetWorkspaceBulkJobByID(ctx context.Context, id uuid.UUID) (WorkspaceBulkJob, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceBulkJobByID, id)
+	var i WorkspaceBulkJob
+	err := row.Scan(
+		&i.ID,
+		&i.InitiatorID,
+		&i.InitiatorIP,
+		&i.InitiatorScope,
+		pq.Array(&i.InitiatorScopes),
+		&i.Action,
+		&i.Query,
+		&i.AutomaticUpdates,
+		&i.Dormant,
+		&i.Concurrency,
+		&i.WorkerID,
+		&i.HeartbeatAt,
+		&i.CreatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const getWorkspaceBulkJobResultsByJobID = `-- name: GetWorkspaceBulkJobResultsByJobID :many
+SELECT
+	workspace_bulk_job_results.job_id,
+	workspace_bulk_job_results.workspace_id,
+	workspace_bulk_job_results.status,
+	workspace_bulk_job_results.error,
+	workspace_bulk_job_results.build_id,
+	workspace_bulk_job_results.updated_at,
+	workspaces.name AS workspace_name,
+	users.username AS workspace_owner_username
+FROM
+	workspace_bulk_job_results
+	JOIN workspaces ON workspaces.id = workspace_bulk_job_results.workspace_id
+	JOIN users ON users.id = workspaces.owner_id
+WHERE
+	workspace_bulk_job_results.job_id = $1
+ORDER BY
+	users.username, workspaces.name
+`
+
+type GetWorkspaceBulkJobResultsByJobIDRow struct {
+	JobID                  uuid.UUID                 `db:"job_id" json:"job_id"`
+	WorkspaceID            uuid.UUID                 `db:"workspace_id" json:"workspace_id"`
+	Status                 WorkspaceBulkResultStatus `db:"status" json:"status"`
+	Error                  string                    `db:"error" json:"error"`
+	BuildID                uuid.NullUUID             `db:"build_id" json:"build_id"`
+	UpdatedAt              time.Time                 `db:"updated_at" json:"updated_at"`
+	WorkspaceName          string                    `db:"workspace_name" json:"workspace_name"`
+	WorkspaceOwnerUsername string                    `db:"workspace_owner_username" json:"workspace_owner_username"`
+}
+
+func (q *sqlQuerier) GetWorkspaceBulkJobResultsByJobID(ctx context.Context, jobID uuid.UUID) ([]GetWorkspaceBulkJobResultsByJobIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceBulkJobResultsByJobID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWorkspaceBulkJobResultsByJobIDRow
+	for rows.Next() {
+		var i GetWorkspaceBulkJobResultsByJobIDRow
+		if err := rows.Scan(
+			&i.JobID,
+			&i.WorkspaceID,
+			&i.Status,
+			&i.Error,
+			&i.BuildID,
+			&i.UpdatedAt,
+			&i.WorkspaceName,
+			&i.WorkspaceOwnerUsername,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertWorkspaceBulkJob = `-- name: InsertWorkspaceBulkJob :one
+INSERT INTO
+	workspace_bulk_jobs (
+		id,
+		initiator_id,
+		initiator_ip,
+		initiator_scope,
+		initiator_scopes,
+		action,
+		query,
+		automatic_updates,
+		dormant,
+		concurrency,
+		created_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, initiator_id, initiator_ip, initiator_scope, initiator_scopes, action, query, automatic_updates, dormant, concurrency, worker_id, heartbeat_at, created_at, completed_at
+`
+
+type InsertWorkspaceBulkJobParams struct {
+	ID               uuid.UUID           `db:"id" json:"id"`
+	InitiatorID      uuid.UUID           `db:"initiator_id" json:"initiator_id"`
+	InitiatorIP      string              `db:"initiator_ip" json:"initiator_ip"`
+	InitiatorScope   APIKeyScope         `db:"initiator_scope" json:"initiator_scope"`
+	InitiatorScopes  []string            `db:"initiator_scopes" json:"initiator_scopes"`
+	Action           WorkspaceBulkAction `db:"action" json:"action"`
+	Query            string              `db:"query" json:"query"`
+	AutomaticUpdates AutomaticUpdates    `db:"automatic_updates" json:"automatic_updates"`
+	Dormant          bool                `db:"dormant" json:"dormant"`
+	Concurrency      int32               `db:"concurrency" json:"concurrency"`
+	CreatedAt        time.Time           `db:"created_at" json:"created_at"`
+}
+
+func (q *sqlQuerier) InsertWorkspaceBulkJob(ctx context.Context, arg InsertWorkspaceBulkJobParams) (WorkspaceBulkJob, error) {
+	row := q.db.QueryRowContext(ctx, insertWorkspaceBulkJob,
+		arg.ID,
+		arg.InitiatorID,
+		arg.InitiatorIP,
+		arg.InitiatorScope,
+		pq.Array(arg.InitiatorScopes),
+		arg.Action,
+		arg.Query,
+		arg.AutomaticUpdates,
+		arg.Dormant,
+		arg.Concurrency,
+		arg.CreatedAt,
+	)
+	var i WorkspaceBulkJob
+	err := row.Scan(
+		&i.ID,
+		&i.InitiatorID,
+		&i.InitiatorIP,
+		&i.InitiatorScope,
+		pq.Array(&i.InitiatorScopes),
+		&i.Action,
+		&i.Query,
+		&i.AutomaticUpdates,
+		&i.Dormant,
+		&i.Concurrency,
+		&i.WorkerID,
+		&i.HeartbeatAt,
+		&i.CreatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const insertWorkspaceBulkJobResults = `-- name: InsertWorkspaceBulkJobResults :exec
+INSERT INTO
+	workspace_bulk_job_results (
+		job_id,
+		workspace_id,
+		updated_at
+	)
+SELECT
+	$1 :: uuid AS job_id,
+	unnest($2 :: uuid[]) AS workspace_id,
+	$3 :: timestamptz AS updated_at
+`
+
+type InsertWorkspaceBulkJobResultsParams struct {
+	JobID        uuid.UUID   `db:"job_id" json:"job_id"`
+	WorkspaceIds []uuid.UUID `db:"workspace_ids" json:"workspace_ids"`
+	UpdatedAt    time.Time   `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) InsertWorkspaceBulkJobResults(ctx context.Context, arg InsertWorkspaceBulkJobResultsParams) error {
+	_, err := q.db.ExecContext(ctx, insertWorkspaceBulkJobResults, arg.JobID, pq.Array(arg.WorkspaceIds), arg.UpdatedAt)
+	return err
+}
+
+const updateWorkspaceBulkJobCompletedAtByID = `-- name: UpdateWorkspaceBulkJobCompletedAtByID :exec
+UPDATE
+	workspace_bulk_jobs
+SET
+	completed_at = $2
+WHERE
+	id = $1
+`
+
+type UpdateWorkspaceBulkJobCompletedAtByIDParams struct {
+	ID          uuid.UUID    `db:"id" json:"id"`
+	CompletedAt sql.NullTime `db:"completed_at" json:"completed_at"`
+}
+
+func (q *sqlQuerier) UpdateWorkspaceBulkJobCompletedAtByID(ctx context.Context, arg UpdateWorkspaceBulkJobCompletedAtByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceBulkJobCompletedAtByID, arg.ID, arg.CompletedAt)
+	return err
+}
+
+const updateWorkspaceBulkJobHeartbeat = `-- name: UpdateWorkspaceBulkJobHeartbeat :one
+UPDATE
+	workspace_bulk_jobs
+SET
+	heartbeat_at = $1 :: timestamptz
+WHERE
+	id = $2
+	AND worker_id = $3 :: uuid
+RETURNING id, initiator_id, initiator_ip, initiator_scope, initiator_scopes, action, query, automatic_updates, dormant, concurrency, worker_id, heartbeat_at, created_at, completed_at
+`
+
+type UpdateWorkspaceBulkJobHeartbeatParams struct {
+	HeartbeatAt time.Time `db:"heartbeat_at" json:"heartbeat_at"`
+	ID          uuid.UUID `db:"id" json:"id"`
+	WorkerID    uuid.UUID `db:"worker_id" json:"worker_id"`
+}
+
+// Returns no rows once another worker took the job over.
+func (q *sqlQuerier) UpdateWorkspaceBulkJobHeartbeat(ctx context.Context, arg UpdateWorkspaceBulkJobHeartbeatParams) (WorkspaceBulkJob, error) {
+	row := q.db.QueryRowContext(ctx, updateWorkspaceBulkJobHeartbeat, arg.HeartbeatAt, arg.ID, arg.WorkerID)
+	var i WorkspaceBulkJob
+	err := row.Scan(
+		&i.ID,
+		&i.InitiatorID,
+		&i.InitiatorIP,
+		&i.InitiatorScope,
+		pq.Array(&i.InitiatorScopes),
+		&i.Action,
+		&i.Query,
+		&i.AutomaticUpdates,
+		&i.Dormant,
+		&i.Concurrency,
+		&i.WorkerID,
+		&i.HeartbeatAt,
+		&i.CreatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const updateWorkspaceBulkJobResult = `-- name: UpdateWorkspaceBulkJobResult :exec
+UPDATE
+	workspace_bulk_job_results
+SET
+	status = $3,
+	error = $4,
+	build_id = $5,
+	updated_at = $6
+WHERE
+	job_id = $1
+	AND workspace_id = $2
+`
+
+type UpdateWorkspaceBulkJobResultParams struct {
+	JobID       uuid.UUID                 `db:"job_id" json:"job_id"`
+	WorkspaceID uuid.UUID                 `db:"workspace_id" json:"workspace_id"`
+	Status      WorkspaceBulkResultStatus `db:"status" json:"status"`
+	Error       string                    `db:"error" json:"error"`
+	BuildID     uuid.NullUUID             `db:"build_id" json:"build_id"`
+	UpdatedAt   time.Time                 `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) UpdateWorkspaceBulkJobResult(ctx context.Context, arg UpdateWorkspaceBulkJobResultParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceBulkJobResult,
+		arg.JobID,
+		arg.WorkspaceID,
+		arg.Status,
+		arg.Error,
+		arg.BuildID,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
 const getWorkspaceResourceByID = `-- name: GetWorkspaceResourceByID :one
 SELECT
 	id, created_at, job_id, transition, type, name, hide, icon, instance_type, daily_cost
@@ -13045,7 +15163,7 @@ func (q *sqlQuerier) GetDeploymentWorkspaceStats(ctx context.Context) (GetDeploy
 
 const getWorkspaceByAgentID = `-- name: GetWorkspaceByAgentID :one
 SELECT
-	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite,
+	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.user_acl, workspaces.group_acl,
 	templates.name as template_name
 FROM
 	workspaces
@@ -13100,6 +15218,8 @@ func (q *sqlQuerier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUI
 		&i.Workspace.DeletingAt,
 		&i.Workspace.AutomaticUpdates,
 		&i.Workspace.Favorite,
+		&i.Workspace.UserACL,
+		&i.Workspace.GroupACL,
 		&i.TemplateName,
 	)
 	return i, err
@@ -13107,7 +15227,7 @@ func (q *sqlQuerier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUI
 
 const getWorkspaceByID = `-- name: GetWorkspaceByID :one
 SELECT
-	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, user_acl, group_acl
 FROM
 	workspaces
 WHERE
@@ -13135,13 +15255,15 @@ func (q *sqlQuerier) GetWorkspaceByID(ctx context.Context, id uuid.UUID) (Worksp
 		&i.DeletingAt,
 		&i.AutomaticUpdates,
 		&i.Favorite,
+		&i.UserACL,
+		&i.GroupACL,
 	)
 	return i, err
 }
 
 const getWorkspaceByOwnerIDAndName = `-- name: GetWorkspaceByOwnerIDAndName :one
 SELECT
-	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, user_acl, group_acl
 FROM
 	workspaces
 WHERE
@@ -13176,13 +15298,15 @@ func (q *sqlQuerier) GetWorkspaceByOwnerIDAndName(ctx context.Context, arg GetWo
 		&i.DeletingAt,
 		&i.AutomaticUpdates,
 		&i.Favorite,
+		&i.UserACL,
+		&i.GroupACL,
 	)
 	return i, err
 }
 
 const getWorkspaceByWorkspaceAppID = `-- name: GetWorkspaceByWorkspaceAppID :one
 SELECT
-	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, user_acl, group_acl
 FROM
 	workspaces
 WHERE
@@ -13236,6 +15360,8 @@ func (q *sqlQuerier) GetWorkspaceByWorkspaceAppID(ctx context.Context, workspace
 		&i.DeletingAt,
 		&i.AutomaticUpdates,
 		&i.Favorite,
+		&i.UserACL,
+		&i.GroupACL,
 	)
 	return i, err
 }
@@ -13287,7 +15413,7 @@ SELECT
 ),
 filtered_workspaces AS (
 SELECT
-	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite,
+	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.user_acl, workspaces.group_acl,
 	COALESCE(template.name, 'unknown') as template_name,
 	latest_build.template_version_id,
 	latest_build.template_version_name,
@@ -13523,7 +15649,7 @@ WHERE
 	-- @authorize_filter
 ), filtered_workspaces_order AS (
 	SELECT
-		fw.id, fw.created_at, fw.updated_at, fw.owner_id, fw.organization_id, fw.template_id, fw.deleted, fw.name, fw.autostart_schedule, fw.ttl, fw.last_used_at, fw.dormant_at, fw.deleting_at, fw.automatic_updates, fw.favorite, fw.template_name, fw.template_version_id, fw.template_version_name, fw.username, fw.latest_build_completed_at, fw.latest_build_canceled_at, fw.latest_build_error, fw.latest_build_transition, fw.latest_build_status
+		fw.id, fw.created_at, fw.updated_at, fw.owner_id, fw.organization_id, fw.template_id, fw.deleted, fw.name, fw.autostart_schedule, fw.ttl, fw.last_used_at, fw.dormant_at, fw.deleting_at, fw.automatic_updates, fw.favorite, fw.user_acl, fw.group_acl, fw.template_name, fw.template_version_id, fw.template_version_name, fw.username, fw.latest_build_completed_at, fw.latest_build_canceled_at, fw.latest_build_error, fw.latest_build_transition, fw.latest_build_status
 	FROM
 		filtered_workspaces fw
 	ORDER BY
@@ -13544,7 +15670,7 @@ WHERE
 		$19
 ), filtered_workspaces_order_with_summary AS (
 	SELECT
-		fwo.id, fwo.created_at, fwo.updated_at, fwo.owner_id, fwo.organization_id, fwo.template_id, fwo.deleted, fwo.name, fwo.autostart_schedule, fwo.ttl, fwo.last_used_at, fwo.dormant_at, fwo.deleting_at, fwo.automatic_updates, fwo.favorite, fwo.template_name, fwo.template_version_id, fwo.template_version_name, fwo.username, fwo.latest_build_completed_at, fwo.latest_build_canceled_at, fwo.latest_build_error, fwo.latest_build_transition, fwo.latest_build_status
+		fwo.id, fwo.created_at, fwo.updated_at, fwo.owner_id, fwo.organization_id, fwo.template_id, fwo.deleted, fwo.name, fwo.autostart_schedule, fwo.ttl, fwo.last_used_at, fwo.dormant_at, fwo.deleting_at, fwo.automatic_updates, fwo.favorite, fwo.user_acl, fwo.group_acl, fwo.template_name, fwo.template_version_id, fwo.template_version_name, fwo.username, fwo.latest_build_completed_at, fwo.latest_build_canceled_at, fwo.latest_build_error, fwo.latest_build_transition, fwo.latest_build_status
 	FROM
 		filtered_workspaces_order fwo
 	-- Return a technical summary row with total count of workspaces.
@@ -13566,6 +15692,8 @@ WHERE
 		'0001-01-01 00:00:00+00'::timestamptz, -- deleting_at
 		'never'::automatic_updates, -- automatic_updates
 		false, -- favorite
+		'{}'::jsonb, -- user_acl
+		'{}'::jsonb, -- group_acl
 		-- Extra columns added to ` + "`" + `filtered_workspaces` + "`" + `
 		'', -- template_name
 		'00000000-0000-0000-0000-000000000000'::uuid, -- template_version_id
@@ -13585,7 +15713,7 @@ WHERE
 		filtered_workspaces
 )
 SELECT
-	fwos.id, fwos.created_at, fwos.updated_at, fwos.owner_id, fwos.organization_id, fwos.template_id, fwos.deleted, fwos.name, fwos.autostart_schedule, fwos.ttl, fwos.last_used_at, fwos.dormant_at, fwos.deleting_at, fwos.automatic_updates, fwos.favorite, fwos.template_name, fwos.template_version_id, fwos.template_version_name, fwos.username, fwos.latest_build_completed_at, fwos.latest_build_canceled_at, fwos.latest_build_error, fwos.latest_build_transition, fwos.latest_build_status,
+	fwos.id, fwos.created_at, fwos.updated_at, fwos.owner_id, fwos.organization_id, fwos.template_id, fwos.deleted, fwos.name, fwos.autostart_schedule, fwos.ttl, fwos.last_used_at, fwos.dormant_at, fwos.deleting_at, fwos.automatic_updates, fwos.favorite, fwos.user_acl, fwos.group_acl, fwos.template_name, fwos.template_version_id, fwos.template_version_name, fwos.username, fwos.latest_build_completed_at, fwos.latest_build_canceled_at, fwos.latest_build_error, fwos.latest_build_transition, fwos.latest_build_status,
 	tc.count
 FROM
 	filtered_workspaces_order_with_summary fwos
@@ -13633,6 +15761,8 @@ type GetWorkspacesRow struct {
 	DeletingAt             sql.NullTime         `db:"deleting_at" json:"deleting_at"`
 	AutomaticUpdates       AutomaticUpdates     `db:"automatic_updates" json:"automatic_updates"`
 	Favorite               bool                 `db:"favorite" json:"favorite"`
+	UserACL                WorkspaceACL         `db:"user_acl" json:"user_acl"`
+	GroupACL               WorkspaceACL         `db:"group_acl" json:"group_acl"`
 	TemplateName           string               `db:"template_name" json:"template_name"`
 	TemplateVersionID      uuid.UUID            `db:"template_version_id" json:"template_version_id"`
 	TemplateVersionName    sql.NullString       `db:"template_version_name" json:"template_version_name"`
@@ -13695,6 +15825,8 @@ func (q *sqlQuerier) GetWorkspaces(ctx context.Context, arg GetWorkspacesParams)
 			&i.DeletingAt,
 			&i.AutomaticUpdates,
 			&i.Favorite,
+			&i.UserACL,
+			&i.GroupACL,
 			&i.TemplateName,
 			&i.TemplateVersionID,
 			&i.TemplateVersionName,
@@ -13721,7 +15853,7 @@ func (q *sqlQuerier) GetWorkspaces(ctx context.Context, arg GetWorkspacesParams)
 
 const getWorkspacesEligibleForTransition = `-- name: GetWorkspacesEligibleForTransition :many
 SELECT
-	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite
+	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.user_acl, workspaces.group_acl
 FROM
 	workspaces
 LEFT JOIN
@@ -13818,6 +15950,8 @@ func (q *sqlQuerier) GetWorkspacesEligibleForTransition(ctx context.Context, now
 			&i.DeletingAt,
 			&i.AutomaticUpdates,
 			&i.Favorite,
+			&i.UserACL,
+			&i.GroupACL,
 		); err != nil {
 			return nil, err
 		}
@@ -13848,7 +15982,7 @@ INSERT INTO
 		automatic_updates
 	)
 VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, user_acl, group_acl
 `
 
 type InsertWorkspaceParams struct {
@@ -13896,6 +16030,8 @@ func (q *sqlQuerier) InsertWorkspace(ctx context.Context, arg InsertWorkspacePar
 		&i.DeletingAt,
 		&i.AutomaticUpdates,
 		&i.Favorite,
+		&i.UserACL,
+		&i.GroupACL,
 	)
 	return i, err
 }
@@ -13935,7 +16071,7 @@ SET
 WHERE
 	id = $1
 	AND deleted = false
-RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite
+RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, user_acl, group_acl
 `
 
 type UpdateWorkspaceParams struct {
@@ -13962,10 +16098,33 @@ func (q *sqlQuerier) UpdateWorkspace(ctx context.Context, arg UpdateWorkspacePar
 		&i.DeletingAt,
 		&i.AutomaticUpdates,
 		&i.Favorite,
+		&i.UserACL,
+		&i.GroupACL,
 	)
 	return i, err
 }
 
+const updateWorkspaceACLByID = `-- name: UpdateWorkspaceACLByID :exec
+UPDATE
+	workspaces
+SET
+	group_acl = $1,
+	user_acl = $2
+WHERE
+	id = $3
+`
+
+type UpdateWorkspaceACLByIDParams struct {
+	GroupACL WorkspaceACL `db:"group_acl" json:"group_acl"`
+	UserACL  WorkspaceACL `db:"user_acl" json:"user_acl"`
+	ID       uuid.UUID    `db:"id" json:"id"`
+}
+
+func (q *sqlQuerier) UpdateWorkspaceACLByID(ctx context.Context, arg UpdateWorkspaceACLByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceACLByID, arg.GroupACL, arg.UserACL, arg.ID)
+	return err
+}
+
 const updateWorkspaceAutomaticUpdates = `-- name: UpdateWorkspaceAutomaticUpdates :exec
 UPDATE
 	workspaces
@@ -14048,7 +16207,7 @@ WHERE
     workspaces.id = $1
     AND templates.id = workspaces.template_id
 RETURNING
-    workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite
+    workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.user_acl, workspaces.group_acl
 `
 
 type UpdateWorkspaceDormantDeletingAtParams struct {
@@ -14075,6 +16234,8 @@ func (q *sqlQuerier) UpdateWorkspaceDormantDeletingAt(ctx context.Context, arg U
 		&i.DeletingAt,
 		&i.AutomaticUpdates,
 		&i.Favorite,
+		&i.UserACL,
+		&i.GroupACL,
 	)
 	return i, err
 }
@@ -14098,6 +16259,55 @@ func (q *sqlQuerier) UpdateWorkspaceLastUsedAt(ctx context.Context, arg UpdateWo
 	return err
 }
 
+const updateWorkspaceOwner = `-- name: UpdateWorkspaceOwner :one
+UPDATE
+	workspaces
+SET
+	owner_id = $1,
+	favorite = false,
+	user_acl = '{}',
+	group_acl = '{}',
+	updated_at = $2
+WHERE
+	id = $3
+	AND deleted = false
+RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, user_acl, group_acl
+`
+
+type UpdateWorkspaceOwnerParams struct {
+	OwnerID   uuid.UUID `db:"owner_id" json:"owner_id"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+	ID        uuid.UUID `db:"id" json:"id"`
+}
+
+// Transfers the workspace to the new owner. The favorite flag belongs to the
+// previous owner, so it is cleared. The previous owner chose who the workspace
+// is shared with, so sharing is revoked.
+func (q *sqlQuerier) UpdateWorkspaceOwner(ctx context.Context, arg UpdateWorkspaceOwnerParams) (Workspace, error) {
+	row := q.db.QueryRowContext(ctx, updateWorkspaceOwner, arg.OwnerID, arg.UpdatedAt, arg.ID)
+	var i Workspace
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OwnerID,
+		&i.OrganizationID,
+		&i.TemplateID,
+		&i.Deleted,
+		&i.Name,
+		&i.AutostartSchedule,
+		&i.Ttl,
+		&i.LastUsedAt,
+		&i.DormantAt,
+		&i.DeletingAt,
+		&i.AutomaticUpdates,
+		&i.Favorite,
+		&i.UserACL,
+		&i.GroupACL,
+	)
+	return i, err
+}
+
 const updateWorkspaceTTL = `-- name: UpdateWorkspaceTTL :exec
 UPDATE
 	workspaces
@@ -14130,7 +16340,7 @@ WHERE
     template_id = $3
 AND
     dormant_at IS NOT NULL
-RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite
+RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, user_acl, group_acl
 `
 
 type UpdateWorkspacesDormantDeletingAtByTemplateIDParams struct {
@@ -14164,6 +16374,8 @@ func (q *sqlQuerier) UpdateWorkspacesDormantDeletingAtByTemplateID(ctx context.C
 			&i.DeletingAt,
 			&i.AutomaticUpdates,
 			&i.Favorite,
+			&i.UserACL,
+			&i.GroupACL,
 		); err != nil {
 			return nil, err
 		}
@@ -30,6 +30,23 @@ SELECT * FROM api_keys WHERE login_type = $1;
 -- name: GetAPIKeysByUserID :many
 SELECT * FROM api_keys WHERE login_type = $1 AND user_id = $2;
 
+-- name: GetSessionAPIKeysByUserID :many
+-- Sessions are the API keys that users get by logging in. Tokens, workspace
+-- app keys and keys issued to OAuth2 applications are not sessions. Expired
+-- sessions can no longer be used, so they are left out.
+SELECT
+	*
+FROM
+	api_keys
+WHERE
+	user_id = @user_id AND
+	login_type != 'token'::login_type AND
+	scope = 'all'::api_key_scope AND
+	token_name = '' AND
+	expires_at > now()
+ORDER BY
+	last_used DESC, created_at DESC;
+
 -- name: InsertAPIKey :one
 INSERT INTO
 	api_keys (
@@ -44,7 +61,9 @@ INSERT INTO
 		updated_at,
 		login_type,
 		scope,
-		token_name
+		token_name,
+		scopes,
+		user_agent
 	)
 VALUES
 	(@id,
@@ -53,7 +72,7 @@ VALUES
 	     WHEN 0 THEN 86400
 		 ELSE @lifetime_seconds::bigint
 	 END
-	 , @hashed_secret, @ip_address, @user_id, @last_used, @expires_at, @created_at, @updated_at, @login_type, @scope, @token_name) RETURNING *;
+	 , @hashed_secret, @ip_address, @user_id, @last_used, @expires_at, @created_at, @updated_at, @login_type, @scope, @token_name, COALESCE(@scopes::text[], '{}'::text[]), @user_agent) RETURNING *;
 
 -- name: UpdateAPIKeyByID :exec
 UPDATE
@@ -148,3 +148,7 @@ INSERT INTO
     )
 VALUES
 	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING *;
+
+-- name: DeleteOldAuditLogs :exec
+-- Delete all audit logs which were created before the given time.
+DELETE FROM audit_logs WHERE "time" < @before_time :: timestamptz;
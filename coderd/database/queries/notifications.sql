@@ -43,6 +43,7 @@ WITH acquired AS (
                 leased_until = NOW() + CONCAT(sqlc.arg('lease_seconds')::int, ' seconds')::interval
             WHERE id IN (SELECT nm.id
                          FROM notification_messages AS nm
+                                  JOIN notification_templates AS nt ON nm.notification_template_id = nt.id
                          WHERE (
                              (
                                  -- message is in acquirable states
@@ -68,6 +69,22 @@ WITH acquired AS (
                                  ELSE true
                                  END
                              )
+                           -- messages of templates with a digest window are held until the oldest of the user's
+                           -- undelivered messages of that template has waited for the window, at which point they are
+                           -- all acquired together to be delivered as a single digest
+                           AND (
+                             nt.digest_window_seconds = 0
+                                 OR EXISTS (SELECT 1
+                                            FROM notification_messages AS oldest
+                                            WHERE oldest.user_id = nm.user_id
+                                              AND oldest.notification_template_id = nm.notification_template_id
+                                              AND oldest.status IN (
+                                                                    'pending'::notification_message_status,
+                                                                    'temporary_failure'::notification_message_status
+                                                )
+                                              AND oldest.created_at <=
+                                                  NOW() - CONCAT(nt.digest_window_seconds, ' seconds')::interval)
+                             )
                          ORDER BY nm.created_at ASC
                                   -- Ensure that multiple concurrent readers cannot retrieve the same rows
                              FOR UPDATE OF nm
@@ -81,10 +98,12 @@ SELECT
     nm.method,
     nm.attempt_count::int AS attempt_count,
     nm.queued_seconds::float AS queued_seconds,
+    nm.user_id,
     -- template
     nt.id AS template_id,
     nt.title_template,
-    nt.body_template
+    nt.body_template,
+    nt.digest_window_seconds
 FROM acquired nm
          JOIN notification_templates nt ON nm.notification_template_id = nt.id;
 
@@ -133,3 +152,117 @@ WHERE id IN
 -- name: GetNotificationMessagesByStatus :many
 SELECT * FROM notification_messages WHERE status = @status LIMIT sqlc.arg('limit')::int;
 
+-- name: GetUserNotificationPreferences :many
+-- Returns the user's preference for every notification template, using the defaults where no preference has been set.
+SELECT nt.id                              AS notification_template_id,
+       nt.name                            AS notification_template_name,
+       nt."group",
+       COALESCE(np.disabled, FALSE)::bool AS disabled,
+       np.method,
+       np.updated_at
+FROM notification_templates nt
+         LEFT JOIN notification_preferences np
+                   ON np.notification_template_id = nt.id AND np.user_id = @user_id::uuid
+ORDER BY nt."group", nt.name;
+
+-- name: GetUserNotificationPreference :one
+SELECT *
+FROM notification_preferences
+WHERE user_id = @user_id::uuid
+  AND notification_template_id = @notification_template_id::uuid;
+
+-- name: UpsertUserNotificationPreferences :execrows
+INSERT INTO notification_preferences (user_id, notification_template_id, disabled, method, created_at, updated_at)
+SELECT @user_id::uuid,
+       new_values.notification_template_id,
+       new_values.disabled,
+       NULLIF(new_values.method, '')::notification_method,
+       NOW(),
+       NOW()
+FROM (SELECT UNNEST(@notification_template_ids::uuid[]) AS notification_template_id,
+             UNNEST(@disabled::bool[])                  AS disabled,
+             UNNEST(@methods::text[])                   AS method) AS new_values
+ON CONFLICT (user_id, notification_template_id) DO UPDATE
+    SET disabled   = EXCLUDED.disabled,
+        method     = EXCLUDED.method,
+        updated_at = NOW();
+
+-- name: InsertInboxNotification :exec
+-- Messages may be redelivered if a notifier fails to record the dispatch result, so conflicting inserts are ignored.
+INSERT INTO inbox_notifications (id, user_id, title, content, actions, created_at)
+VALUES (@id, @user_id, @title, @content, @actions::jsonb, @created_at)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: GetInboxNotificationByID :one
+SELECT *
+FROM inbox_notifications
+WHERE id = @id;
+
+-- name: GetInboxNotificationsByUserID :many
+-- Returns the user's inbox notifications, newest first. If starting_before is set, only notifications created before
+-- the given notification are returned, which allows the inbox to be paginated.
+SELECT *
+FROM inbox_notifications
+WHERE user_id = @user_id
+  AND CASE WHEN @unread_only::bool THEN read_at IS NULL ELSE TRUE END
+  AND CASE
+          WHEN @starting_before::uuid != '00000000-0000-0000-0000-000000000000'::uuid
+              THEN created_at < (SELECT created_at FROM inbox_notifications WHERE id = @starting_before)
+          ELSE TRUE
+    END
+ORDER BY created_at DESC, id DESC
+LIMIT COALESCE(NULLIF(@limit_opt::int, 0), 25);
+
+-- name: CountUnreadInboxNotificationsByUserID :one
+SELECT COUNT(*)
+FROM inbox_notifications
+WHERE user_id = @user_id
+  AND read_at IS NULL;
+
+-- name: UpdateInboxNotificationReadStatus :one
+UPDATE inbox_notifications
+SET read_at = @read_at
+WHERE id = @id
+RETURNING *;
+
+-- name: MarkAllInboxNotificationsAsRead :execrows
+UPDATE inbox_notifications
+SET read_at = @read_at::timestamptz
+WHERE user_id = @user_id
+  AND read_at IS NULL;
+
+-- name: GetNotificationTemplates :many
+SELECT *
+FROM notification_templates
+ORDER BY "group", name;
+
+-- name: GetNotificationTemplateByID :one
+SELECT *
+FROM notification_templates
+WHERE id = @id::uuid;
+
+-- name: UpdateNotificationTemplateByID :one
+-- Replaces the title and body templates, retaining the defaults the first time a template is customized so that it can
+-- later be reset.
+UPDATE notification_templates
+SET default_title_template = COALESCE(default_title_template, title_template),
+    default_body_template  = COALESCE(default_body_template, body_template),
+    title_template         = @title_template::text,
+    body_template          = @body_template::text
+WHERE id = @id::uuid
+RETURNING *;
+
+-- name: ResetNotificationTemplateByID :one
+UPDATE notification_templates
+SET title_template         = COALESCE(default_title_template, title_template),
+    body_template          = COALESCE(default_body_template, body_template),
+    default_title_template = NULL,
+    default_body_template  = NULL
+WHERE id = @id::uuid
+RETURNING *;
+
+-- name: UpdateNotificationTemplateDigestWindowByID :one
+UPDATE notification_templates
+SET digest_window_seconds = @digest_window_seconds::int
+WHERE id = @id::uuid
+RETURNING *;
@@ -5,7 +5,7 @@
 --  - Use both to get a specific org member row
 SELECT
 	sqlc.embed(organization_members),
-	users.username, users.avatar_url, users.name, users.email, users.rbac_roles as "global_roles"
+	users.username, users.avatar_url, users.name, users.email, users.rbac_roles as "global_roles", users.is_service_account
 FROM
 	organization_members
 		INNER JOIN
@@ -37,6 +37,17 @@ VALUES
 	($1, $2, $3, $4, $5) RETURNING *;
 
 -- name: DeleteOrganizationMember :exec
+-- Workspaces can only be shared within their organization, so the user is
+-- removed from the workspace ACLs of the organization too.
+WITH removed_workspace_acls AS (
+	UPDATE
+		workspaces
+	SET
+		user_acl = user_acl - @user_id :: text
+	WHERE
+		organization_id = @organization_id AND
+		user_acl ? @user_id :: text
+)
 DELETE
 	FROM
 		organization_members
@@ -0,0 +1,170 @@
+-- name: GetTemplatePrebuildPools :many
+-- Returns the prebuild pools of templates that are not deleted. A nil
+-- template_id returns the pools of all templates.
+SELECT
+	template_prebuild_pools.*
+FROM
+	template_prebuild_pools
+	JOIN templates ON templates.id = template_prebuild_pools.template_id
+WHERE
+	templates.deleted = false
+	AND CASE
+		WHEN @template_id :: uuid != '00000000-0000-0000-0000-000000000000'::uuid THEN
+			template_prebuild_pools.template_id = @template_id
+		ELSE true
+	END
+ORDER BY
+	template_prebuild_pools.template_id, template_prebuild_pools.name;
+
+-- name: GetTemplatePrebuildPoolByID :one
+SELECT
+	*
+FROM
+	template_prebuild_pools
+WHERE
+	id = $1;
+
+-- name: InsertTemplatePrebuildPool :one
+INSERT INTO
+	template_prebuild_pools (
+		id,
+		template_id,
+		name,
+		desired_instances,
+		parameters,
+		created_at,
+		updated_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7) RETURNING *;
+
+-- name: UpdateTemplatePrebuildPoolByID :one
+UPDATE
+	template_prebuild_pools
+SET
+	desired_instances = $2,
+	updated_at = $3
+WHERE
+	id = $1
+RETURNING *;
+
+-- name: DeleteTemplatePrebuildPoolByID :exec
+DELETE FROM template_prebuild_pools WHERE id = $1;
+
+-- name: InsertPrebuiltWorkspace :exec
+INSERT INTO
+	prebuilt_workspaces (
+		workspace_id,
+		pool_id,
+		created_at
+	)
+VALUES
+	($1, $2, $3);
+
+-- name: GetPrebuiltWorkspaces :many
+-- Returns the prebuilt workspaces that are not deleted, along with the state
+-- of their latest build. A nil template_id returns the prebuilt workspaces of
+-- all templates.
+SELECT
+	prebuilt_workspaces.workspace_id,
+	prebuilt_workspaces.pool_id,
+	prebuilt_workspaces.created_at,
+	workspaces.template_id,
+	workspaces.dormant_at,
+	templates.active_version_id,
+	latest_build.template_version_id,
+	latest_build.transition,
+	provisioner_jobs.job_status,
+	provisioner_jobs.completed_at
+FROM
+	prebuilt_workspaces
+	JOIN workspaces ON workspaces.id = prebuilt_workspaces.workspace_id
+	JOIN templates ON templates.id = workspaces.template_id
+	JOIN LATERAL (
+		SELECT
+			workspace_builds.template_version_id,
+			workspace_builds.transition,
+			workspace_builds.job_id
+		FROM
+			workspace_builds
+		WHERE
+			workspace_builds.workspace_id = workspaces.id
+		ORDER BY
+			workspace_builds.build_number DESC
+		LIMIT
+			1
+	) latest_build ON true
+	JOIN provisioner_jobs ON provisioner_jobs.id = latest_build.job_id
+WHERE
+	workspaces.deleted = false
+	AND CASE
+		WHEN @template_id :: uuid != '00000000-0000-0000-0000-000000000000'::uuid THEN
+			workspaces.template_id = @template_id
+		ELSE true
+	END
+ORDER BY
+	prebuilt_workspaces.created_at;
+
+-- name: ClaimPrebuiltWorkspace :one
+-- Transfers the oldest workspace of the pool that is running the active
+-- version of the template to the new owner, and removes it from the pool.
+-- Workspaces that are being claimed concurrently are skipped.
+WITH claimed AS (
+	DELETE FROM
+		prebuilt_workspaces
+	WHERE
+		workspace_id = (
+			SELECT
+				prebuilt_workspaces.workspace_id
+			FROM
+				prebuilt_workspaces
+				JOIN workspaces ON workspaces.id = prebuilt_workspaces.workspace_id
+				JOIN templates ON templates.id = workspaces.template_id
+				JOIN LATERAL (
+					SELECT
+						workspace_builds.template_version_id,
+						workspace_builds.transition,
+						workspace_builds.job_id
+					FROM
+						workspace_builds
+					WHERE
+						workspace_builds.workspace_id = workspaces.id
+					ORDER BY
+						workspace_builds.build_number DESC
+					LIMIT
+						1
+				) latest_build ON true
+				JOIN provisioner_jobs ON provisioner_jobs.id = latest_build.job_id
+			WHERE
+				prebuilt_workspaces.pool_id = @pool_id :: uuid
+				AND workspaces.organization_id = @organization_id
+				AND workspaces.deleted = false
+				AND workspaces.dormant_at IS NULL
+				AND latest_build.transition = 'start'::workspace_transition
+				AND latest_build.template_version_id = templates.active_version_id
+				AND provisioner_jobs.job_status = 'succeeded'::provisioner_job_status
+			ORDER BY
+				prebuilt_workspaces.created_at
+			LIMIT
+				1
+			FOR UPDATE OF prebuilt_workspaces SKIP LOCKED
+		)
+	RETURNING
+		workspace_id
+)
+UPDATE
+	workspaces
+SET
+	owner_id = @new_owner_id,
+	name = @new_name,
+	autostart_schedule = @autostart_schedule,
+	ttl = @ttl,
+	automatic_updates = @automatic_updates,
+	last_used_at = @now,
+	updated_at = @now
+FROM
+	claimed
+WHERE
+	workspaces.id = claimed.workspace_id
+RETURNING
+	workspaces.*;
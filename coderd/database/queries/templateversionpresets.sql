@@ -0,0 +1,35 @@
+-- name: InsertTemplateVersionPreset :one
+INSERT INTO
+	template_version_presets (
+		id,
+		template_version_id,
+		name,
+		parameters,
+		created_at
+	)
+VALUES
+	($1, $2, $3, $4, $5) RETURNING *;
+
+-- name: GetTemplateVersionPresetByID :one
+SELECT
+	*
+FROM
+	template_version_presets
+WHERE
+	id = $1;
+
+-- name: GetTemplateVersionPresetsByTemplateVersionIDs :many
+SELECT
+	*
+FROM
+	template_version_presets
+WHERE
+	template_version_id = ANY(@ids :: uuid [ ])
+ORDER BY
+	template_version_id, name;
+
+-- name: DeleteTemplateVersionPresetsByTemplateVersionID :exec
+DELETE FROM
+	template_version_presets
+WHERE
+	template_version_id = $1;
@@ -0,0 +1,51 @@
+-- name: GetTemporaryRoleGrantsByUserID :many
+SELECT
+	*
+FROM
+	temporary_role_grants
+WHERE
+	user_id = $1
+ORDER BY
+	expires_at, role_name;
+
+-- name: GetExpiredTemporaryRoleGrants :many
+SELECT
+	*
+FROM
+	temporary_role_grants
+WHERE
+	expires_at <= @now :: timestamptz
+ORDER BY
+	expires_at;
+
+-- name: UpsertTemporaryRoleGrant :one
+INSERT INTO
+	temporary_role_grants (
+		id,
+		user_id,
+		organization_id,
+		role_name,
+		justification,
+		created_at,
+		expires_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (user_id, COALESCE(organization_id, '00000000-0000-0000-0000-000000000000'::uuid), role_name) DO UPDATE SET
+	justification = $5,
+	created_at = $6,
+	expires_at = $7
+RETURNING *;
+
+-- name: DeleteTemporaryRoleGrantByID :exec
+DELETE FROM temporary_role_grants WHERE id = $1;
+
+-- name: DeleteTemporaryRoleGrants :exec
+-- Deletes the grants of the given roles of a user, for example because the
+-- roles were removed. A NULL organization_id selects site wide roles.
+DELETE FROM
+	temporary_role_grants
+WHERE
+	user_id = @user_id
+	AND organization_id IS NOT DISTINCT FROM sqlc.narg('organization_id') :: uuid
+	AND role_name = ANY(@role_names :: text[]);
@@ -0,0 +1,17 @@
+-- name: DeleteUserLoginAttempts :exec
+DELETE FROM user_login_attempts WHERE user_id = $1;
+
+-- name: GetUserLoginAttempts :one
+SELECT * FROM user_login_attempts WHERE user_id = $1;
+
+-- name: UpsertUserFailedLoginAttempts :one
+-- Records a failed login and returns the number of consecutive failed logins
+-- of the user.
+INSERT INTO
+	user_login_attempts (user_id, failed_attempts, last_failed_at)
+VALUES
+	(@user_id, 1, @last_failed_at)
+ON CONFLICT (user_id) DO UPDATE SET
+	failed_attempts = user_login_attempts.failed_attempts + 1,
+	last_failed_at = @last_failed_at
+RETURNING failed_attempts;
@@ -0,0 +1,32 @@
+-- name: DeleteOldUserPasswordHistory :exec
+-- Keeps only the newest entries of the password history of a user.
+DELETE FROM
+	user_password_history
+WHERE
+	user_id = @user_id
+	AND id NOT IN (
+		SELECT id FROM user_password_history
+		WHERE user_id = @user_id
+		ORDER BY created_at DESC
+		LIMIT @keep :: int
+	);
+
+-- name: GetUserPasswordHistory :many
+-- Returns the newest entries of the password history of a user, starting with
+-- the current password.
+SELECT
+	*
+FROM
+	user_password_history
+WHERE
+	user_id = @user_id
+ORDER BY
+	created_at DESC
+LIMIT
+	NULLIF(@limit_opt :: int, 0);
+
+-- name: InsertUserPasswordHistory :exec
+INSERT INTO
+	user_password_history (id, user_id, hashed_password, created_at)
+VALUES
+	($1, $2, $3, $4);
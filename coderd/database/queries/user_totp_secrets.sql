@@ -0,0 +1,53 @@
+-- name: DeleteUserTOTPRecoveryCode :execrows
+-- Removes a recovery code so that it cannot be used again. No rows are
+-- affected if the code is not valid for the user.
+UPDATE
+	user_totp_secrets
+SET
+	hashed_recovery_codes = array_remove(hashed_recovery_codes, @hashed_recovery_code :: text)
+WHERE
+	user_id = @user_id
+	AND @hashed_recovery_code :: text = ANY(hashed_recovery_codes);
+
+-- name: DeleteUserTOTPSecret :exec
+DELETE FROM user_totp_secrets WHERE user_id = $1;
+
+-- name: GetUserTOTPSecret :one
+SELECT * FROM user_totp_secrets WHERE user_id = $1;
+
+-- name: UpdateUserTOTPSecretConfirmed :one
+UPDATE
+	user_totp_secrets
+SET
+	confirmed_at = @confirmed_at,
+	hashed_recovery_codes = @hashed_recovery_codes :: text[],
+	last_used_counter = @last_used_counter
+WHERE
+	user_id = @user_id
+RETURNING *;
+
+-- name: UpdateUserTOTPSecretLastUsedCounter :execrows
+-- Records the time step of an accepted code. No rows are affected if a code
+-- for the same or a later time step was already used.
+UPDATE
+	user_totp_secrets
+SET
+	last_used_counter = @last_used_counter
+WHERE
+	user_id = @user_id
+	AND last_used_counter < @last_used_counter;
+
+-- name: UpsertUserTOTPSecret :one
+-- Starts a new enrollment. An existing secret is replaced and has to be
+-- confirmed again.
+INSERT INTO
+	user_totp_secrets (user_id, secret, created_at)
+VALUES
+	($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE SET
+	secret = $2,
+	hashed_recovery_codes = '{}',
+	last_used_counter = 0,
+	created_at = $3,
+	confirmed_at = NULL
+RETURNING *;
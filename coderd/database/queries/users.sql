@@ -54,7 +54,9 @@ SELECT
 FROM
 	users
 WHERE
-	status = 'active'::user_status AND deleted = false;
+	status = 'active'::user_status AND deleted = false
+	-- Service accounts do not count towards seats.
+	AND is_service_account = false;
 
 -- name: InsertUser :one
 INSERT INTO
@@ -67,10 +69,11 @@ INSERT INTO
 		created_at,
 		updated_at,
 		rbac_roles,
-		login_type
+		login_type,
+		is_service_account
 	)
 VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING *;
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING *;
 
 -- name: UpdateUserProfile :one
 UPDATE
@@ -122,13 +125,14 @@ WHERE
 	id = $1;
 
 -- name: GetUsers :many
--- This will never return deleted users.
+-- This will never return deleted users or service accounts.
 SELECT
 	*, COUNT(*) OVER() AS count
 FROM
 	users
 WHERE
 	users.deleted = false
+	AND users.is_service_account = false
 	AND CASE
 		-- This allows using the last element on a page as effectively a cursor.
 		-- This is an important option for scripts that need to paginate without
@@ -225,7 +229,29 @@ SELECT
 	-- All user roles, including their org roles.
 	array_cat(
 		-- All users are members
-		array_append(users.rbac_roles, 'member'),
+		array_append(
+			ARRAY(
+				SELECT
+					site_roles
+				FROM
+					unnest(users.rbac_roles) AS site_roles
+				WHERE
+					-- Roles granted for a limited time are ignored once they
+					-- expire, even before they are removed.
+					NOT EXISTS (
+						SELECT
+							1
+						FROM
+							temporary_role_grants
+						WHERE
+							temporary_role_grants.user_id = users.id
+							AND temporary_role_grants.organization_id IS NULL
+							AND temporary_role_grants.role_name = site_roles
+							AND temporary_role_grants.expires_at <= NOW()
+					)
+			),
+			'member'
+		),
 		(
 			SELECT
 				-- The roles are returned as a flat array, org scoped and site side.
@@ -239,6 +265,17 @@ SELECT
 				) AS org_roles
 			WHERE
 				user_id = users.id
+				AND NOT EXISTS (
+					SELECT
+						1
+					FROM
+						temporary_role_grants
+					WHERE
+						temporary_role_grants.user_id = users.id
+						AND temporary_role_grants.organization_id = organization_members.organization_id
+						AND temporary_role_grants.role_name = org_roles
+						AND temporary_role_grants.expires_at <= NOW()
+				)
 		)
 	) :: text[] AS roles,
 	-- All groups the user is in.
@@ -0,0 +1,115 @@
+-- name: AcquireWorkspaceBulkJob :one
+-- Acquires the oldest job that is not completed and either not running, or
+-- running on a worker that stopped sending heartbeats.
+UPDATE
+	workspace_bulk_jobs
+SET
+	worker_id = @worker_id :: uuid,
+	heartbeat_at = @heartbeat_at :: timestamptz
+WHERE
+	id = (
+		SELECT
+			id
+		FROM
+			workspace_bulk_jobs AS nested
+		WHERE
+			nested.completed_at IS NULL
+			AND (
+				nested.worker_id IS NULL
+				OR nested.heartbeat_at < @stale_before :: timestamptz
+			)
+		ORDER BY
+			nested.created_at
+		FOR UPDATE
+		SKIP LOCKED
+		LIMIT
+			1
+	) RETURNING *;
+
+-- name: GetWorkspaceBulkJobByID :one
+SELECT
+	*
+FROM
+	workspace_bulk_jobs
+WHERE
+	id = $1;
+
+-- name: GetWorkspaceBulkJobResultsByJobID :many
+SELECT
+	workspace_bulk_job_results.job_id,
+	workspace_bulk_job_results.workspace_id,
+	workspace_bulk_job_results.status,
+	workspace_bulk_job_results.error,
+	workspace_bulk_job_results.build_id,
+	workspace_bulk_job_results.updated_at,
+	workspaces.name AS workspace_name,
+	users.username AS workspace_owner_username
+FROM
+	workspace_bulk_job_results
+	JOIN workspaces ON workspaces.id = workspace_bulk_job_results.workspace_id
+	JOIN users ON users.id = workspaces.owner_id
+WHERE
+	workspace_bulk_job_results.job_id = $1
+ORDER BY
+	users.username, workspaces.name;
+
+-- name: InsertWorkspaceBulkJob :one
+INSERT INTO
+	workspace_bulk_jobs (
+		id,
+		initiator_id,
+		initiator_ip,
+		initiator_scope,
+		initiator_scopes,
+		action,
+		query,
+		automatic_updates,
+		dormant,
+		concurrency,
+		created_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING *;
+
+-- name: InsertWorkspaceBulkJobResults :exec
+INSERT INTO
+	workspace_bulk_job_results (
+		job_id,
+		workspace_id,
+		updated_at
+	)
+SELECT
+	@job_id :: uuid AS job_id,
+	unnest(@workspace_ids :: uuid[]) AS workspace_id,
+	@updated_at :: timestamptz AS updated_at;
+
+-- name: UpdateWorkspaceBulkJobCompletedAtByID :exec
+UPDATE
+	workspace_bulk_jobs
+SET
+	completed_at = $2
+WHERE
+	id = $1;
+
+-- name: UpdateWorkspaceBulkJobHeartbeat :one
+-- Returns no rows once another worker took the job over.
+UPDATE
+	workspace_bulk_jobs
+SET
+	heartbeat_at = @heartbeat_at :: timestamptz
+WHERE
+	id = @id
+	AND worker_id = @worker_id :: uuid
+RETURNING *;
+
+-- name: UpdateWorkspaceBulkJobResult :exec
+UPDATE
+	workspace_bulk_job_results
+SET
+	status = $3,
+	error = $4,
+	build_id = $5,
+	updated_at = $6
+WHERE
+	job_id = $1
+	AND workspace_id = $2;
@@ -367,6 +367,8 @@ WHERE
 		'0001-01-01 00:00:00+00'::timestamptz, -- deleting_at
 		'never'::automatic_updates, -- automatic_updates
 		false, -- favorite
+		'{}'::jsonb, -- user_acl
+		'{}'::jsonb, -- group_acl
 		-- Extra columns added to `filtered_workspaces`
 		'', -- template_name
 		'00000000-0000-0000-0000-000000000000'::uuid, -- template_version_id
@@ -449,6 +451,32 @@ WHERE
 	AND deleted = false
 RETURNING *;
 
+-- name: UpdateWorkspaceOwner :one
+-- Transfers the workspace to the new owner. The favorite flag belongs to the
+-- previous owner, so it is cleared. The previous owner chose who the workspace
+-- is shared with, so sharing is revoked.
+UPDATE
+	workspaces
+SET
+	owner_id = @owner_id,
+	favorite = false,
+	user_acl = '{}',
+	group_acl = '{}',
+	updated_at = @updated_at
+WHERE
+	id = @id
+	AND deleted = false
+RETURNING *;
+
+-- name: UpdateWorkspaceACLByID :exec
+UPDATE
+	workspaces
+SET
+	group_acl = @group_acl,
+	user_acl = @user_acl
+WHERE
+	id = @id;
+
 -- name: UpdateWorkspaceAutostart :exec
 UPDATE
 	workspaces
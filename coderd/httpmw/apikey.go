@@ -406,7 +406,7 @@ func ExtractAPIKey(rw http.ResponseWriter, r *http.Request, cfg ExtractAPIKeyCon
 	// If the key is valid, we also fetch the user roles and status.
 	// The roles are used for RBAC authorize checks, and the status
 	// is to block 'suspended' users from accessing the platform.
-	actor, userStatus, err := UserRBACSubject(ctx, cfg.DB, key.UserID, rbac.ScopeName(key.Scope))
+	actor, userStatus, err := UserRBACSubject(ctx, cfg.DB, key.UserID, key.RBACScope())
 	if err != nil {
 		return write(http.StatusUnauthorized, codersdk.Response{
 			Message: internalErrorMessage,
@@ -500,6 +500,18 @@ func APITokenFromRequest(r *http.Request) string {
 	return ""
 }
 
+// OAuth2BearerTokenFromRequest returns the access token from the
+// "Authorization: Bearer" header used by OAuth2 clients (RFC 6750), falling
+// back to APITokenFromRequest. It is only used by OAuth2 provider endpoints so
+// bearer tokens meant for workspace apps are never consumed by Coder.
+func OAuth2BearerTokenFromRequest(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") && token != "" {
+		return token
+	}
+	return APITokenFromRequest(r)
+}
+
 // SplitAPIToken verifies the format of an API key and returns the split ID and
 // secret.
 //
@@ -18,11 +18,13 @@ import (
 )
 
 type authorizeParams struct {
-	clientID     string
-	redirectURL  *url.URL
-	responseType codersdk.OAuth2ProviderResponseType
-	scope        []string
-	state        string
+	clientID            string
+	codeChallenge       string
+	codeChallengeMethod codersdk.OAuth2PKCECodeChallengeMethod
+	redirectURL         *url.URL
+	responseType        codersdk.OAuth2ProviderResponseType
+	scope               []string
+	state               string
 }
 
 func extractAuthorizeParams(r *http.Request, callbackURL *url.URL) (authorizeParams, []codersdk.ValidationError, error) {
@@ -32,11 +34,20 @@ func extractAuthorizeParams(r *http.Request, callbackURL *url.URL) (authorizePar
 	p.RequiredNotEmpty("state", "response_type", "client_id")
 
 	params := authorizeParams{
-		clientID:     p.String(vals, "", "client_id"),
-		redirectURL:  p.RedirectURL(vals, callbackURL, "redirect_uri"),
-		responseType: httpapi.ParseCustom(p, vals, "", "response_type", httpapi.ParseEnum[codersdk.OAuth2ProviderResponseType]),
-		scope:        p.Strings(vals, []string{}, "scope"),
-		state:        p.String(vals, "", "state"),
+		clientID:      p.String(vals, "", "client_id"),
+		codeChallenge: p.String(vals, "", "code_challenge"),
+		redirectURL:   p.RedirectURL(vals, callbackURL, "redirect_uri"),
+		responseType:  httpapi.ParseCustom(p, vals, "", "response_type", httpapi.ParseEnum[codersdk.OAuth2ProviderResponseType]),
+		scope:         p.Strings(vals, []string{}, "scope"),
+		state:         p.String(vals, "", "state"),
+	}
+	// RFC 7636 defaults the method to "plain", which we do not support, so the
+	// method must be given explicitly with a challenge.
+	if params.codeChallenge != "" {
+		p.RequiredNotEmpty("code_challenge_method")
+		params.codeChallengeMethod = httpapi.ParseCustom(p, vals, "", "code_challenge_method", httpapi.ParseEnum[codersdk.OAuth2PKCECodeChallengeMethod])
+	} else {
+		_ = p.String(vals, "", "code_challenge_method")
 	}
 
 	// We add "redirected" when coming from the authorize page.
@@ -107,11 +118,13 @@ func Authorize(db database.Store, accessURL *url.URL) http.HandlerFunc {
 				// is received.  If the application does wait before exchanging the
 				// token (for example suppose they ask the user to confirm and the user
 				// has left) then they can just retry immediately and get a new code.
-				ExpiresAt:    dbtime.Now().Add(time.Duration(10) * time.Minute),
-				SecretPrefix: []byte(code.Prefix),
-				HashedSecret: []byte(code.Hashed),
-				AppID:        app.ID,
-				UserID:       apiKey.UserID,
+				ExpiresAt:           dbtime.Now().Add(time.Duration(10) * time.Minute),
+				SecretPrefix:        []byte(code.Prefix),
+				HashedSecret:        []byte(code.Hashed),
+				AppID:               app.ID,
+				UserID:              apiKey.UserID,
+				CodeChallenge:       params.codeChallenge,
+				CodeChallengeMethod: string(params.codeChallengeMethod),
 			})
 			if err != nil {
 				return xerrors.Errorf("insert oauth2 authorization code: %w", err)
@@ -0,0 +1,235 @@
+package identityprovider
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/site"
+)
+
+const (
+	// deviceCodeLifetime is how long the user has to approve a device.
+	deviceCodeLifetime = 10 * time.Minute
+	// devicePollInterval is the number of seconds devices wait between polls.
+	devicePollInterval = 5
+	// deviceVerifyPath is where users go to approve a device.
+	deviceVerifyPath = "/oauth2/device/verify"
+)
+
+type deviceAuthorizationParams struct {
+	clientID     string
+	clientSecret string
+}
+
+func extractDeviceAuthorizationParams(r *http.Request) (deviceAuthorizationParams, []codersdk.ValidationError, error) {
+	p := httpapi.NewQueryParamParser()
+	err := r.ParseForm()
+	if err != nil {
+		return deviceAuthorizationParams{}, nil, xerrors.Errorf("parse form: %w", err)
+	}
+
+	vals := r.Form
+	p.RequiredNotEmpty("client_id")
+	params := deviceAuthorizationParams{
+		clientID:     p.String(vals, "", "client_id"),
+		clientSecret: p.String(vals, "", "client_secret"),
+	}
+	// TODO: Ignoring scope for now, like the authorize endpoint.
+	_ = p.String(vals, "", "scope")
+
+	p.ErrorExcessParams(vals)
+	if len(p.Errors) > 0 {
+		return deviceAuthorizationParams{}, p.Errors, xerrors.Errorf("invalid form params: %w", p.Errors)
+	}
+	return params, nil, nil
+}
+
+// DeviceAuthorization starts the device authorization flow (RFC 8628) by
+// issuing a device code for the device to poll with and a user code for the
+// user to enter on the verification page.
+func DeviceAuthorization(db database.Store, accessURL *url.URL) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		app := httpmw.OAuth2ProviderApp(r)
+
+		params, validationErrs, err := extractDeviceAuthorizationParams(r)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message:     "Invalid form params.",
+				Detail:      err.Error(),
+				Validations: validationErrs,
+			})
+			return
+		}
+		_, err = optionalClientSecret(ctx, db, app, params.clientSecret)
+		if errors.Is(err, errBadSecret) {
+			httpapi.Write(ctx, rw, http.StatusUnauthorized, codersdk.Response{
+				Message: err.Error(),
+			})
+			return
+		}
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+
+		var (
+			deviceCode OAuth2ProviderAppSecret
+			userCode   string
+		)
+		// The user code is short, so retry a few times in case it collides
+		// with an existing one.
+		for i := 0; i < 3; i++ {
+			deviceCode, err = GenerateSecret()
+			if err != nil {
+				break
+			}
+			userCode, err = GenerateUserCode()
+			if err != nil {
+				break
+			}
+			//nolint:gocritic // There is no user yet so we must use the system.
+			_, err = db.InsertOAuth2ProviderAppDeviceCode(dbauthz.AsSystemRestricted(ctx), database.InsertOAuth2ProviderAppDeviceCodeParams{
+				ID:           uuid.New(),
+				CreatedAt:    dbtime.Now(),
+				ExpiresAt:    dbtime.Now().Add(deviceCodeLifetime),
+				SecretPrefix: []byte(deviceCode.Prefix),
+				HashedSecret: []byte(deviceCode.Hashed),
+				UserCode:     userCode,
+				AppID:        app.ID,
+			})
+			if !database.IsUniqueViolation(err) {
+				break
+			}
+		}
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Failed to generate OAuth2 device code.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+
+		verifyURL := accessURL.JoinPath(deviceVerifyPath)
+		verifyCompleteURL := *verifyURL
+		verifyCompleteURL.RawQuery = url.Values{"user_code": {FormatUserCode(userCode)}}.Encode()
+
+		httpapi.Write(ctx, rw, http.StatusOK, codersdk.OAuth2DeviceAuthorizationResponse{
+			DeviceCode:              deviceCode.Formatted,
+			UserCode:                FormatUserCode(userCode),
+			VerificationURI:         verifyURL.String(),
+			VerificationURIComplete: verifyCompleteURL.String(),
+			ExpiresIn:               int64(deviceCodeLifetime.Seconds()),
+			Interval:                devicePollInterval,
+		})
+	}
+}
+
+// DeviceVerify is the page where users enter the user code shown by a device
+// and approve it. Like Authorize, the approval is only accepted when the user
+// clicked "allow" on this same page, which is detected via the referer.
+func DeviceVerify(db database.Store, accessURL *url.URL) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		apiKey := httpmw.APIKey(r)
+		ua := httpmw.UserAuthorization(r)
+
+		cameFromSelf, ok := cameFromPage(rw, r, accessURL, deviceVerifyPath)
+		if !ok {
+			return
+		}
+
+		userCode := NormalizeUserCode(r.URL.Query().Get("user_code"))
+		if userCode == "" {
+			site.RenderOAuthAllowPage(rw, r, site.RenderOAuthAllowData{
+				CancelURI:     accessURL.String(),
+				RedirectURI:   deviceVerifyPath,
+				Username:      ua.FriendlyName,
+				EnterUserCode: true,
+			})
+			return
+		}
+
+		//nolint:gocritic // The user cannot read codes they have not approved.
+		dbCode, err := db.GetOAuth2ProviderAppDeviceCodeByUserCode(dbauthz.AsSystemRestricted(ctx), userCode)
+		if err == nil && (dbCode.UserID.Valid || dbCode.ExpiresAt.Before(dbtime.Now())) {
+			err = sql.ErrNoRows
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			site.RenderStaticErrorPage(rw, r, site.ErrorPageData{
+				Status:               http.StatusNotFound,
+				HideStatus:           false,
+				Title:                "Invalid code",
+				Description:          "The code is invalid or has expired. Restart the login on your device to get a new code.",
+				RetryEnabled:         false,
+				DashboardURL:         accessURL.String(),
+				AdditionalButtonLink: deviceVerifyPath,
+				AdditionalButtonText: "Enter another code",
+			})
+			return
+		}
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+
+		//nolint:gocritic // Users can approve devices for any app, like authorize.
+		app, err := db.GetOAuth2ProviderAppByID(dbauthz.AsSystemRestricted(ctx), dbCode.AppID)
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+
+		// If we were redirected here from this same page it means the user
+		// pressed the allow button.
+		if cameFromSelf && r.URL.Query().Get("redirected") != "" {
+			_, err = db.UpdateOAuth2ProviderAppDeviceCodeByID(ctx, database.UpdateOAuth2ProviderAppDeviceCodeByIDParams{
+				ID:     dbCode.ID,
+				UserID: uuid.NullUUID{UUID: apiKey.UserID, Valid: true},
+			})
+			if err != nil {
+				httpapi.InternalServerError(rw, err)
+				return
+			}
+			site.RenderStaticErrorPage(rw, r, site.ErrorPageData{
+				Status:       http.StatusOK,
+				HideStatus:   true,
+				Title:        "Device connected",
+				Description:  "You can close this page and return to your device.",
+				RetryEnabled: false,
+				DashboardURL: accessURL.String(),
+			})
+			return
+		}
+		if r.URL.Query().Get("redirected") != "" {
+			renderRedirectError(rw, r, accessURL)
+			return
+		}
+
+		redirect := *r.URL
+		vals := redirect.Query()
+		vals.Set("user_code", FormatUserCode(userCode))
+		vals.Add("redirected", "true") // For loop detection.
+		redirect.RawQuery = vals.Encode()
+		site.RenderOAuthAllowPage(rw, r, site.RenderOAuthAllowData{
+			AppIcon:     app.Icon,
+			AppName:     app.Name,
+			CancelURI:   accessURL.String(),
+			RedirectURI: redirect.String(),
+			Username:    ua.FriendlyName,
+			UserCode:    FormatUserCode(userCode),
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package identityprovider
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// Discovery serves the OAuth2 authorization server metadata (RFC 8414) so
+// clients can find the provider endpoints. Coder is not an OpenID provider and
+// does not issue ID tokens, so clients have to call the userinfo endpoint with
+// the access token to learn about the user.
+func Discovery(accessURL *url.URL) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		httpapi.Write(r.Context(), rw, http.StatusOK, codersdk.OAuth2ProviderMetadata{
+			Issuer:                      accessURL.String(),
+			AuthorizationEndpoint:       accessURL.JoinPath("/oauth2/authorize").String(),
+			TokenEndpoint:               accessURL.JoinPath("/oauth2/tokens").String(),
+			DeviceAuthorizationEndpoint: accessURL.JoinPath("/oauth2/device").String(),
+			IntrospectionEndpoint:       accessURL.JoinPath("/oauth2/introspect").String(),
+			UserInfoEndpoint:            accessURL.JoinPath("/oauth2/userinfo").String(),
+			ResponseTypesSupported:      []string{string(codersdk.OAuth2ProviderResponseTypeCode)},
+			GrantTypesSupported: []string{
+				string(codersdk.OAuth2ProviderGrantTypeAuthorizationCode),
+				string(codersdk.OAuth2ProviderGrantTypeRefreshToken),
+				string(codersdk.OAuth2ProviderGrantTypeClientCredentials),
+				string(codersdk.OAuth2ProviderGrantTypeDeviceCode),
+			},
+			CodeChallengeMethodsSupported:     []string{string(codersdk.OAuth2PKCECodeChallengeMethodS256)},
+			TokenEndpointAuthMethodsSupported: []string{"client_secret_post", "none"},
+		})
+	}
+}
+
+// UserInfo returns the standard claims of the user that owns the access token.
+func UserInfo(db database.Store) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		apiKey := httpmw.APIKey(r)
+
+		// Tokens limited to a few permissions may not be able to read their
+		// own user, but the claims only ever describe the token owner.
+		//nolint:gocritic // See above.
+		user, err := db.GetUserByID(dbauthz.AsSystemRestricted(ctx), apiKey.UserID)
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+
+		httpapi.Write(ctx, rw, http.StatusOK, codersdk.OAuth2UserInfo{
+			Sub:               user.ID.String(),
+			Name:              user.Name,
+			PreferredUsername: user.Username,
+			Email:             user.Email,
+			// Coder does not verify emails itself, so only emails that come
+			// from an external identity provider are considered verified.
+			EmailVerified: user.LoginType == database.LoginTypeOIDC || user.LoginType == database.LoginTypeGithub,
+			Picture:       user.AvatarURL,
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package identityprovider
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+type introspectParams struct {
+	clientID     string
+	clientSecret string
+	token        string
+}
+
+func extractIntrospectParams(r *http.Request) (introspectParams, []codersdk.ValidationError, error) {
+	p := httpapi.NewQueryParamParser()
+	err := r.ParseForm()
+	if err != nil {
+		return introspectParams{}, nil, xerrors.Errorf("parse form: %w", err)
+	}
+
+	vals := r.Form
+	p.RequiredNotEmpty("client_id", "client_secret", "token")
+	params := introspectParams{
+		clientID:     p.String(vals, "", "client_id"),
+		clientSecret: p.String(vals, "", "client_secret"),
+		token:        p.String(vals, "", "token"),
+	}
+	// Only access tokens can be introspected, so the hint does not matter.
+	_ = p.String(vals, "", "token_type_hint")
+
+	p.ErrorExcessParams(vals)
+	if len(p.Errors) > 0 {
+		return introspectParams{}, p.Errors, xerrors.Errorf("invalid form params: %w", p.Errors)
+	}
+	return params, nil, nil
+}
+
+// Introspect lets an app check whether an access token it was given is still
+// active (RFC 7662). Apps can only introspect their own tokens, any other
+// token is reported as inactive.
+func Introspect(db database.Store) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		app := httpmw.OAuth2ProviderApp(r)
+
+		params, validationErrs, err := extractIntrospectParams(r)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message:     "Invalid form params.",
+				Detail:      err.Error(),
+				Validations: validationErrs,
+			})
+			return
+		}
+
+		_, err = validateClientSecret(ctx, db, app, params.clientSecret)
+		if errors.Is(err, errBadSecret) {
+			httpapi.Write(ctx, rw, http.StatusUnauthorized, codersdk.Response{
+				Message: err.Error(),
+			})
+			return
+		}
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+
+		introspection, err := introspectToken(r, db, app, params.token)
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+		httpapi.Write(ctx, rw, http.StatusOK, introspection)
+	}
+}
+
+func introspectToken(r *http.Request, db database.Store, app database.OAuth2ProviderApp, token string) (codersdk.OAuth2TokenIntrospection, error) {
+	ctx := r.Context()
+	inactive := codersdk.OAuth2TokenIntrospection{Active: false}
+
+	keyID, keySecret, err := httpmw.SplitAPIToken(token)
+	if err != nil {
+		return inactive, nil
+	}
+	//nolint:gocritic // The app is not a user, so we must use the system.
+	key, err := db.GetAPIKeyByID(dbauthz.AsSystemRestricted(ctx), keyID)
+	if httpapi.Is404Error(err) {
+		return inactive, nil
+	}
+	if err != nil {
+		return inactive, xerrors.Errorf("get api key: %w", err)
+	}
+	hashedSecret := sha256.Sum256([]byte(keySecret))
+	if subtle.ConstantTimeCompare(key.HashedSecret, hashedSecret[:]) != 1 {
+		return inactive, nil
+	}
+	if key.ExpiresAt.Before(dbtime.Now()) {
+		return inactive, nil
+	}
+
+	//nolint:gocritic // The app is not a user, so we must use the system.
+	dbToken, err := db.GetOAuth2ProviderAppTokenByAPIKeyID(dbauthz.AsSystemRestricted(ctx), key.ID)
+	if httpapi.Is404Error(err) {
+		return inactive, nil
+	}
+	if err != nil {
+		return inactive, xerrors.Errorf("get oauth2 app token: %w", err)
+	}
+	if dbToken.AppID != app.ID {
+		return inactive, nil
+	}
+
+	//nolint:gocritic // The app is not a user, so we must use the system.
+	user, err := db.GetUserByID(dbauthz.AsSystemRestricted(ctx), key.UserID)
+	if err != nil {
+		return inactive, xerrors.Errorf("get user: %w", err)
+	}
+	if user.Deleted || user.Status == database.UserStatusSuspended {
+		return inactive, nil
+	}
+
+	scope := string(key.Scope)
+	if len(key.Scopes) > 0 {
+		scope = strings.Join(key.Scopes, " ")
+	}
+	return codersdk.OAuth2TokenIntrospection{
+		Active:    true,
+		Scope:     scope,
+		ClientID:  app.ID.String(),
+		Username:  user.Username,
+		TokenType: "Bearer",
+		Exp:       key.ExpiresAt.Unix(),
+		Iat:       key.CreatedAt.Unix(),
+		Sub:       user.ID.String(),
+	}, nil
+}
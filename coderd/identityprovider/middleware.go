@@ -15,37 +15,14 @@ import (
 func authorizeMW(accessURL *url.URL) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-			origin := r.Header.Get(httpmw.OriginHeader)
-			originU, err := url.Parse(origin)
-			if err != nil {
-				httpapi.Write(r.Context(), rw, http.StatusBadRequest, codersdk.Response{
-					Message: "Invalid origin header.",
-					Detail:  err.Error(),
-				})
-				return
-			}
-
-			referer := r.Referer()
-			refererU, err := url.Parse(referer)
-			if err != nil {
-				httpapi.Write(r.Context(), rw, http.StatusBadRequest, codersdk.Response{
-					Message: "Invalid referer header.",
-					Detail:  err.Error(),
-				})
+			cameFromSelf, ok := cameFromPage(rw, r, accessURL, "/oauth2/authorize")
+			if !ok {
 				return
 			}
 
 			app := httpmw.OAuth2ProviderApp(r)
 			ua := httpmw.UserAuthorization(r)
 
-			// url.Parse() allows empty URLs, which is fine because the origin is not
-			// always set by browsers (or other tools like cURL).  If the origin does
-			// exist, we will make sure it matches.  We require `referer` to be set at
-			// a minimum in order to detect whether "allow" has been pressed, however.
-			cameFromSelf := (origin == "" || originU.Hostname() == accessURL.Hostname()) &&
-				refererU.Hostname() == accessURL.Hostname() &&
-				refererU.Path == "/oauth2/authorize"
-
 			// If we were redirected here from this same page it means the user
 			// pressed the allow button so defer to the authorize handler which
 			// generates the code, otherwise show the HTML allow page.
@@ -60,34 +37,7 @@ func authorizeMW(accessURL *url.URL) func(next http.Handler) http.Handler {
 			// in a future PR we should support a cURL-based flow where we output text
 			// instead of HTML.
 			if r.URL.Query().Get("redirected") != "" {
-				// When the user first comes into the page, referer might be blank which
-				// is OK.  But if they click "allow" and their browser has *still* not
-				// sent the referer header, we have no way of telling whether they
-				// actually clicked the button.  "Redirected" means they *might* have
-				// pressed it, but it could also mean an app added it for them as part
-				// of their redirect, so we cannot use it as a replacement for referer
-				// and the best we can do is error.
-				if referer == "" {
-					site.RenderStaticErrorPage(rw, r, site.ErrorPageData{
-						Status:       http.StatusInternalServerError,
-						HideStatus:   false,
-						Title:        "Referer header missing",
-						Description:  "We cannot continue authorization because your client has not sent the referer header.",
-						RetryEnabled: false,
-						DashboardURL: accessURL.String(),
-						Warnings:     nil,
-					})
-					return
-				}
-				site.RenderStaticErrorPage(rw, r, site.ErrorPageData{
-					Status:       http.StatusInternalServerError,
-					HideStatus:   false,
-					Title:        "Oauth Redirect Loop",
-					Description:  "Oauth redirect loop detected.",
-					RetryEnabled: false,
-					DashboardURL: accessURL.String(),
-					Warnings:     nil,
-				})
+				renderRedirectError(rw, r, accessURL)
 				return
 			}
 
@@ -147,3 +97,68 @@ func authorizeMW(accessURL *url.URL) func(next http.Handler) http.Handler {
 		})
 	}
 }
+
+// cameFromPage reports whether the request was made by following a link on the
+// page with the given path, which is how we detect that "allow" was pressed.
+// It writes an error and returns false if the headers cannot be parsed.
+func cameFromPage(rw http.ResponseWriter, r *http.Request, accessURL *url.URL, path string) (cameFromSelf bool, ok bool) {
+	origin := r.Header.Get(httpmw.OriginHeader)
+	originU, err := url.Parse(origin)
+	if err != nil {
+		httpapi.Write(r.Context(), rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid origin header.",
+			Detail:  err.Error(),
+		})
+		return false, false
+	}
+
+	refererU, err := url.Parse(r.Referer())
+	if err != nil {
+		httpapi.Write(r.Context(), rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid referer header.",
+			Detail:  err.Error(),
+		})
+		return false, false
+	}
+
+	// url.Parse() allows empty URLs, which is fine because the origin is not
+	// always set by browsers (or other tools like cURL).  If the origin does
+	// exist, we will make sure it matches.  We require `referer` to be set at
+	// a minimum in order to detect whether "allow" has been pressed, however.
+	return (origin == "" || originU.Hostname() == accessURL.Hostname()) &&
+		refererU.Hostname() == accessURL.Hostname() &&
+		refererU.Path == path, true
+}
+
+// renderRedirectError renders an error page for requests that claim to come
+// from the allow page but did not pass the referer check.
+func renderRedirectError(rw http.ResponseWriter, r *http.Request, accessURL *url.URL) {
+	// When the user first comes into the page, referer might be blank which
+	// is OK.  But if they click "allow" and their browser has *still* not
+	// sent the referer header, we have no way of telling whether they
+	// actually clicked the button.  "Redirected" means they *might* have
+	// pressed it, but it could also mean an app added it for them as part
+	// of their redirect, so we cannot use it as a replacement for referer
+	// and the best we can do is error.
+	if r.Referer() == "" {
+		site.RenderStaticErrorPage(rw, r, site.ErrorPageData{
+			Status:       http.StatusInternalServerError,
+			HideStatus:   false,
+			Title:        "Referer header missing",
+			Description:  "We cannot continue authorization because your client has not sent the referer header.",
+			RetryEnabled: false,
+			DashboardURL: accessURL.String(),
+			Warnings:     nil,
+		})
+		return
+	}
+	site.RenderStaticErrorPage(rw, r, site.ErrorPageData{
+		Status:       http.StatusInternalServerError,
+		HideStatus:   false,
+		Title:        "Oauth Redirect Loop",
+		Description:  "Oauth redirect loop detected.",
+		RetryEnabled: false,
+		DashboardURL: accessURL.String(),
+		Warnings:     nil,
+	})
+}
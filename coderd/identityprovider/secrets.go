@@ -75,3 +75,37 @@ func parseSecret(secret string) (parsedSecret, error) {
 	}
 	return parsedSecret{parts[1], parts[2]}, nil
 }
+
+// userCodeCharset excludes vowels to avoid spelling words and characters that
+// are easily confused, as recommended by RFC 8628 section 6.1.
+const userCodeCharset = "BCDFGHJKLMNPQRSTVWXZ"
+
+// userCodeLength gives 20^8 (about 2^34.5) possible codes, which is plenty
+// for a code that is only valid for a few minutes.
+const userCodeLength = 8
+
+// GenerateUserCode generates the short code a user enters to approve a device.
+// It is returned without separators, use FormatUserCode to display it.
+func GenerateUserCode() (string, error) {
+	return cryptorand.StringCharset(userCodeCharset, userCodeLength)
+}
+
+// NormalizeUserCode removes the separators and casing users may add when
+// typing in a user code so it can be looked up.
+func NormalizeUserCode(code string) string {
+	code = strings.ToUpper(code)
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(userCodeCharset, r) {
+			return r
+		}
+		return -1
+	}, code)
+}
+
+// FormatUserCode splits a user code in two halves to make it easier to read.
+func FormatUserCode(code string) string {
+	if len(code) != userCodeLength {
+		return code
+	}
+	return code[:userCodeLength/2] + "-" + code[userCodeLength/2:]
+}
@@ -2,11 +2,15 @@ package identityprovider
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
 	"database/sql"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/google/uuid"
 	"golang.org/x/oauth2"
@@ -30,15 +34,47 @@ var (
 	errBadCode = xerrors.New("Invalid code")
 	// errBadToken means the user provided a bad token.
 	errBadToken = xerrors.New("Invalid token")
+	// errBadCodeVerifier means the PKCE code verifier does not match the
+	// challenge sent when the code was requested.
+	errBadCodeVerifier = xerrors.New("Invalid code verifier")
+	// errNoServiceAccount means the app is not allowed to use the client
+	// credentials grant.
+	errNoServiceAccount = xerrors.New("App does not have a service account")
+
+	// errAuthorizationPending means the user has not approved the device yet.
+	errAuthorizationPending = oauth2Error{
+		Code:        "authorization_pending",
+		Description: "The user has not approved the device yet.",
+	}
+	// errExpiredToken means the device code expired before it was approved.
+	errExpiredToken = oauth2Error{
+		Code:        "expired_token",
+		Description: "The device code has expired.",
+	}
 )
 
+// oauth2Error is an error response as defined in RFC 6749 section 5.2. Device
+// clients rely on the error code to know whether they should keep polling, so
+// these cannot be written as a codersdk.Response.
+type oauth2Error struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e oauth2Error) Error() string {
+	return e.Description
+}
+
 type tokenParams struct {
 	clientID     string
 	clientSecret string
 	code         string
+	codeVerifier string
+	deviceCode   string
 	grantType    codersdk.OAuth2ProviderGrantType
 	redirectURL  *url.URL
 	refreshToken string
+	scopes       []string
 }
 
 func extractTokenParams(r *http.Request, callbackURL *url.URL) (tokenParams, []codersdk.ValidationError, error) {
@@ -55,16 +91,25 @@ func extractTokenParams(r *http.Request, callbackURL *url.URL) (tokenParams, []c
 	case codersdk.OAuth2ProviderGrantTypeRefreshToken:
 		p.RequiredNotEmpty("refresh_token")
 	case codersdk.OAuth2ProviderGrantTypeAuthorizationCode:
-		p.RequiredNotEmpty("client_secret", "client_id", "code")
+		// The secret can be omitted by public clients that use PKCE instead.
+		p.RequiredNotEmpty("client_id", "code")
+	case codersdk.OAuth2ProviderGrantTypeClientCredentials:
+		p.RequiredNotEmpty("client_secret", "client_id", "scope")
+	case codersdk.OAuth2ProviderGrantTypeDeviceCode:
+		p.RequiredNotEmpty("client_id", "device_code")
 	}
 
 	params := tokenParams{
 		clientID:     p.String(vals, "", "client_id"),
 		clientSecret: p.String(vals, "", "client_secret"),
 		code:         p.String(vals, "", "code"),
+		codeVerifier: p.String(vals, "", "code_verifier"),
+		deviceCode:   p.String(vals, "", "device_code"),
 		grantType:    grantType,
 		redirectURL:  p.RedirectURL(vals, callbackURL, "redirect_uri"),
 		refreshToken: p.String(vals, "", "refresh_token"),
+		// Scopes are space separated as per RFC 6749 section 3.3.
+		scopes: strings.Fields(p.String(vals, "", "scope")),
 	}
 
 	p.ErrorExcessParams(vals)
@@ -103,13 +148,15 @@ func Tokens(db database.Store, lifetimes codersdk.SessionLifetime) http.HandlerF
 		}
 
 		var token oauth2.Token
-		//nolint:gocritic,revive // More cases will be added later.
 		switch params.grantType {
-		// TODO: Client creds, device code.
 		case codersdk.OAuth2ProviderGrantTypeRefreshToken:
 			token, err = refreshTokenGrant(ctx, db, app, lifetimes, params)
 		case codersdk.OAuth2ProviderGrantTypeAuthorizationCode:
 			token, err = authorizationCodeGrant(ctx, db, app, lifetimes, params)
+		case codersdk.OAuth2ProviderGrantTypeClientCredentials:
+			token, err = clientCredentialsGrant(ctx, db, app, lifetimes, params)
+		case codersdk.OAuth2ProviderGrantTypeDeviceCode:
+			token, err = deviceCodeGrant(ctx, db, app, lifetimes, params)
 		default:
 			// Grant types are validated by the parser, so getting through here means
 			// the developer added a type but forgot to add a case here.
@@ -120,7 +167,13 @@ func Tokens(db database.Store, lifetimes codersdk.SessionLifetime) http.HandlerF
 			return
 		}
 
-		if errors.Is(err, errBadCode) || errors.Is(err, errBadSecret) {
+		var oauthErr oauth2Error
+		if errors.As(err, &oauthErr) {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, oauthErr)
+			return
+		}
+		if errors.Is(err, errBadCode) || errors.Is(err, errBadSecret) || errors.Is(err, errBadToken) ||
+			errors.Is(err, errBadCodeVerifier) || errors.Is(err, errNoServiceAccount) {
 			httpapi.Write(r.Context(), rw, http.StatusUnauthorized, codersdk.Response{
 				Message: err.Error(),
 			})
@@ -140,28 +193,53 @@ func Tokens(db database.Store, lifetimes codersdk.SessionLifetime) http.HandlerF
 	}
 }
 
-func authorizationCodeGrant(ctx context.Context, db database.Store, app database.OAuth2ProviderApp, lifetimes codersdk.SessionLifetime, params tokenParams) (oauth2.Token, error) {
-	// Validate the client secret.
-	secret, err := parseSecret(params.clientSecret)
+// validateClientSecret checks that the secret is valid and belongs to the app.
+func validateClientSecret(ctx context.Context, db database.Store, app database.OAuth2ProviderApp, clientSecret string) (database.OAuth2ProviderAppSecret, error) {
+	secret, err := parseSecret(clientSecret)
 	if err != nil {
-		return oauth2.Token{}, errBadSecret
+		return database.OAuth2ProviderAppSecret{}, errBadSecret
 	}
 	//nolint:gocritic // Users cannot read secrets so we must use the system.
 	dbSecret, err := db.GetOAuth2ProviderAppSecretByPrefix(dbauthz.AsSystemRestricted(ctx), []byte(secret.prefix))
 	if errors.Is(err, sql.ErrNoRows) {
-		return oauth2.Token{}, errBadSecret
+		return database.OAuth2ProviderAppSecret{}, errBadSecret
 	}
 	if err != nil {
-		return oauth2.Token{}, err
+		return database.OAuth2ProviderAppSecret{}, err
 	}
 	equal, err := userpassword.Compare(string(dbSecret.HashedSecret), secret.secret)
 	if err != nil {
-		return oauth2.Token{}, xerrors.Errorf("unable to compare secret: %w", err)
+		return database.OAuth2ProviderAppSecret{}, xerrors.Errorf("unable to compare secret: %w", err)
 	}
-	if !equal {
-		return oauth2.Token{}, errBadSecret
+	if !equal || dbSecret.AppID != app.ID {
+		return database.OAuth2ProviderAppSecret{}, errBadSecret
+	}
+	return dbSecret, nil
+}
+
+// optionalClientSecret validates the secret if the client sent one. Public
+// clients cannot keep a secret, so they may leave it out in flows where the
+// client is proven some other way.
+func optionalClientSecret(ctx context.Context, db database.Store, app database.OAuth2ProviderApp, clientSecret string) (uuid.NullUUID, error) {
+	if clientSecret == "" {
+		return uuid.NullUUID{}, nil
+	}
+	dbSecret, err := validateClientSecret(ctx, db, app, clientSecret)
+	if err != nil {
+		return uuid.NullUUID{}, err
 	}
+	return uuid.NullUUID{UUID: dbSecret.ID, Valid: true}, nil
+}
+
+// verifyCodeChallenge checks a PKCE code verifier against the S256 challenge
+// (RFC 7636 section 4.6).
+func verifyCodeChallenge(challenge, verifier string) bool {
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
 
+func authorizationCodeGrant(ctx context.Context, db database.Store, app database.OAuth2ProviderApp, lifetimes codersdk.SessionLifetime, params tokenParams) (oauth2.Token, error) {
 	// Validate the authorization code.
 	code, err := parseSecret(params.code)
 	if err != nil {
@@ -175,11 +253,11 @@ func authorizationCodeGrant(ctx context.Context, db database.Store, app database
 	if err != nil {
 		return oauth2.Token{}, err
 	}
-	equal, err = userpassword.Compare(string(dbCode.HashedSecret), code.secret)
+	equal, err := userpassword.Compare(string(dbCode.HashedSecret), code.secret)
 	if err != nil {
 		return oauth2.Token{}, xerrors.Errorf("unable to compare code: %w", err)
 	}
-	if !equal {
+	if !equal || dbCode.AppID != app.ID {
 		return oauth2.Token{}, errBadCode
 	}
 
@@ -188,28 +266,169 @@ func authorizationCodeGrant(ctx context.Context, db database.Store, app database
 		return oauth2.Token{}, errBadCode
 	}
 
-	// Generate a refresh token.
+	// Validate the client. Confidential clients authenticate with their
+	// secret, public clients must have requested the code with PKCE.
+	if dbCode.CodeChallenge != "" {
+		if params.codeVerifier == "" || !verifyCodeChallenge(dbCode.CodeChallenge, params.codeVerifier) {
+			return oauth2.Token{}, errBadCodeVerifier
+		}
+	} else if params.clientSecret == "" {
+		return oauth2.Token{}, errBadSecret
+	}
+	appSecretID, err := optionalClientSecret(ctx, db, app, params.clientSecret)
+	if err != nil {
+		return oauth2.Token{}, err
+	}
+
+	return issueToken(ctx, db, app, lifetimes, issueTokenParams{
+		userID:      dbCode.UserID,
+		appSecretID: appSecretID,
+		refresh:     true,
+		consume: func(ctx context.Context, tx database.Store) error {
+			err := tx.DeleteOAuth2ProviderAppCodeByID(ctx, dbCode.ID)
+			if err != nil {
+				return xerrors.Errorf("delete oauth2 app code: %w", err)
+			}
+			return nil
+		},
+	})
+}
+
+func deviceCodeGrant(ctx context.Context, db database.Store, app database.OAuth2ProviderApp, lifetimes codersdk.SessionLifetime, params tokenParams) (oauth2.Token, error) {
+	appSecretID, err := optionalClientSecret(ctx, db, app, params.clientSecret)
+	if err != nil {
+		return oauth2.Token{}, err
+	}
+
+	// Validate the device code.
+	code, err := parseSecret(params.deviceCode)
+	if err != nil {
+		return oauth2.Token{}, errBadCode
+	}
+	//nolint:gocritic // There is no user yet so we must use the system.
+	dbCode, err := db.GetOAuth2ProviderAppDeviceCodeByPrefix(dbauthz.AsSystemRestricted(ctx), []byte(code.prefix))
+	if errors.Is(err, sql.ErrNoRows) {
+		return oauth2.Token{}, errBadCode
+	}
+	if err != nil {
+		return oauth2.Token{}, err
+	}
+	equal, err := userpassword.Compare(string(dbCode.HashedSecret), code.secret)
+	if err != nil {
+		return oauth2.Token{}, xerrors.Errorf("unable to compare code: %w", err)
+	}
+	if !equal || dbCode.AppID != app.ID {
+		return oauth2.Token{}, errBadCode
+	}
+
+	if dbCode.ExpiresAt.Before(dbtime.Now()) {
+		return oauth2.Token{}, errExpiredToken
+	}
+	if !dbCode.UserID.Valid {
+		return oauth2.Token{}, errAuthorizationPending
+	}
+
+	return issueToken(ctx, db, app, lifetimes, issueTokenParams{
+		userID:      dbCode.UserID.UUID,
+		appSecretID: appSecretID,
+		refresh:     true,
+		consume: func(ctx context.Context, tx database.Store) error {
+			err := tx.DeleteOAuth2ProviderAppDeviceCodeByID(ctx, dbCode.ID)
+			if err != nil {
+				return xerrors.Errorf("delete oauth2 app device code: %w", err)
+			}
+			return nil
+		},
+	})
+}
+
+func clientCredentialsGrant(ctx context.Context, db database.Store, app database.OAuth2ProviderApp, lifetimes codersdk.SessionLifetime, params tokenParams) (oauth2.Token, error) {
+	dbSecret, err := validateClientSecret(ctx, db, app, params.clientSecret)
+	if err != nil {
+		return oauth2.Token{}, err
+	}
+
+	if !app.ServiceAccountID.Valid {
+		return oauth2.Token{}, errNoServiceAccount
+	}
+	//nolint:gocritic // There is no user yet so we must use the system.
+	user, err := db.GetUserByID(dbauthz.AsSystemRestricted(ctx), app.ServiceAccountID.UUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return oauth2.Token{}, errNoServiceAccount
+	}
+	if err != nil {
+		return oauth2.Token{}, err
+	}
+	if !user.IsServiceAccount || user.Deleted || user.Status == database.UserStatusSuspended {
+		return oauth2.Token{}, errNoServiceAccount
+	}
+
+	// Service accounts can only ever hold scoped tokens.
+	_, err = rbac.ParseScopePermissions(params.scopes)
+	if err != nil {
+		return oauth2.Token{}, oauth2Error{
+			Code:        "invalid_scope",
+			Description: err.Error(),
+		}
+	}
+
+	// There is no user to ask again, so the app can just request a new token
+	// with its secret instead of refreshing.
+	return issueToken(ctx, db, app, lifetimes, issueTokenParams{
+		userID:      user.ID,
+		appSecretID: uuid.NullUUID{UUID: dbSecret.ID, Valid: true},
+		scopes:      params.scopes,
+		keep:        true,
+	})
+}
+
+type issueTokenParams struct {
+	userID      uuid.UUID
+	appSecretID uuid.NullUUID
+	scopes      []string
+	// refresh returns a refresh token along with the access token.
+	refresh bool
+	// keep leaves the previous tokens of the app and user valid until they
+	// expire. Machines may run many clients with the same credentials, which
+	// must not revoke each other's tokens.
+	keep bool
+	// consume runs in the same transaction that creates the token, and is used
+	// to delete the code that was exchanged.
+	consume func(ctx context.Context, tx database.Store) error
+}
+
+// issueToken generates an API key for the user and records it as an OAuth2
+// token of the app, replacing the previous token of the app and user unless
+// params.keep is set.
+func issueToken(ctx context.Context, db database.Store, app database.OAuth2ProviderApp, lifetimes codersdk.SessionLifetime, params issueTokenParams) (oauth2.Token, error) {
+	// Generate a refresh token. One is stored even when it is not returned
+	// since the token row requires it.
 	refreshToken, err := GenerateSecret()
 	if err != nil {
 		return oauth2.Token{}, err
 	}
 
 	// Generate the API key we will swap for the code.
-	// TODO: We are ignoring scopes for now.
-	tokenName := fmt.Sprintf("%s_%s_oauth_session_token", dbCode.UserID, app.ID)
+	tokenID := uuid.New()
+	// For now, we allow only one token per app and user at a time, unless the
+	// previous tokens are kept. Those are named after the grant instead.
+	tokenName := fmt.Sprintf("%s_%s_oauth_session_token", params.userID, app.ID)
+	if params.keep {
+		tokenName = fmt.Sprintf("%s_%s_%s_oauth_session_token", params.userID, app.ID, tokenID)
+	}
 	key, sessionToken, err := apikey.Generate(apikey.CreateParams{
-		UserID:          dbCode.UserID,
+		UserID:          params.userID,
 		LoginType:       database.LoginTypeOAuth2ProviderApp,
 		DefaultLifetime: lifetimes.DefaultDuration.Value(),
-		// For now, we allow only one token per app and user at a time.
-		TokenName: tokenName,
+		Scopes:          params.scopes,
+		TokenName:       tokenName,
 	})
 	if err != nil {
 		return oauth2.Token{}, err
 	}
 
 	// Grab the user roles so we can perform the exchange as the user.
-	actor, _, err := httpmw.UserRBACSubject(ctx, db, dbCode.UserID, rbac.ScopeAll)
+	actor, _, err := httpmw.UserRBACSubject(ctx, db, params.userID, rbac.ScopeAll)
 	if err != nil {
 		return oauth2.Token{}, xerrors.Errorf("fetch user actor: %w", err)
 	}
@@ -217,21 +436,25 @@ func authorizationCodeGrant(ctx context.Context, db database.Store, app database
 	// Do the actual token exchange in the database.
 	err = db.InTx(func(tx database.Store) error {
 		ctx := dbauthz.As(ctx, actor)
-		err = tx.DeleteOAuth2ProviderAppCodeByID(ctx, dbCode.ID)
-		if err != nil {
-			return xerrors.Errorf("delete oauth2 app code: %w", err)
+		if params.consume != nil {
+			err := params.consume(ctx, tx)
+			if err != nil {
+				return err
+			}
 		}
 
 		// Delete the previous key, if any.
-		prevKey, err := tx.GetAPIKeyByName(ctx, database.GetAPIKeyByNameParams{
-			UserID:    dbCode.UserID,
-			TokenName: tokenName,
-		})
-		if err == nil {
-			err = tx.DeleteAPIKeyByID(ctx, prevKey.ID)
-		}
-		if err != nil && !errors.Is(err, sql.ErrNoRows) {
-			return xerrors.Errorf("delete api key by name: %w", err)
+		if !params.keep {
+			prevKey, err := tx.GetAPIKeyByName(ctx, database.GetAPIKeyByNameParams{
+				UserID:    params.userID,
+				TokenName: tokenName,
+			})
+			if err == nil {
+				err = tx.DeleteAPIKeyByID(ctx, prevKey.ID)
+			}
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return xerrors.Errorf("delete api key by name: %w", err)
+			}
 		}
 
 		newKey, err := tx.InsertAPIKey(ctx, key)
@@ -240,12 +463,13 @@ func authorizationCodeGrant(ctx context.Context, db database.Store, app database
 		}
 
 		_, err = tx.InsertOAuth2ProviderAppToken(ctx, database.InsertOAuth2ProviderAppTokenParams{
-			ID:          uuid.New(),
+			ID:          tokenID,
 			CreatedAt:   dbtime.Now(),
 			ExpiresAt:   key.ExpiresAt,
 			HashPrefix:  []byte(refreshToken.Prefix),
 			RefreshHash: []byte(refreshToken.Hashed),
-			AppSecretID: dbSecret.ID,
+			AppSecretID: params.appSecretID,
+			AppID:       app.ID,
 			APIKeyID:    newKey.ID,
 		})
 		if err != nil {
@@ -257,12 +481,15 @@ func authorizationCodeGrant(ctx context.Context, db database.Store, app database
 		return oauth2.Token{}, err
 	}
 
-	return oauth2.Token{
-		AccessToken:  sessionToken,
-		TokenType:    "Bearer",
-		RefreshToken: refreshToken.Formatted,
-		Expiry:       key.ExpiresAt,
-	}, nil
+	token := oauth2.Token{
+		AccessToken: sessionToken,
+		TokenType:   "Bearer",
+		Expiry:      key.ExpiresAt,
+	}
+	if params.refresh {
+		token.RefreshToken = refreshToken.Formatted
+	}
+	return token, nil
 }
 
 func refreshTokenGrant(ctx context.Context, db database.Store, app database.OAuth2ProviderApp, lifetimes codersdk.SessionLifetime, params tokenParams) (oauth2.Token, error) {
@@ -283,7 +510,7 @@ func refreshTokenGrant(ctx context.Context, db database.Store, app database.OAut
 	if err != nil {
 		return oauth2.Token{}, xerrors.Errorf("unable to compare token: %w", err)
 	}
-	if !equal {
+	if !equal || dbToken.AppID != app.ID {
 		return oauth2.Token{}, errBadToken
 	}
 
@@ -310,15 +537,13 @@ func refreshTokenGrant(ctx context.Context, db database.Store, app database.OAut
 		return oauth2.Token{}, err
 	}
 
-	// Generate the new API key.
-	// TODO: We are ignoring scopes for now.
-	tokenName := fmt.Sprintf("%s_%s_oauth_session_token", prevKey.UserID, app.ID)
+	// Generate the new API key. It takes the name of the key it replaces.
 	key, sessionToken, err := apikey.Generate(apikey.CreateParams{
 		UserID:          prevKey.UserID,
 		LoginType:       database.LoginTypeOAuth2ProviderApp,
 		DefaultLifetime: lifetimes.DefaultDuration.Value(),
-		// For now, we allow only one token per app and user at a time.
-		TokenName: tokenName,
+		Scopes:          prevKey.Scopes,
+		TokenName:       prevKey.TokenName,
 	})
 	if err != nil {
 		return oauth2.Token{}, err
@@ -344,6 +569,7 @@ func refreshTokenGrant(ctx context.Context, db database.Store, app database.OAut
 			HashPrefix:  []byte(refreshToken.Prefix),
 			RefreshHash: []byte(refreshToken.Hashed),
 			AppSecretID: dbToken.AppSecretID,
+			AppID:       dbToken.AppID,
 			APIKeyID:    newKey.ID,
 		})
 		if err != nil {
@@ -0,0 +1,393 @@
+package coderd
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// ldapTimeout bounds every request made to the LDAP server during a login.
+const ldapTimeout = 10 * time.Second
+
+// errLDAPInvalidCredentials is returned when the username does not match
+// exactly one user in the directory or the password is incorrect.
+var errLDAPInvalidCredentials = xerrors.New("invalid ldap credentials")
+
+// LDAPConfig configures logging in with the username and password of an
+// account in an LDAP directory such as Active Directory.
+type LDAPConfig struct {
+	// URL is the address of the LDAP server, e.g. ldaps://ldap.example.com.
+	URL string
+	// StartTLS upgrades ldap:// connections to TLS before binding.
+	StartTLS bool
+	// TLSConfig is used for ldaps:// connections and StartTLS.
+	TLSConfig *tls.Config
+	// BindDN and BindPassword are the credentials of the account used to
+	// search for users. If BindDN is empty, an anonymous bind is used.
+	BindDN       string
+	BindPassword string
+	// UserSearchBaseDN is the subtree that is searched for users.
+	UserSearchBaseDN string
+	// UserSearchFilter must match exactly one entry for the user logging in.
+	// "{username}" is replaced with the escaped username.
+	UserSearchFilter string
+	// UsernameAttribute selects the attribute to be used as the created
+	// user's username.
+	UsernameAttribute string
+	// EmailAttribute selects the attribute to be used as the created user's
+	// email.
+	EmailAttribute string
+	// NameAttribute selects the attribute to be used as the created user's
+	// full / given name.
+	NameAttribute string
+	// GroupAttribute selects the attribute that lists the DNs of the groups
+	// of the user, e.g. memberOf. If the attribute is the empty string, then
+	// no group updates will ever come from the directory.
+	GroupAttribute string
+	// GroupNameAttribute opts in to naming groups by the value of the first
+	// component of their DN when it is this attribute, e.g. "developers" for
+	// "cn=developers,ou=groups,dc=example,dc=com" with "cn". Only set this if
+	// these values are unique across the directory. If it is the empty string,
+	// groups are named by their normalized DN.
+	GroupNameAttribute string
+	// CreateMissingGroups controls whether groups of the user are
+	// automatically created in Coder if they are missing.
+	CreateMissingGroups bool
+	// GroupFilter is a regular expression that filters the groups of the
+	// user. Any group not matched by this regex will be ignored.
+	GroupFilter *regexp.Regexp
+	// GroupAllowList is a list of groups that are allowed to log in.
+	// If the list length is 0, then the allow list will not be applied and
+	// this feature is disabled.
+	GroupAllowList map[string]bool
+	// GroupMapping controls how groups of the user get mapped to groups
+	// within Coder.
+	// map[ldapGroupName]coderGroupName
+	GroupMapping map[string]string
+	// UserRoleMapping controls how groups of the user get mapped to roles
+	// within Coder. Groups that are not in the mapping do not grant any role.
+	// map[ldapGroupName][]coderRoleName
+	UserRoleMapping map[string][]string
+	// UserRolesDefault is the default set of roles to assign to a user if role
+	// sync is enabled.
+	UserRolesDefault []string
+	AllowSignups     bool
+	// SignInText is the text to display on the LDAP login button.
+	SignInText string
+}
+
+func (cfg LDAPConfig) RoleSyncEnabled() bool {
+	return len(cfg.UserRoleMapping) > 0
+}
+
+// ldapUser is the entry of a user in the directory.
+type ldapUser struct {
+	DN       string
+	Username string
+	Email    string
+	Name     string
+	Groups   []string
+}
+
+// authenticate finds the entry of the user in the directory and verifies
+// their password by binding as them.
+func (cfg *LDAPConfig) authenticate(username, password string) (ldapUser, error) {
+	// An empty password would be an unauthenticated bind, which many
+	// servers accept for any DN.
+	if username == "" || password == "" {
+		return ldapUser{}, errLDAPInvalidCredentials
+	}
+
+	conn, err := ldap.DialURL(cfg.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: ldapTimeout}),
+		ldap.DialWithTLSConfig(cfg.TLSConfig),
+	)
+	if err != nil {
+		return ldapUser{}, xerrors.Errorf("dial: %w", err)
+	}
+	defer conn.Close()
+	conn.SetTimeout(ldapTimeout)
+
+	if cfg.StartTLS {
+		err = conn.StartTLS(cfg.TLSConfig)
+		if err != nil {
+			return ldapUser{}, xerrors.Errorf("start tls: %w", err)
+		}
+	}
+
+	if cfg.BindDN != "" {
+		err = conn.Bind(cfg.BindDN, cfg.BindPassword)
+	} else {
+		err = conn.UnauthenticatedBind("")
+	}
+	if err != nil {
+		return ldapUser{}, xerrors.Errorf("bind as search user: %w", err)
+	}
+
+	attributes := []string{cfg.UsernameAttribute, cfg.EmailAttribute, cfg.NameAttribute}
+	if cfg.GroupAttribute != "" {
+		attributes = append(attributes, cfg.GroupAttribute)
+	}
+	res, err := conn.Search(ldap.NewSearchRequest(
+		cfg.UserSearchBaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		// Request one more entry than needed to detect ambiguous filters.
+		2, int(ldapTimeout.Seconds()), false,
+		strings.ReplaceAll(cfg.UserSearchFilter, "{username}", ldap.EscapeFilter(username)),
+		attributes, nil,
+	))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return ldapUser{}, xerrors.Errorf("search for user: %w", err)
+	}
+	if res == nil || len(res.Entries) != 1 {
+		return ldapUser{}, errLDAPInvalidCredentials
+	}
+	entry := res.Entries[0]
+
+	err = conn.Bind(entry.DN, password)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return ldapUser{}, errLDAPInvalidCredentials
+	}
+	if err != nil {
+		return ldapUser{}, xerrors.Errorf("bind as user: %w", err)
+	}
+
+	user := ldapUser{
+		DN:       entry.DN,
+		Username: entry.GetAttributeValue(cfg.UsernameAttribute),
+		Email:    entry.GetAttributeValue(cfg.EmailAttribute),
+		Name:     entry.GetAttributeValue(cfg.NameAttribute),
+	}
+	if cfg.GroupAttribute != "" {
+		for _, value := range entry.GetAttributeValues(cfg.GroupAttribute) {
+			user.Groups = append(user.Groups, cfg.GroupName(value))
+		}
+	}
+	return user, nil
+}
+
+// GroupName returns the name of a group of a user from its DN. This is the
+// normalized DN, e.g. "cn=developers,ou=groups,dc=example,dc=com" for
+// "CN=developers, OU=groups, DC=example, DC=com", unless the first component
+// of the DN is the GroupNameAttribute. Values that are not a DN are returned
+// as is. The group names in the mappings and the allow list must be
+// normalized with this as well.
+func (cfg *LDAPConfig) GroupName(value string) string {
+	dn, err := ldap.ParseDN(value)
+	if err != nil || len(dn.RDNs) == 0 {
+		return value
+	}
+	first := dn.RDNs[0].Attributes
+	if cfg.GroupNameAttribute != "" && len(first) == 1 && strings.EqualFold(first[0].Type, cfg.GroupNameAttribute) {
+		return first[0].Value
+	}
+	return dn.String()
+}
+
+// ldapGroups returns the groups for the user from the directory.
+func (api *API) ldapGroups(ctx context.Context, entry ldapUser) (bool, []string, *httpError) {
+	// If the GroupAttribute is the empty string, then groups from LDAP are
+	// not used. This is so we can support manual group assignment.
+	if api.LDAPConfig.GroupAttribute == "" {
+		return false, nil, nil
+	}
+
+	api.Logger.Debug(ctx, "groups returned by ldap",
+		slog.F("len", len(entry.Groups)),
+		slog.F("groups", entry.Groups),
+	)
+	groups, inAllowList := mapGroups(entry.Groups, api.LDAPConfig.GroupMapping, api.LDAPConfig.GroupAllowList)
+	if !inAllowList {
+		api.Logger.Named(userAuthLoggerName).Debug(ctx, "ldap groups not in allow list, rejecting login",
+			slog.F("allow_list_count", len(api.LDAPConfig.GroupAllowList)),
+			slog.F("user_group_count", len(groups)),
+		)
+		return true, groups, groupAllowListError(groups)
+	}
+	return true, groups, nil
+}
+
+// ldapRoles returns the roles for the user from their groups in the directory.
+func (api *API) ldapRoles(entry ldapUser) []string {
+	roles := api.LDAPConfig.UserRolesDefault
+	if !api.LDAPConfig.RoleSyncEnabled() {
+		return roles
+	}
+
+	// Unlike OIDC role claims, group names are not role names, so only
+	// mapped groups grant roles.
+	mapped := make([]string, 0, len(entry.Groups))
+	for _, group := range entry.Groups {
+		if _, ok := api.LDAPConfig.UserRoleMapping[group]; ok {
+			mapped = append(mapped, group)
+		}
+	}
+	return mapRoles(roles, mapped, api.LDAPConfig.UserRoleMapping)
+}
+
+// Authenticates the user with the username and password of their account in
+// the LDAP directory.
+//
+// @Summary Log in user with LDAP
+// @ID log-in-user-with-ldap
+// @Accept json
+// @Produce json
+// @Tags Authorization
+// @Param request body codersdk.LoginWithLDAPRequest true "Login request"
+// @Success 201 {object} codersdk.LoginWithPasswordResponse
+// @Router /users/ldap/login [post]
+func (api *API) postLDAPLogin(rw http.ResponseWriter, r *http.Request) {
+	var (
+		// postLDAPLogin is a system function.
+		//nolint:gocritic
+		ctx               = dbauthz.AsSystemRestricted(r.Context())
+		auditor           = api.Auditor.Load()
+		logger            = api.Logger.Named(userAuthLoggerName)
+		aReq, commitAudit = audit.InitRequest[database.APIKey](rw, &audit.RequestParams{
+			Audit:   *auditor,
+			Log:     api.Logger,
+			Request: r,
+			Action:  database.AuditActionLogin,
+		})
+	)
+	aReq.Old = database.APIKey{}
+	defer commitAudit()
+
+	if api.LDAPConfig == nil {
+		httpapi.Write(ctx, rw, http.StatusPreconditionRequired, codersdk.Response{
+			Message: "LDAP authentication is not enabled.",
+		})
+		return
+	}
+
+	var req codersdk.LoginWithLDAPRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	entry, err := api.LDAPConfig.authenticate(req.Username, req.Password)
+	if xerrors.Is(err, errLDAPInvalidCredentials) {
+		httpapi.Write(ctx, rw, http.StatusUnauthorized, codersdk.Response{
+			Message: "Incorrect username or password.",
+		})
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "ldap: unable to authenticate user", slog.F("username", req.Username), slog.Error(err))
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to authenticate with LDAP.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	if entry.Email == "" {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Your LDAP account does not have an email address.",
+			Detail:  "Ask an administrator to set the email attribute of your account.",
+		})
+		return
+	}
+	username := entry.Username
+	if username == "" {
+		username = req.Username
+	}
+	if httpapi.NameValid(username) != nil {
+		username = httpapi.UsernameFrom(username)
+	}
+	name := httpapi.NormalizeRealUsername(entry.Name)
+
+	ctx = slog.With(ctx, slog.F("email", entry.Email), slog.F("username", username), slog.F("name", name))
+	usingGroups, groups, groupErr := api.ldapGroups(ctx, entry)
+	if groupErr != nil {
+		groupErr.renderStaticPage = false
+		groupErr.Write(rw, r)
+		return
+	}
+
+	user, link, err := findLinkedUser(ctx, api.Database, entry.DN, entry.Email)
+	if err != nil {
+		logger.Error(ctx, "ldap: unable to find linked user", slog.F("email", entry.Email), slog.Error(err))
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to find linked user.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	// If a new user is authenticating for the first time
+	// the audit action is 'register', not 'login'
+	if user.ID == uuid.Nil {
+		aReq.Action = database.AuditActionRegister
+	}
+
+	params := (&oauthLoginParams{
+		User:      user,
+		Link:      link,
+		LinkedID:  entry.DN,
+		LoginType: database.LoginTypeLDAP,
+		// There are no OAuth tokens to store in the user link.
+		State:               httpmw.OAuth2State{Token: &oauth2.Token{}},
+		AllowSignups:        api.LDAPConfig.AllowSignups,
+		Email:               entry.Email,
+		Username:            username,
+		Name:                name,
+		UsingRoles:          api.LDAPConfig.RoleSyncEnabled(),
+		Roles:               api.ldapRoles(entry),
+		UsingGroups:         usingGroups,
+		Groups:              groups,
+		CreateMissingGroups: api.LDAPConfig.CreateMissingGroups,
+		GroupFilter:         api.LDAPConfig.GroupFilter,
+	}).SetInitAuditRequest(func(params *audit.RequestParams) (*audit.Request[database.User], func()) {
+		return audit.InitRequest[database.User](rw, params)
+	})
+	cookies, key, err := api.oauthLogin(r, params)
+	defer params.CommitAuditLogs()
+	var httpErr httpError
+	if xerrors.As(err, &httpErr) {
+		// This endpoint is called by API clients, never by a browser
+		// redirect.
+		httpErr.renderStaticPage = false
+		httpErr.Write(rw, r)
+		return
+	}
+	if err != nil {
+		logger.Error(ctx, "ldap: login failed", slog.F("user", user.Username), slog.Error(err))
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to process LDAP login.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.New = key
+	aReq.UserID = key.UserID
+
+	var sessionToken string
+	for _, cookie := range cookies {
+		if cookie.Name == codersdk.SessionTokenCookie {
+			sessionToken = cookie.Value
+		}
+		http.SetCookie(rw, cookie)
+	}
+
+	httpapi.Write(ctx, rw, http.StatusCreated, codersdk.LoginWithPasswordResponse{
+		SessionToken: sessionToken,
+	})
+}
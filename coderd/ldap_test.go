@@ -0,0 +1,192 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/coderdtest/ldaptest"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestUserLDAP(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Signup", func(t *testing.T) {
+		t.Parallel()
+		fake := ldaptest.New(t)
+		fake.AddUser("alice", "alice-password", "alice@coder.com")
+		owner := coderdtest.New(t, &coderdtest.Options{
+			LDAPConfig: fake.LDAPConfig(),
+		})
+		_ = coderdtest.CreateFirstUser(t, owner)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		methods, err := owner.AuthMethods(ctx)
+		require.NoError(t, err)
+		require.True(t, methods.LDAP.Enabled)
+		require.Equal(t, "LDAP", methods.LDAP.SignInText)
+
+		client := codersdk.New(owner.URL)
+		res, err := client.LoginWithLDAP(ctx, codersdk.LoginWithLDAPRequest{
+			Username: "alice",
+			Password: "alice-password",
+		})
+		require.NoError(t, err)
+		client.SetSessionToken(res.SessionToken)
+
+		user, err := client.User(ctx, codersdk.Me)
+		require.NoError(t, err)
+		require.Equal(t, "alice", user.Username)
+		require.Equal(t, "alice@coder.com", user.Email)
+		require.Equal(t, codersdk.LoginTypeLDAP, user.LoginType)
+
+		// Logging in again uses the linked user.
+		_, err = client.LoginWithLDAP(ctx, codersdk.LoginWithLDAPRequest{
+			Username: "alice",
+			Password: "alice-password",
+		})
+		require.NoError(t, err)
+		users, err := owner.Users(ctx, codersdk.UsersRequest{})
+		require.NoError(t, err)
+		require.Len(t, users.Users, 2)
+	})
+
+	t.Run("InvalidCredentials", func(t *testing.T) {
+		t.Parallel()
+		fake := ldaptest.New(t)
+		fake.AddUser("alice", "alice-password", "alice@coder.com")
+		owner := coderdtest.New(t, &coderdtest.Options{
+			LDAPConfig: fake.LDAPConfig(),
+		})
+		_ = coderdtest.CreateFirstUser(t, owner)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		client := codersdk.New(owner.URL)
+		for _, req := range []codersdk.LoginWithLDAPRequest{
+			{Username: "alice", Password: "wrong"},
+			{Username: "bob", Password: "alice-password"},
+			// The filter must be escaped.
+			{Username: "*", Password: "alice-password"},
+		} {
+			var apiErr *codersdk.Error
+			_, err := client.LoginWithLDAP(ctx, req)
+			require.ErrorAs(t, err, &apiErr)
+			require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode())
+		}
+	})
+
+	t.Run("WrongLoginType", func(t *testing.T) {
+		t.Parallel()
+		fake := ldaptest.New(t)
+		owner := coderdtest.New(t, &coderdtest.Options{
+			LDAPConfig: fake.LDAPConfig(),
+		})
+		first := coderdtest.CreateFirstUser(t, owner)
+		_, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+		fake.AddUser("member", "member-password", user.Email)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		var apiErr *codersdk.Error
+		_, err := codersdk.New(owner.URL).LoginWithLDAP(ctx, codersdk.LoginWithLDAPRequest{
+			Username: "member",
+			Password: "member-password",
+		})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+	})
+
+	t.Run("SignupsDisabled", func(t *testing.T) {
+		t.Parallel()
+		fake := ldaptest.New(t)
+		fake.AddUser("alice", "alice-password", "alice@coder.com")
+		owner := coderdtest.New(t, &coderdtest.Options{
+			LDAPConfig: fake.LDAPConfig(func(cfg *coderd.LDAPConfig) {
+				cfg.AllowSignups = false
+			}),
+		})
+		first := coderdtest.CreateFirstUser(t, owner)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		client := codersdk.New(owner.URL)
+		req := codersdk.LoginWithLDAPRequest{
+			Username: "alice",
+			Password: "alice-password",
+		}
+		var apiErr *codersdk.Error
+		_, err := client.LoginWithLDAP(ctx, req)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+
+		// Users created by an admin can log in.
+		_, err = owner.CreateUser(ctx, codersdk.CreateUserRequest{
+			Email:          "alice@coder.com",
+			Username:       "alice",
+			UserLoginType:  codersdk.LoginTypeLDAP,
+			OrganizationID: first.OrganizationID,
+		})
+		require.NoError(t, err)
+		_, err = client.LoginWithLDAP(ctx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+		owner := coderdtest.New(t, nil)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		var apiErr *codersdk.Error
+		_, err := codersdk.New(owner.URL).LoginWithLDAP(ctx, codersdk.LoginWithLDAPRequest{
+			Username: "alice",
+			Password: "alice-password",
+		})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusPreconditionRequired, apiErr.StatusCode())
+	})
+}
+
+func TestLDAPGroupName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name          string
+		NameAttribute string
+		Value         string
+		Expected      string
+	}{
+		{
+			Name:     "NormalizedDN",
+			Value:    "CN=Developers, OU=Groups, DC=example, DC=com",
+			Expected: "cn=Developers,ou=Groups,dc=example,dc=com",
+		},
+		{
+			Name:     "NotDN",
+			Value:    "developers",
+			Expected: "developers",
+		},
+		{
+			Name:          "NameAttribute",
+			NameAttribute: "cn",
+			Value:         "CN=developers,OU=groups,DC=example,DC=com",
+			Expected:      "developers",
+		},
+		{
+			// Only groups named by the attribute are shortened.
+			Name:          "OtherAttribute",
+			NameAttribute: "cn",
+			Value:         "uid=developers,ou=groups,dc=example,dc=com",
+			Expected:      "uid=developers,ou=groups,dc=example,dc=com",
+		},
+	} {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			cfg := coderd.LDAPConfig{GroupNameAttribute: tc.NameAttribute}
+			require.Equal(t, tc.Expected, cfg.GroupName(tc.Value))
+		})
+	}
+}
@@ -43,6 +43,13 @@ func (api *API) postOrganizationMember(rw http.ResponseWriter, r *http.Request)
 	aReq.Old = database.AuditableOrganizationMember{}
 	defer commitAudit()
 
+	if user.IsServiceAccount {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Service accounts can only be members of the organization that owns them.",
+		})
+		return
+	}
+
 	member, err := api.Database.InsertOrganizationMember(ctx, database.InsertOrganizationMemberParams{
 		OrganizationID: organization.ID,
 		UserID:         user.ID,
@@ -177,18 +184,19 @@ func (api *API) listMembers(rw http.ResponseWriter, r *http.Request) {
 // @Router /organizations/{organization}/members/{user}/roles [put]
 func (api *API) putMemberRoles(rw http.ResponseWriter, r *http.Request) {
 	var (
-		ctx               = r.Context()
-		organization      = httpmw.OrganizationParam(r)
-		member            = httpmw.OrganizationMemberParam(r)
-		apiKey            = httpmw.APIKey(r)
-		auditor           = api.Auditor.Load()
-		aReq, commitAudit = audit.InitRequest[database.AuditableOrganizationMember](rw, &audit.RequestParams{
+		ctx          = r.Context()
+		organization = httpmw.OrganizationParam(r)
+		member       = httpmw.OrganizationMemberParam(r)
+		apiKey       = httpmw.APIKey(r)
+		auditor      = api.Auditor.Load()
+		auditParams  = &audit.RequestParams{
 			OrganizationID: organization.ID,
 			Audit:          *auditor,
 			Log:            api.Logger,
 			Request:        r,
 			Action:         database.AuditActionWrite,
-		})
+		}
+		aReq, commitAudit = audit.InitRequest[database.AuditableOrganizationMember](rw, auditParams)
 	)
 	aReq.Old = member.OrganizationMember.Auditable(member.Username)
 	defer commitAudit()
@@ -204,12 +212,25 @@ func (api *API) putMemberRoles(rw http.ResponseWriter, r *http.Request) {
 	if !httpapi.Read(ctx, rw, r, &params) {
 		return
 	}
-
-	updatedUser, err := api.Database.UpdateMemberRoles(ctx, database.UpdateMemberRolesParams{
-		GrantedRoles: params.Roles,
-		UserID:       member.UserID,
-		OrgID:        organization.ID,
-	})
+	if !validateTemporaryRoles(ctx, rw, params) {
+		return
+	}
+	auditParams.AdditionalFields = temporaryRolesAuditFields(params)
+
+	var updatedUser database.OrganizationMember
+	err := api.Database.InTx(func(tx database.Store) error {
+		var err error
+		updatedUser, err = tx.UpdateMemberRoles(ctx, database.UpdateMemberRolesParams{
+			GrantedRoles: grantedRoles(member.OrganizationMember.Roles, params),
+			UserID:       member.UserID,
+			OrgID:        organization.ID,
+		})
+		if err != nil {
+			return err
+		}
+		orgID := uuid.NullUUID{UUID: organization.ID, Valid: true}
+		return updateTemporaryRoles(ctx, tx, member.UserID, orgID, member.OrganizationMember.Roles, updatedUser.Roles, params)
+	}, nil)
 	if httpapi.Is404Error(err) {
 		httpapi.Forbidden(rw)
 		return
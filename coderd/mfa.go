@@ -0,0 +1,398 @@
+package coderd
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/totp"
+	"github.com/coder/coder/v2/coderd/userpassword"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// mfaEnrollmentScopes limit the session of a user that has to enroll in
+// multi-factor authentication before they can use the deployment.
+var mfaEnrollmentScopes = []string{"user:read", "user:read_personal", "user:update_personal"}
+
+// @Summary Get user MFA status
+// @ID get-user-mfa-status
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Users
+// @Param user path string true "User ID, name, or me"
+// @Success 200 {object} codersdk.UserMFAStatus
+// @Router /users/{user}/mfa [get]
+func (api *API) userMFAStatus(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	secret, err := api.Database.GetUserTOTPSecret(ctx, user.ID)
+	if err != nil && !xerrors.Is(err, sql.ErrNoRows) {
+		if dbauthz.IsNotAuthorizedError(err) {
+			httpapi.ResourceNotFound(rw)
+			return
+		}
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	status := codersdk.UserMFAStatus{}
+	if err == nil && secret.ConfirmedAt.Valid {
+		status.TOTPEnabled = true
+		status.RecoveryCodesRemaining = len(secret.HashedRecoveryCodes)
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, status)
+}
+
+// @Summary Enroll user in TOTP
+// @ID enroll-user-in-totp
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Users
+// @Param user path string true "User ID, name, or me"
+// @Success 201 {object} codersdk.TOTPEnrollment
+// @Router /users/{user}/mfa/totp [post]
+func (api *API) postUserTOTP(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		user   = httpmw.UserParam(r)
+		apiKey = httpmw.APIKey(r)
+	)
+
+	// Nobody else should ever see the secret, including admins.
+	if apiKey.UserID != user.ID {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "Users can only enroll themselves in multi-factor authentication.",
+		})
+		return
+	}
+	if user.LoginType != database.LoginTypePassword {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Multi-factor authentication is only available for users that log in with a password.",
+		})
+		return
+	}
+
+	existing, err := api.Database.GetUserTOTPSecret(ctx, user.ID)
+	if err != nil && !xerrors.Is(err, sql.ErrNoRows) {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	if err == nil && existing.ConfirmedAt.Valid {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "TOTP is already enabled. Disable it before enrolling again.",
+		})
+		return
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	_, err = api.Database.UpsertUserTOTPSecret(ctx, database.UpsertUserTOTPSecretParams{
+		UserID:    user.ID,
+		Secret:    secret,
+		CreatedAt: dbtime.Now(),
+	})
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusCreated, codersdk.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, "Coder", user.Username+"@"+api.AccessURL.Host),
+	})
+}
+
+// @Summary Verify user TOTP enrollment
+// @ID verify-user-totp-enrollment
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Users
+// @Param user path string true "User ID, name, or me"
+// @Param request body codersdk.VerifyTOTPRequest true "Verification request"
+// @Success 200 {object} codersdk.TOTPRecoveryCodes
+// @Router /users/{user}/mfa/totp/verify [post]
+func (api *API) postUserTOTPVerify(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		user   = httpmw.UserParam(r)
+		apiKey = httpmw.APIKey(r)
+	)
+
+	var req codersdk.VerifyTOTPRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	if apiKey.UserID != user.ID {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "Users can only enroll themselves in multi-factor authentication.",
+		})
+		return
+	}
+
+	secret, err := api.Database.GetUserTOTPSecret(ctx, user.ID)
+	if xerrors.Is(err, sql.ErrNoRows) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "TOTP enrollment has not been started.",
+		})
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	if secret.ConfirmedAt.Valid {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "TOTP is already enabled.",
+		})
+		return
+	}
+
+	counter, ok, err := totp.Validate(secret.Secret, req.Code, dbtime.Now(), secret.LastUsedCounter)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	if !ok {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid TOTP code.",
+			Validations: []codersdk.ValidationError{
+				{Field: "code", Detail: "The code does not match. Check that the time on your device is correct."},
+			},
+		})
+		return
+	}
+
+	codes, hashed, err := totp.GenerateRecoveryCodes()
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	_, err = api.Database.UpdateUserTOTPSecretConfirmed(ctx, database.UpdateUserTOTPSecretConfirmedParams{
+		UserID:              user.ID,
+		ConfirmedAt:         sql.NullTime{Time: dbtime.Now(), Valid: true},
+		HashedRecoveryCodes: hashed,
+		LastUsedCounter:     counter,
+	})
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.TOTPRecoveryCodes{
+		RecoveryCodes: codes,
+	})
+}
+
+// @Summary Disable user TOTP
+// @ID disable-user-totp
+// @Security CoderSessionToken
+// @Accept json
+// @Tags Users
+// @Param user path string true "User ID, name, or me"
+// @Param request body codersdk.DisableTOTPRequest false "Re-verification, required when users disable their own TOTP"
+// @Success 204
+// @Router /users/{user}/mfa/totp [delete]
+func (api *API) deleteUserTOTP(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		user              = httpmw.UserParam(r)
+		apiKey            = httpmw.APIKey(r)
+		auditor           = *api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.User](rw, &audit.RequestParams{
+			Audit:            auditor,
+			Log:              api.Logger,
+			Request:          r,
+			Action:           database.AuditActionWrite,
+			AdditionalFields: map[string]string{"reason": "TOTP disabled"},
+		})
+	)
+	defer commitAudit()
+	aReq.Old = user
+	aReq.New = user
+
+	// Admins reset the enrollment of users who lost their authenticator, but
+	// users disabling their own must prove that they are not just holding a
+	// stolen session.
+	if apiKey.UserID == user.ID {
+		var req codersdk.DisableTOTPRequest
+		if !httpapi.Read(ctx, rw, r, &req) {
+			return
+		}
+		if !api.verifyTOTPRemoval(ctx, rw, user, req) {
+			return
+		}
+	}
+
+	err := api.Database.DeleteUserTOTPSecret(ctx, user.ID)
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// verifyTOTPRemoval checks the code or password with which users confirm that
+// they are disabling their own TOTP. If false is returned, the error has been
+// written to the ResponseWriter.
+func (api *API) verifyTOTPRemoval(ctx context.Context, rw http.ResponseWriter, user database.User, req codersdk.DisableTOTPRequest) bool {
+	var (
+		valid bool
+		field string
+		err   error
+	)
+	switch {
+	case req.Password != "":
+		field = "password"
+		valid, err = userpassword.Compare(string(user.HashedPassword), req.Password)
+	case req.Code != "":
+		field = "code"
+		var secret database.UserTOTPSecret
+		secret, err = api.Database.GetUserTOTPSecret(ctx, user.ID)
+		if xerrors.Is(err, sql.ErrNoRows) {
+			err = nil
+			break
+		}
+		if err == nil && secret.ConfirmedAt.Valid {
+			valid, err = api.verifyMFACode(ctx, secret, req.Code)
+		}
+	default:
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "A code from your authenticator app, a recovery code, or your password is required to disable TOTP.",
+		})
+		return false
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return false
+	}
+	if !valid {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Re-verification failed.",
+			Validations: []codersdk.ValidationError{
+				{Field: field, Detail: "The " + field + " is incorrect."},
+			},
+		})
+		return false
+	}
+	return true
+}
+
+// checkLoginMFA verifies the second factor of a password login. It returns
+// whether the user has enabled TOTP. If false is returned for ok, the login
+// must be rejected and the error has been written to the ResponseWriter.
+func (api *API) checkLoginMFA(rw http.ResponseWriter, r *http.Request, user database.User, code string) (enrolled bool, ok bool) {
+	ctx := r.Context()
+	logger := api.Logger.Named(userAuthLoggerName)
+
+	//nolint:gocritic // The user is not authenticated until the code is checked.
+	sysCtx := dbauthz.AsSystemRestricted(ctx)
+	secret, err := api.Database.GetUserTOTPSecret(sysCtx, user.ID)
+	if xerrors.Is(err, sql.ErrNoRows) {
+		return false, true
+	}
+	if err != nil {
+		logger.Error(ctx, "unable to fetch totp secret", slog.Error(err))
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error.",
+		})
+		return false, false
+	}
+	// Enrollment was started but never confirmed.
+	if !secret.ConfirmedAt.Valid {
+		return false, true
+	}
+
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if code == "" {
+		httpapi.Write(ctx, rw, http.StatusUnauthorized, codersdk.Response{
+			Message: "Multi-factor authentication code required.",
+			Validations: []codersdk.ValidationError{
+				{Field: "mfa_code", Detail: "Enter the code from your authenticator app or a recovery code."},
+			},
+		})
+		return true, false
+	}
+
+	valid, err := api.verifyMFACode(ctx, secret, code)
+	if err != nil {
+		logger.Error(ctx, "unable to verify mfa code", slog.Error(err))
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error.",
+		})
+		return true, false
+	}
+	if !valid {
+		err = api.recordFailedLogin(r, user)
+		if err != nil {
+			logger.Error(ctx, "unable to record failed login", slog.F("user_id", user.ID), slog.Error(err))
+		}
+		httpapi.Write(ctx, rw, http.StatusUnauthorized, codersdk.Response{
+			Message: "Invalid multi-factor authentication code.",
+		})
+		return true, false
+	}
+	return true, true
+}
+
+// verifyMFACode checks a TOTP code or recovery code against the confirmed TOTP
+// secret of a user. Valid codes are used up: a TOTP code cannot be used again,
+// and a recovery code is deleted.
+func (api *API) verifyMFACode(ctx context.Context, secret database.UserTOTPSecret, code string) (bool, error) {
+	//nolint:gocritic // The user is not authenticated until the code is checked.
+	sysCtx := dbauthz.AsSystemRestricted(ctx)
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if !isTOTPCode(code) {
+		rows, err := api.Database.DeleteUserTOTPRecoveryCode(sysCtx, database.DeleteUserTOTPRecoveryCodeParams{
+			UserID:             secret.UserID,
+			HashedRecoveryCode: totp.HashRecoveryCode(code),
+		})
+		return rows > 0, err
+	}
+
+	counter, valid, err := totp.Validate(secret.Secret, code, dbtime.Now(), secret.LastUsedCounter)
+	if err != nil || !valid {
+		return false, err
+	}
+	// Another login may have used the same code concurrently.
+	rows, err := api.Database.UpdateUserTOTPSecretLastUsedCounter(sysCtx, database.UpdateUserTOTPSecretLastUsedCounterParams{
+		UserID:          secret.UserID,
+		LastUsedCounter: counter,
+	})
+	return rows > 0, err
+}
+
+// isTOTPCode returns true if the input looks like a TOTP code rather than a
+// recovery code.
+func isTOTPCode(code string) bool {
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
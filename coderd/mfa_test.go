@@ -0,0 +1,259 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/totp"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+	"github.com/coder/serpent"
+)
+
+func TestUserMFA(t *testing.T) {
+	t.Parallel()
+
+	// enroll enrolls the user of the client in TOTP and returns the secret
+	// and recovery codes.
+	enroll := func(t *testing.T, client *codersdk.Client) (string, []string) {
+		t.Helper()
+		ctx := testutil.Context(t, testutil.WaitMedium)
+
+		enrollment, err := client.EnrollTOTP(ctx, codersdk.Me)
+		require.NoError(t, err)
+		require.Contains(t, enrollment.ProvisioningURI, enrollment.Secret)
+
+		// Enrollment is not enforced until it is confirmed.
+		status, err := client.UserMFAStatus(ctx, codersdk.Me)
+		require.NoError(t, err)
+		require.False(t, status.TOTPEnabled)
+
+		var apiErr *codersdk.Error
+		_, err = client.VerifyTOTP(ctx, codersdk.Me, codersdk.VerifyTOTPRequest{Code: "000000"})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+
+		code, err := totp.Code(enrollment.Secret, time.Now())
+		require.NoError(t, err)
+		codes, err := client.VerifyTOTP(ctx, codersdk.Me, codersdk.VerifyTOTPRequest{Code: code})
+		require.NoError(t, err)
+		require.Len(t, codes.RecoveryCodes, totp.RecoveryCodeCount)
+
+		status, err = client.UserMFAStatus(ctx, codersdk.Me)
+		require.NoError(t, err)
+		require.True(t, status.TOTPEnabled)
+		require.Equal(t, totp.RecoveryCodeCount, status.RecoveryCodesRemaining)
+		return enrollment.Secret, codes.RecoveryCodes
+	}
+
+	t.Run("Login", func(t *testing.T) {
+		t.Parallel()
+		owner := coderdtest.New(t, nil)
+		first := coderdtest.CreateFirstUser(t, owner)
+		member, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+		secret, recoveryCodes := enroll(t, member)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		client := codersdk.New(owner.URL)
+		req := codersdk.LoginWithPasswordRequest{
+			Email:    user.Email,
+			Password: coderdtest.FirstUserParams.Password,
+		}
+		_, err := client.LoginWithPassword(ctx, req)
+		require.True(t, codersdk.IsMFARequiredError(err), err)
+
+		req.MFACode = "000000"
+		_, err = client.LoginWithPassword(ctx, req)
+		require.Error(t, err)
+		require.False(t, codersdk.IsMFARequiredError(err))
+
+		// The code used for enrollment cannot be reused, so use the one of
+		// the next period.
+		req.MFACode, err = totp.Code(secret, time.Now().Add(totp.Period))
+		require.NoError(t, err)
+		_, err = client.LoginWithPassword(ctx, req)
+		require.NoError(t, err)
+		_, err = client.LoginWithPassword(ctx, req)
+		require.Error(t, err)
+
+		// Recovery codes can only be used once.
+		req.MFACode = recoveryCodes[0]
+		_, err = client.LoginWithPassword(ctx, req)
+		require.NoError(t, err)
+		_, err = client.LoginWithPassword(ctx, req)
+		require.Error(t, err)
+
+		status, err := member.UserMFAStatus(ctx, codersdk.Me)
+		require.NoError(t, err)
+		require.Equal(t, totp.RecoveryCodeCount-1, status.RecoveryCodesRemaining)
+
+		// Enrolling again requires disabling TOTP first.
+		var apiErr *codersdk.Error
+		_, err = member.EnrollTOTP(ctx, codersdk.Me)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+
+	t.Run("Lockout", func(t *testing.T) {
+		t.Parallel()
+		dv := coderdtest.DeploymentValues(t)
+		dv.PasswordPolicy.LockoutThreshold = 2
+		owner := coderdtest.New(t, &coderdtest.Options{DeploymentValues: dv})
+		first := coderdtest.CreateFirstUser(t, owner)
+		member, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+		secret, _ := enroll(t, member)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		client := codersdk.New(owner.URL)
+		req := codersdk.LoginWithPasswordRequest{
+			Email:    user.Email,
+			Password: coderdtest.FirstUserParams.Password,
+			MFACode:  "000000",
+		}
+		_, err := client.LoginWithPassword(ctx, req)
+		require.Error(t, err)
+
+		// The correct password alone does not reset the failed attempts.
+		_, err = client.LoginWithPassword(ctx, codersdk.LoginWithPasswordRequest{
+			Email:    user.Email,
+			Password: coderdtest.FirstUserParams.Password,
+		})
+		require.True(t, codersdk.IsMFARequiredError(err), err)
+
+		// Invalid codes count as failed logins.
+		_, err = client.LoginWithPassword(ctx, req)
+		require.Error(t, err)
+		req.MFACode, err = totp.Code(secret, time.Now().Add(totp.Period))
+		require.NoError(t, err)
+		var apiErr *codersdk.Error
+		_, err = client.LoginWithPassword(ctx, req)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode())
+	})
+
+	t.Run("AdminReset", func(t *testing.T) {
+		t.Parallel()
+		auditor := audit.NewMock()
+		owner := coderdtest.New(t, &coderdtest.Options{Auditor: auditor})
+		first := coderdtest.CreateFirstUser(t, owner)
+		member, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+		_, _ = enroll(t, member)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+
+		// Admins can reset the enrollment of a user but not enroll them.
+		var apiErr *codersdk.Error
+		_, err := owner.EnrollTOTP(ctx, user.Username)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+
+		// Admins do not need to re-verify.
+		auditor.ResetLogs()
+		err = owner.DisableTOTP(ctx, user.Username, codersdk.DisableTOTPRequest{})
+		require.NoError(t, err)
+		require.True(t, auditor.Contains(t, database.AuditLog{
+			Action:       database.AuditActionWrite,
+			ResourceType: database.ResourceTypeUser,
+			ResourceID:   user.ID,
+			UserID:       first.UserID,
+		}))
+
+		_, err = codersdk.New(owner.URL).LoginWithPassword(ctx, codersdk.LoginWithPasswordRequest{
+			Email:    user.Email,
+			Password: coderdtest.FirstUserParams.Password,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("SelfDisable", func(t *testing.T) {
+		t.Parallel()
+		auditor := audit.NewMock()
+		owner := coderdtest.New(t, &coderdtest.Options{Auditor: auditor})
+		first := coderdtest.CreateFirstUser(t, owner)
+		member, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+		secret, _ := enroll(t, member)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+
+		// A session alone is not enough to disable TOTP.
+		var apiErr *codersdk.Error
+		for _, req := range []codersdk.DisableTOTPRequest{
+			{},
+			{Password: "WrongPassword!"},
+			{Code: "000000"},
+		} {
+			err := member.DisableTOTP(ctx, codersdk.Me, req)
+			require.ErrorAs(t, err, &apiErr)
+			require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+		}
+		status, err := member.UserMFAStatus(ctx, codersdk.Me)
+		require.NoError(t, err)
+		require.True(t, status.TOTPEnabled)
+
+		// The code used for enrollment cannot be reused, so use the one of
+		// the next period.
+		code, err := totp.Code(secret, time.Now().Add(totp.Period))
+		require.NoError(t, err)
+		auditor.ResetLogs()
+		err = member.DisableTOTP(ctx, codersdk.Me, codersdk.DisableTOTPRequest{Code: code})
+		require.NoError(t, err)
+		require.True(t, auditor.Contains(t, database.AuditLog{
+			Action:       database.AuditActionWrite,
+			ResourceType: database.ResourceTypeUser,
+			ResourceID:   user.ID,
+			UserID:       user.ID,
+		}))
+		status, err = member.UserMFAStatus(ctx, codersdk.Me)
+		require.NoError(t, err)
+		require.False(t, status.TOTPEnabled)
+
+		// The password works as well.
+		_, _ = enroll(t, member)
+		err = member.DisableTOTP(ctx, codersdk.Me, codersdk.DisableTOTPRequest{Password: coderdtest.FirstUserParams.Password})
+		require.NoError(t, err)
+	})
+
+	t.Run("Enforced", func(t *testing.T) {
+		t.Parallel()
+		dv := coderdtest.DeploymentValues(t)
+		owner := coderdtest.New(t, &coderdtest.Options{
+			DeploymentValues: dv,
+		})
+		first := coderdtest.CreateFirstUser(t, owner)
+		_, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+		dv.EnforceMFA = serpent.Bool(true)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		client := codersdk.New(owner.URL)
+		req := codersdk.LoginWithPasswordRequest{
+			Email:    user.Email,
+			Password: coderdtest.FirstUserParams.Password,
+		}
+		res, err := client.LoginWithPassword(ctx, req)
+		require.NoError(t, err)
+		require.True(t, res.MFAEnrollmentRequired)
+		client.SetSessionToken(res.SessionToken)
+
+		// The session can only be used to enroll.
+		_, err = client.User(ctx, codersdk.Me)
+		require.NoError(t, err)
+		_, err = client.CreateAPIKey(ctx, codersdk.Me)
+		require.Error(t, err)
+		secret, _ := enroll(t, client)
+
+		req.MFACode, err = totp.Code(secret, time.Now().Add(totp.Period))
+		require.NoError(t, err)
+		res, err = client.LoginWithPassword(ctx, req)
+		require.NoError(t, err)
+		require.False(t, res.MFAEnrollmentRequired)
+		client.SetSessionToken(res.SessionToken)
+		_, err = client.CreateAPIKey(ctx, codersdk.Me)
+		require.NoError(t, err)
+	})
+}
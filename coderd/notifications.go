@@ -2,14 +2,26 @@ package coderd
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/google/uuid"
 
+	"cdr.dev/slog"
+
 	"github.com/coder/coder/v2/coderd/audit"
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/db2sdk"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/notifications"
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/notifications/render"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/codersdk"
@@ -120,3 +132,673 @@ func (api *API) putNotificationsSettings(rw http.ResponseWriter, r *http.Request
 
 	httpapi.Write(r.Context(), rw, http.StatusOK, settings)
 }
+
+// @Summary Get notification templates
+// @ID get-notification-templates
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Success 200 {array} codersdk.NotificationTemplate
+// @Router /notifications/templates [get]
+func (api *API) notificationTemplates(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	templates, err := api.Database.GetNotificationTemplates(ctx)
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to fetch notification templates.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	out := make([]codersdk.NotificationTemplate, 0, len(templates))
+	for _, tmpl := range templates {
+		out = append(out, convertNotificationTemplate(tmpl))
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, out)
+}
+
+// @Summary Update notification template
+// @ID update-notification-template
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param notification_template path string true "Notification template ID" format(uuid)
+// @Param request body codersdk.UpdateNotificationTemplateRequest true "Title and body templates"
+// @Success 200 {object} codersdk.NotificationTemplate
+// @Router /notifications/templates/{notification_template} [put]
+func (api *API) putNotificationTemplate(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+		req codersdk.UpdateNotificationTemplateRequest
+	)
+
+	tmpl, ok := api.notificationTemplateParam(rw, r)
+	if !ok {
+		return
+	}
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	if validations := validateNotificationTemplate(tmpl.ID, req.TitleTemplate, req.BodyTemplate, api.NotificationsTemplateHelpers); len(validations) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid notification template.",
+			Validations: validations,
+		})
+		return
+	}
+
+	auditor := api.Auditor.Load()
+	aReq, commitAudit := audit.InitRequest[database.NotificationTemplate](rw, &audit.RequestParams{
+		Audit:   *auditor,
+		Log:     api.Logger,
+		Request: r,
+		Action:  database.AuditActionWrite,
+	})
+	defer commitAudit()
+	aReq.Old = tmpl
+
+	updated, err := api.Database.UpdateNotificationTemplateByID(ctx, database.UpdateNotificationTemplateByIDParams{
+		ID:            tmpl.ID,
+		TitleTemplate: req.TitleTemplate,
+		BodyTemplate:  req.BodyTemplate,
+	})
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to update notification template.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.New = updated
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertNotificationTemplate(updated))
+}
+
+// @Summary Reset notification template to default
+// @ID reset-notification-template-to-default
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param notification_template path string true "Notification template ID" format(uuid)
+// @Success 200 {object} codersdk.NotificationTemplate
+// @Router /notifications/templates/{notification_template}/reset [post]
+func (api *API) resetNotificationTemplate(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tmpl, ok := api.notificationTemplateParam(rw, r)
+	if !ok {
+		return
+	}
+
+	auditor := api.Auditor.Load()
+	aReq, commitAudit := audit.InitRequest[database.NotificationTemplate](rw, &audit.RequestParams{
+		Audit:   *auditor,
+		Log:     api.Logger,
+		Request: r,
+		Action:  database.AuditActionWrite,
+	})
+	defer commitAudit()
+	aReq.Old = tmpl
+
+	reset, err := api.Database.ResetNotificationTemplateByID(ctx, tmpl.ID)
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to reset notification template.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.New = reset
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertNotificationTemplate(reset))
+}
+
+// @Summary Update notification template digest window
+// @ID update-notification-template-digest-window
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param notification_template path string true "Notification template ID" format(uuid)
+// @Param request body codersdk.UpdateNotificationTemplateDigestRequest true "Digest window"
+// @Success 200 {object} codersdk.NotificationTemplate
+// @Router /notifications/templates/{notification_template}/digest [put]
+func (api *API) putNotificationTemplateDigest(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+		req codersdk.UpdateNotificationTemplateDigestRequest
+	)
+
+	tmpl, ok := api.notificationTemplateParam(rw, r)
+	if !ok {
+		return
+	}
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	if req.DigestWindowSeconds < 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid digest window.",
+			Validations: []codersdk.ValidationError{
+				{Field: "digest_window_seconds", Detail: "must not be negative"},
+			},
+		})
+		return
+	}
+
+	auditor := api.Auditor.Load()
+	aReq, commitAudit := audit.InitRequest[database.NotificationTemplate](rw, &audit.RequestParams{
+		Audit:   *auditor,
+		Log:     api.Logger,
+		Request: r,
+		Action:  database.AuditActionWrite,
+	})
+	defer commitAudit()
+	aReq.Old = tmpl
+
+	updated, err := api.Database.UpdateNotificationTemplateDigestWindowByID(ctx, database.UpdateNotificationTemplateDigestWindowByIDParams{
+		ID:                  tmpl.ID,
+		DigestWindowSeconds: req.DigestWindowSeconds,
+	})
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to update notification template digest window.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.New = updated
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertNotificationTemplate(updated))
+}
+
+// @Summary Preview notification template
+// @ID preview-notification-template
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param notification_template path string true "Notification template ID" format(uuid)
+// @Param request body codersdk.PreviewNotificationTemplateRequest true "Templates to preview"
+// @Success 200 {object} codersdk.NotificationTemplatePreview
+// @Router /notifications/templates/{notification_template}/preview [post]
+func (api *API) previewNotificationTemplate(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+		req codersdk.PreviewNotificationTemplateRequest
+	)
+
+	tmpl, ok := api.notificationTemplateParam(rw, r)
+	if !ok {
+		return
+	}
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	if req.TitleTemplate == "" {
+		req.TitleTemplate = tmpl.TitleTemplate
+	}
+	if req.BodyTemplate == "" {
+		req.BodyTemplate = tmpl.BodyTemplate
+	}
+	if validations := validateNotificationTemplate(tmpl.ID, req.TitleTemplate, req.BodyTemplate, api.NotificationsTemplateHelpers); len(validations) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid notification template.",
+			Validations: validations,
+		})
+		return
+	}
+
+	// Templates are rendered exactly as they would be by the notifier.
+	payload := notifications.SamplePayload(tmpl.ID, tmpl.Name, req.Labels)
+	title, err := render.GoTemplate(req.TitleTemplate, payload, api.NotificationsTemplateHelpers)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Failed to render title template.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	body, err := render.GoTemplate(req.BodyTemplate, payload, api.NotificationsTemplateHelpers)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Failed to render body template.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.NotificationTemplatePreview{
+		Title: title,
+		Body:  body,
+	})
+}
+
+// notificationTemplateParam fetches the notification template referenced by the request's path.
+func (api *API) notificationTemplateParam(rw http.ResponseWriter, r *http.Request) (database.NotificationTemplate, bool) {
+	ctx := r.Context()
+
+	id, ok := httpmw.ParseUUIDParam(rw, r, "notification_template")
+	if !ok {
+		return database.NotificationTemplate{}, false
+	}
+
+	tmpl, err := api.Database.GetNotificationTemplateByID(ctx, id)
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return database.NotificationTemplate{}, false
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to fetch notification template.",
+			Detail:  err.Error(),
+		})
+		return database.NotificationTemplate{}, false
+	}
+	return tmpl, true
+}
+
+func validateNotificationTemplate(templateID uuid.UUID, title, body string, helpers map[string]any) []codersdk.ValidationError {
+	var validations []codersdk.ValidationError
+	if err := notifications.ValidateTemplate(templateID, title, helpers); err != nil {
+		validations = append(validations, codersdk.ValidationError{Field: "title_template", Detail: err.Error()})
+	}
+	if err := notifications.ValidateTemplate(templateID, body, helpers); err != nil {
+		validations = append(validations, codersdk.ValidationError{Field: "body_template", Detail: err.Error()})
+	}
+	return validations
+}
+
+func convertNotificationTemplate(tmpl database.NotificationTemplate) codersdk.NotificationTemplate {
+	return codersdk.NotificationTemplate{
+		ID:                  tmpl.ID,
+		Name:                tmpl.Name,
+		Group:               tmpl.Group.String,
+		TitleTemplate:       tmpl.TitleTemplate,
+		BodyTemplate:        tmpl.BodyTemplate,
+		Actions:             string(tmpl.Actions),
+		Labels:              notifications.KnownLabels(tmpl.ID),
+		Customized:          tmpl.DefaultTitleTemplate.Valid || tmpl.DefaultBodyTemplate.Valid,
+		DigestWindowSeconds: tmpl.DigestWindowSeconds,
+	}
+}
+
+// @Summary Get user notification preferences
+// @ID get-user-notification-preferences
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Success 200 {array} codersdk.NotificationPreference
+// @Router /users/{user}/notifications/preferences [get]
+func (api *API) userNotificationPreferences(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	prefs, err := api.Database.GetUserNotificationPreferences(ctx, user.ID)
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to fetch notification preferences.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, db2sdk.List(prefs, db2sdk.NotificationPreference))
+}
+
+// @Summary Update user notification preferences
+// @ID update-user-notification-preferences
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Param request body codersdk.UpdateUserNotificationPreferences true "Preferences"
+// @Success 200 {array} codersdk.NotificationPreference
+// @Router /users/{user}/notifications/preferences [put]
+func (api *API) putUserNotificationPreferences(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		user   = httpmw.UserParam(r)
+		params codersdk.UpdateUserNotificationPreferences
+	)
+
+	if !httpapi.Read(ctx, rw, r, &params) {
+		return
+	}
+
+	var (
+		validationErrs []codersdk.ValidationError
+		upsert         = database.UpsertUserNotificationPreferencesParams{UserID: user.ID}
+	)
+	for i, pref := range params.Preferences {
+		if pref.NotificationTemplateID == uuid.Nil {
+			validationErrs = append(validationErrs, codersdk.ValidationError{
+				Field:  fmt.Sprintf("preferences[%d].notification_template_id", i),
+				Detail: "Notification template ID is required.",
+			})
+		}
+		if pref.Method != "" && !database.NotificationMethod(pref.Method).Valid() {
+			validationErrs = append(validationErrs, codersdk.ValidationError{
+				Field:  fmt.Sprintf("preferences[%d].method", i),
+				Detail: fmt.Sprintf("%q is not a valid notification method.", pref.Method),
+			})
+		}
+
+		upsert.NotificationTemplateIDs = append(upsert.NotificationTemplateIDs, pref.NotificationTemplateID)
+		upsert.Disabled = append(upsert.Disabled, pref.Disabled)
+		upsert.Methods = append(upsert.Methods, pref.Method)
+	}
+	if len(validationErrs) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid notification preferences.",
+			Validations: validationErrs,
+		})
+		return
+	}
+
+	_, err := api.Database.UpsertUserNotificationPreferences(ctx, upsert)
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if database.IsForeignKeyViolation(err, database.ForeignKeyNotificationPreferencesNotificationTemplateID) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Notification template not found.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to update notification preferences.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	prefs, err := api.Database.GetUserNotificationPreferences(ctx, user.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to fetch notification preferences.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, db2sdk.List(prefs, db2sdk.NotificationPreference))
+}
+
+// @Summary List user inbox notifications
+// @ID list-user-inbox-notifications
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Param unread_only query bool false "Only return unread notifications"
+// @Param starting_before query string false "Only return notifications older than the notification with this ID" format(uuid)
+// @Param limit query int false "Page limit, defaults to 25"
+// @Success 200 {object} codersdk.InboxNotificationsResponse
+// @Router /users/{user}/notifications/inbox [get]
+func (api *API) userInboxNotifications(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	parser := httpapi.NewQueryParamParser()
+	vals := r.URL.Query()
+	params := database.GetInboxNotificationsByUserIDParams{
+		UserID:         user.ID,
+		UnreadOnly:     parser.Boolean(vals, false, "unread_only"),
+		StartingBefore: parser.UUID(vals, uuid.Nil, "starting_before"),
+		LimitOpt:       parser.PositiveInt32(vals, 0, "limit"),
+	}
+	parser.ErrorExcessParams(vals)
+	if len(parser.Errors) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Query parameters have invalid values.",
+			Validations: parser.Errors,
+		})
+		return
+	}
+
+	notifs, err := api.Database.GetInboxNotificationsByUserID(ctx, params)
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to fetch inbox notifications.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	unread, err := api.Database.CountUnreadInboxNotificationsByUserID(ctx, user.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to count unread inbox notifications.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.InboxNotificationsResponse{
+		Notifications: db2sdk.List(notifs, db2sdk.InboxNotification),
+		UnreadCount:   int(unread),
+	})
+}
+
+// @Summary Update read status of inbox notification
+// @ID update-read-status-of-inbox-notification
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Param id path string true "Notification ID" format(uuid)
+// @Param request body codersdk.UpdateInboxNotificationReadStatusRequest true "Read status"
+// @Success 200 {object} codersdk.InboxNotification
+// @Router /users/{user}/notifications/inbox/{id}/read-status [put]
+func (api *API) putUserInboxNotificationReadStatus(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+		req  codersdk.UpdateInboxNotificationReadStatusRequest
+	)
+
+	id, ok := httpmw.ParseUUIDParam(rw, r, "id")
+	if !ok {
+		return
+	}
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	notif, err := api.Database.GetInboxNotificationByID(ctx, id)
+	// Notifications belonging to other users are reported as not found, regardless of the caller's permissions.
+	if httpapi.Is404Error(err) || (err == nil && notif.UserID != user.ID) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to fetch inbox notification.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	var readAt sql.NullTime
+	if req.IsRead {
+		readAt = sql.NullTime{Time: dbtime.Now(), Valid: true}
+	}
+	notif, err = api.Database.UpdateInboxNotificationReadStatus(ctx, database.UpdateInboxNotificationReadStatusParams{
+		ID:     id,
+		ReadAt: readAt,
+	})
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to update inbox notification.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, db2sdk.InboxNotification(notif))
+}
+
+// @Summary Mark all inbox notifications as read
+// @ID mark-all-inbox-notifications-as-read
+// @Security CoderSessionToken
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Success 204
+// @Router /users/{user}/notifications/inbox/mark-all-as-read [put]
+func (api *API) putUserInboxNotificationsMarkAllAsRead(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	_, err := api.Database.MarkAllInboxNotificationsAsRead(ctx, database.MarkAllInboxNotificationsAsReadParams{
+		UserID: user.ID,
+		ReadAt: dbtime.Now(),
+	})
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to mark inbox notifications as read.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Watch user inbox notifications
+// @ID watch-user-inbox-notifications
+// @Security CoderSessionToken
+// @Produce text/event-stream
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Success 200 {object} codersdk.Response
+// @Router /users/{user}/notifications/inbox/watch [get]
+func (api *API) watchUserInboxNotifications(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	// Ensure the caller may read the user's inbox before subscribing, as the subscription itself is not authorized.
+	if _, err := api.Database.CountUnreadInboxNotificationsByUserID(ctx, user.ID); err != nil {
+		if dbauthz.IsNotAuthorizedError(err) {
+			httpapi.ResourceNotFound(rw)
+			return
+		}
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to fetch inbox notifications.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	sendEvent, senderClosed, err := httpapi.ServerSentEventSender(rw, r)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error setting up server-sent events.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	// Prevent handler from returning until the sender is closed.
+	defer func() {
+		<-senderClosed
+	}()
+
+	cancelSubscribe, err := api.Pubsub.Subscribe(dispatch.InboxNotificationsChannel(user.ID), func(_ context.Context, message []byte) {
+		id, err := uuid.ParseBytes(message)
+		if err != nil {
+			api.Logger.Warn(ctx, "invalid inbox notification event", slog.F("message", string(message)), slog.Error(err))
+			return
+		}
+
+		notif, err := api.Database.GetInboxNotificationByID(ctx, id)
+		if err != nil {
+			_ = sendEvent(ctx, codersdk.ServerSentEvent{
+				Type: codersdk.ServerSentEventTypeError,
+				Data: codersdk.Response{
+					Message: "Internal error fetching inbox notification.",
+					Detail:  err.Error(),
+				},
+			})
+			return
+		}
+
+		_ = sendEvent(ctx, codersdk.ServerSentEvent{
+			Type: codersdk.ServerSentEventTypeData,
+			Data: db2sdk.InboxNotification(notif),
+		})
+	})
+	if err != nil {
+		_ = sendEvent(ctx, codersdk.ServerSentEvent{
+			Type: codersdk.ServerSentEventTypeError,
+			Data: codersdk.Response{
+				Message: "Internal error subscribing to inbox notifications.",
+				Detail:  err.Error(),
+			},
+		})
+		return
+	}
+	defer cancelSubscribe()
+
+	// An initial ping signals to the request that the server is now ready
+	// and the client can begin servicing a channel with data.
+	_ = sendEvent(ctx, codersdk.ServerSentEvent{
+		Type: codersdk.ServerSentEventTypePing,
+	})
+
+	select {
+	case <-ctx.Done():
+	case <-senderClosed:
+	}
+}
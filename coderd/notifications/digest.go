@@ -0,0 +1,76 @@
+package notifications
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+)
+
+// groupDigests splits the given messages into batches, each of which is delivered as a single notification.
+//
+// Messages of templates with a digest window are held in the store until the window has passed, and are then acquired
+// together; these are batched by recipient, template, and method. Every other message is delivered on its own.
+// The order of the messages is otherwise preserved.
+func groupDigests(msgs []database.AcquireNotificationMessagesRow) [][]database.AcquireNotificationMessagesRow {
+	type digestKey struct {
+		userID     uuid.UUID
+		templateID uuid.UUID
+		method     database.NotificationMethod
+	}
+
+	var (
+		batches [][]database.AcquireNotificationMessagesRow
+		digests = make(map[digestKey]int)
+	)
+	for _, msg := range msgs {
+		if msg.DigestWindowSeconds <= 0 {
+			batches = append(batches, []database.AcquireNotificationMessagesRow{msg})
+			continue
+		}
+
+		key := digestKey{userID: msg.UserID, templateID: msg.TemplateID, method: msg.Method}
+		if i, ok := digests[key]; ok {
+			batches[i] = append(batches[i], msg)
+			continue
+		}
+		digests[key] = len(batches)
+		batches = append(batches, []database.AcquireNotificationMessagesRow{msg})
+	}
+	return batches
+}
+
+// renderDigest combines the given payloads, and their rendered titles, into a single notification which lists the title
+// of each message. The payloads must share a recipient and template.
+//
+// Since the digest does not describe a single event, its payload carries no labels; it includes the actions of every
+// message, less any duplicates.
+func renderDigest(payloads []types.MessagePayload, titles []string) (payload types.MessagePayload, title, body string) {
+	payload = payloads[0]
+	payload.Labels = nil
+	payload.Actions = nil
+
+	seen := make(map[types.TemplateAction]struct{})
+	for _, p := range payloads {
+		for _, action := range p.Actions {
+			if _, ok := seen[action]; ok {
+				continue
+			}
+			seen[action] = struct{}{}
+			payload.Actions = append(payload.Actions, action)
+		}
+	}
+
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "Hi %s\n\n", payload.UserName)
+	_, _ = fmt.Fprintf(&sb, "There were %d %q notifications:\n\n", len(titles), payload.NotificationName)
+	for _, t := range titles {
+		_, _ = fmt.Fprintf(&sb, "- %s\n", t)
+	}
+
+	title = fmt.Sprintf("%s (%d)", payload.NotificationName, len(titles))
+	return payload, title, sb.String()
+}
@@ -0,0 +1,64 @@
+package dispatch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"golang.org/x/xerrors"
+)
+
+// postJSON delivers the given payload to the endpoint via an HTTP POST request.
+// If out is not nil, a successful response body is decoded into it.
+// The first return param follows the DeliveryFunc contract, indicating whether a failure is temporary.
+func postJSON(ctx context.Context, cl *http.Client, endpoint string, header http.Header, payload, out any) (retryable bool, err error) {
+	m, err := json.Marshal(payload)
+	if err != nil {
+		return false, xerrors.Errorf("marshal payload: %v", err)
+	}
+
+	// Outer context has a deadline (see CODER_NOTIFICATIONS_DISPATCH_TIMEOUT).
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(m))
+	if err != nil {
+		return false, xerrors.Errorf("create HTTP request: %v", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	return doRequest(cl, req, out)
+}
+
+// doRequest sends the given request and classifies the response.
+// Connection errors, timeouts, rate-limiting and server errors are considered retryable; all other non-2xx responses
+// indicate that the request will never succeed and are therefore permanent failures.
+func doRequest(cl *http.Client, req *http.Request, out any) (retryable bool, err error) {
+	resp, err := cl.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return true, xerrors.Errorf("request timeout: %w", err)
+		}
+
+		return true, xerrors.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		// Body could be quite long here, let's grab the first 512B and hope it contains useful debug info.
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		retryable = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return retryable, xerrors.Errorf("non-2xx response (%d): %q", resp.StatusCode, respBody)
+	}
+
+	if out == nil {
+		return false, nil
+	}
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return true, xerrors.Errorf("decode response: %w", err)
+	}
+	return false, nil
+}
@@ -0,0 +1,88 @@
+package dispatch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	markdown "github.com/coder/coder/v2/coderd/render"
+)
+
+// InboxStore is the subset of the store used to deliver messages to users' inboxes.
+type InboxStore interface {
+	InsertInboxNotification(ctx context.Context, arg database.InsertInboxNotificationParams) error
+}
+
+// InboxHandler dispatches notification messages to the recipient's in-app inbox.
+// Listeners are notified of new messages via pubsub, see InboxNotificationsChannel.
+type InboxHandler struct {
+	store InboxStore
+	ps    pubsub.Pubsub
+	log   slog.Logger
+}
+
+func NewInboxHandler(store InboxStore, ps pubsub.Pubsub, log slog.Logger) *InboxHandler {
+	return &InboxHandler{store: store, ps: ps, log: log}
+}
+
+// InboxNotificationsChannel is the pubsub channel on which the IDs of notifications delivered to the given user's inbox
+// are published. Only the ID is published since the notification's content may exceed the maximum payload size.
+func InboxNotificationsChannel(userID uuid.UUID) string {
+	return fmt.Sprintf("inbox_notifications:%s", userID)
+}
+
+func (s *InboxHandler) Dispatcher(payload types.MessagePayload, titleTmpl, bodyTmpl string) (DeliveryFunc, error) {
+	userID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		return nil, xerrors.Errorf("parse user ID: %w", err)
+	}
+
+	title, err := markdown.PlaintextFromMarkdown(titleTmpl)
+	if err != nil {
+		return nil, xerrors.Errorf("render title: %w", err)
+	}
+
+	actions := payload.Actions
+	if actions == nil {
+		actions = []types.TemplateAction{}
+	}
+	encodedActions, err := json.Marshal(actions)
+	if err != nil {
+		return nil, xerrors.Errorf("encode actions: %w", err)
+	}
+
+	// The body is stored as markdown so that it can be rendered by the dashboard.
+	return s.dispatch(userID, title, bodyTmpl, encodedActions), nil
+}
+
+func (s *InboxHandler) dispatch(userID uuid.UUID, title, content string, actions json.RawMessage) DeliveryFunc {
+	return func(ctx context.Context, msgID uuid.UUID) (retryable bool, err error) {
+		err = s.store.InsertInboxNotification(ctx, database.InsertInboxNotificationParams{
+			ID:        msgID,
+			UserID:    userID,
+			Title:     title,
+			Content:   content,
+			Actions:   actions,
+			CreatedAt: dbtime.Now(),
+		})
+		if err != nil {
+			return true, xerrors.Errorf("insert inbox notification: %w", err)
+		}
+
+		// The notification has been delivered at this point; listeners which miss the event will still see it the
+		// next time they list the inbox, so this failure should not cause the message to be redelivered.
+		if err := s.ps.Publish(InboxNotificationsChannel(userID), []byte(msgID.String())); err != nil {
+			s.log.Warn(ctx, "failed to publish inbox notification", slog.F("msg_id", msgID), slog.Error(err))
+		}
+		return false, nil
+	}
+}
@@ -0,0 +1,229 @@
+package dispatch
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	markdown "github.com/coder/coder/v2/coderd/render"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+const (
+	defaultSlackAPIURL = "https://slack.com/api"
+
+	// Limits imposed by Slack's Block Kit; see https://api.slack.com/reference/block-kit/blocks.
+	slackMaxHeaderLen  = 150
+	slackMaxSectionLen = 3000
+	slackMaxButtonLen  = 75
+	slackMaxButtons    = 25
+)
+
+// Slack Web API errors which indicate that a request may succeed if attempted again later.
+// See https://api.slack.com/methods/chat.postMessage#errors.
+var slackRetryableErrors = []string{"ratelimited", "internal_error", "fatal_error", "service_unavailable", "request_timeout"}
+
+// SlackHandler dispatches notification messages to Slack, either via an incoming webhook or via the Web API using a
+// bot token.
+type SlackHandler struct {
+	cfg    codersdk.NotificationsSlackConfig
+	log    slog.Logger
+	apiURL string
+
+	cl *http.Client
+}
+
+// SlackPayload describes the JSON payload to be delivered to Slack.
+// See https://api.slack.com/reference/block-kit/blocks.
+type SlackPayload struct {
+	// Channel is only set when delivering via the Web API.
+	Channel string `json:"channel,omitempty"`
+	// Text is used as a fallback in notifications and clients which don't support blocks.
+	Text   string       `json:"text"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+type SlackBlock struct {
+	Type     string         `json:"type"`
+	Text     *SlackText     `json:"text,omitempty"`
+	Elements []SlackElement `json:"elements,omitempty"`
+}
+
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type SlackElement struct {
+	Type string    `json:"type"`
+	Text SlackText `json:"text"`
+	URL  string    `json:"url,omitempty"`
+}
+
+// slackAPIResponse describes the common fields in all Slack Web API responses.
+type slackAPIResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+	User  struct {
+		ID string `json:"id"`
+	} `json:"user"`
+}
+
+func NewSlackHandler(cfg codersdk.NotificationsSlackConfig, log slog.Logger) *SlackHandler {
+	return &SlackHandler{cfg: cfg, log: log, apiURL: defaultSlackAPIURL, cl: &http.Client{}}
+}
+
+// WithAPIURL allows for tests to direct Slack Web API requests to a fake server.
+func (s *SlackHandler) WithAPIURL(u string) *SlackHandler {
+	s.apiURL = strings.TrimSuffix(u, "/")
+	return s
+}
+
+func (s *SlackHandler) Dispatcher(payload types.MessagePayload, titleTmpl, bodyTmpl string) (DeliveryFunc, error) {
+	if s.cfg.BotToken.String() == "" && s.cfg.Endpoint.String() == "" {
+		return nil, xerrors.New("slack endpoint or bot token not defined")
+	}
+
+	// Header blocks only support plain text.
+	title, err := markdown.PlaintextFromMarkdown(titleTmpl)
+	if err != nil {
+		return nil, xerrors.Errorf("render title: %w", err)
+	}
+	plainBody, err := markdown.PlaintextFromMarkdown(bodyTmpl)
+	if err != nil {
+		return nil, xerrors.Errorf("render body: %w", err)
+	}
+
+	msg := SlackPayload{
+		Text: truncate(title, slackMaxSectionLen),
+		Blocks: []SlackBlock{
+			{Type: "header", Text: &SlackText{Type: "plain_text", Text: truncate(title, slackMaxHeaderLen)}},
+			{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: truncate(slackMrkdwn(bodyTmpl), slackMaxSectionLen)}},
+		},
+	}
+	if plainBody == "" {
+		// Section blocks must not be empty.
+		msg.Blocks = msg.Blocks[:1]
+	}
+
+	if len(payload.Actions) > 0 {
+		actions := SlackBlock{Type: "actions"}
+		for _, action := range payload.Actions {
+			if len(actions.Elements) == slackMaxButtons {
+				break
+			}
+			actions.Elements = append(actions.Elements, SlackElement{
+				Type: "button",
+				Text: SlackText{Type: "plain_text", Text: truncate(action.Label, slackMaxButtonLen)},
+				URL:  action.URL,
+			})
+		}
+		msg.Blocks = append(msg.Blocks, actions)
+	}
+
+	if s.cfg.BotToken.String() != "" {
+		return s.dispatchAPI(msg, payload.UserEmail), nil
+	}
+	return s.dispatchWebhook(msg, s.cfg.Endpoint.String()), nil
+}
+
+// dispatchWebhook delivers the message via an incoming webhook, which is bound to a single channel.
+func (s *SlackHandler) dispatchWebhook(msg SlackPayload, endpoint string) DeliveryFunc {
+	return func(ctx context.Context, msgID uuid.UUID) (retryable bool, err error) {
+		header := http.Header{}
+		header.Set("X-Message-Id", msgID.String())
+
+		retryable, err = postJSON(ctx, s.cl, endpoint, header, msg, nil)
+		if err != nil {
+			s.log.Warn(ctx, "unsuccessful delivery", slog.F("msg_id", msgID), slog.Error(err))
+			return retryable, err
+		}
+		return false, nil
+	}
+}
+
+// dispatchAPI delivers the message via the chat.postMessage Web API method. If no channel is configured, the message
+// is sent as a direct message to the Slack user with the given email address.
+func (s *SlackHandler) dispatchAPI(msg SlackPayload, email string) DeliveryFunc {
+	return func(ctx context.Context, msgID uuid.UUID) (retryable bool, err error) {
+		msg.Channel = s.cfg.Channel.String()
+		if msg.Channel == "" {
+			msg.Channel, retryable, err = s.lookupUserByEmail(ctx, email)
+			if err != nil {
+				return retryable, xerrors.Errorf("lookup slack user: %w", err)
+			}
+		}
+
+		var resp slackAPIResponse
+		retryable, err = postJSON(ctx, s.cl, s.apiURL+"/chat.postMessage", s.authHeader(), msg, &resp)
+		if err != nil {
+			s.log.Warn(ctx, "unsuccessful delivery", slog.F("msg_id", msgID), slog.Error(err))
+			return retryable, err
+		}
+		if !resp.OK {
+			return slices.Contains(slackRetryableErrors, resp.Error), xerrors.Errorf("post message: %s", resp.Error)
+		}
+		return false, nil
+	}
+}
+
+func (s *SlackHandler) lookupUserByEmail(ctx context.Context, email string) (userID string, retryable bool, err error) {
+	if email == "" {
+		return "", false, xerrors.New("recipient has no email address")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.apiURL+"/users.lookupByEmail?email="+url.QueryEscape(email), nil)
+	if err != nil {
+		return "", false, xerrors.Errorf("create HTTP request: %v", err)
+	}
+	req.Header = s.authHeader()
+
+	var resp slackAPIResponse
+	if retryable, err = doRequest(s.cl, req, &resp); err != nil {
+		return "", retryable, err
+	}
+	if !resp.OK {
+		return "", slices.Contains(slackRetryableErrors, resp.Error), xerrors.New(resp.Error)
+	}
+	return resp.User.ID, false, nil
+}
+
+func (s *SlackHandler) authHeader() http.Header {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+s.cfg.BotToken.String())
+	return header
+}
+
+var (
+	slackHeadingRe = regexp.MustCompile(`(?m)^#{1,6}\s+(.+)$`)
+	slackBoldRe    = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	slackLinkRe    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// slackMrkdwn converts the subset of markdown used in notification templates into Slack's mrkdwn format.
+// See https://api.slack.com/reference/surfaces/formatting.
+func slackMrkdwn(md string) string {
+	out := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(md)
+	out = slackHeadingRe.ReplaceAllString(out, "*$1*")
+	out = slackBoldRe.ReplaceAllString(out, "*$1$2*")
+	out = slackLinkRe.ReplaceAllString(out, "<$2|$1>")
+	return out
+}
+
+// truncate shortens the given string to at most n runes, marking it as truncated with an ellipsis.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
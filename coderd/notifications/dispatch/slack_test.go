@@ -0,0 +1,195 @@
+package dispatch_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog"
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/serpent"
+
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSlack(t *testing.T) {
+	t.Parallel()
+
+	const (
+		titleTemplate = "Workspace **foo** deleted"
+		bodyTemplate  = "Hi,\n\nYour workspace **foo** was deleted. See [the docs](https://coder.com/docs)."
+		botToken      = "xoxb-test"
+		slackUserID   = "U012345"
+	)
+
+	msgPayload := types.MessagePayload{
+		Version:          "1.0",
+		NotificationName: "test",
+		UserEmail:        "bob@coder.com",
+		Labels:           map[string]string{"name": "foo"},
+		Actions: []types.TemplateAction{
+			{Label: "View workspaces", URL: "https://coder.com/workspaces"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		channel string
+		// useBot switches delivery from the incoming webhook to the Web API.
+		useBot   bool
+		serverFn func(t *testing.T, w http.ResponseWriter, r *http.Request)
+
+		expectSuccess   bool
+		expectRetryable bool
+		expectErr       string
+	}{
+		{
+			name: "webhook",
+			serverFn: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				var payload dispatch.SlackPayload
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				assert.Empty(t, payload.Channel)
+				assert.Equal(t, "Workspace foo deleted", payload.Text)
+				if assert.Len(t, payload.Blocks, 3) {
+					assert.Equal(t, "header", payload.Blocks[0].Type)
+					assert.Equal(t, "Workspace foo deleted", payload.Blocks[0].Text.Text)
+					assert.Equal(t, "mrkdwn", payload.Blocks[1].Text.Type)
+					assert.Contains(t, payload.Blocks[1].Text.Text, "*foo*")
+					assert.Contains(t, payload.Blocks[1].Text.Text, "<https://coder.com/docs|the docs>")
+					assert.Equal(t, "actions", payload.Blocks[2].Type)
+					assert.Equal(t, "View workspaces", payload.Blocks[2].Elements[0].Text.Text)
+					assert.Equal(t, "https://coder.com/workspaces", payload.Blocks[2].Elements[0].URL)
+				}
+				w.WriteHeader(http.StatusOK)
+			},
+			expectSuccess: true,
+		},
+		{
+			name: "webhook bad request",
+			serverFn: func(_ *testing.T, w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("invalid_blocks"))
+			},
+			expectRetryable: false,
+			expectErr:       "non-2xx response (400)",
+		},
+		{
+			name: "webhook rate limited",
+			serverFn: func(_ *testing.T, w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			expectRetryable: true,
+			expectErr:       "non-2xx response (429)",
+		},
+		{
+			name:    "bot with channel",
+			useBot:  true,
+			channel: "#alerts",
+			serverFn: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/chat.postMessage", r.URL.Path)
+				assert.Equal(t, "Bearer "+botToken, r.Header.Get("Authorization"))
+				var payload dispatch.SlackPayload
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				assert.Equal(t, "#alerts", payload.Channel)
+				_, _ = w.Write([]byte(`{"ok":true}`))
+			},
+			expectSuccess: true,
+		},
+		{
+			name:   "bot direct message",
+			useBot: true,
+			serverFn: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/users.lookupByEmail":
+					assert.Equal(t, "bob@coder.com", r.URL.Query().Get("email"))
+					_, _ = w.Write([]byte(`{"ok":true,"user":{"id":"` + slackUserID + `"}}`))
+				case "/chat.postMessage":
+					var payload dispatch.SlackPayload
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+					assert.Equal(t, slackUserID, payload.Channel)
+					_, _ = w.Write([]byte(`{"ok":true}`))
+				default:
+					t.Errorf("unexpected path %q", r.URL.Path)
+				}
+			},
+			expectSuccess: true,
+		},
+		{
+			name:   "bot unknown user",
+			useBot: true,
+			serverFn: func(_ *testing.T, w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"ok":false,"error":"users_not_found"}`))
+			},
+			expectRetryable: false,
+			expectErr:       "users_not_found",
+		},
+		{
+			name:    "bot rate limited",
+			useBot:  true,
+			channel: "#alerts",
+			serverFn: func(_ *testing.T, w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"ok":false,"error":"ratelimited"}`))
+			},
+			expectRetryable: true,
+			expectErr:       "ratelimited",
+		},
+	}
+
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+
+	// nolint:paralleltest // Irrelevant as of Go v1.22
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := testutil.Context(t, testutil.WaitLong)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tc.serverFn(t, w, r)
+			}))
+			defer server.Close()
+
+			var cfg codersdk.NotificationsSlackConfig
+			if tc.useBot {
+				cfg.BotToken = serpent.String(botToken)
+				cfg.Channel = serpent.String(tc.channel)
+			} else {
+				endpoint, err := url.Parse(server.URL)
+				require.NoError(t, err)
+				cfg.Endpoint = *serpent.URLOf(endpoint)
+			}
+
+			handler := dispatch.NewSlackHandler(cfg, logger.With(slog.F("test", tc.name))).WithAPIURL(server.URL)
+			deliveryFn, err := handler.Dispatcher(msgPayload, titleTemplate, bodyTemplate)
+			require.NoError(t, err)
+
+			retryable, err := deliveryFn(ctx, uuid.New())
+			if tc.expectSuccess {
+				require.NoError(t, err)
+				require.False(t, retryable)
+				return
+			}
+
+			require.ErrorContains(t, err, tc.expectErr)
+			require.Equal(t, tc.expectRetryable, retryable)
+		})
+	}
+}
+
+func TestSlackNotConfigured(t *testing.T) {
+	t.Parallel()
+
+	logger := slogtest.Make(t, nil)
+	handler := dispatch.NewSlackHandler(codersdk.NotificationsSlackConfig{}, logger)
+	_, err := handler.Dispatcher(types.MessagePayload{}, "title", "body")
+	require.ErrorContains(t, err, "slack endpoint or bot token not defined")
+}
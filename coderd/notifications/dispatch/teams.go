@@ -0,0 +1,120 @@
+package dispatch
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	markdown "github.com/coder/coder/v2/coderd/render"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+const (
+	adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	adaptiveCardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+	// Version 1.4 is the latest version supported by all Teams clients.
+	adaptiveCardVersion = "1.4"
+)
+
+// TeamsHandler dispatches notification messages as Adaptive Cards to a Microsoft Teams incoming webhook.
+type TeamsHandler struct {
+	cfg codersdk.NotificationsTeamsConfig
+	log slog.Logger
+
+	cl *http.Client
+}
+
+// TeamsPayload describes the JSON payload to be delivered to the configured Teams webhook.
+// See https://learn.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/connectors-using.
+type TeamsPayload struct {
+	Type        string            `json:"type"`
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+type TeamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+// AdaptiveCard describes the subset of the Adaptive Card schema used by notifications.
+// See https://adaptivecards.io/explorer/AdaptiveCard.html.
+type AdaptiveCard struct {
+	Schema  string                `json:"$schema"`
+	Type    string                `json:"type"`
+	Version string                `json:"version"`
+	Body    []AdaptiveCardElement `json:"body"`
+	Actions []AdaptiveCardAction  `json:"actions,omitempty"`
+	MSTeams map[string]string     `json:"msteams,omitempty"`
+}
+
+type AdaptiveCardElement struct {
+	Type   string `json:"type"`
+	Text   string `json:"text"`
+	Weight string `json:"weight,omitempty"`
+	Size   string `json:"size,omitempty"`
+	Wrap   bool   `json:"wrap"`
+}
+
+type AdaptiveCardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+func NewTeamsHandler(cfg codersdk.NotificationsTeamsConfig, log slog.Logger) *TeamsHandler {
+	return &TeamsHandler{cfg: cfg, log: log, cl: &http.Client{}}
+}
+
+func (t *TeamsHandler) Dispatcher(payload types.MessagePayload, titleTmpl, bodyTmpl string) (DeliveryFunc, error) {
+	if t.cfg.Endpoint.String() == "" {
+		return nil, xerrors.New("teams endpoint not defined")
+	}
+
+	title, err := markdown.PlaintextFromMarkdown(titleTmpl)
+	if err != nil {
+		return nil, xerrors.Errorf("render title: %w", err)
+	}
+
+	// TextBlocks natively support a subset of markdown (bold, italic, lists and links), so the body is passed as-is.
+	card := AdaptiveCard{
+		Schema:  adaptiveCardSchema,
+		Type:    "AdaptiveCard",
+		Version: adaptiveCardVersion,
+		Body: []AdaptiveCardElement{
+			{Type: "TextBlock", Text: title, Weight: "Bolder", Size: "Medium", Wrap: true},
+			{Type: "TextBlock", Text: bodyTmpl, Wrap: true},
+		},
+		MSTeams: map[string]string{"width": "Full"},
+	}
+	for _, action := range payload.Actions {
+		card.Actions = append(card.Actions, AdaptiveCardAction{
+			Type:  "Action.OpenUrl",
+			Title: action.Label,
+			URL:   action.URL,
+		})
+	}
+
+	return t.dispatch(TeamsPayload{
+		Type:        "message",
+		Attachments: []TeamsAttachment{{ContentType: adaptiveCardContentType, Content: card}},
+	}, t.cfg.Endpoint.String()), nil
+}
+
+func (t *TeamsHandler) dispatch(msg TeamsPayload, endpoint string) DeliveryFunc {
+	return func(ctx context.Context, msgID uuid.UUID) (retryable bool, err error) {
+		header := http.Header{}
+		header.Set("X-Message-Id", msgID.String())
+
+		retryable, err = postJSON(ctx, t.cl, endpoint, header, msg, nil)
+		if err != nil {
+			t.log.Warn(ctx, "unsuccessful delivery", slog.F("msg_id", msgID), slog.Error(err))
+			return retryable, err
+		}
+		return false, nil
+	}
+}
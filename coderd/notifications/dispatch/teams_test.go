@@ -0,0 +1,130 @@
+package dispatch_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog"
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/serpent"
+
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestTeams(t *testing.T) {
+	t.Parallel()
+
+	const (
+		titleTemplate = "Workspace **foo** deleted"
+		bodyTemplate  = "Your workspace **foo** was deleted."
+	)
+
+	msgPayload := types.MessagePayload{
+		Version:          "1.0",
+		NotificationName: "test",
+		Actions: []types.TemplateAction{
+			{Label: "View workspaces", URL: "https://coder.com/workspaces"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		serverFn func(t *testing.T, msgID uuid.UUID, w http.ResponseWriter, r *http.Request)
+
+		expectSuccess   bool
+		expectRetryable bool
+		expectErr       string
+	}{
+		{
+			name: "successful",
+			serverFn: func(t *testing.T, msgID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, msgID.String(), r.Header.Get("X-Message-Id"))
+
+				var payload dispatch.TeamsPayload
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				assert.Equal(t, "message", payload.Type)
+				if assert.Len(t, payload.Attachments, 1) {
+					card := payload.Attachments[0].Content
+					assert.Equal(t, "application/vnd.microsoft.card.adaptive", payload.Attachments[0].ContentType)
+					assert.Equal(t, "AdaptiveCard", card.Type)
+					if assert.Len(t, card.Body, 2) {
+						assert.Equal(t, "Workspace foo deleted", card.Body[0].Text)
+						assert.Equal(t, bodyTemplate, card.Body[1].Text)
+					}
+					if assert.Len(t, card.Actions, 1) {
+						assert.Equal(t, "Action.OpenUrl", card.Actions[0].Type)
+						assert.Equal(t, "View workspaces", card.Actions[0].Title)
+						assert.Equal(t, "https://coder.com/workspaces", card.Actions[0].URL)
+					}
+				}
+				w.WriteHeader(http.StatusAccepted)
+			},
+			expectSuccess: true,
+		},
+		{
+			name: "bad request",
+			serverFn: func(_ *testing.T, _ uuid.UUID, w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+			},
+			expectRetryable: false,
+			expectErr:       "non-2xx response (400)",
+		},
+		{
+			name: "server error",
+			serverFn: func(_ *testing.T, _ uuid.UUID, w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			expectRetryable: true,
+			expectErr:       "non-2xx response (502)",
+		},
+	}
+
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+
+	// nolint:paralleltest // Irrelevant as of Go v1.22
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				ctx   = testutil.Context(t, testutil.WaitLong)
+				msgID = uuid.New()
+			)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tc.serverFn(t, msgID, w, r)
+			}))
+			defer server.Close()
+
+			endpoint, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			cfg := codersdk.NotificationsTeamsConfig{
+				Endpoint: *serpent.URLOf(endpoint),
+			}
+			handler := dispatch.NewTeamsHandler(cfg, logger.With(slog.F("test", tc.name)))
+			deliveryFn, err := handler.Dispatcher(msgPayload, titleTemplate, bodyTemplate)
+			require.NoError(t, err)
+
+			retryable, err := deliveryFn(ctx, msgID)
+			if tc.expectSuccess {
+				require.NoError(t, err)
+				require.False(t, retryable)
+				return
+			}
+
+			require.ErrorContains(t, err, tc.expectErr)
+			require.Equal(t, tc.expectRetryable, retryable)
+		})
+	}
+}
@@ -3,10 +3,15 @@ package dispatch
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/xerrors"
@@ -18,6 +23,13 @@ import (
 	"github.com/coder/coder/v2/codersdk"
 )
 
+const (
+	// WebhookTimestampHeader holds the time, in seconds since the Unix epoch, at which a webhook request was signed.
+	WebhookTimestampHeader = "X-Coder-Timestamp"
+	// WebhookSignatureHeader holds the signature of a webhook request; see WebhookSignature.
+	WebhookSignatureHeader = "X-Coder-Signature"
+)
+
 // WebhookHandler dispatches notification messages via an HTTP POST webhook.
 type WebhookHandler struct {
 	cfg codersdk.NotificationsWebhookConfig
@@ -77,8 +89,17 @@ func (w *WebhookHandler) dispatch(msgPayload types.MessagePayload, title, body,
 		if err != nil {
 			return false, xerrors.Errorf("create HTTP request: %v", err)
 		}
+		// Custom headers are set first so that they cannot override those on which receivers rely.
+		for k, v := range w.cfg.Headers.Value {
+			req.Header.Set(k, v)
+		}
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("X-Message-Id", msgID.String())
+		if secret := w.cfg.SigningSecret.String(); secret != "" {
+			ts := time.Now().Unix()
+			req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(ts, 10))
+			req.Header.Set(WebhookSignatureHeader, WebhookSignature(secret, ts, m))
+		}
 
 		// Send request.
 		resp, err := w.cl.Do(req)
@@ -108,3 +129,14 @@ func (w *WebhookHandler) dispatch(msgPayload types.MessagePayload, title, body,
 		return false, nil
 	}
 }
+
+// WebhookSignature returns the hex-encoded HMAC-SHA256, keyed with the given secret, of the timestamp and body of a
+// webhook request joined by a period. Receivers should compare it against the signature header, and reject requests
+// whose timestamp is too old, to prevent forged and replayed payloads respectively.
+func WebhookSignature(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	_, _ = mac.Write([]byte("."))
+	_, _ = mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -3,9 +3,11 @@ package dispatch_test
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"testing"
 	"time"
 
@@ -45,6 +47,8 @@ func TestWebhook(t *testing.T) {
 		serverURL     string
 		serverTimeout time.Duration
 		serverFn      func(uuid.UUID, http.ResponseWriter, *http.Request)
+		signingSecret string
+		headers       map[string]string
 
 		expectSuccess   bool
 		expectRetryable bool
@@ -66,6 +70,48 @@ func TestWebhook(t *testing.T) {
 			},
 			expectSuccess: true,
 		},
+		{
+			name:          "signed",
+			signingSecret: "s3cr3t",
+			serverFn: func(msgID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+
+				ts, err := strconv.ParseInt(r.Header.Get(dispatch.WebhookTimestampHeader), 10, 64)
+				assert.NoError(t, err)
+				assert.WithinDuration(t, time.Now(), time.Unix(ts, 0), testutil.WaitShort)
+				assert.Equal(t, dispatch.WebhookSignature("s3cr3t", ts, body), r.Header.Get(dispatch.WebhookSignatureHeader))
+				assert.NotEqual(t, dispatch.WebhookSignature("wrong", ts, body), r.Header.Get(dispatch.WebhookSignatureHeader))
+
+				w.WriteHeader(http.StatusOK)
+			},
+			expectSuccess: true,
+		},
+		{
+			name: "unsigned",
+			serverFn: func(msgID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+				assert.Empty(t, r.Header.Get(dispatch.WebhookTimestampHeader))
+				assert.Empty(t, r.Header.Get(dispatch.WebhookSignatureHeader))
+
+				w.WriteHeader(http.StatusOK)
+			},
+			expectSuccess: true,
+		},
+		{
+			name: "custom headers",
+			headers: map[string]string{
+				"Authorization": "Bearer token",
+				"X-Message-Id":  "overridden",
+			},
+			serverFn: func(msgID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+				// Custom headers must not override those set by the handler.
+				assert.Equal(t, msgID.String(), r.Header.Get("X-Message-Id"))
+
+				w.WriteHeader(http.StatusOK)
+			},
+			expectSuccess: true,
+		},
 		{
 			name: "invalid endpoint",
 			// Build a deliberately invalid URL to fail validation.
@@ -125,7 +171,9 @@ func TestWebhook(t *testing.T) {
 			}
 
 			cfg := codersdk.NotificationsWebhookConfig{
-				Endpoint: *serpent.URLOf(endpoint),
+				Endpoint:      *serpent.URLOf(endpoint),
+				SigningSecret: serpent.String(tc.signingSecret),
+				Headers:       serpent.Struct[map[string]string]{Value: tc.headers},
 			}
 			handler := dispatch.NewWebhookHandler(cfg, logger.With(slog.F("test", tc.name)))
 			deliveryFn, err := handler.Dispatcher(msgPayload, titleTemplate, bodyTemplate)
@@ -2,6 +2,7 @@ package notifications
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"text/template"
 
@@ -11,6 +12,7 @@ import (
 	"cdr.dev/slog"
 
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
 	"github.com/coder/coder/v2/coderd/notifications/render"
 	"github.com/coder/coder/v2/coderd/notifications/types"
 	"github.com/coder/coder/v2/codersdk"
@@ -20,9 +22,7 @@ type StoreEnqueuer struct {
 	store Store
 	log   slog.Logger
 
-	// TODO: expand this to allow for each notification to have custom delivery methods, or multiple, or none.
-	// 		 For example, Larry might want email notifications for "workspace deleted" notifications, but Harry wants
-	//		 Slack notifications, and Mary doesn't want any.
+	// method is the deployment's default delivery method, which users may override per notification template.
 	method database.NotificationMethod
 	// helpers holds a map of template funcs which are used when rendering templates. These need to be passed in because
 	// the template funcs will return values which are inappropriately encapsulated in this struct.
@@ -47,6 +47,26 @@ func NewStoreEnqueuer(cfg codersdk.NotificationsConfig, store Store, helpers tem
 // Enqueue queues a notification message for later delivery.
 // Messages will be dequeued by a notifier later and dispatched.
 func (s *StoreEnqueuer) Enqueue(ctx context.Context, userID, templateID uuid.UUID, labels map[string]string, createdBy string, targets ...uuid.UUID) (*uuid.UUID, error) {
+	method := s.method
+	// Notifications are enqueued by many actors, e.g. the lifecycle executor, which cannot read the personal
+	// preferences of the recipient.
+	//nolint:gocritic // Preferences are looked up on behalf of the system, regardless of who triggered the event.
+	pref, err := s.store.GetUserNotificationPreference(dbauthz.AsSystemRestricted(ctx), database.GetUserNotificationPreferenceParams{
+		UserID:                 userID,
+		NotificationTemplateID: templateID,
+	})
+	if err != nil && !xerrors.Is(err, sql.ErrNoRows) {
+		return nil, xerrors.Errorf("fetch notification preference: %w", err)
+	}
+	if pref.Disabled {
+		s.log.Debug(ctx, "notification disabled by user preference", slog.F("template_id", templateID), slog.F("user_id", userID))
+		// nolint:nilnil // A disabled notification is not a failure.
+		return nil, nil
+	}
+	if pref.Method.Valid {
+		method = pref.Method.NotificationMethod
+	}
+
 	payload, err := s.buildPayload(ctx, userID, templateID, labels)
 	if err != nil {
 		s.log.Warn(ctx, "failed to build payload", slog.F("template_id", templateID), slog.F("user_id", userID), slog.Error(err))
@@ -63,7 +83,7 @@ func (s *StoreEnqueuer) Enqueue(ctx context.Context, userID, templateID uuid.UUI
 		ID:                     id,
 		UserID:                 userID,
 		NotificationTemplateID: templateID,
-		Method:                 s.method,
+		Method:                 method,
 		Payload:                input,
 		Targets:                targets,
 		CreatedBy:              createdBy,
@@ -12,4 +12,76 @@ var (
 	TemplateWorkspaceDormant           = uuid.MustParse("0ea69165-ec14-4314-91f1-69566ac3c5a0")
 	TemplateWorkspaceAutoUpdated       = uuid.MustParse("c34a0c09-0704-4cac-bd1c-0c0146811c2b")
 	TemplateWorkspaceMarkedForDeletion = uuid.MustParse("51ce2fdf-c9ca-4be1-8d70-628674f9bc42")
+	TemplateWorkspaceQuotaReached      = uuid.MustParse("b5f1e0c2-3a9d-4c8b-9e47-1d2f6a7c8e90")
 )
+
+// Template-related events, which are sent to template administrators.
+var (
+	TemplateTemplateVersionPromoted    = uuid.MustParse("2d1e9f4c-7f1b-4a7e-9d6e-35b1a8c6c0b1")
+	TemplateWorkspaceManualBuildFailed = uuid.MustParse("6a2c8a31-0c4e-4f4e-8d8e-8f4f2b0e2a77")
+)
+
+// User-related events, which are sent to user administrators.
+var (
+	TemplateUserAccountCreated   = uuid.MustParse("e1a3d5f7-9b2c-4d6e-8f01-23456789abcd")
+	TemplateUserAccountSuspended = uuid.MustParse("f3c7a9b1-5d2e-4f8a-b6c4-0e9d8c7b6a51")
+)
+
+// TemplateLabels holds the labels which are passed to each template when a notification is enqueued, along with an
+// example value for each which is used when previewing the template. Templates may only reference these labels.
+var TemplateLabels = map[uuid.UUID]map[string]string{
+	TemplateWorkspaceDeleted: {
+		"name":      "my-workspace",
+		"reason":    "autodeleted due to dormancy",
+		"initiator": "autobuild",
+	},
+	TemplateWorkspaceAutobuildFailed: {
+		"name":      "my-workspace",
+		"reason":    "autostart",
+		"initiator": "autobuild",
+	},
+	TemplateWorkspaceDormant: {
+		"name":          "my-workspace",
+		"reason":        "breached the template's threshold for inactivity",
+		"initiator":     "autobuild",
+		"dormancyHours": "24",
+	},
+	TemplateWorkspaceAutoUpdated: {
+		"name":                  "my-workspace",
+		"reason":                "autostart",
+		"initiator":             "autobuild",
+		"template_version_name": "v2",
+	},
+	TemplateWorkspaceMarkedForDeletion: {
+		"name":          "my-workspace",
+		"reason":        "template updated to new dormancy policy",
+		"dormancyHours": "24",
+	},
+	TemplateWorkspaceQuotaReached: {
+		"name":             "my-workspace",
+		"daily_cost":       "10",
+		"credits_consumed": "95",
+		"budget":           "100",
+	},
+	TemplateTemplateVersionPromoted: {
+		"name":         "my-template",
+		"version_name": "v2",
+		"initiator":    "alice",
+	},
+	TemplateWorkspaceManualBuildFailed: {
+		"name":                     "my-workspace",
+		"workspace_owner_username": "alice",
+		"workspace_build_number":   "3",
+		"transition":               "start",
+		"template_name":            "my-template",
+		"template_version_name":    "v2",
+		"initiator":                "alice",
+	},
+	TemplateUserAccountCreated: {
+		"created_account_name": "alice",
+	},
+	TemplateUserAccountSuspended: {
+		"suspended_account_name": "alice",
+		"initiator":              "bobby",
+	},
+}
@@ -3,6 +3,7 @@ package notifications
 import (
 	"context"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,6 +13,7 @@ import (
 	"cdr.dev/slog"
 
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
 	"github.com/coder/coder/v2/coderd/notifications/dispatch"
 	"github.com/coder/coder/v2/codersdk"
 )
@@ -46,6 +48,7 @@ type Manager struct {
 	method   database.NotificationMethod
 
 	metrics *Metrics
+	helpers template.FuncMap
 
 	success, failure chan dispatchResult
 
@@ -59,7 +62,9 @@ type Manager struct {
 //
 // helpers is a map of template helpers which are used to customize notification messages to use global settings like
 // access URL etc.
-func NewManager(cfg codersdk.NotificationsConfig, store Store, metrics *Metrics, log slog.Logger) (*Manager, error) {
+//
+// ps is used to inform listeners of messages which have been delivered to users' inboxes.
+func NewManager(cfg codersdk.NotificationsConfig, store Store, helpers template.FuncMap, ps pubsub.Pubsub, metrics *Metrics, log slog.Logger) (*Manager, error) {
 	// TODO(dannyk): add the ability to use multiple notification methods.
 	var method database.NotificationMethod
 	if err := method.Scan(cfg.Method.String()); err != nil {
@@ -89,19 +94,23 @@ func NewManager(cfg codersdk.NotificationsConfig, store Store, metrics *Metrics,
 
 		metrics: metrics,
 		method:  method,
+		helpers: helpers,
 
 		stop: make(chan any),
 		done: make(chan any),
 
-		handlers: defaultHandlers(cfg, log),
+		handlers: defaultHandlers(cfg, store, ps, log),
 	}, nil
 }
 
 // defaultHandlers builds a set of known handlers; panics if any error occurs as these handlers should be valid at compile time.
-func defaultHandlers(cfg codersdk.NotificationsConfig, log slog.Logger) map[database.NotificationMethod]Handler {
+func defaultHandlers(cfg codersdk.NotificationsConfig, store Store, ps pubsub.Pubsub, log slog.Logger) map[database.NotificationMethod]Handler {
 	return map[database.NotificationMethod]Handler{
 		database.NotificationMethodSmtp:    dispatch.NewSMTPHandler(cfg.SMTP, log.Named("dispatcher.smtp")),
 		database.NotificationMethodWebhook: dispatch.NewWebhookHandler(cfg.Webhook, log.Named("dispatcher.webhook")),
+		database.NotificationMethodSlack:   dispatch.NewSlackHandler(cfg.Slack, log.Named("dispatcher.slack")),
+		database.NotificationMethodTeams:   dispatch.NewTeamsHandler(cfg.Teams, log.Named("dispatcher.teams")),
+		database.NotificationMethodInbox:   dispatch.NewInboxHandler(store, ps, log.Named("dispatcher.inbox")),
 	}
 }
 
@@ -149,7 +158,7 @@ func (m *Manager) loop(ctx context.Context) error {
 	var eg errgroup.Group
 
 	// Create a notifier to run concurrently, which will handle dequeueing and dispatching notifications.
-	m.notifier = newNotifier(m.cfg, uuid.New(), m.log, m.store, m.handlers, m.method, m.metrics)
+	m.notifier = newNotifier(m.cfg, uuid.New(), m.log, m.store, m.handlers, m.helpers, m.method, m.metrics)
 	eg.Go(func() error {
 		return m.notifier.run(ctx, m.success, m.failure)
 	})
@@ -18,18 +18,23 @@ import (
 
 	"github.com/google/uuid"
 	smtpmock "github.com/mocktools/go-smtp-mock/v2"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
 
 	"github.com/coder/serpent"
 
+	"github.com/coder/coder/v2/coderd/coderdtest"
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
 	"github.com/coder/coder/v2/coderd/database/dbgen"
 	"github.com/coder/coder/v2/coderd/database/dbtestutil"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
 	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/notifications/dispatch"
 	"github.com/coder/coder/v2/coderd/notifications/types"
+	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/util/syncmap"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/testutil"
@@ -57,7 +62,7 @@ func TestBasicNotificationRoundtrip(t *testing.T) {
 	interceptor := &syncInterceptor{Store: db}
 	cfg := defaultNotificationsConfig(method)
 	cfg.RetryInterval = serpent.Duration(time.Hour) // Ensure retries don't interfere with the test
-	mgr, err := notifications.NewManager(cfg, interceptor, createMetrics(), logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, interceptor, defaultHelpers(), pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
 	t.Cleanup(func() {
@@ -131,7 +136,7 @@ func TestSMTPDispatch(t *testing.T) {
 		Hello:     "localhost",
 	}
 	handler := newDispatchInterceptor(dispatch.NewSMTPHandler(cfg.SMTP, logger.Named("smtp")))
-	mgr, err := notifications.NewManager(cfg, db, createMetrics(), logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, db, defaultHelpers(), pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
 	t.Cleanup(func() {
@@ -192,7 +197,7 @@ func TestWebhookDispatch(t *testing.T) {
 	cfg.Webhook = codersdk.NotificationsWebhookConfig{
 		Endpoint: *serpent.URLOf(endpoint),
 	}
-	mgr, err := notifications.NewManager(cfg, db, createMetrics(), logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, db, defaultHelpers(), pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		assert.NoError(t, mgr.Stop(ctx))
@@ -290,7 +295,7 @@ func TestBackpressure(t *testing.T) {
 	storeInterceptor := &syncInterceptor{Store: db}
 
 	// GIVEN: a notification manager whose updates will be intercepted
-	mgr, err := notifications.NewManager(cfg, storeInterceptor, createMetrics(), logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, storeInterceptor, defaultHelpers(), pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
 	enq, err := notifications.NewStoreEnqueuer(cfg, db, defaultHelpers(), logger.Named("enqueuer"))
@@ -385,7 +390,7 @@ func TestRetries(t *testing.T) {
 	// Intercept calls to submit the buffered updates to the store.
 	storeInterceptor := &syncInterceptor{Store: db}
 
-	mgr, err := notifications.NewManager(cfg, storeInterceptor, createMetrics(), logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, storeInterceptor, defaultHelpers(), pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		assert.NoError(t, mgr.Stop(ctx))
@@ -446,7 +451,7 @@ func TestExpiredLeaseIsRequeued(t *testing.T) {
 	mgrCtx, cancelManagerCtx := context.WithCancel(context.Background())
 	t.Cleanup(cancelManagerCtx)
 
-	mgr, err := notifications.NewManager(cfg, noopInterceptor, createMetrics(), logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, noopInterceptor, defaultHelpers(), pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	enq, err := notifications.NewStoreEnqueuer(cfg, db, defaultHelpers(), logger.Named("enqueuer"))
 	require.NoError(t, err)
@@ -493,7 +498,7 @@ func TestExpiredLeaseIsRequeued(t *testing.T) {
 	// Intercept calls to submit the buffered updates to the store.
 	storeInterceptor := &syncInterceptor{Store: db}
 	handler := newDispatchInterceptor(&fakeHandler{})
-	mgr, err = notifications.NewManager(cfg, storeInterceptor, createMetrics(), logger.Named("manager"))
+	mgr, err = notifications.NewManager(cfg, storeInterceptor, defaultHelpers(), pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
 
@@ -534,7 +539,7 @@ func TestInvalidConfig(t *testing.T) {
 	cfg.DispatchTimeout = serpent.Duration(leasePeriod)
 
 	// WHEN: the manager is created with invalid config
-	_, err := notifications.NewManager(cfg, db, createMetrics(), logger.Named("manager"))
+	_, err := notifications.NewManager(cfg, db, defaultHelpers(), pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 
 	// THEN: the manager will fail to be created, citing invalid config as error
 	require.ErrorIs(t, err, notifications.ErrInvalidDispatchTimeout)
@@ -552,7 +557,7 @@ func TestNotifierPaused(t *testing.T) {
 	user := createSampleUser(t, db)
 
 	cfg := defaultNotificationsConfig(method)
-	mgr, err := notifications.NewManager(cfg, db, createMetrics(), logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, db, defaultHelpers(), pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
 	t.Cleanup(func() {
@@ -603,6 +608,312 @@ func TestNotifierPaused(t *testing.T) {
 	}, testutil.WaitShort, testutil.IntervalFast)
 }
 
+func TestUserNotificationPreferences(t *testing.T) {
+	t.Parallel()
+
+	// SETUP
+	if !dbtestutil.WillUsePostgres() {
+		t.Skip("This test requires postgres; it relies on business-logic only implemented in the database")
+	}
+
+	ctx, logger, db := setup(t)
+
+	// GIVEN: an enqueuer which defaults to SMTP
+	cfg := defaultNotificationsConfig(database.NotificationMethodSmtp)
+	enq, err := notifications.NewStoreEnqueuer(cfg, db, defaultHelpers(), logger.Named("enqueuer"))
+	require.NoError(t, err)
+
+	user := createSampleUser(t, db)
+
+	// GIVEN: the user has disabled one notification and chosen webhook delivery for another
+	_, err = db.UpsertUserNotificationPreferences(ctx, database.UpsertUserNotificationPreferencesParams{
+		UserID:                  user.ID,
+		NotificationTemplateIDs: []uuid.UUID{notifications.TemplateWorkspaceDeleted, notifications.TemplateWorkspaceAutobuildFailed},
+		Disabled:                []bool{true, false},
+		Methods:                 []string{"", string(database.NotificationMethodWebhook)},
+	})
+	require.NoError(t, err)
+
+	// WHEN: the disabled notification is enqueued
+	id, err := enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{}, "test")
+
+	// THEN: nothing is enqueued
+	require.NoError(t, err)
+	require.Nil(t, id)
+
+	// WHEN: the other notification is enqueued
+	id, err = enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceAutobuildFailed, map[string]string{}, "test")
+	require.NoError(t, err)
+	require.NotNil(t, id)
+
+	// THEN: it will be delivered using the user's preferred method
+	pending, err := db.GetNotificationMessagesByStatus(ctx, database.GetNotificationMessagesByStatusParams{
+		Status: database.NotificationMessageStatusPending,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, *id, pending[0].ID)
+	require.Equal(t, database.NotificationMethodWebhook, pending[0].Method)
+}
+
+func TestUserNotificationPreferencesBackgroundActor(t *testing.T) {
+	t.Parallel()
+
+	// SETUP
+	ctx, logger, db := setupInMemory(t)
+	authz := rbac.NewStrictCachingAuthorizer(prometheus.NewRegistry())
+	authzDB := dbauthz.New(db, authz, logger, coderdtest.AccessControlStorePointer())
+
+	// GIVEN: an enqueuer whose store enforces authorization
+	cfg := defaultNotificationsConfig(database.NotificationMethodSmtp)
+	enq, err := notifications.NewStoreEnqueuer(cfg, authzDB, defaultHelpers(), logger.Named("enqueuer"))
+	require.NoError(t, err)
+
+	user := createSampleUser(t, db)
+
+	// GIVEN: the user has a notification preference
+	_, err = db.UpsertUserNotificationPreferences(ctx, database.UpsertUserNotificationPreferencesParams{
+		UserID:                  user.ID,
+		NotificationTemplateIDs: []uuid.UUID{notifications.TemplateWorkspaceAutobuildFailed},
+		Disabled:                []bool{false},
+		Methods:                 []string{string(database.NotificationMethodWebhook)},
+	})
+	require.NoError(t, err)
+
+	// WHEN: a notification is enqueued by a background actor which cannot read the user's preferences
+	id, err := enq.Enqueue(dbauthz.AsAutostart(context.Background()), user.ID, notifications.TemplateWorkspaceAutobuildFailed, map[string]string{}, "autobuild")
+
+	// THEN: it is enqueued with the user's preferred method
+	require.NoError(t, err)
+	require.NotNil(t, id)
+	pending, err := db.GetNotificationMessagesByStatus(ctx, database.GetNotificationMessagesByStatusParams{
+		Status: database.NotificationMessageStatusPending,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, database.NotificationMethodWebhook, pending[0].Method)
+}
+
+func TestValidateTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		in       string
+		errorMsg string
+	}{
+		{
+			name: "valid",
+			in:   `Hi {{ .UserName }}, workspace "{{ .Labels.name }}" was deleted{{ if .Labels.initiator }} by {{ .Labels.initiator }}{{ end }}`,
+		},
+		{
+			name: "helper",
+			in:   `Workspace "{{ .Labels.name }}" was deleted, see {{ base_url }}/workspaces`,
+		},
+		{
+			name:     "does not parse",
+			in:       `Workspace "{{ .Labels.name }}" deleted{{ end }}`,
+			errorMsg: "template parse",
+		},
+		{
+			name:     "unknown label",
+			in:       `Workspace "{{ .Labels.workspace_name }}" deleted`,
+			errorMsg: "unknown labels workspace_name",
+		},
+		{
+			name:     "unknown field",
+			in:       `Hi {{ .UserFullName }}`,
+			errorMsg: "template execute",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := notifications.ValidateTemplate(notifications.TemplateWorkspaceDeleted, tc.in, defaultHelpers())
+			if tc.errorMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.errorMsg)
+		})
+	}
+}
+
+func TestCustomizeTemplate(t *testing.T) {
+	t.Parallel()
+
+	// SETUP
+	if !dbtestutil.WillUsePostgres() {
+		t.Skip("This test requires postgres; notification templates are only stored in the database")
+	}
+
+	ctx, _, db := setup(t)
+
+	original, err := db.GetNotificationTemplateByID(ctx, notifications.TemplateWorkspaceDeleted)
+	require.NoError(t, err)
+	require.False(t, original.DefaultTitleTemplate.Valid)
+
+	// WHEN: the template is customized twice
+	_, err = db.UpdateNotificationTemplateByID(ctx, database.UpdateNotificationTemplateByIDParams{
+		ID:            notifications.TemplateWorkspaceDeleted,
+		TitleTemplate: "First",
+		BodyTemplate:  "First",
+	})
+	require.NoError(t, err)
+	customized, err := db.UpdateNotificationTemplateByID(ctx, database.UpdateNotificationTemplateByIDParams{
+		ID:            notifications.TemplateWorkspaceDeleted,
+		TitleTemplate: "Second",
+		BodyTemplate:  "Second",
+	})
+	require.NoError(t, err)
+
+	// THEN: the original templates are retained as the defaults
+	require.Equal(t, "Second", customized.TitleTemplate)
+	require.Equal(t, original.TitleTemplate, customized.DefaultTitleTemplate.String)
+	require.Equal(t, original.BodyTemplate, customized.DefaultBodyTemplate.String)
+
+	// WHEN: the template is reset
+	reset, err := db.ResetNotificationTemplateByID(ctx, notifications.TemplateWorkspaceDeleted)
+	require.NoError(t, err)
+
+	// THEN: the original templates are restored
+	require.Equal(t, original, reset)
+}
+
+func TestNotificationDigest(t *testing.T) {
+	t.Parallel()
+
+	// SETUP
+	if !dbtestutil.WillUsePostgres() {
+		t.Skip("This test requires postgres; it relies on business-logic only implemented in the database")
+	}
+
+	ctx, logger, db := setup(t)
+	method := database.NotificationMethodSmtp
+
+	// GIVEN: a template whose messages are held for an hour before being delivered as a digest
+	_, err := db.UpdateNotificationTemplateDigestWindowByID(ctx, database.UpdateNotificationTemplateDigestWindowByIDParams{
+		ID:                  notifications.TemplateWorkspaceDeleted,
+		DigestWindowSeconds: int32(time.Hour.Seconds()),
+	})
+	require.NoError(t, err)
+
+	cfg := defaultNotificationsConfig(method)
+	enq, err := notifications.NewStoreEnqueuer(cfg, db, defaultHelpers(), logger.Named("enqueuer"))
+	require.NoError(t, err)
+
+	user := createSampleUser(t, db)
+
+	// WHEN: several messages of the digest template are enqueued, along with one of another template
+	for i := 1; i <= 3; i++ {
+		_, err = enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{
+			"name":   fmt.Sprintf("ws-%d", i),
+			"reason": "autodeleted due to dormancy",
+		}, "test")
+		require.NoError(t, err)
+	}
+	otherID, err := enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceAutobuildFailed, map[string]string{
+		"name":   "ws-4",
+		"reason": "autostart",
+	}, "test")
+	require.NoError(t, err)
+
+	// THEN: only the other message can be acquired while the digest window has not passed
+	acquired, err := db.AcquireNotificationMessages(ctx, database.AcquireNotificationMessagesParams{
+		Count:           10,
+		MaxAttemptCount: 5,
+		NotifierID:      uuid.New(),
+		LeaseSeconds:    int32(time.Hour.Seconds()),
+	})
+	require.NoError(t, err)
+	require.Len(t, acquired, 1)
+	require.Equal(t, *otherID, acquired[0].ID)
+
+	// WHEN: the digest window is shortened so that it has passed, and the messages are dispatched
+	_, err = db.UpdateNotificationTemplateDigestWindowByID(ctx, database.UpdateNotificationTemplateDigestWindowByIDParams{
+		ID:                  notifications.TemplateWorkspaceDeleted,
+		DigestWindowSeconds: 1,
+	})
+	require.NoError(t, err)
+
+	handler := &recordingHandler{}
+	interceptor := &syncInterceptor{Store: db}
+	mgr, err := notifications.NewManager(cfg, interceptor, defaultHelpers(), pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
+	require.NoError(t, err)
+	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
+	t.Cleanup(func() {
+		assert.NoError(t, mgr.Stop(ctx))
+	})
+	mgr.Run(ctx)
+
+	// THEN: all of the messages are marked as sent
+	require.Eventually(t, func() bool {
+		return interceptor.sent.Load() == 3
+	}, testutil.WaitLong, testutil.IntervalFast)
+
+	// THEN: they were delivered as a single digest which lists each of them
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	require.Len(t, handler.titles, 1)
+	require.Equal(t, "Workspace Deleted (3)", handler.titles[0])
+	for i := 1; i <= 3; i++ {
+		require.Contains(t, handler.bodies[0], fmt.Sprintf(`- Workspace "ws-%d" deleted`, i))
+	}
+	require.Nil(t, handler.payloads[0].Labels)
+	require.Len(t, handler.payloads[0].Actions, 2, "identical actions should be deduplicated")
+}
+
+func TestTemplatesAreValid(t *testing.T) {
+	t.Parallel()
+
+	// SETUP
+	if !dbtestutil.WillUsePostgres() {
+		t.Skip("This test requires postgres; notification templates are only stored in the database")
+	}
+
+	ctx, _, db := setup(t)
+
+	for _, id := range []uuid.UUID{
+		notifications.TemplateWorkspaceQuotaReached,
+		notifications.TemplateTemplateVersionPromoted,
+		notifications.TemplateWorkspaceManualBuildFailed,
+		notifications.TemplateUserAccountCreated,
+		notifications.TemplateUserAccountSuspended,
+	} {
+		tmpl, err := db.GetNotificationTemplateByID(ctx, id)
+		require.NoError(t, err)
+
+		// THEN: the templates only reference their known labels, and render with example values
+		require.NoError(t, notifications.ValidateTemplate(id, tmpl.TitleTemplate, defaultHelpers()), tmpl.Name)
+		require.NoError(t, notifications.ValidateTemplate(id, tmpl.BodyTemplate, defaultHelpers()), tmpl.Name)
+	}
+}
+
+// recordingHandler records the notifications it is asked to dispatch, all of which succeed.
+type recordingHandler struct {
+	mu             sync.Mutex
+	titles, bodies []string
+	payloads       []types.MessagePayload
+}
+
+func (r *recordingHandler) Dispatcher(payload types.MessagePayload, title, body string) (dispatch.DeliveryFunc, error) {
+	return func(_ context.Context, _ uuid.UUID) (retryable bool, err error) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.titles = append(r.titles, title)
+		r.bodies = append(r.bodies, body)
+		r.payloads = append(r.payloads, payload)
+		return false, nil
+	}, nil
+}
+
 type fakeHandler struct {
 	mu                sync.RWMutex
 	succeeded, failed []string
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/google/uuid"
@@ -36,9 +37,12 @@ type notifier struct {
 	method   database.NotificationMethod
 	handlers map[database.NotificationMethod]Handler
 	metrics  *Metrics
+
+	// helpers holds a map of template funcs which are used when rendering templates.
+	helpers template.FuncMap
 }
 
-func newNotifier(cfg codersdk.NotificationsConfig, id uuid.UUID, log slog.Logger, db Store, hr map[database.NotificationMethod]Handler, method database.NotificationMethod, metrics *Metrics) *notifier {
+func newNotifier(cfg codersdk.NotificationsConfig, id uuid.UUID, log slog.Logger, db Store, hr map[database.NotificationMethod]Handler, helpers template.FuncMap, method database.NotificationMethod, metrics *Metrics) *notifier {
 	return &notifier{
 		id:       id,
 		cfg:      cfg,
@@ -50,6 +54,7 @@ func newNotifier(cfg codersdk.NotificationsConfig, id uuid.UUID, log slog.Logger
 		handlers: hr,
 		method:   method,
 		metrics:  metrics,
+		helpers:  helpers,
 	}
 }
 
@@ -143,12 +148,14 @@ func (n *notifier) process(ctx context.Context, success chan<- dispatchResult, f
 	}
 
 	var eg errgroup.Group
-	for _, msg := range msgs {
+	for _, batch := range groupDigests(msgs) {
 		// A message failing to be prepared correctly should not affect other messages.
-		deliverFn, err := n.prepare(ctx, msg)
+		deliverFn, err := n.prepare(ctx, batch)
 		if err != nil {
-			n.log.Warn(ctx, "dispatcher construction failed", slog.F("msg_id", msg.ID), slog.Error(err))
-			failure <- n.newFailedDispatch(msg, err, false)
+			n.log.Warn(ctx, "dispatcher construction failed", slog.F("msg_id", batch[0].ID), slog.F("batch_size", len(batch)), slog.Error(err))
+			for _, msg := range batch {
+				failure <- n.newFailedDispatch(msg, err, false)
+			}
 
 			n.metrics.PendingUpdates.Set(float64(len(success) + len(failure)))
 			continue
@@ -156,7 +163,7 @@ func (n *notifier) process(ctx context.Context, success chan<- dispatchResult, f
 
 		eg.Go(func() error {
 			// Dispatch must only return an error for exceptional cases, NOT for failed messages.
-			return n.deliver(ctx, msg, deliverFn, success, failure)
+			return n.deliver(ctx, batch, deliverFn, success, failure)
 		})
 	}
 
@@ -187,42 +194,61 @@ func (n *notifier) fetch(ctx context.Context) ([]database.AcquireNotificationMes
 
 // prepare has two roles:
 // 1. render the title & body templates
-// 2. build a dispatcher from the given message, payload, and these templates - to be used for delivering the notification
-func (n *notifier) prepare(ctx context.Context, msg database.AcquireNotificationMessagesRow) (dispatch.DeliveryFunc, error) {
+// 2. build a dispatcher from the given messages, payloads, and these templates - to be used for delivering the notification
+//
+// The given messages share a recipient, template, and method; if there is more than one, they are combined into a digest.
+func (n *notifier) prepare(ctx context.Context, msgs []database.AcquireNotificationMessagesRow) (dispatch.DeliveryFunc, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
 	}
 
-	// NOTE: when we change the format of the MessagePayload, we have to bump its version and handle unmarshalling
-	// differently here based on that version.
-	var payload types.MessagePayload
-	err := json.Unmarshal(msg.Payload, &payload)
-	if err != nil {
-		return nil, xerrors.Errorf("unmarshal payload: %w", err)
-	}
-
-	handler, ok := n.handlers[msg.Method]
+	handler, ok := n.handlers[msgs[0].Method]
 	if !ok {
-		return nil, xerrors.Errorf("failed to resolve handler %q", msg.Method)
+		return nil, xerrors.Errorf("failed to resolve handler %q", msgs[0].Method)
 	}
 
-	var title, body string
-	if title, err = render.GoTemplate(msg.TitleTemplate, payload, nil); err != nil {
-		return nil, xerrors.Errorf("render title: %w", err)
+	payloads := make([]types.MessagePayload, 0, len(msgs))
+	titles := make([]string, 0, len(msgs))
+	var body string
+	for _, msg := range msgs {
+		// NOTE: when we change the format of the MessagePayload, we have to bump its version and handle unmarshalling
+		// differently here based on that version.
+		var payload types.MessagePayload
+		err := json.Unmarshal(msg.Payload, &payload)
+		if err != nil {
+			return nil, xerrors.Errorf("unmarshal payload: %w", err)
+		}
+
+		title, err := render.GoTemplate(msg.TitleTemplate, payload, n.helpers)
+		if err != nil {
+			return nil, xerrors.Errorf("render title: %w", err)
+		}
+		// The body of each message is not included in a digest, so only the first is rendered.
+		if len(payloads) == 0 {
+			if body, err = render.GoTemplate(msg.BodyTemplate, payload, n.helpers); err != nil {
+				return nil, xerrors.Errorf("render body: %w", err)
+			}
+		}
+
+		payloads = append(payloads, payload)
+		titles = append(titles, title)
 	}
-	if body, err = render.GoTemplate(msg.BodyTemplate, payload, nil); err != nil {
-		return nil, xerrors.Errorf("render body: %w", err)
+
+	if len(msgs) == 1 {
+		return handler.Dispatcher(payloads[0], titles[0], body)
 	}
 
+	payload, title, body := renderDigest(payloads, titles)
 	return handler.Dispatcher(payload, title, body)
 }
 
-// deliver sends a given notification message via its defined method.
+// deliver sends the given notification messages via their defined method. Multiple messages are delivered together as a
+// single digest, which is identified by the first message.
 // This method *only* returns an error when a context error occurs; any other error is interpreted as a failure to
-// deliver the notification and as such the message will be marked as failed (to later be optionally retried).
-func (n *notifier) deliver(ctx context.Context, msg database.AcquireNotificationMessagesRow, deliver dispatch.DeliveryFunc, success, failure chan<- dispatchResult) error {
+// deliver the notification and as such the messages will be marked as failed (to later be optionally retried).
+func (n *notifier) deliver(ctx context.Context, msgs []database.AcquireNotificationMessagesRow, deliver dispatch.DeliveryFunc, success, failure chan<- dispatchResult) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -231,14 +257,20 @@ func (n *notifier) deliver(ctx context.Context, msg database.AcquireNotification
 
 	ctx, cancel := context.WithTimeout(ctx, n.cfg.DispatchTimeout.Value())
 	defer cancel()
+	msg := msgs[0]
 	logger := n.log.With(slog.F("msg_id", msg.ID), slog.F("method", msg.Method), slog.F("attempt", msg.AttemptCount+1))
+	if len(msgs) > 1 {
+		logger = logger.With(slog.F("digest_size", len(msgs)))
+	}
 
-	if msg.AttemptCount > 0 {
-		n.metrics.RetryCount.WithLabelValues(string(n.method), msg.TemplateID.String()).Inc()
+	for _, m := range msgs {
+		if m.AttemptCount > 0 {
+			n.metrics.RetryCount.WithLabelValues(string(n.method), m.TemplateID.String()).Inc()
+		}
+		n.metrics.QueuedSeconds.WithLabelValues(string(n.method)).Observe(m.QueuedSeconds)
 	}
 
 	n.metrics.InflightDispatches.WithLabelValues(string(n.method), msg.TemplateID.String()).Inc()
-	n.metrics.QueuedSeconds.WithLabelValues(string(n.method)).Observe(msg.QueuedSeconds)
 
 	start := time.Now()
 	retryable, err := deliver(ctx, msg.ID)
@@ -259,21 +291,25 @@ func (n *notifier) deliver(ctx context.Context, msg database.AcquireNotification
 			return err
 		}
 
-		select {
-		case <-ctx.Done():
-			logger.Warn(context.Background(), "cannot record dispatch failure result", slog.Error(ctx.Err()))
-			return ctx.Err()
-		case failure <- n.newFailedDispatch(msg, err, retryable):
-			logger.Warn(ctx, "message dispatch failed", slog.Error(err))
+		for _, m := range msgs {
+			select {
+			case <-ctx.Done():
+				logger.Warn(context.Background(), "cannot record dispatch failure result", slog.Error(ctx.Err()))
+				return ctx.Err()
+			case failure <- n.newFailedDispatch(m, err, retryable):
+			}
 		}
+		logger.Warn(ctx, "message dispatch failed", slog.Error(err))
 	} else {
-		select {
-		case <-ctx.Done():
-			logger.Warn(context.Background(), "cannot record dispatch success result", slog.Error(ctx.Err()))
-			return ctx.Err()
-		case success <- n.newSuccessfulDispatch(msg):
-			logger.Debug(ctx, "message dispatch succeeded")
+		for _, m := range msgs {
+			select {
+			case <-ctx.Done():
+				logger.Warn(context.Background(), "cannot record dispatch success result", slog.Error(ctx.Err()))
+				return ctx.Err()
+			case success <- n.newSuccessfulDispatch(m):
+			}
 		}
+		logger.Debug(ctx, "message dispatch succeeded")
 	}
 	n.metrics.PendingUpdates.Set(float64(len(success) + len(failure)))
 
@@ -0,0 +1,41 @@
+package notifications
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// TemplateAdmins returns the IDs of the users who may administer templates, and should therefore be notified of
+// template events.
+func TemplateAdmins(ctx context.Context, store database.Store) ([]uuid.UUID, error) {
+	return findUsersWithRoles(ctx, store, codersdk.RoleOwner, codersdk.RoleTemplateAdmin)
+}
+
+// UserAdmins returns the IDs of the users who may administer users, and should therefore be notified of user events.
+func UserAdmins(ctx context.Context, store database.Store) ([]uuid.UUID, error) {
+	return findUsersWithRoles(ctx, store, codersdk.RoleOwner, codersdk.RoleUserAdmin)
+}
+
+func findUsersWithRoles(ctx context.Context, store database.Store, roles ...string) ([]uuid.UUID, error) {
+	//nolint:gocritic // Recipients are looked up on behalf of the system, regardless of who triggered the event.
+	users, err := store.GetUsers(dbauthz.AsSystemRestricted(ctx), database.GetUsersParams{
+		RbacRole: roles,
+		// Suspended users cannot act on notifications.
+		Status: []database.UserStatus{database.UserStatusActive, database.UserStatusDormant},
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("get users with roles %v: %w", roles, err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	return ids, nil
+}
@@ -1,8 +1,10 @@
 package render
 
 import (
+	"sort"
 	"strings"
 	"text/template"
+	"text/template/parse"
 
 	"golang.org/x/xerrors"
 
@@ -24,3 +26,80 @@ func GoTemplate(in string, payload types.MessagePayload, extraFuncs template.Fun
 
 	return out.String(), nil
 }
+
+// ReferencedLabels parses the given template and returns the sorted names of the payload labels it references, e.g.
+// "name" for "{{ .Labels.name }}". Labels which are only referenced dynamically (such as with "index") are not returned.
+func ReferencedLabels(in string, extraFuncs template.FuncMap) ([]string, error) {
+	tmpl, err := template.New("text").Funcs(extraFuncs).Parse(in)
+	if err != nil {
+		return nil, xerrors.Errorf("template parse: %w", err)
+	}
+
+	seen := make(map[string]struct{})
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			collectLabels(t.Tree.Root, seen)
+		}
+	}
+
+	labels := make([]string, 0, len(seen))
+	for label := range seen {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels, nil
+}
+
+func collectLabels(node parse.Node, seen map[string]struct{}) {
+	addIdent := func(ident []string) {
+		if len(ident) >= 2 && ident[0] == "Labels" {
+			seen[ident[1]] = struct{}{}
+		}
+	}
+
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectLabels(child, seen)
+		}
+	case *parse.ActionNode:
+		collectLabels(n.Pipe, seen)
+	case *parse.IfNode:
+		collectBranchLabels(&n.BranchNode, seen)
+	case *parse.RangeNode:
+		collectBranchLabels(&n.BranchNode, seen)
+	case *parse.WithNode:
+		collectBranchLabels(&n.BranchNode, seen)
+	case *parse.TemplateNode:
+		collectLabels(n.Pipe, seen)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectLabels(cmd, seen)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectLabels(arg, seen)
+		}
+	case *parse.ChainNode:
+		collectLabels(n.Node, seen)
+	case *parse.FieldNode:
+		addIdent(n.Ident)
+	case *parse.VariableNode:
+		// Only references to the root of the payload, e.g. "$.Labels.name", are considered.
+		if len(n.Ident) > 0 && n.Ident[0] == "$" {
+			addIdent(n.Ident[1:])
+		}
+	}
+}
+
+func collectBranchLabels(n *parse.BranchNode, seen map[string]struct{}) {
+	collectLabels(n.Pipe, seen)
+	collectLabels(n.List, seen)
+	collectLabels(n.ElseList, seen)
+}
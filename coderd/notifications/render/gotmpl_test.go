@@ -77,3 +77,51 @@ func TestGoTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestReferencedLabels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		in       string
+		expected []string
+		errorMsg string
+	}{
+		{
+			name:     "no labels",
+			in:       "Hi {{ .UserName }}",
+			expected: []string{},
+		},
+		{
+			name:     "labels are deduplicated and sorted",
+			in:       `Workspace "{{ .Labels.name }}" was {{ .Labels.reason }}; see {{ .Labels.name }}`,
+			expected: []string{"name", "reason"},
+		},
+		{
+			name:     "labels in branches and root variables",
+			in:       `{{ if .Labels.initiator }}({{ $.Labels.initiator }}){{ else }}{{ .Labels.fallback }}{{ end }}`,
+			expected: []string{"fallback", "initiator"},
+		},
+		{
+			name:     "invalid template",
+			in:       "{{ .Labels.name }}{{ end }}",
+			errorMsg: "template parse",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc // unnecessary as of go1.22 but the linter is outdated
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			labels, err := render.ReferencedLabels(tc.in, nil)
+			if tc.errorMsg != "" {
+				require.ErrorContains(t, err, tc.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, labels)
+		})
+	}
+}
@@ -22,6 +22,8 @@ type Store interface {
 	FetchNewMessageMetadata(ctx context.Context, arg database.FetchNewMessageMetadataParams) (database.FetchNewMessageMetadataRow, error)
 	GetNotificationMessagesByStatus(ctx context.Context, arg database.GetNotificationMessagesByStatusParams) ([]database.NotificationMessage, error)
 	GetNotificationsSettings(ctx context.Context) (string, error)
+	GetUserNotificationPreference(ctx context.Context, arg database.GetUserNotificationPreferenceParams) (database.NotificationPreference, error)
+	InsertInboxNotification(ctx context.Context, arg database.InsertInboxNotificationParams) error
 }
 
 // Handler is responsible for preparing and delivering a notification by a given method.
@@ -31,6 +33,7 @@ type Handler interface {
 }
 
 // Enqueuer enqueues a new notification message in the store and returns its ID, should it enqueue without failure.
+// If the user has disabled notifications of the given template, nothing is enqueued and a nil ID is returned.
 type Enqueuer interface {
 	Enqueue(ctx context.Context, userID, templateID uuid.UUID, labels map[string]string, createdBy string, targets ...uuid.UUID) (*uuid.UUID, error)
 }
@@ -0,0 +1,71 @@
+package notifications
+
+import (
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/notifications/render"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+)
+
+// KnownLabels returns the sorted names of the labels which may be referenced by the given template.
+func KnownLabels(templateID uuid.UUID) []string {
+	labels := make([]string, 0, len(TemplateLabels[templateID]))
+	for label := range TemplateLabels[templateID] {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// SamplePayload builds a payload with example values for the given template, with which it can be previewed.
+// The given labels take precedence over the example labels.
+func SamplePayload(templateID uuid.UUID, templateName string, labels map[string]string) types.MessagePayload {
+	merged := make(map[string]string, len(TemplateLabels[templateID])+len(labels))
+	for k, v := range TemplateLabels[templateID] {
+		merged[k] = v
+	}
+	for k, v := range labels {
+		merged[k] = v
+	}
+
+	return types.MessagePayload{
+		Version:          "1.0",
+		NotificationName: templateName,
+		UserID:           uuid.Nil.String(),
+		UserEmail:        "bobby@coder.com",
+		UserName:         "Bobby",
+		UserUsername:     "bobby",
+		Labels:           merged,
+	}
+}
+
+// ValidateTemplate ensures that the given title or body template parses, only references labels which are passed to the
+// template with the given ID, and can be rendered with the given helpers. These must be the helpers the notifier renders
+// messages with.
+func ValidateTemplate(templateID uuid.UUID, in string, helpers template.FuncMap) error {
+	labels, err := render.ReferencedLabels(in, helpers)
+	if err != nil {
+		return err
+	}
+
+	var unknown []string
+	for _, label := range labels {
+		if _, ok := TemplateLabels[templateID][label]; !ok {
+			unknown = append(unknown, label)
+		}
+	}
+	if len(unknown) > 0 {
+		return xerrors.Errorf("unknown labels %s; known labels are: %s", strings.Join(unknown, ", "), strings.Join(KnownLabels(templateID), ", "))
+	}
+
+	// Rendering catches references to fields which are not part of the payload.
+	if _, err := render.GoTemplate(in, SamplePayload(templateID, "", nil), helpers); err != nil {
+		return err
+	}
+	return nil
+}
@@ -1,12 +1,21 @@
 package coderd_test
 
 import (
+	"fmt"
 	"net/http"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"cdr.dev/slog/sloggers/slogtest"
+
 	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtestutil"
+	"github.com/coder/coder/v2/coderd/notifications"
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/notifications/types"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/testutil"
 )
@@ -93,3 +102,390 @@ func TestUpdateNotificationsSettings(t *testing.T) {
 		require.Equal(t, expected.NotifierPaused, actual.NotifierPaused)
 	})
 }
+
+func TestUserNotificationPreferences(t *testing.T) {
+	t.Parallel()
+
+	if !dbtestutil.WillUsePostgres() {
+		t.Skip("This test requires postgres; notification templates are only stored in the database")
+	}
+
+	t.Run("Update preferences", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		firstUser := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, firstUser.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		// given: every notification is enabled by default
+		prefs, err := member.GetUserNotificationPreferences(ctx, codersdk.Me)
+		require.NoError(t, err)
+		require.NotEmpty(t, prefs)
+		for _, pref := range prefs {
+			require.False(t, pref.Disabled)
+			require.Empty(t, pref.Method)
+			require.Nil(t, pref.UpdatedAt)
+		}
+
+		// when
+		prefs, err = member.UpdateUserNotificationPreferences(ctx, codersdk.Me, codersdk.UpdateUserNotificationPreferences{
+			Preferences: []codersdk.UpdateNotificationPreference{{
+				NotificationTemplateID: notifications.TemplateWorkspaceDeleted,
+				Disabled:               true,
+				Method:                 string(database.NotificationMethodWebhook),
+			}},
+		})
+		require.NoError(t, err)
+
+		// then
+		var found bool
+		for _, pref := range prefs {
+			if pref.NotificationTemplateID != notifications.TemplateWorkspaceDeleted {
+				require.False(t, pref.Disabled)
+				continue
+			}
+			found = true
+			require.True(t, pref.Disabled)
+			require.Equal(t, string(database.NotificationMethodWebhook), pref.Method)
+			require.NotNil(t, pref.UpdatedAt)
+		}
+		require.True(t, found)
+	})
+
+	t.Run("Invalid method", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		_, err := client.UpdateUserNotificationPreferences(ctx, codersdk.Me, codersdk.UpdateUserNotificationPreferences{
+			Preferences: []codersdk.UpdateNotificationPreference{{
+				NotificationTemplateID: notifications.TemplateWorkspaceDeleted,
+				Method:                 "carrier-pigeon",
+			}},
+		})
+		var sdkError *codersdk.Error
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+	})
+
+	t.Run("Other user", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		firstUser := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, firstUser.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		_, err := member.UpdateUserNotificationPreferences(ctx, firstUser.UserID.String(), codersdk.UpdateUserNotificationPreferences{
+			Preferences: []codersdk.UpdateNotificationPreference{{
+				NotificationTemplateID: notifications.TemplateWorkspaceDeleted,
+				Disabled:               true,
+			}},
+		})
+		var sdkError *codersdk.Error
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusForbidden, sdkError.StatusCode())
+	})
+}
+
+func TestNotificationTemplates(t *testing.T) {
+	t.Parallel()
+
+	if !dbtestutil.WillUsePostgres() {
+		t.Skip("This test requires postgres; notification templates are only stored in the database")
+	}
+
+	t.Run("Customize and reset", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		// given
+		templates, err := client.GetNotificationTemplates(ctx)
+		require.NoError(t, err)
+		var original codersdk.NotificationTemplate
+		for _, tmpl := range templates {
+			if tmpl.ID == notifications.TemplateWorkspaceDeleted {
+				original = tmpl
+			}
+		}
+		require.Equal(t, notifications.TemplateWorkspaceDeleted, original.ID)
+		require.False(t, original.Customized)
+		require.Contains(t, original.Labels, "name")
+
+		// when
+		updated, err := client.UpdateNotificationTemplate(ctx, original.ID, codersdk.UpdateNotificationTemplateRequest{
+			TitleTemplate: `Goodbye, "{{.Labels.name}}"`,
+			BodyTemplate:  original.BodyTemplate,
+		})
+		require.NoError(t, err)
+
+		// then
+		require.True(t, updated.Customized)
+		require.Equal(t, `Goodbye, "{{.Labels.name}}"`, updated.TitleTemplate)
+		require.Equal(t, original.BodyTemplate, updated.BodyTemplate)
+
+		preview, err := client.PreviewNotificationTemplate(ctx, original.ID, codersdk.PreviewNotificationTemplateRequest{
+			Labels: map[string]string{"name": "my-workspace"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, `Goodbye, "my-workspace"`, preview.Title)
+
+		// when
+		reset, err := client.ResetNotificationTemplate(ctx, original.ID)
+		require.NoError(t, err)
+
+		// then
+		require.Equal(t, original, reset)
+	})
+
+	t.Run("Invalid template", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		for _, req := range []codersdk.UpdateNotificationTemplateRequest{
+			{TitleTemplate: "{{ .Labels.name ", BodyTemplate: "body"},
+			{TitleTemplate: "title", BodyTemplate: "{{ .Labels.unknown }}"},
+		} {
+			_, err := client.UpdateNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted, req)
+			var sdkError *codersdk.Error
+			require.ErrorAs(t, err, &sdkError)
+			require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+			require.Len(t, sdkError.Validations, 1)
+		}
+	})
+
+	t.Run("Template helpers", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, &coderdtest.Options{
+			NotificationsTemplateHelpers: map[string]any{
+				"base_url": func() string { return "https://coder.example.com" },
+			},
+		})
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		// when
+		_, err := client.UpdateNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted, codersdk.UpdateNotificationTemplateRequest{
+			TitleTemplate: `Workspace "{{ .Labels.name }}" deleted`,
+			BodyTemplate:  `See {{ base_url }}/workspaces`,
+		})
+		require.NoError(t, err)
+
+		// then
+		preview, err := client.PreviewNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted, codersdk.PreviewNotificationTemplateRequest{})
+		require.NoError(t, err)
+		require.Equal(t, "See https://coder.example.com/workspaces", preview.Body)
+	})
+
+	t.Run("Digest window", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		// when
+		updated, err := client.UpdateNotificationTemplateDigest(ctx, notifications.TemplateWorkspaceDeleted, codersdk.UpdateNotificationTemplateDigestRequest{
+			DigestWindowSeconds: 3600,
+		})
+		require.NoError(t, err)
+
+		// then
+		require.EqualValues(t, 3600, updated.DigestWindowSeconds)
+		require.False(t, updated.Customized, "the digest window does not customize the template's content")
+
+		// when
+		_, err = client.UpdateNotificationTemplateDigest(ctx, notifications.TemplateWorkspaceDeleted, codersdk.UpdateNotificationTemplateDigestRequest{
+			DigestWindowSeconds: -1,
+		})
+
+		// then
+		var sdkError *codersdk.Error
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+	})
+
+	t.Run("Permissions denied", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		firstUser := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, firstUser.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		_, err := member.GetNotificationTemplates(ctx)
+		var sdkError *codersdk.Error
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusForbidden, sdkError.StatusCode())
+
+		// Members cannot read the template, so it is indistinguishable from a missing one.
+		_, err = member.UpdateNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted, codersdk.UpdateNotificationTemplateRequest{
+			TitleTemplate: "title",
+			BodyTemplate:  "body",
+		})
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusNotFound, sdkError.StatusCode())
+	})
+}
+
+func TestUserInboxNotifications(t *testing.T) {
+	t.Parallel()
+
+	deliver := func(t *testing.T, handler *dispatch.InboxHandler, userID uuid.UUID, title string) uuid.UUID {
+		t.Helper()
+
+		deliveryFn, err := handler.Dispatcher(types.MessagePayload{
+			UserID:  userID.String(),
+			Actions: []types.TemplateAction{{Label: "View", URL: "https://coder.com"}},
+		}, title, "**body**")
+		require.NoError(t, err)
+
+		msgID := uuid.New()
+		retryable, err := deliveryFn(testutil.Context(t, testutil.WaitShort), msgID)
+		require.NoError(t, err)
+		require.False(t, retryable)
+		return msgID
+	}
+
+	t.Run("List and mark as read", func(t *testing.T) {
+		t.Parallel()
+
+		db, ps := dbtestutil.NewDB(t)
+		client := coderdtest.New(t, &coderdtest.Options{Database: db, Pubsub: ps})
+		firstUser := coderdtest.CreateFirstUser(t, client)
+		member, memberUser := coderdtest.CreateAnotherUser(t, client, firstUser.OrganizationID)
+		handler := dispatch.NewInboxHandler(db, ps, slogtest.Make(t, nil))
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		// given
+		first := deliver(t, handler, memberUser.ID, "First")
+		second := deliver(t, handler, memberUser.ID, "Second")
+
+		// when
+		res, err := member.GetInboxNotifications(ctx, codersdk.Me, codersdk.InboxNotificationsRequest{})
+		require.NoError(t, err)
+
+		// then: newest first
+		require.Equal(t, 2, res.UnreadCount)
+		require.Len(t, res.Notifications, 2)
+		require.Equal(t, second, res.Notifications[0].ID)
+		require.Equal(t, first, res.Notifications[1].ID)
+		require.Equal(t, "First", res.Notifications[1].Title)
+		require.Equal(t, "**body**", res.Notifications[1].Content)
+		require.Equal(t, []codersdk.InboxNotificationAction{{Label: "View", URL: "https://coder.com"}}, res.Notifications[1].Actions)
+		require.Nil(t, res.Notifications[1].ReadAt)
+
+		// when
+		notif, err := member.UpdateInboxNotificationReadStatus(ctx, codersdk.Me, first, codersdk.UpdateInboxNotificationReadStatusRequest{IsRead: true})
+		require.NoError(t, err)
+		require.NotNil(t, notif.ReadAt)
+
+		// then
+		res, err = member.GetInboxNotifications(ctx, codersdk.Me, codersdk.InboxNotificationsRequest{UnreadOnly: true})
+		require.NoError(t, err)
+		require.Equal(t, 1, res.UnreadCount)
+		require.Len(t, res.Notifications, 1)
+		require.Equal(t, second, res.Notifications[0].ID)
+
+		// when
+		err = member.MarkAllInboxNotificationsAsRead(ctx, codersdk.Me)
+		require.NoError(t, err)
+
+		// then
+		res, err = member.GetInboxNotifications(ctx, codersdk.Me, codersdk.InboxNotificationsRequest{})
+		require.NoError(t, err)
+		require.Zero(t, res.UnreadCount)
+		require.Len(t, res.Notifications, 2)
+		for _, n := range res.Notifications {
+			require.NotNil(t, n.ReadAt)
+		}
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		t.Parallel()
+
+		db, ps := dbtestutil.NewDB(t)
+		client := coderdtest.New(t, &coderdtest.Options{Database: db, Pubsub: ps})
+		firstUser := coderdtest.CreateFirstUser(t, client)
+		handler := dispatch.NewInboxHandler(db, ps, slogtest.Make(t, nil))
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		for i := 0; i < 3; i++ {
+			_ = deliver(t, handler, firstUser.UserID, fmt.Sprintf("Notification %d", i))
+		}
+
+		page, err := client.GetInboxNotifications(ctx, codersdk.Me, codersdk.InboxNotificationsRequest{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, page.Notifications, 2)
+		require.Equal(t, 3, page.UnreadCount)
+
+		rest, err := client.GetInboxNotifications(ctx, codersdk.Me, codersdk.InboxNotificationsRequest{
+			StartingBefore: page.Notifications[1].ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, rest.Notifications, 1)
+		require.Equal(t, "Notification 0", rest.Notifications[0].Title)
+	})
+
+	t.Run("Other user", func(t *testing.T) {
+		t.Parallel()
+
+		db, ps := dbtestutil.NewDB(t)
+		client := coderdtest.New(t, &coderdtest.Options{Database: db, Pubsub: ps})
+		firstUser := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, firstUser.OrganizationID)
+		handler := dispatch.NewInboxHandler(db, ps, slogtest.Make(t, nil))
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		id := deliver(t, handler, firstUser.UserID, "Private")
+
+		_, err := member.GetInboxNotifications(ctx, firstUser.UserID.String(), codersdk.InboxNotificationsRequest{})
+		require.Error(t, err)
+
+		var sdkError *codersdk.Error
+		_, err = member.UpdateInboxNotificationReadStatus(ctx, codersdk.Me, id, codersdk.UpdateInboxNotificationReadStatusRequest{IsRead: true})
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusNotFound, sdkError.StatusCode())
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		t.Parallel()
+
+		db, ps := dbtestutil.NewDB(t)
+		client := coderdtest.New(t, &coderdtest.Options{Database: db, Pubsub: ps})
+		firstUser := coderdtest.CreateFirstUser(t, client)
+		handler := dispatch.NewInboxHandler(db, ps, slogtest.Make(t, nil))
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		notifs, err := client.WatchInboxNotifications(ctx, codersdk.Me)
+		require.NoError(t, err)
+
+		id := deliver(t, handler, firstUser.UserID, "Watched")
+
+		notif := testutil.RequireRecvCtx(ctx, t, notifs)
+		require.Equal(t, id, notif.ID)
+		require.Equal(t, "Watched", notif.Title)
+	})
+}
@@ -113,13 +113,18 @@ func (api *API) postOAuth2ProviderApp(rw http.ResponseWriter, r *http.Request) {
 	if !httpapi.Read(ctx, rw, r, &req) {
 		return
 	}
+	serviceAccountID, ok := api.oAuth2ProviderAppServiceAccount(rw, r, req.ServiceAccountID)
+	if !ok {
+		return
+	}
 	app, err := api.Database.InsertOAuth2ProviderApp(ctx, database.InsertOAuth2ProviderAppParams{
-		ID:          uuid.New(),
-		CreatedAt:   dbtime.Now(),
-		UpdatedAt:   dbtime.Now(),
-		Name:        req.Name,
-		Icon:        req.Icon,
-		CallbackURL: req.CallbackURL,
+		ID:               uuid.New(),
+		CreatedAt:        dbtime.Now(),
+		UpdatedAt:        dbtime.Now(),
+		Name:             req.Name,
+		Icon:             req.Icon,
+		CallbackURL:      req.CallbackURL,
+		ServiceAccountID: serviceAccountID,
 	})
 	if err != nil {
 		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
@@ -160,12 +165,17 @@ func (api *API) putOAuth2ProviderApp(rw http.ResponseWriter, r *http.Request) {
 	if !httpapi.Read(ctx, rw, r, &req) {
 		return
 	}
+	serviceAccountID, ok := api.oAuth2ProviderAppServiceAccount(rw, r, req.ServiceAccountID)
+	if !ok {
+		return
+	}
 	app, err := api.Database.UpdateOAuth2ProviderAppByID(ctx, database.UpdateOAuth2ProviderAppByIDParams{
-		ID:          app.ID,
-		UpdatedAt:   dbtime.Now(),
-		Name:        req.Name,
-		Icon:        req.Icon,
-		CallbackURL: req.CallbackURL,
+		ID:               app.ID,
+		UpdatedAt:        dbtime.Now(),
+		Name:             req.Name,
+		Icon:             req.Icon,
+		CallbackURL:      req.CallbackURL,
+		ServiceAccountID: serviceAccountID,
 	})
 	if err != nil {
 		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
@@ -178,6 +188,31 @@ func (api *API) putOAuth2ProviderApp(rw http.ResponseWriter, r *http.Request) {
 	httpapi.Write(ctx, rw, http.StatusOK, db2sdk.OAuth2ProviderApp(api.AccessURL, app))
 }
 
+// oAuth2ProviderAppServiceAccount validates that the service account an app
+// acts as with the client credentials grant exists.
+func (api *API) oAuth2ProviderAppServiceAccount(rw http.ResponseWriter, r *http.Request, id *uuid.UUID) (uuid.NullUUID, bool) {
+	ctx := r.Context()
+	if id == nil {
+		return uuid.NullUUID{}, true
+	}
+	user, err := api.Database.GetUserByID(ctx, *id)
+	if err != nil && !httpapi.Is404Error(err) {
+		httpapi.InternalServerError(rw, err)
+		return uuid.NullUUID{}, false
+	}
+	if err != nil || !user.IsServiceAccount || user.Deleted {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid service account.",
+			Validations: []codersdk.ValidationError{{
+				Field:  "service_account_id",
+				Detail: fmt.Sprintf("%q is not a service account", id),
+			}},
+		})
+		return uuid.NullUUID{}, false
+	}
+	return uuid.NullUUID{UUID: user.ID, Valid: true}, true
+}
+
 // @Summary Delete OAuth2 application.
 // @ID delete-oauth2-application
 // @Security CoderSessionToken
@@ -336,6 +371,8 @@ func (api *API) deleteOAuth2ProviderAppSecret(rw http.ResponseWriter, r *http.Re
 // @Param response_type query codersdk.OAuth2ProviderResponseType true "Response type"
 // @Param redirect_uri query string false "Redirect here after authorization"
 // @Param scope query string false "Token scopes (currently ignored)"
+// @Param code_challenge query string false "PKCE code challenge, required for public clients"
+// @Param code_challenge_method query codersdk.OAuth2PKCECodeChallengeMethod false "PKCE code challenge method, required with code_challenge"
 // @Success 302
 // @Router /oauth2/authorize [post]
 func (api *API) getOAuth2ProviderAppAuthorize() http.HandlerFunc {
@@ -346,10 +383,13 @@ func (api *API) getOAuth2ProviderAppAuthorize() http.HandlerFunc {
 // @ID oauth2-token-exchange
 // @Produce json
 // @Tags Enterprise
-// @Param client_id formData string false "Client ID, required if grant_type=authorization_code"
-// @Param client_secret formData string false "Client secret, required if grant_type=authorization_code"
+// @Param client_id formData string false "Client ID, required unless grant_type=refresh_token"
+// @Param client_secret formData string false "Client secret, required if grant_type=client_credentials or if grant_type=authorization_code without PKCE"
 // @Param code formData string false "Authorization code, required if grant_type=authorization_code"
+// @Param code_verifier formData string false "PKCE code verifier, required if the code was requested with a code challenge"
+// @Param device_code formData string false "Device code, required if grant_type=urn:ietf:params:oauth:grant-type:device_code"
 // @Param refresh_token formData string false "Refresh token, required if grant_type=refresh_token"
+// @Param scope formData string false "Space separated <resource>:<action> permissions, required if grant_type=client_credentials"
 // @Param grant_type formData codersdk.OAuth2ProviderGrantType true "Grant type"
 // @Success 200 {object} oauth2.Token
 // @Router /oauth2/tokens [post]
@@ -367,3 +407,62 @@ func (api *API) postOAuth2ProviderAppToken() http.HandlerFunc {
 func (api *API) deleteOAuth2ProviderAppTokens() http.HandlerFunc {
 	return identityprovider.RevokeApp(api.Database)
 }
+
+// @Summary OAuth2 device authorization request.
+// @ID oauth2-device-authorization-request
+// @Produce json
+// @Tags Enterprise
+// @Param client_id formData string true "Client ID"
+// @Param client_secret formData string false "Client secret"
+// @Param scope formData string false "Token scopes (currently ignored)"
+// @Success 200 {object} codersdk.OAuth2DeviceAuthorizationResponse
+// @Router /oauth2/device [post]
+func (api *API) postOAuth2ProviderAppDeviceAuthorization() http.HandlerFunc {
+	return identityprovider.DeviceAuthorization(api.Database, api.AccessURL)
+}
+
+// @Summary OAuth2 device verification.
+// @ID oauth2-device-verification
+// @Security CoderSessionToken
+// @Tags Enterprise
+// @Param user_code query string false "User code displayed on the device"
+// @Success 200
+// @Router /oauth2/device/verify [get]
+func (api *API) getOAuth2ProviderAppDeviceVerify() http.HandlerFunc {
+	return identityprovider.DeviceVerify(api.Database, api.AccessURL)
+}
+
+// @Summary OAuth2 token introspection.
+// @ID oauth2-token-introspection
+// @Produce json
+// @Tags Enterprise
+// @Param client_id formData string true "Client ID"
+// @Param client_secret formData string true "Client secret"
+// @Param token formData string true "Access token"
+// @Param token_type_hint formData string false "Token type hint (ignored)"
+// @Success 200 {object} codersdk.OAuth2TokenIntrospection
+// @Router /oauth2/introspect [post]
+func (api *API) postOAuth2ProviderAppIntrospect() http.HandlerFunc {
+	return identityprovider.Introspect(api.Database)
+}
+
+// @Summary OAuth2 user info.
+// @ID oauth2-user-info
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Enterprise
+// @Success 200 {object} codersdk.OAuth2UserInfo
+// @Router /oauth2/userinfo [get]
+func (api *API) getOAuth2ProviderUserInfo() http.HandlerFunc {
+	return identityprovider.UserInfo(api.Database)
+}
+
+// @Summary OAuth2 authorization server metadata.
+// @ID oauth2-authorization-server-metadata
+// @Produce json
+// @Tags Enterprise
+// @Success 200 {object} codersdk.OAuth2ProviderMetadata
+// @Router /.well-known/oauth-authorization-server [get]
+func (api *API) getOAuth2ProviderDiscovery() http.HandlerFunc {
+	return identityprovider.Discovery(api.AccessURL)
+}
@@ -2,16 +2,20 @@ package coderd_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 
 	"github.com/coder/coder/v2/coderd/apikey"
 	"github.com/coder/coder/v2/coderd/coderdtest"
@@ -377,6 +381,10 @@ func TestOAuth2ProviderTokenExchange(t *testing.T) {
 	//nolint:gocritic // OAauth2 app management requires owner permission.
 	secret, err := ownerClient.PostOAuth2ProviderAppSecret(topCtx, apps.Default.ID)
 	require.NoError(t, err)
+	//nolint:gocritic // OAauth2 app management requires owner permission.
+	otherSecret, err := ownerClient.PostOAuth2ProviderAppSecret(topCtx, apps.NoPort.ID)
+	require.NoError(t, err)
+	verifier := oauth2.GenerateVerifier()
 
 	// The typical oauth2 flow from this point is:
 	// Create an oauth2.Config using the id, secret, endpoints, and redirect:
@@ -401,6 +409,7 @@ func TestOAuth2ProviderTokenExchange(t *testing.T) {
 		// The flow is setup(ctx, client, user) -> preAuth(cfg) -> cfg.AuthCodeURL() -> preToken(cfg) -> cfg.Exchange()
 		setup      func(context.Context, *codersdk.Client, codersdk.User) error
 		preAuth    func(valid *oauth2.Config)
+		authMutate []oauth2.AuthCodeOption
 		authError  string
 		preToken   func(valid *oauth2.Config)
 		tokenError string
@@ -578,7 +587,55 @@ func TestOAuth2ProviderTokenExchange(t *testing.T) {
 			preToken: func(valid *oauth2.Config) {
 				valid.ClientSecret = ""
 			},
-			tokenError: "Invalid query params",
+			tokenError: "Invalid client secret",
+		},
+		{
+			name: "OtherAppSecret",
+			app:  apps.Default,
+			preToken: func(valid *oauth2.Config) {
+				valid.ClientSecret = otherSecret.ClientSecretFull
+			},
+			tokenError: "Invalid client secret",
+		},
+		{
+			name:       "PKCEPublicClient",
+			app:        apps.Default,
+			authMutate: []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(verifier)},
+			preToken: func(valid *oauth2.Config) {
+				valid.ClientSecret = ""
+			},
+			exchangeMutate: []oauth2.AuthCodeOption{oauth2.VerifierOption(verifier)},
+		},
+		{
+			name:           "PKCEConfidentialClient",
+			app:            apps.Default,
+			authMutate:     []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(verifier)},
+			exchangeMutate: []oauth2.AuthCodeOption{oauth2.VerifierOption(verifier)},
+		},
+		{
+			name:       "PKCEMissingVerifier",
+			app:        apps.Default,
+			authMutate: []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(verifier)},
+			preToken: func(valid *oauth2.Config) {
+				valid.ClientSecret = ""
+			},
+			tokenError: "Invalid code verifier",
+		},
+		{
+			name:           "PKCEWrongVerifier",
+			app:            apps.Default,
+			authMutate:     []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(verifier)},
+			exchangeMutate: []oauth2.AuthCodeOption{oauth2.VerifierOption(oauth2.GenerateVerifier())},
+			tokenError:     "Invalid code verifier",
+		},
+		{
+			name: "PKCEPlainMethod",
+			app:  apps.Default,
+			authMutate: []oauth2.AuthCodeOption{
+				oauth2.SetAuthURLParam("code_challenge", verifier),
+				oauth2.SetAuthURLParam("code_challenge_method", "plain"),
+			},
+			authError: "Invalid query params",
 		},
 		{
 			name:        "NoCodeScheme",
@@ -699,7 +756,7 @@ func TestOAuth2ProviderTokenExchange(t *testing.T) {
 				code = *test.defaultCode
 			} else {
 				var err error
-				code, err = authorizationFlow(ctx, userClient, valid)
+				code, err = authorizationFlow(ctx, userClient, valid, test.authMutate...)
 				if test.authError != "" {
 					require.Error(t, err)
 					require.ErrorContains(t, err, test.authError)
@@ -836,7 +893,8 @@ func TestOAuth2ProviderTokenRefresh(t *testing.T) {
 				ExpiresAt:   expires,
 				HashPrefix:  []byte(token.Prefix),
 				RefreshHash: []byte(token.Hashed),
-				AppSecretID: secret.ID,
+				AppSecretID: uuid.NullUUID{UUID: secret.ID, Valid: true},
+				AppID:       test.app.ID,
 				APIKeyID:    newKey.ID,
 			})
 			require.NoError(t, err)
@@ -1053,6 +1111,398 @@ func TestOAuth2ProviderRevoke(t *testing.T) {
 	}
 }
 
+func TestOAuth2ProviderClientCredentials(t *testing.T) {
+	t.Parallel()
+
+	db, pubsub := dbtestutil.NewDB(t)
+	ownerClient := coderdtest.New(t, &coderdtest.Options{
+		Database: db,
+		Pubsub:   pubsub,
+	})
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	topCtx := testutil.Context(t, testutil.WaitLong)
+	apps := generateApps(topCtx, t, ownerClient, "client-creds")
+
+	//nolint:gocritic // Service accounts are managed by org admins.
+	account, err := ownerClient.CreateServiceAccount(topCtx, owner.OrganizationID, codersdk.CreateServiceAccountRequest{
+		Username: "oauth2-bot",
+	})
+	require.NoError(t, err)
+
+	//nolint:gocritic // OAauth2 app management requires owner permission.
+	app, err := ownerClient.PostOAuth2ProviderApp(topCtx, codersdk.PostOAuth2ProviderAppRequest{
+		Name:             "machine-app",
+		CallbackURL:      "http://localhost:3000",
+		ServiceAccountID: &account.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &account.ID, app.ServiceAccountID)
+	//nolint:gocritic // OAauth2 app management requires owner permission.
+	secret, err := ownerClient.PostOAuth2ProviderAppSecret(topCtx, app.ID)
+	require.NoError(t, err)
+	//nolint:gocritic // OAauth2 app management requires owner permission.
+	defaultSecret, err := ownerClient.PostOAuth2ProviderAppSecret(topCtx, apps.Default.ID)
+	require.NoError(t, err)
+
+	config := func(app codersdk.OAuth2ProviderApp, secret string, scopes ...string) *clientcredentials.Config {
+		return &clientcredentials.Config{
+			ClientID:     app.ID.String(),
+			ClientSecret: secret,
+			TokenURL:     app.Endpoints.Token,
+			Scopes:       scopes,
+			AuthStyle:    oauth2.AuthStyleInParams,
+		}
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		token, err := config(app, secret.ClientSecretFull, "user:read").Token(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, token.AccessToken)
+		// The app can always get a new token with its secret.
+		require.Empty(t, token.RefreshToken)
+
+		accountClient := codersdk.New(ownerClient.URL)
+		accountClient.SetSessionToken(token.AccessToken)
+		me, err := accountClient.User(ctx, codersdk.Me)
+		require.NoError(t, err)
+		require.Equal(t, account.ID, me.ID)
+
+		introspection := introspect(ctx, t, ownerClient, url.Values{
+			"client_id":     {app.ID.String()},
+			"client_secret": {secret.ClientSecretFull},
+			"token":         {token.AccessToken},
+		})
+		require.True(t, introspection.Active)
+		require.Equal(t, "user:read", introspection.Scope)
+	})
+
+	t.Run("MultipleTokens", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		// A new grant does not revoke the tokens of other clients that use the
+		// same credentials.
+		first, err := config(app, secret.ClientSecretFull, "user:read").Token(ctx)
+		require.NoError(t, err)
+		second, err := config(app, secret.ClientSecretFull, "user:read").Token(ctx)
+		require.NoError(t, err)
+		require.NotEqual(t, first.AccessToken, second.AccessToken)
+
+		for _, token := range []*oauth2.Token{first, second} {
+			accountClient := codersdk.New(ownerClient.URL)
+			accountClient.SetSessionToken(token.AccessToken)
+			me, err := accountClient.User(ctx, codersdk.Me)
+			require.NoError(t, err)
+			require.Equal(t, account.ID, me.ID)
+		}
+	})
+
+	t.Run("NoServiceAccount", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_, err := config(apps.Default, defaultSecret.ClientSecretFull, "user:read").Token(ctx)
+		require.ErrorContains(t, err, "App does not have a service account")
+	})
+
+	t.Run("OtherAppSecret", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_, err := config(app, defaultSecret.ClientSecretFull, "user:read").Token(ctx)
+		require.ErrorContains(t, err, "Invalid client secret")
+	})
+
+	t.Run("MissingScope", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_, err := config(app, secret.ClientSecretFull).Token(ctx)
+		require.ErrorContains(t, err, "Invalid query params")
+	})
+
+	t.Run("InvalidScope", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_, err := config(app, secret.ClientSecretFull, "user:fly").Token(ctx)
+		require.ErrorContains(t, err, "invalid_scope")
+	})
+
+	t.Run("NotServiceAccount", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		//nolint:gocritic // OAauth2 app management requires owner permission.
+		_, err := ownerClient.PostOAuth2ProviderApp(ctx, codersdk.PostOAuth2ProviderAppRequest{
+			Name:             "human-app",
+			CallbackURL:      "http://localhost:3000",
+			ServiceAccountID: &owner.UserID,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+}
+
+func TestOAuth2ProviderDeviceFlow(t *testing.T) {
+	t.Parallel()
+
+	db, pubsub := dbtestutil.NewDB(t)
+	ownerClient := coderdtest.New(t, &coderdtest.Options{
+		Database: db,
+		Pubsub:   pubsub,
+	})
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	ctx := testutil.Context(t, testutil.WaitLong)
+	apps := generateApps(ctx, t, ownerClient, "device-flow")
+	userClient, user := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+
+	// Devices are public clients, so no secret is used.
+	cfg := &oauth2.Config{
+		ClientID: apps.Default.ID.String(),
+		Endpoint: oauth2.Endpoint{
+			DeviceAuthURL: apps.Default.Endpoints.DeviceAuth,
+			TokenURL:      apps.Default.Endpoints.Token,
+			AuthStyle:     oauth2.AuthStyleInParams,
+		},
+	}
+	da, err := cfg.DeviceAuth(ctx)
+	require.NoError(t, err)
+	require.Regexp(t, `^[A-Z]{4}-[A-Z]{4}$`, da.UserCode)
+	require.Equal(t, ownerClient.URL.JoinPath("/oauth2/device/verify").String(), da.VerificationURI)
+
+	poll := func() (*http.Response, error) {
+		return postForm(ctx, ownerClient, "/oauth2/tokens", url.Values{
+			"client_id":   {apps.Default.ID.String()},
+			"grant_type":  {string(codersdk.OAuth2ProviderGrantTypeDeviceCode)},
+			"device_code": {da.DeviceCode},
+		})
+	}
+
+	// The device has to wait for the user.
+	res, err := poll()
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	var oauthErr struct {
+		Error string `json:"error"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&oauthErr))
+	require.Equal(t, "authorization_pending", oauthErr.Error)
+
+	// Unknown codes are rejected.
+	res, err = userClient.Request(ctx, http.MethodGet, "/oauth2/device/verify?user_code=BBBB-BBBB", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	// The user is shown the allow page first.
+	res, err = userClient.Request(ctx, http.MethodGet, da.VerificationURIComplete, nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), da.UserCode)
+	require.Contains(t, string(body), apps.Default.Name)
+
+	// Then clicks "allow", which we detect with the referer.
+	allowURL := must(url.Parse(da.VerificationURIComplete))
+	allowURL.RawQuery = allowURL.Query().Encode() + "&redirected=true"
+	res, err = userClient.Request(ctx, http.MethodGet, allowURL.String(), nil, func(req *http.Request) {
+		req.Header.Set("Referer", da.VerificationURIComplete)
+	})
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	da.Interval = 1
+	token, err := cfg.DeviceAccessToken(ctx, da)
+	require.NoError(t, err)
+	require.NotEmpty(t, token.RefreshToken)
+
+	newClient := codersdk.New(userClient.URL)
+	newClient.SetSessionToken(token.AccessToken)
+	gotUser, err := newClient.User(ctx, codersdk.Me)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, gotUser.ID)
+
+	// The device code can only be used once.
+	res, err = poll()
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
+
+func TestOAuth2ProviderDeviceFlowRateLimit(t *testing.T) {
+	t.Parallel()
+
+	db, pubsub := dbtestutil.NewDB(t)
+	ownerClient := coderdtest.New(t, &coderdtest.Options{
+		Database:       db,
+		Pubsub:         pubsub,
+		LoginRateLimit: 1,
+	})
+	_ = coderdtest.CreateFirstUser(t, ownerClient)
+	ctx := testutil.Context(t, testutil.WaitLong)
+	apps := generateApps(ctx, t, ownerClient, "device-limit")
+
+	authorize := func() int {
+		res, err := postForm(ctx, ownerClient, "/oauth2/device", url.Values{
+			"client_id": {apps.Default.ID.String()},
+		})
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return res.StatusCode
+	}
+
+	// Every call creates a device code, so unauthenticated devices are limited.
+	require.Equal(t, http.StatusOK, authorize())
+	require.Equal(t, http.StatusTooManyRequests, authorize())
+}
+
+func TestOAuth2ProviderIntrospection(t *testing.T) {
+	t.Parallel()
+
+	db, pubsub := dbtestutil.NewDB(t)
+	ownerClient := coderdtest.New(t, &coderdtest.Options{
+		Database: db,
+		Pubsub:   pubsub,
+	})
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	ctx := testutil.Context(t, testutil.WaitLong)
+	apps := generateApps(ctx, t, ownerClient, "introspect")
+	userClient, user := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+
+	//nolint:gocritic // OAauth2 app management requires owner permission.
+	secret, err := ownerClient.PostOAuth2ProviderAppSecret(ctx, apps.Default.ID)
+	require.NoError(t, err)
+	//nolint:gocritic // OAauth2 app management requires owner permission.
+	otherSecret, err := ownerClient.PostOAuth2ProviderAppSecret(ctx, apps.NoPort.ID)
+	require.NoError(t, err)
+
+	cfg := &oauth2.Config{
+		ClientID:     apps.Default.ID.String(),
+		ClientSecret: secret.ClientSecretFull,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   apps.Default.Endpoints.Authorization,
+			TokenURL:  apps.Default.Endpoints.Token,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+		RedirectURL: apps.Default.CallbackURL,
+	}
+	code, err := authorizationFlow(ctx, userClient, cfg)
+	require.NoError(t, err)
+	token, err := cfg.Exchange(ctx, code)
+	require.NoError(t, err)
+
+	introspection := introspect(ctx, t, ownerClient, url.Values{
+		"client_id":       {apps.Default.ID.String()},
+		"client_secret":   {secret.ClientSecretFull},
+		"token":           {token.AccessToken},
+		"token_type_hint": {"access_token"},
+	})
+	require.True(t, introspection.Active)
+	require.Equal(t, apps.Default.ID.String(), introspection.ClientID)
+	require.Equal(t, user.ID.String(), introspection.Sub)
+	require.Equal(t, user.Username, introspection.Username)
+	require.Equal(t, "Bearer", introspection.TokenType)
+
+	// Apps cannot introspect tokens of other apps.
+	introspection = introspect(ctx, t, ownerClient, url.Values{
+		"client_id":     {apps.NoPort.ID.String()},
+		"client_secret": {otherSecret.ClientSecretFull},
+		"token":         {token.AccessToken},
+	})
+	require.False(t, introspection.Active)
+
+	// Regular session tokens are not OAuth2 tokens.
+	introspection = introspect(ctx, t, ownerClient, url.Values{
+		"client_id":     {apps.Default.ID.String()},
+		"client_secret": {secret.ClientSecretFull},
+		"token":         {userClient.SessionToken()},
+	})
+	require.False(t, introspection.Active)
+
+	introspection = introspect(ctx, t, ownerClient, url.Values{
+		"client_id":     {apps.Default.ID.String()},
+		"client_secret": {secret.ClientSecretFull},
+		"token":         {"invalid"},
+	})
+	require.False(t, introspection.Active)
+
+	// The app must authenticate.
+	res, err := postForm(ctx, ownerClient, "/oauth2/introspect", url.Values{
+		"client_id":     {apps.Default.ID.String()},
+		"client_secret": {otherSecret.ClientSecretFull},
+		"token":         {token.AccessToken},
+	})
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
+
+func TestOAuth2ProviderDiscovery(t *testing.T) {
+	t.Parallel()
+
+	db, pubsub := dbtestutil.NewDB(t)
+	ownerClient := coderdtest.New(t, &coderdtest.Options{
+		Database: db,
+		Pubsub:   pubsub,
+	})
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	ctx := testutil.Context(t, testutil.WaitLong)
+	apps := generateApps(ctx, t, ownerClient, "discovery")
+	userClient, user := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+
+	res, err := ownerClient.Request(ctx, http.MethodGet, "/.well-known/oauth-authorization-server", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var metadata codersdk.OAuth2ProviderMetadata
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&metadata))
+	require.Equal(t, apps.Default.Endpoints.Authorization, metadata.AuthorizationEndpoint)
+	require.Equal(t, apps.Default.Endpoints.Token, metadata.TokenEndpoint)
+	require.Equal(t, apps.Default.Endpoints.DeviceAuth, metadata.DeviceAuthorizationEndpoint)
+	require.Contains(t, metadata.GrantTypesSupported, string(codersdk.OAuth2ProviderGrantTypeClientCredentials))
+	require.Equal(t, []string{"S256"}, metadata.CodeChallengeMethodsSupported)
+
+	//nolint:gocritic // OAauth2 app management requires owner permission.
+	secret, err := ownerClient.PostOAuth2ProviderAppSecret(ctx, apps.Default.ID)
+	require.NoError(t, err)
+	cfg := &oauth2.Config{
+		ClientID:     apps.Default.ID.String(),
+		ClientSecret: secret.ClientSecretFull,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   metadata.AuthorizationEndpoint,
+			TokenURL:  metadata.TokenEndpoint,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+		RedirectURL: apps.Default.CallbackURL,
+	}
+	code, err := authorizationFlow(ctx, userClient, cfg)
+	require.NoError(t, err)
+	token, err := cfg.Exchange(ctx, code)
+	require.NoError(t, err)
+
+	// The client sends the access token as a bearer token.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadata.UserInfoEndpoint, nil)
+	require.NoError(t, err)
+	res, err = cfg.Client(ctx, token).Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var userInfo codersdk.OAuth2UserInfo
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&userInfo))
+	require.Equal(t, user.ID.String(), userInfo.Sub)
+	require.Equal(t, user.Username, userInfo.PreferredUsername)
+	require.Equal(t, user.Email, userInfo.Email)
+}
+
 type provisionedApps struct {
 	Default   codersdk.OAuth2ProviderApp
 	NoPort    codersdk.OAuth2ProviderApp
@@ -1087,10 +1537,10 @@ func generateApps(ctx context.Context, t *testing.T, client *codersdk.Client, su
 	}
 }
 
-func authorizationFlow(ctx context.Context, client *codersdk.Client, cfg *oauth2.Config) (string, error) {
+func authorizationFlow(ctx context.Context, client *codersdk.Client, cfg *oauth2.Config, opts ...oauth2.AuthCodeOption) (string, error) {
 	state := uuid.NewString()
 	return oidctest.OAuth2GetCode(
-		cfg.AuthCodeURL(state),
+		cfg.AuthCodeURL(state, opts...),
 		func(req *http.Request) (*http.Response, error) {
 			// TODO: Would be better if client had a .Do() method.
 			// TODO: Is this the best way to handle redirects?
@@ -1107,6 +1557,23 @@ func authorizationFlow(ctx context.Context, client *codersdk.Client, cfg *oauth2
 	)
 }
 
+func postForm(ctx context.Context, client *codersdk.Client, path string, vals url.Values) (*http.Response, error) {
+	return client.Request(ctx, http.MethodPost, path, strings.NewReader(vals.Encode()), func(req *http.Request) {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	})
+}
+
+func introspect(ctx context.Context, t *testing.T, client *codersdk.Client, vals url.Values) codersdk.OAuth2TokenIntrospection {
+	t.Helper()
+	res, err := postForm(ctx, client, "/oauth2/introspect", vals)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var introspection codersdk.OAuth2TokenIntrospection
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&introspection))
+	return introspection
+}
+
 func must[T any](value T, err error) T {
 	if err != nil {
 		panic(err)
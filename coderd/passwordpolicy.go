@@ -0,0 +1,185 @@
+package coderd
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/userpassword"
+)
+
+// passwordChangeScopes limit the session of a user whose password has expired
+// to changing the password. Changing the password deletes the API keys of the
+// user, including the session itself.
+var passwordChangeScopes = []string{"api_key:delete", "user:read", "user:read_personal", "user:update_personal"}
+
+// passwordPolicy returns the requirements for new passwords.
+func (api *API) passwordPolicy() userpassword.Policy {
+	return userpassword.Policy{
+		MinLength: int(api.DeploymentValues.PasswordPolicy.MinLength.Value()),
+	}
+}
+
+// recordPasswordHistory records the new password of a user and removes the
+// entries that are no longer needed to enforce the password policy. The newest
+// entry is always kept to know when the password expires.
+func (api *API) recordPasswordHistory(ctx context.Context, store database.Store, userID uuid.UUID, hashedPassword []byte) error {
+	err := store.InsertUserPasswordHistory(ctx, database.InsertUserPasswordHistoryParams{
+		ID:             uuid.New(),
+		UserID:         userID,
+		HashedPassword: hashedPassword,
+		CreatedAt:      dbtime.Now(),
+	})
+	if err != nil {
+		return xerrors.Errorf("insert password history: %w", err)
+	}
+
+	keep := max(api.DeploymentValues.PasswordPolicy.History.Value(), 1)
+	err = store.DeleteOldUserPasswordHistory(ctx, database.DeleteOldUserPasswordHistoryParams{
+		UserID: userID,
+		Keep:   int32(keep),
+	})
+	if err != nil {
+		return xerrors.Errorf("delete old password history: %w", err)
+	}
+	return nil
+}
+
+// passwordReused reports whether the password matches one of the previous
+// passwords of the user that the password policy forbids to reuse.
+func (api *API) passwordReused(ctx context.Context, userID uuid.UUID, password string) (bool, error) {
+	history := api.DeploymentValues.PasswordPolicy.History.Value()
+	if history <= 0 {
+		return false, nil
+	}
+
+	entries, err := api.Database.GetUserPasswordHistory(ctx, database.GetUserPasswordHistoryParams{
+		UserID:   userID,
+		LimitOpt: int32(history),
+	})
+	if err != nil {
+		return false, xerrors.Errorf("get password history: %w", err)
+	}
+	for _, entry := range entries {
+		match, err := userpassword.Compare(string(entry.HashedPassword), password)
+		if err != nil {
+			return false, xerrors.Errorf("compare password: %w", err)
+		}
+		if match {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// passwordExpired reports whether the password of the user is older than the
+// maximum password age.
+func (api *API) passwordExpired(ctx context.Context, userID uuid.UUID) (bool, error) {
+	maxAge := api.DeploymentValues.PasswordPolicy.MaxAge.Value()
+	if maxAge <= 0 {
+		return false, nil
+	}
+
+	//nolint:gocritic // The age of the password is checked before the user is logged in.
+	entries, err := api.Database.GetUserPasswordHistory(dbauthz.AsSystemRestricted(ctx), database.GetUserPasswordHistoryParams{
+		UserID:   userID,
+		LimitOpt: 1,
+	})
+	if err != nil {
+		return false, xerrors.Errorf("get password history: %w", err)
+	}
+	if len(entries) == 0 {
+		return false, nil
+	}
+	return dbtime.Now().Sub(entries[0].CreatedAt) > maxAge, nil
+}
+
+// loginLockedUntil returns when the lockout of the user after too many failed
+// logins ends, or the zero time if the user is not locked out.
+func (api *API) loginLockedUntil(ctx context.Context, user database.User) (time.Time, error) {
+	threshold := api.DeploymentValues.PasswordPolicy.LockoutThreshold.Value()
+	if threshold <= 0 || user.ID == uuid.Nil {
+		return time.Time{}, nil
+	}
+
+	//nolint:gocritic // Lockouts are checked before the user is logged in.
+	attempts, err := api.Database.GetUserLoginAttempts(dbauthz.AsSystemRestricted(ctx), user.ID)
+	if xerrors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, xerrors.Errorf("get failed logins: %w", err)
+	}
+	if int64(attempts.FailedAttempts) < threshold {
+		return time.Time{}, nil
+	}
+	lockedUntil := attempts.LastFailedAt.Add(api.DeploymentValues.PasswordPolicy.LockoutDuration.Value())
+	if !dbtime.Now().Before(lockedUntil) {
+		return time.Time{}, nil
+	}
+	return lockedUntil, nil
+}
+
+// recordFailedLogin counts a failed password or multi-factor authentication
+// login of the user. Once the lockout threshold is reached, every failed login
+// locks the account for the lockout duration, until the user logs in
+// successfully.
+func (api *API) recordFailedLogin(r *http.Request, user database.User) error {
+	ctx := r.Context()
+	threshold := api.DeploymentValues.PasswordPolicy.LockoutThreshold.Value()
+	if threshold <= 0 || user.ID == uuid.Nil || user.LoginType != database.LoginTypePassword {
+		return nil
+	}
+
+	now := dbtime.Now()
+	//nolint:gocritic // Failed logins are recorded before the user is logged in.
+	attempts, err := api.Database.UpsertUserFailedLoginAttempts(dbauthz.AsSystemRestricted(ctx), database.UpsertUserFailedLoginAttemptsParams{
+		UserID:       user.ID,
+		LastFailedAt: now,
+	})
+	if err != nil {
+		return xerrors.Errorf("record failed login: %w", err)
+	}
+	if int64(attempts) < threshold {
+		return nil
+	}
+
+	lockedUntil := now.Add(api.DeploymentValues.PasswordPolicy.LockoutDuration.Value())
+	api.Logger.Warn(ctx, "locked user after too many failed logins",
+		slog.F("user_id", user.ID),
+		slog.F("failed_attempts", attempts),
+		slog.F("locked_until", lockedUntil),
+	)
+
+	fields, err := json.Marshal(map[string]string{
+		"reason": fmt.Sprintf("locked out until %s after %d failed logins", lockedUntil.Format(time.RFC3339), attempts),
+	})
+	if err != nil {
+		return xerrors.Errorf("marshal audit fields: %w", err)
+	}
+	audit.BackgroundAudit(ctx, &audit.BackgroundAuditParams[database.User]{
+		Audit:            *api.Auditor.Load(),
+		Log:              api.Logger,
+		UserID:           user.ID,
+		RequestID:        httpmw.RequestID(r),
+		Status:           http.StatusUnauthorized,
+		Action:           database.AuditActionWrite,
+		IP:               r.RemoteAddr,
+		AdditionalFields: fields,
+		Old:              user,
+		New:              user,
+	})
+	return nil
+}
@@ -0,0 +1,249 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+	"github.com/coder/serpent"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("MinLength", func(t *testing.T) {
+		t.Parallel()
+		dv := coderdtest.DeploymentValues(t)
+		dv.PasswordPolicy.MinLength = 18
+		client := coderdtest.New(t, &coderdtest.Options{DeploymentValues: dv})
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		var apiErr *codersdk.Error
+		err := client.UpdateUserPassword(ctx, codersdk.Me, codersdk.UpdateUserPasswordRequest{
+			Password: "MySecurePassword!",
+		})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+		require.Len(t, apiErr.Validations, 1)
+		require.Equal(t, "password", apiErr.Validations[0].Field)
+
+		err = client.UpdateUserPassword(ctx, codersdk.Me, codersdk.UpdateUserPasswordRequest{
+			Password: "MyEvenMoreSecurePassword!",
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("History", func(t *testing.T) {
+		t.Parallel()
+		dv := coderdtest.DeploymentValues(t)
+		dv.PasswordPolicy.History = 2
+		owner := coderdtest.New(t, &coderdtest.Options{DeploymentValues: dv})
+		first := coderdtest.CreateFirstUser(t, owner)
+		_, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		setPassword := func(password string) error {
+			return owner.UpdateUserPassword(ctx, user.ID.String(), codersdk.UpdateUserPasswordRequest{
+				Password: password,
+			})
+		}
+		require.NoError(t, setPassword("FirstNewPassword!"))
+
+		// The initial password is one of the last two passwords.
+		var apiErr *codersdk.Error
+		err := setPassword("SomeSecurePassword!")
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+		require.Contains(t, apiErr.Message, "last 2 passwords")
+
+		require.NoError(t, setPassword("SecondNewPassword!"))
+		// The initial password has been forgotten.
+		require.NoError(t, setPassword("SomeSecurePassword!"))
+	})
+
+	t.Run("MaxAge", func(t *testing.T) {
+		t.Parallel()
+		dv := coderdtest.DeploymentValues(t)
+		dv.PasswordPolicy.MaxAge = serpent.Duration(time.Hour)
+		owner, db := coderdtest.NewWithDatabase(t, &coderdtest.Options{DeploymentValues: dv})
+		first := coderdtest.CreateFirstUser(t, owner)
+		_, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		// Pretend the password was set two hours ago.
+		//nolint:gocritic // Tests need to edit the password history directly.
+		sysCtx := dbauthz.AsSystemRestricted(ctx)
+		history, err := db.GetUserPasswordHistory(sysCtx, database.GetUserPasswordHistoryParams{UserID: user.ID})
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		err = db.DeleteOldUserPasswordHistory(sysCtx, database.DeleteOldUserPasswordHistoryParams{UserID: user.ID})
+		require.NoError(t, err)
+		err = db.InsertUserPasswordHistory(sysCtx, database.InsertUserPasswordHistoryParams{
+			ID:             history[0].ID,
+			UserID:         user.ID,
+			HashedPassword: history[0].HashedPassword,
+			CreatedAt:      dbtime.Now().Add(-2 * time.Hour),
+		})
+		require.NoError(t, err)
+
+		client := codersdk.New(owner.URL)
+		res, err := client.LoginWithPassword(ctx, codersdk.LoginWithPasswordRequest{
+			Email:    user.Email,
+			Password: "SomeSecurePassword!",
+		})
+		require.NoError(t, err)
+		require.True(t, res.PasswordChangeRequired)
+		client.SetSessionToken(res.SessionToken)
+
+		// The session can only be used to change the password.
+		_, err = client.User(ctx, codersdk.Me)
+		require.NoError(t, err)
+		_, err = client.CreateAPIKey(ctx, codersdk.Me)
+		require.Error(t, err)
+
+		err = client.UpdateUserPassword(ctx, codersdk.Me, codersdk.UpdateUserPasswordRequest{
+			OldPassword: "SomeSecurePassword!",
+			Password:    "MyNewSecurePassword!",
+		})
+		require.NoError(t, err)
+
+		// Changing the password logged out the session.
+		var apiErr *codersdk.Error
+		_, err = client.User(ctx, codersdk.Me)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode())
+
+		res, err = client.LoginWithPassword(ctx, codersdk.LoginWithPasswordRequest{
+			Email:    user.Email,
+			Password: "MyNewSecurePassword!",
+		})
+		require.NoError(t, err)
+		require.False(t, res.PasswordChangeRequired)
+	})
+
+	t.Run("Lockout", func(t *testing.T) {
+		t.Parallel()
+		dv := coderdtest.DeploymentValues(t)
+		dv.PasswordPolicy.LockoutThreshold = 3
+		dv.PasswordPolicy.LockoutDuration = serpent.Duration(time.Hour)
+		auditor := audit.NewMock()
+		owner, db := coderdtest.NewWithDatabase(t, &coderdtest.Options{DeploymentValues: dv, Auditor: auditor})
+		first := coderdtest.CreateFirstUser(t, owner)
+		_, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		client := codersdk.New(owner.URL)
+		login := func(password string) error {
+			_, err := client.LoginWithPassword(ctx, codersdk.LoginWithPasswordRequest{
+				Email:    user.Email,
+				Password: password,
+			})
+			return err
+		}
+		requireLocked := func() {
+			t.Helper()
+			// The correct password does not unlock the account.
+			var apiErr *codersdk.Error
+			err := login("SomeSecurePassword!")
+			require.ErrorAs(t, err, &apiErr)
+			require.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode())
+			require.Contains(t, apiErr.Message, "locked")
+		}
+		// expireLockout pretends the last failed login was before the
+		// lockout duration.
+		expireLockout := func() {
+			t.Helper()
+			//nolint:gocritic // Tests need to edit the failed logins directly.
+			_, err := db.UpsertUserFailedLoginAttempts(dbauthz.AsSystemRestricted(ctx), database.UpsertUserFailedLoginAttemptsParams{
+				UserID:       user.ID,
+				LastFailedAt: dbtime.Now().Add(-2 * time.Hour),
+			})
+			require.NoError(t, err)
+		}
+
+		// A successful login resets the failed attempts.
+		require.Error(t, login("WrongPassword!"))
+		require.Error(t, login("WrongPassword!"))
+		require.NoError(t, login("SomeSecurePassword!"))
+		require.Error(t, login("WrongPassword!"))
+		require.Error(t, login("WrongPassword!"))
+		require.NoError(t, login("SomeSecurePassword!"))
+
+		auditor.ResetLogs()
+		for i := 0; i < 3; i++ {
+			require.Error(t, login("WrongPassword!"))
+		}
+		requireLocked()
+		require.True(t, auditor.Contains(t, database.AuditLog{
+			Action:       database.AuditActionWrite,
+			ResourceType: database.ResourceTypeUser,
+			ResourceID:   user.ID,
+			UserID:       user.ID,
+		}))
+		// The lockout does not change the status of the user.
+		found, err := owner.User(ctx, user.ID.String())
+		require.NoError(t, err)
+		require.Equal(t, codersdk.UserStatusActive, found.Status)
+
+		// Once the lockout has passed, every further failed login locks the
+		// account again.
+		expireLockout()
+		require.Error(t, login("WrongPassword!"))
+		requireLocked()
+
+		expireLockout()
+		require.NoError(t, login("SomeSecurePassword!"))
+		require.Error(t, login("WrongPassword!"))
+		require.NoError(t, login("SomeSecurePassword!"))
+
+		// Activating the user lifts the lockout.
+		for i := 0; i < 3; i++ {
+			require.Error(t, login("WrongPassword!"))
+		}
+		requireLocked()
+		_, err = owner.UpdateUserStatus(ctx, user.ID.String(), codersdk.UserStatusActive)
+		require.NoError(t, err)
+		require.NoError(t, login("SomeSecurePassword!"))
+	})
+
+	t.Run("OwnerLockedOut", func(t *testing.T) {
+		t.Parallel()
+		dv := coderdtest.DeploymentValues(t)
+		dv.PasswordPolicy.LockoutThreshold = 1
+		dv.PasswordPolicy.LockoutDuration = serpent.Duration(time.Hour)
+		owner := coderdtest.New(t, &coderdtest.Options{DeploymentValues: dv})
+		first := coderdtest.CreateFirstUser(t, owner)
+		otherOwner, _ := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID, rbac.RoleOwner())
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		client := codersdk.New(owner.URL)
+		login := func(password string) error {
+			_, err := client.LoginWithPassword(ctx, codersdk.LoginWithPasswordRequest{
+				Email:    coderdtest.FirstUserParams.Email,
+				Password: password,
+			})
+			return err
+		}
+		require.Error(t, login("WrongPassword!"))
+		var apiErr *codersdk.Error
+		err := login(coderdtest.FirstUserParams.Password)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode())
+
+		// Another owner can unlock them.
+		_, err = otherOwner.UpdateUserStatus(ctx, first.UserID.String(), codersdk.UserStatusActive)
+		require.NoError(t, err)
+		require.NoError(t, login(coderdtest.FirstUserParams.Password))
+	})
+}
@@ -0,0 +1,241 @@
+package prebuilds
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/db2sdk"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+const (
+	// ReconcileInterval is how often the reconciler brings the prebuild
+	// pools to their desired size.
+	ReconcileInterval = time.Minute
+
+	// FailedBuildBackoff is how long the reconciler waits after a prebuilt
+	// workspace failed to start before it replaces it. No new prebuilt
+	// workspaces are created for the pool in the meantime, so that a broken
+	// template does not queue builds forever.
+	FailedBuildBackoff = 5 * time.Minute
+
+	// SystemUsername is the username of the user that owns prebuilt
+	// workspaces until they are claimed. It is not a valid username, so it
+	// cannot collide with the name of a real user.
+	SystemUsername = "prebuilds_system"
+)
+
+// SystemUserID is the ID of the user that owns prebuilt workspaces until
+// they are claimed. The user is created by the reconciler the first time a
+// pool needs a workspace.
+var SystemUserID = uuid.MustParse("c42fdf75-3097-471c-8c33-fb52454d81c0")
+
+// ensureSystemUser creates the user that owns prebuilt workspaces if it does
+// not exist yet.
+func ensureSystemUser(ctx context.Context, db database.Store) error {
+	_, err := db.GetUserByID(ctx, SystemUserID)
+	if err == nil {
+		return nil
+	}
+	if !xerrors.Is(err, sql.ErrNoRows) {
+		return xerrors.Errorf("get prebuilds user: %w", err)
+	}
+	now := dbtime.Now()
+	// Only the system may assign the implied member role.
+	//nolint:gocritic // The reconciler cannot create users by itself.
+	_, err = db.InsertUser(dbauthz.AsSystemRestricted(ctx), database.InsertUserParams{
+		ID:               SystemUserID,
+		Email:            fmt.Sprintf("%s@system.invalid", SystemUsername),
+		Username:         SystemUsername,
+		Name:             "Prebuilt workspaces",
+		HashedPassword:   []byte{},
+		CreatedAt:        now,
+		UpdatedAt:        now,
+		RBACRoles:        []string{},
+		LoginType:        database.LoginTypeNone,
+		IsServiceAccount: true,
+	})
+	if err != nil {
+		return xerrors.Errorf("insert prebuilds user %q: %w", SystemUsername, err)
+	}
+	return nil
+}
+
+// ResolveParameters returns the values of the non-ephemeral parameters of a
+// template version that a workspace created with values would have. Values
+// that are not provided fall back to the default value of the parameter.
+func ResolveParameters(params []database.TemplateVersionParameter, values []codersdk.WorkspaceBuildParameter) (map[string]string, error) {
+	resolver := codersdk.ParameterResolver{}
+	resolved := make(map[string]string, len(params))
+	for _, param := range params {
+		if param.Ephemeral {
+			continue
+		}
+		tvp, err := db2sdk.TemplateVersionParameter(param)
+		if err != nil {
+			return nil, xerrors.Errorf("convert template version parameter %q: %w", param.Name, err)
+		}
+		var value *codersdk.WorkspaceBuildParameter
+		for _, v := range values {
+			if v.Name == param.Name {
+				value = &v
+				break
+			}
+		}
+		resolved[param.Name], err = resolver.ValidateResolve(tvp, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resolved, nil
+}
+
+// PoolParameters converts the parameters of a pool to build parameters.
+func PoolParameters(pool database.TemplatePrebuildPool) []codersdk.WorkspaceBuildParameter {
+	values := make([]codersdk.WorkspaceBuildParameter, 0, len(pool.Parameters))
+	for _, name := range sortedKeys(pool.Parameters) {
+		values = append(values, codersdk.WorkspaceBuildParameter{
+			Name:  name,
+			Value: pool.Parameters[name],
+		})
+	}
+	return values
+}
+
+// FindPool returns the pool whose workspaces were built with the same
+// parameter values as a workspace created with values. Pools whose
+// parameters are no longer valid for the template version never match.
+func FindPool(pools []database.TemplatePrebuildPool, params []database.TemplateVersionParameter, values []codersdk.WorkspaceBuildParameter) (database.TemplatePrebuildPool, bool) {
+	want, err := ResolveParameters(params, values)
+	if err != nil {
+		return database.TemplatePrebuildPool{}, false
+	}
+	for _, pool := range pools {
+		got, err := ResolveParameters(params, PoolParameters(pool))
+		if err != nil {
+			continue
+		}
+		if maps.Equal(want, got) {
+			return pool, true
+		}
+	}
+	return database.TemplatePrebuildPool{}, false
+}
+
+// InstanceCounts returns the number of prebuilt workspaces of the pool that
+// can be claimed, and the number that are being built.
+func InstanceCounts(pool database.TemplatePrebuildPool, prebuilds []database.GetPrebuiltWorkspacesRow) (ready, starting int) {
+	for _, prebuild := range prebuilds {
+		if !prebuild.PoolID.Valid || prebuild.PoolID.UUID != pool.ID {
+			continue
+		}
+		switch {
+		case isReady(prebuild):
+			ready++
+		case isStarting(prebuild):
+			starting++
+		}
+	}
+	return ready, starting
+}
+
+// ClaimParams are the settings of the workspace that a claimed prebuilt
+// workspace replaces.
+type ClaimParams struct {
+	Template          database.Template
+	OwnerID           uuid.UUID
+	Name              string
+	AutostartSchedule sql.NullString
+	Ttl               sql.NullInt64
+	AutomaticUpdates  database.AutomaticUpdates
+	Parameters        []codersdk.WorkspaceBuildParameter
+}
+
+// Claim transfers a prebuilt workspace of the pool matching the parameters
+// to the new owner. It returns sql.ErrNoRows if no prebuilt workspace is
+// ready to be claimed, in which case a workspace should be built from
+// scratch. The caller must start a build of the claimed workspace with the
+// returned parameters to apply the new owner to its resources.
+func Claim(ctx context.Context, db database.Store, params ClaimParams) (database.Workspace, []codersdk.WorkspaceBuildParameter, error) {
+	pools, err := db.GetTemplatePrebuildPools(ctx, params.Template.ID)
+	if err != nil {
+		return database.Workspace{}, nil, xerrors.Errorf("get template prebuild pools: %w", err)
+	}
+	if len(pools) == 0 {
+		return database.Workspace{}, nil, sql.ErrNoRows
+	}
+	tvp, err := db.GetTemplateVersionParameters(ctx, params.Template.ActiveVersionID)
+	if err != nil && !xerrors.Is(err, sql.ErrNoRows) {
+		return database.Workspace{}, nil, xerrors.Errorf("get template version parameters: %w", err)
+	}
+	pool, ok := FindPool(pools, tvp, params.Parameters)
+	if !ok {
+		return database.Workspace{}, nil, sql.ErrNoRows
+	}
+
+	workspace, err := db.ClaimPrebuiltWorkspace(ctx, database.ClaimPrebuiltWorkspaceParams{
+		PoolID:            pool.ID,
+		OrganizationID:    params.Template.OrganizationID,
+		NewOwnerID:        params.OwnerID,
+		NewName:           params.Name,
+		AutostartSchedule: params.AutostartSchedule,
+		Ttl:               params.Ttl,
+		AutomaticUpdates:  params.AutomaticUpdates,
+		Now:               dbtime.Now(),
+	})
+	if err != nil {
+		return database.Workspace{}, nil, err
+	}
+
+	// The agent of the prebuilt workspace was given a session token of the
+	// prebuilds user. The claim build issues one for the new owner, so the
+	// old one must not outlive the ownership change.
+	//nolint:gocritic // The caller cannot read the API keys of the prebuilds user.
+	sysCtx := dbauthz.AsSystemRestricted(ctx)
+	key, err := db.GetAPIKeyByName(sysCtx, database.GetAPIKeyByNameParams{
+		UserID:    SystemUserID,
+		TokenName: fmt.Sprintf("%s_%s_session_token", SystemUserID, workspace.ID),
+	})
+	if err == nil {
+		err = db.DeleteAPIKeyByID(sysCtx, key.ID)
+	}
+	if err != nil && !xerrors.Is(err, sql.ErrNoRows) {
+		return database.Workspace{}, nil, xerrors.Errorf("delete prebuilds user session token: %w", err)
+	}
+	return workspace, EphemeralParameters(tvp, params.Parameters), nil
+}
+
+// EphemeralParameters returns the values of ephemeral parameters. They are
+// the only parameters that may be passed to the build of a claimed
+// workspace, as the others were already set by the prebuild.
+func EphemeralParameters(params []database.TemplateVersionParameter, values []codersdk.WorkspaceBuildParameter) []codersdk.WorkspaceBuildParameter {
+	ephemeral := make([]codersdk.WorkspaceBuildParameter, 0)
+	for _, value := range values {
+		for _, param := range params {
+			if param.Name == value.Name && param.Ephemeral {
+				ephemeral = append(ephemeral, value)
+				break
+			}
+		}
+	}
+	return ephemeral
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,378 @@
+package prebuilds
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/database/provisionerjobs"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
+	"github.com/coder/coder/v2/coderd/wsbuilder"
+	"github.com/coder/coder/v2/cryptorand"
+)
+
+// acquireLockError is returned when the reconciler fails to acquire the lock
+// of a pool because another replica is reconciling it.
+type acquireLockError struct{}
+
+// Error implements error.
+func (acquireLockError) Error() string {
+	return "lock is held by another client"
+}
+
+// Reconciler keeps the number of prebuilt workspaces of every template
+// prebuild pool at the desired number of instances.
+type Reconciler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	db     database.Store
+	pubsub pubsub.Pubsub
+	log    slog.Logger
+	tick   <-chan time.Time
+	stats  chan<- Stats
+}
+
+// Stats contains statistics about the last run of the reconciler.
+type Stats struct {
+	// Created contains the IDs of the prebuilt workspaces that were created.
+	Created []uuid.UUID
+	// Deleted contains the IDs of the prebuilt workspaces that were
+	// scheduled for deletion.
+	Deleted []uuid.UUID
+	// Error is the fatal error that occurred during the last run of the
+	// reconciler, if any.
+	Error error
+}
+
+// New returns a new prebuilds reconciler.
+func New(ctx context.Context, db database.Store, pub pubsub.Pubsub, log slog.Logger, tick <-chan time.Time) *Reconciler {
+	//nolint:gocritic // The reconciler has a limited set of permissions.
+	ctx, cancel := context.WithCancel(dbauthz.AsPrebuildsReconciler(ctx))
+	return &Reconciler{
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+		db:     db,
+		pubsub: pub,
+		log:    log,
+		tick:   tick,
+		stats:  nil,
+	}
+}
+
+// WithStatsChannel will cause the reconciler to push Stats to ch after every
+// tick. This push is blocking, so if ch is not read, the reconciler will
+// hang. This should only be used in tests.
+func (r *Reconciler) WithStatsChannel(ch chan<- Stats) *Reconciler {
+	r.stats = ch
+	return r
+}
+
+// Start will cause the reconciler to reconcile the prebuild pools on every
+// tick from its channel. It will stop when its context is Done, or when its
+// channel is closed.
+//
+// Start should only be called once.
+func (r *Reconciler) Start() {
+	go func() {
+		defer close(r.done)
+		defer r.cancel()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				return
+			case t, ok := <-r.tick:
+				if !ok {
+					return
+				}
+				stats := r.run(t)
+				if stats.Error != nil {
+					r.log.Warn(r.ctx, "error reconciling prebuilt workspaces", slog.Error(stats.Error))
+				}
+				if r.stats != nil {
+					select {
+					case <-r.ctx.Done():
+						return
+					case r.stats <- stats:
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Close will stop the reconciler.
+func (r *Reconciler) Close() {
+	r.cancel()
+	<-r.done
+}
+
+func (r *Reconciler) run(t time.Time) Stats {
+	ctx, cancel := context.WithTimeout(r.ctx, 5*time.Minute)
+	defer cancel()
+
+	stats := Stats{
+		Created: []uuid.UUID{},
+		Deleted: []uuid.UUID{},
+		Error:   nil,
+	}
+
+	pools, err := r.db.GetTemplatePrebuildPools(ctx, uuid.Nil)
+	if err != nil {
+		stats.Error = xerrors.Errorf("get template prebuild pools: %w", err)
+		return stats
+	}
+	prebuilds, err := r.db.GetPrebuiltWorkspaces(ctx, uuid.Nil)
+	if err != nil {
+		stats.Error = xerrors.Errorf("get prebuilt workspaces: %w", err)
+		return stats
+	}
+	if len(pools) == 0 && len(prebuilds) == 0 {
+		return stats
+	}
+	err = ensureSystemUser(ctx, r.db)
+	if err != nil {
+		stats.Error = err
+		return stats
+	}
+
+	for _, pool := range pools {
+		log := r.log.With(slog.F("template_id", pool.TemplateID), slog.F("pool_id", pool.ID))
+		created, deleted, err := r.reconcilePool(ctx, log, pool.ID, t)
+		if err != nil {
+			if !xerrors.As(err, &acquireLockError{}) {
+				log.Error(ctx, "error reconciling prebuild pool", slog.Error(err))
+			}
+			continue
+		}
+		stats.Created = append(stats.Created, created...)
+		stats.Deleted = append(stats.Deleted, deleted...)
+	}
+
+	// Prebuilt workspaces whose pool was removed are no longer needed.
+	poolIDs := make(map[uuid.UUID]struct{}, len(pools))
+	for _, pool := range pools {
+		poolIDs[pool.ID] = struct{}{}
+	}
+	for _, prebuild := range prebuilds {
+		if _, ok := poolIDs[prebuild.PoolID.UUID]; ok && prebuild.PoolID.Valid {
+			continue
+		}
+		if prebuild.Transition == database.WorkspaceTransitionDelete || !jobCompleted(prebuild.JobStatus) {
+			continue
+		}
+		log := r.log.With(slog.F("workspace_id", prebuild.WorkspaceID))
+		job, err := r.deletePrebuild(ctx, r.db, prebuild.WorkspaceID)
+		if err != nil {
+			log.Error(ctx, "error deleting orphaned prebuilt workspace", slog.Error(err))
+			continue
+		}
+		r.postJob(ctx, log, job)
+		stats.Deleted = append(stats.Deleted, prebuild.WorkspaceID)
+	}
+
+	return stats
+}
+
+// reconcilePool creates and deletes the prebuilt workspaces of a pool until
+// it has the desired number of instances.
+func (r *Reconciler) reconcilePool(ctx context.Context, log slog.Logger, poolID uuid.UUID, now time.Time) (created, deleted []uuid.UUID, err error) {
+	var jobs []database.ProvisionerJob
+	err = r.db.InTx(func(tx database.Store) error {
+		created, deleted, jobs = nil, nil, nil
+		locked, err := tx.TryAcquireLock(ctx, database.GenLockID(fmt.Sprintf("prebuilds:%s", poolID)))
+		if err != nil {
+			return xerrors.Errorf("acquire lock: %w", err)
+		}
+		if !locked {
+			return acquireLockError{}
+		}
+
+		// Refetch the pool and its workspaces while we hold the lock.
+		pool, err := tx.GetTemplatePrebuildPoolByID(ctx, poolID)
+		if err != nil {
+			return xerrors.Errorf("get template prebuild pool: %w", err)
+		}
+		prebuilds, err := tx.GetPrebuiltWorkspaces(ctx, pool.TemplateID)
+		if err != nil {
+			return xerrors.Errorf("get prebuilt workspaces: %w", err)
+		}
+
+		var (
+			ready    []database.GetPrebuiltWorkspacesRow
+			starting int
+			backoff  bool
+			outdated []database.GetPrebuiltWorkspacesRow
+		)
+		for _, prebuild := range prebuilds {
+			if !prebuild.PoolID.Valid || prebuild.PoolID.UUID != pool.ID {
+				continue
+			}
+			switch {
+			case prebuild.Transition == database.WorkspaceTransitionDelete:
+				// Already being deleted.
+			case !jobCompleted(prebuild.JobStatus):
+				// Wait for the build to finish before deciding whether the
+				// workspace can be used.
+				if isStarting(prebuild) {
+					starting++
+				}
+			case prebuild.Transition != database.WorkspaceTransitionStart ||
+				prebuild.TemplateVersionID != prebuild.ActiveVersionID ||
+				prebuild.DormantAt.Valid:
+				outdated = append(outdated, prebuild)
+			case prebuild.JobStatus != database.ProvisionerJobStatusSucceeded:
+				if prebuild.CompletedAt.Valid && now.Sub(prebuild.CompletedAt.Time) < FailedBuildBackoff {
+					backoff = true
+					continue
+				}
+				outdated = append(outdated, prebuild)
+			default:
+				ready = append(ready, prebuild)
+			}
+		}
+
+		// Delete the newest workspaces first, as the oldest ones are
+		// claimed first.
+		desired := int(pool.DesiredInstances)
+		for len(ready)+starting > desired && len(ready) > 0 {
+			outdated = append(outdated, ready[len(ready)-1])
+			ready = ready[:len(ready)-1]
+		}
+		for _, prebuild := range outdated {
+			job, err := r.deletePrebuild(ctx, tx, prebuild.WorkspaceID)
+			if err != nil {
+				return err
+			}
+			jobs = append(jobs, job)
+			deleted = append(deleted, prebuild.WorkspaceID)
+		}
+
+		if backoff {
+			log.Debug(ctx, "prebuilt workspace failed recently, not creating new ones")
+			return nil
+		}
+		for i := len(ready) + starting; i < desired; i++ {
+			workspaceID, job, err := r.createPrebuild(ctx, tx, pool)
+			if err != nil {
+				return err
+			}
+			jobs = append(jobs, job)
+			created = append(created, workspaceID)
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, job := range jobs {
+		r.postJob(ctx, log, job)
+	}
+	return created, deleted, nil
+}
+
+func (r *Reconciler) createPrebuild(ctx context.Context, db database.Store, pool database.TemplatePrebuildPool) (uuid.UUID, database.ProvisionerJob, error) {
+	template, err := db.GetTemplateByID(ctx, pool.TemplateID)
+	if err != nil {
+		return uuid.Nil, database.ProvisionerJob{}, xerrors.Errorf("get template: %w", err)
+	}
+	suffix, err := cryptorand.HexString(8)
+	if err != nil {
+		return uuid.Nil, database.ProvisionerJob{}, xerrors.Errorf("generate name: %w", err)
+	}
+
+	now := dbtime.Now()
+	workspace, err := db.InsertWorkspace(ctx, database.InsertWorkspaceParams{
+		ID:               uuid.New(),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+		OwnerID:          SystemUserID,
+		OrganizationID:   template.OrganizationID,
+		TemplateID:       template.ID,
+		Name:             "prebuild-" + suffix,
+		LastUsedAt:       now,
+		AutomaticUpdates: database.AutomaticUpdatesNever,
+	})
+	if err != nil {
+		return uuid.Nil, database.ProvisionerJob{}, xerrors.Errorf("insert workspace: %w", err)
+	}
+	err = db.InsertPrebuiltWorkspace(ctx, database.InsertPrebuiltWorkspaceParams{
+		WorkspaceID: workspace.ID,
+		PoolID:      uuid.NullUUID{UUID: pool.ID, Valid: true},
+		CreatedAt:   now,
+	})
+	if err != nil {
+		return uuid.Nil, database.ProvisionerJob{}, xerrors.Errorf("insert prebuilt workspace: %w", err)
+	}
+
+	builder := wsbuilder.New(workspace, database.WorkspaceTransitionStart).
+		Reason(database.BuildReasonInitiator).
+		Initiator(SystemUserID).
+		ActiveVersion().
+		RichParameterValues(PoolParameters(pool))
+	_, job, err := builder.Build(ctx, db, nil, audit.WorkspaceBuildBaggage{IP: "127.0.0.1"})
+	if err != nil {
+		return uuid.Nil, database.ProvisionerJob{}, xerrors.Errorf("build workspace: %w", err)
+	}
+	return workspace.ID, *job, nil
+}
+
+func (*Reconciler) deletePrebuild(ctx context.Context, db database.Store, workspaceID uuid.UUID) (database.ProvisionerJob, error) {
+	workspace, err := db.GetWorkspaceByID(ctx, workspaceID)
+	if err != nil {
+		return database.ProvisionerJob{}, xerrors.Errorf("get workspace: %w", err)
+	}
+	builder := wsbuilder.New(workspace, database.WorkspaceTransitionDelete).
+		Reason(database.BuildReasonInitiator).
+		Initiator(SystemUserID)
+	_, job, err := builder.Build(ctx, db, nil, audit.WorkspaceBuildBaggage{IP: "127.0.0.1"})
+	if err != nil {
+		return database.ProvisionerJob{}, xerrors.Errorf("delete workspace %s: %w", workspaceID, err)
+	}
+	return *job, nil
+}
+
+func (r *Reconciler) postJob(ctx context.Context, log slog.Logger, job database.ProvisionerJob) {
+	err := provisionerjobs.PostJob(r.pubsub, job)
+	if err != nil {
+		log.Error(ctx, "failed to post provisioner job to pubsub", slog.F("job_id", job.ID), slog.Error(err))
+	}
+}
+
+// isStarting returns whether the prebuilt workspace is being built with the
+// active version of its template.
+func isStarting(prebuild database.GetPrebuiltWorkspacesRow) bool {
+	return !jobCompleted(prebuild.JobStatus) &&
+		prebuild.Transition == database.WorkspaceTransitionStart &&
+		prebuild.TemplateVersionID == prebuild.ActiveVersionID
+}
+
+// isReady returns whether the prebuilt workspace can be claimed.
+func isReady(prebuild database.GetPrebuiltWorkspacesRow) bool {
+	return prebuild.JobStatus == database.ProvisionerJobStatusSucceeded &&
+		prebuild.Transition == database.WorkspaceTransitionStart &&
+		prebuild.TemplateVersionID == prebuild.ActiveVersionID &&
+		!prebuild.DormantAt.Valid
+}
+
+func jobCompleted(status database.ProvisionerJobStatus) bool {
+	switch status {
+	case database.ProvisionerJobStatusSucceeded,
+		database.ProvisionerJobStatusFailed,
+		database.ProvisionerJobStatusCanceled:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,263 @@
+package prebuilds_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/prebuilds"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/provisioner/echo"
+	"github.com/coder/coder/v2/provisionersdk/proto"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestReconciler(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tickCh  = make(chan time.Time)
+		statsCh = make(chan prebuilds.Stats)
+		client  = coderdtest.New(t, &coderdtest.Options{
+			IncludeProvisionerDaemon: true,
+			PrebuildsTicker:          tickCh,
+			PrebuildsStats:           statsCh,
+		})
+		owner                = coderdtest.CreateFirstUser(t, client)
+		memberClient, member = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		// A regular user may have the name of the prebuilds user.
+		_, _ = coderdtest.CreateAnotherUserMutators(t, client, owner.OrganizationID, nil, func(r *codersdk.CreateUserRequest) {
+			r.Username = "prebuilds"
+		})
+		version = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, regionResponses(), func(req *codersdk.CreateTemplateVersionRequest) {
+			req.Presets = []codersdk.TemplateVersionPresetRequest{{
+				Name:       "eu",
+				Parameters: []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "eu"}},
+			}}
+		})
+		_        = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		ctx      = testutil.Context(t, testutil.WaitLong)
+	)
+
+	reconcile := func() prebuilds.Stats {
+		t.Helper()
+		tickCh <- time.Now()
+		stats := testutil.RequireRecvCtx(ctx, t, statsCh)
+		require.NoError(t, stats.Error)
+		return stats
+	}
+	awaitBuild := func(workspaceID uuid.UUID) {
+		t.Helper()
+		workspace, err := client.Workspace(ctx, workspaceID)
+		require.NoError(t, err)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+	}
+
+	pools, err := client.UpdateTemplatePrebuildPools(ctx, template.ID, codersdk.UpdateTemplatePrebuildPoolsRequest{
+		Pools: []codersdk.TemplatePrebuildPoolRequest{{
+			Name:             "eu",
+			DesiredInstances: 1,
+			Parameters:       []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "eu"}},
+		}},
+	})
+	require.NoError(t, err)
+	require.Len(t, pools, 1)
+
+	stats := reconcile()
+	require.Len(t, stats.Created, 1)
+	require.Empty(t, stats.Deleted)
+	prebuildID := stats.Created[0]
+	awaitBuild(prebuildID)
+
+	prebuild, err := client.Workspace(ctx, prebuildID)
+	require.NoError(t, err)
+	require.Equal(t, prebuilds.SystemUserID, prebuild.OwnerID)
+
+	pools, err = client.TemplatePrebuildPools(ctx, template.ID)
+	require.NoError(t, err)
+	require.Len(t, pools, 1)
+	require.EqualValues(t, 1, pools[0].ReadyInstances)
+	require.EqualValues(t, 0, pools[0].StartingInstances)
+
+	// The pool is full, so nothing happens.
+	stats = reconcile()
+	require.Empty(t, stats.Created)
+	require.Empty(t, stats.Deleted)
+
+	// Workspaces with other parameters are built from scratch.
+	cold := coderdtest.CreateWorkspace(t, memberClient, owner.OrganizationID, template.ID, func(req *codersdk.CreateWorkspaceRequest) {
+		req.RichParameterValues = []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "us"}}
+	})
+	require.NotEqual(t, prebuildID, cold.ID)
+	require.EqualValues(t, 1, cold.LatestBuild.BuildNumber)
+
+	// Workspaces with the parameters of the pool claim the prebuilt one.
+	claimed := coderdtest.CreateWorkspace(t, memberClient, owner.OrganizationID, template.ID, func(req *codersdk.CreateWorkspaceRequest) {
+		req.Name = "claimed"
+		req.RichParameterValues = []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "eu"}}
+	})
+	require.Equal(t, prebuildID, claimed.ID)
+	require.Equal(t, member.ID, claimed.OwnerID)
+	require.Equal(t, "claimed", claimed.Name)
+	require.EqualValues(t, 2, claimed.LatestBuild.BuildNumber)
+	require.Equal(t, member.ID, claimed.LatestBuild.InitiatorID)
+	coderdtest.AwaitWorkspaceBuildJobCompleted(t, memberClient, claimed.LatestBuild.ID)
+
+	// The pool is refilled.
+	stats = reconcile()
+	require.Len(t, stats.Created, 1)
+	require.NotEqual(t, prebuildID, stats.Created[0])
+	prebuildID = stats.Created[0]
+	awaitBuild(prebuildID)
+
+	// Presets with the parameters of the pool claim it too.
+	claimed = coderdtest.CreateWorkspace(t, memberClient, owner.OrganizationID, template.ID, func(req *codersdk.CreateWorkspaceRequest) {
+		req.Name = "claimed-preset"
+		req.TemplateVersionPresetID = version.Presets[0].ID
+	})
+	require.Equal(t, prebuildID, claimed.ID)
+	coderdtest.AwaitWorkspaceBuildJobCompleted(t, memberClient, claimed.LatestBuild.ID)
+	params, err := memberClient.WorkspaceBuildParameters(ctx, claimed.LatestBuild.ID)
+	require.NoError(t, err)
+	require.Equal(t, []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "eu"}}, params)
+
+	stats = reconcile()
+	require.Len(t, stats.Created, 1)
+	awaitBuild(stats.Created[0])
+
+	// Shrinking the pool deletes the prebuilt workspaces.
+	_, err = client.UpdateTemplatePrebuildPools(ctx, template.ID, codersdk.UpdateTemplatePrebuildPoolsRequest{
+		Pools: []codersdk.TemplatePrebuildPoolRequest{{
+			Name:             "eu",
+			DesiredInstances: 0,
+			Parameters:       []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "eu"}},
+		}},
+	})
+	require.NoError(t, err)
+	deletedID := stats.Created[0]
+	stats = reconcile()
+	require.Empty(t, stats.Created)
+	require.Equal(t, []uuid.UUID{deletedID}, stats.Deleted)
+	deleted, err := client.DeletedWorkspace(ctx, deletedID)
+	require.NoError(t, err)
+	require.Equal(t, codersdk.WorkspaceTransitionDelete, deleted.LatestBuild.Transition)
+}
+
+func TestReconcilerRemovedPool(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tickCh  = make(chan time.Time)
+		statsCh = make(chan prebuilds.Stats)
+		client  = coderdtest.New(t, &coderdtest.Options{
+			IncludeProvisionerDaemon: true,
+			PrebuildsTicker:          tickCh,
+			PrebuildsStats:           statsCh,
+		})
+		owner    = coderdtest.CreateFirstUser(t, client)
+		version  = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, regionResponses())
+		_        = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		ctx      = testutil.Context(t, testutil.WaitLong)
+	)
+
+	_, err := client.UpdateTemplatePrebuildPools(ctx, template.ID, codersdk.UpdateTemplatePrebuildPoolsRequest{
+		Pools: []codersdk.TemplatePrebuildPoolRequest{{Name: "default", DesiredInstances: 2}},
+	})
+	require.NoError(t, err)
+
+	tickCh <- time.Now()
+	stats := testutil.RequireRecvCtx(ctx, t, statsCh)
+	require.NoError(t, stats.Error)
+	require.Len(t, stats.Created, 2)
+	for _, id := range stats.Created {
+		workspace, err := client.Workspace(ctx, id)
+		require.NoError(t, err)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+	}
+	created := stats.Created
+
+	pools, err := client.UpdateTemplatePrebuildPools(ctx, template.ID, codersdk.UpdateTemplatePrebuildPoolsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, pools)
+
+	tickCh <- time.Now()
+	stats = testutil.RequireRecvCtx(ctx, t, statsCh)
+	require.NoError(t, stats.Error)
+	require.Empty(t, stats.Created)
+	require.ElementsMatch(t, created, stats.Deleted)
+}
+
+func TestFindPool(t *testing.T) {
+	t.Parallel()
+
+	params := []database.TemplateVersionParameter{
+		{Name: "region", Type: "string", DefaultValue: "us", Mutable: false, Options: []byte("[]")},
+		{
+			Name:          "size",
+			Type:          "number",
+			DefaultValue:  "1",
+			Mutable:       true,
+			Options:       []byte("[]"),
+			ValidationMin: sql.NullInt32{Int32: 1, Valid: true},
+			ValidationMax: sql.NullInt32{Int32: 4, Valid: true},
+		},
+		{Name: "reason", Type: "string", Mutable: true, Ephemeral: true, Options: []byte("[]")},
+	}
+	defaults := database.TemplatePrebuildPool{ID: uuid.New(), Parameters: database.StringMap{}}
+	eu := database.TemplatePrebuildPool{ID: uuid.New(), Parameters: database.StringMap{"region": "eu"}}
+	invalid := database.TemplatePrebuildPool{ID: uuid.New(), Parameters: database.StringMap{"size": "8"}}
+	pools := []database.TemplatePrebuildPool{invalid, defaults, eu}
+
+	for _, tc := range []struct {
+		name   string
+		values []codersdk.WorkspaceBuildParameter
+		pool   uuid.UUID
+	}{
+		{name: "Defaults", values: nil, pool: defaults.ID},
+		{name: "ExplicitDefaults", values: []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "us"}, {Name: "size", Value: "1"}}, pool: defaults.ID},
+		{name: "Ephemeral", values: []codersdk.WorkspaceBuildParameter{{Name: "reason", Value: "debugging"}}, pool: defaults.ID},
+		{name: "Region", values: []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "eu"}}, pool: eu.ID},
+		{name: "NoMatch", values: []codersdk.WorkspaceBuildParameter{{Name: "size", Value: "2"}}, pool: uuid.Nil},
+		{name: "Invalid", values: []codersdk.WorkspaceBuildParameter{{Name: "size", Value: "8"}}, pool: uuid.Nil},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			pool, ok := prebuilds.FindPool(pools, params, tc.values)
+			require.Equal(t, tc.pool != uuid.Nil, ok)
+			require.Equal(t, tc.pool, pool.ID)
+		})
+	}
+}
+
+// regionResponses returns the responses of a template with an immutable
+// region parameter.
+func regionResponses() *echo.Responses {
+	return &echo.Responses{
+		Parse: echo.ParseComplete,
+		ProvisionPlan: []*proto.Response{{
+			Type: &proto.Response_Plan{
+				Plan: &proto.PlanComplete{
+					Parameters: []*proto.RichParameter{{
+						Name:         "region",
+						Type:         "string",
+						DefaultValue: "us",
+					}},
+				},
+			},
+		}},
+		ProvisionApply: echo.ApplyComplete,
+	}
+}
@@ -1098,7 +1098,8 @@ func (s *server) FailJob(ctx context.Context, failJob *proto.FailedJob) (*proto.
 func (s *server) notifyWorkspaceBuildFailed(ctx context.Context, workspace database.Workspace, build database.WorkspaceBuild) {
 	var reason string
 	if build.Reason.Valid() && build.Reason == database.BuildReasonInitiator {
-		return // failed workspace build initiated by a user should not notify
+		s.notifyWorkspaceManualBuildFailed(ctx, workspace, build)
+		return
 	}
 	reason = string(build.Reason)
 	initiator := "autobuild"
@@ -1116,6 +1117,63 @@ func (s *server) notifyWorkspaceBuildFailed(ctx context.Context, workspace datab
 	}
 }
 
+// notifyWorkspaceManualBuildFailed notifies template admins and the workspace owner, other than the one who initiated
+// the build, that a workspace build initiated by a user has failed. The initiator is not notified, as they will see the
+// failure directly.
+func (s *server) notifyWorkspaceManualBuildFailed(ctx context.Context, workspace database.Workspace, build database.WorkspaceBuild) {
+	admins, err := notifications.TemplateAdmins(ctx, s.Database)
+	if err != nil {
+		s.Logger.Warn(ctx, "failed to find template admins to notify of failed workspace build", slog.Error(err))
+		return
+	}
+	recipients := make([]uuid.UUID, 0, len(admins)+1)
+	for _, id := range append(admins, workspace.OwnerID) {
+		if id == build.InitiatorID || slices.Contains(recipients, id) {
+			continue
+		}
+		recipients = append(recipients, id)
+	}
+	if len(recipients) == 0 {
+		return
+	}
+
+	//nolint:gocritic // Provisionerd cannot read users or templates.
+	sysCtx := dbauthz.AsSystemRestricted(ctx)
+	owner, err := s.Database.GetUserByID(sysCtx, workspace.OwnerID)
+	if err != nil {
+		s.Logger.Warn(ctx, "failed to fetch workspace owner", slog.Error(err))
+		return
+	}
+	template, err := s.Database.GetTemplateByID(sysCtx, workspace.TemplateID)
+	if err != nil {
+		s.Logger.Warn(ctx, "failed to fetch workspace template", slog.Error(err))
+		return
+	}
+	templateVersion, err := s.Database.GetTemplateVersionByID(sysCtx, build.TemplateVersionID)
+	if err != nil {
+		s.Logger.Warn(ctx, "failed to fetch workspace template version", slog.Error(err))
+		return
+	}
+
+	for _, recipient := range recipients {
+		if _, err := s.NotificationsEnqueuer.Enqueue(ctx, recipient, notifications.TemplateWorkspaceManualBuildFailed,
+			map[string]string{
+				"name":                     workspace.Name,
+				"workspace_owner_username": owner.Username,
+				"workspace_build_number":   strconv.FormatInt(int64(build.BuildNumber), 10),
+				"transition":               string(build.Transition),
+				"template_name":            template.Name,
+				"template_version_name":    templateVersion.Name,
+				"initiator":                build.InitiatorByUsername,
+			}, "provisionerdserver",
+			// Associate this notification with all the related entities.
+			workspace.ID, workspace.OwnerID, workspace.TemplateID, workspace.OrganizationID,
+		); err != nil {
+			s.Logger.Warn(ctx, "failed to notify of failed workspace build", slog.Error(err))
+		}
+	}
+}
+
 // CompleteJob is triggered by a provision daemon to mark a provisioner job as completed.
 func (s *server) CompleteJob(ctx context.Context, completed *proto.CompletedJob) (*proto.Empty, error) {
 	ctx, span := s.startTrace(ctx, tracing.FuncName())
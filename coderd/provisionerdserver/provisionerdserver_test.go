@@ -1805,6 +1805,116 @@ func TestNotifications(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("Workspace manual build failed", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name string
+			// ownerInitiated is true when the workspace owner, rather than an admin, initiates the build.
+			ownerInitiated bool
+		}{
+			{name: "AdminInitiated"},
+			{name: "OwnerInitiated", ownerInitiated: true},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				ctx := context.Background()
+				notifEnq := &testutil.FakeNotificationsEnqueuer{}
+
+				//	Otherwise `(*Server).FailJob` fails with:
+				// audit log - get build {"error": "sql: no rows in result set"}
+				ignoreLogErrors := true
+				srv, db, ps, pd := setup(t, ignoreLogErrors, &overrides{
+					notificationEnqueuer: notifEnq,
+				})
+
+				user := dbgen.User(t, db, database.User{})
+				templateAdmin := dbgen.User(t, db, database.User{RBACRoles: []string{codersdk.RoleTemplateAdmin}})
+				initiatingAdmin := dbgen.User(t, db, database.User{RBACRoles: []string{codersdk.RoleOwner}})
+				initiator := initiatingAdmin
+				if tc.ownerInitiated {
+					initiator = user
+				}
+
+				template := dbgen.Template(t, db, database.Template{
+					Name:           "template",
+					Provisioner:    database.ProvisionerTypeEcho,
+					OrganizationID: pd.OrganizationID,
+				})
+				file := dbgen.File(t, db, database.File{CreatedBy: user.ID})
+				workspace := dbgen.Workspace(t, db, database.Workspace{
+					TemplateID:     template.ID,
+					OwnerID:        user.ID,
+					OrganizationID: pd.OrganizationID,
+				})
+				version := dbgen.TemplateVersion(t, db, database.TemplateVersion{
+					OrganizationID: pd.OrganizationID,
+					TemplateID: uuid.NullUUID{
+						UUID:  template.ID,
+						Valid: true,
+					},
+					JobID: uuid.New(),
+				})
+				build := dbgen.WorkspaceBuild(t, db, database.WorkspaceBuild{
+					WorkspaceID:       workspace.ID,
+					TemplateVersionID: version.ID,
+					InitiatorID:       initiator.ID,
+					Transition:        database.WorkspaceTransitionStart,
+					Reason:            database.BuildReasonInitiator,
+				})
+				job := dbgen.ProvisionerJob(t, db, ps, database.ProvisionerJob{
+					FileID: file.ID,
+					Type:   database.ProvisionerJobTypeWorkspaceBuild,
+					Input: must(json.Marshal(provisionerdserver.WorkspaceProvisionJob{
+						WorkspaceBuildID: build.ID,
+					})),
+					OrganizationID: pd.OrganizationID,
+				})
+				_, err := db.AcquireProvisionerJob(ctx, database.AcquireProvisionerJobParams{
+					OrganizationID: pd.OrganizationID,
+					WorkerID: uuid.NullUUID{
+						UUID:  pd.ID,
+						Valid: true,
+					},
+					Types: []database.ProvisionerType{database.ProvisionerTypeEcho},
+				})
+				require.NoError(t, err)
+
+				_, err = srv.FailJob(ctx, &proto.FailedJob{
+					JobId: job.ID.String(),
+					Type: &proto.FailedJob_WorkspaceBuild_{
+						WorkspaceBuild: &proto.FailedJob_WorkspaceBuild{
+							State: []byte{},
+						},
+					},
+				})
+				require.NoError(t, err)
+
+				// Template admins and the workspace owner are notified, except for the one who initiated the build.
+				expected := []uuid.UUID{templateAdmin.ID, user.ID}
+				if tc.ownerInitiated {
+					expected = []uuid.UUID{templateAdmin.ID, initiatingAdmin.ID}
+				}
+				recipients := make([]uuid.UUID, 0, len(notifEnq.Sent))
+				for _, sent := range notifEnq.Sent {
+					recipients = append(recipients, sent.UserID)
+					require.Equal(t, notifications.TemplateWorkspaceManualBuildFailed, sent.TemplateID)
+					require.Contains(t, sent.Targets, workspace.ID)
+					require.Equal(t, workspace.Name, sent.Labels["name"])
+					require.Equal(t, user.Username, sent.Labels["workspace_owner_username"])
+					require.Equal(t, template.Name, sent.Labels["template_name"])
+					require.Equal(t, version.Name, sent.Labels["template_version_name"])
+					require.Equal(t, "start", sent.Labels["transition"])
+					require.Equal(t, "1", sent.Labels["workspace_build_number"])
+				}
+				require.ElementsMatch(t, expected, recipients)
+			})
+		}
+	})
 }
 
 type overrides struct {
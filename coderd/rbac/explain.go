@@ -0,0 +1,242 @@
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"github.com/open-policy-agent/opa/rego"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/rbac/policy"
+)
+
+// Decision is the outcome of the policy at a single level (site, org or
+// user). Any negative permission at a level denies the request, even if a
+// positive permission also matches.
+type Decision int
+
+const (
+	DecisionDeny    Decision = -1
+	DecisionAbstain Decision = 0
+	DecisionAllow   Decision = 1
+)
+
+func (d Decision) String() string {
+	switch d {
+	case DecisionDeny:
+		return "deny"
+	case DecisionAllow:
+		return "allow"
+	default:
+		return "abstain"
+	}
+}
+
+// Level is where in the hierarchy of a role a permission applies.
+type Level string
+
+const (
+	LevelSite Level = "site"
+	LevelOrg  Level = "org"
+	LevelUser Level = "user"
+)
+
+// Explanation describes how the policy came to its decision for a single
+// authorization request. It is intended for debugging, Authorize should be
+// used to make authorization decisions.
+type Explanation struct {
+	Allowed bool
+	// RoleAllowed is true if the roles of the subject allow the request.
+	RoleAllowed bool
+	// ACLAllowed is true if the user and group ACL of the object allow the
+	// request.
+	ACLAllowed bool
+	// ScopeAllowed is true if the scope of the subject allows the request.
+	// Requests are only allowed if both the scope and either the roles or the
+	// ACL allow it.
+	ScopeAllowed bool
+	// ScopeAllowList is true if the object is in the allow list of the
+	// scope.
+	ScopeAllowList bool
+	// OrgMember is true if the object belongs to an organization the subject
+	// is a member of.
+	OrgMember bool
+
+	Roles LevelDecisions
+	Scope LevelDecisions
+
+	// Permissions are the permissions of the roles and scope of the subject
+	// that match the action and object.
+	Permissions []ExplainedPermission
+	// ACL are the entries of the object's ACL that apply to the subject and
+	// include the action.
+	ACL []ExplainedACLEntry
+}
+
+// LevelDecisions are the decisions of the policy for each level.
+type LevelDecisions struct {
+	Site Decision
+	Org  Decision
+	User Decision
+}
+
+type ExplainedPermission struct {
+	Role RoleIdentifier
+	// Scope is true if the permission belongs to the scope of the subject
+	// rather than one of its roles.
+	Scope      bool
+	Level      Level
+	Permission Permission
+}
+
+type ExplainedACLEntry struct {
+	// Only one of UserID and GroupID is set. A group ID that matches the
+	// organization of the object is the "Everyone" group.
+	UserID  string
+	GroupID string
+	Actions []policy.Action
+}
+
+var (
+	explainQueryOnce sync.Once
+	explainQuery     rego.PreparedEvalQuery
+)
+
+// Explain evaluates the policy for the request and returns the intermediate
+// results of the evaluation, along with the permissions and ACL entries that
+// contributed to them.
+func Explain(ctx context.Context, subject Subject, action policy.Action, object Object) (Explanation, error) {
+	explainQueryOnce.Do(func() {
+		var err error
+		// Evaluating the whole package returns the value of every rule, not
+		// just the final 'allow'.
+		explainQuery, err = rego.New(
+			rego.Query("data.authz"),
+			rego.Module("policy.rego", regoPolicy),
+		).PrepareForEval(context.Background())
+		if err != nil {
+			panic(xerrors.Errorf("compile explain rego: %w", err))
+		}
+	})
+
+	if subject.Roles == nil {
+		return Explanation{}, xerrors.Errorf("subject must have roles")
+	}
+	if subject.Scope == nil {
+		return Explanation{}, xerrors.Errorf("subject must have a scope")
+	}
+
+	astV, err := regoInputValue(subject, action, object)
+	if err != nil {
+		return Explanation{}, xerrors.Errorf("convert input to value: %w", err)
+	}
+	results, err := explainQuery.Eval(ctx, rego.EvalParsedInput(astV))
+	if err != nil {
+		return Explanation{}, xerrors.Errorf("evaluate rego: %w", correctCancelError(err))
+	}
+	if len(results) != 1 || len(results[0].Expressions) != 1 {
+		return Explanation{}, xerrors.Errorf("unexpected rego result: %v", results)
+	}
+	doc, ok := results[0].Expressions[0].Value.(map[string]interface{})
+	if !ok {
+		return Explanation{}, xerrors.Errorf("unexpected rego value %T", results[0].Expressions[0].Value)
+	}
+
+	// Rules that are not true are undefined rather than false.
+	rule := func(name string) bool {
+		v, _ := doc[name].(bool)
+		return v
+	}
+	decision := func(name string) Decision {
+		v, ok := doc[name].(json.Number)
+		if !ok {
+			return DecisionAbstain
+		}
+		n, err := v.Int64()
+		if err != nil {
+			return DecisionAbstain
+		}
+		return Decision(n)
+	}
+
+	explanation := Explanation{
+		Allowed:        rule("allow"),
+		RoleAllowed:    rule("role_allow"),
+		ACLAllowed:     rule("acl_allow"),
+		ScopeAllowed:   rule("scope_allow"),
+		ScopeAllowList: rule("scope_allow_list"),
+		OrgMember:      rule("org_mem"),
+		Roles: LevelDecisions{
+			Site: decision("site"),
+			Org:  decision("org"),
+			User: decision("user"),
+		},
+		Scope: LevelDecisions{
+			Site: decision("scope_site"),
+			Org:  decision("scope_org"),
+			User: decision("scope_user"),
+		},
+	}
+
+	roles, err := subject.Roles.Expand()
+	if err != nil {
+		return Explanation{}, xerrors.Errorf("expand roles: %w", err)
+	}
+	for _, role := range roles {
+		explanation.Permissions = append(explanation.Permissions, matchingPermissions(role, false, subject, action, object)...)
+	}
+	scope, err := subject.Scope.Expand()
+	if err != nil {
+		return Explanation{}, xerrors.Errorf("expand scope: %w", err)
+	}
+	explanation.Permissions = append(explanation.Permissions, matchingPermissions(scope.Role, true, subject, action, object)...)
+
+	if actions, ok := object.ACLUserList[subject.ID]; ok && aclIncludes(actions, action) {
+		explanation.ACL = append(explanation.ACL, ExplainedACLEntry{UserID: subject.ID, Actions: actions})
+	}
+	if explanation.OrgMember {
+		groups := append(slices.Clone(subject.Groups), object.OrgID)
+		for _, group := range groups {
+			if actions, ok := object.ACLGroupList[group]; ok && aclIncludes(actions, action) {
+				explanation.ACL = append(explanation.ACL, ExplainedACLEntry{GroupID: group, Actions: actions})
+			}
+		}
+	}
+	return explanation, nil
+}
+
+// matchingPermissions mirrors the matching of permissions in the policy, to
+// find which permissions of the role were used for each level.
+func matchingPermissions(role Role, scope bool, subject Subject, action policy.Action, object Object) []ExplainedPermission {
+	var matches []ExplainedPermission
+	add := func(level Level, perms []Permission) {
+		for _, perm := range perms {
+			if perm.Action != action && perm.Action != policy.WildcardSymbol {
+				continue
+			}
+			if perm.ResourceType != object.Type && perm.ResourceType != policy.WildcardSymbol {
+				continue
+			}
+			matches = append(matches, ExplainedPermission{
+				Role:       role.Identifier,
+				Scope:      scope,
+				Level:      level,
+				Permission: perm,
+			})
+		}
+	}
+	add(LevelSite, role.Site)
+	if object.OrgID != "" {
+		add(LevelOrg, role.Org[object.OrgID])
+	}
+	if object.Owner != "" && object.Owner == subject.ID {
+		add(LevelUser, role.User)
+	}
+	return matches
+}
+
+func aclIncludes(actions []policy.Action, action policy.Action) bool {
+	return slices.Contains(actions, action) || slices.Contains(actions, policy.WildcardSymbol)
+}
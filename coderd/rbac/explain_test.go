@@ -0,0 +1,133 @@
+package rbac_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/coderd/rbac/policy"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+	groupID := uuid.NewString()
+	member := rbac.Subject{
+		ID:     userID.String(),
+		Roles:  rbac.RoleIdentifiers{rbac.RoleMember(), rbac.ScopedRoleOrgMember(orgID)},
+		Groups: []string{groupID},
+		Scope:  rbac.ScopeAll,
+	}
+	owner := rbac.Subject{
+		ID:    userID.String(),
+		Roles: rbac.RoleIdentifiers{rbac.RoleOwner(), rbac.RoleMember()},
+		Scope: rbac.ScopeAll,
+	}
+
+	t.Run("SiteRole", func(t *testing.T) {
+		t.Parallel()
+
+		explanation, err := rbac.Explain(context.Background(), owner, policy.ActionUpdate, rbac.ResourceTemplate.InOrg(orgID))
+		require.NoError(t, err)
+		assert.True(t, explanation.Allowed)
+		assert.True(t, explanation.RoleAllowed)
+		assert.True(t, explanation.ScopeAllowed)
+		assert.False(t, explanation.ACLAllowed)
+		assert.Equal(t, rbac.DecisionAllow, explanation.Roles.Site)
+		assert.Contains(t, explanation.Permissions, rbac.ExplainedPermission{
+			Role:  rbac.RoleOwner(),
+			Level: rbac.LevelSite,
+			Permission: rbac.Permission{
+				ResourceType: rbac.ResourceTemplate.Type,
+				Action:       policy.WildcardSymbol,
+			},
+		})
+	})
+
+	t.Run("Denied", func(t *testing.T) {
+		t.Parallel()
+
+		explanation, err := rbac.Explain(context.Background(), member, policy.ActionUpdate, rbac.ResourceTemplate.InOrg(orgID))
+		require.NoError(t, err)
+		assert.False(t, explanation.Allowed)
+		assert.False(t, explanation.RoleAllowed)
+		assert.False(t, explanation.ACLAllowed)
+		assert.True(t, explanation.OrgMember)
+		assert.Empty(t, explanation.ACL)
+	})
+
+	t.Run("UserACL", func(t *testing.T) {
+		t.Parallel()
+
+		object := rbac.ResourceTemplate.InOrg(orgID).WithACLUserList(map[string][]policy.Action{
+			userID.String(): {policy.ActionRead, policy.ActionUpdate},
+		})
+		explanation, err := rbac.Explain(context.Background(), member, policy.ActionUpdate, object)
+		require.NoError(t, err)
+		assert.True(t, explanation.Allowed)
+		assert.False(t, explanation.RoleAllowed)
+		assert.True(t, explanation.ACLAllowed)
+		assert.Equal(t, []rbac.ExplainedACLEntry{{
+			UserID:  userID.String(),
+			Actions: []policy.Action{policy.ActionRead, policy.ActionUpdate},
+		}}, explanation.ACL)
+	})
+
+	t.Run("GroupACL", func(t *testing.T) {
+		t.Parallel()
+
+		object := rbac.ResourceTemplate.InOrg(orgID).WithGroupACL(map[string][]policy.Action{
+			groupID:          {policy.WildcardSymbol},
+			orgID.String():   {policy.ActionRead},
+			uuid.NewString(): {policy.WildcardSymbol},
+		})
+		explanation, err := rbac.Explain(context.Background(), member, policy.ActionUpdate, object)
+		require.NoError(t, err)
+		assert.True(t, explanation.Allowed)
+		assert.True(t, explanation.ACLAllowed)
+		assert.Equal(t, []rbac.ExplainedACLEntry{{
+			GroupID: groupID,
+			Actions: []policy.Action{policy.WildcardSymbol},
+		}}, explanation.ACL)
+	})
+
+	t.Run("Scope", func(t *testing.T) {
+		t.Parallel()
+
+		subject := owner
+		subject.Scope = rbac.ScopeApplicationConnect
+		explanation, err := rbac.Explain(context.Background(), subject, policy.ActionUpdate, rbac.ResourceTemplate.InOrg(orgID))
+		require.NoError(t, err)
+		assert.False(t, explanation.Allowed)
+		assert.True(t, explanation.RoleAllowed)
+		assert.False(t, explanation.ScopeAllowed)
+		assert.Equal(t, rbac.DecisionAbstain, explanation.Scope.Site)
+	})
+
+	t.Run("MatchesAuthorize", func(t *testing.T) {
+		t.Parallel()
+
+		auth := rbac.NewAuthorizer(nil)
+		for _, subject := range []rbac.Subject{owner, member} {
+			for _, object := range []rbac.Object{
+				rbac.ResourceTemplate.InOrg(orgID),
+				rbac.ResourceWorkspace.InOrg(orgID).WithOwner(userID.String()),
+				rbac.ResourceWorkspace.InOrg(uuid.New()).WithOwner(userID.String()),
+				rbac.ResourceUser,
+			} {
+				for _, action := range []policy.Action{policy.ActionRead, policy.ActionUpdate, policy.ActionDelete} {
+					explanation, err := rbac.Explain(context.Background(), subject, action, object)
+					require.NoError(t, err)
+					err = auth.Authorize(context.Background(), subject, action, object)
+					assert.Equal(t, err == nil, explanation.Allowed, "%s %s %s", subject.Roles.Names(), action, object.Type)
+				}
+			}
+		}
+	})
+}
@@ -60,8 +60,10 @@ func WorkspaceConverter() *sqltypes.VariableConverter {
 		userOwnerMatcher(),
 	)
 	matcher.RegisterMatcher(
-		sqltypes.AlwaysFalse(groupACLMatcher(matcher)),
-		sqltypes.AlwaysFalse(userACLMatcher(matcher)),
+		// The workspaces query joins the templates table, which has ACL
+		// columns of its own, so the workspace columns must be qualified.
+		ACLGroupMatcher(matcher, "workspaces.group_acl", []string{"input", "object", "acl_group_list"}),
+		ACLGroupMatcher(matcher, "workspaces.user_acl", []string{"input", "object", "acl_user_list"}),
 	)
 
 	return matcher
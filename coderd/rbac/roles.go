@@ -508,6 +508,11 @@ var assignRoles = map[string]map[string]bool{
 		customSiteRole:         true,
 		customOrganizationRole: true,
 	},
+	// SCIM only provisions users with the default roles.
+	"scim": {
+		member:    true,
+		orgMember: true,
+	},
 	owner: {
 		owner:                  true,
 		auditor:                true,
@@ -2,6 +2,9 @@ package rbac
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/google/uuid"
 
@@ -83,6 +86,65 @@ var builtinScopes = map[ScopeName]Scope{
 	},
 }
 
+// ScopePermissions is a list of "<resource>:<action>" permissions, e.g.
+// "workspace:ssh". It expands to a scope that only allows the listed
+// permissions. Since a scope is intersected with the roles of the subject,
+// it can only ever take permissions away.
+type ScopePermissions []string
+
+// ParseScopePermissions validates the given "<resource>:<action>" strings
+// against the known RBAC permissions. The returned list is sorted and
+// deduplicated.
+func ParseScopePermissions(scopes []string) (ScopePermissions, error) {
+	perms := make(ScopePermissions, 0, len(scopes))
+	for _, scope := range scopes {
+		resource, action, ok := strings.Cut(scope, ":")
+		if !ok || resource == "" || action == "" {
+			return nil, xerrors.Errorf("invalid scope %q, expected format <resource>:<action>", scope)
+		}
+		perm := Permission{
+			ResourceType: resource,
+			Action:       policy.Action(action),
+		}
+		if err := perm.Valid(); err != nil {
+			return nil, xerrors.Errorf("invalid scope %q: %w", scope, err)
+		}
+		perms = append(perms, scope)
+	}
+	sort.Strings(perms)
+	return slices.Compact(perms), nil
+}
+
+func (p ScopePermissions) Expand() (Scope, error) {
+	perms, err := ParseScopePermissions(p)
+	if err != nil {
+		return Scope{}, err
+	}
+	site := make(map[string][]policy.Action)
+	for _, perm := range perms {
+		resource, action, _ := strings.Cut(perm, ":")
+		site[resource] = append(site[resource], policy.Action(action))
+	}
+	return Scope{
+		Role: Role{
+			Identifier:  p.Name(),
+			DisplayName: "Limited to " + strings.Join(perms, ", "),
+			Site:        Permissions(site),
+			Org:         map[string][]Permission{},
+			User:        []Permission{},
+		},
+		AllowIDList: []string{policy.WildcardSymbol},
+	}, nil
+}
+
+// Name includes every permission so that subjects with different
+// permissions never compare as equal.
+func (p ScopePermissions) Name() RoleIdentifier {
+	sorted := slices.Clone(p)
+	sort.Strings(sorted)
+	return RoleIdentifier{Name: fmt.Sprintf("Scope_permissions(%s)", strings.Join(slices.Compact(sorted), ","))}
+}
+
 type ExpandableScope interface {
 	Expand() (Scope, error)
 	// Name is for logging and tracing purposes, we want to know the human
@@ -116,6 +116,26 @@ func TestSubjectEqual(t *testing.T) {
 			},
 			Expected: false,
 		},
+		{
+			Name: "SamePermissionScopes",
+			A: rbac.Subject{
+				Scope: rbac.ScopePermissions{"workspace:read", "template:update"},
+			},
+			B: rbac.Subject{
+				Scope: rbac.ScopePermissions{"template:update", "workspace:read"},
+			},
+			Expected: true,
+		},
+		{
+			Name: "DifferentPermissionScopes",
+			A: rbac.Subject{
+				Scope: rbac.ScopePermissions{"workspace:read"},
+			},
+			B: rbac.Subject{
+				Scope: rbac.ScopePermissions{"workspace:read", "workspace:ssh"},
+			},
+			Expected: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,422 @@
+package coderd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/coderd/rbac/policy"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// serviceAccountEmail returns the placeholder email of a service account.
+// Service accounts never receive mail, but emails must be unique.
+func serviceAccountEmail(username string) string {
+	return fmt.Sprintf("%s@service-accounts.invalid", username)
+}
+
+// @Summary List organization service accounts
+// @ID list-organization-service-accounts
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Members
+// @Param organization path string true "Organization ID"
+// @Success 200 {array} codersdk.ServiceAccount
+// @Router /organizations/{organization}/service-accounts [get]
+func (api *API) serviceAccounts(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx          = r.Context()
+		organization = httpmw.OrganizationParam(r)
+	)
+
+	members, err := api.Database.OrganizationMembers(ctx, database.OrganizationMembersParams{
+		OrganizationID: organization.ID,
+		UserID:         uuid.Nil,
+	})
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	accounts := make([]codersdk.ServiceAccount, 0)
+	for _, member := range members {
+		if !member.IsServiceAccount {
+			continue
+		}
+		accounts = append(accounts, codersdk.ServiceAccount{
+			ID:             member.OrganizationMember.UserID,
+			Username:       member.Username,
+			Name:           member.Name,
+			OrganizationID: member.OrganizationMember.OrganizationID,
+			CreatedAt:      member.OrganizationMember.CreatedAt,
+		})
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, accounts)
+}
+
+// @Summary Create organization service account
+// @ID create-organization-service-account
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Members
+// @Param organization path string true "Organization ID"
+// @Param request body codersdk.CreateServiceAccountRequest true "Create service account request"
+// @Success 201 {object} codersdk.ServiceAccount
+// @Router /organizations/{organization}/service-accounts [post]
+func (api *API) postServiceAccount(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		organization      = httpmw.OrganizationParam(r)
+		auditor           = *api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.User](rw, &audit.RequestParams{
+			OrganizationID: organization.ID,
+			Audit:          auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionCreate,
+		})
+	)
+	aReq.Old = database.User{}
+	defer commitAudit()
+
+	var req codersdk.CreateServiceAccountRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	// Service accounts are managed by whoever can manage the members of
+	// the organization, which does not require any site-wide permissions.
+	if !api.Authorize(r, policy.ActionCreate, rbac.ResourceOrganizationMember.InOrg(organization.ID)) {
+		httpapi.Forbidden(rw)
+		return
+	}
+
+	// nolint:gocritic // The caller may not be able to read site users.
+	sysCtx := dbauthz.AsSystemRestricted(ctx)
+	_, err := api.Database.GetUserByEmailOrUsername(sysCtx, database.GetUserByEmailOrUsernameParams{
+		Username: req.Username,
+		Email:    serviceAccountEmail(req.Username),
+	})
+	if err == nil {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: "User already exists.",
+		})
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching user.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	user, _, err := api.CreateUser(sysCtx, api.Database, CreateUserRequest{
+		CreateUserRequest: codersdk.CreateUserRequest{
+			Email:          serviceAccountEmail(req.Username),
+			Username:       req.Username,
+			Name:           req.Name,
+			OrganizationID: organization.ID,
+		},
+		LoginType:      database.LoginTypeNone,
+		ServiceAccount: true,
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error creating service account.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.New = user
+
+	httpapi.Write(ctx, rw, http.StatusCreated, codersdk.ServiceAccount{
+		ID:             user.ID,
+		Username:       user.Username,
+		Name:           user.Name,
+		OrganizationID: organization.ID,
+		CreatedAt:      user.CreatedAt,
+	})
+}
+
+// serviceAccountParam returns the service account of the organization member
+// in the URL. A 404 is written if the member is not a service account.
+func (api *API) serviceAccountParam(rw http.ResponseWriter, r *http.Request) (database.User, bool) {
+	ctx := r.Context()
+	member := httpmw.OrganizationMemberParam(r)
+
+	// nolint:gocritic // The caller may not be able to read site users.
+	user, err := api.Database.GetUserByID(dbauthz.AsSystemRestricted(ctx), member.UserID)
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return database.User{}, false
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return database.User{}, false
+	}
+	if !user.IsServiceAccount || user.Deleted {
+		httpapi.ResourceNotFound(rw)
+		return database.User{}, false
+	}
+	return user, true
+}
+
+// @Summary Delete organization service account
+// @ID delete-organization-service-account
+// @Security CoderSessionToken
+// @Tags Members
+// @Param organization path string true "Organization ID"
+// @Param user path string true "Service account ID or username"
+// @Success 204
+// @Router /organizations/{organization}/service-accounts/{user} [delete]
+func (api *API) deleteServiceAccount(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		organization      = httpmw.OrganizationParam(r)
+		auditor           = *api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.User](rw, &audit.RequestParams{
+			OrganizationID: organization.ID,
+			Audit:          auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionDelete,
+		})
+	)
+	defer commitAudit()
+
+	if !api.Authorize(r, policy.ActionDelete, rbac.ResourceOrganizationMember.InOrg(organization.ID)) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	user, ok := api.serviceAccountParam(rw, r)
+	if !ok {
+		return
+	}
+	aReq.Old = user
+
+	// nolint:gocritic // Service accounts are managed by organization admins.
+	sysCtx := dbauthz.AsSystemRestricted(ctx)
+	workspaces, err := api.Database.GetWorkspaces(sysCtx, database.GetWorkspacesParams{
+		OwnerID: user.ID,
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspaces.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	if len(workspaces) > 0 {
+		httpapi.Write(ctx, rw, http.StatusExpectationFailed, codersdk.Response{
+			Message: "You cannot delete a service account that has workspaces. Delete its workspaces and try again!",
+		})
+		return
+	}
+
+	err = api.Database.InTx(func(tx database.Store) error {
+		err := tx.DeleteOrganizationMember(ctx, database.DeleteOrganizationMemberParams{
+			OrganizationID: organization.ID,
+			UserID:         user.ID,
+		})
+		if err != nil {
+			return err
+		}
+		// Deleting the user also deletes all of its tokens.
+		return tx.UpdateUserDeletedByID(sysCtx, user.ID)
+	}, nil)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error deleting service account.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	user.Deleted = true
+	aReq.New = user
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Create service account token
+// @ID create-service-account-token
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Members
+// @Param organization path string true "Organization ID"
+// @Param user path string true "Service account ID or username"
+// @Param request body codersdk.CreateTokenRequest true "Create token request"
+// @Success 201 {object} codersdk.GenerateAPIKeyResponse
+// @Router /organizations/{organization}/service-accounts/{user}/tokens [post]
+func (api *API) postServiceAccountToken(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		organization      = httpmw.OrganizationParam(r)
+		auditor           = *api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.APIKey](rw, &audit.RequestParams{
+			OrganizationID: organization.ID,
+			Audit:          auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionCreate,
+		})
+	)
+	aReq.Old = database.APIKey{}
+	defer commitAudit()
+
+	if !api.Authorize(r, policy.ActionUpdate, rbac.ResourceOrganizationMember.InOrg(organization.ID)) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	user, ok := api.serviceAccountParam(rw, r)
+	if !ok {
+		return
+	}
+
+	var createToken codersdk.CreateTokenRequest
+	if !httpapi.Read(ctx, rw, r, &createToken) {
+		return
+	}
+
+	params, err := api.tokenCreateParams(user, createToken)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Failed to validate create API key request.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	// nolint:gocritic // Service accounts are managed by organization admins.
+	cookie, key, err := api.createAPIKey(dbauthz.AsSystemRestricted(ctx), params)
+	if err != nil {
+		writeCreateTokenError(ctx, rw, params.TokenName, err)
+		return
+	}
+	aReq.New = *key
+	httpapi.Write(ctx, rw, http.StatusCreated, codersdk.GenerateAPIKeyResponse{Key: cookie.Value})
+}
+
+// @Summary List service account tokens
+// @ID list-service-account-tokens
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Members
+// @Param organization path string true "Organization ID"
+// @Param user path string true "Service account ID or username"
+// @Success 200 {array} codersdk.APIKey
+// @Router /organizations/{organization}/service-accounts/{user}/tokens [get]
+func (api *API) serviceAccountTokens(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx          = r.Context()
+		organization = httpmw.OrganizationParam(r)
+	)
+
+	if !api.Authorize(r, policy.ActionRead, rbac.ResourceOrganizationMember.InOrg(organization.ID)) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	user, ok := api.serviceAccountParam(rw, r)
+	if !ok {
+		return
+	}
+
+	// nolint:gocritic // Service accounts are managed by organization admins.
+	keys, err := api.Database.GetAPIKeysByUserID(dbauthz.AsSystemRestricted(ctx), database.GetAPIKeysByUserIDParams{
+		LoginType: database.LoginTypeToken,
+		UserID:    user.ID,
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching API keys.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	apiKeys := make([]codersdk.APIKey, 0, len(keys))
+	for _, key := range keys {
+		apiKeys = append(apiKeys, convertAPIKey(key))
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, apiKeys)
+}
+
+// @Summary Delete service account token
+// @ID delete-service-account-token
+// @Security CoderSessionToken
+// @Tags Members
+// @Param organization path string true "Organization ID"
+// @Param user path string true "Service account ID or username"
+// @Param keyid path string true "Key ID"
+// @Success 204
+// @Router /organizations/{organization}/service-accounts/{user}/tokens/{keyid} [delete]
+func (api *API) deleteServiceAccountToken(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		organization      = httpmw.OrganizationParam(r)
+		keyID             = chi.URLParam(r, "keyid")
+		auditor           = *api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.APIKey](rw, &audit.RequestParams{
+			OrganizationID: organization.ID,
+			Audit:          auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionDelete,
+		})
+	)
+	defer commitAudit()
+
+	if !api.Authorize(r, policy.ActionUpdate, rbac.ResourceOrganizationMember.InOrg(organization.ID)) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	user, ok := api.serviceAccountParam(rw, r)
+	if !ok {
+		return
+	}
+
+	// nolint:gocritic // Service accounts are managed by organization admins.
+	sysCtx := dbauthz.AsSystemRestricted(ctx)
+	key, err := api.Database.GetAPIKeyByID(sysCtx, keyID)
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	if key.UserID != user.ID {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	aReq.Old = key
+
+	err = api.Database.DeleteAPIKeyByID(sysCtx, keyID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error deleting API key.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,147 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/coderd/userpassword"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestServiceAccounts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+		owner := coderdtest.New(t, nil)
+		first := coderdtest.CreateFirstUser(t, owner)
+		orgAdmin, _ := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID, rbac.ScopedRoleOrgAdmin(first.OrganizationID))
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		account, err := orgAdmin.CreateServiceAccount(ctx, first.OrganizationID, codersdk.CreateServiceAccountRequest{
+			Username: "ci-bot",
+			Name:     "CI Bot",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "ci-bot", account.Username)
+		require.Equal(t, first.OrganizationID, account.OrganizationID)
+
+		accounts, err := orgAdmin.ServiceAccounts(ctx, first.OrganizationID)
+		require.NoError(t, err)
+		require.Len(t, accounts, 1)
+		require.Equal(t, account.ID, accounts[0].ID)
+
+		// Service accounts are not listed with the users of the deployment.
+		users, err := owner.Users(ctx, codersdk.UsersRequest{})
+		require.NoError(t, err)
+		for _, user := range users.Users {
+			require.NotEqual(t, account.ID, user.ID)
+		}
+
+		// Tokens must be scoped.
+		var apiErr *codersdk.Error
+		_, err = orgAdmin.CreateServiceAccountToken(ctx, first.OrganizationID, account.Username, codersdk.CreateTokenRequest{})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+
+		res, err := orgAdmin.CreateServiceAccountToken(ctx, first.OrganizationID, account.Username, codersdk.CreateTokenRequest{
+			TokenName: "ci",
+			Scopes:    []string{"user:read"},
+		})
+		require.NoError(t, err)
+		tokens, err := orgAdmin.ServiceAccountTokens(ctx, first.OrganizationID, account.Username)
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+		require.Equal(t, []string{"user:read"}, tokens[0].Scopes)
+
+		accountClient := codersdk.New(owner.URL)
+		accountClient.SetSessionToken(res.Key)
+		me, err := accountClient.User(ctx, codersdk.Me)
+		require.NoError(t, err)
+		require.Equal(t, account.ID, me.ID)
+
+		// Service accounts cannot mint unscoped keys for themselves.
+		_, err = accountClient.CreateAPIKey(ctx, codersdk.Me)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+
+		err = orgAdmin.DeleteServiceAccountToken(ctx, first.OrganizationID, account.Username, tokens[0].ID)
+		require.NoError(t, err)
+		_, err = accountClient.User(ctx, codersdk.Me)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode())
+
+		err = orgAdmin.DeleteServiceAccount(ctx, first.OrganizationID, account.Username)
+		require.NoError(t, err)
+		accounts, err = orgAdmin.ServiceAccounts(ctx, first.OrganizationID)
+		require.NoError(t, err)
+		require.Empty(t, accounts)
+	})
+
+	t.Run("CannotLogin", func(t *testing.T) {
+		t.Parallel()
+		owner, db := coderdtest.NewWithDatabase(t, nil)
+		first := coderdtest.CreateFirstUser(t, owner)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		account, err := owner.CreateServiceAccount(ctx, first.OrganizationID, codersdk.CreateServiceAccountRequest{
+			Username: "ci-bot",
+		})
+		require.NoError(t, err)
+
+		// Even with a password, the service account is rejected because it is
+		// one, rather than because the password is wrong.
+		const password = "SomeSecurePassword!"
+		hashed, err := userpassword.Hash(password)
+		require.NoError(t, err)
+		err = db.UpdateUserHashedPassword(dbauthz.AsSystemRestricted(ctx), database.UpdateUserHashedPasswordParams{
+			ID:             account.ID,
+			HashedPassword: []byte(hashed),
+		})
+		require.NoError(t, err)
+
+		_, err = owner.LoginWithPassword(ctx, codersdk.LoginWithPasswordRequest{
+			Email:    "ci-bot@service-accounts.invalid",
+			Password: password,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+		require.Contains(t, apiErr.Message, "Service accounts cannot log in")
+	})
+
+	t.Run("NotServiceAccount", func(t *testing.T) {
+		t.Parallel()
+		owner := coderdtest.New(t, nil)
+		first := coderdtest.CreateFirstUser(t, owner)
+		_, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		var apiErr *codersdk.Error
+		err := owner.DeleteServiceAccount(ctx, first.OrganizationID, user.Username)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+
+	t.Run("MemberForbidden", func(t *testing.T) {
+		t.Parallel()
+		owner := coderdtest.New(t, nil)
+		first := coderdtest.CreateFirstUser(t, owner)
+		member, _ := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		var apiErr *codersdk.Error
+		_, err := member.CreateServiceAccount(ctx, first.OrganizationID, codersdk.CreateServiceAccountRequest{
+			Username: "ci-bot",
+		})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+	})
+}
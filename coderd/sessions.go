@@ -0,0 +1,198 @@
+package coderd
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/coderd/rbac/policy"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// @Summary Get user sessions
+// @ID get-user-sessions
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Users
+// @Param user path string true "User ID, name, or me"
+// @Success 200 {array} codersdk.UserSession
+// @Router /users/{user}/sessions [get]
+func (api *API) userSessions(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		user   = httpmw.UserParam(r)
+		apiKey = httpmw.APIKey(r)
+	)
+
+	if !api.Authorize(r, policy.ActionRead, rbac.ResourceApiKey.WithOwner(user.ID.String())) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+
+	keys, err := api.Database.GetSessionAPIKeysByUserID(ctx, user.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching sessions.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	sessions := make([]codersdk.UserSession, 0, len(keys))
+	for _, key := range keys {
+		sessions = append(sessions, convertUserSession(key, apiKey.ID))
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, sessions)
+}
+
+// @Summary Revoke user session
+// @ID revoke-user-session
+// @Security CoderSessionToken
+// @Tags Users
+// @Param user path string true "User ID, name, or me"
+// @Param sessionid path string true "Session ID"
+// @Success 204
+// @Router /users/{user}/sessions/{sessionid} [delete]
+func (api *API) deleteUserSession(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		user              = httpmw.UserParam(r)
+		sessionID         = chi.URLParam(r, "sessionid")
+		auditor           = api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.APIKey](rw, &audit.RequestParams{
+			Audit:   *auditor,
+			Log:     api.Logger,
+			Request: r,
+			Action:  database.AuditActionDelete,
+		})
+	)
+	defer commitAudit()
+
+	key, err := api.Database.GetAPIKeyByID(ctx, sessionID)
+	// Tokens and keys of other users are not sessions of this user.
+	if httpapi.Is404Error(err) || (err == nil && (key.UserID != user.ID || !isSessionAPIKey(key))) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching session.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.Old = key
+
+	err = api.Database.DeleteAPIKeyByID(ctx, key.ID)
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error deleting session.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Revoke all user sessions
+// @ID revoke-all-user-sessions
+// @Security CoderSessionToken
+// @Tags Users
+// @Param user path string true "User ID, name, or me"
+// @Success 204
+// @Router /users/{user}/sessions [delete]
+func (api *API) deleteUserSessions(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		user    = httpmw.UserParam(r)
+		apiKey  = httpmw.APIKey(r)
+		auditor = api.Auditor.Load()
+	)
+
+	if !api.Authorize(r, policy.ActionDelete, rbac.ResourceApiKey.WithOwner(user.ID.String())) {
+		httpapi.Forbidden(rw)
+		return
+	}
+
+	keys, err := api.Database.GetSessionAPIKeysByUserID(ctx, user.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching sessions.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	for _, key := range keys {
+		err = api.Database.DeleteAPIKeyByID(ctx, key.ID)
+		if err != nil && !httpapi.Is404Error(err) {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error deleting session.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+		audit.BackgroundAudit(ctx, &audit.BackgroundAuditParams[database.APIKey]{
+			Audit:     *auditor,
+			Log:       api.Logger,
+			UserID:    apiKey.UserID,
+			RequestID: httpmw.RequestID(r),
+			Status:    http.StatusNoContent,
+			Action:    database.AuditActionDelete,
+			IP:        r.RemoteAddr,
+			Old:       key,
+		})
+	}
+
+	// Subdomain app tokens are minted from sessions, so they are invalidated
+	// too. This matches logging out.
+	err = api.Database.DeleteApplicationConnectAPIKeysByUserID(ctx, user.ID)
+	if err != nil {
+		api.Logger.Error(ctx, "unable to invalidate subdomain app tokens", slog.F("user_id", user.ID), slog.Error(err))
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error deleting app tokens.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// isSessionAPIKey reports whether the key was created by logging in, as
+// opposed to a token or a key scoped to workspace applications. It matches
+// the GetSessionAPIKeysByUserID query, apart from the expiry.
+func isSessionAPIKey(key database.APIKey) bool {
+	return key.LoginType != database.LoginTypeToken &&
+		key.Scope == database.APIKeyScopeAll &&
+		key.TokenName == ""
+}
+
+func convertUserSession(key database.APIKey, currentID string) codersdk.UserSession {
+	var ip string
+	if key.IPAddress.Valid {
+		ip = key.IPAddress.IPNet.IP.String()
+	}
+	return codersdk.UserSession{
+		ID:        key.ID,
+		CreatedAt: key.CreatedAt,
+		LastUsed:  key.LastUsed,
+		ExpiresAt: key.ExpiresAt,
+		LoginType: codersdk.LoginType(key.LoginType),
+		IPAddress: ip,
+		UserAgent: key.UserAgent,
+		Current:   key.ID == currentID,
+	}
+}
@@ -0,0 +1,143 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestUserSessions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("List", func(t *testing.T) {
+		t.Parallel()
+		owner, db := coderdtest.NewWithDatabase(t, nil)
+		first := coderdtest.CreateFirstUser(t, owner)
+		client, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		// Tokens are not sessions.
+		_, err := client.CreateToken(ctx, codersdk.Me, codersdk.CreateTokenRequest{})
+		require.NoError(t, err)
+		// Neither are sessions that expired.
+		_, _ = dbgen.APIKey(t, db, database.APIKey{
+			UserID:    user.ID,
+			ExpiresAt: dbtime.Now().Add(-time.Hour),
+		})
+		current, err := client.APIKeyByID(ctx, codersdk.Me, client.SessionToken()[:10])
+		require.NoError(t, err)
+
+		sessions, err := client.UserSessions(ctx, codersdk.Me)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		require.Equal(t, current.ID, sessions[0].ID)
+		require.Equal(t, codersdk.LoginTypePassword, sessions[0].LoginType)
+		require.NotEmpty(t, sessions[0].IPAddress)
+		require.NotEmpty(t, sessions[0].UserAgent)
+		require.True(t, sessions[0].Current)
+
+		// Owners can list the sessions of other users.
+		sessions, err = owner.UserSessions(ctx, user.ID.String())
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		require.False(t, sessions[0].Current)
+
+		// Sessions are only visible to owners, not user admins.
+		userAdmin, _ := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID, rbac.RoleUserAdmin())
+		var apiErr *codersdk.Error
+		_, err = userAdmin.UserSessions(ctx, user.ID.String())
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		t.Parallel()
+		owner := coderdtest.New(t, nil)
+		first := coderdtest.CreateFirstUser(t, owner)
+		client, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		other := codersdk.New(owner.URL)
+		res, err := other.LoginWithPassword(ctx, codersdk.LoginWithPasswordRequest{
+			Email:    user.Email,
+			Password: "SomeSecurePassword!",
+		})
+		require.NoError(t, err)
+		other.SetSessionToken(res.SessionToken)
+
+		err = client.RevokeUserSession(ctx, codersdk.Me, res.SessionToken[:10])
+		require.NoError(t, err)
+
+		var apiErr *codersdk.Error
+		_, err = other.User(ctx, codersdk.Me)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode())
+
+		// The session that made the request is unaffected.
+		sessions, err := client.UserSessions(ctx, codersdk.Me)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		require.True(t, sessions[0].Current)
+	})
+
+	t.Run("RevokeToken", func(t *testing.T) {
+		t.Parallel()
+		owner := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, owner)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		token, err := owner.CreateToken(ctx, codersdk.Me, codersdk.CreateTokenRequest{})
+		require.NoError(t, err)
+
+		// Tokens are revoked through the keys endpoints.
+		var apiErr *codersdk.Error
+		err = owner.RevokeUserSession(ctx, codersdk.Me, token.Key[:10])
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+
+	t.Run("RevokeAll", func(t *testing.T) {
+		t.Parallel()
+		owner := coderdtest.New(t, nil)
+		first := coderdtest.CreateFirstUser(t, owner)
+		client, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		token, err := client.CreateToken(ctx, codersdk.Me, codersdk.CreateTokenRequest{})
+		require.NoError(t, err)
+
+		// Only owners can sign out other users.
+		userAdmin, _ := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID, rbac.RoleUserAdmin())
+		var apiErr *codersdk.Error
+		err = userAdmin.RevokeUserSessions(ctx, user.ID.String())
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+
+		err = owner.RevokeUserSessions(ctx, user.ID.String())
+		require.NoError(t, err)
+
+		_, err = client.User(ctx, codersdk.Me)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode())
+
+		// Tokens keep working.
+		tokenClient := codersdk.New(owner.URL)
+		tokenClient.SetSessionToken(token.Key)
+		_, err = tokenClient.User(ctx, codersdk.Me)
+		require.NoError(t, err)
+
+		sessions, err := owner.UserSessions(ctx, user.ID.String())
+		require.NoError(t, err)
+		require.Empty(t, sessions)
+	})
+}
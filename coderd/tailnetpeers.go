@@ -0,0 +1,104 @@
+package coderd
+
+import (
+	"net/netip"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/tailnet"
+	"github.com/coder/coder/v2/tailnet/proto"
+)
+
+// tailnetPeerUsers remembers the user behind every tailnet client that
+// coordinates with an agent through this replica. Agents only see the
+// tailnet address of the peer that connects to them, so this is used to
+// attribute the connections they report to a user.
+type tailnetPeerUsers struct {
+	mu sync.Mutex
+	// users is keyed by agent ID and client address.
+	users map[tailnetPeerKey]uuid.UUID
+}
+
+type tailnetPeerKey struct {
+	agentID uuid.UUID
+	addr    netip.Addr
+}
+
+func newTailnetPeerUsers() *tailnetPeerUsers {
+	return &tailnetPeerUsers{users: map[tailnetPeerKey]uuid.UUID{}}
+}
+
+// User returns the user of the client with the given address that
+// coordinates with the agent, if it coordinates through this replica.
+func (p *tailnetPeerUsers) User(agentID uuid.UUID, addr netip.Addr) (uuid.UUID, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	userID, ok := p.users[tailnetPeerKey{agentID: agentID, addr: addr}]
+	return userID, ok
+}
+
+// Auth returns the coordinatee auth of a client of the user that connects to
+// the agent. It records the addresses the client announces until Close is
+// called.
+func (p *tailnetPeerUsers) Auth(userID, agentID uuid.UUID) *tailnetPeerAuth {
+	return &tailnetPeerAuth{
+		ClientCoordinateeAuth: tailnet.ClientCoordinateeAuth{AgentID: agentID},
+		peers:                 p,
+		userID:                userID,
+	}
+}
+
+func (p *tailnetPeerUsers) set(agentID, userID uuid.UUID, oldAddrs, newAddrs []netip.Addr) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, addr := range oldAddrs {
+		key := tailnetPeerKey{agentID: agentID, addr: addr}
+		if p.users[key] == userID {
+			delete(p.users, key)
+		}
+	}
+	for _, addr := range newAddrs {
+		p.users[tailnetPeerKey{agentID: agentID, addr: addr}] = userID
+	}
+}
+
+type tailnetPeerAuth struct {
+	tailnet.ClientCoordinateeAuth
+	peers  *tailnetPeerUsers
+	userID uuid.UUID
+
+	mu    sync.Mutex
+	addrs []netip.Addr
+}
+
+func (a *tailnetPeerAuth) Authorize(req *proto.CoordinateRequest) error {
+	err := a.ClientCoordinateeAuth.Authorize(req)
+	if err != nil {
+		return err
+	}
+	if upd := req.GetUpdateSelf(); upd != nil {
+		addrs := make([]netip.Addr, 0, len(upd.Node.Addresses))
+		for _, addrStr := range upd.Node.Addresses {
+			// Already validated by ClientCoordinateeAuth.
+			pre, err := netip.ParsePrefix(addrStr)
+			if err != nil {
+				continue
+			}
+			addrs = append(addrs, pre.Addr())
+		}
+		a.mu.Lock()
+		a.peers.set(a.AgentID, a.userID, a.addrs, addrs)
+		a.addrs = addrs
+		a.mu.Unlock()
+	}
+	return nil
+}
+
+// Close forgets the addresses of the client.
+func (a *tailnetPeerAuth) Close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.peers.set(a.AgentID, a.userID, a.addrs, nil)
+	a.addrs = nil
+}
@@ -0,0 +1,57 @@
+package coderd
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/tailnet"
+	"github.com/coder/coder/v2/tailnet/proto"
+)
+
+func TestTailnetPeerUsers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		peers     = newTailnetPeerUsers()
+		userID    = uuid.New()
+		agentID   = uuid.New()
+		otherID   = uuid.New()
+		firstIP   = tailnet.IP()
+		secondIP  = tailnet.IP()
+		updateFor = func(addrs ...netip.Addr) *proto.CoordinateRequest {
+			node := &proto.Node{}
+			for _, addr := range addrs {
+				node.Addresses = append(node.Addresses, netip.PrefixFrom(addr, 128).String())
+			}
+			return &proto.CoordinateRequest{UpdateSelf: &proto.CoordinateRequest_UpdateSelf{Node: node}}
+		}
+	)
+
+	auth := peers.Auth(userID, agentID)
+	require.NoError(t, auth.Authorize(updateFor(firstIP)))
+	got, ok := peers.User(agentID, firstIP)
+	require.True(t, ok)
+	require.Equal(t, userID, got)
+	// Only the agent the client coordinates with can attribute it.
+	_, ok = peers.User(otherID, firstIP)
+	require.False(t, ok)
+
+	// The previous addresses are forgotten when the client updates them.
+	require.NoError(t, auth.Authorize(updateFor(secondIP)))
+	_, ok = peers.User(agentID, firstIP)
+	require.False(t, ok)
+	got, ok = peers.User(agentID, secondIP)
+	require.True(t, ok)
+	require.Equal(t, userID, got)
+
+	// Tunnels to other agents are still rejected.
+	err := auth.Authorize(&proto.CoordinateRequest{AddTunnel: &proto.CoordinateRequest_Tunnel{Id: otherID[:]}})
+	require.Error(t, err)
+
+	auth.Close()
+	_, ok = peers.User(agentID, secondIP)
+	require.False(t, ok)
+}
@@ -0,0 +1,246 @@
+package coderd
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/prebuilds"
+	"github.com/coder/coder/v2/coderd/rbac/policy"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// @Summary Get template prebuild pools
+// @ID get-template-prebuild-pools
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Templates
+// @Param template path string true "Template ID" format(uuid)
+// @Success 200 {array} codersdk.TemplatePrebuildPool
+// @Router /templates/{template}/prebuilds [get]
+func (api *API) templatePrebuildPools(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx      = r.Context()
+		template = httpmw.TemplateParam(r)
+	)
+
+	pools, err := api.Database.GetTemplatePrebuildPools(ctx, template.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template prebuild pools.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	prebuilt, err := api.Database.GetPrebuiltWorkspaces(ctx, template.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching prebuilt workspaces.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertTemplatePrebuildPools(pools, prebuilt))
+}
+
+// @Summary Update template prebuild pools
+// @Description Replaces the prebuild pools of a template. Pools are matched
+// @Description by name. Pools that are not in the request are removed, along
+// @Description with their prebuilt workspaces.
+// @ID update-template-prebuild-pools
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Templates
+// @Param template path string true "Template ID" format(uuid)
+// @Param request body codersdk.UpdateTemplatePrebuildPoolsRequest true "Update template prebuild pools request"
+// @Success 200 {array} codersdk.TemplatePrebuildPool
+// @Router /templates/{template}/prebuilds [put]
+func (api *API) putTemplatePrebuildPools(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx      = r.Context()
+		template = httpmw.TemplateParam(r)
+	)
+
+	if !api.Authorize(r, policy.ActionUpdate, template) {
+		httpapi.Forbidden(rw)
+		return
+	}
+
+	var req codersdk.UpdateTemplatePrebuildPoolsRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	tvp, err := api.Database.GetTemplateVersionParameters(ctx, template.ActiveVersionID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template version parameters.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	var (
+		validErrs  []codersdk.ValidationError
+		names      = make(map[string]struct{}, len(req.Pools))
+		parameters = make([]database.StringMap, len(req.Pools))
+	)
+	for i, pool := range req.Pools {
+		field := fmt.Sprintf("pools[%d]", i)
+		if err := httpapi.NameValid(pool.Name); err != nil {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: field + ".name", Detail: err.Error()})
+		}
+		if _, ok := names[pool.Name]; ok {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: field + ".name", Detail: fmt.Sprintf("Pool %q is specified more than once.", pool.Name)})
+		}
+		names[pool.Name] = struct{}{}
+		if err := validatePrebuildPoolParameters(tvp, pool.Parameters); err != nil {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: field + ".parameters", Detail: err.Error()})
+		}
+		parameters[i] = make(database.StringMap, len(pool.Parameters))
+		for _, param := range pool.Parameters {
+			parameters[i][param.Name] = param.Value
+		}
+	}
+	if len(validErrs) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid prebuild pools.",
+			Validations: validErrs,
+		})
+		return
+	}
+
+	var pools []database.TemplatePrebuildPool
+	err = api.Database.InTx(func(tx database.Store) error {
+		existing, err := tx.GetTemplatePrebuildPools(ctx, template.ID)
+		if err != nil {
+			return xerrors.Errorf("get template prebuild pools: %w", err)
+		}
+		byName := make(map[string]database.TemplatePrebuildPool, len(existing))
+		for _, pool := range existing {
+			byName[pool.Name] = pool
+		}
+
+		now := dbtime.Now()
+		pools = make([]database.TemplatePrebuildPool, 0, len(req.Pools))
+		for i, reqPool := range req.Pools {
+			pool, ok := byName[reqPool.Name]
+			delete(byName, reqPool.Name)
+			switch {
+			case ok && maps.Equal(pool.Parameters, parameters[i]):
+				if pool.DesiredInstances != reqPool.DesiredInstances {
+					pool, err = tx.UpdateTemplatePrebuildPoolByID(ctx, database.UpdateTemplatePrebuildPoolByIDParams{
+						ID:               pool.ID,
+						DesiredInstances: reqPool.DesiredInstances,
+						UpdatedAt:        now,
+					})
+					if err != nil {
+						return xerrors.Errorf("update template prebuild pool %q: %w", reqPool.Name, err)
+					}
+				}
+				pools = append(pools, pool)
+				continue
+			case ok:
+				// The prebuilt workspaces of the pool were built with other
+				// parameters, so they are replaced along with the pool.
+				err = tx.DeleteTemplatePrebuildPoolByID(ctx, pool.ID)
+				if err != nil {
+					return xerrors.Errorf("delete template prebuild pool %q: %w", reqPool.Name, err)
+				}
+			}
+			pool, err = tx.InsertTemplatePrebuildPool(ctx, database.InsertTemplatePrebuildPoolParams{
+				ID:               uuid.New(),
+				TemplateID:       template.ID,
+				Name:             reqPool.Name,
+				DesiredInstances: reqPool.DesiredInstances,
+				Parameters:       parameters[i],
+				CreatedAt:        now,
+				UpdatedAt:        now,
+			})
+			if err != nil {
+				return xerrors.Errorf("insert template prebuild pool %q: %w", reqPool.Name, err)
+			}
+			pools = append(pools, pool)
+		}
+		for _, pool := range byName {
+			err = tx.DeleteTemplatePrebuildPoolByID(ctx, pool.ID)
+			if err != nil {
+				return xerrors.Errorf("delete template prebuild pool %q: %w", pool.Name, err)
+			}
+		}
+		slices.SortFunc(pools, func(a, b database.TemplatePrebuildPool) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		return nil
+	}, nil)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error updating template prebuild pools.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	prebuilt, err := api.Database.GetPrebuiltWorkspaces(ctx, template.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching prebuilt workspaces.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertTemplatePrebuildPools(pools, prebuilt))
+}
+
+// validatePrebuildPoolParameters checks that the parameters of a pool can be
+// used to build a workspace with the template version.
+func validatePrebuildPoolParameters(tvp []database.TemplateVersionParameter, values []codersdk.WorkspaceBuildParameter) error {
+	for _, value := range values {
+		found := false
+		for _, param := range tvp {
+			if param.Name != value.Name {
+				continue
+			}
+			if param.Ephemeral {
+				return xerrors.Errorf("Parameter %q is ephemeral and cannot be prebuilt.", value.Name)
+			}
+			found = true
+			break
+		}
+		if !found {
+			return xerrors.Errorf("Parameter %q does not exist in the active template version.", value.Name)
+		}
+	}
+	_, err := prebuilds.ResolveParameters(tvp, values)
+	return err
+}
+
+func convertTemplatePrebuildPools(pools []database.TemplatePrebuildPool, prebuilt []database.GetPrebuiltWorkspacesRow) []codersdk.TemplatePrebuildPool {
+	converted := make([]codersdk.TemplatePrebuildPool, 0, len(pools))
+	for _, pool := range pools {
+		ready, starting := prebuilds.InstanceCounts(pool, prebuilt)
+		converted = append(converted, codersdk.TemplatePrebuildPool{
+			ID:                pool.ID,
+			Name:              pool.Name,
+			DesiredInstances:  pool.DesiredInstances,
+			Parameters:        prebuilds.PoolParameters(pool),
+			ReadyInstances:    int32(ready),
+			StartingInstances: int32(starting),
+			CreatedAt:         pool.CreatedAt,
+			UpdatedAt:         pool.UpdatedAt,
+		})
+	}
+	return converted
+}
@@ -0,0 +1,126 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/provisioner/echo"
+	"github.com/coder/coder/v2/provisionersdk/proto"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestTemplatePrebuildPools(t *testing.T) {
+	t.Parallel()
+
+	client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+	owner := coderdtest.CreateFirstUser(t, client)
+	version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, &echo.Responses{
+		Parse: echo.ParseComplete,
+		ProvisionPlan: []*proto.Response{{
+			Type: &proto.Response_Plan{
+				Plan: &proto.PlanComplete{
+					Parameters: []*proto.RichParameter{
+						{Name: "region", Type: "string", DefaultValue: "us"},
+						{Name: "reason", Type: "string", Mutable: true, Ephemeral: true},
+					},
+				},
+			},
+		}},
+		ProvisionApply: echo.ApplyComplete,
+	})
+	coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+	template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+
+	t.Run("Update", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+
+		pools, err := client.TemplatePrebuildPools(ctx, template.ID)
+		require.NoError(t, err)
+		require.Empty(t, pools)
+
+		pools, err = client.UpdateTemplatePrebuildPools(ctx, template.ID, codersdk.UpdateTemplatePrebuildPoolsRequest{
+			Pools: []codersdk.TemplatePrebuildPoolRequest{
+				{Name: "us", DesiredInstances: 2},
+				{Name: "eu", DesiredInstances: 1, Parameters: []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "eu"}}},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, pools, 2)
+		require.Equal(t, "eu", pools[0].Name)
+		require.Equal(t, []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "eu"}}, pools[0].Parameters)
+		require.Equal(t, "us", pools[1].Name)
+		require.EqualValues(t, 2, pools[1].DesiredInstances)
+		require.Empty(t, pools[1].Parameters)
+		eu, us := pools[0], pools[1]
+
+		// Changing the size keeps the pool, changing the parameters
+		// replaces it.
+		pools, err = client.UpdateTemplatePrebuildPools(ctx, template.ID, codersdk.UpdateTemplatePrebuildPoolsRequest{
+			Pools: []codersdk.TemplatePrebuildPoolRequest{
+				{Name: "us", DesiredInstances: 3},
+				{Name: "eu", DesiredInstances: 1, Parameters: []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "eu-west"}}},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, pools, 2)
+		require.NotEqual(t, eu.ID, pools[0].ID)
+		require.Equal(t, us.ID, pools[1].ID)
+		require.EqualValues(t, 3, pools[1].DesiredInstances)
+
+		// Pools that are not listed are removed.
+		pools, err = client.UpdateTemplatePrebuildPools(ctx, template.ID, codersdk.UpdateTemplatePrebuildPoolsRequest{
+			Pools: []codersdk.TemplatePrebuildPoolRequest{{Name: "us", DesiredInstances: 3}},
+		})
+		require.NoError(t, err)
+		require.Len(t, pools, 1)
+		require.Equal(t, us.ID, pools[0].ID)
+
+		pools, err = client.TemplatePrebuildPools(ctx, template.ID)
+		require.NoError(t, err)
+		require.Len(t, pools, 1)
+		require.Equal(t, us.ID, pools[0].ID)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+
+		for _, pools := range [][]codersdk.TemplatePrebuildPoolRequest{
+			{{Name: "default", DesiredInstances: 1}, {Name: "default", DesiredInstances: 2}},
+			{{Name: "not a name", DesiredInstances: 1}},
+			{{Name: "default", DesiredInstances: 1, Parameters: []codersdk.WorkspaceBuildParameter{{Name: "size", Value: "1"}}}},
+			{{Name: "default", DesiredInstances: 1, Parameters: []codersdk.WorkspaceBuildParameter{{Name: "reason", Value: "testing"}}}},
+			{{Name: "default", DesiredInstances: -1}},
+		} {
+			_, err := client.UpdateTemplatePrebuildPools(ctx, template.ID, codersdk.UpdateTemplatePrebuildPoolsRequest{Pools: pools})
+			var apiErr *codersdk.Error
+			require.ErrorAs(t, err, &apiErr)
+			require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+		}
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		member, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		// Members can see the pools of templates they can use, but not
+		// change them.
+		_, err := member.TemplatePrebuildPools(ctx, template.ID)
+		require.NoError(t, err)
+		_, err = member.UpdateTemplatePrebuildPools(ctx, template.ID, codersdk.UpdateTemplatePrebuildPoolsRequest{
+			Pools: []codersdk.TemplatePrebuildPoolRequest{{Name: "default", DesiredInstances: 1}},
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+	})
+}
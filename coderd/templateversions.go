@@ -9,6 +9,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -25,6 +27,7 @@ import (
 	"github.com/coder/coder/v2/coderd/externalauth"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/provisionerdserver"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
@@ -74,7 +77,16 @@ func (api *API) templateVersion(rw http.ResponseWriter, r *http.Request) {
 		warnings = append(warnings, codersdk.TemplateVersionWarningUnsupportedWorkspaces)
 	}
 
-	httpapi.Write(ctx, rw, http.StatusOK, convertTemplateVersion(templateVersion, convertProvisionerJob(jobs[0]), warnings))
+	presets, err := api.Database.GetTemplateVersionPresetsByTemplateVersionIDs(ctx, []uuid.UUID{templateVersion.ID})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template version presets.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertTemplateVersion(templateVersion, convertProvisionerJob(jobs[0]), presets, warnings))
 }
 
 // @Summary Patch template version by ID
@@ -95,6 +107,15 @@ func (api *API) patchTemplateVersion(rw http.ResponseWriter, r *http.Request) {
 	if !httpapi.Read(ctx, rw, r, &params) {
 		return
 	}
+	if params.Presets != nil {
+		if validErrs := validateTemplateVersionPresets(*params.Presets); len(validErrs) > 0 {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message:     "Invalid template version presets.",
+				Validations: validErrs,
+			})
+			return
+		}
+	}
 
 	updateParams := database.UpdateTemplateVersionByIDParams{
 		ID:         templateVersion.ID,
@@ -138,6 +159,17 @@ func (api *API) patchTemplateVersion(rw http.ResponseWriter, r *http.Request) {
 			return xerrors.Errorf("error on patching template version: %v", err)
 		}
 
+		if params.Presets != nil {
+			err = tx.DeleteTemplateVersionPresetsByTemplateVersionID(ctx, templateVersion.ID)
+			if err != nil {
+				return xerrors.Errorf("delete template version presets: %w", err)
+			}
+			err = insertTemplateVersionPresets(ctx, tx, templateVersion.ID, *params.Presets)
+			if err != nil {
+				return err
+			}
+		}
+
 		updatedTemplateVersion, err = tx.GetTemplateVersionByID(ctx, updateParams.ID)
 		if err != nil {
 			return xerrors.Errorf("error on fetching patched template version: %v", err)
@@ -170,7 +202,16 @@ func (api *API) patchTemplateVersion(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httpapi.Write(ctx, rw, http.StatusOK, convertTemplateVersion(updatedTemplateVersion, convertProvisionerJob(jobs[0]), nil))
+	presets, err := api.Database.GetTemplateVersionPresetsByTemplateVersionIDs(ctx, []uuid.UUID{templateVersion.ID})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template version presets.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertTemplateVersion(updatedTemplateVersion, convertProvisionerJob(jobs[0]), presets, nil))
 }
 
 // @Summary Cancel template version by ID
@@ -802,6 +843,23 @@ func (api *API) templateVersionsByTemplate(rw http.ResponseWriter, r *http.Reque
 			jobByID[job.ProvisionerJob.ID.String()] = job
 		}
 
+		versionIDs := make([]uuid.UUID, 0, len(versions))
+		for _, version := range versions {
+			versionIDs = append(versionIDs, version.ID)
+		}
+		presets, err := store.GetTemplateVersionPresetsByTemplateVersionIDs(ctx, versionIDs)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error fetching template version presets.",
+				Detail:  err.Error(),
+			})
+			return err
+		}
+		presetsByVersionID := make(map[uuid.UUID][]database.TemplateVersionPreset)
+		for _, preset := range presets {
+			presetsByVersionID[preset.TemplateVersionID] = append(presetsByVersionID[preset.TemplateVersionID], preset)
+		}
+
 		for _, version := range versions {
 			job, exists := jobByID[version.JobID.String()]
 			if !exists {
@@ -811,7 +869,7 @@ func (api *API) templateVersionsByTemplate(rw http.ResponseWriter, r *http.Reque
 				return err
 			}
 
-			apiVersions = append(apiVersions, convertTemplateVersion(version, convertProvisionerJob(job), nil))
+			apiVersions = append(apiVersions, convertTemplateVersion(version, convertProvisionerJob(job), presetsByVersionID[version.ID], nil))
 		}
 
 		return nil
@@ -866,7 +924,16 @@ func (api *API) templateVersionByName(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httpapi.Write(ctx, rw, http.StatusOK, convertTemplateVersion(templateVersion, convertProvisionerJob(jobs[0]), nil))
+	presets, err := api.Database.GetTemplateVersionPresetsByTemplateVersionIDs(ctx, []uuid.UUID{templateVersion.ID})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template version presets.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertTemplateVersion(templateVersion, convertProvisionerJob(jobs[0]), presets, nil))
 }
 
 // @Summary Get template version by organization, template, and name
@@ -931,7 +998,16 @@ func (api *API) templateVersionByOrganizationTemplateAndName(rw http.ResponseWri
 		return
 	}
 
-	httpapi.Write(ctx, rw, http.StatusOK, convertTemplateVersion(templateVersion, convertProvisionerJob(jobs[0]), nil))
+	presets, err := api.Database.GetTemplateVersionPresetsByTemplateVersionIDs(ctx, []uuid.UUID{templateVersion.ID})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template version presets.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertTemplateVersion(templateVersion, convertProvisionerJob(jobs[0]), presets, nil))
 }
 
 // @Summary Get previous template version by organization, template, and name
@@ -1017,7 +1093,16 @@ func (api *API) previousTemplateVersionByOrganizationTemplateAndName(rw http.Res
 		return
 	}
 
-	httpapi.Write(ctx, rw, http.StatusOK, convertTemplateVersion(previousTemplateVersion, convertProvisionerJob(jobs[0]), nil))
+	presets, err := api.Database.GetTemplateVersionPresetsByTemplateVersionIDs(ctx, []uuid.UUID{previousTemplateVersion.ID})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template version presets.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertTemplateVersion(previousTemplateVersion, convertProvisionerJob(jobs[0]), presets, nil))
 }
 
 // @Summary Archive template unused versions by template id
@@ -1284,12 +1369,40 @@ func (api *API) patchActiveTemplateVersion(rw http.ResponseWriter, r *http.Reque
 	aReq.New = newTemplate
 
 	api.publishTemplateUpdate(ctx, template.ID)
+	api.notifyTemplateVersionPromoted(ctx, template, version, httpmw.UserAuthorization(r))
 
 	httpapi.Write(ctx, rw, http.StatusOK, codersdk.Response{
 		Message: "Updated the active template version!",
 	})
 }
 
+// notifyTemplateVersionPromoted notifies template admins, other than the one who promoted it, that a template has a
+// new active version.
+func (api *API) notifyTemplateVersionPromoted(ctx context.Context, template database.Template, version database.TemplateVersion, initiator rbac.Subject) {
+	admins, err := notifications.TemplateAdmins(ctx, api.Database)
+	if err != nil {
+		api.Logger.Warn(ctx, "failed to find template admins to notify of promoted template version", slog.Error(err))
+		return
+	}
+
+	for _, admin := range admins {
+		if admin.String() == initiator.ID {
+			continue
+		}
+		if _, err := api.NotificationsEnqueuer.Enqueue(ctx, admin, notifications.TemplateTemplateVersionPromoted,
+			map[string]string{
+				"name":         template.Name,
+				"version_name": version.Name,
+				"initiator":    initiator.FriendlyName,
+			}, "api-templates-promote",
+			// Associate this notification with all the related entities.
+			template.ID, version.ID, template.OrganizationID,
+		); err != nil {
+			api.Logger.Warn(ctx, "failed to notify of promoted template version", slog.F("template_id", template.ID), slog.Error(err))
+		}
+	}
+}
+
 // postTemplateVersionsByOrganization creates a new version of a template. An import job is queued to parse the storage method provided.
 //
 // @Summary Create template version by organization
@@ -1323,6 +1436,13 @@ func (api *API) postTemplateVersionsByOrganization(rw http.ResponseWriter, r *ht
 	if !httpapi.Read(ctx, rw, r, &req) {
 		return
 	}
+	if validErrs := validateTemplateVersionPresets(req.Presets); len(validErrs) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid template version presets.",
+			Validations: validErrs,
+		})
+		return
+	}
 
 	if req.TemplateID != uuid.Nil {
 		_, err := api.Database.GetTemplateByID(ctx, req.TemplateID)
@@ -1439,6 +1559,7 @@ func (api *API) postTemplateVersionsByOrganization(rw http.ResponseWriter, r *ht
 
 	var templateVersion database.TemplateVersion
 	var provisionerJob database.ProvisionerJob
+	var presets []database.TemplateVersionPreset
 	err = api.Database.InTx(func(tx database.Store) error {
 		jobID := uuid.New()
 
@@ -1504,6 +1625,15 @@ func (api *API) postTemplateVersionsByOrganization(rw http.ResponseWriter, r *ht
 			return xerrors.Errorf("insert template version: %w", err)
 		}
 
+		err = insertTemplateVersionPresets(ctx, tx, templateVersionID, req.Presets)
+		if err != nil {
+			return err
+		}
+		presets, err = tx.GetTemplateVersionPresetsByTemplateVersionIDs(ctx, []uuid.UUID{templateVersionID})
+		if err != nil {
+			return xerrors.Errorf("get template version presets: %w", err)
+		}
+
 		templateVersion, err = tx.GetTemplateVersionByID(ctx, templateVersionID)
 		if err != nil {
 			return xerrors.Errorf("fetched inserted template version: %w", err)
@@ -1527,7 +1657,7 @@ func (api *API) postTemplateVersionsByOrganization(rw http.ResponseWriter, r *ht
 	httpapi.Write(ctx, rw, http.StatusCreated, convertTemplateVersion(templateVersion, convertProvisionerJob(database.GetProvisionerJobsByIDsWithQueuePositionRow{
 		ProvisionerJob: provisionerJob,
 		QueuePosition:  0,
-	}), nil))
+	}), presets, nil))
 }
 
 // templateVersionResources returns the workspace agent resources associated
@@ -1594,7 +1724,7 @@ func (api *API) templateVersionLogs(rw http.ResponseWriter, r *http.Request) {
 	api.provisionerJobLogs(rw, r, job)
 }
 
-func convertTemplateVersion(version database.TemplateVersion, job codersdk.ProvisionerJob, warnings []codersdk.TemplateVersionWarning) codersdk.TemplateVersion {
+func convertTemplateVersion(version database.TemplateVersion, job codersdk.ProvisionerJob, presets []database.TemplateVersionPreset, warnings []codersdk.TemplateVersionWarning) codersdk.TemplateVersion {
 	return codersdk.TemplateVersion{
 		ID:             version.ID,
 		TemplateID:     &version.TemplateID.UUID,
@@ -1611,10 +1741,95 @@ func convertTemplateVersion(version database.TemplateVersion, job codersdk.Provi
 			AvatarURL: version.CreatedByAvatarURL,
 		},
 		Archived: version.Archived,
+		Presets:  convertTemplateVersionPresets(presets),
 		Warnings: warnings,
 	}
 }
 
+func convertTemplateVersionPresets(presets []database.TemplateVersionPreset) []codersdk.TemplateVersionPreset {
+	converted := make([]codersdk.TemplateVersionPreset, 0, len(presets))
+	for _, preset := range presets {
+		parameters := make([]codersdk.WorkspaceBuildParameter, 0, len(preset.Parameters))
+		for name, value := range preset.Parameters {
+			parameters = append(parameters, codersdk.WorkspaceBuildParameter{Name: name, Value: value})
+		}
+		slices.SortFunc(parameters, func(a, b codersdk.WorkspaceBuildParameter) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		converted = append(converted, codersdk.TemplateVersionPreset{
+			ID:         preset.ID,
+			Name:       preset.Name,
+			Parameters: parameters,
+		})
+	}
+	return converted
+}
+
+// presetParameterValues returns the values of the preset merged with the
+// given values, which take precedence.
+func presetParameterValues(preset database.TemplateVersionPreset, values []codersdk.WorkspaceBuildParameter) []codersdk.WorkspaceBuildParameter {
+	merged := slices.Clone(values)
+	for _, param := range convertTemplateVersionPresets([]database.TemplateVersionPreset{preset})[0].Parameters {
+		if !slices.ContainsFunc(values, func(value codersdk.WorkspaceBuildParameter) bool {
+			return value.Name == param.Name
+		}) {
+			merged = append(merged, param)
+		}
+	}
+	return merged
+}
+
+// validateTemplateVersionPresets checks the names of presets and their
+// parameters. The values are validated when a workspace is built with the
+// preset, since the parameters of a new version are not known until it is
+// imported.
+func validateTemplateVersionPresets(presets []codersdk.TemplateVersionPresetRequest) []codersdk.ValidationError {
+	var validErrs []codersdk.ValidationError
+	names := make(map[string]struct{}, len(presets))
+	for i, preset := range presets {
+		field := fmt.Sprintf("presets[%d]", i)
+		if err := httpapi.NameValid(preset.Name); err != nil {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: field + ".name", Detail: err.Error()})
+		}
+		if _, ok := names[preset.Name]; ok {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: field + ".name", Detail: fmt.Sprintf("Preset %q is specified more than once.", preset.Name)})
+		}
+		names[preset.Name] = struct{}{}
+		parameters := make(map[string]struct{}, len(preset.Parameters))
+		for _, param := range preset.Parameters {
+			if param.Name == "" {
+				validErrs = append(validErrs, codersdk.ValidationError{Field: field + ".parameters", Detail: "Parameter name is required."})
+				continue
+			}
+			if _, ok := parameters[param.Name]; ok {
+				validErrs = append(validErrs, codersdk.ValidationError{Field: field + ".parameters", Detail: fmt.Sprintf("Parameter %q is specified more than once.", param.Name)})
+			}
+			parameters[param.Name] = struct{}{}
+		}
+	}
+	return validErrs
+}
+
+func insertTemplateVersionPresets(ctx context.Context, tx database.Store, templateVersionID uuid.UUID, presets []codersdk.TemplateVersionPresetRequest) error {
+	for _, preset := range presets {
+		parameters := make(database.StringMap, len(preset.Parameters))
+		for _, param := range preset.Parameters {
+			parameters[param.Name] = param.Value
+		}
+		_, err := tx.InsertTemplateVersionPreset(ctx, database.InsertTemplateVersionPresetParams{
+			ID:                uuid.New(),
+			TemplateVersionID: templateVersionID,
+			Name:              preset.Name,
+			Parameters:        parameters,
+			CreatedAt:         dbtime.Now(),
+		})
+		if err != nil {
+			return xerrors.Errorf("insert template version preset %q: %w", preset.Name, err)
+		}
+	}
+	return nil
+}
+
 func convertTemplateVersionParameters(dbParams []database.TemplateVersionParameter) ([]codersdk.TemplateVersionParameter, error) {
 	params := make([]codersdk.TemplateVersionParameter, 0)
 	for _, dbParameter := range dbParams {
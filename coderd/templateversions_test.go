@@ -17,6 +17,7 @@ import (
 	"github.com/coder/coder/v2/coderd/coderdtest"
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/externalauth"
+	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/codersdk"
@@ -673,6 +674,40 @@ func TestPatchActiveTemplateVersion(t *testing.T) {
 		require.Len(t, auditor.AuditLogs(), 6)
 		assert.Equal(t, database.AuditActionWrite, auditor.AuditLogs()[5].Action)
 	})
+
+	t.Run("NotifiesTemplateAdmins", func(t *testing.T) {
+		t.Parallel()
+		notifyEnq := &testutil.FakeNotificationsEnqueuer{}
+		client := coderdtest.New(t, &coderdtest.Options{
+			IncludeProvisionerDaemon: true,
+			NotificationsEnqueuer:    notifyEnq,
+		})
+		user := coderdtest.CreateFirstUser(t, client)
+		templateAdminClient, templateAdmin := coderdtest.CreateAnotherUser(t, client, user.OrganizationID, rbac.RoleTemplateAdmin())
+		_, _ = coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		version = coderdtest.UpdateTemplateVersion(t, client, user.OrganizationID, nil, template.ID)
+		_ = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		notifyEnq.Clear()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		err := templateAdminClient.UpdateActiveTemplateVersion(ctx, template.ID, codersdk.UpdateActiveTemplateVersion{
+			ID: version.ID,
+		})
+		require.NoError(t, err)
+
+		// Only the owner is notified, as the template admin promoted the version and the member administers nothing.
+		require.Len(t, notifyEnq.Sent, 1)
+		require.Equal(t, user.UserID, notifyEnq.Sent[0].UserID)
+		require.Equal(t, notifications.TemplateTemplateVersionPromoted, notifyEnq.Sent[0].TemplateID)
+		require.Equal(t, template.Name, notifyEnq.Sent[0].Labels["name"])
+		require.Equal(t, version.Name, notifyEnq.Sent[0].Labels["version_name"])
+		require.Equal(t, templateAdmin.Username, notifyEnq.Sent[0].Labels["initiator"])
+		require.Contains(t, notifyEnq.Sent[0].Targets, template.ID)
+	})
 }
 
 func TestTemplateVersionDryRun(t *testing.T) {
@@ -1460,6 +1495,93 @@ func TestTemplateVersionPatch(t *testing.T) {
 	})
 }
 
+func TestTemplateVersionPresets(t *testing.T) {
+	t.Parallel()
+
+	client := coderdtest.New(t, nil)
+	user := coderdtest.CreateFirstUser(t, client)
+
+	t.Run("Create", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil, func(req *codersdk.CreateTemplateVersionRequest) {
+			req.Presets = []codersdk.TemplateVersionPresetRequest{
+				{Name: "small", Parameters: []codersdk.WorkspaceBuildParameter{{Name: "size", Value: "small"}}},
+				{Name: "large", Parameters: []codersdk.WorkspaceBuildParameter{{Name: "size", Value: "large"}, {Name: "region", Value: "eu"}}},
+			}
+		})
+		require.Len(t, version.Presets, 2)
+		require.Equal(t, "large", version.Presets[0].Name)
+		require.Equal(t, []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "eu"}, {Name: "size", Value: "large"}}, version.Presets[0].Parameters)
+		require.Equal(t, "small", version.Presets[1].Name)
+
+		got, err := client.TemplateVersion(ctx, version.ID)
+		require.NoError(t, err)
+		require.Equal(t, version.Presets, got.Presets)
+
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		versions, err := client.TemplateVersionsByTemplate(ctx, codersdk.TemplateVersionsByTemplateRequest{TemplateID: template.ID})
+		require.NoError(t, err)
+		require.Len(t, versions, 1)
+		require.Equal(t, version.Presets, versions[0].Presets)
+	})
+
+	t.Run("Patch", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil, func(req *codersdk.CreateTemplateVersionRequest) {
+			req.Presets = []codersdk.TemplateVersionPresetRequest{{Name: "small"}}
+		})
+
+		// Presets are kept unless they are given.
+		updated, err := client.UpdateTemplateVersion(ctx, version.ID, codersdk.PatchTemplateVersionRequest{
+			Name: "renamed",
+		})
+		require.NoError(t, err)
+		require.Equal(t, version.Presets, updated.Presets)
+
+		updated, err = client.UpdateTemplateVersion(ctx, version.ID, codersdk.PatchTemplateVersionRequest{
+			Presets: &[]codersdk.TemplateVersionPresetRequest{
+				{Name: "large", Parameters: []codersdk.WorkspaceBuildParameter{{Name: "size", Value: "large"}}},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, updated.Presets, 1)
+		require.Equal(t, "large", updated.Presets[0].Name)
+
+		updated, err = client.UpdateTemplateVersion(ctx, version.ID, codersdk.PatchTemplateVersionRequest{
+			Presets: &[]codersdk.TemplateVersionPresetRequest{},
+		})
+		require.NoError(t, err)
+		require.Empty(t, updated.Presets)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		for _, presets := range [][]codersdk.TemplateVersionPresetRequest{
+			{{Name: "small"}, {Name: "small"}},
+			{{Name: "not a name"}},
+			{{Name: "small", Parameters: []codersdk.WorkspaceBuildParameter{{Name: "", Value: "small"}}}},
+			{{Name: "small", Parameters: []codersdk.WorkspaceBuildParameter{{Name: "size", Value: "1"}, {Name: "size", Value: "2"}}}},
+		} {
+			_, err := client.UpdateTemplateVersion(ctx, version.ID, codersdk.PatchTemplateVersionRequest{
+				Presets: &presets,
+			})
+			var apiErr *codersdk.Error
+			require.ErrorAs(t, err, &apiErr)
+			require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+		}
+	})
+}
+
 func TestTemplateVersionParameters_Order(t *testing.T) {
 	t.Parallel()
 
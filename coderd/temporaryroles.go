@@ -0,0 +1,131 @@
+package coderd
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// validateTemporaryRoles checks the expiry of a role update and writes an
+// error response if it is invalid.
+func validateTemporaryRoles(ctx context.Context, rw http.ResponseWriter, params codersdk.UpdateRoles) bool {
+	if params.ExpiresAt == nil {
+		if params.Justification != "" {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "A justification can only be given for roles that expire.",
+				Validations: []codersdk.ValidationError{
+					{Field: "justification", Detail: "requires expires_at to be set"},
+				},
+			})
+			return false
+		}
+		return true
+	}
+	if !params.ExpiresAt.After(dbtime.Now()) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Roles must expire in the future.",
+			Validations: []codersdk.ValidationError{
+				{Field: "expires_at", Detail: "must be in the future"},
+			},
+		})
+		return false
+	}
+	return true
+}
+
+// temporaryRolesAuditFields records the expiry of a role update in the audit
+// log, since it is not part of the audited user or member.
+func temporaryRolesAuditFields(params codersdk.UpdateRoles) map[string]any {
+	if params.ExpiresAt == nil {
+		return nil
+	}
+	return map[string]any{
+		"expires_at":    params.ExpiresAt.UTC(),
+		"justification": params.Justification,
+	}
+}
+
+// grantedRoles returns the roles of a user after an update of their current
+// roles. Roles that expire are added to the current roles instead of replacing
+// them.
+func grantedRoles(current []string, params codersdk.UpdateRoles) []string {
+	if params.ExpiresAt == nil {
+		return params.Roles
+	}
+	roles := slices.Clone(current)
+	for _, role := range params.Roles {
+		if !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// updateTemporaryRoles updates the temporary role grants of a user after their
+// roles changed from oldRoles to newRoles. Grants of removed roles are deleted.
+// If the update expires, the roles it names are granted until then, except
+// for roles the user already had permanently. Otherwise every role the user
+// has is granted permanently.
+func updateTemporaryRoles(ctx context.Context, tx database.Store, userID uuid.UUID, orgID uuid.NullUUID, oldRoles, newRoles []string, params codersdk.UpdateRoles) error {
+	var clearGrants []string
+	for _, role := range oldRoles {
+		if !slices.Contains(newRoles, role) {
+			clearGrants = append(clearGrants, role)
+		}
+	}
+	if params.ExpiresAt == nil {
+		clearGrants = append(clearGrants, newRoles...)
+	}
+	if len(clearGrants) > 0 {
+		err := tx.DeleteTemporaryRoleGrants(ctx, database.DeleteTemporaryRoleGrantsParams{
+			UserID:         userID,
+			OrganizationID: orgID,
+			RoleNames:      clearGrants,
+		})
+		if err != nil {
+			return xerrors.Errorf("delete grants: %w", err)
+		}
+	}
+
+	if params.ExpiresAt == nil {
+		return nil
+	}
+
+	grants, err := tx.GetTemporaryRoleGrantsByUserID(ctx, userID)
+	if err != nil {
+		return xerrors.Errorf("get temporary role grants: %w", err)
+	}
+	temporary := make(map[string]bool)
+	for _, grant := range grants {
+		if grant.OrganizationID == orgID {
+			temporary[grant.RoleName] = true
+		}
+	}
+
+	for _, role := range newRoles {
+		if !slices.Contains(params.Roles, role) || (slices.Contains(oldRoles, role) && !temporary[role]) {
+			continue
+		}
+		_, err := tx.UpsertTemporaryRoleGrant(ctx, database.UpsertTemporaryRoleGrantParams{
+			ID:             uuid.New(),
+			UserID:         userID,
+			OrganizationID: orgID,
+			RoleName:       role,
+			Justification:  params.Justification,
+			CreatedAt:      dbtime.Now(),
+			ExpiresAt:      dbtime.Time(*params.ExpiresAt),
+		})
+		if err != nil {
+			return xerrors.Errorf("grant role %q: %w", role, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package temporaryroles
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+)
+
+const (
+	// Expired roles are already ignored for authorization, so they do not
+	// have to be removed right away.
+	delay = time.Minute
+)
+
+// New starts removing roles that were granted for a limited time once they
+// expire, and records the removals in the audit log.
+// It is the caller's responsibility to call Close on the returned instance.
+func New(ctx context.Context, logger slog.Logger, db database.Store, auditor *atomic.Pointer[audit.Auditor]) io.Closer {
+	closed := make(chan struct{})
+
+	ctx, cancelFunc := context.WithCancel(ctx)
+	//nolint:gocritic // The system removes expired roles without user input.
+	ctx = dbauthz.AsSystemRestricted(ctx)
+
+	// Use time.Nanosecond to force an initial tick. It will be reset to the
+	// correct duration after executing once.
+	ticker := time.NewTicker(time.Nanosecond)
+	doTick := func() {
+		defer ticker.Reset(delay)
+
+		var audits []func()
+		// Start a transaction to grab advisory lock, we don't want to remove
+		// the same roles on multiple replicas.
+		err := db.InTx(func(tx database.Store) error {
+			audits = nil
+			ok, err := tx.TryAcquireLock(ctx, database.LockIDExpireTemporaryRoles)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				logger.Debug(ctx, "unable to acquire lock for expiring temporary roles, skipping")
+				return nil
+			}
+
+			grants, err := tx.GetExpiredTemporaryRoleGrants(ctx, dbtime.Now())
+			if err != nil {
+				return xerrors.Errorf("get expired grants: %w", err)
+			}
+			for _, grant := range grants {
+				commitAudit, err := expire(ctx, logger, tx, auditor, grant)
+				if err != nil {
+					return xerrors.Errorf("expire role %q of user %s: %w", grant.RoleName, grant.UserID, err)
+				}
+				if commitAudit == nil {
+					continue
+				}
+				audits = append(audits, commitAudit)
+				logger.Info(ctx, "removed expired role",
+					slog.F("user_id", grant.UserID),
+					slog.F("organization_id", grant.OrganizationID.UUID),
+					slog.F("role", grant.RoleName),
+				)
+			}
+			return nil
+		}, nil)
+		if err != nil {
+			logger.Error(ctx, "failed to expire temporary roles", slog.Error(err))
+			return
+		}
+		for _, commitAudit := range audits {
+			commitAudit()
+		}
+	}
+
+	go func() {
+		defer close(closed)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				ticker.Stop()
+				doTick()
+			}
+		}
+	}()
+	return &instance{
+		cancel: cancelFunc,
+		closed: closed,
+	}
+}
+
+// expire removes an expired role from the user or organization member, and
+// returns a function that records the change in the audit log once it is
+// committed. It returns no function if the role was already removed.
+func expire(ctx context.Context, logger slog.Logger, tx database.Store, auditor *atomic.Pointer[audit.Auditor], grant database.TemporaryRoleGrant) (func(), error) {
+	err := tx.DeleteTemporaryRoleGrantByID(ctx, grant.ID)
+	if err != nil {
+		return nil, xerrors.Errorf("delete grant: %w", err)
+	}
+	fields, err := json.Marshal(map[string]string{
+		"reason":        "temporary role expired",
+		"role":          grant.RoleName,
+		"justification": grant.Justification,
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("marshal audit fields: %w", err)
+	}
+
+	if !grant.OrganizationID.Valid {
+		user, err := tx.GetUserByID(ctx, grant.UserID)
+		if err != nil {
+			return nil, xerrors.Errorf("get user: %w", err)
+		}
+		if !slices.Contains(user.RBACRoles, grant.RoleName) {
+			return nil, nil
+		}
+		updated, err := tx.UpdateUserRoles(ctx, database.UpdateUserRolesParams{
+			GrantedRoles: slices.DeleteFunc(slices.Clone(user.RBACRoles), func(role string) bool {
+				return role == grant.RoleName
+			}),
+			ID: user.ID,
+		})
+		if err != nil {
+			return nil, xerrors.Errorf("update user roles: %w", err)
+		}
+		return func() {
+			audit.BackgroundAudit(ctx, &audit.BackgroundAuditParams[database.User]{
+				Audit:            *auditor.Load(),
+				Log:              logger,
+				Status:           http.StatusOK,
+				Action:           database.AuditActionWrite,
+				AdditionalFields: fields,
+				Old:              user,
+				New:              updated,
+			})
+		}, nil
+	}
+
+	member, err := database.ExpectOne(tx.OrganizationMembers(ctx, database.OrganizationMembersParams{
+		OrganizationID: grant.OrganizationID.UUID,
+		UserID:         grant.UserID,
+	}))
+	if errors.Is(err, sql.ErrNoRows) {
+		// The user left the organization, which removed the role already.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("get organization member: %w", err)
+	}
+	if !slices.Contains(member.OrganizationMember.Roles, grant.RoleName) {
+		return nil, nil
+	}
+	updated, err := tx.UpdateMemberRoles(ctx, database.UpdateMemberRolesParams{
+		GrantedRoles: slices.DeleteFunc(slices.Clone(member.OrganizationMember.Roles), func(role string) bool {
+			return role == grant.RoleName
+		}),
+		UserID: grant.UserID,
+		OrgID:  grant.OrganizationID.UUID,
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("update member roles: %w", err)
+	}
+	return func() {
+		audit.BackgroundAudit(ctx, &audit.BackgroundAuditParams[database.AuditableOrganizationMember]{
+			Audit:            *auditor.Load(),
+			Log:              logger,
+			Status:           http.StatusOK,
+			Action:           database.AuditActionWrite,
+			OrganizationID:   grant.OrganizationID.UUID,
+			AdditionalFields: fields,
+			Old:              member.OrganizationMember.Auditable(member.Username),
+			New:              updated.Auditable(member.Username),
+		})
+	}, nil
+}
+
+type instance struct {
+	cancel context.CancelFunc
+	closed chan struct{}
+}
+
+func (i *instance) Close() error {
+	i.cancel()
+	<-i.closed
+	return nil
+}
@@ -0,0 +1,114 @@
+package temporaryroles_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"cdr.dev/slog/sloggers/slogtest"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/database/dbmem"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/coderd/temporaryroles"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestExpire(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	db := dbmem.New()
+	org := dbgen.Organization(t, db, database.Organization{})
+	user := dbgen.User(t, db, database.User{
+		RBACRoles: []string{rbac.RoleTemplateAdmin().Name, rbac.RoleAuditor().Name},
+	})
+	dbgen.OrganizationMember(t, db, database.OrganizationMember{
+		OrganizationID: org.ID,
+		UserID:         user.ID,
+		Roles:          []string{rbac.RoleOrgAdmin()},
+	})
+	// The template admin role has expired, the auditor role has not.
+	dbgen.TemporaryRoleGrant(t, db, database.TemporaryRoleGrant{
+		UserID:    user.ID,
+		RoleName:  rbac.RoleTemplateAdmin().Name,
+		ExpiresAt: dbtime.Now().Add(-time.Minute),
+	})
+	active := dbgen.TemporaryRoleGrant(t, db, database.TemporaryRoleGrant{
+		UserID:    user.ID,
+		RoleName:  rbac.RoleAuditor().Name,
+		ExpiresAt: dbtime.Now().Add(time.Hour),
+	})
+	dbgen.TemporaryRoleGrant(t, db, database.TemporaryRoleGrant{
+		UserID:         user.ID,
+		OrganizationID: uuid.NullUUID{UUID: org.ID, Valid: true},
+		RoleName:       rbac.RoleOrgAdmin(),
+		ExpiresAt:      dbtime.Now().Add(-time.Minute),
+	})
+
+	// Expired roles are ignored for authorization before they are removed.
+	roles, err := db.GetAuthorizationUserRoles(ctx, user.ID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		rbac.RoleAuditor().Name,
+		rbac.RoleMember().Name,
+		rbac.RoleOrgMember() + ":" + org.ID.String(),
+	}, roles.Roles)
+
+	mockAuditor := audit.NewMock()
+	var auditor atomic.Pointer[audit.Auditor]
+	var a audit.Auditor = mockAuditor
+	auditor.Store(&a)
+
+	expirer := temporaryroles.New(context.Background(), slogtest.Make(t, nil), db, &auditor)
+	defer expirer.Close()
+
+	require.Eventually(t, func() bool {
+		grants, err := db.GetTemporaryRoleGrantsByUserID(ctx, user.ID)
+		return err == nil && len(grants) == 1
+	}, testutil.WaitShort, testutil.IntervalFast)
+	require.NoError(t, expirer.Close())
+
+	grants, err := db.GetTemporaryRoleGrantsByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, []database.TemporaryRoleGrant{active}, grants)
+
+	updated, err := db.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{rbac.RoleAuditor().Name}, updated.RBACRoles)
+	member, err := database.ExpectOne(db.OrganizationMembers(ctx, database.OrganizationMembersParams{
+		OrganizationID: org.ID,
+		UserID:         user.ID,
+	}))
+	require.NoError(t, err)
+	require.Empty(t, member.OrganizationMember.Roles)
+
+	logs := mockAuditor.AuditLogs()
+	require.Len(t, logs, 2)
+	for _, log := range logs {
+		assert.Equal(t, database.AuditActionWrite, log.Action)
+		assert.Equal(t, uuid.Nil, log.UserID)
+		assert.Contains(t, string(log.AdditionalFields), "temporary role expired")
+	}
+	assert.True(t, mockAuditor.Contains(t, database.AuditLog{
+		ResourceType: database.ResourceTypeUser,
+		ResourceID:   user.ID,
+	}))
+	assert.True(t, mockAuditor.Contains(t, database.AuditLog{
+		ResourceType:   database.ResourceTypeOrganizationMember,
+		OrganizationID: org.ID,
+	}))
+}
@@ -0,0 +1,193 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestTemporaryRoles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SiteRole", func(t *testing.T) {
+		t.Parallel()
+		auditor := audit.NewMock()
+		owner := coderdtest.New(t, &coderdtest.Options{Auditor: auditor})
+		first := coderdtest.CreateFirstUser(t, owner)
+		_, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID, rbac.RoleAuditor())
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		expiresAt := dbtime.Now().Add(4 * time.Hour)
+		_, err := owner.UpdateUserRoles(ctx, user.ID.String(), codersdk.UpdateRoles{
+			Roles:         []string{codersdk.RoleAuditor, codersdk.RoleTemplateAdmin},
+			ExpiresAt:     &expiresAt,
+			Justification: "Fixing the docker template",
+		})
+		require.NoError(t, err)
+		require.True(t, auditor.Contains(t, database.AuditLog{
+			ResourceType: database.ResourceTypeUser,
+			ResourceID:   user.ID,
+			Action:       database.AuditActionWrite,
+		}))
+
+		// Only the added role expires.
+		roles, err := owner.UserRoles(ctx, user.ID.String())
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{codersdk.RoleAuditor, codersdk.RoleTemplateAdmin}, roles.Roles)
+		require.Len(t, roles.TemporaryRoles, 1)
+		require.Equal(t, codersdk.RoleTemplateAdmin, roles.TemporaryRoles[0].RoleName)
+		require.Nil(t, roles.TemporaryRoles[0].OrganizationID)
+		require.Equal(t, "Fixing the docker template", roles.TemporaryRoles[0].Justification)
+		require.WithinDuration(t, expiresAt, roles.TemporaryRoles[0].ExpiresAt, time.Second)
+
+		// The expiry of a temporary role can be extended, and roles that
+		// expire are added to the current roles.
+		expiresAt = expiresAt.Add(time.Hour)
+		_, err = owner.UpdateUserRoles(ctx, user.ID.String(), codersdk.UpdateRoles{
+			Roles:     []string{codersdk.RoleTemplateAdmin},
+			ExpiresAt: &expiresAt,
+		})
+		require.NoError(t, err)
+		roles, err = owner.UserRoles(ctx, user.ID.String())
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{codersdk.RoleAuditor, codersdk.RoleTemplateAdmin}, roles.Roles)
+		require.Len(t, roles.TemporaryRoles, 1)
+		require.WithinDuration(t, expiresAt, roles.TemporaryRoles[0].ExpiresAt, time.Second)
+		require.Empty(t, roles.TemporaryRoles[0].Justification)
+
+		// Adding another temporary role does not change the expiry of the
+		// existing one.
+		userAdminExpiresAt := expiresAt.Add(time.Hour)
+		_, err = owner.UpdateUserRoles(ctx, user.ID.String(), codersdk.UpdateRoles{
+			Roles:     []string{codersdk.RoleUserAdmin},
+			ExpiresAt: &userAdminExpiresAt,
+		})
+		require.NoError(t, err)
+		roles, err = owner.UserRoles(ctx, user.ID.String())
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{codersdk.RoleAuditor, codersdk.RoleTemplateAdmin, codersdk.RoleUserAdmin}, roles.Roles)
+		require.Len(t, roles.TemporaryRoles, 2)
+		for _, grant := range roles.TemporaryRoles {
+			want := expiresAt
+			if grant.RoleName == codersdk.RoleUserAdmin {
+				want = userAdminExpiresAt
+			}
+			require.WithinDuration(t, want, grant.ExpiresAt, time.Second, grant.RoleName)
+		}
+
+		// Updates without an expiry grant the roles permanently, and removing
+		// a role removes its expiry.
+		_, err = owner.UpdateUserRoles(ctx, user.ID.String(), codersdk.UpdateRoles{
+			Roles: []string{codersdk.RoleTemplateAdmin},
+		})
+		require.NoError(t, err)
+		roles, err = owner.UserRoles(ctx, user.ID.String())
+		require.NoError(t, err)
+		require.Equal(t, []string{codersdk.RoleTemplateAdmin}, roles.Roles)
+		require.Empty(t, roles.TemporaryRoles)
+	})
+
+	t.Run("OrganizationRole", func(t *testing.T) {
+		t.Parallel()
+		owner := coderdtest.New(t, nil)
+		first := coderdtest.CreateFirstUser(t, owner)
+		_, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		expiresAt := dbtime.Now().Add(time.Hour)
+		_, err := owner.UpdateOrganizationMemberRoles(ctx, first.OrganizationID, user.ID.String(), codersdk.UpdateRoles{
+			Roles:     []string{codersdk.RoleOrganizationAdmin},
+			ExpiresAt: &expiresAt,
+		})
+		require.NoError(t, err)
+
+		roles, err := owner.UserRoles(ctx, user.ID.String())
+		require.NoError(t, err)
+		require.Equal(t, []string{codersdk.RoleOrganizationAdmin}, roles.OrganizationRoles[first.OrganizationID])
+		require.Len(t, roles.TemporaryRoles, 1)
+		require.Equal(t, codersdk.RoleOrganizationAdmin, roles.TemporaryRoles[0].RoleName)
+		require.Equal(t, &first.OrganizationID, roles.TemporaryRoles[0].OrganizationID)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		t.Parallel()
+		owner, db := coderdtest.NewWithDatabase(t, nil)
+		first := coderdtest.CreateFirstUser(t, owner)
+		client, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		expiresAt := dbtime.Now().Add(time.Hour)
+		_, err := owner.UpdateUserRoles(ctx, user.ID.String(), codersdk.UpdateRoles{
+			Roles:     []string{codersdk.RoleTemplateAdmin},
+			ExpiresAt: &expiresAt,
+		})
+		require.NoError(t, err)
+
+		canCreateTemplates := func() bool {
+			resp, err := client.AuthCheck(ctx, codersdk.AuthorizationRequest{
+				Checks: map[string]codersdk.AuthorizationCheck{
+					"create": {
+						Object: codersdk.AuthorizationObject{
+							ResourceType:   codersdk.ResourceTemplate,
+							OrganizationID: first.OrganizationID.String(),
+						},
+						Action: codersdk.ActionCreate,
+					},
+				},
+			})
+			require.NoError(t, err)
+			return resp["create"]
+		}
+		require.True(t, canCreateTemplates())
+
+		// Expire the role without removing it, like before the background
+		// job runs.
+		_, err = db.UpsertTemporaryRoleGrant(dbauthz.AsSystemRestricted(ctx), database.UpsertTemporaryRoleGrantParams{
+			ID:        uuid.New(),
+			UserID:    user.ID,
+			RoleName:  codersdk.RoleTemplateAdmin,
+			CreatedAt: dbtime.Now(),
+			ExpiresAt: dbtime.Now().Add(-time.Minute),
+		})
+		require.NoError(t, err)
+		require.False(t, canCreateTemplates())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		owner := coderdtest.New(t, nil)
+		first := coderdtest.CreateFirstUser(t, owner)
+		_, user := coderdtest.CreateAnotherUser(t, owner, first.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		past := dbtime.Now().Add(-time.Hour)
+		_, err := owner.UpdateUserRoles(ctx, user.ID.String(), codersdk.UpdateRoles{
+			Roles:     []string{codersdk.RoleTemplateAdmin},
+			ExpiresAt: &past,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+		require.Equal(t, "expires_at", apiErr.Validations[0].Field)
+
+		_, err = owner.UpdateUserRoles(ctx, user.ID.String(), codersdk.UpdateRoles{
+			Roles:         []string{codersdk.RoleTemplateAdmin},
+			Justification: "Because",
+		})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+		require.Equal(t, "justification", apiErr.Validations[0].Field)
+	})
+}
@@ -0,0 +1,155 @@
+// Package totp implements time-based one-time passwords (RFC 6238) as
+// generated by authenticator apps, and the single-use recovery codes that
+// accompany them.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //#nosec // SHA1 is mandated by RFC 6238 and supported by every authenticator app.
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cryptorand"
+)
+
+const (
+	// Digits is the length of a code.
+	Digits = 6
+	// Period is how long a code is valid for.
+	Period = 30 * time.Second
+	// RecoveryCodeCount is the number of recovery codes generated on
+	// enrollment.
+	RecoveryCodeCount = 10
+
+	secretSize = 20
+	// skew is the number of periods before and after the current one that
+	// codes are accepted for, to allow for clock drift.
+	skew = 1
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random secret encoded as base32, which is the
+// format authenticator apps expect.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", xerrors.Errorf("read random bytes: %w", err)
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+// ProvisioningURI returns the otpauth:// URI that authenticator apps read
+// from a QR code.
+func ProvisioningURI(secret, issuer, account string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(Digits))
+	v.Set("period", fmt.Sprint(int(Period.Seconds())))
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: v.Encode(),
+	}
+	return u.String()
+}
+
+// Counter returns the time step that t falls into.
+func Counter(t time.Time) int64 {
+	return t.Unix() / int64(Period.Seconds())
+}
+
+// Code returns the code for the given secret at time t.
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return code(key, Counter(t)), nil
+}
+
+// Validate checks the code against the secret at time t. Codes for time
+// steps at or before lastCounter are rejected so that a code cannot be used
+// twice. The time step of the accepted code is returned so that callers can
+// store it as the new lastCounter.
+func Validate(secret, input string, t time.Time, lastCounter int64) (int64, bool, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false, err
+	}
+	input = strings.ReplaceAll(strings.TrimSpace(input), " ", "")
+	if len(input) != Digits {
+		return 0, false, nil
+	}
+	current := Counter(t)
+	for counter := current - skew; counter <= current+skew; counter++ {
+		if counter <= lastCounter {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(code(key, counter)), []byte(input)) == 1 {
+			return counter, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// GenerateRecoveryCodes returns new recovery codes and their hashes. Only
+// the hashes should be stored.
+func GenerateRecoveryCodes() (codes []string, hashed []string, err error) {
+	for i := 0; i < RecoveryCodeCount; i++ {
+		c, err := cryptorand.StringCharset(cryptorand.Human, 10)
+		if err != nil {
+			return nil, nil, xerrors.Errorf("generate recovery code: %w", err)
+		}
+		c = c[:5] + "-" + c[5:]
+		codes = append(codes, c)
+		hashed = append(hashed, HashRecoveryCode(c))
+	}
+	return codes, hashed, nil
+}
+
+// HashRecoveryCode hashes a recovery code for storage. Recovery codes are
+// random, so unlike passwords they do not need a slow hash.
+func HashRecoveryCode(c string) string {
+	c = strings.ToLower(strings.TrimSpace(c))
+	sum := sha256.Sum256([]byte(c))
+	return hex.EncodeToString(sum[:])
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return nil, xerrors.Errorf("decode secret: %w", err)
+	}
+	return key, nil
+}
+
+func code(key []byte, counter int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+	mac := hmac.New(sha1.New, key)
+	_, _ = mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	// Dynamic truncation as described in RFC 4226 section 5.3.
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
@@ -0,0 +1,96 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/totp"
+)
+
+// rfcSecret is the SHA1 test secret from RFC 6238 appendix B.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	t.Parallel()
+
+	// The RFC uses 8 digit codes, these are the last 6 digits.
+	for unix, want := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		got, err := totp.Code(rfcSecret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		require.Equal(t, want, got, unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	now := time.Now()
+
+	code, err := totp.Code(secret, now)
+	require.NoError(t, err)
+	counter, ok, err := totp.Validate(secret, code, now, 0)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, totp.Counter(now), counter)
+
+	// A code cannot be used twice.
+	_, ok, err = totp.Validate(secret, code, now, counter)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Codes from the previous period are accepted to allow for clock drift.
+	previous, err := totp.Code(secret, now.Add(-totp.Period))
+	require.NoError(t, err)
+	_, ok, err = totp.Validate(secret, previous, now, 0)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	old, err := totp.Code(secret, now.Add(-5*totp.Period))
+	require.NoError(t, err)
+	_, ok, err = totp.Validate(secret, old, now, 0)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, ok, err = totp.Validate(secret, "12345", now, 0)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	t.Parallel()
+
+	u, err := url.Parse(totp.ProvisioningURI("ABC", "Coder", "dean"))
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", u.Scheme)
+	require.Equal(t, "totp", u.Host)
+	require.Equal(t, "/Coder:dean", u.Path)
+	require.Equal(t, "ABC", u.Query().Get("secret"))
+	require.Equal(t, "Coder", u.Query().Get("issuer"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	t.Parallel()
+
+	codes, hashed, err := totp.GenerateRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, totp.RecoveryCodeCount)
+	require.Len(t, hashed, totp.RecoveryCodeCount)
+	for i, c := range codes {
+		require.Len(t, c, 11)
+		require.Equal(t, hashed[i], totp.HashRecoveryCode(c))
+		require.NotEqual(t, c, hashed[i])
+	}
+}
@@ -107,7 +107,7 @@ func (api *API) postConvertLoginType(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	// This handles the email/pass checking.
-	user, _, ok := api.loginRequest(ctx, rw, codersdk.LoginWithPasswordRequest{
+	user, _, ok := api.loginRequest(rw, r, codersdk.LoginWithPasswordRequest{
 		Email:    user.Email,
 		Password: req.Password,
 	})
@@ -232,7 +232,7 @@ func (api *API) postLogin(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, actor, ok := api.loginRequest(ctx, rw, loginWithPassword)
+	user, actor, ok := api.loginRequest(rw, r, loginWithPassword)
 	// 'user.ID' will be empty, or will be an actual value. Either is correct
 	// here.
 	aReq.UserID = user.ID
@@ -241,12 +241,48 @@ func (api *API) postLogin(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	enrolled, ok := api.checkLoginMFA(rw, r, user, loginWithPassword.MFACode)
+	if !ok {
+		return
+	}
+	//nolint:gocritic // Failed logins are reset before the user is logged in.
+	err := api.Database.DeleteUserLoginAttempts(dbauthz.AsSystemRestricted(ctx), user.ID)
+	if err != nil {
+		logger.Error(ctx, "unable to reset failed logins", slog.Error(err))
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error.",
+		})
+		return
+	}
+	// Users that have to enroll in MFA get a session that can only be used
+	// to enroll.
+	var scopes []string
+	enrollmentRequired := api.DeploymentValues.EnforceMFA.Value() && !enrolled
+	if enrollmentRequired {
+		scopes = mfaEnrollmentScopes
+	}
+	// Users with an expired password get a session that can only be used to
+	// change the password.
+	passwordExpired, err := api.passwordExpired(ctx, user.ID)
+	if err != nil {
+		logger.Error(ctx, "unable to check password age", slog.Error(err))
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error.",
+		})
+		return
+	}
+	if passwordExpired {
+		scopes = passwordChangeScopes
+	}
+
 	//nolint:gocritic // Creating the API key as the user instead of as system.
 	cookie, key, err := api.createAPIKey(dbauthz.As(ctx, actor), apikey.CreateParams{
 		UserID:          user.ID,
 		LoginType:       database.LoginTypePassword,
 		RemoteAddr:      r.RemoteAddr,
+		UserAgent:       r.UserAgent(),
 		DefaultLifetime: api.DeploymentValues.Sessions.DefaultDuration.Value(),
+		Scopes:          scopes,
 	})
 	if err != nil {
 		logger.Error(ctx, "unable to create API key", slog.Error(err))
@@ -262,7 +298,9 @@ func (api *API) postLogin(rw http.ResponseWriter, r *http.Request) {
 	http.SetCookie(rw, cookie)
 
 	httpapi.Write(ctx, rw, http.StatusCreated, codersdk.LoginWithPasswordResponse{
-		SessionToken: cookie.Value,
+		SessionToken:           cookie.Value,
+		MFAEnrollmentRequired:  enrollmentRequired,
+		PasswordChangeRequired: passwordExpired,
 	})
 }
 
@@ -272,7 +310,8 @@ func (api *API) postLogin(rw http.ResponseWriter, r *http.Request) {
 //
 // The user struct is always returned, even if authentication failed. This is
 // to support knowing what user attempted to login.
-func (api *API) loginRequest(ctx context.Context, rw http.ResponseWriter, req codersdk.LoginWithPasswordRequest) (database.User, rbac.Subject, bool) {
+func (api *API) loginRequest(rw http.ResponseWriter, r *http.Request, req codersdk.LoginWithPasswordRequest) (database.User, rbac.Subject, bool) {
+	ctx := r.Context()
 	logger := api.Logger.Named(userAuthLoggerName)
 
 	//nolint:gocritic // In order to login, we need to get the user first!
@@ -287,6 +326,22 @@ func (api *API) loginRequest(ctx context.Context, rw http.ResponseWriter, req co
 		return user, rbac.Subject{}, false
 	}
 
+	lockedUntil, err := api.loginLockedUntil(ctx, user)
+	if err != nil {
+		logger.Error(ctx, "unable to check failed logins", slog.Error(err))
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error.",
+		})
+		return user, rbac.Subject{}, false
+	}
+	if !lockedUntil.IsZero() {
+		httpapi.Write(ctx, rw, http.StatusTooManyRequests, codersdk.Response{
+			Message: "Your account is locked after too many failed logins.",
+			Detail:  fmt.Sprintf("Try again after %s.", lockedUntil.Format(time.RFC3339)),
+		})
+		return user, rbac.Subject{}, false
+	}
+
 	// If the user doesn't exist, it will be a default struct.
 	equal, err := userpassword.Compare(string(user.HashedPassword), req.Password)
 	if err != nil {
@@ -298,6 +353,10 @@ func (api *API) loginRequest(ctx context.Context, rw http.ResponseWriter, req co
 	}
 
 	if !equal {
+		err = api.recordFailedLogin(r, user)
+		if err != nil {
+			logger.Error(ctx, "unable to record failed login", slog.F("user_id", user.ID), slog.Error(err))
+		}
 		// This message is the same as above to remove ease in detecting whether
 		// users are registered or not. Attackers still could with a timing attack.
 		httpapi.Write(ctx, rw, http.StatusUnauthorized, codersdk.Response{
@@ -306,6 +365,15 @@ func (api *API) loginRequest(ctx context.Context, rw http.ResponseWriter, req co
 		return user, rbac.Subject{}, false
 	}
 
+	// Service accounts only authenticate with API tokens, even if a password
+	// was somehow set.
+	if user.IsServiceAccount {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "Service accounts cannot log in. Use an API token instead.",
+		})
+		return user, rbac.Subject{}, false
+	}
+
 	// If password authentication is disabled and the user does not have the
 	// owner role, block the request.
 	if api.DeploymentValues.DisablePasswordAuth {
@@ -463,6 +531,11 @@ func (api *API) userAuthMethods(rw http.ResponseWriter, r *http.Request) {
 	if api.OIDCConfig != nil {
 		iconURL = api.OIDCConfig.IconURL
 	}
+	ldapAuthMethod := codersdk.LDAPAuthMethod{}
+	if api.LDAPConfig != nil {
+		ldapAuthMethod.Enabled = true
+		ldapAuthMethod.SignInText = api.LDAPConfig.SignInText
+	}
 
 	httpapi.Write(r.Context(), rw, http.StatusOK, codersdk.AuthMethods{
 		TermsOfServiceURL: api.DeploymentValues.TermsOfServiceURL.Value(),
@@ -475,6 +548,7 @@ func (api *API) userAuthMethods(rw http.ResponseWriter, r *http.Request) {
 			SignInText: signInText,
 			IconURL:    iconURL,
 		},
+		LDAP: ldapAuthMethod,
 	})
 }
 
@@ -1094,15 +1168,7 @@ func (api *API) oidcGroups(ctx context.Context, mergedClaims map[string]interfac
 				slog.F("groups", parsedGroups),
 			)
 
-			for _, group := range parsedGroups {
-				if mappedGroup, ok := api.OIDCConfig.GroupMapping[group]; ok {
-					group = mappedGroup
-				}
-				if _, ok := api.OIDCConfig.GroupAllowList[group]; ok {
-					inAllowList = true
-				}
-				groups = append(groups, group)
-			}
+			groups, inAllowList = mapGroups(parsedGroups, api.OIDCConfig.GroupMapping, api.OIDCConfig.GroupAllowList)
 		}
 
 		if !inAllowList {
@@ -1110,16 +1176,7 @@ func (api *API) oidcGroups(ctx context.Context, mergedClaims map[string]interfac
 				slog.F("allow_list_count", len(api.OIDCConfig.GroupAllowList)),
 				slog.F("user_group_count", len(groups)),
 			)
-			detail := "Ask an administrator to add one of your groups to the whitelist"
-			if len(groups) == 0 {
-				detail = "You are currently not a member of any groups! Ask an administrator to add you to an authorized group to login."
-			}
-			return usingGroups, groups, &httpError{
-				code:             http.StatusForbidden,
-				msg:              "Not a member of an allowed group",
-				detail:           detail,
-				renderStaticPage: true,
-			}
+			return usingGroups, groups, groupAllowListError(groups)
 		}
 	}
 
@@ -1172,8 +1229,51 @@ func (api *API) oidcRoles(ctx context.Context, mergedClaims map[string]interface
 		slog.F("len", len(parsedRoles)),
 		slog.F("roles", parsedRoles),
 	)
+	return mapRoles(roles, parsedRoles, api.OIDCConfig.UserRoleMapping), nil
+}
+
+// mapGroups applies the group mapping of an identity provider to the groups
+// of a user. It returns false if an allow list is configured and none of the
+// mapped groups are in it.
+func mapGroups(parsedGroups []string, mapping map[string]string, allowList map[string]bool) ([]string, bool) {
+	// If the allow list is empty, then the user is allowed to log in.
+	// Otherwise, they must belong to at least 1 group in the allow list.
+	inAllowList := len(allowList) == 0
+	var groups []string
+	for _, group := range parsedGroups {
+		if mappedGroup, ok := mapping[group]; ok {
+			group = mappedGroup
+		}
+		if _, ok := allowList[group]; ok {
+			inAllowList = true
+		}
+		groups = append(groups, group)
+	}
+	return groups, inAllowList
+}
+
+// groupAllowListError is returned when a user is not a member of any group in
+// the allow list of an identity provider.
+func groupAllowListError(groups []string) *httpError {
+	detail := "Ask an administrator to add one of your groups to the whitelist"
+	if len(groups) == 0 {
+		detail = "You are currently not a member of any groups! Ask an administrator to add you to an authorized group to login."
+	}
+	return &httpError{
+		code:             http.StatusForbidden,
+		msg:              "Not a member of an allowed group",
+		detail:           detail,
+		renderStaticPage: true,
+	}
+}
+
+// mapRoles applies the role mapping of an identity provider to the roles of a
+// user and appends them to the default roles. Roles mapped to an empty list
+// are ignored.
+func mapRoles(defaults []string, parsedRoles []string, mapping map[string][]string) []string {
+	roles := defaults
 	for _, role := range parsedRoles {
-		if mappedRoles, ok := api.OIDCConfig.UserRoleMapping[role]; ok {
+		if mappedRoles, ok := mapping[role]; ok {
 			if len(mappedRoles) == 0 {
 				continue
 			}
@@ -1184,7 +1284,7 @@ func (api *API) oidcRoles(ctx context.Context, mergedClaims map[string]interface
 
 		roles = append(roles, role)
 	}
-	return roles, nil
+	return roles
 }
 
 // claimFields returns the sorted list of fields in the claims map.
@@ -1328,7 +1428,7 @@ func (api *API) oauthLogin(r *http.Request, params *oauthLoginParams) ([]*http.C
 		logger  = api.Logger.Named(userAuthLoggerName)
 	)
 
-	var isConvertLoginType bool
+	var isConvertLoginType, isNewUser bool
 	err := api.Database.InTx(func(tx database.Store) error {
 		var (
 			link database.UserLink
@@ -1427,6 +1527,7 @@ func (api *API) oauthLogin(r *http.Request, params *oauthLoginParams) ([]*http.C
 			if err != nil {
 				return xerrors.Errorf("create user: %w", err)
 			}
+			isNewUser = true
 		}
 
 		// Activate dormant user on sigin
@@ -1612,6 +1713,10 @@ func (api *API) oauthLogin(r *http.Request, params *oauthLoginParams) ([]*http.C
 	if err != nil {
 		return nil, database.APIKey{}, xerrors.Errorf("in tx: %w", err)
 	}
+	if isNewUser {
+		//nolint:gocritic // The user signing in has no permission to enqueue notifications.
+		api.NotifyUserCreated(dbauthz.AsSystemRestricted(ctx), user)
+	}
 
 	var key database.APIKey
 	oldKey, _, ok := httpmw.APIKeyFromRequest(ctx, api.Database, nil, r)
@@ -1645,6 +1750,7 @@ func (api *API) oauthLogin(r *http.Request, params *oauthLoginParams) ([]*http.C
 			LoginType:       params.LoginType,
 			DefaultLifetime: api.DeploymentValues.Sessions.DefaultDuration.Value(),
 			RemoteAddr:      r.RemoteAddr,
+			UserAgent:       r.UserAgent(),
 		})
 		if err != nil {
 			return nil, database.APIKey{}, xerrors.Errorf("create API key: %w", err)
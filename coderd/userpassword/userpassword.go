@@ -148,3 +148,19 @@ func Validate(password string) error {
 	}
 	return nil
 }
+
+// Policy contains the password requirements configured for the deployment in
+// addition to the minimum requirements checked by Validate.
+type Policy struct {
+	// MinLength is the minimum number of characters. 0 disables the minimum.
+	MinLength int
+}
+
+// Validate checks that the plain text password meets the minimum password
+// requirements and the policy.
+func (p Policy) Validate(password string) error {
+	if len(password) < p.MinLength {
+		return xerrors.Errorf("password must be at least %d characters", p.MinLength)
+	}
+	return Validate(password)
+}
@@ -55,3 +55,14 @@ func TestUserPassword(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := userpassword.Policy{MinLength: 20}
+	require.ErrorContains(t, policy.Validate("MySecurePassword!"), "at least 20 characters")
+	require.NoError(t, policy.Validate("MyEvenMoreSecurePassword!"))
+	// The minimum requirements still apply.
+	require.Error(t, policy.Validate("aaaaaaaaaaaaaaaaaaaaaaaa"))
+	require.NoError(t, userpassword.Policy{}.Validate("MySecurePassword!"))
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/google/uuid"
 	"golang.org/x/xerrors"
 
+	"cdr.dev/slog"
+
 	"github.com/coder/coder/v2/coderd/audit"
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/database/db2sdk"
@@ -20,6 +22,7 @@ import (
 	"github.com/coder/coder/v2/coderd/gitsshkey"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/coderd/searchquery"
@@ -140,7 +143,7 @@ func (api *API) postFirstUser(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = userpassword.Validate(createUser.Password)
+	err = api.passwordPolicy().Validate(createUser.Password)
 	if err != nil {
 		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
 			Message: "Password not strong enough!",
@@ -200,6 +203,8 @@ func (api *API) postFirstUser(rw http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	//nolint:gocritic // The first user has no permission to enqueue notifications yet.
+	api.NotifyUserCreated(dbauthz.AsSystemRestricted(ctx), user)
 
 	if api.RefreshEntitlements != nil {
 		err = api.RefreshEntitlements(ctx)
@@ -448,7 +453,7 @@ func (api *API) postUser(rw http.ResponseWriter, r *http.Request) {
 	case codersdk.LoginTypeNone:
 		loginType = database.LoginTypeNone
 	case codersdk.LoginTypePassword:
-		err = userpassword.Validate(req.Password)
+		err = api.passwordPolicy().Validate(req.Password)
 		if err != nil {
 			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
 				Message: "Password not strong enough!",
@@ -464,6 +469,8 @@ func (api *API) postUser(rw http.ResponseWriter, r *http.Request) {
 		loginType = database.LoginTypeOIDC
 	case codersdk.LoginTypeGithub:
 		loginType = database.LoginTypeGithub
+	case codersdk.LoginTypeLDAP:
+		loginType = database.LoginTypeLDAP
 	default:
 		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
 			Message: fmt.Sprintf("Unsupported login type %q for manually creating new users.", req.UserLoginType),
@@ -487,6 +494,7 @@ func (api *API) postUser(rw http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	api.NotifyUserCreated(ctx, user)
 
 	aReq.New = user
 
@@ -826,6 +834,22 @@ func (api *API) putUserStatus(status database.UserStatus) func(rw http.ResponseW
 		}
 		aReq.New = suspendedUser
 
+		if status == database.UserStatusSuspended {
+			api.NotifyUserSuspended(ctx, suspendedUser, apiKey.UserID, httpmw.UserAuthorization(r).FriendlyName)
+		}
+		// Activating a user also lifts a lockout after too many failed
+		// logins.
+		if status == database.UserStatusActive {
+			err = api.Database.DeleteUserLoginAttempts(ctx, user.ID)
+			if err != nil {
+				httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+					Message: "Internal error resetting failed logins.",
+					Detail:  err.Error(),
+				})
+				return
+			}
+		}
+
 		organizations, err := userOrganizationIDs(ctx, api, user)
 		if err != nil {
 			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
@@ -926,7 +950,7 @@ func (api *API) putUserPassword(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := userpassword.Validate(params.Password)
+	err := api.passwordPolicy().Validate(params.Password)
 	if err != nil {
 		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
 			Message: "Invalid password.",
@@ -972,6 +996,26 @@ func (api *API) putUserPassword(rw http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	reused, err := api.passwordReused(ctx, user.ID, params.Password)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error checking password history.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	if reused {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: fmt.Sprintf("New password cannot match any of your last %d passwords.", api.DeploymentValues.PasswordPolicy.History.Value()),
+			Validations: []codersdk.ValidationError{
+				{
+					Field:  "password",
+					Detail: "Password has been used before.",
+				},
+			},
+		})
+		return
+	}
 
 	hashedPassword, err := userpassword.Hash(params.Password)
 	if err != nil {
@@ -991,6 +1035,11 @@ func (api *API) putUserPassword(rw http.ResponseWriter, r *http.Request) {
 			return xerrors.Errorf("update user hashed password: %w", err)
 		}
 
+		err = api.recordPasswordHistory(ctx, tx, user.ID, []byte(hashedPassword))
+		if err != nil {
+			return err
+		}
+
 		err = tx.DeleteAPIKeysByUserID(ctx, user.ID)
 		if err != nil {
 			return xerrors.Errorf("delete api keys by user ID: %w", err)
@@ -1036,6 +1085,16 @@ func (api *API) userRoles(rw http.ResponseWriter, r *http.Request) {
 		OrganizationRoles: make(map[uuid.UUID][]string),
 	}
 
+	grants, err := api.Database.GetTemporaryRoleGrantsByUserID(ctx, user.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching user's temporary roles.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	resp.TemporaryRoles = db2sdk.List(grants, db2sdk.TemporaryRoleGrant)
+
 	memberships, err := api.Database.OrganizationMembers(ctx, database.OrganizationMembersParams{
 		UserID:         user.ID,
 		OrganizationID: uuid.Nil,
@@ -1069,15 +1128,16 @@ func (api *API) putUserRoles(rw http.ResponseWriter, r *http.Request) {
 	var (
 		ctx = r.Context()
 		// User is the user to modify.
-		user              = httpmw.UserParam(r)
-		apiKey            = httpmw.APIKey(r)
-		auditor           = *api.Auditor.Load()
-		aReq, commitAudit = audit.InitRequest[database.User](rw, &audit.RequestParams{
+		user        = httpmw.UserParam(r)
+		apiKey      = httpmw.APIKey(r)
+		auditor     = *api.Auditor.Load()
+		auditParams = &audit.RequestParams{
 			Audit:   auditor,
 			Log:     api.Logger,
 			Request: r,
 			Action:  database.AuditActionWrite,
-		})
+		}
+		aReq, commitAudit = audit.InitRequest[database.User](rw, auditParams)
 	)
 	defer commitAudit()
 	aReq.Old = user
@@ -1090,6 +1150,14 @@ func (api *API) putUserRoles(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if user.LoginType == database.LoginTypeLDAP && api.LDAPConfig != nil && api.LDAPConfig.RoleSyncEnabled() {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Cannot modify roles for LDAP users when role sync is enabled.",
+			Detail:  "'User Role Mapping' is set in the LDAP configuration. All role changes must come from the LDAP directory.",
+		})
+		return
+	}
+
 	if apiKey.UserID == user.ID {
 		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
 			Message: "You cannot change your own roles.",
@@ -1101,11 +1169,23 @@ func (api *API) putUserRoles(rw http.ResponseWriter, r *http.Request) {
 	if !httpapi.Read(ctx, rw, r, &params) {
 		return
 	}
+	if !validateTemporaryRoles(ctx, rw, params) {
+		return
+	}
+	auditParams.AdditionalFields = temporaryRolesAuditFields(params)
 
-	updatedUser, err := api.Database.UpdateUserRoles(ctx, database.UpdateUserRolesParams{
-		GrantedRoles: params.Roles,
-		ID:           user.ID,
-	})
+	var updatedUser database.User
+	err := api.Database.InTx(func(tx database.Store) error {
+		var err error
+		updatedUser, err = tx.UpdateUserRoles(ctx, database.UpdateUserRolesParams{
+			GrantedRoles: grantedRoles(user.RBACRoles, params),
+			ID:           user.ID,
+		})
+		if err != nil {
+			return err
+		}
+		return updateTemporaryRoles(ctx, tx, user.ID, uuid.NullUUID{}, user.RBACRoles, updatedUser.RBACRoles, params)
+	}, nil)
 	if dbauthz.IsNotAuthorizedError(err) {
 		httpapi.Forbidden(rw)
 		return
@@ -1200,9 +1280,12 @@ func (api *API) organizationByUserAndName(rw http.ResponseWriter, r *http.Reques
 
 type CreateUserRequest struct {
 	codersdk.CreateUserRequest
-	LoginType database.LoginType
+	LoginType      database.LoginType
+	ServiceAccount bool
 }
 
+// CreateUser creates a user in the given store, which may be a transaction. Unless the user is a service account, the
+// caller must notify user admins with NotifyUserCreated once the user has been committed.
 func (api *API) CreateUser(ctx context.Context, store database.Store, req CreateUserRequest) (database.User, uuid.UUID, error) {
 	// Ensure the username is valid. It's the caller's responsibility to ensure
 	// the username is valid and unique.
@@ -1211,7 +1294,7 @@ func (api *API) CreateUser(ctx context.Context, store database.Store, req Create
 	}
 
 	var user database.User
-	return user, req.OrganizationID, store.InTx(func(tx database.Store) error {
+	err := store.InTx(func(tx database.Store) error {
 		orgRoles := make([]string, 0)
 		// Organization is required to know where to allocate the user.
 		if req.OrganizationID == uuid.Nil {
@@ -1227,8 +1310,9 @@ func (api *API) CreateUser(ctx context.Context, store database.Store, req Create
 			UpdatedAt:      dbtime.Now(),
 			HashedPassword: []byte{},
 			// All new users are defaulted to members of the site.
-			RBACRoles: []string{},
-			LoginType: req.LoginType,
+			RBACRoles:        []string{},
+			LoginType:        req.LoginType,
+			IsServiceAccount: req.ServiceAccount,
 		}
 		// If a user signs up with OAuth, they can have no password!
 		if req.Password != "" {
@@ -1244,6 +1328,12 @@ func (api *API) CreateUser(ctx context.Context, store database.Store, req Create
 		if err != nil {
 			return xerrors.Errorf("create user: %w", err)
 		}
+		if len(params.HashedPassword) > 0 {
+			err = api.recordPasswordHistory(ctx, tx, user.ID, params.HashedPassword)
+			if err != nil {
+				return err
+			}
+		}
 
 		privateKey, publicKey, err := gitsshkey.Generate(api.SSHKeygenAlgorithm)
 		if err != nil {
@@ -1272,6 +1362,51 @@ func (api *API) CreateUser(ctx context.Context, store database.Store, req Create
 		}
 		return nil
 	}, nil)
+	return user, req.OrganizationID, err
+}
+
+// NotifyUserCreated notifies user admins that a new account has been created.
+func (api *API) NotifyUserCreated(ctx context.Context, user database.User) {
+	admins, err := notifications.UserAdmins(ctx, api.Database)
+	if err != nil {
+		api.Logger.Warn(ctx, "failed to find user admins to notify of created user", slog.Error(err))
+		return
+	}
+
+	for _, admin := range admins {
+		if _, err := api.NotificationsEnqueuer.Enqueue(ctx, admin, notifications.TemplateUserAccountCreated,
+			map[string]string{
+				"created_account_name": user.Username,
+			}, "api-users-create",
+			user.ID,
+		); err != nil {
+			api.Logger.Warn(ctx, "failed to notify of created user", slog.F("created_user", user.Username), slog.Error(err))
+		}
+	}
+}
+
+// NotifyUserSuspended notifies user admins, other than the initiator, that an account has been suspended.
+func (api *API) NotifyUserSuspended(ctx context.Context, user database.User, initiatorID uuid.UUID, initiator string) {
+	admins, err := notifications.UserAdmins(ctx, api.Database)
+	if err != nil {
+		api.Logger.Warn(ctx, "failed to find user admins to notify of suspended user", slog.Error(err))
+		return
+	}
+
+	for _, admin := range admins {
+		if admin == initiatorID || admin == user.ID {
+			continue
+		}
+		if _, err := api.NotificationsEnqueuer.Enqueue(ctx, admin, notifications.TemplateUserAccountSuspended,
+			map[string]string{
+				"suspended_account_name": user.Username,
+				"initiator":              initiator,
+			}, "api-users-suspend",
+			user.ID,
+		); err != nil {
+			api.Logger.Warn(ctx, "failed to notify of suspended user", slog.F("suspended_user", user.Username), slog.Error(err))
+		}
+	}
 }
 
 func convertUsers(users []database.User, organizationIDsByUserID map[uuid.UUID][]uuid.UUID) []codersdk.User {
@@ -1317,6 +1452,7 @@ func convertAPIKey(k database.APIKey) codersdk.APIKey {
 		UpdatedAt:       k.UpdatedAt,
 		LoginType:       codersdk.LoginType(k.LoginType),
 		Scope:           codersdk.APIKeyScope(k.Scope),
+		Scopes:          k.Scopes,
 		LifetimeSeconds: k.LifetimeSeconds,
 		TokenName:       k.TokenName,
 	}
@@ -28,6 +28,7 @@ import (
 	"github.com/coder/coder/v2/coderd/database/dbfake"
 	"github.com/coder/coder/v2/coderd/database/dbgen"
 	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/util/slice"
 	"github.com/coder/coder/v2/codersdk"
@@ -947,6 +948,39 @@ func TestInitialRoles(t *testing.T) {
 	require.ElementsMatch(t, roles.OrganizationRoles[first.OrganizationID], []string{}, "should be a member")
 }
 
+func TestNotifyCreatedUser(t *testing.T) {
+	t.Parallel()
+
+	notifyEnq := &testutil.FakeNotificationsEnqueuer{}
+	client := coderdtest.New(t, &coderdtest.Options{NotificationsEnqueuer: notifyEnq})
+	firstUser := coderdtest.CreateFirstUser(t, client)
+	_, userAdmin := coderdtest.CreateAnotherUser(t, client, firstUser.OrganizationID, rbac.RoleUserAdmin())
+	_, templateAdmin := coderdtest.CreateAnotherUser(t, client, firstUser.OrganizationID, rbac.RoleTemplateAdmin())
+	notifyEnq.Clear()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+	defer cancel()
+
+	user, err := client.CreateUser(ctx, codersdk.CreateUserRequest{
+		OrganizationID: firstUser.OrganizationID,
+		Email:          "another@user.org",
+		Username:       "someone-else",
+		Password:       "SomeSecurePassword!",
+	})
+	require.NoError(t, err)
+
+	// Both the owner and the user admin are notified, but not the template admin.
+	require.Len(t, notifyEnq.Sent, 2)
+	recipients := []uuid.UUID{notifyEnq.Sent[0].UserID, notifyEnq.Sent[1].UserID}
+	require.ElementsMatch(t, []uuid.UUID{firstUser.UserID, userAdmin.ID}, recipients)
+	require.NotContains(t, recipients, templateAdmin.ID)
+	for _, sent := range notifyEnq.Sent {
+		require.Equal(t, notifications.TemplateUserAccountCreated, sent.TemplateID)
+		require.Equal(t, user.Username, sent.Labels["created_account_name"])
+		require.Contains(t, sent.Targets, user.ID)
+	}
+}
+
 func TestPutUserSuspend(t *testing.T) {
 	t.Parallel()
 
@@ -988,6 +1022,29 @@ func TestPutUserSuspend(t *testing.T) {
 		require.Equal(t, database.AuditActionWrite, auditor.AuditLogs()[numLogs-1].Action)
 	})
 
+	t.Run("NotifiesUserAdmins", func(t *testing.T) {
+		t.Parallel()
+		notifyEnq := &testutil.FakeNotificationsEnqueuer{}
+		client := coderdtest.New(t, &coderdtest.Options{NotificationsEnqueuer: notifyEnq})
+		me := coderdtest.CreateFirstUser(t, client)
+		_, userAdmin := coderdtest.CreateAnotherUser(t, client, me.OrganizationID, rbac.RoleUserAdmin())
+		_, user := coderdtest.CreateAnotherUser(t, client, me.OrganizationID)
+		notifyEnq.Clear()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		_, err := client.UpdateUserStatus(ctx, user.Username, codersdk.UserStatusSuspended)
+		require.NoError(t, err)
+
+		// The owner who suspended the user is not notified.
+		require.Len(t, notifyEnq.Sent, 1)
+		require.Equal(t, userAdmin.ID, notifyEnq.Sent[0].UserID)
+		require.Equal(t, notifications.TemplateUserAccountSuspended, notifyEnq.Sent[0].TemplateID)
+		require.Equal(t, user.Username, notifyEnq.Sent[0].Labels["suspended_account_name"])
+		require.Contains(t, notifyEnq.Sent[0].Targets, user.ID)
+	})
+
 	t.Run("SuspendItSelf", func(t *testing.T) {
 		t.Parallel()
 		client := coderdtest.New(t, nil)
@@ -866,7 +866,15 @@ func (api *API) workspaceAgentClientCoordinate(rw http.ResponseWriter, r *http.R
 	go httpapi.Heartbeat(ctx, conn)
 
 	defer conn.Close(websocket.StatusNormalClosure, "")
-	err = api.TailnetClientService.ServeClient(ctx, version, wsNetConn, uuid.New(), workspaceAgent.ID)
+	var auth tailnet.CoordinateeAuth = tailnet.ClientCoordinateeAuth{AgentID: workspaceAgent.ID}
+	// Workspace proxies coordinate on behalf of all of their users, so only
+	// clients with an API key are attributed to a user.
+	if apiKey, ok := httpmw.APIKeyOptional(r); ok {
+		peerAuth := api.tailnetPeerUsers.Auth(apiKey.UserID, workspaceAgent.ID)
+		defer peerAuth.Close()
+		auth = peerAuth
+	}
+	err = api.TailnetClientService.ServeClientWithAuth(ctx, version, wsNetConn, uuid.New(), workspaceAgent.ID, auth)
 	if err != nil && !xerrors.Is(err, io.EOF) && !xerrors.Is(err, context.Canceled) {
 		_ = conn.Close(websocket.StatusInternalError, err.Error())
 		return
@@ -410,7 +410,9 @@ func (api *API) postWorkspaceBuilds(rw http.ResponseWriter, r *http.Request) {
 		api.Logger.Error(ctx, "failed to post provisioner job to pubsub", slog.Error(err))
 	}
 
-	users, err := api.Database.GetUsersByIDs(ctx, []uuid.UUID{
+	// Shared workspaces are built by users who cannot read the owner.
+	//nolint:gocritic // The caller is already authorized to build the workspace.
+	users, err := api.Database.GetUsersByIDs(dbauthz.AsSystemRestricted(ctx), []uuid.UUID{
 		workspace.OwnerID,
 		workspaceBuild.InitiatorID,
 	})
@@ -664,7 +666,9 @@ func (api *API) workspaceBuildsData(ctx context.Context, workspaces []database.W
 	for _, workspace := range workspaces {
 		userIDs = append(userIDs, workspace.OwnerID)
 	}
-	users, err := api.Database.GetUsersByIDs(ctx, userIDs)
+	// Users a workspace is shared with cannot read its owner otherwise.
+	//nolint:gocritic // The caller is already authorized to read the workspaces.
+	users, err := api.Database.GetUsersByIDs(dbauthz.AsSystemRestricted(ctx), userIDs)
 	if err != nil {
 		return workspaceBuildsData{}, xerrors.Errorf("get users: %w", err)
 	}
@@ -0,0 +1,627 @@
+package coderd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/database/provisionerjobs"
+	"github.com/coder/coder/v2/coderd/dormancy"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/coderd/rbac/policy"
+	"github.com/coder/coder/v2/coderd/searchquery"
+	"github.com/coder/coder/v2/coderd/wsbuilder"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+const (
+	// defaultWorkspaceBulkJobConcurrency is the number of workspaces a bulk
+	// job acts on at the same time when the request does not set it.
+	defaultWorkspaceBulkJobConcurrency = 5
+	// workspaceBulkJobPollInterval is how often the worker looks for jobs
+	// created on other replicas, or left behind by a replica that stopped.
+	workspaceBulkJobPollInterval = 15 * time.Second
+	// workspaceBulkJobHeartbeatInterval is how often a worker marks the job
+	// it runs as alive.
+	workspaceBulkJobHeartbeatInterval = 15 * time.Second
+	// workspaceBulkJobStaleAfter is how long a job can go without a heartbeat
+	// before another worker takes it over.
+	workspaceBulkJobStaleAfter = time.Minute
+	// workspaceBulkJobBuildPollInterval is how often the worker checks whether
+	// a build it started completed, in case the workspace update published on
+	// completion is missed.
+	workspaceBulkJobBuildPollInterval = 10 * time.Second
+)
+
+// @Summary Create workspace bulk job
+// @Description The job runs in the background, poll it to follow its progress.
+// @ID create-workspace-bulk-job
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Workspaces
+// @Param request body codersdk.CreateWorkspaceBulkJobRequest true "Create workspace bulk job request"
+// @Success 201 {object} codersdk.WorkspaceBulkJob
+// @Router /workspaces/bulk [post]
+func (api *API) postWorkspaceBulkJob(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		apiKey = httpmw.APIKey(r)
+	)
+
+	var req codersdk.CreateWorkspaceBulkJobRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	action := database.WorkspaceBulkAction(req.Action)
+	if !action.Valid() {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid request",
+			Validations: []codersdk.ValidationError{{Field: "action", Detail: fmt.Sprintf("must be one of %v", database.AllWorkspaceBulkActionValues())}},
+		})
+		return
+	}
+	automaticUpdates := database.AutomaticUpdatesNever
+	if action == database.WorkspaceBulkActionAutomaticUpdates {
+		automaticUpdates = database.AutomaticUpdates(req.AutomaticUpdates)
+		if !automaticUpdates.Valid() {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message:     "Invalid request",
+				Validations: []codersdk.ValidationError{{Field: "automatic_updates", Detail: "must be always or never"}},
+			})
+			return
+		}
+	}
+	concurrency := req.Concurrency
+	if concurrency == 0 {
+		concurrency = defaultWorkspaceBulkJobConcurrency
+	}
+
+	filter, errs := searchquery.Workspaces(req.Query, codersdk.Pagination{}, api.AgentInactiveDisconnectTimeout)
+	if len(errs) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid workspace search query.",
+			Validations: errs,
+		})
+		return
+	}
+	if filter.OwnerUsername == "me" {
+		filter.OwnerID = apiKey.UserID
+		filter.OwnerUsername = ""
+	}
+
+	// Workspaces that can be read but not changed are kept, so that they show
+	// up as failed in the results instead of being silently left out.
+	prepared, err := api.HTTPAuth.AuthorizeSQLFilter(r, policy.ActionRead, rbac.ResourceWorkspace.Type)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error preparing sql filter.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	rows, err := api.Database.GetAuthorizedWorkspaces(ctx, filter, prepared)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspaces.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	if len(rows) == 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "No workspaces match the search query.",
+		})
+		return
+	}
+	workspaces := database.ConvertWorkspaceRows(rows)
+	workspaceIDs := make([]uuid.UUID, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		workspaceIDs = append(workspaceIDs, workspace.ID)
+	}
+
+	var job database.WorkspaceBulkJob
+	err = api.Database.InTx(func(tx database.Store) error {
+		now := dbtime.Now()
+		job, err = tx.InsertWorkspaceBulkJob(ctx, database.InsertWorkspaceBulkJobParams{
+			ID:               uuid.New(),
+			InitiatorID:      apiKey.UserID,
+			InitiatorIP:      audit.WorkspaceBuildBaggageFromRequest(r).IP,
+			InitiatorScope:   apiKey.Scope,
+			InitiatorScopes:  apiKey.Scopes,
+			Action:           action,
+			Query:            req.Query,
+			AutomaticUpdates: automaticUpdates,
+			Dormant:          req.Dormant,
+			Concurrency:      int32(concurrency),
+			CreatedAt:        now,
+		})
+		if err != nil {
+			return xerrors.Errorf("insert workspace bulk job: %w", err)
+		}
+		err = tx.InsertWorkspaceBulkJobResults(ctx, database.InsertWorkspaceBulkJobResultsParams{
+			JobID:        job.ID,
+			WorkspaceIds: workspaceIDs,
+			UpdatedAt:    now,
+		})
+		if err != nil {
+			return xerrors.Errorf("insert workspace bulk job results: %w", err)
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error creating workspace bulk job.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	results, err := api.Database.GetWorkspaceBulkJobResultsByJobID(ctx, job.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace bulk job results.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	// Wake up the worker of this replica instead of waiting for its next
+	// poll.
+	select {
+	case api.workspaceBulkJobsAcquire <- struct{}{}:
+	default:
+	}
+
+	httpapi.Write(ctx, rw, http.StatusCreated, convertWorkspaceBulkJob(job, results))
+}
+
+// @Summary Get workspace bulk job
+// @ID get-workspace-bulk-job
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Workspaces
+// @Param workspacebulkjob path string true "Workspace bulk job ID" format(uuid)
+// @Success 200 {object} codersdk.WorkspaceBulkJob
+// @Router /workspaces/bulk/{workspacebulkjob} [get]
+func (api *API) workspaceBulkJob(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	jobID, ok := httpmw.ParseUUIDParam(rw, r, "workspacebulkjob")
+	if !ok {
+		return
+	}
+
+	job, err := api.Database.GetWorkspaceBulkJobByID(ctx, jobID)
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace bulk job.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	results, err := api.Database.GetWorkspaceBulkJobResultsByJobID(ctx, job.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace bulk job results.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertWorkspaceBulkJob(job, results))
+}
+
+// runWorkspaceBulkJobWorker acquires bulk jobs and runs them one at a time
+// until ctx is canceled. A job is leased to the worker for as long as it sends
+// heartbeats, so a job left behind by a replica that stopped is resumed by
+// another one.
+func (api *API) runWorkspaceBulkJobWorker(ctx context.Context) {
+	//nolint:gocritic // The worker acquires and records the jobs of every user.
+	ctx = dbauthz.AsSystemRestricted(ctx)
+	ticker := time.NewTicker(workspaceBulkJobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			now := dbtime.Now()
+			job, err := api.Database.AcquireWorkspaceBulkJob(ctx, database.AcquireWorkspaceBulkJobParams{
+				WorkerID:    api.ID,
+				HeartbeatAt: now,
+				StaleBefore: now.Add(-workspaceBulkJobStaleAfter),
+			})
+			if err != nil {
+				if !xerrors.Is(err, sql.ErrNoRows) && ctx.Err() == nil {
+					api.Logger.Error(ctx, "failed to acquire workspace bulk job", slog.Error(err))
+				}
+				break
+			}
+			api.runWorkspaceBulkJob(ctx, job)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-api.workspaceBulkJobsAcquire:
+		}
+	}
+}
+
+// runWorkspaceBulkJob applies the action of a bulk job to the workspaces it
+// has not reached yet, at most job.Concurrency at a time. Builds count until
+// they complete. The job stops if ctx is canceled or the lease is lost, and
+// the remaining workspaces are left pending for the worker that resumes it.
+func (api *API) runWorkspaceBulkJob(ctx context.Context, job database.WorkspaceBulkJob) {
+	logger := api.Logger.With(slog.F("workspace_bulk_job_id", job.ID), slog.F("action", job.Action))
+	// Results of workspaces that were acted on are recorded even if the job
+	// stops.
+	recordCtx := context.WithoutCancel(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		ticker := time.NewTicker(workspaceBulkJobHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			_, err := api.Database.UpdateWorkspaceBulkJobHeartbeat(ctx, database.UpdateWorkspaceBulkJobHeartbeatParams{
+				HeartbeatAt: dbtime.Now(),
+				ID:          job.ID,
+				WorkerID:    api.ID,
+			})
+			if err != nil {
+				if ctx.Err() == nil {
+					// Another worker may take the job over, so stop before
+					// both act on the same workspaces.
+					logger.Warn(ctx, "failed to send workspace bulk job heartbeat, stopping the job", slog.Error(err))
+					cancel()
+				}
+				return
+			}
+		}
+	}()
+	defer func() {
+		cancel()
+		<-heartbeatDone
+	}()
+
+	results, err := api.Database.GetWorkspaceBulkJobResultsByJobID(ctx, job.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to fetch workspace bulk job results", slog.Error(err))
+		return
+	}
+
+	// We only use errgroup here for the concurrency limit, errors are
+	// recorded as results.
+	eg := errgroup.Group{}
+	eg.SetLimit(int(job.Concurrency))
+	for _, result := range results {
+		if result.Status != database.WorkspaceBulkResultStatusPending {
+			continue
+		}
+		eg.Go(func() error {
+			if ctx.Err() != nil {
+				return nil
+			}
+			update := database.UpdateWorkspaceBulkJobResultParams{
+				JobID:       job.ID,
+				WorkspaceID: result.WorkspaceID,
+			}
+			status, buildID, err := api.applyWorkspaceBulkJobResult(ctx, job, result.WorkspaceID)
+			if err != nil && ctx.Err() != nil {
+				// The job stopped, the worker that resumes it looks at the
+				// workspace again.
+				return nil
+			}
+			update.Status = status
+			update.BuildID = buildID
+			if err != nil {
+				update.Status = database.WorkspaceBulkResultStatusFailed
+				update.Error = workspaceBulkResultError(err)
+			}
+			update.UpdatedAt = dbtime.Now()
+
+			err = api.Database.UpdateWorkspaceBulkJobResult(recordCtx, update)
+			if err != nil {
+				logger.Error(ctx, "failed to record workspace bulk job result", slog.F("workspace_id", result.WorkspaceID), slog.Error(err))
+			}
+			return nil
+		})
+	}
+	_ = eg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	err = api.Database.UpdateWorkspaceBulkJobCompletedAtByID(recordCtx, database.UpdateWorkspaceBulkJobCompletedAtByIDParams{
+		ID:          job.ID,
+		CompletedAt: sql.NullTime{Time: dbtime.Now(), Valid: true},
+	})
+	if err != nil {
+		logger.Error(ctx, "failed to complete workspace bulk job", slog.Error(err))
+	}
+}
+
+// applyWorkspaceBulkJobResult applies the action of a bulk job to a single
+// workspace as the initiator, limited to the scope of the API key that created
+// the job. The initiator and the workspace are fetched again for every
+// workspace, so a job stops acting for a user that was suspended or lost
+// access while it runs.
+func (api *API) applyWorkspaceBulkJobResult(ctx context.Context, job database.WorkspaceBulkJob, workspaceID uuid.UUID) (database.WorkspaceBulkResultStatus, uuid.NullUUID, error) {
+	actor, status, err := httpmw.UserRBACSubject(ctx, api.Database, job.InitiatorID, job.RBACScope())
+	if err != nil {
+		return "", uuid.NullUUID{}, xerrors.Errorf("get initiator: %w", err)
+	}
+	if status == database.UserStatusSuspended {
+		return "", uuid.NullUUID{}, xerrors.New("The user that started the job is suspended.")
+	}
+	ctx = dbauthz.As(ctx, actor)
+
+	workspace, err := api.Database.GetWorkspaceByID(ctx, workspaceID)
+	if err != nil {
+		return "", uuid.NullUUID{}, xerrors.Errorf("get workspace: %w", err)
+	}
+	return api.applyWorkspaceBulkAction(ctx, actor, job, workspace, audit.WorkspaceBuildBaggage{IP: job.InitiatorIP})
+}
+
+// applyWorkspaceBulkAction applies the action of a bulk job to a single
+// workspace. Workspaces that are already in the requested state are skipped.
+func (api *API) applyWorkspaceBulkAction(ctx context.Context, actor rbac.Subject, job database.WorkspaceBulkJob, workspace database.Workspace, baggage audit.WorkspaceBuildBaggage) (database.WorkspaceBulkResultStatus, uuid.NullUUID, error) {
+	switch job.Action {
+	case database.WorkspaceBulkActionAutomaticUpdates:
+		if workspace.AutomaticUpdates == job.AutomaticUpdates {
+			return database.WorkspaceBulkResultStatusSkipped, uuid.NullUUID{}, nil
+		}
+		err := api.Database.UpdateWorkspaceAutomaticUpdates(ctx, database.UpdateWorkspaceAutomaticUpdatesParams{
+			ID:               workspace.ID,
+			AutomaticUpdates: job.AutomaticUpdates,
+		})
+		if err != nil {
+			return "", uuid.NullUUID{}, err
+		}
+		newWorkspace := workspace
+		newWorkspace.AutomaticUpdates = job.AutomaticUpdates
+		api.auditWorkspaceBulkAction(ctx, job, workspace, newWorkspace, baggage)
+		return database.WorkspaceBulkResultStatusSucceeded, uuid.NullUUID{}, nil
+
+	case database.WorkspaceBulkActionDormant:
+		if workspace.DormantAt.Valid == job.Dormant {
+			return database.WorkspaceBulkResultStatusSkipped, uuid.NullUUID{}, nil
+		}
+		dormantAt := sql.NullTime{Valid: job.Dormant}
+		if job.Dormant {
+			dormantAt.Time = dbtime.Now()
+		}
+		newWorkspace, err := api.Database.UpdateWorkspaceDormantDeletingAt(ctx, database.UpdateWorkspaceDormantDeletingAtParams{
+			ID:        workspace.ID,
+			DormantAt: dormantAt,
+		})
+		if err != nil {
+			return "", uuid.NullUUID{}, err
+		}
+		api.auditWorkspaceBulkAction(ctx, job, workspace, newWorkspace, baggage)
+		// We don't need to notify the owner if they started the job.
+		if job.Dormant && job.InitiatorID != workspace.OwnerID {
+			_, err = dormancy.NotifyWorkspaceDormant(ctx, api.NotificationsEnqueuer, dormancy.WorkspaceDormantNotification{
+				Workspace: newWorkspace,
+				Initiator: actor.FriendlyName,
+				Reason:    "requested by user",
+				CreatedBy: "api",
+			})
+			if err != nil {
+				api.Logger.Warn(ctx, "failed to notify of workspace marked as dormant", slog.Error(err))
+			}
+		}
+		api.publishWorkspaceUpdate(ctx, workspace.ID)
+		return database.WorkspaceBulkResultStatusSucceeded, uuid.NullUUID{}, nil
+	}
+
+	return api.workspaceBulkBuild(ctx, actor, job, workspace, baggage)
+}
+
+// workspaceBulkBuild starts a build for the start, stop, update and delete
+// actions.
+func (api *API) workspaceBulkBuild(ctx context.Context, actor rbac.Subject, job database.WorkspaceBulkJob, workspace database.Workspace, baggage audit.WorkspaceBuildBaggage) (database.WorkspaceBulkResultStatus, uuid.NullUUID, error) {
+	latestBuild, err := api.Database.GetLatestWorkspaceBuildByWorkspaceID(ctx, workspace.ID)
+	if err != nil {
+		return "", uuid.NullUUID{}, xerrors.Errorf("get latest workspace build: %w", err)
+	}
+	latestJob, err := api.Database.GetProvisionerJobByID(ctx, latestBuild.JobID)
+	if err != nil {
+		return "", uuid.NullUUID{}, xerrors.Errorf("get latest provisioner job: %w", err)
+	}
+
+	transition := database.WorkspaceTransitionStart
+	switch job.Action {
+	case database.WorkspaceBulkActionStop:
+		transition = database.WorkspaceTransitionStop
+	case database.WorkspaceBulkActionDelete:
+		transition = database.WorkspaceTransitionDelete
+	}
+	// A failed or canceled build is retried, anything else already applies
+	// the transition.
+	inTransition := latestBuild.Transition == transition &&
+		latestJob.JobStatus != database.ProvisionerJobStatusFailed &&
+		latestJob.JobStatus != database.ProvisionerJobStatusCanceled
+
+	builder := wsbuilder.New(workspace, transition).
+		Initiator(job.InitiatorID).
+		DeploymentValues(api.Options.DeploymentValues)
+	if job.Action == database.WorkspaceBulkActionUpdate {
+		template, err := api.Database.GetTemplateByID(ctx, workspace.TemplateID)
+		if err != nil {
+			return "", uuid.NullUUID{}, xerrors.Errorf("get template: %w", err)
+		}
+		if inTransition && latestBuild.TemplateVersionID == template.ActiveVersionID {
+			return database.WorkspaceBulkResultStatusSkipped, uuid.NullUUID{}, nil
+		}
+		builder = builder.ActiveVersion()
+	} else if inTransition {
+		return database.WorkspaceBulkResultStatusSkipped, uuid.NullUUID{}, nil
+	}
+
+	build, provisionerJob, err := builder.Build(
+		ctx,
+		api.Database,
+		func(action policy.Action, object rbac.Objecter) bool {
+			return api.HTTPAuth.Authorizer.Authorize(ctx, actor, action, object.RBACObject()) == nil
+		},
+		baggage,
+	)
+	if err != nil {
+		return "", uuid.NullUUID{}, err
+	}
+	err = provisionerjobs.PostJob(api.Pubsub, *provisionerJob)
+	if err != nil {
+		// The job is picked up by provisioners eventually, so just log it.
+		api.Logger.Error(ctx, "failed to post provisioner job to pubsub", slog.Error(err))
+	}
+	api.publishWorkspaceUpdate(ctx, workspace.ID)
+
+	// The build keeps its place in the concurrency limit of the job until it
+	// completes, and its outcome is the result of the workspace.
+	buildID := uuid.NullUUID{UUID: build.ID, Valid: true}
+	completedJob, err := api.awaitWorkspaceBulkBuild(ctx, workspace.ID, provisionerJob.ID)
+	if err != nil {
+		return "", buildID, xerrors.Errorf("wait for build: %w", err)
+	}
+	if completedJob.JobStatus != database.ProvisionerJobStatusSucceeded {
+		return database.WorkspaceBulkResultStatusFailed, buildID, xerrors.Errorf("build %s: %s", completedJob.JobStatus, completedJob.Error.String)
+	}
+	return database.WorkspaceBulkResultStatusSucceeded, buildID, nil
+}
+
+// awaitWorkspaceBulkBuild waits until the provisioner job of a build started by
+// a bulk job completes.
+func (api *API) awaitWorkspaceBulkBuild(ctx context.Context, workspaceID, jobID uuid.UUID) (database.ProvisionerJob, error) {
+	updated := make(chan struct{}, 1)
+	cancelSubscribe, err := api.Pubsub.Subscribe(codersdk.WorkspaceNotifyChannel(workspaceID), func(context.Context, []byte) {
+		select {
+		case updated <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		return database.ProvisionerJob{}, xerrors.Errorf("subscribe to workspace updates: %w", err)
+	}
+	defer cancelSubscribe()
+
+	ticker := time.NewTicker(workspaceBulkJobBuildPollInterval)
+	defer ticker.Stop()
+	//nolint:gocritic // The initiator may lose access to the workspace while the build runs.
+	sysCtx := dbauthz.AsSystemRestricted(ctx)
+	for {
+		job, err := api.Database.GetProvisionerJobByID(sysCtx, jobID)
+		if err != nil {
+			return database.ProvisionerJob{}, xerrors.Errorf("get provisioner job: %w", err)
+		}
+		switch job.JobStatus {
+		case database.ProvisionerJobStatusSucceeded, database.ProvisionerJobStatusFailed, database.ProvisionerJobStatusCanceled:
+			return job, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return database.ProvisionerJob{}, ctx.Err()
+		case <-updated:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (api *API) auditWorkspaceBulkAction(ctx context.Context, job database.WorkspaceBulkJob, oldWorkspace, newWorkspace database.Workspace, baggage audit.WorkspaceBuildBaggage) {
+	audit.BackgroundAudit(ctx, &audit.BackgroundAuditParams[database.Workspace]{
+		Audit:          *api.Auditor.Load(),
+		Log:            api.Logger,
+		UserID:         job.InitiatorID,
+		RequestID:      job.ID,
+		Status:         http.StatusOK,
+		Action:         database.AuditActionWrite,
+		OrganizationID: newWorkspace.OrganizationID,
+		IP:             baggage.IP,
+		Old:            oldWorkspace,
+		New:            newWorkspace,
+	})
+}
+
+// workspaceBulkResultError returns the error shown in the results of a bulk
+// job. Build errors carry a message meant for users.
+func workspaceBulkResultError(err error) string {
+	var buildErr wsbuilder.BuildError
+	if xerrors.As(err, &buildErr) {
+		return fmt.Sprintf("%s: %s", buildErr.Message, buildErr.Error())
+	}
+	if dbauthz.IsNotAuthorizedError(err) {
+		return "You are not allowed to change this workspace."
+	}
+	return err.Error()
+}
+
+func convertWorkspaceBulkJob(job database.WorkspaceBulkJob, results []database.GetWorkspaceBulkJobResultsByJobIDRow) codersdk.WorkspaceBulkJob {
+	sdkJob := codersdk.WorkspaceBulkJob{
+		ID:          job.ID,
+		InitiatorID: job.InitiatorID,
+		Action:      codersdk.WorkspaceBulkAction(job.Action),
+		Query:       job.Query,
+		Dormant:     job.Dormant,
+		Concurrency: int(job.Concurrency),
+		Status:      codersdk.WorkspaceBulkJobStatusRunning,
+		Total:       len(results),
+		CreatedAt:   job.CreatedAt,
+		Results:     make([]codersdk.WorkspaceBulkJobResult, 0, len(results)),
+	}
+	if job.Action == database.WorkspaceBulkActionAutomaticUpdates {
+		sdkJob.AutomaticUpdates = codersdk.AutomaticUpdates(job.AutomaticUpdates)
+	}
+	if job.CompletedAt.Valid {
+		sdkJob.Status = codersdk.WorkspaceBulkJobStatusCompleted
+		sdkJob.CompletedAt = &job.CompletedAt.Time
+	}
+	for _, result := range results {
+		switch result.Status {
+		case database.WorkspaceBulkResultStatusSucceeded:
+			sdkJob.Succeeded++
+		case database.WorkspaceBulkResultStatusFailed:
+			sdkJob.Failed++
+		case database.WorkspaceBulkResultStatusSkipped:
+			sdkJob.Skipped++
+		}
+		sdkResult := codersdk.WorkspaceBulkJobResult{
+			WorkspaceID:        result.WorkspaceID,
+			WorkspaceName:      result.WorkspaceName,
+			WorkspaceOwnerName: result.WorkspaceOwnerUsername,
+			Status:             codersdk.WorkspaceBulkResultStatus(result.Status),
+			Error:              result.Error,
+			UpdatedAt:          result.UpdatedAt,
+		}
+		if result.BuildID.Valid {
+			sdkResult.BuildID = &result.BuildID.UUID
+		}
+		sdkJob.Results = append(sdkJob.Results, sdkResult)
+	}
+	return sdkJob
+}
@@ -0,0 +1,368 @@
+package coderd_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/provisioner/echo"
+	"github.com/coder/coder/v2/provisionersdk/proto"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestWorkspaceBulkJob(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Stop", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			client       = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			owner        = coderdtest.CreateFirstUser(t, client)
+			version      = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			otherVersion = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_            = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			_            = coderdtest.AwaitTemplateVersionJobCompleted(t, client, otherVersion.ID)
+			// Only workspaces of this template are matched.
+			template      = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			otherTemplate = coderdtest.CreateTemplate(t, client, owner.OrganizationID, otherVersion.ID)
+			running       = coderdtest.CreateWorkspace(t, client, owner.OrganizationID, template.ID)
+			stopped       = coderdtest.CreateWorkspace(t, client, owner.OrganizationID, template.ID)
+			other         = coderdtest.CreateWorkspace(t, client, owner.OrganizationID, otherTemplate.ID)
+		)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, running.LatestBuild.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, stopped.LatestBuild.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, other.LatestBuild.ID)
+		stopBuild := coderdtest.CreateWorkspaceBuild(t, client, stopped, database.WorkspaceTransitionStop)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, stopBuild.ID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		job, err := client.CreateWorkspaceBulkJob(ctx, codersdk.CreateWorkspaceBulkJobRequest{
+			Action: codersdk.WorkspaceBulkActionStop,
+			Query:  "template:" + template.Name,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, job.Total)
+		require.Equal(t, 5, job.Concurrency)
+
+		job = awaitWorkspaceBulkJobCompleted(ctx, t, client, job.ID)
+		require.Equal(t, 1, job.Succeeded)
+		require.Equal(t, 1, job.Skipped)
+		require.Zero(t, job.Failed)
+		for _, result := range job.Results {
+			switch result.WorkspaceID {
+			case running.ID:
+				require.Equal(t, codersdk.WorkspaceBulkResultStatusSucceeded, result.Status)
+				require.NotNil(t, result.BuildID)
+				build := coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, *result.BuildID)
+				require.Equal(t, codersdk.WorkspaceTransitionStop, build.Transition)
+			case stopped.ID:
+				require.Equal(t, codersdk.WorkspaceBulkResultStatusSkipped, result.Status)
+				require.Nil(t, result.BuildID)
+			default:
+				t.Fatalf("unexpected workspace %s in results", result.WorkspaceName)
+			}
+		}
+
+		other, err = client.Workspace(ctx, other.ID)
+		require.NoError(t, err)
+		require.Equal(t, codersdk.WorkspaceTransitionStart, other.LatestBuild.Transition)
+	})
+
+	t.Run("AutomaticUpdates", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			client    = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			owner     = coderdtest.CreateFirstUser(t, client)
+			version   = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_         = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template  = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			workspace = coderdtest.CreateWorkspace(t, client, owner.OrganizationID, template.ID)
+		)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		job, err := client.CreateWorkspaceBulkJob(ctx, codersdk.CreateWorkspaceBulkJobRequest{
+			Action:           codersdk.WorkspaceBulkActionAutomaticUpdates,
+			Query:            "owner:me",
+			AutomaticUpdates: codersdk.AutomaticUpdatesAlways,
+		})
+		require.NoError(t, err)
+		job = awaitWorkspaceBulkJobCompleted(ctx, t, client, job.ID)
+		require.Equal(t, 1, job.Succeeded)
+
+		workspace, err = client.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Equal(t, codersdk.AutomaticUpdatesAlways, workspace.AutomaticUpdates)
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		_, err := client.CreateWorkspaceBulkJob(ctx, codersdk.CreateWorkspaceBulkJobRequest{
+			Action: codersdk.WorkspaceBulkActionStart,
+			Query:  "template:doesnotexist",
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+
+	t.Run("InvalidAction", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		_, err := client.CreateWorkspaceBulkJob(ctx, codersdk.CreateWorkspaceBulkJobRequest{
+			Action: "restart",
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+
+	t.Run("OtherUser", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			client          = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			owner           = coderdtest.CreateFirstUser(t, client)
+			memberClient, _ = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			version         = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_               = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template        = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			workspace       = coderdtest.CreateWorkspace(t, client, owner.OrganizationID, template.ID)
+		)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		job, err := client.CreateWorkspaceBulkJob(ctx, codersdk.CreateWorkspaceBulkJobRequest{
+			Action: codersdk.WorkspaceBulkActionStop,
+		})
+		require.NoError(t, err)
+
+		// Jobs are only visible to the user that created them.
+		_, err = memberClient.WorkspaceBulkJob(ctx, job.ID)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+
+		awaitWorkspaceBulkJobCompleted(ctx, t, client, job.ID)
+	})
+
+	t.Run("ScopedToken", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			client    = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			owner     = coderdtest.CreateFirstUser(t, client)
+			version   = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_         = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template  = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			workspace = coderdtest.CreateWorkspace(t, client, owner.OrganizationID, template.ID)
+		)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		// The token can create and follow jobs, but not delete workspaces.
+		token, err := client.CreateToken(ctx, codersdk.Me, codersdk.CreateTokenRequest{
+			Scopes: []string{"workspace:read", "user:read_personal", "user:update_personal"},
+		})
+		require.NoError(t, err)
+		scopedClient := codersdk.New(client.URL)
+		scopedClient.SetSessionToken(token.Key)
+
+		job, err := scopedClient.CreateWorkspaceBulkJob(ctx, codersdk.CreateWorkspaceBulkJobRequest{
+			Action: codersdk.WorkspaceBulkActionDelete,
+			Query:  "owner:me",
+		})
+		require.NoError(t, err)
+		job = awaitWorkspaceBulkJobCompleted(ctx, t, scopedClient, job.ID)
+		require.Equal(t, 1, job.Failed)
+		require.Zero(t, job.Succeeded)
+
+		workspace, err = client.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Equal(t, codersdk.WorkspaceTransitionStart, workspace.LatestBuild.Transition)
+	})
+
+	t.Run("WaitsForBuilds", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			client   = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			owner    = coderdtest.CreateFirstUser(t, client)
+			version  = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_        = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		)
+		for i := 0; i < 3; i++ {
+			workspace := coderdtest.CreateWorkspace(t, client, owner.OrganizationID, template.ID)
+			coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		job, err := client.CreateWorkspaceBulkJob(ctx, codersdk.CreateWorkspaceBulkJobRequest{
+			Action:      codersdk.WorkspaceBulkActionStop,
+			Query:       "template:" + template.Name,
+			Concurrency: 1,
+		})
+		require.NoError(t, err)
+		job = awaitWorkspaceBulkJobCompleted(ctx, t, client, job.ID)
+		require.Equal(t, 3, job.Succeeded)
+
+		// Every build completed before the next one was started.
+		builds := make([]codersdk.WorkspaceBuild, 0, len(job.Results))
+		for _, result := range job.Results {
+			require.NotNil(t, result.BuildID)
+			build, err := client.WorkspaceBuild(ctx, *result.BuildID)
+			require.NoError(t, err)
+			require.Equal(t, codersdk.ProvisionerJobSucceeded, build.Job.Status)
+			builds = append(builds, build)
+		}
+		slices.SortFunc(builds, func(a, b codersdk.WorkspaceBuild) int {
+			return a.Job.CreatedAt.Compare(b.Job.CreatedAt)
+		})
+		for i := 1; i < len(builds); i++ {
+			require.False(t, builds[i].Job.CreatedAt.Before(*builds[i-1].Job.CompletedAt))
+		}
+	})
+
+	t.Run("FailedBuild", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			client  = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			owner   = coderdtest.CreateFirstUser(t, client)
+			version = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, &echo.Responses{
+				Parse:          echo.ParseComplete,
+				ProvisionApply: echo.ApplyComplete,
+				ProvisionApplyMap: map[proto.WorkspaceTransition][]*proto.Response{
+					proto.WorkspaceTransition_STOP: {{
+						Type: &proto.Response_Apply{Apply: &proto.ApplyComplete{Error: "stop failed"}},
+					}},
+				},
+			})
+			_         = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template  = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			workspace = coderdtest.CreateWorkspace(t, client, owner.OrganizationID, template.ID)
+		)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		job, err := client.CreateWorkspaceBulkJob(ctx, codersdk.CreateWorkspaceBulkJobRequest{
+			Action: codersdk.WorkspaceBulkActionStop,
+			Query:  "template:" + template.Name,
+		})
+		require.NoError(t, err)
+		job = awaitWorkspaceBulkJobCompleted(ctx, t, client, job.ID)
+		require.Equal(t, 1, job.Failed)
+		require.Len(t, job.Results, 1)
+		require.NotNil(t, job.Results[0].BuildID)
+		require.Contains(t, job.Results[0].Error, "stop failed")
+	})
+
+	t.Run("ResumeStale", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			client, db = coderdtest.NewWithDatabase(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			owner      = coderdtest.CreateFirstUser(t, client)
+			version    = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_          = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template   = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			workspace  = coderdtest.CreateWorkspace(t, client, owner.OrganizationID, template.ID)
+		)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+		sysCtx := dbauthz.AsSystemRestricted(ctx)
+
+		// Simulate a replica that acquired a job and stopped before running
+		// it.
+		job := dbgen.WorkspaceBulkJob(t, db, database.WorkspaceBulkJob{
+			InitiatorID:      owner.UserID,
+			Action:           database.WorkspaceBulkActionAutomaticUpdates,
+			AutomaticUpdates: database.AutomaticUpdatesAlways,
+			CreatedAt:        dbtime.Now().Add(-time.Hour),
+		})
+		err := db.InsertWorkspaceBulkJobResults(sysCtx, database.InsertWorkspaceBulkJobResultsParams{
+			JobID:        job.ID,
+			WorkspaceIds: []uuid.UUID{workspace.ID},
+			UpdatedAt:    dbtime.Now(),
+		})
+		require.NoError(t, err)
+		acquired, err := db.AcquireWorkspaceBulkJob(sysCtx, database.AcquireWorkspaceBulkJobParams{
+			WorkerID:    uuid.New(),
+			HeartbeatAt: dbtime.Now().Add(-time.Hour),
+			StaleBefore: dbtime.Now().Add(-time.Hour),
+		})
+		require.NoError(t, err)
+		require.Equal(t, job.ID, acquired.ID)
+
+		// Creating a job wakes up the worker, which takes over the oldest
+		// stale job first.
+		_, err = client.CreateWorkspaceBulkJob(ctx, codersdk.CreateWorkspaceBulkJobRequest{
+			Action: codersdk.WorkspaceBulkActionStop,
+		})
+		require.NoError(t, err)
+
+		resumed := awaitWorkspaceBulkJobCompleted(ctx, t, client, job.ID)
+		require.Equal(t, 1, resumed.Succeeded)
+
+		updated, err := client.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Equal(t, codersdk.AutomaticUpdatesAlways, updated.AutomaticUpdates)
+	})
+}
+
+func awaitWorkspaceBulkJobCompleted(ctx context.Context, t *testing.T, client *codersdk.Client, id uuid.UUID) codersdk.WorkspaceBulkJob {
+	t.Helper()
+
+	var job codersdk.WorkspaceBulkJob
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = client.WorkspaceBulkJob(ctx, id)
+		if err != nil {
+			t.Logf("get workspace bulk job: %v", err)
+			return false
+		}
+		return job.Status == codersdk.WorkspaceBulkJobStatusCompleted
+	}, testutil.WaitLong, testutil.IntervalFast)
+	return job
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -26,6 +27,7 @@ import (
 	"github.com/coder/coder/v2/coderd/dormancy"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/prebuilds"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/coderd/schedule/cron"
@@ -163,7 +165,6 @@ func (api *API) workspaces(rw http.ResponseWriter, r *http.Request) {
 		filter.OwnerUsername = ""
 	}
 
-	// Workspaces do not have ACL columns.
 	prepared, err := api.HTTPAuth.AuthorizeSQLFilter(r, policy.ActionRead, rbac.ResourceWorkspace.Type)
 	if err != nil {
 		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
@@ -536,6 +537,56 @@ func (api *API) postWorkspacesByOrganization(rw http.ResponseWriter, r *http.Req
 		workspaceBuild *database.WorkspaceBuild
 	)
 	err = api.Database.InTx(func(db database.Store) error {
+		// Prebuilt workspaces run the active version of the template, so
+		// they can only replace workspaces built with that version.
+		claimParameters, canClaim := createWorkspace.RichParameterValues, true
+		if createWorkspace.TemplateVersionID != uuid.Nil && createWorkspace.TemplateVersionID != template.ActiveVersionID {
+			canClaim = false
+		}
+		if canClaim && createWorkspace.TemplateVersionPresetID != uuid.Nil {
+			// A preset that can't be used is reported by the builder below.
+			preset, err := db.GetTemplateVersionPresetByID(ctx, createWorkspace.TemplateVersionPresetID)
+			if err == nil && preset.TemplateVersionID == template.ActiveVersionID {
+				claimParameters = presetParameterValues(preset, createWorkspace.RichParameterValues)
+			} else {
+				canClaim = false
+			}
+		}
+		if canClaim {
+			claimed, parameters, err := prebuilds.Claim(ctx, db, prebuilds.ClaimParams{
+				Template:          template,
+				OwnerID:           member.UserID,
+				Name:              createWorkspace.Name,
+				AutostartSchedule: dbAutostartSchedule,
+				Ttl:               dbTTL,
+				AutomaticUpdates:  dbAU,
+				Parameters:        claimParameters,
+			})
+			if err == nil {
+				workspace = claimed
+				// The workspace is already running, the build only has to
+				// apply the new owner to its resources.
+				builder := wsbuilder.New(workspace, database.WorkspaceTransitionStart).
+					Reason(database.BuildReasonInitiator).
+					Initiator(apiKey.UserID).
+					ActiveVersion().
+					RichParameterValues(parameters).
+					TemplateVersionPreset(createWorkspace.TemplateVersionPresetID)
+				workspaceBuild, provisionerJob, err = builder.Build(
+					ctx,
+					db,
+					func(action policy.Action, object rbac.Objecter) bool {
+						return api.Authorize(r, action, object)
+					},
+					audit.WorkspaceBuildBaggageFromRequest(r),
+				)
+				return err
+			}
+			if !errors.Is(err, sql.ErrNoRows) {
+				return xerrors.Errorf("claim prebuilt workspace: %w", err)
+			}
+		}
+
 		now := dbtime.Now()
 		// Workspaces are created without any versions.
 		workspace, err = db.InsertWorkspace(ctx, database.InsertWorkspaceParams{
@@ -561,7 +612,8 @@ func (api *API) postWorkspacesByOrganization(rw http.ResponseWriter, r *http.Req
 			Reason(database.BuildReasonInitiator).
 			Initiator(apiKey.UserID).
 			ActiveVersion().
-			RichParameterValues(createWorkspace.RichParameterValues)
+			RichParameterValues(createWorkspace.RichParameterValues).
+			TemplateVersionPreset(createWorkspace.TemplateVersionPresetID)
 		if createWorkspace.TemplateVersionID != uuid.Nil {
 			builder = builder.VersionID(createWorkspace.TemplateVersionID)
 		}
@@ -1396,6 +1448,518 @@ func (api *API) putWorkspaceAutoupdates(rw http.ResponseWriter, r *http.Request)
 	rw.WriteHeader(http.StatusNoContent)
 }
 
+// @Summary Transfer workspace to another user
+// @ID transfer-workspace-to-another-user
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param request body codersdk.TransferWorkspaceRequest true "Transfer workspace request"
+// @Success 201 {object} codersdk.WorkspaceBuild
+// @Router /workspaces/{workspace}/transfer [post]
+func (api *API) postWorkspaceTransfer(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		workspace         = httpmw.WorkspaceParam(r)
+		apiKey            = httpmw.APIKey(r)
+		auditor           = api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.Workspace](rw, &audit.RequestParams{
+			Audit:          *auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionWrite,
+			OrganizationID: workspace.OrganizationID,
+		})
+	)
+	defer commitAudit()
+	aReq.Old = workspace
+
+	var req codersdk.TransferWorkspaceRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	if req.OwnerID == workspace.OwnerID {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Workspace is already owned by this user.",
+		})
+		return
+	}
+	if workspace.OwnerID == prebuilds.SystemUserID || req.OwnerID == prebuilds.SystemUserID {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Prebuilt workspaces are handed out by creating a workspace from their template.",
+		})
+		return
+	}
+	// Owners may give their workspaces away. Users the workspace is shared
+	// with may not, so anyone else must be able to update the workspace
+	// regardless of its ACL.
+	canTransfer := api.Authorize(r, policy.ActionUpdate, workspace) &&
+		(workspace.OwnerID == apiKey.UserID ||
+			api.Authorize(r, policy.ActionUpdate, rbac.ResourceWorkspace.WithID(workspace.ID).InOrg(workspace.OrganizationID).WithOwner(workspace.OwnerID.String())))
+	if !canTransfer {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "You are not allowed to transfer this workspace.",
+		})
+		return
+	}
+
+	// Members may not be able to see each other, so eligibility of the new
+	// owner is checked regardless of the caller.
+	//nolint:gocritic // The caller may not be able to read the new owner.
+	member, err := database.ExpectOne(api.Database.OrganizationMembers(dbauthz.AsSystemRestricted(ctx), database.OrganizationMembersParams{
+		OrganizationID: workspace.OrganizationID,
+		UserID:         req.OwnerID,
+	}))
+	if httpapi.Is404Error(err) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "The new owner must be a member of the organization of the workspace.",
+			Validations: []codersdk.ValidationError{{
+				Field:  "owner_id",
+				Detail: "User is not a member of the organization.",
+			}},
+		})
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching organization member.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	// The new owner must be able to create the workspace themselves.
+	newOwner, newOwnerStatus, err := httpmw.UserRBACSubject(ctx, api.Database, req.OwnerID, rbac.ScopeAll)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching new owner roles.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	template, err := api.Database.GetTemplateByID(ctx, workspace.TemplateID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	newOwnerAuthorize := func(action policy.Action, object rbac.Objecter) bool {
+		return api.HTTPAuth.Authorizer.Authorize(ctx, newOwner, action, object.RBACObject()) == nil
+	}
+	if newOwnerStatus == database.UserStatusSuspended {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "The new owner is suspended.",
+			Validations: []codersdk.ValidationError{{
+				Field:  "owner_id",
+				Detail: "User is suspended.",
+			}},
+		})
+		return
+	}
+	if !newOwnerAuthorize(policy.ActionRead, template) ||
+		!newOwnerAuthorize(policy.ActionCreate, rbac.ResourceWorkspace.WithOwner(req.OwnerID.String()).InOrg(workspace.OrganizationID)) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "The new owner is not allowed to use the template of the workspace.",
+			Validations: []codersdk.ValidationError{{
+				Field:  "owner_id",
+				Detail: "User cannot create workspaces from the template.",
+			}},
+		})
+		return
+	}
+
+	latestBuild, err := api.Database.GetLatestWorkspaceBuildByWorkspaceID(ctx, workspace.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching latest workspace build.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	var (
+		transferred    database.Workspace
+		workspaceBuild *database.WorkspaceBuild
+		provisionerJob *database.ProvisionerJob
+	)
+	err = api.Database.InTx(func(tx database.Store) error {
+		var err error
+		transferred, err = tx.UpdateWorkspaceOwner(ctx, database.UpdateWorkspaceOwnerParams{
+			ID:        workspace.ID,
+			OwnerID:   req.OwnerID,
+			UpdatedAt: dbtime.Now(),
+		})
+		if err != nil {
+			return xerrors.Errorf("update workspace owner: %w", err)
+		}
+
+		// The agent is given a session token of the workspace owner. The
+		// build issues one for the new owner, so the one of the previous
+		// owner must not outlive the transfer.
+		//nolint:gocritic // The caller cannot read the API keys of the previous owner.
+		sysCtx := dbauthz.AsSystemRestricted(ctx)
+		key, err := tx.GetAPIKeyByName(sysCtx, database.GetAPIKeyByNameParams{
+			UserID:    workspace.OwnerID,
+			TokenName: fmt.Sprintf("%s_%s_session_token", workspace.OwnerID, workspace.ID),
+		})
+		if err == nil {
+			err = tx.DeleteAPIKeyByID(sysCtx, key.ID)
+		}
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return xerrors.Errorf("delete previous owner session token: %w", err)
+		}
+
+		// The build re-evaluates the owner of the workspace in the template.
+		// The agent token is regenerated by the provisioner on every build,
+		// and only agents of the latest build can authenticate, so the
+		// previous owner loses access to the agent as well. The caller may
+		// no longer access the workspace, so the build is authorized as the
+		// new owner.
+		builder := wsbuilder.New(transferred, latestBuild.Transition).
+			Reason(database.BuildReasonInitiator).
+			Initiator(apiKey.UserID).
+			DeploymentValues(api.Options.DeploymentValues)
+		workspaceBuild, provisionerJob, err = builder.Build(
+			dbauthz.As(ctx, newOwner),
+			tx,
+			newOwnerAuthorize,
+			audit.WorkspaceBuildBaggageFromRequest(r),
+		)
+		return err
+	}, nil)
+	var bldErr wsbuilder.BuildError
+	if xerrors.As(err, &bldErr) {
+		httpapi.Write(ctx, rw, bldErr.Status, codersdk.Response{
+			Message: bldErr.Message,
+			Detail:  bldErr.Error(),
+		})
+		return
+	}
+	if database.IsUniqueViolation(err) {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: fmt.Sprintf("Workspace %q already exists for %q.", workspace.Name, member.Username),
+			Validations: []codersdk.ValidationError{{
+				Field:  "owner_id",
+				Detail: "The user already owns a workspace with the same name.",
+			}},
+		})
+		return
+	}
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error transferring workspace.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.New = transferred
+
+	err = provisionerjobs.PostJob(api.Pubsub, *provisionerJob)
+	if err != nil {
+		// Client probably doesn't care about this error, so just log it.
+		api.Logger.Error(ctx, "failed to post provisioner job to pubsub", slog.Error(err))
+	}
+
+	apiBuild, err := api.convertWorkspaceBuild(
+		*workspaceBuild,
+		transferred,
+		database.GetProvisionerJobsByIDsWithQueuePositionRow{
+			ProvisionerJob: *provisionerJob,
+			QueuePosition:  0,
+		},
+		member.Username,
+		member.AvatarURL,
+		[]database.WorkspaceResource{},
+		[]database.WorkspaceResourceMetadatum{},
+		[]database.WorkspaceAgent{},
+		[]database.WorkspaceApp{},
+		[]database.WorkspaceAgentScript{},
+		[]database.WorkspaceAgentLogSource{},
+		database.TemplateVersion{},
+	)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error converting workspace build.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	api.publishWorkspaceUpdate(ctx, workspace.ID)
+
+	httpapi.Write(ctx, rw, http.StatusCreated, apiBuild)
+}
+
+// @Summary Get workspace ACL
+// @ID get-workspace-acl
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Success 200 {object} codersdk.WorkspaceACL
+// @Router /workspaces/{workspace}/acl [get]
+func (api *API) workspaceACL(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx       = r.Context()
+		workspace = httpmw.WorkspaceParam(r)
+	)
+
+	// Anyone who can read the workspace may see who it is shared with, even
+	// if they cannot read those users and groups otherwise.
+	//nolint:gocritic
+	sysCtx := dbauthz.AsSystemRestricted(ctx)
+
+	userIDs := make([]uuid.UUID, 0, len(workspace.UserACL))
+	for id := range workspace.UserACL {
+		userID, err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+		userIDs = append(userIDs, userID)
+	}
+	users, err := api.Database.GetUsersByIDs(sysCtx, userIDs)
+	if err != nil && !httpapi.Is404Error(err) {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace users.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	groups, err := api.Database.GetGroupsByOrganizationID(sysCtx, workspace.OrganizationID)
+	if err != nil && !httpapi.Is404Error(err) {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace groups.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	acl := codersdk.WorkspaceACL{
+		Users:  make([]codersdk.WorkspaceUser, 0, len(users)),
+		Groups: make([]codersdk.WorkspaceGroup, 0, len(workspace.GroupACL)),
+	}
+	for _, user := range users {
+		acl.Users = append(acl.Users, codersdk.WorkspaceUser{
+			MinimalUser: codersdk.MinimalUser{
+				ID:        user.ID,
+				Username:  user.Username,
+				AvatarURL: user.AvatarURL,
+			},
+			Role: convertToWorkspaceRole(workspace.UserACL[user.ID.String()]),
+		})
+	}
+	for _, group := range groups {
+		actions, ok := workspace.GroupACL[group.ID.String()]
+		if !ok {
+			continue
+		}
+		acl.Groups = append(acl.Groups, codersdk.WorkspaceGroup{
+			ID:          group.ID,
+			Name:        group.Name,
+			DisplayName: group.DisplayName,
+			Role:        convertToWorkspaceRole(actions),
+		})
+	}
+	slices.SortFunc(acl.Users, func(a, b codersdk.WorkspaceUser) int {
+		return strings.Compare(a.Username, b.Username)
+	})
+	slices.SortFunc(acl.Groups, func(a, b codersdk.WorkspaceGroup) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	httpapi.Write(ctx, rw, http.StatusOK, acl)
+}
+
+// @Summary Update workspace ACL
+// @ID update-workspace-acl
+// @Security CoderSessionToken
+// @Accept json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param request body codersdk.UpdateWorkspaceACL true "Update workspace ACL request"
+// @Success 204
+// @Router /workspaces/{workspace}/acl [patch]
+func (api *API) patchWorkspaceACL(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		workspace         = httpmw.WorkspaceParam(r)
+		auditor           = api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.Workspace](rw, &audit.RequestParams{
+			Audit:          *auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionWrite,
+			OrganizationID: workspace.OrganizationID,
+		})
+	)
+	defer commitAudit()
+	aReq.Old = workspace
+
+	var req codersdk.UpdateWorkspaceACL
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	// Users the workspace is shared with can read it, but only those who
+	// could have created it may share it.
+	if !api.Authorize(r, policy.ActionCreate, workspace) {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "You are not allowed to share this workspace.",
+		})
+		return
+	}
+
+	validErrs := api.validateWorkspaceACLPerms(ctx, workspace, req.UserPerms, "user_perms", true)
+	validErrs = append(validErrs,
+		api.validateWorkspaceACLPerms(ctx, workspace, req.GroupPerms, "group_perms", false)...)
+	if len(validErrs) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid request to update workspace ACL.",
+			Validations: validErrs,
+		})
+		return
+	}
+
+	err := api.Database.InTx(func(tx database.Store) error {
+		var err error
+		workspace, err = tx.GetWorkspaceByID(ctx, workspace.ID)
+		if err != nil {
+			return xerrors.Errorf("get workspace by ID: %w", err)
+		}
+		if workspace.UserACL == nil {
+			workspace.UserACL = database.WorkspaceACL{}
+		}
+		if workspace.GroupACL == nil {
+			workspace.GroupACL = database.WorkspaceACL{}
+		}
+
+		for id, role := range req.UserPerms {
+			// An empty role implies deletion.
+			if role == codersdk.WorkspaceRoleDeleted {
+				delete(workspace.UserACL, id)
+				continue
+			}
+			workspace.UserACL[id] = convertSDKWorkspaceRole(role)
+		}
+		for id, role := range req.GroupPerms {
+			if role == codersdk.WorkspaceRoleDeleted {
+				delete(workspace.GroupACL, id)
+				continue
+			}
+			workspace.GroupACL[id] = convertSDKWorkspaceRole(role)
+		}
+
+		err = tx.UpdateWorkspaceACLByID(ctx, database.UpdateWorkspaceACLByIDParams{
+			ID:       workspace.ID,
+			UserACL:  workspace.UserACL,
+			GroupACL: workspace.GroupACL,
+		})
+		if err != nil {
+			return xerrors.Errorf("update workspace ACL by ID: %w", err)
+		}
+		return nil
+	}, nil)
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error updating workspace ACL.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.New = workspace
+
+	api.publishWorkspaceUpdate(ctx, workspace.ID)
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+//nolint:revive // The flag selects whether perms are keyed by user or group IDs.
+func (api *API) validateWorkspaceACLPerms(ctx context.Context, workspace database.Workspace, perms map[string]codersdk.WorkspaceRole, field string, isUser bool) []codersdk.ValidationError {
+	// Validation requires reading users and groups the caller may not see.
+	//nolint:gocritic
+	ctx = dbauthz.AsSystemRestricted(ctx)
+	var validErrs []codersdk.ValidationError
+	for k, v := range perms {
+		if v != codersdk.WorkspaceRoleDeleted && convertSDKWorkspaceRole(v) == nil {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: fmt.Sprintf("Role %q is not a valid workspace role.", v)})
+			continue
+		}
+
+		id, err := uuid.Parse(k)
+		if err != nil {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: "ID " + k + " must be a valid UUID."})
+			continue
+		}
+		// Revoking access must work even if the user or group is gone.
+		if v == codersdk.WorkspaceRoleDeleted {
+			continue
+		}
+
+		if isUser {
+			if id == workspace.OwnerID {
+				validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: "The workspace owner cannot be added to the ACL."})
+				continue
+			}
+			_, err = database.ExpectOne(api.Database.OrganizationMembers(ctx, database.OrganizationMembersParams{
+				OrganizationID: workspace.OrganizationID,
+				UserID:         id,
+			}))
+		} else {
+			var group database.Group
+			group, err = api.Database.GetGroupByID(ctx, id)
+			if err == nil && group.OrganizationID != workspace.OrganizationID {
+				err = sql.ErrNoRows
+			}
+		}
+		if err != nil {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: fmt.Sprintf("Failed to find %q in the organization of the workspace: %v", k, err.Error())})
+			continue
+		}
+	}
+
+	return validErrs
+}
+
+var (
+	workspaceRoleUseActions   = []policy.Action{policy.ActionRead, policy.ActionSSH, policy.ActionApplicationConnect}
+	workspaceRoleAdminActions = append(slices.Clone(workspaceRoleUseActions), policy.ActionUpdate, policy.ActionWorkspaceStart, policy.ActionWorkspaceStop)
+)
+
+func convertToWorkspaceRole(actions []policy.Action) codersdk.WorkspaceRole {
+	switch {
+	case slices.Equal(actions, workspaceRoleAdminActions):
+		return codersdk.WorkspaceRoleAdmin
+	case slices.Equal(actions, workspaceRoleUseActions):
+		return codersdk.WorkspaceRoleUse
+	}
+
+	return ""
+}
+
+func convertSDKWorkspaceRole(role codersdk.WorkspaceRole) []policy.Action {
+	switch role {
+	case codersdk.WorkspaceRoleAdmin:
+		return slices.Clone(workspaceRoleAdminActions)
+	case codersdk.WorkspaceRoleUse:
+		return slices.Clone(workspaceRoleUseActions)
+	}
+
+	return nil
+}
+
 // @Summary Resolve workspace autostart by id.
 // @ID resolve-workspace-autostart-by-id
 // @Security CoderSessionToken
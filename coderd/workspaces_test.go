@@ -2923,6 +2923,92 @@ func TestWorkspaceWithRichParameters(t *testing.T) {
 	require.ElementsMatch(t, expectedBuildParameters, workspaceBuildParameters)
 }
 
+func TestWorkspaceWithPreset(t *testing.T) {
+	t.Parallel()
+
+	client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+	user := coderdtest.CreateFirstUser(t, client)
+	responses := &echo.Responses{
+		Parse: echo.ParseComplete,
+		ProvisionPlan: []*proto.Response{{
+			Type: &proto.Response_Plan{
+				Plan: &proto.PlanComplete{
+					Parameters: []*proto.RichParameter{
+						{Name: "region", Type: "string", DefaultValue: "us"},
+						{Name: "size", Type: "number", DefaultValue: "1", Mutable: true, ValidationMin: ptr.Ref(int32(1)), ValidationMax: ptr.Ref(int32(4))},
+					},
+				},
+			},
+		}},
+		ProvisionApply: echo.ApplyComplete,
+	}
+	version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, responses, func(req *codersdk.CreateTemplateVersionRequest) {
+		req.Presets = []codersdk.TemplateVersionPresetRequest{
+			{Name: "large", Parameters: []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "eu"}, {Name: "size", Value: "4"}}},
+			{Name: "invalid", Parameters: []codersdk.WorkspaceBuildParameter{{Name: "size", Value: "8"}}},
+		}
+	})
+	coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+	template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+	presets := make(map[string]uuid.UUID)
+	for _, preset := range version.Presets {
+		presets[preset.Name] = preset.ID
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		// Values given with the request take precedence over the preset.
+		workspace := coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID, func(req *codersdk.CreateWorkspaceRequest) {
+			req.TemplateVersionPresetID = presets["large"]
+			req.RichParameterValues = []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "ap"}}
+		})
+		build := coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+
+		params, err := client.WorkspaceBuildParameters(ctx, build.ID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []codersdk.WorkspaceBuildParameter{
+			{Name: "region", Value: "ap"},
+			{Name: "size", Value: "4"},
+		}, params)
+	})
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_, err := client.CreateWorkspace(ctx, user.OrganizationID, codersdk.Me, codersdk.CreateWorkspaceRequest{
+			TemplateID:              template.ID,
+			Name:                    "invalid",
+			TemplateVersionPresetID: presets["invalid"],
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+
+	t.Run("OtherVersion", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		other := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, responses, func(req *codersdk.CreateTemplateVersionRequest) {
+			req.Presets = []codersdk.TemplateVersionPresetRequest{{Name: "large"}}
+		})
+		_, err := client.CreateWorkspace(ctx, user.OrganizationID, codersdk.Me, codersdk.CreateWorkspaceRequest{
+			TemplateID:              template.ID,
+			Name:                    "other",
+			TemplateVersionPresetID: other.Presets[0].ID,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+}
+
 func TestWorkspaceWithOptionalRichParameters(t *testing.T) {
 	t.Parallel()
 
@@ -3235,6 +3321,452 @@ func TestWorkspaceDormant(t *testing.T) {
 	})
 }
 
+func TestWorkspaceTransfer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			auditor              = audit.NewMock()
+			client               = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true, Auditor: auditor})
+			owner                = coderdtest.CreateFirstUser(t, client)
+			memberClient, _      = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			_, newOwner          = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			sharedClient, shared = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			version              = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_                    = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template             = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			workspace            = coderdtest.CreateWorkspace(t, memberClient, owner.OrganizationID, template.ID)
+			_                    = coderdtest.AwaitWorkspaceBuildJobCompleted(t, memberClient, workspace.LatestBuild.ID)
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		// The previous owner shared the workspace, which the transfer revokes.
+		err := memberClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				shared.ID.String(): codersdk.WorkspaceRoleUse,
+			},
+			GroupPerms: map[string]codersdk.WorkspaceRole{
+				owner.OrganizationID.String(): codersdk.WorkspaceRoleUse,
+			},
+		})
+		require.NoError(t, err)
+
+		build, err := client.TransferWorkspace(ctx, workspace.ID, codersdk.TransferWorkspaceRequest{
+			OwnerID: newOwner.ID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, codersdk.WorkspaceTransitionStart, build.Transition)
+		require.Equal(t, newOwner.ID, build.WorkspaceOwnerID)
+		require.Equal(t, owner.UserID, build.InitiatorID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, build.ID)
+
+		transferred, err := client.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Equal(t, newOwner.ID, transferred.OwnerID)
+		require.Equal(t, build.ID, transferred.LatestBuild.ID)
+
+		// The previous owner no longer has access to the workspace.
+		_, err = memberClient.Workspace(ctx, workspace.ID)
+		require.Error(t, err)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+
+		acl, err := client.WorkspaceACL(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Empty(t, acl.Users)
+		require.Empty(t, acl.Groups)
+		_, err = sharedClient.Workspace(ctx, workspace.ID)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+
+		require.True(t, auditor.Contains(t, database.AuditLog{
+			Action:       database.AuditActionWrite,
+			ResourceType: database.ResourceTypeWorkspace,
+			ResourceID:   workspace.ID,
+			UserID:       owner.UserID,
+		}))
+	})
+
+	t.Run("MemberToMember", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			client                   = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			owner                    = coderdtest.CreateFirstUser(t, client)
+			memberClient, member     = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			newOwnerClient, newOwner = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			version                  = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_                        = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template                 = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			workspace                = coderdtest.CreateWorkspace(t, memberClient, owner.OrganizationID, template.ID)
+			_                        = coderdtest.AwaitWorkspaceBuildJobCompleted(t, memberClient, workspace.LatestBuild.ID)
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		// Members may give their own workspaces to other members.
+		build, err := memberClient.TransferWorkspace(ctx, workspace.ID, codersdk.TransferWorkspaceRequest{
+			OwnerID: newOwner.ID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, newOwner.ID, build.WorkspaceOwnerID)
+		require.Equal(t, member.ID, build.InitiatorID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, newOwnerClient, build.ID)
+
+		transferred, err := newOwnerClient.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Equal(t, newOwner.ID, transferred.OwnerID)
+
+		_, err = memberClient.Workspace(ctx, workspace.ID)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			client               = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			owner                = coderdtest.CreateFirstUser(t, client)
+			memberClient, _      = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			sharedClient, shared = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			version              = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_                    = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template             = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			workspace            = coderdtest.CreateWorkspace(t, memberClient, owner.OrganizationID, template.ID)
+			_                    = coderdtest.AwaitWorkspaceBuildJobCompleted(t, memberClient, workspace.LatestBuild.ID)
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		// Users the workspace is shared with cannot give it away, even to
+		// themselves.
+		err := memberClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				shared.ID.String(): codersdk.WorkspaceRoleAdmin,
+			},
+		})
+		require.NoError(t, err)
+		_, err = sharedClient.TransferWorkspace(ctx, workspace.ID, codersdk.TransferWorkspaceRequest{
+			OwnerID: shared.ID,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+	})
+
+	t.Run("SuspendedOwner", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			client          = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			owner           = coderdtest.CreateFirstUser(t, client)
+			memberClient, _ = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			_, newOwner     = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			version         = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_               = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template        = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			workspace       = coderdtest.CreateWorkspace(t, memberClient, owner.OrganizationID, template.ID)
+			_               = coderdtest.AwaitWorkspaceBuildJobCompleted(t, memberClient, workspace.LatestBuild.ID)
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		_, err := client.UpdateUserStatus(ctx, newOwner.ID.String(), codersdk.UserStatusSuspended)
+		require.NoError(t, err)
+		_, err = memberClient.TransferWorkspace(ctx, workspace.ID, codersdk.TransferWorkspaceRequest{
+			OwnerID: newOwner.ID,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+
+	t.Run("SameOwner", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			client    = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			owner     = coderdtest.CreateFirstUser(t, client)
+			version   = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_         = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template  = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			workspace = coderdtest.CreateWorkspace(t, client, owner.OrganizationID, template.ID)
+			_         = coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		_, err := client.TransferWorkspace(ctx, workspace.ID, codersdk.TransferWorkspaceRequest{
+			OwnerID: owner.UserID,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+
+	t.Run("NameConflict", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			client                   = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			owner                    = coderdtest.CreateFirstUser(t, client)
+			memberClient, _          = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			newOwnerClient, newOwner = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			version                  = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_                        = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template                 = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			workspace                = coderdtest.CreateWorkspace(t, memberClient, owner.OrganizationID, template.ID)
+			_                        = coderdtest.AwaitWorkspaceBuildJobCompleted(t, memberClient, workspace.LatestBuild.ID)
+			existing                 = coderdtest.CreateWorkspace(t, newOwnerClient, owner.OrganizationID, template.ID, func(cwr *codersdk.CreateWorkspaceRequest) {
+				cwr.Name = workspace.Name
+			})
+			_ = coderdtest.AwaitWorkspaceBuildJobCompleted(t, newOwnerClient, existing.LatestBuild.ID)
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		_, err := client.TransferWorkspace(ctx, workspace.ID, codersdk.TransferWorkspaceRequest{
+			OwnerID: newOwner.ID,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusConflict, apiErr.StatusCode())
+	})
+}
+
+func TestWorkspaceACL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			auditor              = audit.NewMock()
+			client               = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true, Auditor: auditor, AllowWorkspaceRenames: true})
+			owner                = coderdtest.CreateFirstUser(t, client)
+			memberClient, member = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			sharedClient, shared = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			version              = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_                    = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template             = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			workspace            = coderdtest.CreateWorkspace(t, memberClient, owner.OrganizationID, template.ID)
+			_                    = coderdtest.AwaitWorkspaceBuildJobCompleted(t, memberClient, workspace.LatestBuild.ID)
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		// The workspace is not visible before it is shared.
+		_, err := sharedClient.Workspace(ctx, workspace.ID)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+
+		err = memberClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				shared.ID.String(): codersdk.WorkspaceRoleUse,
+			},
+		})
+		require.NoError(t, err)
+		require.True(t, auditor.Contains(t, database.AuditLog{
+			Action:       database.AuditActionWrite,
+			ResourceType: database.ResourceTypeWorkspace,
+			ResourceID:   workspace.ID,
+			UserID:       member.ID,
+		}))
+
+		acl, err := memberClient.WorkspaceACL(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Len(t, acl.Users, 1)
+		require.Equal(t, shared.ID, acl.Users[0].ID)
+		require.Equal(t, codersdk.WorkspaceRoleUse, acl.Users[0].Role)
+		require.Empty(t, acl.Groups)
+
+		// Users can read a workspace shared with them, but "use" does not
+		// allow changing its state.
+		got, err := sharedClient.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Equal(t, member.ID, got.OwnerID)
+		_, err = sharedClient.CreateWorkspaceBuild(ctx, workspace.ID, codersdk.CreateWorkspaceBuildRequest{
+			Transition: codersdk.WorkspaceTransitionStop,
+		})
+		require.Error(t, err)
+		err = sharedClient.UpdateWorkspaceTTL(ctx, workspace.ID, codersdk.UpdateWorkspaceTTLRequest{
+			TTLMillis: ptr.Ref(time.Hour.Milliseconds()),
+		})
+		require.Error(t, err)
+
+		// Nor may they share it further.
+		err = sharedClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				shared.ID.String(): codersdk.WorkspaceRoleAdmin,
+			},
+		})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+
+		// "admin" allows stopping the workspace.
+		err = memberClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				shared.ID.String(): codersdk.WorkspaceRoleAdmin,
+			},
+		})
+		require.NoError(t, err)
+		build, err := sharedClient.CreateWorkspaceBuild(ctx, workspace.ID, codersdk.CreateWorkspaceBuildRequest{
+			Transition: codersdk.WorkspaceTransitionStop,
+		})
+		require.NoError(t, err)
+		require.Equal(t, shared.ID, build.InitiatorID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, build.ID)
+
+		// "admin" grants full update access, so the settings of the workspace
+		// can be changed too.
+		err = sharedClient.UpdateWorkspaceTTL(ctx, workspace.ID, codersdk.UpdateWorkspaceTTLRequest{
+			TTLMillis: ptr.Ref(time.Hour.Milliseconds()),
+		})
+		require.NoError(t, err)
+		err = sharedClient.UpdateWorkspace(ctx, workspace.ID, codersdk.UpdateWorkspaceRequest{
+			Name: "renamed",
+		})
+		require.NoError(t, err)
+		got, err = sharedClient.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Equal(t, "renamed", got.Name)
+		require.Equal(t, ptr.Ref(time.Hour.Milliseconds()), got.TTLMillis)
+
+		// Revoking access hides the workspace again.
+		err = memberClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				shared.ID.String(): codersdk.WorkspaceRoleDeleted,
+			},
+		})
+		require.NoError(t, err)
+		_, err = sharedClient.Workspace(ctx, workspace.ID)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+
+	t.Run("Group", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			client          = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			owner           = coderdtest.CreateFirstUser(t, client)
+			memberClient, _ = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			sharedClient, _ = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			version         = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_               = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template        = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			workspace       = coderdtest.CreateWorkspace(t, memberClient, owner.OrganizationID, template.ID)
+			_               = coderdtest.AwaitWorkspaceBuildJobCompleted(t, memberClient, workspace.LatestBuild.ID)
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		// The "Everyone" group shares the ID of its organization.
+		err := memberClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			GroupPerms: map[string]codersdk.WorkspaceRole{
+				owner.OrganizationID.String(): codersdk.WorkspaceRoleUse,
+			},
+		})
+		require.NoError(t, err)
+
+		acl, err := memberClient.WorkspaceACL(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Len(t, acl.Groups, 1)
+		require.Equal(t, owner.OrganizationID, acl.Groups[0].ID)
+		require.Equal(t, codersdk.WorkspaceRoleUse, acl.Groups[0].Role)
+
+		_, err = sharedClient.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("RemovedMember", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			client               = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			owner                = coderdtest.CreateFirstUser(t, client)
+			memberClient, _      = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			sharedClient, shared = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			version              = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_                    = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template             = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			workspace            = coderdtest.CreateWorkspace(t, memberClient, owner.OrganizationID, template.ID)
+			_                    = coderdtest.AwaitWorkspaceBuildJobCompleted(t, memberClient, workspace.LatestBuild.ID)
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		err := memberClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				shared.ID.String(): codersdk.WorkspaceRoleAdmin,
+			},
+		})
+		require.NoError(t, err)
+
+		// Leaving the organization revokes access to its workspaces.
+		err = client.DeleteOrganizationMember(ctx, owner.OrganizationID, shared.Username)
+		require.NoError(t, err)
+		acl, err := memberClient.WorkspaceACL(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Empty(t, acl.Users)
+		_, err = sharedClient.Workspace(ctx, workspace.ID)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			client               = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			owner                = coderdtest.CreateFirstUser(t, client)
+			memberClient, member = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			_, other             = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+			version              = coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+			_                    = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+			template             = coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+			workspace            = coderdtest.CreateWorkspace(t, memberClient, owner.OrganizationID, template.ID)
+			_                    = coderdtest.AwaitWorkspaceBuildJobCompleted(t, memberClient, workspace.LatestBuild.ID)
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		for _, perms := range []map[string]codersdk.WorkspaceRole{
+			{other.ID.String(): "owner"},
+			{"not-a-uuid": codersdk.WorkspaceRoleUse},
+			{member.ID.String(): codersdk.WorkspaceRoleUse},
+			{uuid.NewString(): codersdk.WorkspaceRoleUse},
+		} {
+			err := memberClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+				UserPerms: perms,
+			})
+			var apiErr *codersdk.Error
+			require.ErrorAs(t, err, &apiErr)
+			require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+			require.Len(t, apiErr.Validations, 1)
+			require.Equal(t, "user_perms", apiErr.Validations[0].Field)
+		}
+	})
+}
+
 func TestWorkspaceFavoriteUnfavorite(t *testing.T) {
 	t.Parallel()
 	// Given:
@@ -3469,6 +4001,9 @@ func TestNotifications(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
 			t.Cleanup(cancel)
 
+			// Clear the notification sent to the first user when the member was created
+			notifyEnq.Clear()
+
 			// When
 			err := memberClient.UpdateWorkspaceDormancy(ctx, workspace.ID, codersdk.UpdateWorkspaceDormancy{
 				Dormant: true,
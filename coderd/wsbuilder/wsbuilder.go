@@ -51,6 +51,7 @@ type Builder struct {
 	deploymentValues *codersdk.DeploymentValues
 
 	richParameterValues []codersdk.WorkspaceBuildParameter
+	presetID            uuid.UUID
 	initiator           uuid.UUID
 	reason              database.BuildReason
 
@@ -64,6 +65,7 @@ type Builder struct {
 	templateVersionJob           *database.ProvisionerJob
 	templateVersionParameters    *[]database.TemplateVersionParameter
 	templateVersionWorkspaceTags *[]database.TemplateVersionWorkspaceTag
+	templateVersionPreset        *database.TemplateVersionPreset
 	lastBuild                    *database.WorkspaceBuild
 	lastBuildErr                 *error
 	lastBuildParameters          *[]database.WorkspaceBuildParameter
@@ -166,6 +168,15 @@ func (b Builder) RichParameterValues(p []codersdk.WorkspaceBuildParameter) Build
 	return b
 }
 
+// TemplateVersionPreset uses the parameter values of the preset for
+// parameters that are not given with RichParameterValues. The preset must
+// belong to the template version of the build.
+func (b Builder) TemplateVersionPreset(id uuid.UUID) Builder {
+	// nolint: revive
+	b.presetID = id
+	return b
+}
+
 // SetLastWorkspaceBuildInTx prepopulates the Builder's cache with the last workspace build.  This allows us
 // to avoid a repeated database query when the Builder's caller also needs the workspace build, e.g. auto-start &
 // auto-stop.
@@ -530,6 +541,10 @@ func (b *Builder) getParameters() (names, values []string, err error) {
 	if err != nil {
 		return nil, nil, BuildError{http.StatusBadRequest, "Unable to build workspace with unsupported parameters", err}
 	}
+	preset, err := b.getTemplateVersionPreset()
+	if err != nil {
+		return nil, nil, err
+	}
 	resolver := codersdk.ParameterResolver{
 		Rich: db2sdk.WorkspaceBuildParameters(lastBuildParameters),
 	}
@@ -538,10 +553,17 @@ func (b *Builder) getParameters() (names, values []string, err error) {
 		if err != nil {
 			return nil, nil, BuildError{http.StatusInternalServerError, "failed to convert template version parameter", err}
 		}
-		value, err := resolver.ValidateResolve(
-			tvp,
-			b.findNewBuildParameterValue(templateVersionParameter.Name),
-		)
+		newValue := b.findNewBuildParameterValue(templateVersionParameter.Name)
+		if presetValue, ok := preset.Parameters[templateVersionParameter.Name]; ok && newValue == nil {
+			// A value the workspace already has is not a change, so presets
+			// can be applied to claimed prebuilt workspaces with immutable
+			// parameters.
+			lastValue, ok := lastBuildParameterValue(lastBuildParameters, templateVersionParameter.Name)
+			if !ok || lastValue != presetValue || templateVersionParameter.Ephemeral {
+				newValue = &codersdk.WorkspaceBuildParameter{Name: templateVersionParameter.Name, Value: presetValue}
+			}
+		}
+		value, err := resolver.ValidateResolve(tvp, newValue)
 		if err != nil {
 			// At this point, we've queried all the data we need from the database,
 			// so the only errors are problems with the request (missing data, failed
@@ -566,6 +588,47 @@ func (b *Builder) findNewBuildParameterValue(name string) *codersdk.WorkspaceBui
 	return nil
 }
 
+func lastBuildParameterValue(params []database.WorkspaceBuildParameter, name string) (string, bool) {
+	for _, p := range params {
+		if p.Name == name {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+// getTemplateVersionPreset returns the preset of the build, or an empty preset
+// if none was given.
+func (b *Builder) getTemplateVersionPreset() (database.TemplateVersionPreset, error) {
+	if b.templateVersionPreset != nil {
+		return *b.templateVersionPreset, nil
+	}
+	if b.presetID == uuid.Nil {
+		b.templateVersionPreset = &database.TemplateVersionPreset{}
+		return *b.templateVersionPreset, nil
+	}
+	preset, err := b.store.GetTemplateVersionPresetByID(b.ctx, b.presetID)
+	if httpapi.Is404Error(err) {
+		return database.TemplateVersionPreset{}, BuildError{http.StatusBadRequest, "Template version preset not found", err}
+	}
+	if err != nil {
+		return database.TemplateVersionPreset{}, BuildError{http.StatusInternalServerError, "failed to fetch template version preset", err}
+	}
+	tvID, err := b.getTemplateVersionID()
+	if err != nil {
+		return database.TemplateVersionPreset{}, BuildError{http.StatusInternalServerError, "failed to get template version ID", err}
+	}
+	if preset.TemplateVersionID != tvID {
+		return database.TemplateVersionPreset{}, BuildError{
+			http.StatusBadRequest,
+			"Template version preset does not belong to the template version of the build",
+			xerrors.Errorf("preset %s belongs to template version %s, not %s", preset.ID, preset.TemplateVersionID, tvID),
+		}
+	}
+	b.templateVersionPreset = &preset
+	return preset, nil
+}
+
 func (b *Builder) getLastBuildParameters() ([]database.WorkspaceBuildParameter, error) {
 	if b.lastBuildParameters != nil {
 		return *b.lastBuildParameters, nil
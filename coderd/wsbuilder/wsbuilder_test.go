@@ -41,6 +41,7 @@ var (
 	lastBuildID       = uuid.MustParse("12341234-0000-0000-000b-000000000000")
 	lastBuildJobID    = uuid.MustParse("12341234-0000-0000-000c-000000000000")
 	otherUserID       = uuid.MustParse("12341234-0000-0000-000d-000000000000")
+	presetID          = uuid.MustParse("12341234-0000-0000-000e-000000000000")
 )
 
 func TestBuilder_NoOptions(t *testing.T) {
@@ -724,6 +725,126 @@ func TestWorkspaceBuildWithRichParameters(t *testing.T) {
 	})
 }
 
+func TestWorkspaceBuildWithPreset(t *testing.T) {
+	t.Parallel()
+
+	richParameters := []database.TemplateVersionParameter{
+		{Name: "region", Mutable: false, Options: json.RawMessage("[]")},
+		{Name: "size", Mutable: true, Options: json.RawMessage("[]")},
+		{Name: "image", Mutable: true, DefaultValue: "ubuntu", Options: json.RawMessage("[]")},
+	}
+	initialBuildParameters := []database.WorkspaceBuildParameter{
+		{Name: "region", Value: "us"},
+		{Name: "size", Value: "small"},
+		{Name: "image", Value: "ubuntu"},
+	}
+
+	t.Run("UsePresetParameterValues", func(t *testing.T) {
+		t.Parallel()
+
+		req := require.New(t)
+		asrt := assert.New(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		expectedParams := map[string]string{
+			"region": "us",
+			"size":   "large",
+			"image":  "fedora",
+		}
+
+		mDB := expectDB(t,
+			// Inputs
+			withTemplate,
+			withInactiveVersion(richParameters),
+			withLastBuildFound,
+			withRichParameters(initialBuildParameters),
+			withParameterSchemas(inactiveJobID, nil),
+			withTemplateVersionPreset(inactiveVersionID, database.StringMap{"size": "large", "image": "debian"}),
+			withWorkspaceTags(inactiveVersionID, nil),
+
+			// Outputs
+			expectProvisionerJob(func(job database.InsertProvisionerJobParams) {}),
+			withInTx,
+			expectBuild(func(bld database.InsertWorkspaceBuildParams) {}),
+			expectBuildParameters(func(params database.InsertWorkspaceBuildParametersParams) {
+				asrt.Len(params.Name, len(expectedParams))
+				for i := range params.Name {
+					value, ok := expectedParams[params.Name[i]]
+					asrt.True(ok, "unexpected name %s", params.Name[i])
+					asrt.Equal(value, params.Value[i])
+				}
+			}),
+			withBuild,
+		)
+
+		// Values given with the build take precedence over the preset.
+		ws := database.Workspace{ID: workspaceID, TemplateID: templateID, OwnerID: userID}
+		uut := wsbuilder.New(ws, database.WorkspaceTransitionStart).
+			TemplateVersionPreset(presetID).
+			RichParameterValues([]codersdk.WorkspaceBuildParameter{{Name: "image", Value: "fedora"}})
+		_, _, err := uut.Build(ctx, mDB, nil, audit.WorkspaceBuildBaggage{})
+		req.NoError(err)
+	})
+
+	t.Run("DoNotModifyImmutables", func(t *testing.T) {
+		t.Parallel()
+
+		req := require.New(t)
+		asrt := assert.New(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mDB := expectDB(t,
+			// Inputs
+			withTemplate,
+			withInactiveVersion(richParameters),
+			withLastBuildFound,
+			withRichParameters(initialBuildParameters),
+			withParameterSchemas(inactiveJobID, nil),
+			withTemplateVersionPreset(inactiveVersionID, database.StringMap{"region": "eu"}),
+			withWorkspaceTags(inactiveVersionID, nil),
+		)
+
+		ws := database.Workspace{ID: workspaceID, TemplateID: templateID, OwnerID: userID}
+		uut := wsbuilder.New(ws, database.WorkspaceTransitionStart).TemplateVersionPreset(presetID)
+		_, _, err := uut.Build(ctx, mDB, nil, audit.WorkspaceBuildBaggage{})
+		bldErr := wsbuilder.BuildError{}
+		req.ErrorAs(err, &bldErr)
+		asrt.Equal(http.StatusBadRequest, bldErr.Status)
+	})
+
+	t.Run("PresetOfOtherVersion", func(t *testing.T) {
+		t.Parallel()
+
+		req := require.New(t)
+		asrt := assert.New(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mDB := expectDB(t,
+			// Inputs
+			withTemplate,
+			withInactiveVersion(richParameters),
+			withLastBuildFound,
+			withRichParameters(initialBuildParameters),
+			withParameterSchemas(inactiveJobID, nil),
+			withTemplateVersionPreset(activeVersionID, database.StringMap{"size": "large"}),
+			withWorkspaceTags(inactiveVersionID, nil),
+		)
+
+		ws := database.Workspace{ID: workspaceID, TemplateID: templateID, OwnerID: userID}
+		uut := wsbuilder.New(ws, database.WorkspaceTransitionStart).TemplateVersionPreset(presetID)
+		_, _, err := uut.Build(ctx, mDB, nil, audit.WorkspaceBuildBaggage{})
+		bldErr := wsbuilder.BuildError{}
+		req.ErrorAs(err, &bldErr)
+		asrt.Equal(http.StatusBadRequest, bldErr.Status)
+	})
+}
+
 type txExpect func(mTx *dbmock.MockStore)
 
 func expectDB(t *testing.T, opts ...txExpect) *dbmock.MockStore {
@@ -924,6 +1045,19 @@ func withWorkspaceTags(versionID uuid.UUID, tags []database.TemplateVersionWorks
 	}
 }
 
+func withTemplateVersionPreset(versionID uuid.UUID, params database.StringMap) func(mTx *dbmock.MockStore) {
+	return func(mTx *dbmock.MockStore) {
+		mTx.EXPECT().GetTemplateVersionPresetByID(gomock.Any(), presetID).
+			Times(1).
+			Return(database.TemplateVersionPreset{
+				ID:                presetID,
+				TemplateVersionID: versionID,
+				Name:              "preset",
+				Parameters:        params,
+			}, nil)
+	}
+}
+
 // Since there is expected to be only one each of job, build, and build-parameters inserted, instead
 // of building matchers, we match any call and then assert its parameters.  This will feel
 // more familiar to the way we write other tests.
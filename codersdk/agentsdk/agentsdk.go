@@ -177,6 +177,24 @@ func (c *Client) ConnectRPC21(ctx context.Context) (proto.DRPCAgentClient21, err
 	return proto.NewDRPCAgentClient(conn), nil
 }
 
+// ConnectRPC22 returns a dRPC client to the Agent API v2.2.
+func (c *Client) ConnectRPC22(ctx context.Context) (proto.DRPCAgentClient22, error) {
+	conn, err := c.connectRPCVersion(ctx, apiversion.New(2, 2))
+	if err != nil {
+		return nil, err
+	}
+	return proto.NewDRPCAgentClient(conn), nil
+}
+
+// ConnectRPC23 returns a dRPC client to the Agent API v2.3, which adds connection reporting.
+func (c *Client) ConnectRPC23(ctx context.Context) (proto.DRPCAgentClient23, error) {
+	conn, err := c.connectRPCVersion(ctx, apiversion.New(2, 3))
+	if err != nil {
+		return nil, err
+	}
+	return proto.NewDRPCAgentClient(conn), nil
+}
+
 // ConnectRPC connects to the workspace agent API and tailnet API
 func (c *Client) ConnectRPC(ctx context.Context) (drpc.Conn, error) {
 	return c.connectRPCVersion(ctx, proto.CurrentVersion)
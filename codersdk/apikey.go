@@ -18,8 +18,9 @@ type APIKey struct {
 	ExpiresAt       time.Time   `json:"expires_at" validate:"required" format:"date-time"`
 	CreatedAt       time.Time   `json:"created_at" validate:"required" format:"date-time"`
 	UpdatedAt       time.Time   `json:"updated_at" validate:"required" format:"date-time"`
-	LoginType       LoginType   `json:"login_type" validate:"required" enums:"password,github,oidc,token"`
+	LoginType       LoginType   `json:"login_type" validate:"required" enums:"password,github,oidc,ldap,token"`
 	Scope           APIKeyScope `json:"scope" validate:"required" enums:"all,application_connect"`
+	Scopes          []string    `json:"scopes"`
 	TokenName       string      `json:"token_name" validate:"required"`
 	LifetimeSeconds int64       `json:"lifetime_seconds" validate:"required"`
 }
@@ -32,6 +33,7 @@ const (
 	LoginTypePassword LoginType = "password"
 	LoginTypeGithub   LoginType = "github"
 	LoginTypeOIDC     LoginType = "oidc"
+	LoginTypeLDAP     LoginType = "ldap"
 	LoginTypeToken    LoginType = "token"
 	// LoginTypeNone is used if no login method is available for this user.
 	// If this is set, the user has no method of logging in.
@@ -51,9 +53,13 @@ const (
 )
 
 type CreateTokenRequest struct {
-	Lifetime  time.Duration `json:"lifetime"`
-	Scope     APIKeyScope   `json:"scope" enums:"all,application_connect"`
-	TokenName string        `json:"token_name"`
+	Lifetime time.Duration `json:"lifetime"`
+	Scope    APIKeyScope   `json:"scope" enums:"all,application_connect"`
+	// Scopes limits the token to the given "resource:action"
+	// permissions, e.g. "workspace:read" or "template:update". The token
+	// can never do more than the roles of its owner allow.
+	Scopes    []string `json:"scopes,omitempty"`
+	TokenName string   `json:"token_name"`
 }
 
 // GenerateAPIKeyResponse contains an API key for a user.
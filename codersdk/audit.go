@@ -3,6 +3,7 @@ package codersdk
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/netip"
 	"strings"
@@ -26,6 +27,7 @@ const (
 	ResourceTypeConvertLogin          ResourceType = "convert_login"
 	ResourceTypeHealthSettings        ResourceType = "health_settings"
 	ResourceTypeNotificationsSettings ResourceType = "notifications_settings"
+	ResourceTypeNotificationTemplate  ResourceType = "notification_template"
 	ResourceTypeWorkspaceProxy        ResourceType = "workspace_proxy"
 	ResourceTypeOrganization          ResourceType = "organization"
 	ResourceTypeOAuth2ProviderApp     ResourceType = "oauth2_provider_app"
@@ -67,6 +69,8 @@ func (r ResourceType) FriendlyString() string {
 		return "health_settings"
 	case ResourceTypeNotificationsSettings:
 		return "notifications_settings"
+	case ResourceTypeNotificationTemplate:
+		return "notification template"
 	case ResourceTypeOAuth2ProviderApp:
 		return "oauth2 app"
 	case ResourceTypeOAuth2ProviderAppSecret:
@@ -83,14 +87,16 @@ func (r ResourceType) FriendlyString() string {
 type AuditAction string
 
 const (
-	AuditActionCreate   AuditAction = "create"
-	AuditActionWrite    AuditAction = "write"
-	AuditActionDelete   AuditAction = "delete"
-	AuditActionStart    AuditAction = "start"
-	AuditActionStop     AuditAction = "stop"
-	AuditActionLogin    AuditAction = "login"
-	AuditActionLogout   AuditAction = "logout"
-	AuditActionRegister AuditAction = "register"
+	AuditActionCreate     AuditAction = "create"
+	AuditActionWrite      AuditAction = "write"
+	AuditActionDelete     AuditAction = "delete"
+	AuditActionStart      AuditAction = "start"
+	AuditActionStop       AuditAction = "stop"
+	AuditActionLogin      AuditAction = "login"
+	AuditActionLogout     AuditAction = "logout"
+	AuditActionRegister   AuditAction = "register"
+	AuditActionConnect    AuditAction = "connect"
+	AuditActionDisconnect AuditAction = "disconnect"
 )
 
 func (a AuditAction) Friendly() string {
@@ -111,6 +117,10 @@ func (a AuditAction) Friendly() string {
 		return "logged out"
 	case AuditActionRegister:
 		return "registered"
+	case AuditActionConnect:
+		return "connected to"
+	case AuditActionDisconnect:
+		return "disconnected from"
 	default:
 		return "unknown"
 	}
@@ -161,6 +171,21 @@ type AuditLogResponse struct {
 	Count     int64      `json:"count"`
 }
 
+type AuditLogExportFormat string
+
+const (
+	// AuditLogExportFormatCSV exports one row per audit log, with a header
+	// row naming the columns.
+	AuditLogExportFormatCSV AuditLogExportFormat = "csv"
+	// AuditLogExportFormatJSONL exports one JSON encoded AuditLog per line.
+	AuditLogExportFormatJSONL AuditLogExportFormat = "jsonl"
+)
+
+type ExportAuditLogsRequest struct {
+	SearchQuery string               `json:"q,omitempty"`
+	Format      AuditLogExportFormat `json:"format"`
+}
+
 type CreateTestAuditLogRequest struct {
 	Action           AuditAction     `json:"action,omitempty" enums:"create,write,delete,start,stop"`
 	ResourceType     ResourceType    `json:"resource_type,omitempty" enums:"template,template_version,user,workspace,workspace_build,git_ssh_key,auditable_group"`
@@ -200,6 +225,27 @@ func (c *Client) AuditLogs(ctx context.Context, req AuditLogsRequest) (AuditLogR
 	return logRes, nil
 }
 
+// ExportAuditLogs streams every audit log matching the search query in the
+// given format, newest first. The caller must close the returned reader.
+func (c *Client) ExportAuditLogs(ctx context.Context, req ExportAuditLogsRequest) (io.ReadCloser, error) {
+	res, err := c.Request(ctx, http.MethodGet, "/api/v2/audit/export", nil, func(r *http.Request) {
+		q := r.URL.Query()
+		q.Set("format", string(req.Format))
+		if req.SearchQuery != "" {
+			q.Set("q", req.SearchQuery)
+		}
+		r.URL.RawQuery = q.Encode()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		return nil, ReadBodyAsError(res)
+	}
+	return res.Body, nil
+}
+
 // CreateTestAuditLog creates a fake audit log. Only owners of the organization
 // can perform this action. It's used for testing purposes.
 func (c *Client) CreateTestAuditLog(ctx context.Context, req CreateTestAuditLogRequest) error {
@@ -3,6 +3,7 @@ package codersdk
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 )
 
@@ -70,3 +71,100 @@ func (c *Client) AuthCheck(ctx context.Context, req AuthorizationRequest) (Autho
 	var resp AuthorizationResponse
 	return resp, json.NewDecoder(res.Body).Decode(&resp)
 }
+
+// AuthorizationExplainRequest asks how the authorization policy decides
+// whether a user can perform an action on an object.
+type AuthorizationExplainRequest struct {
+	// Object is resolved from the database if a resource ID of a workspace,
+	// template, user or group is given.
+	Object AuthorizationObject `json:"object"`
+	Action RBACAction          `json:"action"`
+	// Scope is the API key scope to evaluate the request with. Defaults to
+	// "all".
+	Scope APIKeyScope `json:"scope,omitempty" enums:"all,application_connect"`
+}
+
+// AuthorizationDecision is the outcome of the policy for a single level of
+// roles.
+type AuthorizationDecision string
+
+const (
+	AuthorizationDecisionAllow   AuthorizationDecision = "allow"
+	AuthorizationDecisionDeny    AuthorizationDecision = "deny"
+	AuthorizationDecisionAbstain AuthorizationDecision = "abstain"
+)
+
+// AuthorizationLevelDecisions are the decisions of the policy for the site,
+// organization and user level permissions. A deny at a level takes precedence
+// over the levels below it.
+type AuthorizationLevelDecisions struct {
+	Site         AuthorizationDecision `json:"site" enums:"allow,deny,abstain"`
+	Organization AuthorizationDecision `json:"organization" enums:"allow,deny,abstain"`
+	User         AuthorizationDecision `json:"user" enums:"allow,deny,abstain"`
+}
+
+// AuthorizationExplanation describes how the authorization policy came to its
+// decision.
+type AuthorizationExplanation struct {
+	// Object is the object the request was evaluated against.
+	Object AuthorizationObject `json:"object"`
+	// SubjectRoles are the roles of the user that were evaluated, including
+	// roles granted by organization membership.
+	SubjectRoles  []string `json:"subject_roles"`
+	SubjectGroups []string `json:"subject_groups" format:"uuid"`
+	Scope         string   `json:"scope"`
+
+	Allowed bool `json:"allowed"`
+	// RoleAllowed is true if the roles of the user allow the request.
+	RoleAllowed bool `json:"role_allowed"`
+	// ACLAllowed is true if the user or group ACL of the object allows the
+	// request.
+	ACLAllowed bool `json:"acl_allowed"`
+	// ScopeAllowed is true if the scope allows the request. Requests are only
+	// allowed if both the scope and either the roles or the ACL allow it.
+	ScopeAllowed bool `json:"scope_allowed"`
+	// OrganizationMember is true if the object belongs to an organization the
+	// user is a member of.
+	OrganizationMember bool `json:"organization_member"`
+
+	RoleDecisions  AuthorizationLevelDecisions `json:"role_decisions"`
+	ScopeDecisions AuthorizationLevelDecisions `json:"scope_decisions"`
+	// Permissions are the permissions of the roles and scope that match the
+	// action and object.
+	Permissions []AuthorizationExplainedPermission `json:"permissions"`
+	// ACL are the entries of the object's ACL that apply to the user and
+	// include the action.
+	ACL []AuthorizationExplainedACLEntry `json:"acl"`
+}
+
+type AuthorizationExplainedPermission struct {
+	Role string `json:"role"`
+	// Scope is true if the permission belongs to the scope rather than one
+	// of the roles.
+	Scope        bool         `json:"scope"`
+	Level        string       `json:"level" enums:"site,org,user"`
+	Negate       bool         `json:"negate"`
+	ResourceType RBACResource `json:"resource_type"`
+	Action       RBACAction   `json:"action"`
+}
+
+type AuthorizationExplainedACLEntry struct {
+	UserID  string       `json:"user_id,omitempty" format:"uuid"`
+	GroupID string       `json:"group_id,omitempty" format:"uuid"`
+	Actions []RBACAction `json:"actions"`
+}
+
+// ExplainAuthorization explains how the authorization policy decides whether
+// the given user can perform the action.
+func (c *Client) ExplainAuthorization(ctx context.Context, user string, req AuthorizationExplainRequest) (AuthorizationExplanation, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/debug/%s/authz-explain", user), req)
+	if err != nil {
+		return AuthorizationExplanation{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return AuthorizationExplanation{}, ReadBodyAsError(res)
+	}
+	var resp AuthorizationExplanation
+	return resp, json.NewDecoder(res.Body).Decode(&resp)
+}
@@ -342,6 +342,8 @@ type DeploymentValues struct {
 	PostgresAuth                    string                               `json:"pg_auth,omitempty" typescript:",notnull"`
 	OAuth2                          OAuth2Config                         `json:"oauth2,omitempty" typescript:",notnull"`
 	OIDC                            OIDCConfig                           `json:"oidc,omitempty" typescript:",notnull"`
+	LDAP                            LDAPConfig                           `json:"ldap,omitempty" typescript:",notnull"`
+	PasswordPolicy                  PasswordPolicyConfig                 `json:"password_policy,omitempty" typescript:",notnull"`
 	Telemetry                       TelemetryConfig                      `json:"telemetry,omitempty" typescript:",notnull"`
 	TLS                             TLSConfig                            `json:"tls,omitempty" typescript:",notnull"`
 	Trace                           TraceConfig                          `json:"trace,omitempty" typescript:",notnull"`
@@ -365,6 +367,7 @@ type DeploymentValues struct {
 	DisablePathApps                 serpent.Bool                         `json:"disable_path_apps,omitempty" typescript:",notnull"`
 	Sessions                        SessionLifetime                      `json:"session_lifetime,omitempty" typescript:",notnull"`
 	DisablePasswordAuth             serpent.Bool                         `json:"disable_password_auth,omitempty" typescript:",notnull"`
+	EnforceMFA                      serpent.Bool                         `json:"enforce_mfa,omitempty" typescript:",notnull"`
 	Support                         SupportConfig                        `json:"support,omitempty" typescript:",notnull"`
 	ExternalAuthConfigs             serpent.Struct[[]ExternalAuthConfig] `json:"external_auth,omitempty" typescript:",notnull"`
 	SSHConfig                       SSHConfig                            `json:"config_ssh,omitempty" typescript:",notnull"`
@@ -379,6 +382,7 @@ type DeploymentValues struct {
 	CLIUpgradeMessage               serpent.String                       `json:"cli_upgrade_message,omitempty" typescript:",notnull"`
 	TermsOfServiceURL               serpent.String                       `json:"terms_of_service_url,omitempty" typescript:",notnull"`
 	Notifications                   NotificationsConfig                  `json:"notifications,omitempty" typescript:",notnull"`
+	AuditLogging                    AuditLoggingConfig                   `json:"audit_logging,omitempty" typescript:",notnull"`
 
 	Config      serpent.YAMLConfigPath `json:"config,omitempty" typescript:",notnull"`
 	WriteConfig serpent.Bool           `json:"write_config,omitempty" typescript:",notnull"`
@@ -526,6 +530,39 @@ type OIDCConfig struct {
 	SkipIssuerChecks    serpent.Bool                        `json:"skip_issuer_checks" typescript:",notnull"`
 }
 
+type LDAPConfig struct {
+	URL                serpent.String                      `json:"url" typescript:",notnull"`
+	StartTLS           serpent.Bool                        `json:"start_tls" typescript:",notnull"`
+	CAFile             serpent.String                      `json:"ca_file" typescript:",notnull"`
+	BindDN             serpent.String                      `json:"bind_dn" typescript:",notnull"`
+	BindPassword       serpent.String                      `json:"bind_password" typescript:",notnull"`
+	UserSearchBaseDN   serpent.String                      `json:"user_search_base_dn" typescript:",notnull"`
+	UserSearchFilter   serpent.String                      `json:"user_search_filter" typescript:",notnull"`
+	UsernameAttribute  serpent.String                      `json:"username_attribute" typescript:",notnull"`
+	EmailAttribute     serpent.String                      `json:"email_attribute" typescript:",notnull"`
+	NameAttribute      serpent.String                      `json:"name_attribute" typescript:",notnull"`
+	GroupAttribute     serpent.String                      `json:"group_attribute" typescript:",notnull"`
+	GroupNameAttribute serpent.String                      `json:"group_name_attribute" typescript:",notnull"`
+	GroupMapping       serpent.Struct[map[string]string]   `json:"group_mapping" typescript:",notnull"`
+	GroupAutoCreate    serpent.Bool                        `json:"group_auto_create" typescript:",notnull"`
+	GroupRegexFilter   serpent.Regexp                      `json:"group_regex_filter" typescript:",notnull"`
+	GroupAllowList     serpent.StringArray                 `json:"group_allow_list" typescript:",notnull"`
+	UserRoleMapping    serpent.Struct[map[string][]string] `json:"user_role_mapping" typescript:",notnull"`
+	UserRolesDefault   serpent.StringArray                 `json:"user_roles_default" typescript:",notnull"`
+	AllowSignups       serpent.Bool                        `json:"allow_signups" typescript:",notnull"`
+	SignInText         serpent.String                      `json:"sign_in_text" typescript:",notnull"`
+}
+
+// PasswordPolicyConfig configures the requirements for the passwords of users
+// who log in with a password. A zero value disables each requirement.
+type PasswordPolicyConfig struct {
+	MinLength        serpent.Int64    `json:"min_length" typescript:",notnull"`
+	History          serpent.Int64    `json:"history" typescript:",notnull"`
+	MaxAge           serpent.Duration `json:"max_age" typescript:",notnull"`
+	LockoutThreshold serpent.Int64    `json:"lockout_threshold" typescript:",notnull"`
+	LockoutDuration  serpent.Duration `json:"lockout_duration" typescript:",notnull"`
+}
+
 type TelemetryConfig struct {
 	Enable serpent.Bool `json:"enable" typescript:",notnull"`
 	Trace  serpent.Bool `json:"trace" typescript:",notnull"`
@@ -659,7 +696,7 @@ type NotificationsConfig struct {
 	// How often to query the database for queued notifications.
 	FetchInterval serpent.Duration `json:"fetch_interval"`
 
-	// Which delivery method to use (available options: 'smtp', 'webhook').
+	// Which delivery method to use (available options: 'smtp', 'webhook', 'slack', 'teams', 'inbox').
 	Method serpent.String `json:"method"`
 	// How long to wait while a notification is being sent before giving up.
 	DispatchTimeout serpent.Duration `json:"dispatch_timeout"`
@@ -667,6 +704,10 @@ type NotificationsConfig struct {
 	SMTP NotificationsEmailConfig `json:"email" typescript:",notnull"`
 	// Webhook settings.
 	Webhook NotificationsWebhookConfig `json:"webhook" typescript:",notnull"`
+	// Slack settings.
+	Slack NotificationsSlackConfig `json:"slack" typescript:",notnull"`
+	// Microsoft Teams settings.
+	Teams NotificationsTeamsConfig `json:"teams" typescript:",notnull"`
 }
 
 type NotificationsEmailConfig struct {
@@ -722,6 +763,77 @@ func (c *NotificationsEmailTLSConfig) Empty() bool {
 type NotificationsWebhookConfig struct {
 	// The URL to which the payload will be sent with an HTTP POST request.
 	Endpoint serpent.URL `json:"endpoint" typescript:",notnull"`
+	// The secret with which each payload is signed. If set, an HMAC-SHA256 signature of the request's timestamp and
+	// body is sent so that receivers can verify that the payload originated from this deployment.
+	SigningSecret serpent.String `json:"signing_secret" typescript:",notnull"`
+	// Additional headers which will be sent with every request.
+	Headers serpent.Struct[map[string]string] `json:"headers" typescript:",notnull"`
+}
+
+type NotificationsSlackConfig struct {
+	// The Slack incoming webhook URL to which messages will be posted.
+	Endpoint serpent.URL `json:"endpoint" typescript:",notnull"`
+	// The Slack bot token used to post messages via the Web API; takes precedence over the incoming webhook.
+	BotToken serpent.String `json:"bot_token" typescript:",notnull"`
+	// The channel to which messages are posted when using a bot token. If unset, messages are sent as direct
+	// messages to the Slack user matching the recipient's email address.
+	Channel serpent.String `json:"channel" typescript:",notnull"`
+}
+
+type NotificationsTeamsConfig struct {
+	// The Microsoft Teams incoming webhook URL to which Adaptive Cards will be posted.
+	Endpoint serpent.URL `json:"endpoint" typescript:",notnull"`
+}
+
+// The backends to which audit logs can be streamed, in addition to the database.
+const (
+	AuditLoggingBackendSyslog = "syslog"
+	AuditLoggingBackendHTTP   = "http"
+	AuditLoggingBackendFile   = "file"
+)
+
+type AuditLoggingConfig struct {
+	// The backends to which audit logs are streamed, in addition to being stored in the database.
+	Backends serpent.StringArray `json:"backends" typescript:",notnull"`
+	// How long audit logs are kept in the database before they are deleted.
+	Retention serpent.Duration `json:"retention" typescript:",notnull"`
+	// Syslog settings.
+	Syslog AuditLoggingSyslogConfig `json:"syslog" typescript:",notnull"`
+	// HTTP settings.
+	HTTP AuditLoggingHTTPConfig `json:"http" typescript:",notnull"`
+	// File settings.
+	File AuditLoggingFileConfig `json:"file" typescript:",notnull"`
+}
+
+type AuditLoggingSyslogConfig struct {
+	// The syslog server (host:port) to which audit logs are sent over TCP.
+	Address serpent.String `json:"address" typescript:",notnull"`
+	// Whether to connect to the syslog server over TLS.
+	TLS serpent.Bool `json:"tls" typescript:",notnull"`
+	// The CA certificate file used to verify the syslog server; the system's are used if unset.
+	CAFile serpent.String `json:"ca_file" typescript:",notnull"`
+}
+
+type AuditLoggingHTTPConfig struct {
+	// The URL to which batches of audit logs are sent with an HTTP POST request.
+	Endpoint serpent.URL `json:"endpoint" typescript:",notnull"`
+	// Additional headers which will be sent with every request.
+	Headers serpent.Struct[map[string]string] `json:"headers" typescript:",notnull"`
+	// The largest number of audit logs which are sent in a single request.
+	BatchSize serpent.Int64 `json:"batch_size" typescript:",notnull"`
+	// How often audit logs are sent, regardless of whether a batch is full.
+	FlushInterval serpent.Duration `json:"flush_interval" typescript:",notnull"`
+	// The directory in which audit logs are kept until they are delivered.
+	SpoolDir serpent.String `json:"spool_dir" typescript:",notnull"`
+}
+
+type AuditLoggingFileConfig struct {
+	// The file to which audit logs are appended as JSON lines.
+	Path serpent.String `json:"path" typescript:",notnull"`
+	// The size, in megabytes, at which the file is rotated.
+	MaxSize serpent.Int64 `json:"max_size" typescript:",notnull"`
+	// The number of rotated files to retain.
+	MaxBackups serpent.Int64 `json:"max_backups" typescript:",notnull"`
 }
 
 const (
@@ -838,6 +950,16 @@ func (c *DeploymentValues) Options() serpent.OptionSet {
 			Name: "OIDC",
 			YAML: "oidc",
 		}
+		deploymentGroupLDAP = serpent.Group{
+			Name:        "LDAP",
+			Description: "Configure login and user-provisioning with an LDAP directory such as Active Directory.",
+			YAML:        "ldap",
+		}
+		deploymentGroupPasswordPolicy = serpent.Group{
+			Name:        "Password Policy",
+			Description: "Configure the requirements for passwords and the protection of password logins against brute-force attacks.",
+			YAML:        "passwordPolicy",
+		}
 		deploymentGroupTelemetry = serpent.Group{
 			Name: "Telemetry",
 			YAML: "telemetry",
@@ -897,6 +1019,41 @@ when required by your organization's security policy.`,
 			Parent: &deploymentGroupNotifications,
 			YAML:   "webhook",
 		}
+		deploymentGroupNotificationsSlack = serpent.Group{
+			Name:        "Slack",
+			Parent:      &deploymentGroupNotifications,
+			Description: "Configure how Slack notifications are sent.",
+			YAML:        "slack",
+		}
+		deploymentGroupNotificationsTeams = serpent.Group{
+			Name:        "Microsoft Teams",
+			Parent:      &deploymentGroupNotifications,
+			Description: "Configure how Microsoft Teams notifications are sent.",
+			YAML:        "teams",
+		}
+		deploymentGroupAuditLogging = serpent.Group{
+			Name:        "Audit Logging",
+			YAML:        "auditLogging",
+			Description: "Configure the backends to which audit logs are streamed.",
+		}
+		deploymentGroupAuditLoggingSyslog = serpent.Group{
+			Name:        "Syslog",
+			Parent:      &deploymentGroupAuditLogging,
+			Description: "Configure how audit logs are sent to a syslog server, formatted per RFC 5424.",
+			YAML:        "syslog",
+		}
+		deploymentGroupAuditLoggingHTTP = serpent.Group{
+			Name:        "HTTP",
+			Parent:      &deploymentGroupAuditLogging,
+			Description: "Configure how batches of audit logs are sent to an HTTP endpoint as JSON.",
+			YAML:        "http",
+		}
+		deploymentGroupAuditLoggingFile = serpent.Group{
+			Name:        "File",
+			Parent:      &deploymentGroupAuditLogging,
+			Description: "Configure how audit logs are written to a file as JSON lines.",
+			YAML:        "file",
+		}
 	)
 
 	httpAddress := serpent.Option{
@@ -1655,6 +1812,257 @@ when required by your organization's security policy.`,
 			Group: &deploymentGroupOIDC,
 			YAML:  "dangerousSkipIssuerChecks",
 		},
+		// LDAP settings.
+		{
+			Name:        "LDAP URL",
+			Description: "URL of the LDAP server to use for Login with LDAP, e.g. ldaps://ldap.example.com:636. Login with LDAP is disabled if this is not set.",
+			Flag:        "ldap-url",
+			Env:         "CODER_LDAP_URL",
+			Value:       &c.LDAP.URL,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "url",
+		},
+		{
+			Name:        "LDAP StartTLS",
+			Description: "Upgrade ldap:// connections to TLS with StartTLS before binding.",
+			Flag:        "ldap-start-tls",
+			Env:         "CODER_LDAP_START_TLS",
+			Default:     "false",
+			Value:       &c.LDAP.StartTLS,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "startTLS",
+		},
+		{
+			Name:        "LDAP CA File",
+			Description: "PEM-encoded CA certificates used to verify the certificate of the LDAP server. The system certificate pool is used if this is not set.",
+			Flag:        "ldap-ca-file",
+			Env:         "CODER_LDAP_CA_FILE",
+			Value:       &c.LDAP.CAFile,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "caFile",
+		},
+		{
+			Name:        "LDAP Bind DN",
+			Description: "DN of the account used to search for users. An anonymous bind is used if this is not set.",
+			Flag:        "ldap-bind-dn",
+			Env:         "CODER_LDAP_BIND_DN",
+			Value:       &c.LDAP.BindDN,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "bindDN",
+		},
+		{
+			Name:        "LDAP Bind Password",
+			Description: "Password of the account used to search for users.",
+			Flag:        "ldap-bind-password",
+			Env:         "CODER_LDAP_BIND_PASSWORD",
+			Annotations: serpent.Annotations{}.Mark(annotationSecretKey, "true"),
+			Value:       &c.LDAP.BindPassword,
+			Group:       &deploymentGroupLDAP,
+		},
+		{
+			Name:        "LDAP User Search Base DN",
+			Description: "DN of the subtree that is searched for users.",
+			Flag:        "ldap-user-search-base-dn",
+			Env:         "CODER_LDAP_USER_SEARCH_BASE_DN",
+			Value:       &c.LDAP.UserSearchBaseDN,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "userSearchBaseDN",
+		},
+		{
+			Name:        "LDAP User Search Filter",
+			Description: "Filter used to find the entry of the user logging in. {username} is replaced with the username they entered. Use (sAMAccountName={username}) for Active Directory.",
+			Flag:        "ldap-user-search-filter",
+			Env:         "CODER_LDAP_USER_SEARCH_FILTER",
+			Default:     "(uid={username})",
+			Value:       &c.LDAP.UserSearchFilter,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "userSearchFilter",
+		},
+		{
+			Name:        "LDAP Username Attribute",
+			Description: "LDAP attribute to use as the username.",
+			Flag:        "ldap-username-attribute",
+			Env:         "CODER_LDAP_USERNAME_ATTRIBUTE",
+			Default:     "uid",
+			Value:       &c.LDAP.UsernameAttribute,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "usernameAttribute",
+		},
+		{
+			Name:        "LDAP Email Attribute",
+			Description: "LDAP attribute to use as the email.",
+			Flag:        "ldap-email-attribute",
+			Env:         "CODER_LDAP_EMAIL_ATTRIBUTE",
+			Default:     "mail",
+			Value:       &c.LDAP.EmailAttribute,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "emailAttribute",
+		},
+		{
+			Name:        "LDAP Name Attribute",
+			Description: "LDAP attribute to use as the name.",
+			Flag:        "ldap-name-attribute",
+			Env:         "CODER_LDAP_NAME_ATTRIBUTE",
+			Default:     "cn",
+			Value:       &c.LDAP.NameAttribute,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "nameAttribute",
+		},
+		{
+			Name:        "LDAP Group Attribute",
+			Description: "This field must be set if using the group sync feature. Set to the attribute of the user entry that lists the DNs of their groups, e.g. memberOf. Groups are named by their normalized DN unless --ldap-group-name-attribute is set.",
+			Flag:        "ldap-group-attribute",
+			Env:         "CODER_LDAP_GROUP_ATTRIBUTE",
+			// This value is intentionally blank. If this is empty, then LDAP
+			// group behavior is disabled.
+			Default: "",
+			Value:   &c.LDAP.GroupAttribute,
+			Group:   &deploymentGroupLDAP,
+			YAML:    "groupAttribute",
+		},
+		{
+			Name:        "LDAP Group Name Attribute",
+			Description: "Name groups by the value of the first component of their DN instead of the full DN when it is this attribute, e.g. cn. Only set this if these values are unique across the directory, as groups of the same name in other parts of the directory will match as well.",
+			Flag:        "ldap-group-name-attribute",
+			Env:         "CODER_LDAP_GROUP_NAME_ATTRIBUTE",
+			Default:     "",
+			Value:       &c.LDAP.GroupNameAttribute,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "groupNameAttribute",
+		},
+		{
+			Name:        "LDAP Group Mapping",
+			Description: "A map of LDAP group names and the group in Coder it should map to.",
+			Flag:        "ldap-group-mapping",
+			Env:         "CODER_LDAP_GROUP_MAPPING",
+			Default:     "{}",
+			Value:       &c.LDAP.GroupMapping,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "groupMapping",
+		},
+		{
+			Name:        "Enable LDAP Group Auto Create",
+			Description: "Automatically creates missing groups from a user's LDAP groups.",
+			Flag:        "ldap-group-auto-create",
+			Env:         "CODER_LDAP_GROUP_AUTO_CREATE",
+			Default:     "false",
+			Value:       &c.LDAP.GroupAutoCreate,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "enableGroupAutoCreate",
+		},
+		{
+			Name:        "LDAP Regex Group Filter",
+			Description: "If provided any group name not matching the regex is ignored. This filter is applied after the group mapping.",
+			Flag:        "ldap-group-regex-filter",
+			Env:         "CODER_LDAP_GROUP_REGEX_FILTER",
+			Default:     ".*",
+			Value:       &c.LDAP.GroupRegexFilter,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "groupRegexFilter",
+		},
+		{
+			Name:        "LDAP Allowed Groups",
+			Description: "If provided any group name not in the list will not be allowed to authenticate. This filter is applied after the group mapping and before the regex filter.",
+			Flag:        "ldap-allowed-groups",
+			Env:         "CODER_LDAP_ALLOWED_GROUPS",
+			Default:     "",
+			Value:       &c.LDAP.GroupAllowList,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "groupAllowed",
+		},
+		{
+			Name:        "LDAP User Role Mapping",
+			Description: "A map of LDAP group names and the roles in Coder they grant. Setting this enables the user roles sync feature, which requires the group attribute to be set.",
+			Flag:        "ldap-user-role-mapping",
+			Env:         "CODER_LDAP_USER_ROLE_MAPPING",
+			Default:     "{}",
+			Value:       &c.LDAP.UserRoleMapping,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "userRoleMapping",
+		},
+		{
+			Name:        "LDAP User Role Default",
+			Description: "If user role sync is enabled, these roles are always included for all authenticated users. The 'member' role is always assigned.",
+			Flag:        "ldap-user-role-default",
+			Env:         "CODER_LDAP_USER_ROLE_DEFAULT",
+			Default:     "",
+			Value:       &c.LDAP.UserRolesDefault,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "userRoleDefault",
+		},
+		{
+			Name:        "LDAP Allow Signups",
+			Description: "Whether new users can sign up with LDAP.",
+			Flag:        "ldap-allow-signups",
+			Env:         "CODER_LDAP_ALLOW_SIGNUPS",
+			Default:     "true",
+			Value:       &c.LDAP.AllowSignups,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "allowSignups",
+		},
+		{
+			Name:        "LDAP sign in text",
+			Description: "The text to show on the LDAP sign in button.",
+			Flag:        "ldap-sign-in-text",
+			Env:         "CODER_LDAP_SIGN_IN_TEXT",
+			Default:     "LDAP",
+			Value:       &c.LDAP.SignInText,
+			Group:       &deploymentGroupLDAP,
+			YAML:        "signInText",
+		},
+		// Password policy settings
+		{
+			Name:        "Password Minimum Length",
+			Description: "The minimum number of characters of new passwords. Passwords must also pass the built-in strength check and be no longer than 64 characters. 0 disables the minimum.",
+			Flag:        "password-min-length",
+			Env:         "CODER_PASSWORD_MIN_LENGTH",
+			Default:     "0",
+			Value:       &c.PasswordPolicy.MinLength,
+			Group:       &deploymentGroupPasswordPolicy,
+			YAML:        "minLength",
+		},
+		{
+			Name:        "Password History",
+			Description: "The number of previous passwords of a user that cannot be reused when changing the password. 0 allows reusing passwords.",
+			Flag:        "password-history",
+			Env:         "CODER_PASSWORD_HISTORY",
+			Default:     "0",
+			Value:       &c.PasswordPolicy.History,
+			Group:       &deploymentGroupPasswordPolicy,
+			YAML:        "history",
+		},
+		{
+			Name:        "Password Maximum Age",
+			Description: "How long a password can be used before the user has to change it. Sessions of users with an expired password can only be used to change the password. 0 disables expiry.",
+			Flag:        "password-max-age",
+			Env:         "CODER_PASSWORD_MAX_AGE",
+			Default:     "0",
+			Value:       &c.PasswordPolicy.MaxAge,
+			Group:       &deploymentGroupPasswordPolicy,
+			YAML:        "maxAge",
+			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
+		},
+		{
+			Name:        "Password Lockout Threshold",
+			Description: "The number of consecutive failed password or multi-factor authentication logins after which an account is locked for the lockout duration. Every further failed login locks it again until the user logs in successfully. 0 disables lockouts.",
+			Flag:        "password-lockout-threshold",
+			Env:         "CODER_PASSWORD_LOCKOUT_THRESHOLD",
+			Default:     "0",
+			Value:       &c.PasswordPolicy.LockoutThreshold,
+			Group:       &deploymentGroupPasswordPolicy,
+			YAML:        "lockoutThreshold",
+		},
+		{
+			Name:        "Password Lockout Duration",
+			Description: "How long an account is locked after too many failed logins. User admins can unlock it sooner by activating the user.",
+			Flag:        "password-lockout-duration",
+			Env:         "CODER_PASSWORD_LOCKOUT_DURATION",
+			Default:     (15 * time.Minute).String(),
+			Value:       &c.PasswordPolicy.LockoutDuration,
+			Group:       &deploymentGroupPasswordPolicy,
+			YAML:        "lockoutDuration",
+			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
+		},
 		// Telemetry settings
 		{
 			Name:        "Telemetry Enable",
@@ -2169,6 +2577,16 @@ when required by your organization's security policy.`,
 			Group: &deploymentGroupNetworkingHTTP,
 			YAML:  "disablePasswordAuth",
 		},
+		{
+			Name:        "Enforce Multi-Factor Authentication",
+			Description: "Require users who log in with a password to use multi-factor authentication. Sessions of users who have not enrolled yet can only be used to enroll.",
+			Flag:        "enforce-mfa",
+			Env:         "CODER_ENFORCE_MFA",
+
+			Value: &c.EnforceMFA,
+			Group: &deploymentGroupNetworkingHTTP,
+			YAML:  "enforceMFA",
+		},
 		{
 			Name:          "Config Path",
 			Description:   `Specify a YAML file to load configuration from.`,
@@ -2326,7 +2744,7 @@ Write out the current server config as YAML to stdout.`,
 		// Notifications Options
 		{
 			Name:        "Notifications: Method",
-			Description: "Which delivery method to use (available options: 'smtp', 'webhook').",
+			Description: "Which delivery method to use (available options: 'smtp', 'webhook', 'slack', 'teams', 'inbox').",
 			Flag:        "notifications-method",
 			Env:         "CODER_NOTIFICATIONS_METHOD",
 			Value:       &c.Notifications.Method,
@@ -2483,6 +2901,64 @@ Write out the current server config as YAML to stdout.`,
 			Group:       &deploymentGroupNotificationsWebhook,
 			YAML:        "endpoint",
 		},
+		{
+			Name: "Notifications: Webhook: Signing Secret",
+			Description: "The secret with which webhook payloads are signed. If set, each request includes an " +
+				"X-Coder-Timestamp header and an X-Coder-Signature header holding the hex-encoded HMAC-SHA256 of " +
+				"the timestamp, a period, and the request body.",
+			Flag:        "notifications-webhook-signing-secret",
+			Env:         "CODER_NOTIFICATIONS_WEBHOOK_SIGNING_SECRET",
+			Value:       &c.Notifications.Webhook.SigningSecret,
+			Group:       &deploymentGroupNotificationsWebhook,
+			Annotations: serpent.Annotations{}.Mark(annotationSecretKey, "true"),
+		},
+		{
+			Name:        "Notifications: Webhook: Headers",
+			Description: "Additional headers, as a JSON object, which are sent with every webhook request.",
+			Flag:        "notifications-webhook-headers",
+			Env:         "CODER_NOTIFICATIONS_WEBHOOK_HEADERS",
+			Default:     "{}",
+			Value:       &c.Notifications.Webhook.Headers,
+			Group:       &deploymentGroupNotificationsWebhook,
+			Annotations: serpent.Annotations{}.Mark(annotationSecretKey, "true"),
+		},
+		{
+			Name:        "Notifications: Slack: Endpoint",
+			Description: "The Slack incoming webhook URL to which messages will be posted.",
+			Flag:        "notifications-slack-endpoint",
+			Env:         "CODER_NOTIFICATIONS_SLACK_ENDPOINT",
+			Value:       &c.Notifications.Slack.Endpoint,
+			Group:       &deploymentGroupNotificationsSlack,
+			YAML:        "endpoint",
+		},
+		{
+			Name:        "Notifications: Slack: Bot Token",
+			Description: "The Slack bot token used to post messages via the Web API. Takes precedence over the incoming webhook endpoint.",
+			Flag:        "notifications-slack-bot-token",
+			Env:         "CODER_NOTIFICATIONS_SLACK_BOT_TOKEN",
+			Value:       &c.Notifications.Slack.BotToken,
+			Group:       &deploymentGroupNotificationsSlack,
+			Annotations: serpent.Annotations{}.Mark(annotationSecretKey, "true"),
+		},
+		{
+			Name: "Notifications: Slack: Channel",
+			Description: "The channel to which messages are posted when using a bot token. If unset, messages are sent as " +
+				"direct messages to the Slack user whose email address matches the recipient's.",
+			Flag:  "notifications-slack-channel",
+			Env:   "CODER_NOTIFICATIONS_SLACK_CHANNEL",
+			Value: &c.Notifications.Slack.Channel,
+			Group: &deploymentGroupNotificationsSlack,
+			YAML:  "channel",
+		},
+		{
+			Name:        "Notifications: Microsoft Teams: Endpoint",
+			Description: "The Microsoft Teams incoming webhook URL to which Adaptive Cards will be posted.",
+			Flag:        "notifications-teams-endpoint",
+			Env:         "CODER_NOTIFICATIONS_TEAMS_ENDPOINT",
+			Value:       &c.Notifications.Teams.Endpoint,
+			Group:       &deploymentGroupNotificationsTeams,
+			YAML:        "endpoint",
+		},
 		{
 			Name:        "Notifications: Max Send Attempts",
 			Description: "The upper limit of attempts to send a notification.",
@@ -2573,6 +3049,138 @@ Write out the current server config as YAML to stdout.`,
 			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
 			Hidden:      true, // Hidden because most operators should not need to modify this.
 		},
+		// Audit Logging Options
+		{
+			Name: "Audit Logging: Backends",
+			Description: "The backends to which audit logs are streamed, in addition to being stored in the database " +
+				"(available options: 'syslog', 'http', 'file').",
+			Flag:  "audit-logging-backends",
+			Env:   "CODER_AUDIT_LOGGING_BACKENDS",
+			Value: &c.AuditLogging.Backends,
+			Group: &deploymentGroupAuditLogging,
+			YAML:  "backends",
+		},
+		{
+			Name: "Audit Logging: Retention",
+			Description: "How long audit logs are kept in the database before they are deleted. " +
+				"If 0, audit logs are kept forever. Logs sent to streaming backends are not affected.",
+			Flag:        "audit-logging-retention",
+			Env:         "CODER_AUDIT_LOGGING_RETENTION",
+			Default:     "0",
+			Value:       &c.AuditLogging.Retention,
+			Group:       &deploymentGroupAuditLogging,
+			YAML:        "retention",
+			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
+		},
+		{
+			Name:        "Audit Logging: Syslog: Address",
+			Description: "The syslog server (host:port) to which audit logs are sent over TCP.",
+			Flag:        "audit-logging-syslog-address",
+			Env:         "CODER_AUDIT_LOGGING_SYSLOG_ADDRESS",
+			Value:       &c.AuditLogging.Syslog.Address,
+			Group:       &deploymentGroupAuditLoggingSyslog,
+			YAML:        "address",
+		},
+		{
+			Name:        "Audit Logging: Syslog: TLS",
+			Description: "Whether to connect to the syslog server over TLS.",
+			Flag:        "audit-logging-syslog-tls",
+			Env:         "CODER_AUDIT_LOGGING_SYSLOG_TLS",
+			Default:     "false",
+			Value:       &c.AuditLogging.Syslog.TLS,
+			Group:       &deploymentGroupAuditLoggingSyslog,
+			YAML:        "tls",
+		},
+		{
+			Name:        "Audit Logging: Syslog: CA File",
+			Description: "The CA certificate file used to verify the syslog server. If unset, the system's CA certificates are used.",
+			Flag:        "audit-logging-syslog-ca-file",
+			Env:         "CODER_AUDIT_LOGGING_SYSLOG_CA_FILE",
+			Value:       &c.AuditLogging.Syslog.CAFile,
+			Group:       &deploymentGroupAuditLoggingSyslog,
+			YAML:        "caFile",
+		},
+		{
+			Name:        "Audit Logging: HTTP: Endpoint",
+			Description: "The URL to which batches of audit logs are sent, as a JSON array, with an HTTP POST request.",
+			Flag:        "audit-logging-http-endpoint",
+			Env:         "CODER_AUDIT_LOGGING_HTTP_ENDPOINT",
+			Value:       &c.AuditLogging.HTTP.Endpoint,
+			Group:       &deploymentGroupAuditLoggingHTTP,
+			YAML:        "endpoint",
+		},
+		{
+			Name:        "Audit Logging: HTTP: Headers",
+			Description: "Additional headers, as a JSON object, which are sent with every request.",
+			Flag:        "audit-logging-http-headers",
+			Env:         "CODER_AUDIT_LOGGING_HTTP_HEADERS",
+			Default:     "{}",
+			Value:       &c.AuditLogging.HTTP.Headers,
+			Group:       &deploymentGroupAuditLoggingHTTP,
+			Annotations: serpent.Annotations{}.Mark(annotationSecretKey, "true"),
+		},
+		{
+			Name:        "Audit Logging: HTTP: Batch Size",
+			Description: "The largest number of audit logs which are sent in a single request.",
+			Flag:        "audit-logging-http-batch-size",
+			Env:         "CODER_AUDIT_LOGGING_HTTP_BATCH_SIZE",
+			Default:     "100",
+			Value:       &c.AuditLogging.HTTP.BatchSize,
+			Group:       &deploymentGroupAuditLoggingHTTP,
+			YAML:        "batchSize",
+		},
+		{
+			Name: "Audit Logging: HTTP: Flush Interval",
+			Description: "How often audit logs are sent, regardless of whether a batch is full. Batches which could not " +
+				"be delivered are retried at this interval.",
+			Flag:        "audit-logging-http-flush-interval",
+			Env:         "CODER_AUDIT_LOGGING_HTTP_FLUSH_INTERVAL",
+			Default:     (5 * time.Second).String(),
+			Value:       &c.AuditLogging.HTTP.FlushInterval,
+			Group:       &deploymentGroupAuditLoggingHTTP,
+			YAML:        "flushInterval",
+			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
+		},
+		{
+			Name: "Audit Logging: HTTP: Spool Directory",
+			Description: "The directory in which audit logs are kept until they are delivered, so that none are lost " +
+				"while the endpoint is unavailable or the server restarts. If unset, a directory within the cache " +
+				"directory is used.",
+			Flag:  "audit-logging-http-spool-dir",
+			Env:   "CODER_AUDIT_LOGGING_HTTP_SPOOL_DIR",
+			Value: &c.AuditLogging.HTTP.SpoolDir,
+			Group: &deploymentGroupAuditLoggingHTTP,
+			YAML:  "spoolDir",
+		},
+		{
+			Name:        "Audit Logging: File: Path",
+			Description: "The file to which audit logs are appended as JSON lines.",
+			Flag:        "audit-logging-file-path",
+			Env:         "CODER_AUDIT_LOGGING_FILE_PATH",
+			Value:       &c.AuditLogging.File.Path,
+			Group:       &deploymentGroupAuditLoggingFile,
+			YAML:        "path",
+		},
+		{
+			Name:        "Audit Logging: File: Max Size",
+			Description: "The size, in megabytes, at which the file is rotated.",
+			Flag:        "audit-logging-file-max-size",
+			Env:         "CODER_AUDIT_LOGGING_FILE_MAX_SIZE",
+			Default:     "100",
+			Value:       &c.AuditLogging.File.MaxSize,
+			Group:       &deploymentGroupAuditLoggingFile,
+			YAML:        "maxSize",
+		},
+		{
+			Name:        "Audit Logging: File: Max Backups",
+			Description: "The number of rotated files to retain. If 0, all rotated files are retained.",
+			Flag:        "audit-logging-file-max-backups",
+			Env:         "CODER_AUDIT_LOGGING_FILE_MAX_BACKUPS",
+			Default:     "10",
+			Value:       &c.AuditLogging.File.MaxBackups,
+			Group:       &deploymentGroupAuditLoggingFile,
+			YAML:        "maxBackups",
+		},
 	}
 
 	return opts
@@ -2627,6 +3235,8 @@ func (c *DeploymentValues) WithoutSecrets() (*DeploymentValues, error) {
 			if err != nil {
 				panic(err)
 			}
+		case *serpent.Struct[map[string]string]:
+			v.Value = nil
 		default:
 			return nil, xerrors.Errorf("unsupported type %T", v)
 		}
@@ -59,6 +59,9 @@ func TestDeploymentValues_HighlyConfigurable(t *testing.T) {
 		"OIDC Client Secret": {
 			yaml: true,
 		},
+		"LDAP Bind Password": {
+			yaml: true,
+		},
 		"Postgres Connection URL": {
 			yaml: true,
 		},
@@ -68,6 +71,18 @@ func TestDeploymentValues_HighlyConfigurable(t *testing.T) {
 		"External Token Encryption Keys": {
 			yaml: true,
 		},
+		"Notifications: Slack: Bot Token": {
+			yaml: true,
+		},
+		"Notifications: Webhook: Signing Secret": {
+			yaml: true,
+		},
+		"Notifications: Webhook: Headers": {
+			yaml: true,
+		},
+		"Audit Logging: HTTP: Headers": {
+			yaml: true,
+		},
 		"External Auth Providers": {
 			// Technically External Auth Providers can be provided through the env,
 			// but bypassing serpent. See cli.ReadExternalAuthProvidersFromEnv.
@@ -0,0 +1,115 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// UserMFAStatus describes the multi-factor authentication methods a user has
+// enrolled in.
+type UserMFAStatus struct {
+	TOTPEnabled            bool `json:"totp_enabled"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+// TOTPEnrollment is returned when a user begins enrolling in TOTP. The secret
+// must be added to an authenticator app, usually by scanning the provisioning
+// URI as a QR code, and confirmed with a code before it is enforced.
+type TOTPEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type VerifyTOTPRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// DisableTOTPRequest re-verifies users that disable their own TOTP, with
+// either a code from their authenticator app, a recovery code, or their
+// password. It is not required when an admin resets the enrollment of another
+// user.
+type DisableTOTPRequest struct {
+	Code     string `json:"code,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// TOTPRecoveryCodes are single-use codes that can be used in place of a TOTP
+// code if the user loses their authenticator. They are only shown once.
+type TOTPRecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// IsMFARequiredError returns true if the error is returned by a password login
+// for a user that has enrolled in multi-factor authentication but did not
+// provide a code.
+func IsMFARequiredError(err error) bool {
+	apiErr, ok := AsError(err)
+	if !ok || apiErr.StatusCode() != http.StatusUnauthorized {
+		return false
+	}
+	for _, v := range apiErr.Validations {
+		if v.Field == "mfa_code" {
+			return true
+		}
+	}
+	return false
+}
+
+// UserMFAStatus returns the multi-factor authentication status of a user.
+func (c *Client) UserMFAStatus(ctx context.Context, user string) (UserMFAStatus, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/mfa", user), nil)
+	if err != nil {
+		return UserMFAStatus{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return UserMFAStatus{}, ReadBodyAsError(res)
+	}
+	var status UserMFAStatus
+	return status, json.NewDecoder(res.Body).Decode(&status)
+}
+
+// EnrollTOTP generates a new TOTP secret for a user. Any unconfirmed secret is
+// replaced. Enrolling while TOTP is already enabled is not allowed.
+func (c *Client) EnrollTOTP(ctx context.Context, user string) (TOTPEnrollment, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/users/%s/mfa/totp", user), nil)
+	if err != nil {
+		return TOTPEnrollment{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return TOTPEnrollment{}, ReadBodyAsError(res)
+	}
+	var enrollment TOTPEnrollment
+	return enrollment, json.NewDecoder(res.Body).Decode(&enrollment)
+}
+
+// VerifyTOTP confirms a pending TOTP enrollment and returns the user's
+// recovery codes.
+func (c *Client) VerifyTOTP(ctx context.Context, user string, req VerifyTOTPRequest) (TOTPRecoveryCodes, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/users/%s/mfa/totp/verify", user), req)
+	if err != nil {
+		return TOTPRecoveryCodes{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return TOTPRecoveryCodes{}, ReadBodyAsError(res)
+	}
+	var codes TOTPRecoveryCodes
+	return codes, json.NewDecoder(res.Body).Decode(&codes)
+}
+
+// DisableTOTP removes the TOTP secret and recovery codes of a user.
+func (c *Client) DisableTOTP(ctx context.Context, user string, req DisableTOTPRequest) error {
+	res, err := c.Request(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/users/%s/mfa/totp", user), req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
@@ -3,7 +3,12 @@ package codersdk
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type NotificationsSettings struct {
@@ -38,3 +43,304 @@ func (c *Client) PutNotificationsSettings(ctx context.Context, settings Notifica
 	}
 	return nil
 }
+
+// NotificationTemplate is a template from which notification messages are created.
+type NotificationTemplate struct {
+	ID            uuid.UUID `json:"id" format:"uuid"`
+	Name          string    `json:"name"`
+	Group         string    `json:"group"`
+	TitleTemplate string    `json:"title_template"`
+	BodyTemplate  string    `json:"body_template"`
+	// Actions is the JSON-encoded template of the actions included in each notification. Actions cannot be changed.
+	Actions string `json:"actions"`
+	// Labels are the names of the labels which the title and body templates may reference, e.g. {{ .Labels.name }}.
+	Labels []string `json:"labels"`
+	// Customized is true if the title or body template has been changed from its default.
+	Customized bool `json:"customized"`
+	// DigestWindowSeconds is how long messages of this template are held before they are delivered to each user as a
+	// single digest. If zero, every message is delivered as soon as possible.
+	DigestWindowSeconds int32 `json:"digest_window_seconds"`
+}
+
+type UpdateNotificationTemplateRequest struct {
+	TitleTemplate string `json:"title_template" validate:"required"`
+	BodyTemplate  string `json:"body_template" validate:"required"`
+}
+
+// UpdateNotificationTemplateDigestRequest sets how long messages of a notification template are held before they are
+// delivered to each user as a single digest. Zero disables digests for the template.
+type UpdateNotificationTemplateDigestRequest struct {
+	DigestWindowSeconds int32 `json:"digest_window_seconds"`
+}
+
+// PreviewNotificationTemplateRequest renders the given title and body templates with example values. If either
+// template is empty, the notification template's current one is used instead.
+type PreviewNotificationTemplateRequest struct {
+	TitleTemplate string `json:"title_template,omitempty"`
+	BodyTemplate  string `json:"body_template,omitempty"`
+	// Labels override the example values of the notification template's labels.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type NotificationTemplatePreview struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// GetNotificationTemplates returns every notification template.
+func (c *Client) GetNotificationTemplates(ctx context.Context) ([]NotificationTemplate, error) {
+	res, err := c.Request(ctx, http.MethodGet, "/api/v2/notifications/templates", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var templates []NotificationTemplate
+	return templates, json.NewDecoder(res.Body).Decode(&templates)
+}
+
+// UpdateNotificationTemplate changes the title and body templates of the given notification template.
+func (c *Client) UpdateNotificationTemplate(ctx context.Context, id uuid.UUID, req UpdateNotificationTemplateRequest) (NotificationTemplate, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/notifications/templates/%s", id), req)
+	if err != nil {
+		return NotificationTemplate{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return NotificationTemplate{}, ReadBodyAsError(res)
+	}
+	var template NotificationTemplate
+	return template, json.NewDecoder(res.Body).Decode(&template)
+}
+
+// ResetNotificationTemplate restores the default title and body templates of the given notification template.
+func (c *Client) ResetNotificationTemplate(ctx context.Context, id uuid.UUID) (NotificationTemplate, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/notifications/templates/%s/reset", id), nil)
+	if err != nil {
+		return NotificationTemplate{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return NotificationTemplate{}, ReadBodyAsError(res)
+	}
+	var template NotificationTemplate
+	return template, json.NewDecoder(res.Body).Decode(&template)
+}
+
+// UpdateNotificationTemplateDigest changes how long messages of the given notification template are held before they are
+// delivered as a digest.
+func (c *Client) UpdateNotificationTemplateDigest(ctx context.Context, id uuid.UUID, req UpdateNotificationTemplateDigestRequest) (NotificationTemplate, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/notifications/templates/%s/digest", id), req)
+	if err != nil {
+		return NotificationTemplate{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return NotificationTemplate{}, ReadBodyAsError(res)
+	}
+	var template NotificationTemplate
+	return template, json.NewDecoder(res.Body).Decode(&template)
+}
+
+// PreviewNotificationTemplate renders the given notification template with example values.
+func (c *Client) PreviewNotificationTemplate(ctx context.Context, id uuid.UUID, req PreviewNotificationTemplateRequest) (NotificationTemplatePreview, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/notifications/templates/%s/preview", id), req)
+	if err != nil {
+		return NotificationTemplatePreview{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return NotificationTemplatePreview{}, ReadBodyAsError(res)
+	}
+	var preview NotificationTemplatePreview
+	return preview, json.NewDecoder(res.Body).Decode(&preview)
+}
+
+// NotificationPreference describes whether, and how, a user receives notifications of a given template.
+type NotificationPreference struct {
+	NotificationTemplateID   uuid.UUID `json:"notification_template_id" format:"uuid"`
+	NotificationTemplateName string    `json:"notification_template_name"`
+	Group                    string    `json:"group"`
+	Disabled                 bool      `json:"disabled"`
+	// Method is the preferred delivery method. If empty, the deployment's default method is used.
+	Method string `json:"method,omitempty"`
+	// UpdatedAt is unset if the user has never changed this preference.
+	UpdatedAt *time.Time `json:"updated_at,omitempty" format:"date-time"`
+}
+
+type UpdateUserNotificationPreferences struct {
+	Preferences []UpdateNotificationPreference `json:"preferences" validate:"required"`
+}
+
+type UpdateNotificationPreference struct {
+	NotificationTemplateID uuid.UUID `json:"notification_template_id" format:"uuid" validate:"required"`
+	Disabled               bool      `json:"disabled"`
+	// Method is the preferred delivery method. If empty, the deployment's default method is used.
+	Method string `json:"method,omitempty"`
+}
+
+// GetUserNotificationPreferences returns the user's preference for every notification template.
+func (c *Client) GetUserNotificationPreferences(ctx context.Context, user string) ([]NotificationPreference, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/notifications/preferences", user), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var prefs []NotificationPreference
+	return prefs, json.NewDecoder(res.Body).Decode(&prefs)
+}
+
+// UpdateUserNotificationPreferences updates the given preferences and returns the user's preference for every
+// notification template. Preferences for templates which are not included in the request are left unchanged.
+func (c *Client) UpdateUserNotificationPreferences(ctx context.Context, user string, req UpdateUserNotificationPreferences) ([]NotificationPreference, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/users/%s/notifications/preferences", user), req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var prefs []NotificationPreference
+	return prefs, json.NewDecoder(res.Body).Decode(&prefs)
+}
+
+// InboxNotification is a notification which was delivered to a user's in-app inbox.
+type InboxNotification struct {
+	ID        uuid.UUID                 `json:"id" format:"uuid"`
+	UserID    uuid.UUID                 `json:"user_id" format:"uuid"`
+	Title     string                    `json:"title"`
+	Content   string                    `json:"content"`
+	Actions   []InboxNotificationAction `json:"actions"`
+	ReadAt    *time.Time                `json:"read_at,omitempty" format:"date-time"`
+	CreatedAt time.Time                 `json:"created_at" format:"date-time"`
+}
+
+type InboxNotificationAction struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+type InboxNotificationsResponse struct {
+	Notifications []InboxNotification `json:"notifications"`
+	UnreadCount   int                 `json:"unread_count"`
+}
+
+// InboxNotificationsRequest filters the notifications returned by GetInboxNotifications.
+// @typescript-ignore InboxNotificationsRequest
+type InboxNotificationsRequest struct {
+	UnreadOnly bool
+	// StartingBefore, if set, only returns notifications older than the notification with the given ID.
+	StartingBefore uuid.UUID
+	Limit          int
+}
+
+type UpdateInboxNotificationReadStatusRequest struct {
+	IsRead bool `json:"is_read"`
+}
+
+// GetInboxNotifications returns the notifications in the user's inbox, newest first.
+func (c *Client) GetInboxNotifications(ctx context.Context, user string, req InboxNotificationsRequest) (InboxNotificationsResponse, error) {
+	opts := []RequestOption{
+		WithQueryParam("unread_only", strconv.FormatBool(req.UnreadOnly)),
+	}
+	if req.StartingBefore != uuid.Nil {
+		opts = append(opts, WithQueryParam("starting_before", req.StartingBefore.String()))
+	}
+	if req.Limit > 0 {
+		opts = append(opts, WithQueryParam("limit", strconv.Itoa(req.Limit)))
+	}
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/notifications/inbox", user), nil, opts...)
+	if err != nil {
+		return InboxNotificationsResponse{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return InboxNotificationsResponse{}, ReadBodyAsError(res)
+	}
+	var resp InboxNotificationsResponse
+	return resp, json.NewDecoder(res.Body).Decode(&resp)
+}
+
+// UpdateInboxNotificationReadStatus marks the given notification as read or unread.
+func (c *Client) UpdateInboxNotificationReadStatus(ctx context.Context, user string, id uuid.UUID, req UpdateInboxNotificationReadStatusRequest) (InboxNotification, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/users/%s/notifications/inbox/%s/read-status", user, id), req)
+	if err != nil {
+		return InboxNotification{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return InboxNotification{}, ReadBodyAsError(res)
+	}
+	var notif InboxNotification
+	return notif, json.NewDecoder(res.Body).Decode(&notif)
+}
+
+// MarkAllInboxNotificationsAsRead marks every unread notification in the user's inbox as read.
+func (c *Client) MarkAllInboxNotificationsAsRead(ctx context.Context, user string) error {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/users/%s/notifications/inbox/mark-all-as-read", user), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
+// WatchInboxNotifications streams notifications as they are delivered to the user's inbox.
+// The returned channel is closed when the context is canceled or the connection is lost.
+func (c *Client) WatchInboxNotifications(ctx context.Context, user string) (<-chan InboxNotification, error) {
+	//nolint:bodyclose
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/notifications/inbox/watch", user), nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	nextEvent := ServerSentEventReader(ctx, res.Body)
+
+	nc := make(chan InboxNotification, 256)
+	go func() {
+		defer close(nc)
+		defer res.Body.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				sse, err := nextEvent()
+				if err != nil {
+					return
+				}
+				if sse.Type != ServerSentEventTypeData {
+					continue
+				}
+				var notif InboxNotification
+				b, ok := sse.Data.([]byte)
+				if !ok {
+					return
+				}
+				err = json.Unmarshal(b, &notif)
+				if err != nil {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case nc <- notif:
+				}
+			}
+		}
+	}()
+
+	return nc, nil
+}
@@ -14,6 +14,9 @@ type OAuth2ProviderApp struct {
 	Name        string    `json:"name"`
 	CallbackURL string    `json:"callback_url"`
 	Icon        string    `json:"icon"`
+	// ServiceAccountID is the service account the app acts as when using the
+	// client credentials grant.
+	ServiceAccountID *uuid.UUID `json:"service_account_id,omitempty" format:"uuid"`
 
 	// Endpoints are included in the app response for easier discovery. The OAuth2
 	// spec does not have a defined place to find these (for comparison, OIDC has
@@ -73,6 +76,9 @@ type PostOAuth2ProviderAppRequest struct {
 	Name        string `json:"name" validate:"required,oauth2_app_name"`
 	CallbackURL string `json:"callback_url" validate:"required,http_url"`
 	Icon        string `json:"icon" validate:"omitempty"`
+	// ServiceAccountID enables the client credentials grant for the app. Tokens
+	// issued with the grant act as this service account.
+	ServiceAccountID *uuid.UUID `json:"service_account_id,omitempty" format:"uuid"`
 }
 
 // PostOAuth2ProviderApp adds an application that can authenticate using Coder
@@ -94,6 +100,9 @@ type PutOAuth2ProviderAppRequest struct {
 	Name        string `json:"name" validate:"required,oauth2_app_name"`
 	CallbackURL string `json:"callback_url" validate:"required,http_url"`
 	Icon        string `json:"icon" validate:"omitempty"`
+	// ServiceAccountID enables the client credentials grant for the app. Tokens
+	// issued with the grant act as this service account.
+	ServiceAccountID *uuid.UUID `json:"service_account_id,omitempty" format:"uuid"`
 }
 
 // PutOAuth2ProviderApp updates an application that can authenticate using Coder
@@ -185,11 +194,14 @@ type OAuth2ProviderGrantType string
 const (
 	OAuth2ProviderGrantTypeAuthorizationCode OAuth2ProviderGrantType = "authorization_code"
 	OAuth2ProviderGrantTypeRefreshToken      OAuth2ProviderGrantType = "refresh_token"
+	OAuth2ProviderGrantTypeClientCredentials OAuth2ProviderGrantType = "client_credentials"
+	OAuth2ProviderGrantTypeDeviceCode        OAuth2ProviderGrantType = "urn:ietf:params:oauth:grant-type:device_code"
 )
 
 func (e OAuth2ProviderGrantType) Valid() bool {
 	switch e {
-	case OAuth2ProviderGrantTypeAuthorizationCode, OAuth2ProviderGrantTypeRefreshToken:
+	case OAuth2ProviderGrantTypeAuthorizationCode, OAuth2ProviderGrantTypeRefreshToken,
+		OAuth2ProviderGrantTypeClientCredentials, OAuth2ProviderGrantTypeDeviceCode:
 		return true
 	}
 	return false
@@ -210,6 +222,78 @@ func (e OAuth2ProviderResponseType) Valid() bool {
 	return false
 }
 
+// OAuth2PKCECodeChallengeMethod is the method used to derive a PKCE code
+// challenge from the code verifier (RFC 7636). Only S256 is accepted since
+// "plain" does not protect against intercepted authorization requests.
+type OAuth2PKCECodeChallengeMethod string
+
+const (
+	OAuth2PKCECodeChallengeMethodS256 OAuth2PKCECodeChallengeMethod = "S256"
+)
+
+func (e OAuth2PKCECodeChallengeMethod) Valid() bool {
+	//nolint:gocritic,revive // More cases might be added later.
+	switch e {
+	case OAuth2PKCECodeChallengeMethodS256:
+		return true
+	}
+	return false
+}
+
+// OAuth2DeviceAuthorizationResponse is returned when a device starts the
+// device authorization flow (RFC 8628).
+type OAuth2DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	// ExpiresIn is the lifetime of the device and user codes in seconds.
+	ExpiresIn int64 `json:"expires_in"`
+	// Interval is the minimum number of seconds the device should wait
+	// between token requests.
+	Interval int64 `json:"interval"`
+}
+
+// OAuth2TokenIntrospection describes an access token (RFC 7662). Only Active
+// is set for tokens that are invalid, expired, or belong to another app.
+type OAuth2TokenIntrospection struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	Exp       int64  `json:"exp,omitempty"`
+	Iat       int64  `json:"iat,omitempty"`
+	Sub       string `json:"sub,omitempty"`
+}
+
+// OAuth2UserInfo contains the standard OpenID Connect claims of the user that
+// owns an access token.
+type OAuth2UserInfo struct {
+	Sub               string `json:"sub"`
+	Name              string `json:"name,omitempty"`
+	PreferredUsername string `json:"preferred_username"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Picture           string `json:"picture,omitempty"`
+}
+
+// OAuth2ProviderMetadata is the RFC 8414 authorization server metadata served
+// at /.well-known/oauth-authorization-server. Coder does not issue ID tokens,
+// so clients have to use the userinfo endpoint to learn about the user.
+type OAuth2ProviderMetadata struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint       string   `json:"device_authorization_endpoint"`
+	IntrospectionEndpoint             string   `json:"introspection_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+}
+
 // RevokeOAuth2ProviderApp completely revokes an app's access for the
 // authenticated user.
 func (c *Client) RevokeOAuth2ProviderApp(ctx context.Context, appID uuid.UUID) error {
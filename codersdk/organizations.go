@@ -107,6 +107,9 @@ type CreateTemplateVersionRequest struct {
 	ProvisionerTags map[string]string        `json:"tags"`
 
 	UserVariableValues []VariableValue `json:"user_variable_values,omitempty"`
+	// Presets are named sets of parameter values that users can pick when
+	// creating a workspace from the version.
+	Presets []TemplateVersionPresetRequest `json:"presets,omitempty"`
 }
 
 type VariableValue struct {
@@ -203,6 +206,9 @@ type CreateWorkspaceRequest struct {
 	// during the initial provision.
 	RichParameterValues []WorkspaceBuildParameter `json:"rich_parameter_values,omitempty"`
 	AutomaticUpdates    AutomaticUpdates          `json:"automatic_updates,omitempty"`
+	// TemplateVersionPresetID uses the parameter values of a preset of the
+	// template version for parameters that are not in RichParameterValues.
+	TemplateVersionPresetID uuid.UUID `json:"template_version_preset_id,omitempty" format:"uuid"`
 }
 
 func (c *Client) OrganizationByName(ctx context.Context, name string) (Organization, error) {
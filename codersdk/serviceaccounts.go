@@ -0,0 +1,110 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceAccount is a non-human user owned by an organization. Service
+// accounts cannot log in, do not count towards seats and can only
+// authenticate with scoped tokens.
+type ServiceAccount struct {
+	ID             uuid.UUID `json:"id" format:"uuid"`
+	Username       string    `json:"username"`
+	Name           string    `json:"name"`
+	OrganizationID uuid.UUID `json:"organization_id" format:"uuid"`
+	CreatedAt      time.Time `json:"created_at" format:"date-time"`
+}
+
+type CreateServiceAccountRequest struct {
+	Username string `json:"username" validate:"required,username"`
+	Name     string `json:"name" validate:"user_real_name"`
+}
+
+// CreateServiceAccount creates a service account in an organization.
+func (c *Client) CreateServiceAccount(ctx context.Context, organizationID uuid.UUID, req CreateServiceAccountRequest) (ServiceAccount, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/organizations/%s/service-accounts", organizationID), req)
+	if err != nil {
+		return ServiceAccount{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return ServiceAccount{}, ReadBodyAsError(res)
+	}
+	var account ServiceAccount
+	return account, json.NewDecoder(res.Body).Decode(&account)
+}
+
+// ServiceAccounts lists the service accounts of an organization.
+func (c *Client) ServiceAccounts(ctx context.Context, organizationID uuid.UUID) ([]ServiceAccount, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/organizations/%s/service-accounts", organizationID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var accounts []ServiceAccount
+	return accounts, json.NewDecoder(res.Body).Decode(&accounts)
+}
+
+// DeleteServiceAccount deletes a service account and all of its tokens.
+func (c *Client) DeleteServiceAccount(ctx context.Context, organizationID uuid.UUID, user string) error {
+	res, err := c.Request(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/organizations/%s/service-accounts/%s", organizationID, user), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
+// CreateServiceAccountToken creates a token for a service account. The
+// request must include at least one scope.
+func (c *Client) CreateServiceAccountToken(ctx context.Context, organizationID uuid.UUID, user string, req CreateTokenRequest) (GenerateAPIKeyResponse, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/organizations/%s/service-accounts/%s/tokens", organizationID, user), req)
+	if err != nil {
+		return GenerateAPIKeyResponse{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return GenerateAPIKeyResponse{}, ReadBodyAsError(res)
+	}
+	var apiKey GenerateAPIKeyResponse
+	return apiKey, json.NewDecoder(res.Body).Decode(&apiKey)
+}
+
+// ServiceAccountTokens lists the tokens of a service account.
+func (c *Client) ServiceAccountTokens(ctx context.Context, organizationID uuid.UUID, user string) ([]APIKey, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/organizations/%s/service-accounts/%s/tokens", organizationID, user), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var apiKeys []APIKey
+	return apiKeys, json.NewDecoder(res.Body).Decode(&apiKeys)
+}
+
+// DeleteServiceAccountToken deletes a token of a service account.
+func (c *Client) DeleteServiceAccountToken(ctx context.Context, organizationID uuid.UUID, user string, keyID string) error {
+	res, err := c.Request(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/organizations/%s/service-accounts/%s/tokens/%s", organizationID, user, keyID), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// UserSession is an API key created by logging in to the dashboard or the
+// CLI. Unlike tokens, sessions are not named and are revoked by logging out.
+type UserSession struct {
+	ID        string    `json:"id" validate:"required"`
+	CreatedAt time.Time `json:"created_at" validate:"required" format:"date-time"`
+	LastUsed  time.Time `json:"last_used" validate:"required" format:"date-time"`
+	ExpiresAt time.Time `json:"expires_at" validate:"required" format:"date-time"`
+	LoginType LoginType `json:"login_type" validate:"required" enums:"password,github,oidc,ldap"`
+	// IPAddress is the address the session was last used from.
+	IPAddress string `json:"ip_address"`
+	// UserAgent is the User-Agent of the client that created the session.
+	UserAgent string `json:"user_agent"`
+	// Current is true for the session that made the request.
+	Current bool `json:"current"`
+}
+
+// UserSessions lists the active sessions of a user, most recently used first.
+func (c *Client) UserSessions(ctx context.Context, user string) ([]UserSession, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/sessions", user), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var sessions []UserSession
+	return sessions, json.NewDecoder(res.Body).Decode(&sessions)
+}
+
+// RevokeUserSession logs out a single session of a user.
+func (c *Client) RevokeUserSession(ctx context.Context, user string, id string) error {
+	res, err := c.Request(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/users/%s/sessions/%s", user, id), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
+// RevokeUserSessions logs out every session of a user, including the one
+// making the request if it belongs to the user. Tokens are not revoked.
+func (c *Client) RevokeUserSessions(ctx context.Context, user string) error {
+	res, err := c.Request(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/users/%s/sessions", user), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
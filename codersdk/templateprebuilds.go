@@ -0,0 +1,70 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TemplatePrebuildPool is a set of workspaces that are built ahead of time
+// with the same parameters. Creating a workspace with those parameters claims
+// one of them instead of building a new one.
+type TemplatePrebuildPool struct {
+	ID               uuid.UUID                 `json:"id" format:"uuid"`
+	Name             string                    `json:"name"`
+	DesiredInstances int32                     `json:"desired_instances"`
+	Parameters       []WorkspaceBuildParameter `json:"parameters"`
+	// ReadyInstances is the number of prebuilt workspaces that can be
+	// claimed.
+	ReadyInstances int32 `json:"ready_instances"`
+	// StartingInstances is the number of prebuilt workspaces that are being
+	// built.
+	StartingInstances int32     `json:"starting_instances"`
+	CreatedAt         time.Time `json:"created_at" format:"date-time"`
+	UpdatedAt         time.Time `json:"updated_at" format:"date-time"`
+}
+
+type TemplatePrebuildPoolRequest struct {
+	Name             string                    `json:"name" validate:"required"`
+	DesiredInstances int32                     `json:"desired_instances" validate:"min=0"`
+	Parameters       []WorkspaceBuildParameter `json:"parameters"`
+}
+
+// UpdateTemplatePrebuildPoolsRequest replaces the prebuild pools of a
+// template. Pools are matched by name. Pools that are not in the request are
+// removed, along with their prebuilt workspaces.
+type UpdateTemplatePrebuildPoolsRequest struct {
+	Pools []TemplatePrebuildPoolRequest `json:"pools" validate:"dive"`
+}
+
+// TemplatePrebuildPools returns the prebuild pools of a template.
+func (c *Client) TemplatePrebuildPools(ctx context.Context, templateID uuid.UUID) ([]TemplatePrebuildPool, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/templates/%s/prebuilds", templateID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var pools []TemplatePrebuildPool
+	return pools, json.NewDecoder(res.Body).Decode(&pools)
+}
+
+// UpdateTemplatePrebuildPools replaces the prebuild pools of a template.
+func (c *Client) UpdateTemplatePrebuildPools(ctx context.Context, templateID uuid.UUID, req UpdateTemplatePrebuildPoolsRequest) ([]TemplatePrebuildPool, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/templates/%s/prebuilds", templateID), req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var pools []TemplatePrebuildPool
+	return pools, json.NewDecoder(res.Body).Decode(&pools)
+}
@@ -30,10 +30,27 @@ type TemplateVersion struct {
 	Readme         string         `json:"readme"`
 	CreatedBy      MinimalUser    `json:"created_by"`
 	Archived       bool           `json:"archived"`
+	// Presets are named sets of parameter values that can be used to create
+	// a workspace from the version.
+	Presets []TemplateVersionPreset `json:"presets"`
 
 	Warnings []TemplateVersionWarning `json:"warnings,omitempty" enums:"DEPRECATED_PARAMETERS"`
 }
 
+// TemplateVersionPreset is a named set of parameter values. Values of the
+// preset are validated like values provided by the user when a workspace is
+// built.
+type TemplateVersionPreset struct {
+	ID         uuid.UUID                 `json:"id" format:"uuid"`
+	Name       string                    `json:"name"`
+	Parameters []WorkspaceBuildParameter `json:"parameters"`
+}
+
+type TemplateVersionPresetRequest struct {
+	Name       string                    `json:"name" validate:"required"`
+	Parameters []WorkspaceBuildParameter `json:"parameters"`
+}
+
 type TemplateVersionExternalAuth struct {
 	ID              string `json:"id"`
 	Type            string `json:"type"`
@@ -93,6 +110,8 @@ type TemplateVersionVariable struct {
 type PatchTemplateVersionRequest struct {
 	Name    string  `json:"name" validate:"omitempty,template_version_name"`
 	Message *string `json:"message,omitempty" validate:"omitempty,lt=1048577"`
+	// Presets replaces the presets of the version if set.
+	Presets *[]TemplateVersionPresetRequest `json:"presets,omitempty"`
 }
 
 // TemplateVersion returns a template version by ID.
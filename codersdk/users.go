@@ -175,11 +175,30 @@ type UpdateUserQuietHoursScheduleRequest struct {
 
 type UpdateRoles struct {
 	Roles []string `json:"roles" validate:""`
+	// ExpiresAt grants the roles until this time, in addition to the roles
+	// the user already has. Roles that are already granted permanently are
+	// unaffected. Without it, all the roles are granted permanently.
+	ExpiresAt *time.Time `json:"expires_at,omitempty" format:"date-time"`
+	// Justification is recorded with roles granted until ExpiresAt.
+	Justification string `json:"justification,omitempty"`
 }
 
 type UserRoles struct {
 	Roles             []string               `json:"roles"`
 	OrganizationRoles map[uuid.UUID][]string `json:"organization_roles"`
+	// TemporaryRoles are the roles above that expire, after which they are
+	// removed.
+	TemporaryRoles []TemporaryRoleGrant `json:"temporary_roles"`
+}
+
+// TemporaryRoleGrant is a role that was granted to a user for a limited time.
+type TemporaryRoleGrant struct {
+	RoleName string `json:"role_name"`
+	// OrganizationID is set for organization roles.
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty" format:"uuid"`
+	Justification  string     `json:"justification"`
+	CreatedAt      time.Time  `json:"created_at" format:"date-time"`
+	ExpiresAt      time.Time  `json:"expires_at" format:"date-time"`
 }
 
 type ConvertLoginRequest struct {
@@ -192,11 +211,30 @@ type ConvertLoginRequest struct {
 type LoginWithPasswordRequest struct {
 	Email    string `json:"email" validate:"required,email" format:"email"`
 	Password string `json:"password" validate:"required"`
+	// MFACode is a code from the user's authenticator app or one of their
+	// recovery codes. It is required if the user has enrolled in
+	// multi-factor authentication.
+	MFACode string `json:"mfa_code,omitempty"`
+}
+
+// LoginWithLDAPRequest enables callers to authenticate with the username and
+// password of their account in the LDAP directory.
+type LoginWithLDAPRequest struct {
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
 }
 
 // LoginWithPasswordResponse contains a session token for the newly authenticated user.
 type LoginWithPasswordResponse struct {
 	SessionToken string `json:"session_token" validate:"required"`
+	// MFAEnrollmentRequired is true if the deployment enforces multi-factor
+	// authentication and the user has not enrolled yet. The session token
+	// can only be used to enroll until the user logs in again.
+	MFAEnrollmentRequired bool `json:"mfa_enrollment_required,omitempty"`
+	// PasswordChangeRequired is true if the password of the user is older
+	// than the maximum password age of the deployment. The session token can
+	// only be used to change the password.
+	PasswordChangeRequired bool `json:"password_change_required,omitempty"`
 }
 
 type OAuthConversionResponse struct {
@@ -212,12 +250,18 @@ type AuthMethods struct {
 	Password          AuthMethod     `json:"password"`
 	Github            AuthMethod     `json:"github"`
 	OIDC              OIDCAuthMethod `json:"oidc"`
+	LDAP              LDAPAuthMethod `json:"ldap"`
 }
 
 type AuthMethod struct {
 	Enabled bool `json:"enabled"`
 }
 
+type LDAPAuthMethod struct {
+	AuthMethod
+	SignInText string `json:"signInText"`
+}
+
 type UserLoginType struct {
 	LoginType LoginType `json:"login_type"`
 }
@@ -486,6 +530,25 @@ func (c *Client) LoginWithPassword(ctx context.Context, req LoginWithPasswordReq
 	return resp, nil
 }
 
+// LoginWithLDAP creates a session token authenticated by the LDAP directory
+// configured for the deployment.
+func (c *Client) LoginWithLDAP(ctx context.Context, req LoginWithLDAPRequest) (LoginWithPasswordResponse, error) {
+	res, err := c.Request(ctx, http.MethodPost, "/api/v2/users/ldap/login", req)
+	if err != nil {
+		return LoginWithPasswordResponse{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return LoginWithPasswordResponse{}, ReadBodyAsError(res)
+	}
+	var resp LoginWithPasswordResponse
+	err = json.NewDecoder(res.Body).Decode(&resp)
+	if err != nil {
+		return LoginWithPasswordResponse{}, err
+	}
+	return resp, nil
+}
+
 // ConvertLoginType will send a request to convert the user from password
 // based authentication to oauth based. The response has the oauth state code
 // to use in the oauth flow.
@@ -0,0 +1,124 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceBulkAction is the action a bulk job applies to every matched
+// workspace.
+type WorkspaceBulkAction string
+
+const (
+	WorkspaceBulkActionStart WorkspaceBulkAction = "start"
+	WorkspaceBulkActionStop  WorkspaceBulkAction = "stop"
+	// WorkspaceBulkActionUpdate starts the workspace with the active version
+	// of its template.
+	WorkspaceBulkActionUpdate           WorkspaceBulkAction = "update"
+	WorkspaceBulkActionDelete           WorkspaceBulkAction = "delete"
+	WorkspaceBulkActionAutomaticUpdates WorkspaceBulkAction = "automatic_updates"
+	WorkspaceBulkActionDormant          WorkspaceBulkAction = "dormant"
+)
+
+type WorkspaceBulkJobStatus string
+
+const (
+	WorkspaceBulkJobStatusRunning   WorkspaceBulkJobStatus = "running"
+	WorkspaceBulkJobStatusCompleted WorkspaceBulkJobStatus = "completed"
+)
+
+type WorkspaceBulkResultStatus string
+
+const (
+	WorkspaceBulkResultStatusPending   WorkspaceBulkResultStatus = "pending"
+	WorkspaceBulkResultStatusSucceeded WorkspaceBulkResultStatus = "succeeded"
+	WorkspaceBulkResultStatusFailed    WorkspaceBulkResultStatus = "failed"
+	// WorkspaceBulkResultStatusSkipped is used when the workspace is already
+	// in the requested state.
+	WorkspaceBulkResultStatusSkipped WorkspaceBulkResultStatus = "skipped"
+)
+
+// CreateWorkspaceBulkJobRequest applies an action to every workspace matched
+// by a workspace search query.
+type CreateWorkspaceBulkJobRequest struct {
+	Action WorkspaceBulkAction `json:"action" validate:"required"`
+	// Query is a workspace search query, in the same format as the workspace
+	// list endpoint.
+	Query string `json:"q"`
+	// AutomaticUpdates is the setting applied by the automatic_updates
+	// action.
+	AutomaticUpdates AutomaticUpdates `json:"automatic_updates,omitempty"`
+	// Dormant is applied by the dormant action. False makes dormant
+	// workspaces active again.
+	Dormant bool `json:"dormant,omitempty"`
+	// Concurrency is the maximum number of workspaces acted on at the same
+	// time. Defaults to 5.
+	Concurrency int `json:"concurrency,omitempty" validate:"min=0,max=50"`
+}
+
+// WorkspaceBulkJob is a bulk action running in the background, along with
+// the result of every matched workspace.
+type WorkspaceBulkJob struct {
+	ID               uuid.UUID                `json:"id" format:"uuid"`
+	InitiatorID      uuid.UUID                `json:"initiator_id" format:"uuid"`
+	Action           WorkspaceBulkAction      `json:"action"`
+	Query            string                   `json:"q"`
+	AutomaticUpdates AutomaticUpdates         `json:"automatic_updates,omitempty"`
+	Dormant          bool                     `json:"dormant"`
+	Concurrency      int                      `json:"concurrency"`
+	Status           WorkspaceBulkJobStatus   `json:"status"`
+	Total            int                      `json:"total"`
+	Succeeded        int                      `json:"succeeded"`
+	Failed           int                      `json:"failed"`
+	Skipped          int                      `json:"skipped"`
+	CreatedAt        time.Time                `json:"created_at" format:"date-time"`
+	CompletedAt      *time.Time               `json:"completed_at,omitempty" format:"date-time"`
+	Results          []WorkspaceBulkJobResult `json:"results"`
+}
+
+type WorkspaceBulkJobResult struct {
+	WorkspaceID        uuid.UUID                 `json:"workspace_id" format:"uuid"`
+	WorkspaceName      string                    `json:"workspace_name"`
+	WorkspaceOwnerName string                    `json:"workspace_owner_name"`
+	Status             WorkspaceBulkResultStatus `json:"status"`
+	Error              string                    `json:"error,omitempty"`
+	// BuildID is the workspace build started by the start, stop, update and
+	// delete actions.
+	BuildID   *uuid.UUID `json:"build_id,omitempty" format:"uuid"`
+	UpdatedAt time.Time  `json:"updated_at" format:"date-time"`
+}
+
+// CreateWorkspaceBulkJob starts a bulk action on the workspaces matched by a
+// search query. The job runs in the background, use WorkspaceBulkJob to track
+// its progress.
+func (c *Client) CreateWorkspaceBulkJob(ctx context.Context, req CreateWorkspaceBulkJobRequest) (WorkspaceBulkJob, error) {
+	res, err := c.Request(ctx, http.MethodPost, "/api/v2/workspaces/bulk", req)
+	if err != nil {
+		return WorkspaceBulkJob{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return WorkspaceBulkJob{}, ReadBodyAsError(res)
+	}
+	var job WorkspaceBulkJob
+	return job, json.NewDecoder(res.Body).Decode(&job)
+}
+
+// WorkspaceBulkJob returns a bulk job and the results of its workspaces.
+func (c *Client) WorkspaceBulkJob(ctx context.Context, id uuid.UUID) (WorkspaceBulkJob, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaces/bulk/%s", id), nil)
+	if err != nil {
+		return WorkspaceBulkJob{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WorkspaceBulkJob{}, ReadBodyAsError(res)
+	}
+	var job WorkspaceBulkJob
+	return job, json.NewDecoder(res.Body).Decode(&job)
+}
@@ -480,6 +480,98 @@ func (c *Client) UpdateWorkspaceAutomaticUpdates(ctx context.Context, id uuid.UU
 	return nil
 }
 
+// TransferWorkspaceRequest is a request to hand a workspace over to another
+// user of its organization.
+type TransferWorkspaceRequest struct {
+	OwnerID uuid.UUID `json:"owner_id" format:"uuid" validate:"required"`
+}
+
+// TransferWorkspace reassigns the workspace to a new owner. The returned build
+// applies the new owner to the resources of the workspace.
+func (c *Client) TransferWorkspace(ctx context.Context, id uuid.UUID, req TransferWorkspaceRequest) (WorkspaceBuild, error) {
+	path := fmt.Sprintf("/api/v2/workspaces/%s/transfer", id.String())
+	res, err := c.Request(ctx, http.MethodPost, path, req)
+	if err != nil {
+		return WorkspaceBuild{}, xerrors.Errorf("transfer workspace: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return WorkspaceBuild{}, ReadBodyAsError(res)
+	}
+	var build WorkspaceBuild
+	return build, json.NewDecoder(res.Body).Decode(&build)
+}
+
+// WorkspaceRole is the access level a workspace is shared with.
+type WorkspaceRole string
+
+const (
+	// WorkspaceRoleAdmin can additionally start, stop and update the workspace,
+	// including its name, schedule and other settings.
+	WorkspaceRoleAdmin WorkspaceRole = "admin"
+	// WorkspaceRoleUse can connect to the workspace with SSH, apps and the
+	// web terminal.
+	WorkspaceRoleUse     WorkspaceRole = "use"
+	WorkspaceRoleDeleted WorkspaceRole = ""
+)
+
+// WorkspaceACL lists the users and groups a workspace is shared with.
+type WorkspaceACL struct {
+	Users  []WorkspaceUser  `json:"users"`
+	Groups []WorkspaceGroup `json:"groups"`
+}
+
+type WorkspaceUser struct {
+	MinimalUser
+	Role WorkspaceRole `json:"role" enums:"admin,use"`
+}
+
+type WorkspaceGroup struct {
+	ID          uuid.UUID     `json:"id" format:"uuid"`
+	Name        string        `json:"name"`
+	DisplayName string        `json:"display_name"`
+	Role        WorkspaceRole `json:"role" enums:"admin,use"`
+}
+
+type UpdateWorkspaceACL struct {
+	// UserPerms should be a mapping of user id to role. The user id must be the
+	// uuid of the user, not a username or email address. An empty role removes
+	// the user from the ACL.
+	UserPerms map[string]WorkspaceRole `json:"user_perms,omitempty" example:"<user_id>:admin,4df59e74-c027-470b-ab4d-cbba8963a5e9:use"`
+	// GroupPerms should be a mapping of group id to role.
+	GroupPerms map[string]WorkspaceRole `json:"group_perms,omitempty" example:"<group_id>:admin,8bd26b20-f3e8-48be-a903-46bb920cf671:use"`
+}
+
+// WorkspaceACL returns the users and groups the workspace is shared with.
+func (c *Client) WorkspaceACL(ctx context.Context, id uuid.UUID) (WorkspaceACL, error) {
+	path := fmt.Sprintf("/api/v2/workspaces/%s/acl", id.String())
+	res, err := c.Request(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return WorkspaceACL{}, xerrors.Errorf("get workspace acl: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WorkspaceACL{}, ReadBodyAsError(res)
+	}
+	var acl WorkspaceACL
+	return acl, json.NewDecoder(res.Body).Decode(&acl)
+}
+
+// UpdateWorkspaceACL shares the workspace with, or revokes access from, the
+// given users and groups.
+func (c *Client) UpdateWorkspaceACL(ctx context.Context, id uuid.UUID, req UpdateWorkspaceACL) error {
+	path := fmt.Sprintf("/api/v2/workspaces/%s/acl", id.String())
+	res, err := c.Request(ctx, http.MethodPatch, path, req)
+	if err != nil {
+		return xerrors.Errorf("update workspace acl: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
 type WorkspaceFilter struct {
 	// Owner can be "me" or a username
 	Owner string `json:"owner,omitempty" typescript:"-"`
@@ -0,0 +1,66 @@
+package backends
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/enterprise/audit"
+)
+
+// Event is the JSON representation of an audit log which is sent to the
+// syslog, HTTP and file backends.
+type Event struct {
+	ID               uuid.UUID             `json:"id"`
+	Time             time.Time             `json:"time"`
+	UserID           uuid.UUID             `json:"user_id"`
+	OrganizationID   uuid.UUID             `json:"organization_id"`
+	IP               string                `json:"ip,omitempty"`
+	UserAgent        string                `json:"user_agent,omitempty"`
+	ResourceType     database.ResourceType `json:"resource_type"`
+	ResourceID       uuid.UUID             `json:"resource_id"`
+	ResourceTarget   string                `json:"resource_target"`
+	ResourceIcon     string                `json:"resource_icon,omitempty"`
+	Action           database.AuditAction  `json:"action"`
+	Diff             json.RawMessage       `json:"diff,omitempty"`
+	StatusCode       int32                 `json:"status_code"`
+	AdditionalFields json.RawMessage       `json:"additional_fields,omitempty"`
+	RequestID        uuid.UUID             `json:"request_id"`
+	Actor            *audit.Actor          `json:"actor,omitempty"`
+}
+
+func newEvent(alog database.AuditLog, details audit.BackendDetails) Event {
+	e := Event{
+		ID:             alog.ID,
+		Time:           alog.Time.UTC(),
+		UserID:         alog.UserID,
+		OrganizationID: alog.OrganizationID,
+		UserAgent:      alog.UserAgent.String,
+		ResourceType:   alog.ResourceType,
+		ResourceID:     alog.ResourceID,
+		ResourceTarget: alog.ResourceTarget,
+		ResourceIcon:   alog.ResourceIcon,
+		Action:         alog.Action,
+		StatusCode:     alog.StatusCode,
+		RequestID:      alog.RequestID,
+		Actor:          details.Actor,
+	}
+	if alog.Ip.Valid {
+		e.IP = alog.Ip.IPNet.IP.String()
+	}
+	// Invalid JSON would fail to marshal the whole event, so it is omitted.
+	if json.Valid(alog.Diff) {
+		e.Diff = alog.Diff
+	}
+	if json.Valid(alog.AdditionalFields) {
+		e.AdditionalFields = alog.AdditionalFields
+	}
+	return e
+}
+
+// marshalEvent returns the JSON representation of the given audit log.
+func marshalEvent(alog database.AuditLog, details audit.BackendDetails) ([]byte, error) {
+	return json.Marshal(newEvent(alog, details))
+}
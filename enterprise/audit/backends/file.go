@@ -0,0 +1,61 @@
+package backends
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/enterprise/audit"
+)
+
+// FileOptions configure the file backend.
+type FileOptions struct {
+	// Path is the file to which audit logs are appended.
+	Path string
+	// MaxSizeMB is the size, in megabytes, at which the file is rotated.
+	MaxSizeMB int
+	// MaxBackups is the number of rotated files to retain. If 0, all rotated
+	// files are retained.
+	MaxBackups int
+}
+
+// FileBackend appends audit logs to a file as JSON lines. Once the file
+// reaches its maximum size, it is renamed with a timestamp and a new file is
+// started in its place.
+type FileBackend struct {
+	w *lumberjack.Logger
+}
+
+func NewFile(opts FileOptions) *FileBackend {
+	return &FileBackend{w: &lumberjack.Logger{
+		Filename:   opts.Path,
+		MaxSize:    opts.MaxSizeMB,
+		MaxBackups: opts.MaxBackups,
+	}}
+}
+
+func (*FileBackend) Decision() audit.FilterDecision {
+	return audit.FilterDecisionExport
+}
+
+func (b *FileBackend) Export(_ context.Context, alog database.AuditLog, details audit.BackendDetails) error {
+	line, err := marshalEvent(alog, details)
+	if err != nil {
+		return xerrors.Errorf("marshal audit log: %w", err)
+	}
+
+	// The line is written in a single call so that concurrent exports are not
+	// interleaved.
+	_, err = b.w.Write(append(line, '\n'))
+	if err != nil {
+		return xerrors.Errorf("write audit log: %w", err)
+	}
+	return nil
+}
+
+// Close closes the file.
+func (b *FileBackend) Close() error {
+	return b.w.Close()
+}
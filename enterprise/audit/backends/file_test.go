@@ -0,0 +1,55 @@
+package backends_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/enterprise/audit"
+	"github.com/coder/coder/v2/enterprise/audit/audittest"
+	"github.com/coder/coder/v2/enterprise/audit/backends"
+)
+
+func TestFileBackend(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx     = context.Background()
+		path    = filepath.Join(t.TempDir(), "audit.jsonl")
+		backend = backends.NewFile(backends.FileOptions{
+			Path:       path,
+			MaxSizeMB:  1,
+			MaxBackups: 1,
+		})
+	)
+
+	first, second := audittest.RandomLog(), audittest.RandomLog()
+	err := backend.Export(ctx, first, audit.BackendDetails{})
+	require.NoError(t, err)
+	err = backend.Export(ctx, second, audit.BackendDetails{Actor: &audit.Actor{Username: "coadler"}})
+	require.NoError(t, err)
+	require.NoError(t, backend.Close())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var events []backends.Event
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var event backends.Event
+		require.NoError(t, json.Unmarshal(s.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.NoError(t, s.Err())
+	require.Len(t, events, 2)
+	require.Equal(t, first.ID, events[0].ID)
+	require.Nil(t, events[0].Actor)
+	require.Equal(t, second.ID, events[1].ID)
+	require.Equal(t, "coadler", events[1].Actor.Username)
+}
@@ -0,0 +1,373 @@
+package backends
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/enterprise/audit"
+)
+
+const (
+	// spoolCurrent is the spool file to which audit logs are appended until
+	// it is sealed into a batch.
+	spoolCurrent = "current.jsonl"
+	// spoolBatchPrefix prefixes sealed batches, which are named so that they
+	// sort in the order in which they were sealed.
+	spoolBatchPrefix = "batch-"
+	// spoolFailedPrefix replaces the batch prefix of batches which are given up
+	// on, which are kept in the spool directory but not retried.
+	spoolFailedPrefix = "failed-"
+	// httpDefaultMaxAttempts is the number of times a batch is sent before it
+	// is given up on, unless configured otherwise.
+	httpDefaultMaxAttempts = 30
+)
+
+// errBatchRejected is returned when the endpoint rejects a batch with a status
+// which indicates that retrying it cannot succeed.
+var errBatchRejected = xerrors.New("batch rejected")
+
+// HTTPOptions configure the HTTP backend.
+type HTTPOptions struct {
+	// Endpoint is the URL to which batches are sent with a POST request.
+	Endpoint string
+	// Headers are sent with every request.
+	Headers map[string]string
+	// BatchSize is the largest number of audit logs sent in a single request.
+	BatchSize int
+	// FlushInterval is how often batches are sent, regardless of whether they
+	// are full, and how often undelivered batches are retried.
+	FlushInterval time.Duration
+	// SpoolDir is the directory in which audit logs are kept until they are
+	// delivered.
+	SpoolDir string
+	// MaxAttempts is the number of times a batch is sent before it is given
+	// up on. Defaults to 30.
+	MaxAttempts int
+}
+
+// HTTPBackend sends batches of audit logs to an HTTP endpoint as a JSON array
+// of events.
+//
+// Exported audit logs are first appended to a spool file on disk, which is
+// sealed into a batch once it is full or the flush interval elapses. Batches
+// are delivered in order, and are only removed once the endpoint responds
+// successfully; a batch which fails to be delivered, and every batch after it,
+// is retried at the next flush. Since the spool persists, audit logs which are
+// undelivered when the server stops are sent after it next starts.
+//
+// A batch which the endpoint rejects with a 4xx status, other than 408 and 429,
+// or which fails to be delivered MaxAttempts times, is given up on: it is
+// renamed with the "failed-" prefix and left in the spool directory.
+type HTTPBackend struct {
+	log  slog.Logger
+	opts HTTPOptions
+	cl   *http.Client
+
+	mu       sync.Mutex
+	spool    *os.File
+	spooled  int
+	lastSeal int64
+
+	// attempts counts the failed deliveries of each batch by name. It is only
+	// used by the goroutine which delivers batches.
+	attempts map[string]int
+
+	flush  chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewHTTP returns an HTTP backend, and starts delivering any batches left in
+// the spool directory by a previous run.
+func NewHTTP(logger slog.Logger, opts HTTPOptions) (*HTTPBackend, error) {
+	if opts.Endpoint == "" {
+		return nil, xerrors.New("endpoint not defined")
+	}
+	if opts.BatchSize <= 0 {
+		return nil, xerrors.Errorf("batch size must be positive, got %d", opts.BatchSize)
+	}
+	if opts.FlushInterval <= 0 {
+		return nil, xerrors.Errorf("flush interval must be positive, got %s", opts.FlushInterval)
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = httpDefaultMaxAttempts
+	}
+
+	err := os.MkdirAll(opts.SpoolDir, 0o700)
+	if err != nil {
+		return nil, xerrors.Errorf("create spool directory: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &HTTPBackend{
+		log:      logger,
+		opts:     opts,
+		cl:       &http.Client{},
+		attempts: make(map[string]int),
+		flush:    make(chan struct{}, 1),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	// Audit logs which were spooled, but not sealed, by a previous run are
+	// sealed before any more are appended.
+	fi, err := os.Stat(filepath.Join(opts.SpoolDir, spoolCurrent))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		cancel()
+		return nil, xerrors.Errorf("stat spool: %w", err)
+	}
+	if fi != nil && fi.Size() > 0 {
+		b.spooled = 1
+	}
+	err = b.openSpoolLocked()
+	if err == nil {
+		err = b.sealLocked()
+	}
+	if err != nil {
+		cancel()
+		if b.spool != nil {
+			_ = b.spool.Close()
+		}
+		return nil, err
+	}
+
+	go b.run(ctx)
+	b.flush <- struct{}{}
+	return b, nil
+}
+
+func (*HTTPBackend) Decision() audit.FilterDecision {
+	return audit.FilterDecisionExport
+}
+
+func (b *HTTPBackend) Export(_ context.Context, alog database.AuditLog, details audit.BackendDetails) error {
+	line, err := marshalEvent(alog, details)
+	if err != nil {
+		return xerrors.Errorf("marshal audit log: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.spool == nil {
+		return xerrors.New("backend is closed")
+	}
+	_, err = b.spool.Write(append(line, '\n'))
+	if err != nil {
+		return xerrors.Errorf("spool audit log: %w", err)
+	}
+	b.spooled++
+
+	if b.spooled >= b.opts.BatchSize {
+		err = b.sealLocked()
+		if err != nil {
+			return err
+		}
+		select {
+		case b.flush <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Close stops delivering batches. Any audit logs which have not been
+// delivered are left in the spool directory.
+func (b *HTTPBackend) Close() error {
+	b.cancel()
+	<-b.done
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.spool == nil {
+		return nil
+	}
+	err := b.spool.Close()
+	b.spool = nil
+	return err
+}
+
+func (b *HTTPBackend) run(ctx context.Context) {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.mu.Lock()
+			err := b.sealLocked()
+			b.mu.Unlock()
+			if err != nil {
+				b.log.Error(ctx, "seal audit log batch", slog.Error(err))
+			}
+		case <-b.flush:
+		}
+
+		err := b.deliver(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			b.log.Warn(ctx, "failed to deliver audit logs, will retry", slog.Error(err))
+		}
+	}
+}
+
+// deliver sends every sealed batch, oldest first, stopping at the first which
+// fails and can be retried.
+func (b *HTTPBackend) deliver(ctx context.Context) error {
+	entries, err := os.ReadDir(b.opts.SpoolDir)
+	if err != nil {
+		return xerrors.Errorf("read spool directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, spoolBatchPrefix) {
+			continue
+		}
+
+		path := filepath.Join(b.opts.SpoolDir, name)
+		err = b.send(ctx, path)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			b.attempts[name]++
+			if !errors.Is(err, errBatchRejected) && b.attempts[name] < b.opts.MaxAttempts {
+				return xerrors.Errorf("send batch %q: %w", name, err)
+			}
+
+			b.log.Error(ctx, "giving up on delivering audit log batch",
+				slog.F("batch", name), slog.F("attempts", b.attempts[name]), slog.Error(err))
+			delete(b.attempts, name)
+			err = os.Rename(path, filepath.Join(b.opts.SpoolDir, spoolFailedPrefix+strings.TrimPrefix(name, spoolBatchPrefix)))
+			if err != nil {
+				return xerrors.Errorf("set aside failed batch %q: %w", name, err)
+			}
+			continue
+		}
+		delete(b.attempts, name)
+		err = os.Remove(path)
+		if err != nil {
+			return xerrors.Errorf("remove delivered batch %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (b *HTTPBackend) send(ctx context.Context, path string) error {
+	lines, err := os.ReadFile(path)
+	if err != nil {
+		return xerrors.Errorf("read batch: %w", err)
+	}
+
+	// Each line is a JSON object, so joining them with commas forms an array.
+	// A line may have been truncated if the server stopped while it was being
+	// written; since it can never be delivered, it is dropped.
+	body := bytes.NewBuffer(make([]byte, 0, len(lines)+2))
+	body.WriteByte('[')
+	var n int
+	for _, line := range bytes.Split(lines, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		if !json.Valid(line) {
+			b.log.Error(ctx, "dropping malformed audit log from spool", slog.F("batch", filepath.Base(path)))
+			continue
+		}
+		if n > 0 {
+			body.WriteByte(',')
+		}
+		body.Write(line)
+		n++
+	}
+	body.WriteByte(']')
+	if n == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, b.opts.FlushInterval)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.opts.Endpoint, body)
+	if err != nil {
+		return xerrors.Errorf("create HTTP request: %w", err)
+	}
+	for k, v := range b.opts.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.cl.Do(req)
+	if err != nil {
+		return xerrors.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 512))
+
+	switch {
+	case resp.StatusCode/100 == 2:
+	case resp.StatusCode/100 == 4 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests:
+		return xerrors.Errorf("%w: non-2xx response (%d)", errBatchRejected, resp.StatusCode)
+	default:
+		return xerrors.Errorf("non-2xx response (%d)", resp.StatusCode)
+	}
+	return nil
+}
+
+// sealLocked renames the current spool file, if it holds any audit logs, to a
+// new batch, and starts a new spool file.
+func (b *HTTPBackend) sealLocked() error {
+	if b.spooled == 0 {
+		return nil
+	}
+
+	err := b.spool.Close()
+	if err != nil {
+		return xerrors.Errorf("close spool: %w", err)
+	}
+	b.spool = nil
+
+	// Batches are named by the time at which they were sealed, made unique in
+	// case two are sealed within the clock's resolution.
+	seal := time.Now().UnixNano()
+	if seal <= b.lastSeal {
+		seal = b.lastSeal + 1
+	}
+	b.lastSeal = seal
+	name := fmt.Sprintf("%s%020d.jsonl", spoolBatchPrefix, seal)
+	err = os.Rename(filepath.Join(b.opts.SpoolDir, spoolCurrent), filepath.Join(b.opts.SpoolDir, name))
+	if err != nil {
+		return xerrors.Errorf("seal batch: %w", err)
+	}
+	b.spooled = 0
+
+	return b.openSpoolLocked()
+}
+
+func (b *HTTPBackend) openSpoolLocked() error {
+	f, err := os.OpenFile(filepath.Join(b.opts.SpoolDir, spoolCurrent), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return xerrors.Errorf("open spool: %w", err)
+	}
+	b.spool = f
+	return nil
+}
@@ -0,0 +1,263 @@
+package backends_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog"
+	"cdr.dev/slog/sloggers/slogtest"
+
+	"github.com/coder/coder/v2/enterprise/audit"
+	"github.com/coder/coder/v2/enterprise/audit/audittest"
+	"github.com/coder/coder/v2/enterprise/audit/backends"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestHTTPBackend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Batches", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			ctx     = testutil.Context(t, testutil.WaitShort)
+			logger  = slogtest.Make(t, nil).Leveled(slog.LevelDebug)
+			batches = make(chan []backends.Event, 10)
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+			var batch []backends.Event
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+			batches <- batch
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer srv.Close()
+
+		backend, err := backends.NewHTTP(logger, backends.HTTPOptions{
+			Endpoint:  srv.URL,
+			Headers:   map[string]string{"Authorization": "Bearer token"},
+			BatchSize: 2,
+			// Batches are only sent once full.
+			FlushInterval: time.Hour,
+			SpoolDir:      t.TempDir(),
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+
+		var ids []uuid.UUID
+		for i := 0; i < 3; i++ {
+			alog := audittest.RandomLog()
+			ids = append(ids, alog.ID)
+			require.NoError(t, backend.Export(ctx, alog, audit.BackendDetails{}))
+		}
+
+		batch := testutil.RequireRecvCtx(ctx, t, batches)
+		require.Len(t, batch, 2)
+		require.Equal(t, ids[0], batch[0].ID)
+		require.Equal(t, ids[1], batch[1].ID)
+
+		// The third audit log waits for the batch to fill.
+		select {
+		case batch = <-batches:
+			t.Fatalf("unexpected batch of %d", len(batch))
+		case <-time.After(testutil.IntervalMedium):
+		}
+	})
+
+	t.Run("Retries", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			ctx       = testutil.Context(t, testutil.WaitLong)
+			logger    = slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+			attempts  atomic.Int32
+			delivered = make(chan []backends.Event, 10)
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The first two attempts fail.
+			if attempts.Add(1) <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			var batch []backends.Event
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+			delivered <- batch
+		}))
+		defer srv.Close()
+
+		spool := t.TempDir()
+		backend, err := backends.NewHTTP(logger, backends.HTTPOptions{
+			Endpoint:      srv.URL,
+			BatchSize:     10,
+			FlushInterval: testutil.IntervalFast,
+			SpoolDir:      spool,
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+
+		alog := audittest.RandomLog()
+		require.NoError(t, backend.Export(ctx, alog, audit.BackendDetails{}))
+
+		batch := testutil.RequireRecvCtx(ctx, t, delivered)
+		require.Len(t, batch, 1)
+		require.Equal(t, alog.ID, batch[0].ID)
+		require.GreaterOrEqual(t, attempts.Load(), int32(3))
+
+		// Delivered batches are removed from the spool.
+		require.Eventually(t, func() bool {
+			entries, err := os.ReadDir(spool)
+			if err != nil {
+				return false
+			}
+			for _, entry := range entries {
+				if entry.Name() != "current.jsonl" {
+					return false
+				}
+			}
+			return true
+		}, testutil.WaitShort, testutil.IntervalFast)
+	})
+
+	t.Run("GivesUp", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name   string
+			status int
+			// attempts is the number of times the first batch is sent.
+			attempts int32
+		}{
+			{name: "Rejected", status: http.StatusBadRequest, attempts: 1},
+			{name: "MaxAttempts", status: http.StatusServiceUnavailable, attempts: 3},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				var (
+					ctx       = testutil.Context(t, testutil.WaitLong)
+					logger    = slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+					attempts  atomic.Int32
+					delivered = make(chan []backends.Event, 10)
+				)
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var batch []backends.Event
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+					// Only the first batch fails.
+					if attempts.Load() < tc.attempts {
+						attempts.Add(1)
+						w.WriteHeader(tc.status)
+						return
+					}
+					delivered <- batch
+				}))
+				defer srv.Close()
+
+				spool := t.TempDir()
+				backend, err := backends.NewHTTP(logger, backends.HTTPOptions{
+					Endpoint:      srv.URL,
+					BatchSize:     1,
+					FlushInterval: testutil.IntervalFast,
+					SpoolDir:      spool,
+					MaxAttempts:   3,
+				})
+				require.NoError(t, err)
+				defer backend.Close()
+
+				first, second := audittest.RandomLog(), audittest.RandomLog()
+				require.NoError(t, backend.Export(ctx, first, audit.BackendDetails{}))
+				require.NoError(t, backend.Export(ctx, second, audit.BackendDetails{}))
+
+				// The first batch is given up on, and the second is delivered.
+				batch := testutil.RequireRecvCtx(ctx, t, delivered)
+				require.Len(t, batch, 1)
+				require.Equal(t, second.ID, batch[0].ID)
+				require.Equal(t, tc.attempts, attempts.Load())
+
+				failed, err := filepath.Glob(filepath.Join(spool, "failed-*"))
+				require.NoError(t, err)
+				require.Len(t, failed, 1)
+				data, err := os.ReadFile(failed[0])
+				require.NoError(t, err)
+				require.Contains(t, string(data), first.ID.String())
+			})
+		}
+	})
+
+	t.Run("ResumesSpool", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			ctx       = testutil.Context(t, testutil.WaitShort)
+			logger    = slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+			spool     = t.TempDir()
+			delivered = make(chan []backends.Event, 10)
+		)
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var batch []backends.Event
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+			delivered <- batch
+		}))
+		defer srv.Close()
+		opts := backends.HTTPOptions{
+			Endpoint:      srv.URL,
+			BatchSize:     10,
+			FlushInterval: time.Hour,
+			SpoolDir:      spool,
+		}
+
+		// The backend is closed before the batch is full or the flush
+		// interval elapses, so the audit log is left in the spool.
+		backend, err := backends.NewHTTP(logger, opts)
+		require.NoError(t, err)
+		alog := audittest.RandomLog()
+		require.NoError(t, backend.Export(ctx, alog, audit.BackendDetails{}))
+		require.NoError(t, backend.Close())
+		require.Empty(t, delivered)
+
+		data, err := os.ReadFile(filepath.Join(spool, "current.jsonl"))
+		require.NoError(t, err)
+		require.Contains(t, string(data), alog.ID.String())
+
+		// It is delivered once the backend starts again.
+		backend, err = backends.NewHTTP(logger, opts)
+		require.NoError(t, err)
+		defer backend.Close()
+
+		batch := testutil.RequireRecvCtx(ctx, t, delivered)
+		require.Len(t, batch, 1)
+		require.Equal(t, alog.ID, batch[0].ID)
+	})
+}
+
+func TestHTTPBackend_Closed(t *testing.T) {
+	t.Parallel()
+
+	backend, err := backends.NewHTTP(slogtest.Make(t, nil), backends.HTTPOptions{
+		Endpoint:      "http://localhost",
+		BatchSize:     1,
+		FlushInterval: time.Hour,
+		SpoolDir:      t.TempDir(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, backend.Close())
+
+	err = backend.Export(context.Background(), audittest.RandomLog(), audit.BackendDetails{})
+	require.ErrorContains(t, err, "closed")
+}
@@ -0,0 +1,176 @@
+package backends
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/enterprise/audit"
+)
+
+const (
+	// syslogPriority is the PRI of every message: the "log audit" facility (13)
+	// at the "informational" severity (6).
+	syslogPriority = 13*8 + 6
+	syslogAppName  = "coder"
+	syslogMsgID    = "audit"
+	// syslogTimestamp is RFC 3339, limited to the microsecond precision
+	// permitted by RFC 5424.
+	syslogTimestamp    = "2006-01-02T15:04:05.000000Z07:00"
+	syslogDialTimeout  = 10 * time.Second
+	syslogWriteTimeout = 10 * time.Second
+	// syslogQueueSize is the number of audit logs which are buffered while the
+	// syslog server is slow or unreachable.
+	syslogQueueSize = 1024
+)
+
+// SyslogOptions configure the syslog backend.
+type SyslogOptions struct {
+	// Address is the host:port of the syslog server.
+	Address string
+	// TLSConfig, if set, is used to connect to the syslog server over TLS.
+	TLSConfig *tls.Config
+}
+
+// SyslogBackend sends audit logs to a syslog server over TCP, optionally
+// secured with TLS. Messages are formatted per RFC 5424, with the audit log
+// as a JSON message, and framed by octet counting per RFC 5425 and RFC 6587.
+//
+// Audit logs are queued and sent in the background, so that a slow or
+// unreachable server does not hold up the requests which are audited. Audit
+// logs are dropped if the queue is full, or if they cannot be sent.
+type SyslogBackend struct {
+	log      slog.Logger
+	opts     SyslogOptions
+	hostname string
+	procID   string
+
+	queue  chan []byte
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// conn is only used by the goroutine which sends queued audit logs.
+	conn net.Conn
+}
+
+// NewSyslog returns a syslog backend, and starts sending queued audit logs.
+// The connection to the server is made once the first audit log is exported,
+// and is remade if it is lost.
+func NewSyslog(logger slog.Logger, opts SyslogOptions) *SyslogBackend {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &SyslogBackend{
+		log:      logger,
+		opts:     opts,
+		hostname: hostname,
+		procID:   fmt.Sprint(os.Getpid()),
+		queue:    make(chan []byte, syslogQueueSize),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go b.run(ctx)
+	return b
+}
+
+func (*SyslogBackend) Decision() audit.FilterDecision {
+	return audit.FilterDecisionExport
+}
+
+func (b *SyslogBackend) Export(_ context.Context, alog database.AuditLog, details audit.BackendDetails) error {
+	msg, err := marshalEvent(alog, details)
+	if err != nil {
+		return xerrors.Errorf("marshal audit log: %w", err)
+	}
+
+	select {
+	case b.queue <- b.frame(alog.Time, msg):
+		return nil
+	default:
+		return xerrors.New("syslog queue is full, dropping audit log")
+	}
+}
+
+// Close stops sending audit logs, and closes the connection to the syslog
+// server, if any. Audit logs which are still queued are dropped.
+func (b *SyslogBackend) Close() error {
+	b.cancel()
+	<-b.done
+
+	if b.conn == nil {
+		return nil
+	}
+	err := b.conn.Close()
+	b.conn = nil
+	return err
+}
+
+func (b *SyslogBackend) run(ctx context.Context) {
+	defer close(b.done)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case frame := <-b.queue:
+			err := b.send(ctx, frame)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				b.log.Warn(ctx, "failed to send audit log to syslog server, dropping it", slog.Error(err))
+			}
+		}
+	}
+}
+
+func (b *SyslogBackend) send(ctx context.Context, frame []byte) error {
+	// A connection which was closed by the server is only noticed once it is
+	// written to, so the write is retried once on a new connection.
+	for attempt := 0; ; attempt++ {
+		if b.conn == nil {
+			var err error
+			b.conn, err = b.dial(ctx)
+			if err != nil {
+				return xerrors.Errorf("connect to syslog server: %w", err)
+			}
+		}
+
+		_ = b.conn.SetWriteDeadline(time.Now().Add(syslogWriteTimeout))
+		_, err := b.conn.Write(frame)
+		if err == nil {
+			return nil
+		}
+
+		_ = b.conn.Close()
+		b.conn = nil
+		if attempt > 0 {
+			return xerrors.Errorf("write to syslog server: %w", err)
+		}
+	}
+}
+
+func (b *SyslogBackend) dial(ctx context.Context) (net.Conn, error) {
+	d := &net.Dialer{Timeout: syslogDialTimeout}
+	if b.opts.TLSConfig != nil {
+		td := &tls.Dialer{NetDialer: d, Config: b.opts.TLSConfig}
+		return td.DialContext(ctx, "tcp", b.opts.Address)
+	}
+	return d.DialContext(ctx, "tcp", b.opts.Address)
+}
+
+// frame formats the given message per RFC 5424, without structured data, and
+// prefixes it with its length.
+func (b *SyslogBackend) frame(ts time.Time, msg []byte) []byte {
+	header := fmt.Sprintf("<%d>1 %s %s %s %s %s - ",
+		syslogPriority, ts.UTC().Format(syslogTimestamp), b.hostname, syslogAppName, b.procID, syslogMsgID)
+	return append([]byte(fmt.Sprintf("%d %s", len(header)+len(msg), header)), msg...)
+}
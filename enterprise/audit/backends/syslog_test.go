@@ -0,0 +1,155 @@
+package backends_test
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/sloggers/slogtest"
+
+	"github.com/coder/coder/v2/enterprise/audit"
+	"github.com/coder/coder/v2/enterprise/audit/audittest"
+	"github.com/coder/coder/v2/enterprise/audit/backends"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSyslogBackend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("TCP", func(t *testing.T) {
+		t.Parallel()
+
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer ln.Close()
+
+		testSyslogBackend(t, ln, backends.NewSyslog(slogtest.Make(t, nil), backends.SyslogOptions{
+			Address: ln.Addr().String(),
+		}))
+	})
+
+	t.Run("TLS", func(t *testing.T) {
+		t.Parallel()
+
+		cert := testutil.GenerateTLSCertificate(t, "localhost")
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		require.NoError(t, err)
+		roots := x509.NewCertPool()
+		roots.AddCert(leaf)
+
+		ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+		})
+		require.NoError(t, err)
+		defer ln.Close()
+
+		testSyslogBackend(t, ln, backends.NewSyslog(slogtest.Make(t, nil), backends.SyslogOptions{
+			Address: ln.Addr().String(),
+			TLSConfig: &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				RootCAs:    roots,
+			},
+		}))
+	})
+}
+
+func testSyslogBackend(t *testing.T, ln net.Listener, backend *backends.SyslogBackend) {
+	t.Helper()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	defer backend.Close()
+
+	msgs := make(chan string, 2)
+	go func() {
+		conn, err := ln.Accept()
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		// Each message is prefixed by its length and a space.
+		r := bufio.NewReader(conn)
+		for {
+			l, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, err := strconv.Atoi(strings.TrimSuffix(l, " "))
+			if !assert.NoError(t, err) {
+				return
+			}
+			msg := make([]byte, n)
+			_, err = io.ReadFull(r, msg)
+			if !assert.NoError(t, err) {
+				return
+			}
+			msgs <- string(msg)
+		}
+	}()
+
+	// Both audit logs are sent over the same connection.
+	actors := []audit.Actor{{Username: "first"}, {Username: "second"}}
+	for _, actor := range actors {
+		actor := actor
+		alog := audittest.RandomLog()
+		err := backend.Export(ctx, alog, audit.BackendDetails{Actor: &actor})
+		require.NoError(t, err)
+
+		msg := testutil.RequireRecvCtx(ctx, t, msgs)
+		require.True(t, strings.HasPrefix(msg, "<110>1 "), msg)
+		fields := strings.SplitN(msg, " ", 8)
+		require.Len(t, fields, 8)
+		require.Equal(t, alog.Time.UTC().Format("2006-01-02T15:04:05.000000Z07:00"), fields[1])
+		require.Equal(t, "coder", fields[3])
+		require.Equal(t, "audit", fields[5])
+		require.Equal(t, "-", fields[6])
+
+		var event backends.Event
+		require.NoError(t, json.Unmarshal([]byte(fields[7]), &event))
+		require.Equal(t, alog.ID, event.ID)
+		require.Equal(t, "127.0.0.1", event.IP)
+		require.Equal(t, actor.Username, event.Actor.Username)
+	}
+}
+
+func TestSyslogBackend_Unresponsive(t *testing.T) {
+	t.Parallel()
+
+	// The server accepts connections, but never completes the TLS handshake.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	backend := backends.NewSyslog(slogtest.Make(t, nil), backends.SyslogOptions{
+		Address:   ln.Addr().String(),
+		TLSConfig: &tls.Config{MinVersion: tls.VersionTLS12},
+	})
+
+	// Exporting does not wait for the server, and audit logs are dropped once
+	// the queue is full.
+	ctx := testutil.Context(t, testutil.WaitShort)
+	for {
+		err = backend.Export(ctx, audittest.RandomLog(), audit.BackendDetails{})
+		if err != nil {
+			break
+		}
+	}
+	require.ErrorContains(t, err, "queue is full")
+
+	// Closing does not wait for the server either.
+	closed := make(chan error, 1)
+	go func() {
+		closed <- backend.Close()
+	}()
+	require.NoError(t, testutil.RequireRecvCtx(ctx, t, closed))
+}
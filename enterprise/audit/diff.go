@@ -144,6 +144,8 @@ func convertDiffType(left, right any) (newLeft, newRight any, changed bool) {
 		return leftInt64Ptr, rightInt64Ptr, true
 	case database.TemplateACL:
 		return fmt.Sprintf("%+v", left), fmt.Sprintf("%+v", right), true
+	case database.WorkspaceACL:
+		return fmt.Sprintf("%+v", left), fmt.Sprintf("%+v", right), true
 	case database.CustomRolePermissions:
 		// String representation is much easier to visually inspect
 		leftArr := make([]string, 0)
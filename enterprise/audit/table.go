@@ -20,7 +20,7 @@ var AuditActionMap = map[string][]codersdk.AuditAction{
 	"Template":        {codersdk.AuditActionWrite, codersdk.AuditActionDelete},
 	"TemplateVersion": {codersdk.AuditActionCreate, codersdk.AuditActionWrite},
 	"User":            {codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete},
-	"Workspace":       {codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete},
+	"Workspace":       {codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete, codersdk.AuditActionConnect, codersdk.AuditActionDisconnect},
 	"WorkspaceBuild":  {codersdk.AuditActionStart, codersdk.AuditActionStop},
 	"Group":           {codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete},
 	"APIKey":          {codersdk.AuditActionLogin, codersdk.AuditActionLogout, codersdk.AuditActionRegister, codersdk.AuditActionCreate, codersdk.AuditActionDelete},
@@ -144,6 +144,7 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"quiet_hours_schedule": ActionTrack,
 		"theme_preference":     ActionIgnore,
 		"name":                 ActionTrack,
+		"is_service_account":   ActionTrack,
 	},
 	&database.Workspace{}: {
 		"id":                 ActionTrack,
@@ -161,6 +162,8 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"deleting_at":        ActionTrack,
 		"automatic_updates":  ActionTrack,
 		"favorite":           ActionTrack,
+		"user_acl":           ActionTrack,
+		"group_acl":          ActionTrack,
 	},
 	&database.WorkspaceBuild{}: {
 		"id":                      ActionIgnore,
@@ -202,7 +205,9 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"lifetime_seconds": ActionIgnore,
 		"ip_address":       ActionIgnore,
 		"scope":            ActionIgnore,
+		"scopes":           ActionTrack,
 		"token_name":       ActionIgnore,
+		"user_agent":       ActionIgnore,
 	},
 	&database.AuditOAuthConvertState{}: {
 		"created_at":      ActionTrack,
@@ -219,6 +224,17 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"id":              ActionIgnore,
 		"notifier_paused": ActionTrack,
 	},
+	&database.NotificationTemplate{}: {
+		"id":                     ActionTrack,
+		"name":                   ActionTrack,
+		"title_template":         ActionTrack,
+		"body_template":          ActionTrack,
+		"actions":                ActionIgnore, // Not editable.
+		"group":                  ActionIgnore, // Not editable.
+		"default_title_template": ActionIgnore, // Only used to reset the template.
+		"default_body_template":  ActionIgnore, // Only used to reset the template.
+		"digest_window_seconds":  ActionTrack,
+	},
 	// TODO: track an ID here when the below ticket is completed:
 	// https://github.com/coder/coder/pull/6012
 	&database.License{}: {
@@ -245,12 +261,13 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"version":             ActionTrack,
 	},
 	&database.OAuth2ProviderApp{}: {
-		"id":           ActionIgnore,
-		"created_at":   ActionIgnore,
-		"updated_at":   ActionIgnore,
-		"name":         ActionTrack,
-		"icon":         ActionTrack,
-		"callback_url": ActionTrack,
+		"id":                 ActionIgnore,
+		"created_at":         ActionIgnore,
+		"updated_at":         ActionIgnore,
+		"name":               ActionTrack,
+		"icon":               ActionTrack,
+		"callback_url":       ActionTrack,
+		"service_account_id": ActionTrack,
 	},
 	&database.OAuth2ProviderAppSecret{}: {
 		"id":             ActionIgnore,
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/xerrors"
+
+	agpl "github.com/coder/coder/v2/cli"
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) audit() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "audit",
+		Short: "Manage audit logs",
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.auditExport(),
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) auditExport() *serpent.Command {
+	var (
+		format      string
+		searchQuery string
+		outputPath  string
+	)
+	client := new(codersdk.Client)
+
+	cmd := &serpent.Command{
+		Use:   "export",
+		Short: "Export audit logs as CSV or JSON lines",
+		Long: agpl.FormatExamples(
+			agpl.Example{
+				Description: "Export every audit log to a CSV file",
+				Command:     "coder audit export --format csv --output-file audit.csv",
+			},
+			agpl.Example{
+				Description: "Export audit logs created since August 2024 as JSON lines",
+				Command:     `coder audit export --search "date_from:2024-08-01"`,
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			export, err := client.ExportAuditLogs(inv.Context(), codersdk.ExportAuditLogsRequest{
+				SearchQuery: searchQuery,
+				Format:      codersdk.AuditLogExportFormat(format),
+			})
+			if err != nil {
+				return xerrors.Errorf("export audit logs: %w", err)
+			}
+			defer export.Close()
+
+			out := inv.Stdout
+			if outputPath != "" {
+				f, err := os.Create(outputPath)
+				if err != nil {
+					return xerrors.Errorf("create output file: %w", err)
+				}
+				defer f.Close()
+				out = f
+			}
+
+			_, err = io.Copy(out, export)
+			if err != nil {
+				return xerrors.Errorf("write audit logs: %w", err)
+			}
+			if outputPath != "" {
+				cliui.Infof(inv.Stderr, "Wrote audit logs to %s", outputPath)
+			}
+			return nil
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "format",
+			Description: "The format in which audit logs are exported.",
+			Default:     string(codersdk.AuditLogExportFormatJSONL),
+			Value:       serpent.EnumOf(&format, string(codersdk.AuditLogExportFormatCSV), string(codersdk.AuditLogExportFormatJSONL)),
+		},
+		{
+			Flag:        "search",
+			Description: "Only export audit logs matching the search query, using the same syntax as the audit log page.",
+			Value:       serpent.StringOf(&searchQuery),
+		},
+		{
+			Flag:          "output-file",
+			FlagShorthand: "O",
+			Description:   "File to which audit logs are written. Defaults to stdout.",
+			Value:         serpent.StringOf(&outputPath),
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,52 @@
+package cli_test
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/enterprise/coderd/coderdenttest"
+	"github.com/coder/coder/v2/enterprise/coderd/license"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestAuditExport(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitMedium)
+	client, admin := coderdenttest.New(t, &coderdenttest.Options{LicenseOptions: &coderdenttest.LicenseOptions{
+		Features: license.Features{
+			codersdk.FeatureAuditLog: 1,
+		},
+	}})
+
+	for _, action := range []codersdk.AuditAction{codersdk.AuditActionCreate, codersdk.AuditActionDelete} {
+		err := client.CreateTestAuditLog(ctx, codersdk.CreateTestAuditLogRequest{
+			Action:     action,
+			ResourceID: admin.UserID,
+			Time:       time.Date(2022, 8, 15, 14, 30, 45, 100, time.UTC),
+		})
+		require.NoError(t, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "audit.csv")
+	inv, conf := newCLI(t, "audit", "export", "--format", "csv", "--search", "action:delete", "--output-file", path)
+	clitest.SetupConfig(t, client, conf) //nolint:gocritic // Only owners can read audit logs.
+	err := inv.WithContext(ctx).Run()
+	require.NoError(t, err)
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, "id", records[0][0])
+	require.Contains(t, records[1], string(codersdk.AuditActionDelete))
+}
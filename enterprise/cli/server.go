@@ -4,17 +4,24 @@ package cli
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
 	"encoding/base64"
 	"errors"
 	"io"
 	"net/url"
+	"os"
+	"path/filepath"
 
 	"golang.org/x/xerrors"
 	"tailscale.com/derp"
 	"tailscale.com/types/key"
 
+	"cdr.dev/slog"
+
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/cryptorand"
 	"github.com/coder/coder/v2/enterprise/audit"
 	"github.com/coder/coder/v2/enterprise/audit/backends"
@@ -74,11 +81,22 @@ func (r *RootCmd) Server(_ func()) *serpent.Command {
 			options.DERPServer.SetMeshKey(meshKey)
 		}
 
+		streamingBackends, streamingClosers, err := auditStreamingBackends(options.Logger.Named("audit"), options.DeploymentValues, options.CacheDir)
+		if err != nil {
+			return nil, nil, xerrors.Errorf("configure audit logging: %w", err)
+		}
+		closeStreamingBackends := func() {
+			for _, c := range streamingClosers {
+				_ = c.Close()
+			}
+		}
 		options.Auditor = audit.NewAuditor(
 			options.Database,
 			audit.DefaultFilter,
-			backends.NewPostgres(options.Database, true),
-			backends.NewSlog(options.Logger),
+			append([]audit.Backend{
+				backends.NewPostgres(options.Database, true),
+				backends.NewSlog(options.Logger),
+			}, streamingBackends...)...,
 		)
 
 		options.TrialGenerator = trialer.New(options.Database, "https://v2-licensor.coder.com/trial", coderd.Keys)
@@ -103,12 +121,14 @@ func (r *RootCmd) Server(_ func()) *serpent.Command {
 			for idx, ek := range encKeys {
 				dk, err := base64.StdEncoding.DecodeString(ek)
 				if err != nil {
+					closeStreamingBackends()
 					return nil, nil, xerrors.Errorf("decode external-token-encryption-key %d: %w", idx, err)
 				}
 				keys = append(keys, dk)
 			}
 			cs, err := dbcrypt.NewCiphers(keys...)
 			if err != nil {
+				closeStreamingBackends()
 				return nil, nil, xerrors.Errorf("initialize encryption: %w", err)
 			}
 			o.ExternalTokenEncryption = cs
@@ -116,9 +136,16 @@ func (r *RootCmd) Server(_ func()) *serpent.Command {
 
 		api, err := coderd.New(ctx, o)
 		if err != nil {
+			closeStreamingBackends()
 			return nil, nil, err
 		}
-		return api.AGPL, api, nil
+		// The backends are closed once the API, which exports audit logs to
+		// them, has stopped.
+		return api.AGPL, closeFunc(func() error {
+			err := api.Close()
+			closeStreamingBackends()
+			return err
+		}), nil
 	})
 
 	cmd.AddSubcommands(
@@ -126,3 +153,89 @@ func (r *RootCmd) Server(_ func()) *serpent.Command {
 	)
 	return cmd
 }
+
+// auditStreamingBackends returns the backends, selected by the deployment
+// config, to which audit logs are streamed in addition to the database, and
+// the closers which release their resources.
+func auditStreamingBackends(logger slog.Logger, vals *codersdk.DeploymentValues, cacheDir string) ([]audit.Backend, []io.Closer, error) {
+	var (
+		auditBackends []audit.Backend
+		closers       []io.Closer
+	)
+	closeAll := func() {
+		for _, c := range closers {
+			_ = c.Close()
+		}
+	}
+
+	cfg := vals.AuditLogging
+	for _, name := range cfg.Backends.Value() {
+		switch name {
+		case codersdk.AuditLoggingBackendSyslog:
+			if cfg.Syslog.Address.String() == "" {
+				closeAll()
+				return nil, nil, xerrors.New("audit-logging-syslog-address must be set to stream audit logs to syslog")
+			}
+			opts := backends.SyslogOptions{Address: cfg.Syslog.Address.String()}
+			if cfg.Syslog.TLS.Value() {
+				opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+				if caFile := cfg.Syslog.CAFile.Value(); caFile != "" {
+					pem, err := os.ReadFile(caFile)
+					if err != nil {
+						closeAll()
+						return nil, nil, xerrors.Errorf("read audit-logging-syslog-ca-file: %w", err)
+					}
+					opts.TLSConfig.RootCAs = x509.NewCertPool()
+					if !opts.TLSConfig.RootCAs.AppendCertsFromPEM(pem) {
+						closeAll()
+						return nil, nil, xerrors.Errorf("audit-logging-syslog-ca-file %q contains no certificates", caFile)
+					}
+				}
+			}
+			b := backends.NewSyslog(logger.Named("syslog"), opts)
+			auditBackends = append(auditBackends, b)
+			closers = append(closers, b)
+		case codersdk.AuditLoggingBackendHTTP:
+			spoolDir := cfg.HTTP.SpoolDir.Value()
+			if spoolDir == "" {
+				spoolDir = filepath.Join(cacheDir, "audit-spool")
+			}
+			b, err := backends.NewHTTP(logger.Named("http"), backends.HTTPOptions{
+				Endpoint:      cfg.HTTP.Endpoint.String(),
+				Headers:       cfg.HTTP.Headers.Value,
+				BatchSize:     int(cfg.HTTP.BatchSize.Value()),
+				FlushInterval: cfg.HTTP.FlushInterval.Value(),
+				SpoolDir:      spoolDir,
+			})
+			if err != nil {
+				closeAll()
+				return nil, nil, xerrors.Errorf("create HTTP audit backend: %w", err)
+			}
+			auditBackends = append(auditBackends, b)
+			closers = append(closers, b)
+		case codersdk.AuditLoggingBackendFile:
+			if cfg.File.Path.Value() == "" {
+				closeAll()
+				return nil, nil, xerrors.New("audit-logging-file-path must be set to write audit logs to a file")
+			}
+			b := backends.NewFile(backends.FileOptions{
+				Path:       cfg.File.Path.Value(),
+				MaxSizeMB:  int(cfg.File.MaxSize.Value()),
+				MaxBackups: int(cfg.File.MaxBackups.Value()),
+			})
+			auditBackends = append(auditBackends, b)
+			closers = append(closers, b)
+		default:
+			closeAll()
+			return nil, nil, xerrors.Errorf("unknown audit logging backend %q (available options: %q, %q, %q)", name,
+				codersdk.AuditLoggingBackendSyslog, codersdk.AuditLoggingBackendHTTP, codersdk.AuditLoggingBackendFile)
+		}
+	}
+	return auditBackends, closers, nil
+}
+
+type closeFunc func() error
+
+func (f closeFunc) Close() error {
+	return f()
+}
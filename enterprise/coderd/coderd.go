@@ -400,8 +400,21 @@ func New(ctx context.Context, options *Options) (_ *API, err error) {
 				r.Get("/", api.scimGetUsers)
 				r.Post("/", api.scimPostUser)
 				r.Get("/{id}", api.scimGetUser)
+				r.Put("/{id}", api.scimPutUser)
 				r.Patch("/{id}", api.scimPatchUser)
+				r.Delete("/{id}", api.scimDeleteUser)
 			})
+			r.Route("/Groups", func(r chi.Router) {
+				r.Get("/", api.scimGetGroups)
+				r.Post("/", api.scimPostGroup)
+				r.Get("/{id}", api.scimGetGroup)
+				r.Put("/{id}", api.scimPutGroup)
+				r.Patch("/{id}", api.scimPatchGroup)
+				r.Delete("/{id}", api.scimDeleteGroup)
+			})
+			r.Get("/ServiceProviderConfig", api.scimServiceProviderConfig)
+			r.Get("/Schemas", api.scimSchemas)
+			r.Get("/ResourceTypes", api.scimResourceTypes)
 		})
 	}
 
@@ -661,8 +674,9 @@ func (api *API) updateEntitlements(ctx context.Context) error {
 	if initial, changed, enabled := featureChanged(codersdk.FeatureTemplateRBAC); shouldUpdate(initial, changed, enabled) {
 		if enabled {
 			committer := committer{
-				Log:      api.Logger.Named("quota_committer"),
-				Database: api.Database,
+				Log:                   api.Logger.Named("quota_committer"),
+				Database:              api.Database,
+				NotificationsEnqueuer: api.NotificationsEnqueuer,
 			}
 			qcPtr := proto.QuotaCommitter(&committer)
 			api.AGPL.QuotaCommitter.Store(&qcPtr)
@@ -4,13 +4,15 @@ import (
 	"crypto/subtle"
 	"database/sql"
 	"encoding/json"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/imulab/go-scim/pkg/v2/handlerutil"
-	scimjson "github.com/imulab/go-scim/pkg/v2/json"
-	"github.com/imulab/go-scim/pkg/v2/service"
 	"github.com/imulab/go-scim/pkg/v2/spec"
 	"golang.org/x/xerrors"
 
@@ -21,6 +23,7 @@ import (
 	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/enterprise/coderd/scim"
 )
 
 func (api *API) scimEnabledMW(next http.Handler) http.Handler {
@@ -44,54 +47,160 @@ func (api *API) scimVerifyAuthHeader(r *http.Request) bool {
 	return len(api.SCIMAPIKey) != 0 && subtle.ConstantTimeCompare(hdr, api.SCIMAPIKey) == 1
 }
 
-// scimGetUsers intentionally always returns no users. This is done to always force
-// Okta to try and create each user individually, this way we don't need to
-// implement fetching users twice.
+// scimErrUnauthorized is returned when the request does not contain the SCIM
+// API key.
+var scimErrUnauthorized = &spec.Error{Status: http.StatusUnauthorized, Type: "invalidAuthorization"}
+
+// scimErrConflict is returned when a resource cannot be modified in its current
+// state.
+var scimErrConflict = &spec.Error{Status: http.StatusConflict, Type: "conflict"}
+
+// scimError writes a SCIM error response. handlerutil.WriteError only uses the
+// status and type of the error prototype when it is wrapped.
+func scimError(rw http.ResponseWriter, proto *spec.Error, detail string) {
+	_ = handlerutil.WriteError(rw, xerrors.Errorf("%s: %w", detail, proto))
+}
+
+// scimAuthorized verifies the SCIM API key of the request, and writes an error
+// response if it is invalid.
+func (api *API) scimAuthorized(rw http.ResponseWriter, r *http.Request) bool {
+	if !api.scimVerifyAuthHeader(r) {
+		scimError(rw, scimErrUnauthorized, "invalid authorization")
+		return false
+	}
+	return true
+}
+
+// scimListParams reads the filter and page of a list request, and writes an
+// error response if they are invalid.
+func scimListParams(rw http.ResponseWriter, r *http.Request) (scim.Filter, scim.Page, bool) {
+	filter, err := scim.ParseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		scimError(rw, spec.ErrInvalidFilter, err.Error())
+		return scim.Filter{}, scim.Page{}, false
+	}
+	page, err := scim.ParsePage(r.URL.Query())
+	if err != nil {
+		scimError(rw, spec.ErrInvalidValue, err.Error())
+		return scim.Filter{}, scim.Page{}, false
+	}
+	return filter, page, true
+}
+
+// scimGetUsers returns the users that match the filter of the request. Okta
+// looks up users by their userName before creating them.
 //
 // @Summary SCIM 2.0: Get users
 // @ID scim-get-users
 // @Security CoderSessionToken
 // @Produce application/scim+json
 // @Tags Enterprise
+// @Param filter query string false "SCIM filter expression"
+// @Param startIndex query int false "1-based index of the first result"
+// @Param count query int false "Maximum number of results"
 // @Success 200
 // @Router /scim/v2/Users [get]
 //
 //nolint:revive
 func (api *API) scimGetUsers(rw http.ResponseWriter, r *http.Request) {
-	if !api.scimVerifyAuthHeader(r) {
-		_ = handlerutil.WriteError(rw, spec.Error{Status: http.StatusUnauthorized, Type: "invalidAuthorization"})
+	ctx := r.Context()
+	if !api.scimAuthorized(rw, r) {
+		return
+	}
+	filter, page, ok := scimListParams(rw, r)
+	if !ok {
 		return
 	}
 
-	_ = handlerutil.WriteSearchResultToResponse(rw, &service.QueryResponse{
-		TotalResults: 0,
-		StartIndex:   1,
-		ItemsPerPage: 0,
-		Resources:    []scimjson.Serializable{},
-	})
+	// The page is selected in SQL. A count of 0 still needs a row to learn
+	// the total number of users.
+	params := database.GetUsersParams{
+		OffsetOpt: int32(min(page.StartIndex-1, math.MaxInt32)),
+		LimitOpt:  int32(max(page.Count, 1)),
+	}
+	userFilter := scimUserFilter{filter}
+	//nolint:gocritic // needed for SCIM
+	rows, err := api.Database.GetFilteredUsers(dbauthz.AsSCIM(ctx), params, userFilter, nil)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	total := 0
+	if len(rows) > 0 {
+		total = int(rows[0].Count)
+	} else if params.OffsetOpt > 0 {
+		// The page is past the last user, so the total is unknown.
+		params.OffsetOpt = 0
+		params.LimitOpt = 1
+		//nolint:gocritic // needed for SCIM
+		first, err := api.Database.GetFilteredUsers(dbauthz.AsSCIM(ctx), params, userFilter, nil)
+		if err != nil {
+			_ = handlerutil.WriteError(rw, err)
+			return
+		}
+		if len(first) > 0 {
+			total = int(first[0].Count)
+		}
+	}
+
+	users := []SCIMUser{}
+	if page.Count > 0 {
+		for _, user := range database.ConvertUserRows(rows) {
+			users = append(users, scimUserFromDB(user))
+		}
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, scim.NewPagedListResponse(users, total, page))
 }
 
-// scimGetUser intentionally always returns an error saying the user wasn't found.
-// This is done to always force Okta to try and create the user, this way we
-// don't need to implement fetching users twice.
+// scimGetUser returns a user by ID.
 //
 // @Summary SCIM 2.0: Get user by ID
 // @ID scim-get-user-by-id
 // @Security CoderSessionToken
-// @Produce application/scim+json
+// @Produce json
 // @Tags Enterprise
 // @Param id path string true "User ID" format(uuid)
+// @Success 200 {object} coderd.SCIMUser
 // @Failure 404
 // @Router /scim/v2/Users/{id} [get]
 //
 //nolint:revive
 func (api *API) scimGetUser(rw http.ResponseWriter, r *http.Request) {
-	if !api.scimVerifyAuthHeader(r) {
-		_ = handlerutil.WriteError(rw, spec.Error{Status: http.StatusUnauthorized, Type: "invalidAuthorization"})
+	ctx := r.Context()
+	if !api.scimAuthorized(rw, r) {
 		return
 	}
 
-	_ = handlerutil.WriteError(rw, spec.ErrNotFound)
+	dbUser, ok := api.scimUser(rw, r)
+	if !ok {
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, scimUserFromDB(dbUser))
+}
+
+// scimUser returns the user with the ID in the path of the request, and writes
+// an error response if there is no such user. Service accounts cannot be
+// managed with SCIM.
+func (api *API) scimUser(rw http.ResponseWriter, r *http.Request) (database.User, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		scimError(rw, spec.ErrNotFound, "invalid user ID")
+		return database.User{}, false
+	}
+
+	//nolint:gocritic // needed for SCIM
+	dbUser, err := api.Database.GetUserByID(dbauthz.AsSCIM(r.Context()), id)
+	if xerrors.Is(err, sql.ErrNoRows) || (err == nil && (dbUser.Deleted || dbUser.IsServiceAccount)) {
+		scimError(rw, spec.ErrNotFound, "user not found")
+		return database.User{}, false
+	}
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return database.User{}, false
+	}
+	return dbUser, true
 }
 
 // We currently use our own struct instead of using the SCIM package. This was
@@ -124,6 +233,88 @@ var SCIMAuditAdditionalFields = map[string]string{
 	"automatic_subsystem": "scim",
 }
 
+func scimUserFromDB(user database.User) SCIMUser {
+	sUser := SCIMUser{
+		Schemas:  []string{scim.SchemaUser},
+		ID:       user.ID.String(),
+		UserName: user.Username,
+		Emails: []struct {
+			Primary bool   `json:"primary"`
+			Value   string `json:"value" format:"email"`
+			Type    string `json:"type"`
+			Display string `json:"display"`
+		}{{Primary: true, Value: user.Email, Type: "work"}},
+		Active: user.Status != database.UserStatusSuspended,
+		Groups: []interface{}{},
+	}
+	// Coder only stores the full name of the user.
+	sUser.Name.GivenName, sUser.Name.FamilyName, _ = strings.Cut(user.Name, " ")
+	sUser.Meta.ResourceType = "User"
+	return sUser
+}
+
+// scimUserAttributes returns the attributes of the user that can be used in
+// filters.
+func scimUserAttributes(user database.User) scim.Attributes {
+	given, family, _ := strings.Cut(user.Name, " ")
+	return scim.Attributes{
+		"id":                {user.ID.String()},
+		"username":          {user.Username},
+		"emails.value":      {user.Email},
+		"name.givenname":    {given},
+		"name.familyname":   {family},
+		"active":            {strconv.FormatBool(user.Status != database.UserStatusSuspended)},
+		"meta.created":      {user.CreatedAt.UTC().Format(time.RFC3339)},
+		"meta.lastmodified": {user.UpdatedAt.UTC().Format(time.RFC3339)},
+	}
+}
+
+// scimUserColumns are the SQL expressions of the attributes in
+// scimUserAttributes.
+var scimUserColumns = scim.Columns{
+	"id":                "users.id::text",
+	"username":          "lower(users.username)",
+	"emails.value":      "lower(users.email)",
+	"name.givenname":    "lower(split_part(users.name, ' ', 1))",
+	"name.familyname":   "lower(coalesce(substring(users.name from ' (.*)$'), ''))",
+	"active":            "(users.status != 'suspended')::text",
+	"meta.created":      `to_char(users.created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"t"HH24:MI:SS"z"')`,
+	"meta.lastmodified": `to_char(users.updated_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"t"HH24:MI:SS"z"')`,
+}
+
+// scimUserFilter filters users in the database with a SCIM filter.
+type scimUserFilter struct {
+	scim.Filter
+}
+
+func (f scimUserFilter) CompileToSQL(argStart int) (string, []any) {
+	return f.Filter.CompileToSQL(scimUserColumns, argStart)
+}
+
+func (f scimUserFilter) Match(user database.User) bool {
+	return f.Filter.Match(scimUserAttributes(user))
+}
+
+// scimUserStatus returns the status of a user with the current status after
+// the SCIM client has (de)activated them.
+func scimUserStatus(active bool, current database.UserStatus) database.UserStatus {
+	if !active {
+		return database.UserStatusSuspended
+	}
+	switch current {
+	case database.UserStatusActive:
+		// Keep the user active
+		return database.UserStatusActive
+	case database.UserStatusDormant, database.UserStatusSuspended:
+		// Move (or keep) as dormant
+		return database.UserStatusDormant
+	default:
+		// If the status is unknown, just move them to dormant.
+		// The user will get transitioned to Active after logging in.
+		return database.UserStatusDormant
+	}
+}
+
 // scimPostUser creates a new user, or returns the existing user if it exists.
 //
 // @Summary SCIM 2.0: Create new user
@@ -172,7 +363,7 @@ func (api *API) scimPostUser(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	//nolint:gocritic
-	dbUser, err := api.Database.GetUserByEmailOrUsername(dbauthz.AsSystemRestricted(ctx), database.GetUserByEmailOrUsernameParams{
+	dbUser, err := api.Database.GetUserByEmailOrUsername(dbauthz.AsSCIM(ctx), database.GetUserByEmailOrUsernameParams{
 		Email:    email,
 		Username: sUser.UserName,
 	})
@@ -186,7 +377,7 @@ func (api *API) scimPostUser(rw http.ResponseWriter, r *http.Request) {
 
 		if sUser.Active && dbUser.Status == database.UserStatusSuspended {
 			//nolint:gocritic
-			newUser, err := api.Database.UpdateUserStatus(dbauthz.AsSystemRestricted(r.Context()), database.UpdateUserStatusParams{
+			newUser, err := api.Database.UpdateUserStatus(dbauthz.AsSCIM(r.Context()), database.UpdateUserStatusParams{
 				ID: dbUser.ID,
 				// The user will get transitioned to Active after logging in.
 				Status:    database.UserStatusDormant,
@@ -225,14 +416,14 @@ func (api *API) scimPostUser(rw http.ResponseWriter, r *http.Request) {
 	// 	deployments. This assumption places all new SCIM users into the
 	//	default organization.
 	//nolint:gocritic
-	defaultOrganization, err := api.Database.GetDefaultOrganization(dbauthz.AsSystemRestricted(ctx))
+	defaultOrganization, err := api.Database.GetDefaultOrganization(dbauthz.AsSCIM(ctx))
 	if err != nil {
 		_ = handlerutil.WriteError(rw, err)
 		return
 	}
 
 	//nolint:gocritic // needed for SCIM
-	dbUser, _, err = api.AGPL.CreateUser(dbauthz.AsSystemRestricted(ctx), api.Database, agpl.CreateUserRequest{
+	dbUser, _, err = api.AGPL.CreateUser(dbauthz.AsSCIM(ctx), api.Database, agpl.CreateUserRequest{
 		CreateUserRequest: codersdk.CreateUserRequest{
 			Username:       sUser.UserName,
 			Email:          email,
@@ -244,6 +435,8 @@ func (api *API) scimPostUser(rw http.ResponseWriter, r *http.Request) {
 		_ = handlerutil.WriteError(rw, err)
 		return
 	}
+	//nolint:gocritic // needed for SCIM
+	api.AGPL.NotifyUserCreated(dbauthz.AsSCIM(ctx), dbUser)
 	aReq.New = dbUser
 	aReq.UserID = dbUser.ID
 
@@ -298,7 +491,7 @@ func (api *API) scimPatchUser(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	//nolint:gocritic // needed for SCIM
-	dbUser, err := api.Database.GetUserByID(dbauthz.AsSystemRestricted(ctx), uid)
+	dbUser, err := api.Database.GetUserByID(dbauthz.AsSCIM(ctx), uid)
 	if err != nil {
 		_ = handlerutil.WriteError(rw, err)
 		return
@@ -306,27 +499,10 @@ func (api *API) scimPatchUser(rw http.ResponseWriter, r *http.Request) {
 	aReq.Old = dbUser
 	aReq.UserID = dbUser.ID
 
-	var status database.UserStatus
-	if sUser.Active {
-		switch dbUser.Status {
-		case database.UserStatusActive:
-			// Keep the user active
-			status = database.UserStatusActive
-		case database.UserStatusDormant, database.UserStatusSuspended:
-			// Move (or keep) as dormant
-			status = database.UserStatusDormant
-		default:
-			// If the status is unknown, just move them to dormant.
-			// The user will get transitioned to Active after logging in.
-			status = database.UserStatusDormant
-		}
-	} else {
-		status = database.UserStatusSuspended
-	}
-
+	status := scimUserStatus(sUser.Active, dbUser.Status)
 	if dbUser.Status != status {
 		//nolint:gocritic // needed for SCIM
-		userNew, err := api.Database.UpdateUserStatus(dbauthz.AsSystemRestricted(r.Context()), database.UpdateUserStatusParams{
+		userNew, err := api.Database.UpdateUserStatus(dbauthz.AsSCIM(r.Context()), database.UpdateUserStatusParams{
 			ID:        dbUser.ID,
 			Status:    status,
 			UpdatedAt: dbtime.Now(),
@@ -336,6 +512,9 @@ func (api *API) scimPatchUser(rw http.ResponseWriter, r *http.Request) {
 			return
 		}
 		dbUser = userNew
+		if status == database.UserStatusSuspended {
+			api.AGPL.NotifyUserSuspended(ctx, dbUser, uuid.Nil, "SCIM")
+		}
 	} else {
 		// Do not push an audit log if there is no change.
 		commitAudit(false)
@@ -344,3 +523,218 @@ func (api *API) scimPatchUser(rw http.ResponseWriter, r *http.Request) {
 	aReq.New = dbUser
 	httpapi.Write(ctx, rw, http.StatusOK, sUser)
 }
+
+// scimPutUser replaces the username, name, email address and status of a
+// user. Usernames that are invalid in Coder, such as email addresses, leave
+// the username unchanged.
+//
+// @Summary SCIM 2.0: Replace user
+// @ID scim-replace-user
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Enterprise
+// @Param id path string true "User ID" format(uuid)
+// @Param request body coderd.SCIMUser true "Replace user request"
+// @Success 200 {object} coderd.SCIMUser
+// @Router /scim/v2/Users/{id} [put]
+func (api *API) scimPutUser(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !api.scimAuthorized(rw, r) {
+		return
+	}
+
+	auditor := *api.AGPL.Auditor.Load()
+	aReq, commitAudit := audit.InitRequestWithCancel[database.User](rw, &audit.RequestParams{
+		Audit:            auditor,
+		Log:              api.Logger,
+		Request:          r,
+		Action:           database.AuditActionWrite,
+		AdditionalFields: SCIMAuditAdditionalFields,
+	})
+	defer commitAudit(true)
+
+	var sUser SCIMUser
+	err := json.NewDecoder(r.Body).Decode(&sUser)
+	if err != nil {
+		scimError(rw, spec.ErrInvalidSyntax, err.Error())
+		return
+	}
+
+	dbUser, ok := api.scimUser(rw, r)
+	if !ok {
+		return
+	}
+	aReq.Old = dbUser
+	aReq.UserID = dbUser.ID
+
+	profile := database.UpdateUserProfileParams{
+		ID:        dbUser.ID,
+		Email:     dbUser.Email,
+		Username:  dbUser.Username,
+		AvatarURL: dbUser.AvatarURL,
+		Name:      httpapi.NormalizeRealUsername(sUser.Name.GivenName + " " + sUser.Name.FamilyName),
+		UpdatedAt: dbtime.Now(),
+	}
+	if httpapi.NameValid(sUser.UserName) == nil {
+		profile.Username = sUser.UserName
+	}
+	for _, e := range sUser.Emails {
+		if e.Primary && e.Value != "" {
+			profile.Email = e.Value
+			break
+		}
+	}
+	status := scimUserStatus(sUser.Active, dbUser.Status)
+
+	if profile.Username == dbUser.Username && profile.Email == dbUser.Email &&
+		profile.Name == dbUser.Name && status == dbUser.Status {
+		// Do not push an audit log if there is no change.
+		commitAudit(false)
+		httpapi.Write(ctx, rw, http.StatusOK, scimUserFromDB(dbUser))
+		return
+	}
+
+	newUser := dbUser
+	err = api.Database.InTx(func(tx database.Store) error {
+		//nolint:gocritic // needed for SCIM
+		newUser, err = tx.UpdateUserProfile(dbauthz.AsSCIM(ctx), profile)
+		if err != nil {
+			return xerrors.Errorf("update user profile: %w", err)
+		}
+		if status != dbUser.Status {
+			//nolint:gocritic // needed for SCIM
+			newUser, err = tx.UpdateUserStatus(dbauthz.AsSCIM(ctx), database.UpdateUserStatusParams{
+				ID:        dbUser.ID,
+				Status:    status,
+				UpdatedAt: dbtime.Now(),
+			})
+			if err != nil {
+				return xerrors.Errorf("update user status: %w", err)
+			}
+		}
+		return nil
+	}, nil)
+	if database.IsUniqueViolation(err) {
+		scimError(rw, spec.ErrUniqueness, "the username or email address is already in use")
+		return
+	}
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	if status != dbUser.Status && status == database.UserStatusSuspended {
+		api.AGPL.NotifyUserSuspended(ctx, newUser, uuid.Nil, "SCIM")
+	}
+
+	aReq.New = newUser
+	httpapi.Write(ctx, rw, http.StatusOK, scimUserFromDB(newUser))
+}
+
+// scimDeleteUser deletes a user. Users that own workspaces cannot be deleted,
+// deactivate them instead.
+//
+// @Summary SCIM 2.0: Delete user
+// @ID scim-delete-user
+// @Security CoderSessionToken
+// @Tags Enterprise
+// @Param id path string true "User ID" format(uuid)
+// @Success 204
+// @Router /scim/v2/Users/{id} [delete]
+func (api *API) scimDeleteUser(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !api.scimAuthorized(rw, r) {
+		return
+	}
+
+	auditor := *api.AGPL.Auditor.Load()
+	aReq, commitAudit := audit.InitRequest[database.User](rw, &audit.RequestParams{
+		Audit:            auditor,
+		Log:              api.Logger,
+		Request:          r,
+		Action:           database.AuditActionDelete,
+		AdditionalFields: SCIMAuditAdditionalFields,
+	})
+	defer commitAudit()
+
+	dbUser, ok := api.scimUser(rw, r)
+	if !ok {
+		return
+	}
+	aReq.Old = dbUser
+	aReq.UserID = dbUser.ID
+
+	//nolint:gocritic // needed for SCIM
+	workspaces, err := api.Database.GetWorkspaces(dbauthz.AsSCIM(ctx), database.GetWorkspacesParams{
+		OwnerID: dbUser.ID,
+	})
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	if len(workspaces) > 0 {
+		scimError(rw, scimErrConflict, "the user owns workspaces, delete them or deactivate the user instead")
+		return
+	}
+
+	//nolint:gocritic // needed for SCIM
+	err = api.Database.UpdateUserDeletedByID(dbauthz.AsSCIM(ctx), dbUser.ID)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	dbUser.Deleted = true
+	aReq.New = dbUser
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// scimServiceProviderConfig describes the SCIM features that are supported.
+//
+// @Summary SCIM 2.0: Get service provider config
+// @ID scim-get-service-provider-config
+// @Security CoderSessionToken
+// @Produce application/scim+json
+// @Tags Enterprise
+// @Success 200
+// @Router /scim/v2/ServiceProviderConfig [get]
+func (api *API) scimServiceProviderConfig(rw http.ResponseWriter, r *http.Request) {
+	if !api.scimAuthorized(rw, r) {
+		return
+	}
+
+	httpapi.Write(r.Context(), rw, http.StatusOK, scim.NewServiceProviderConfig("https://coder.com/docs/admin/auth#scim-enterprise"))
+}
+
+// scimSchemas returns the definitions of the supported resources.
+//
+// @Summary SCIM 2.0: Get schemas
+// @ID scim-get-schemas
+// @Security CoderSessionToken
+// @Produce application/scim+json
+// @Tags Enterprise
+// @Success 200
+// @Router /scim/v2/Schemas [get]
+func (api *API) scimSchemas(rw http.ResponseWriter, r *http.Request) {
+	if !api.scimAuthorized(rw, r) {
+		return
+	}
+
+	httpapi.Write(r.Context(), rw, http.StatusOK, scim.NewListResponse(scim.Schemas(), scim.Page{StartIndex: 1, Count: scim.MaxResults}))
+}
+
+// scimResourceTypes returns the types of the supported resources.
+//
+// @Summary SCIM 2.0: Get resource types
+// @ID scim-get-resource-types
+// @Security CoderSessionToken
+// @Produce application/scim+json
+// @Tags Enterprise
+// @Success 200
+// @Router /scim/v2/ResourceTypes [get]
+func (api *API) scimResourceTypes(rw http.ResponseWriter, r *http.Request) {
+	if !api.scimAuthorized(rw, r) {
+		return
+	}
+
+	httpapi.Write(r.Context(), rw, http.StatusOK, scim.NewListResponse(scim.ResourceTypes(), scim.Page{StartIndex: 1, Count: scim.MaxResults}))
+}
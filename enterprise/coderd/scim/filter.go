@@ -0,0 +1,353 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/xerrors"
+)
+
+// Attributes are the values of a resource that filters are evaluated against,
+// keyed by the lowercase attribute path, e.g. "username" or "emails.value".
+// Every attribute may have multiple values.
+type Attributes map[string][]string
+
+// Filter is a compiled SCIM filter expression as defined in RFC 7644 section
+// 3.4.2.2. The zero value matches every resource.
+type Filter struct {
+	root filterNode
+}
+
+// ParseFilter compiles a SCIM filter expression, such as
+// `userName eq "alice" and not (active eq false)`. Filters on the
+// sub-attributes of multi-valued attributes, such as `emails[type eq "work"]`,
+// are not supported.
+func ParseFilter(filter string) (Filter, error) {
+	tokens, err := tokenizeFilter(filter)
+	if err != nil {
+		return Filter{}, xerrors.Errorf("invalid filter %q: %w", filter, err)
+	}
+	if len(tokens) == 0 {
+		return Filter{}, nil
+	}
+	p := &filterParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err == nil && p.pos < len(p.tokens) {
+		err = xerrors.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	if err != nil {
+		return Filter{}, xerrors.Errorf("invalid filter %q: %w", filter, err)
+	}
+	return Filter{root: root}, nil
+}
+
+// Match reports whether the resource with the given attributes matches the
+// filter. Attribute names and string comparisons are case-insensitive.
+func (f Filter) Match(attrs Attributes) bool {
+	if f.root == nil {
+		return true
+	}
+	return f.root.match(attrs)
+}
+
+// Columns maps the lowercase attribute paths of a resource to the SQL
+// expressions of their lowercase values, e.g. "username" to
+// "lower(users.username)". Attributes without a column never match.
+type Columns map[string]string
+
+// CompileToSQL returns a boolean SQL expression that agrees with Match, and
+// the arguments it references. Arguments are numbered starting at argStart.
+func (f Filter) CompileToSQL(columns Columns, argStart int) (string, []any) {
+	if f.root == nil {
+		return "TRUE", nil
+	}
+	c := &sqlCompiler{columns: columns, argStart: argStart}
+	return c.compile(f.root), c.args
+}
+
+type sqlCompiler struct {
+	columns  Columns
+	argStart int
+	args     []any
+}
+
+func (c *sqlCompiler) arg(value string) string {
+	c.args = append(c.args, value)
+	return fmt.Sprintf("$%d::text", c.argStart+len(c.args)-1)
+}
+
+func (c *sqlCompiler) compile(node filterNode) string {
+	switch n := node.(type) {
+	case logicalNode:
+		op := "OR"
+		if n.and {
+			op = "AND"
+		}
+		return fmt.Sprintf("(%s %s %s)", c.compile(n.left), op, c.compile(n.right))
+	case notNode:
+		return fmt.Sprintf("(NOT %s)", c.compile(n.filterNode))
+	case compareNode:
+		column, ok := c.columns[n.path]
+		if !ok {
+			column, ok = c.columns[n.path+".value"]
+		}
+		if !ok {
+			// Like Match, a missing attribute has no values.
+			if n.op == "ne" {
+				return "TRUE"
+			}
+			return "FALSE"
+		}
+		switch n.op {
+		case "pr":
+			return fmt.Sprintf("(%s <> '')", column)
+		case "eq":
+			return fmt.Sprintf("(%s = %s)", column, c.arg(n.value))
+		case "ne":
+			return fmt.Sprintf("(%s <> %s)", column, c.arg(n.value))
+		case "co":
+			return fmt.Sprintf("(strpos(%s, %s) > 0)", column, c.arg(n.value))
+		case "sw":
+			return fmt.Sprintf("starts_with(%s, %s)", column, c.arg(n.value))
+		case "ew":
+			value := c.arg(n.value)
+			return fmt.Sprintf("(right(%s, length(%s)) = %s)", column, value, value)
+		default:
+			// Ordering compares bytes, like Match.
+			op := map[string]string{"gt": ">", "ge": ">=", "lt": "<", "le": "<="}[n.op]
+			return fmt.Sprintf("(%s COLLATE \"C\" %s %s)", column, op, c.arg(n.value))
+		}
+	}
+	panic(fmt.Sprintf("unknown filter node %T", node))
+}
+
+type filterNode interface {
+	match(attrs Attributes) bool
+}
+
+type logicalNode struct {
+	and         bool
+	left, right filterNode
+}
+
+func (n logicalNode) match(attrs Attributes) bool {
+	if n.and {
+		return n.left.match(attrs) && n.right.match(attrs)
+	}
+	return n.left.match(attrs) || n.right.match(attrs)
+}
+
+type notNode struct {
+	filterNode
+}
+
+func (n notNode) match(attrs Attributes) bool {
+	return !n.filterNode.match(attrs)
+}
+
+type compareNode struct {
+	path  string
+	op    string
+	value string
+}
+
+var compareOps = map[string]func(value, want string) bool{
+	"eq": func(value, want string) bool { return value == want },
+	"ne": func(value, want string) bool { return value == want },
+	"co": strings.Contains,
+	"sw": strings.HasPrefix,
+	"ew": strings.HasSuffix,
+	// Ordering compares strings, which is correct for timestamps.
+	"gt": func(value, want string) bool { return value > want },
+	"ge": func(value, want string) bool { return value >= want },
+	"lt": func(value, want string) bool { return value < want },
+	"le": func(value, want string) bool { return value <= want },
+}
+
+func (n compareNode) match(attrs Attributes) bool {
+	values, ok := attrs[n.path]
+	if !ok {
+		// A complex attribute without a sub-attribute refers to its "value"
+		// sub-attribute, e.g. "emails" refers to "emails.value".
+		values = attrs[n.path+".value"]
+	}
+
+	if n.op == "pr" {
+		for _, value := range values {
+			if value != "" {
+				return true
+			}
+		}
+		return false
+	}
+
+	// A multi-valued attribute matches if any of its values match.
+	found := false
+	for _, value := range values {
+		if compareOps[n.op](strings.ToLower(value), n.value) {
+			found = true
+			break
+		}
+	}
+	if n.op == "ne" {
+		return !found
+	}
+	return found
+}
+
+type filterParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *filterParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() (string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", xerrors.New("unexpected end of filter")
+	}
+	p.pos++
+	return p.tokens[p.pos-1], nil
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "and") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	token, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case strings.EqualFold(token, "not"):
+		if p.peek() != "(" {
+			return nil, xerrors.New(`"not" must be followed by "("`)
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{node}, nil
+	case token == "(":
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		closing, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if closing != ")" {
+			return nil, xerrors.Errorf(`expected ")", got %q`, closing)
+		}
+		return node, nil
+	case token == ")" || strings.HasPrefix(token, `"`):
+		return nil, xerrors.Errorf("expected an attribute, got %q", token)
+	}
+
+	// Attributes may be prefixed with the URN of their schema.
+	path := strings.ToLower(token[strings.LastIndex(token, ":")+1:])
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	op = strings.ToLower(op)
+	if op == "pr" {
+		return compareNode{path: path, op: op}, nil
+	}
+	if _, ok := compareOps[op]; !ok {
+		return nil, xerrors.Errorf("unsupported operator %q", op)
+	}
+
+	value, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if value == "(" || value == ")" {
+		return nil, xerrors.Errorf("expected a value, got %q", value)
+	}
+	if strings.HasPrefix(value, `"`) {
+		err = json.Unmarshal([]byte(value), &value)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid string: %w", err)
+		}
+	}
+	// true, false, null and numbers are compared by their text.
+	return compareNode{path: path, op: op, value: strings.ToLower(value)}, nil
+}
+
+// tokenizeFilter splits a filter into parentheses, quoted strings and words.
+func tokenizeFilter(filter string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(filter); {
+		c := filter[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '[' || c == ']':
+			return nil, xerrors.New("filters on sub-attributes are not supported")
+		case c == '"':
+			end := i + 1
+			for ; end < len(filter) && filter[end] != '"'; end++ {
+				if filter[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(filter) {
+				return nil, xerrors.New("unterminated string")
+			}
+			tokens = append(tokens, filter[i:end+1])
+			i = end + 1
+		default:
+			end := strings.IndexAny(filter[i:], " \t\r\n()[]\"")
+			if end < 0 {
+				end = len(filter)
+			} else {
+				end += i
+			}
+			tokens = append(tokens, filter[i:end])
+			i = end
+		}
+	}
+	return tokens, nil
+}
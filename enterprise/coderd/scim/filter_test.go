@@ -0,0 +1,131 @@
+package scim_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/enterprise/coderd/scim"
+)
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	attrs := scim.Attributes{
+		"id":             {"7b2e"},
+		"username":       {"Alice"},
+		"emails.value":   {"alice@coder.com", "alice@example.com"},
+		"name.formatted": {""},
+		"active":         {"true"},
+	}
+
+	for _, tc := range []struct {
+		filter string
+		match  bool
+	}{
+		{filter: "", match: true},
+		{filter: `userName eq "alice"`, match: true},
+		{filter: `USERNAME eq "ALICE"`, match: true},
+		{filter: `userName eq "bob"`, match: false},
+		{filter: `userName ne "bob"`, match: true},
+		{filter: `userName sw "al"`, match: true},
+		{filter: `userName ew "ce"`, match: true},
+		{filter: `userName co "lic"`, match: true},
+		{filter: `emails eq "alice@example.com"`, match: true},
+		{filter: `emails.value ew "@coder.com"`, match: true},
+		{filter: `emails.value ne "alice@coder.com"`, match: false},
+		{filter: `active eq true`, match: true},
+		{filter: `active eq false`, match: false},
+		{filter: `id pr`, match: true},
+		{filter: `name.formatted pr`, match: false},
+		{filter: `externalId pr`, match: false},
+		{filter: `userName eq "alice" and active eq false`, match: false},
+		{filter: `userName eq "bob" or active eq true`, match: true},
+		{filter: `not (userName eq "alice")`, match: false},
+		{filter: `(userName eq "bob" or userName eq "alice") and emails co "coder"`, match: true},
+	} {
+		filter, err := scim.ParseFilter(tc.filter)
+		require.NoError(t, err, tc.filter)
+		require.Equal(t, tc.match, filter.Match(attrs), tc.filter)
+	}
+
+	for _, invalid := range []string{
+		`userName`,
+		`userName eq`,
+		`"alice" eq userName`,
+		`(userName eq "alice"`,
+		`userName is "alice"`,
+		`userName eq "alice`,
+		`emails[type eq "work"]`,
+		`not userName eq "alice"`,
+	} {
+		_, err := scim.ParseFilter(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestFilterCompileToSQL(t *testing.T) {
+	t.Parallel()
+
+	columns := scim.Columns{
+		"username":     "lower(users.username)",
+		"emails.value": "lower(users.email)",
+	}
+
+	for _, tc := range []struct {
+		filter string
+		sql    string
+		args   []any
+	}{
+		{filter: "", sql: "TRUE"},
+		{filter: `userName eq "Alice"`, sql: "(lower(users.username) = $3::text)", args: []any{"alice"}},
+		{filter: `emails ew "@coder.com"`, sql: "(right(lower(users.email), length($3::text)) = $3::text)", args: []any{"@coder.com"}},
+		{filter: `userName pr`, sql: "(lower(users.username) <> '')"},
+		{filter: `externalId eq "1"`, sql: "FALSE"},
+		{filter: `externalId ne "1"`, sql: "TRUE"},
+		{
+			filter: `userName sw "a" and not (emails co "example")`,
+			sql:    "(starts_with(lower(users.username), $3::text) AND (NOT (strpos(lower(users.email), $4::text) > 0)))",
+			args:   []any{"a", "example"},
+		},
+		{
+			filter: `userName gt "b" or userName le "a"`,
+			sql:    `((lower(users.username) COLLATE "C" > $3::text) OR (lower(users.username) COLLATE "C" <= $4::text))`,
+			args:   []any{"b", "a"},
+		},
+	} {
+		filter, err := scim.ParseFilter(tc.filter)
+		require.NoError(t, err, tc.filter)
+		sql, args := filter.CompileToSQL(columns, 3)
+		require.Equal(t, tc.sql, sql, tc.filter)
+		require.Equal(t, tc.args, args, tc.filter)
+	}
+}
+
+func TestListResponse(t *testing.T) {
+	t.Parallel()
+
+	resources := []string{"a", "b", "c", "d", "e"}
+	page, err := scim.ParsePage(url.Values{})
+	require.NoError(t, err)
+	res := scim.NewListResponse(resources, page)
+	require.Equal(t, 5, res.TotalResults)
+	require.Equal(t, 5, res.ItemsPerPage)
+
+	page, err = scim.ParsePage(url.Values{"startIndex": {"2"}, "count": {"2"}})
+	require.NoError(t, err)
+	res = scim.NewListResponse(resources, page)
+	require.Equal(t, 5, res.TotalResults)
+	require.Equal(t, 2, res.StartIndex)
+	require.Equal(t, []any{"b", "c"}, res.Resources)
+
+	page, err = scim.ParsePage(url.Values{"startIndex": {"0"}, "count": {"0"}})
+	require.NoError(t, err)
+	res = scim.NewListResponse(resources, page)
+	require.Equal(t, 1, res.StartIndex)
+	require.Empty(t, res.Resources)
+
+	_, err = scim.ParsePage(url.Values{"count": {"many"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,189 @@
+// Package scim implements the parts of the SCIM 2.0 protocol (RFC 7643 and
+// RFC 7644) that are used by the provisioning endpoints of Coder.
+package scim
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+)
+
+// MaxResults is the maximum number of resources returned in a single list
+// response.
+const MaxResults = 1000
+
+// Page is the page of resources requested with the startIndex and count query
+// parameters.
+type Page struct {
+	// StartIndex is the 1-based index of the first resource.
+	StartIndex int
+	Count      int
+}
+
+// ParsePage reads the page from the query parameters of a list request. The
+// page contains up to MaxResults resources by default.
+func ParsePage(query url.Values) (Page, error) {
+	page := Page{StartIndex: 1, Count: MaxResults}
+	if raw := query.Get("startIndex"); raw != "" {
+		startIndex, err := strconv.Atoi(raw)
+		if err != nil {
+			return Page{}, xerrors.Errorf("parse startIndex: %w", err)
+		}
+		// Values less than 1 are interpreted as 1.
+		page.StartIndex = max(startIndex, 1)
+	}
+	if raw := query.Get("count"); raw != "" {
+		count, err := strconv.Atoi(raw)
+		if err != nil {
+			return Page{}, xerrors.Errorf("parse count: %w", err)
+		}
+		// Negative values are interpreted as 0.
+		page.Count = min(max(count, 0), MaxResults)
+	}
+	return page, nil
+}
+
+// ListResponse is the response to a query for resources.
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// NewListResponse returns the requested page of the resources.
+func NewListResponse[T any](resources []T, page Page) ListResponse {
+	res := ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   page.StartIndex,
+		Resources:    []any{},
+	}
+	for i := page.StartIndex - 1; i < len(resources) && len(res.Resources) < page.Count; i++ {
+		res.Resources = append(res.Resources, resources[i])
+	}
+	res.ItemsPerPage = len(res.Resources)
+	return res
+}
+
+// NewPagedListResponse returns a list response for resources that were
+// already paginated, out of total resources matching the query.
+func NewPagedListResponse[T any](resources []T, total int, page Page) ListResponse {
+	res := ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   page.StartIndex,
+		ItemsPerPage: len(resources),
+		Resources:    make([]any, 0, len(resources)),
+	}
+	for _, resource := range resources {
+		res.Resources = append(res.Resources, resource)
+	}
+	return res
+}
+
+// PatchRequest is the body of a PATCH request, which modifies a resource with
+// a list of operations.
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is a single modification of a resource. Path is empty when
+// Value contains the attributes to modify.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ServiceProviderConfig describes the SCIM features that Coder supports.
+type ServiceProviderConfig struct {
+	Schemas               []string               `json:"schemas"`
+	DocumentationURI      string                 `json:"documentationUri,omitempty"`
+	Patch                 Supported              `json:"patch"`
+	Bulk                  BulkConfig             `json:"bulk"`
+	Filter                FilterConfig           `json:"filter"`
+	ChangePassword        Supported              `json:"changePassword"`
+	Sort                  Supported              `json:"sort"`
+	ETag                  Supported              `json:"etag"`
+	AuthenticationSchemes []AuthenticationScheme `json:"authenticationSchemes"`
+}
+
+type Supported struct {
+	Supported bool `json:"supported"`
+}
+
+type BulkConfig struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type FilterConfig struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type AuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// NewServiceProviderConfig returns the configuration of the service provider.
+func NewServiceProviderConfig(documentationURI string) ServiceProviderConfig {
+	return ServiceProviderConfig{
+		Schemas:          []string{SchemaServiceProviderConfig},
+		DocumentationURI: documentationURI,
+		Patch:            Supported{Supported: true},
+		Bulk:             BulkConfig{Supported: false},
+		Filter:           FilterConfig{Supported: true, MaxResults: MaxResults},
+		ChangePassword:   Supported{Supported: false},
+		Sort:             Supported{Supported: false},
+		ETag:             Supported{Supported: false},
+		AuthenticationSchemes: []AuthenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "API key",
+			Description: "The Authorization header contains the SCIM API key of the deployment.",
+		}},
+	}
+}
+
+var (
+	//go:embed schemas.json
+	schemasJSON []byte
+	//go:embed resourcetypes.json
+	resourceTypesJSON []byte
+)
+
+// Schemas returns the definitions of the User and Group resources.
+func Schemas() []json.RawMessage {
+	return mustUnmarshalList(schemasJSON)
+}
+
+// ResourceTypes returns the types of resources that are available.
+func ResourceTypes() []json.RawMessage {
+	return mustUnmarshalList(resourceTypesJSON)
+}
+
+func mustUnmarshalList(data []byte) []json.RawMessage {
+	var list []json.RawMessage
+	err := json.Unmarshal(data, &list)
+	if err != nil {
+		panic(xerrors.Errorf("unmarshal embedded list: %w", err))
+	}
+	return list
+}
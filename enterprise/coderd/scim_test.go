@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -16,11 +18,13 @@ import (
 	"github.com/coder/coder/v2/coderd/coderdtest"
 	"github.com/coder/coder/v2/coderd/coderdtest/oidctest"
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/cryptorand"
 	"github.com/coder/coder/v2/enterprise/coderd"
 	"github.com/coder/coder/v2/enterprise/coderd/coderdenttest"
 	"github.com/coder/coder/v2/enterprise/coderd/license"
+	"github.com/coder/coder/v2/enterprise/coderd/scim"
 	"github.com/coder/coder/v2/testutil"
 )
 
@@ -444,4 +448,221 @@ func TestScim(t *testing.T) {
 			require.Equal(t, codersdk.UserStatusActive, scimUser.Status, "user is still active")
 		})
 	})
+
+	t.Run("getUsers", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		scimAPIKey := []byte("hi")
+		client, _ := coderdenttest.New(t, &coderdenttest.Options{
+			SCIMAPIKey: scimAPIKey,
+			LicenseOptions: &coderdenttest.LicenseOptions{
+				AccountID: "coolin",
+				Features: license.Features{
+					codersdk.FeatureSCIM: 1,
+				},
+			},
+		})
+
+		sUser := makeScimUser(t)
+		res, err := client.Request(ctx, "POST", "/scim/v2/Users", sUser, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		list := func(query string) scim.ListResponse {
+			res, err := client.Request(ctx, "GET", "/scim/v2/Users?"+query, nil, setScimAuth(scimAPIKey))
+			require.NoError(t, err)
+			defer res.Body.Close()
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			var list scim.ListResponse
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&list))
+			return list
+		}
+
+		// The first user and the SCIM user.
+		require.Equal(t, 2, list("").TotalResults)
+		require.Equal(t, 1, list("count=1").ItemsPerPage)
+		require.Equal(t, 2, list("count=1").TotalResults)
+		require.Equal(t, 1, list("startIndex=2").ItemsPerPage)
+		// Pages past the end still report the total.
+		past := list("startIndex=5")
+		require.Equal(t, 2, past.TotalResults)
+		require.Empty(t, past.Resources)
+		require.Empty(t, list("count=0").Resources)
+		require.Equal(t, 2, list("count=0").TotalResults)
+		require.Equal(t, 1, list(url.Values{"filter": {fmt.Sprintf("userName eq %q", sUser.UserName)}}.Encode()).TotalResults)
+		require.Equal(t, 1, list(url.Values{"filter": {fmt.Sprintf("emails.value eq %q and active eq true", sUser.Emails[0].Value)}}.Encode()).TotalResults)
+		require.Equal(t, 0, list(url.Values{"filter": {`userName eq "nobody"`}}.Encode()).TotalResults)
+
+		res, err = client.Request(ctx, "GET", "/scim/v2/Users?filter="+url.QueryEscape("userName eq"), nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("putUser", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		scimAPIKey := []byte("hi")
+		mockAudit := audit.NewMock()
+		client, _ := coderdenttest.New(t, &coderdenttest.Options{
+			Options:      &coderdtest.Options{Auditor: mockAudit},
+			SCIMAPIKey:   scimAPIKey,
+			AuditLogging: true,
+			LicenseOptions: &coderdenttest.LicenseOptions{
+				AccountID: "coolin",
+				Features: license.Features{
+					codersdk.FeatureSCIM:     1,
+					codersdk.FeatureAuditLog: 1,
+				},
+			},
+		})
+
+		sUser := makeScimUser(t)
+		res, err := client.Request(ctx, "POST", "/scim/v2/Users", sUser, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		err = json.NewDecoder(res.Body).Decode(&sUser)
+		require.NoError(t, err)
+
+		// Email addresses are not valid usernames, so the username is kept.
+		oldUserName := sUser.UserName
+		sUser.UserName = "renamed@coder.com"
+		sUser.Name.GivenName = "Alice"
+		sUser.Name.FamilyName = "Smith"
+		sUser.Emails[0].Value = "alice@coder.com"
+		sUser.Active = false
+		mockAudit.ResetLogs()
+		res, err = client.Request(ctx, "PUT", "/scim/v2/Users/"+sUser.ID, sUser, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		var putUser coderd.SCIMUser
+		err = json.NewDecoder(res.Body).Decode(&putUser)
+		require.NoError(t, err)
+		require.Equal(t, oldUserName, putUser.UserName)
+		require.False(t, putUser.Active)
+
+		aLogs := mockAudit.AuditLogs()
+		require.Len(t, aLogs, 1)
+		assert.Equal(t, database.AuditActionWrite, aLogs[0].Action)
+
+		user, err := client.User(ctx, sUser.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Alice Smith", user.Name)
+		require.Equal(t, "alice@coder.com", user.Email)
+		require.Equal(t, codersdk.UserStatusSuspended, user.Status)
+
+		// Valid usernames are updated.
+		sUser.UserName = "renamed"
+		res, err = client.Request(ctx, "PUT", "/scim/v2/Users/"+sUser.ID, sUser, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		user, err = client.User(ctx, sUser.ID)
+		require.NoError(t, err)
+		require.Equal(t, "renamed", user.Username)
+	})
+
+	t.Run("deleteUser", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		scimAPIKey := []byte("hi")
+		client, db, owner := coderdenttest.NewWithDatabase(t, &coderdenttest.Options{
+			SCIMAPIKey: scimAPIKey,
+			LicenseOptions: &coderdenttest.LicenseOptions{
+				AccountID: "coolin",
+				Features: license.Features{
+					codersdk.FeatureSCIM: 1,
+				},
+			},
+		})
+
+		createUser := func() coderd.SCIMUser {
+			sUser := makeScimUser(t)
+			res, err := client.Request(ctx, "POST", "/scim/v2/Users", sUser, setScimAuth(scimAPIKey))
+			require.NoError(t, err)
+			defer res.Body.Close()
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			err = json.NewDecoder(res.Body).Decode(&sUser)
+			require.NoError(t, err)
+			return sUser
+		}
+
+		sUser := createUser()
+		res, err := client.Request(ctx, "DELETE", "/scim/v2/Users/"+sUser.ID, nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusNoContent, res.StatusCode)
+
+		res, err = client.Request(ctx, "GET", "/scim/v2/Users/"+sUser.ID, nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusNotFound, res.StatusCode)
+
+		// Users that own workspaces cannot be deleted.
+		sUser = createUser()
+		dbfake.WorkspaceBuild(t, db, database.Workspace{
+			OrganizationID: owner.OrganizationID,
+			OwnerID:        uuid.MustParse(sUser.ID),
+		}).Do()
+		res, err = client.Request(ctx, "DELETE", "/scim/v2/Users/"+sUser.ID, nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+
+	t.Run("discovery", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		scimAPIKey := []byte("hi")
+		client, _ := coderdenttest.New(t, &coderdenttest.Options{
+			SCIMAPIKey: scimAPIKey,
+			LicenseOptions: &coderdenttest.LicenseOptions{
+				AccountID: "coolin",
+				Features: license.Features{
+					codersdk.FeatureSCIM: 1,
+				},
+			},
+		})
+
+		res, err := client.Request(ctx, "GET", "/scim/v2/ServiceProviderConfig", nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		var config scim.ServiceProviderConfig
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&config))
+		require.True(t, config.Patch.Supported)
+		require.True(t, config.Filter.Supported)
+
+		for _, path := range []string{"/scim/v2/Schemas", "/scim/v2/ResourceTypes"} {
+			res, err := client.Request(ctx, "GET", path, nil, setScimAuth(scimAPIKey))
+			require.NoError(t, err)
+			defer res.Body.Close()
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			var list scim.ListResponse
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&list))
+			require.Equal(t, 2, list.TotalResults, path)
+		}
+
+		res, err = client.Request(ctx, "GET", "/scim/v2/Schemas", nil)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
 }
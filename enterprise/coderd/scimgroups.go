@@ -0,0 +1,742 @@
+package coderd
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/imulab/go-scim/pkg/v2/handlerutil"
+	"github.com/imulab/go-scim/pkg/v2/spec"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/enterprise/coderd/scim"
+)
+
+// SCIMGroup is a group in the default organization. The Everyone group cannot
+// be managed with SCIM.
+type SCIMGroup struct {
+	Schemas     []string          `json:"schemas"`
+	ID          string            `json:"id"`
+	DisplayName string            `json:"displayName"`
+	Members     []SCIMGroupMember `json:"members,omitempty"`
+	Meta        struct {
+		ResourceType string `json:"resourceType"`
+	} `json:"meta"`
+}
+
+type SCIMGroupMember struct {
+	Value   string `json:"value" format:"uuid"`
+	Display string `json:"display,omitempty"`
+}
+
+func scimGroupFromDB(group database.Group, members []database.User) SCIMGroup {
+	sGroup := SCIMGroup{
+		Schemas:     []string{scim.SchemaGroup},
+		ID:          group.ID.String(),
+		DisplayName: group.DisplayName,
+		Members:     []SCIMGroupMember{},
+	}
+	if sGroup.DisplayName == "" {
+		sGroup.DisplayName = group.Name
+	}
+	for _, member := range members {
+		sGroup.Members = append(sGroup.Members, SCIMGroupMember{
+			Value:   member.ID.String(),
+			Display: member.Username,
+		})
+	}
+	sGroup.Meta.ResourceType = "Group"
+	return sGroup
+}
+
+// scimGroupAttributes returns the attributes of the group that can be used in
+// filters.
+func scimGroupAttributes(group database.Group, members []database.User) scim.Attributes {
+	attrs := scim.Attributes{
+		"id":          {group.ID.String()},
+		"displayname": {group.DisplayName, group.Name},
+	}
+	for _, member := range members {
+		attrs["members.value"] = append(attrs["members.value"], member.ID.String())
+		attrs["members.display"] = append(attrs["members.display"], member.Username)
+	}
+	return attrs
+}
+
+var (
+	scimGroupNameReplace = regexp.MustCompile(`[^a-zA-Z0-9-]+`)
+	scimGroupNameHyphens = regexp.MustCompile(`-{2,}`)
+)
+
+// scimGroupName returns the name of a group with the given display name. Display
+// names are used as names as-is if they are valid, e.g. "Engineering" or
+// "eng-team". Otherwise, they are normalized, so "Platform Team (EU)" becomes
+// "Platform-Team-EU".
+func scimGroupName(displayName string) (string, error) {
+	if httpapi.NameValid(displayName) == nil && displayName != database.EveryoneGroup {
+		return displayName, nil
+	}
+	name := scimGroupNameReplace.ReplaceAllString(displayName, "-")
+	name = scimGroupNameHyphens.ReplaceAllString(name, "-")
+	if len(name) > 32 {
+		name = name[:32]
+	}
+	name = strings.Trim(name, "-")
+	if err := httpapi.NameValid(name); err != nil || name == database.EveryoneGroup {
+		return "", xerrors.Errorf("cannot derive a group name from displayName %q: %w", displayName, spec.ErrInvalidValue)
+	}
+	return name, nil
+}
+
+// scimExcludeMembers reports whether the members of groups are excluded from
+// the response. Okta and Azure AD exclude members when looking up groups,
+// since groups can be large.
+func scimExcludeMembers(r *http.Request) bool {
+	for _, attr := range strings.Split(r.URL.Query().Get("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attr), "members") {
+			return true
+		}
+	}
+	return false
+}
+
+// scimGetGroups returns the groups of the default organization that match the
+// filter of the request.
+//
+// @Summary SCIM 2.0: Get groups
+// @ID scim-get-groups
+// @Security CoderSessionToken
+// @Produce application/scim+json
+// @Tags Enterprise
+// @Param filter query string false "SCIM filter expression"
+// @Param startIndex query int false "1-based index of the first result"
+// @Param count query int false "Maximum number of results"
+// @Param excludedAttributes query string false "Set to members to omit the members of groups"
+// @Success 200
+// @Router /scim/v2/Groups [get]
+func (api *API) scimGetGroups(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !api.scimAuthorized(rw, r) {
+		return
+	}
+	filter, page, ok := scimListParams(rw, r)
+	if !ok {
+		return
+	}
+
+	//nolint:gocritic // needed for SCIM
+	defaultOrganization, err := api.Database.GetDefaultOrganization(dbauthz.AsSCIM(ctx))
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	//nolint:gocritic // needed for SCIM
+	groups, err := api.Database.GetGroups(dbauthz.AsSCIM(ctx))
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	members, err := api.scimMembersByGroup(ctx)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	excludeMembers := scimExcludeMembers(r)
+	sGroups := []SCIMGroup{}
+	for _, group := range groups {
+		if group.OrganizationID != defaultOrganization.ID || group.IsEveryone() {
+			continue
+		}
+		if !filter.Match(scimGroupAttributes(group, members[group.ID])) {
+			continue
+		}
+		sGroup := scimGroupFromDB(group, members[group.ID])
+		if excludeMembers {
+			sGroup.Members = nil
+		}
+		sGroups = append(sGroups, sGroup)
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, scim.NewListResponse(sGroups, page))
+}
+
+// scimMembersByGroup returns the users that are members of every group,
+// except the "Everyone" groups, keyed by group ID. Deleted users are omitted.
+func (api *API) scimMembersByGroup(ctx context.Context) (map[uuid.UUID][]database.User, error) {
+	//nolint:gocritic // needed for SCIM
+	groupMembers, err := api.Database.GetGroupMembers(dbauthz.AsSCIM(ctx))
+	if err != nil {
+		return nil, xerrors.Errorf("get group members: %w", err)
+	}
+	userIDs := make([]uuid.UUID, 0, len(groupMembers))
+	seen := make(map[uuid.UUID]bool, len(groupMembers))
+	for _, member := range groupMembers {
+		if !seen[member.UserID] {
+			seen[member.UserID] = true
+			userIDs = append(userIDs, member.UserID)
+		}
+	}
+	//nolint:gocritic // needed for SCIM
+	users, err := api.Database.GetUsersByIDs(dbauthz.AsSCIM(ctx), userIDs)
+	if err != nil {
+		return nil, xerrors.Errorf("get users: %w", err)
+	}
+	usersByID := make(map[uuid.UUID]database.User, len(users))
+	for _, user := range users {
+		if !user.Deleted {
+			usersByID[user.ID] = user
+		}
+	}
+
+	members := make(map[uuid.UUID][]database.User)
+	for _, member := range groupMembers {
+		if user, ok := usersByID[member.UserID]; ok {
+			members[member.GroupID] = append(members[member.GroupID], user)
+		}
+	}
+	return members, nil
+}
+
+// scimGetGroup returns a group by ID.
+//
+// @Summary SCIM 2.0: Get group by ID
+// @ID scim-get-group-by-id
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Enterprise
+// @Param id path string true "Group ID" format(uuid)
+// @Param excludedAttributes query string false "Set to members to omit the members of the group"
+// @Success 200 {object} coderd.SCIMGroup
+// @Failure 404
+// @Router /scim/v2/Groups/{id} [get]
+func (api *API) scimGetGroup(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !api.scimAuthorized(rw, r) {
+		return
+	}
+
+	//nolint:gocritic // needed for SCIM
+	defaultOrganization, err := api.Database.GetDefaultOrganization(dbauthz.AsSCIM(ctx))
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	group, members, ok := api.scimGroup(rw, r, defaultOrganization.ID)
+	if !ok {
+		return
+	}
+
+	sGroup := scimGroupFromDB(group, members)
+	if scimExcludeMembers(r) {
+		sGroup.Members = nil
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, sGroup)
+}
+
+// scimPostGroup creates a group in the default organization.
+//
+// @Summary SCIM 2.0: Create new group
+// @ID scim-create-new-group
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Enterprise
+// @Param request body coderd.SCIMGroup true "New group"
+// @Success 201 {object} coderd.SCIMGroup
+// @Router /scim/v2/Groups [post]
+func (api *API) scimPostGroup(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !api.scimAuthorized(rw, r) {
+		return
+	}
+
+	//nolint:gocritic // needed for SCIM
+	defaultOrganization, err := api.Database.GetDefaultOrganization(dbauthz.AsSCIM(ctx))
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	var sGroup SCIMGroup
+	err = json.NewDecoder(r.Body).Decode(&sGroup)
+	if err != nil {
+		scimError(rw, spec.ErrInvalidSyntax, err.Error())
+		return
+	}
+	if sGroup.DisplayName == "" {
+		scimError(rw, spec.ErrInvalidValue, "displayName is required")
+		return
+	}
+	if err := httpapi.DisplayNameValid(sGroup.DisplayName); err != nil {
+		scimError(rw, spec.ErrInvalidValue, "invalid displayName: "+err.Error())
+		return
+	}
+	name, err := scimGroupName(sGroup.DisplayName)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	members, err := api.scimGroupMembers(ctx, defaultOrganization.ID, nil, sGroup.Members)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	var group database.Group
+	err = api.Database.InTx(func(tx database.Store) error {
+		//nolint:gocritic // needed for SCIM
+		group, err = tx.InsertGroup(dbauthz.AsSCIM(ctx), database.InsertGroupParams{
+			ID:             uuid.New(),
+			Name:           name,
+			DisplayName:    sGroup.DisplayName,
+			OrganizationID: defaultOrganization.ID,
+		})
+		if err != nil {
+			return xerrors.Errorf("insert group: %w", err)
+		}
+		group, err = scimUpdateGroup(ctx, tx, group, group.DisplayName, nil, members)
+		return err
+	}, nil)
+	if database.IsUniqueViolation(err) {
+		scimError(rw, spec.ErrUniqueness, "a group named "+name+" already exists")
+		return
+	}
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	//nolint:gocritic // needed for SCIM
+	newMembers, err := api.Database.GetGroupMembersByGroupID(dbauthz.AsSCIM(ctx), group.ID)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	api.scimAuditGroup(r, http.StatusCreated, database.AuditActionCreate, database.AuditableGroup{}, group.Auditable(newMembers))
+
+	httpapi.Write(ctx, rw, http.StatusCreated, scimGroupFromDB(group, newMembers))
+}
+
+// scimPutGroup replaces the display name and members of a group.
+//
+// @Summary SCIM 2.0: Replace group
+// @ID scim-replace-group
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Enterprise
+// @Param id path string true "Group ID" format(uuid)
+// @Param request body coderd.SCIMGroup true "Replace group request"
+// @Success 200 {object} coderd.SCIMGroup
+// @Router /scim/v2/Groups/{id} [put]
+func (api *API) scimPutGroup(rw http.ResponseWriter, r *http.Request) {
+	api.scimModifyGroup(rw, r, func(displayName *string, members map[string]SCIMGroupMember) error {
+		var sGroup SCIMGroup
+		err := json.NewDecoder(r.Body).Decode(&sGroup)
+		if err != nil {
+			return xerrors.Errorf("%s: %w", err.Error(), spec.ErrInvalidSyntax)
+		}
+		*displayName = sGroup.DisplayName
+		clear(members)
+		for _, member := range sGroup.Members {
+			members[scimMemberKey(member.Value)] = member
+		}
+		return nil
+	})
+}
+
+// scimPatchGroup modifies the display name and members of a group. Members
+// are added and removed with the "members" path, or removed individually
+// with the `members[value eq "<user id>"]` path.
+//
+// @Summary SCIM 2.0: Update group
+// @ID scim-update-group
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Enterprise
+// @Param id path string true "Group ID" format(uuid)
+// @Param request body scim.PatchRequest true "Update group request"
+// @Success 200 {object} coderd.SCIMGroup
+// @Router /scim/v2/Groups/{id} [patch]
+func (api *API) scimPatchGroup(rw http.ResponseWriter, r *http.Request) {
+	api.scimModifyGroup(rw, r, func(displayName *string, members map[string]SCIMGroupMember) error {
+		var req scim.PatchRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return xerrors.Errorf("%s: %w", err.Error(), spec.ErrInvalidSyntax)
+		}
+		for _, op := range req.Operations {
+			err := scimPatchGroupOperation(op, displayName, members)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// scimModifyGroup updates the display name and members of the group in the
+// path of the request with the modify function. Members are keyed by their
+// user ID.
+func (api *API) scimModifyGroup(rw http.ResponseWriter, r *http.Request, modify func(displayName *string, members map[string]SCIMGroupMember) error) {
+	ctx := r.Context()
+	if !api.scimAuthorized(rw, r) {
+		return
+	}
+
+	//nolint:gocritic // needed for SCIM
+	defaultOrganization, err := api.Database.GetDefaultOrganization(dbauthz.AsSCIM(ctx))
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	group, currentMembers, ok := api.scimGroup(rw, r, defaultOrganization.ID)
+	if !ok {
+		return
+	}
+
+	currentDisplayName := scimGroupFromDB(group, nil).DisplayName
+	displayName := currentDisplayName
+	members := map[string]SCIMGroupMember{}
+	for _, member := range currentMembers {
+		members[member.ID.String()] = SCIMGroupMember{Value: member.ID.String()}
+	}
+	err = modify(&displayName, members)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	if displayName == "" {
+		scimError(rw, spec.ErrInvalidValue, "displayName is required")
+		return
+	}
+	if err := httpapi.DisplayNameValid(displayName); err != nil {
+		scimError(rw, spec.ErrInvalidValue, "invalid displayName: "+err.Error())
+		return
+	}
+	if displayName == currentDisplayName {
+		// Groups without a display name show their name instead.
+		displayName = group.DisplayName
+	}
+
+	memberList := make([]SCIMGroupMember, 0, len(members))
+	for _, member := range members {
+		memberList = append(memberList, member)
+	}
+	memberIDs, err := api.scimGroupMembers(ctx, group.OrganizationID, currentMembers, memberList)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	oldGroup := group
+	err = api.Database.InTx(func(tx database.Store) error {
+		group, err = scimUpdateGroup(ctx, tx, group, displayName, currentMembers, memberIDs)
+		return err
+	}, nil)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	//nolint:gocritic // needed for SCIM
+	newMembers, err := api.Database.GetGroupMembersByGroupID(dbauthz.AsSCIM(ctx), group.ID)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	api.scimAuditGroup(r, http.StatusOK, database.AuditActionWrite, oldGroup.Auditable(currentMembers), group.Auditable(newMembers))
+
+	httpapi.Write(ctx, rw, http.StatusOK, scimGroupFromDB(group, newMembers))
+}
+
+// scimPatchGroupOperation applies a single patch operation to the display
+// name and members of a group.
+func scimPatchGroupOperation(op scim.PatchOperation, displayName *string, members map[string]SCIMGroupMember) error {
+	opName := strings.ToLower(op.Op)
+	if opName != "add" && opName != "remove" && opName != "replace" {
+		return xerrors.Errorf("unsupported operation %q: %w", op.Op, spec.ErrInvalidSyntax)
+	}
+
+	path := strings.ToLower(op.Path)
+	switch {
+	case path == "":
+		if opName == "remove" {
+			return xerrors.Errorf("remove requires a path: %w", spec.ErrNoTarget)
+		}
+		var value struct {
+			DisplayName *string           `json:"displayName"`
+			Members     []SCIMGroupMember `json:"members"`
+		}
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return xerrors.Errorf("invalid value: %s: %w", err.Error(), spec.ErrInvalidValue)
+		}
+		if value.DisplayName != nil {
+			*displayName = *value.DisplayName
+		}
+		if value.Members != nil {
+			if opName == "replace" {
+				clear(members)
+			}
+			for _, member := range value.Members {
+				members[scimMemberKey(member.Value)] = member
+			}
+		}
+	case path == "displayname":
+		if opName == "remove" {
+			return xerrors.Errorf("displayName is required: %w", spec.ErrMutability)
+		}
+		if err := json.Unmarshal(op.Value, displayName); err != nil {
+			return xerrors.Errorf("invalid displayName: %s: %w", err.Error(), spec.ErrInvalidValue)
+		}
+	case path == "members":
+		var value []SCIMGroupMember
+		if len(op.Value) > 0 {
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				return xerrors.Errorf("invalid members: %s: %w", err.Error(), spec.ErrInvalidValue)
+			}
+		}
+		switch opName {
+		case "remove":
+			if value == nil {
+				// Removing the attribute without a value removes all members.
+				clear(members)
+			}
+			for _, member := range value {
+				delete(members, scimMemberKey(member.Value))
+			}
+		case "replace":
+			clear(members)
+			fallthrough
+		default:
+			for _, member := range value {
+				members[scimMemberKey(member.Value)] = member
+			}
+		}
+	case strings.HasPrefix(path, "members[") && strings.HasSuffix(path, "]"):
+		if opName != "remove" {
+			return xerrors.Errorf("members can only be removed by filter: %w", spec.ErrInvalidPath)
+		}
+		filter, err := scim.ParseFilter(op.Path[len("members[") : len(op.Path)-1])
+		if err != nil {
+			return xerrors.Errorf("%s: %w", err.Error(), spec.ErrInvalidPath)
+		}
+		for id := range members {
+			if filter.Match(scim.Attributes{"value": {strings.ToLower(id)}}) {
+				delete(members, id)
+			}
+		}
+	default:
+		return xerrors.Errorf("unsupported path %q: %w", op.Path, spec.ErrInvalidPath)
+	}
+	return nil
+}
+
+// scimMemberKey returns the key of a member with the given value. User IDs
+// are compared in their canonical form.
+func scimMemberKey(value string) string {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return value
+	}
+	return id.String()
+}
+
+// scimDeleteGroup deletes a group.
+//
+// @Summary SCIM 2.0: Delete group
+// @ID scim-delete-group
+// @Security CoderSessionToken
+// @Tags Enterprise
+// @Param id path string true "Group ID" format(uuid)
+// @Success 204
+// @Router /scim/v2/Groups/{id} [delete]
+func (api *API) scimDeleteGroup(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !api.scimAuthorized(rw, r) {
+		return
+	}
+
+	//nolint:gocritic // needed for SCIM
+	defaultOrganization, err := api.Database.GetDefaultOrganization(dbauthz.AsSCIM(ctx))
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	group, members, ok := api.scimGroup(rw, r, defaultOrganization.ID)
+	if !ok {
+		return
+	}
+	//nolint:gocritic // needed for SCIM
+	err = api.Database.DeleteGroupByID(dbauthz.AsSCIM(ctx), group.ID)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	api.scimAuditGroup(r, http.StatusNoContent, database.AuditActionDelete, group.Auditable(members), database.AuditableGroup{})
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// scimAuditGroup records a change of a group. Unlike changes of users, they
+// cannot be attributed to a user, so they are recorded without one.
+func (api *API) scimAuditGroup(r *http.Request, status int, action database.AuditAction, old, new database.AuditableGroup) {
+	fields, err := json.Marshal(SCIMAuditAdditionalFields)
+	if err != nil {
+		api.Logger.Warn(r.Context(), "marshal audit fields", slog.Error(err))
+		fields = json.RawMessage("{}")
+	}
+	organizationID := new.OrganizationID
+	if action == database.AuditActionDelete {
+		organizationID = old.OrganizationID
+	}
+	audit.BackgroundAudit(r.Context(), &audit.BackgroundAuditParams[database.AuditableGroup]{
+		Audit:            *api.AGPL.Auditor.Load(),
+		Log:              api.Logger,
+		RequestID:        httpmw.RequestID(r),
+		Status:           status,
+		Action:           action,
+		OrganizationID:   organizationID,
+		IP:               r.RemoteAddr,
+		AdditionalFields: fields,
+		Old:              old,
+		New:              new,
+	})
+}
+
+// scimGroup returns the group with the ID in the path of the request and its
+// members, and writes an error response if there is no such group in the
+// organization.
+func (api *API) scimGroup(rw http.ResponseWriter, r *http.Request, organizationID uuid.UUID) (database.Group, []database.User, bool) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		scimError(rw, spec.ErrNotFound, "invalid group ID")
+		return database.Group{}, nil, false
+	}
+
+	//nolint:gocritic // needed for SCIM
+	group, err := api.Database.GetGroupByID(dbauthz.AsSCIM(ctx), id)
+	if xerrors.Is(err, sql.ErrNoRows) || (err == nil && (group.OrganizationID != organizationID || group.IsEveryone())) {
+		scimError(rw, spec.ErrNotFound, "group not found")
+		return database.Group{}, nil, false
+	}
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return database.Group{}, nil, false
+	}
+
+	//nolint:gocritic // needed for SCIM
+	members, err := api.Database.GetGroupMembersByGroupID(dbauthz.AsSCIM(ctx), group.ID)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return database.Group{}, nil, false
+	}
+	return group, members, true
+}
+
+// scimGroupMembers returns the IDs of the members of a group. Users that are
+// not yet members of the group must be members of the organization.
+func (api *API) scimGroupMembers(ctx context.Context, organizationID uuid.UUID, current []database.User, members []SCIMGroupMember) ([]uuid.UUID, error) {
+	isMember := make(map[uuid.UUID]bool, len(current))
+	for _, user := range current {
+		isMember[user.ID] = true
+	}
+
+	ids := make([]uuid.UUID, 0, len(members))
+	seen := make(map[uuid.UUID]bool, len(members))
+	for _, member := range members {
+		id, err := uuid.Parse(member.Value)
+		if err != nil {
+			return nil, xerrors.Errorf("member %q must be a user ID: %w", member.Value, spec.ErrInvalidValue)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if isMember[id] {
+			ids = append(ids, id)
+			continue
+		}
+		//nolint:gocritic // needed for SCIM
+		_, err = database.ExpectOne(api.Database.OrganizationMembers(dbauthz.AsSCIM(ctx), database.OrganizationMembersParams{
+			OrganizationID: organizationID,
+			UserID:         id,
+		}))
+		if xerrors.Is(err, sql.ErrNoRows) {
+			return nil, xerrors.Errorf("member %q is not a user of the organization: %w", member.Value, spec.ErrInvalidValue)
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("get organization member: %w", err)
+		}
+		isMember[id] = true
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// scimUpdateGroup sets the display name and members of a group.
+func scimUpdateGroup(ctx context.Context, tx database.Store, group database.Group, displayName string, current []database.User, members []uuid.UUID) (database.Group, error) {
+	//nolint:gocritic // needed for SCIM
+	ctx = dbauthz.AsSCIM(ctx)
+
+	if displayName != group.DisplayName {
+		var err error
+		group, err = tx.UpdateGroupByID(ctx, database.UpdateGroupByIDParams{
+			ID:             group.ID,
+			Name:           group.Name,
+			DisplayName:    displayName,
+			AvatarURL:      group.AvatarURL,
+			QuotaAllowance: group.QuotaAllowance,
+		})
+		if err != nil {
+			return database.Group{}, xerrors.Errorf("update group: %w", err)
+		}
+	}
+
+	keep := make(map[uuid.UUID]bool, len(members))
+	for _, id := range members {
+		keep[id] = true
+	}
+	for _, user := range current {
+		if keep[user.ID] {
+			delete(keep, user.ID)
+			continue
+		}
+		err := tx.DeleteGroupMemberFromGroup(ctx, database.DeleteGroupMemberFromGroupParams{
+			UserID:  user.ID,
+			GroupID: group.ID,
+		})
+		if err != nil {
+			return database.Group{}, xerrors.Errorf("delete group member %q: %w", user.ID, err)
+		}
+	}
+	// Only the users that are not members yet remain.
+	for id := range keep {
+		err := tx.InsertGroupMember(ctx, database.InsertGroupMemberParams{
+			UserID:  id,
+			GroupID: group.ID,
+		})
+		if err != nil {
+			return database.Group{}, xerrors.Errorf("insert group member %q: %w", id, err)
+		}
+	}
+	return group, nil
+}
@@ -0,0 +1,192 @@
+package coderd_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/enterprise/coderd"
+	"github.com/coder/coder/v2/enterprise/coderd/coderdenttest"
+	"github.com/coder/coder/v2/enterprise/coderd/license"
+	"github.com/coder/coder/v2/enterprise/coderd/scim"
+	"github.com/coder/coder/v2/testutil"
+)
+
+//nolint:gocritic // SCIM authenticates via a special header and bypasses internal RBAC.
+func TestScimGroups(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, auditor audit.Auditor) (*codersdk.Client, codersdk.CreateFirstUserResponse, func(method, path string, body interface{}, res interface{}) int) {
+		scimAPIKey := []byte("hi")
+		dv := coderdtest.DeploymentValues(t)
+		dv.Experiments = []string{string(codersdk.ExperimentMultiOrganization)}
+		client, owner := coderdenttest.New(t, &coderdenttest.Options{
+			Options: &coderdtest.Options{
+				Auditor:          auditor,
+				DeploymentValues: dv,
+			},
+			SCIMAPIKey:   scimAPIKey,
+			AuditLogging: true,
+			LicenseOptions: &coderdenttest.LicenseOptions{
+				AccountID: "coolin",
+				Features: license.Features{
+					codersdk.FeatureSCIM:                  1,
+					codersdk.FeatureAuditLog:              1,
+					codersdk.FeatureTemplateRBAC:          1,
+					codersdk.FeatureMultipleOrganizations: 1,
+				},
+			},
+		})
+		do := func(method, path string, body interface{}, out interface{}) int {
+			ctx := testutil.Context(t, testutil.WaitLong)
+			res, err := client.Request(ctx, method, path, body, setScimAuth(scimAPIKey))
+			require.NoError(t, err)
+			defer res.Body.Close()
+			if out != nil && res.StatusCode < 300 {
+				require.NoError(t, json.NewDecoder(res.Body).Decode(out))
+			}
+			_, _ = io.Copy(io.Discard, res.Body)
+			return res.StatusCode
+		}
+		return client, owner, do
+	}
+
+	t.Run("Lifecycle", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		mockAudit := audit.NewMock()
+		client, owner, do := setup(t, mockAudit)
+		_, alice := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		_, bob := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		mockAudit.ResetLogs()
+
+		var sGroup coderd.SCIMGroup
+		status := do("POST", "/scim/v2/Groups", coderd.SCIMGroup{
+			DisplayName: "Platform Team (EU)",
+			Members:     []coderd.SCIMGroupMember{{Value: alice.ID.String()}},
+		}, &sGroup)
+		require.Equal(t, http.StatusCreated, status)
+		require.Equal(t, "Platform Team (EU)", sGroup.DisplayName)
+		require.Equal(t, []coderd.SCIMGroupMember{{Value: alice.ID.String(), Display: alice.Username}}, sGroup.Members)
+
+		aLogs := mockAudit.AuditLogs()
+		require.Len(t, aLogs, 1)
+		assert.Equal(t, database.AuditActionCreate, aLogs[0].Action)
+
+		group, err := client.Group(ctx, uuid.MustParse(sGroup.ID))
+		require.NoError(t, err)
+		require.Equal(t, "Platform-Team-EU", group.Name)
+
+		// The same display name cannot be used twice.
+		status = do("POST", "/scim/v2/Groups", coderd.SCIMGroup{DisplayName: "Platform Team (EU)"}, nil)
+		require.Equal(t, http.StatusConflict, status)
+
+		// Okta adds members and renames groups with patches.
+		status = do("PATCH", "/scim/v2/Groups/"+sGroup.ID, scim.PatchRequest{
+			Schemas: []string{scim.SchemaPatchOp},
+			Operations: []scim.PatchOperation{
+				{Op: "add", Path: "members", Value: json.RawMessage(fmt.Sprintf(`[{"value":%q}]`, bob.ID))},
+				{Op: "replace", Value: json.RawMessage(`{"id":"ignored","displayName":"Platform"}`)},
+			},
+		}, &sGroup)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "Platform", sGroup.DisplayName)
+		require.Len(t, sGroup.Members, 2)
+
+		// Azure AD removes members with filters.
+		status = do("PATCH", "/scim/v2/Groups/"+sGroup.ID, scim.PatchRequest{
+			Schemas: []string{scim.SchemaPatchOp},
+			Operations: []scim.PatchOperation{
+				{Op: "Remove", Path: fmt.Sprintf(`members[value eq %q]`, alice.ID)},
+			},
+		}, &sGroup)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, []coderd.SCIMGroupMember{{Value: bob.ID.String(), Display: bob.Username}}, sGroup.Members)
+
+		var list scim.ListResponse
+		status = do("GET", "/scim/v2/Groups?"+url.Values{"filter": {`displayName eq "platform"`}}.Encode(), nil, &list)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, 1, list.TotalResults)
+		status = do("GET", "/scim/v2/Groups?"+url.Values{"filter": {fmt.Sprintf(`members eq %q`, alice.ID)}}.Encode(), nil, &list)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, 0, list.TotalResults)
+
+		status = do("PUT", "/scim/v2/Groups/"+sGroup.ID, coderd.SCIMGroup{
+			DisplayName: "Platform",
+			Members:     []coderd.SCIMGroupMember{{Value: alice.ID.String()}},
+		}, &sGroup)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, []coderd.SCIMGroupMember{{Value: alice.ID.String(), Display: alice.Username}}, sGroup.Members)
+
+		var got coderd.SCIMGroup
+		status = do("GET", "/scim/v2/Groups/"+sGroup.ID+"?excludedAttributes=members", nil, &got)
+		require.Equal(t, http.StatusOK, status)
+		require.Empty(t, got.Members)
+
+		mockAudit.ResetLogs()
+		status = do("DELETE", "/scim/v2/Groups/"+sGroup.ID, nil, nil)
+		require.Equal(t, http.StatusNoContent, status)
+		aLogs = mockAudit.AuditLogs()
+		require.Len(t, aLogs, 1)
+		assert.Equal(t, database.AuditActionDelete, aLogs[0].Action)
+
+		status = do("GET", "/scim/v2/Groups/"+sGroup.ID, nil, nil)
+		require.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		client, owner, do := setup(t, audit.NewMock())
+		otherOrg := coderdenttest.CreateOrganization(t, client, coderdenttest.CreateOrganizationOptions{})
+		_, outsider := coderdtest.CreateAnotherUser(t, client, otherOrg.ID)
+
+		// The Everyone group cannot be managed with SCIM.
+		status := do("GET", "/scim/v2/Groups/"+owner.OrganizationID.String(), nil, nil)
+		require.Equal(t, http.StatusNotFound, status)
+		var list scim.ListResponse
+		status = do("GET", "/scim/v2/Groups", nil, &list)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, 0, list.TotalResults)
+
+		status = do("POST", "/scim/v2/Groups", coderd.SCIMGroup{DisplayName: "!!!"}, nil)
+		require.Equal(t, http.StatusBadRequest, status)
+		status = do("POST", "/scim/v2/Groups", coderd.SCIMGroup{
+			DisplayName: "outsiders",
+			Members:     []coderd.SCIMGroupMember{{Value: outsider.ID.String()}},
+		}, nil)
+		require.Equal(t, http.StatusBadRequest, status)
+
+		var sGroup coderd.SCIMGroup
+		status = do("POST", "/scim/v2/Groups", coderd.SCIMGroup{DisplayName: "insiders"}, &sGroup)
+		require.Equal(t, http.StatusCreated, status)
+		status = do("PATCH", "/scim/v2/Groups/"+sGroup.ID, scim.PatchRequest{
+			Operations: []scim.PatchOperation{{Op: "replace", Path: "externalId", Value: json.RawMessage(`"x"`)}},
+		}, nil)
+		require.Equal(t, http.StatusBadRequest, status)
+
+		// Groups of other organizations are not visible.
+		otherGroup, err := client.CreateGroup(ctx, otherOrg.ID, codersdk.CreateGroupRequest{Name: "other"})
+		require.NoError(t, err)
+		status = do("GET", "/scim/v2/Groups/"+otherGroup.ID.String(), nil, nil)
+		require.Equal(t, http.StatusNotFound, status)
+	})
+}
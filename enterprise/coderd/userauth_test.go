@@ -12,6 +12,7 @@ import (
 
 	"github.com/coder/coder/v2/coderd"
 	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/coderdtest/ldaptest"
 	"github.com/coder/coder/v2/coderd/coderdtest/oidctest"
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/database/dbauthz"
@@ -775,6 +776,92 @@ func TestEnterpriseUserLogin(t *testing.T) {
 
 // oidcTestRunner is just a helper to setup and run oidc tests.
 // An actual Coderd instance is used to run the tests.
+func TestUserLDAP(t *testing.T) {
+	t.Parallel()
+
+	login := func(t *testing.T, runner *oidcTestRunner, username, password string) error {
+		t.Helper()
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		_, err := codersdk.New(runner.AdminClient.URL).LoginWithLDAP(ctx, codersdk.LoginWithLDAPRequest{
+			Username: username,
+			Password: password,
+		})
+		return err
+	}
+
+	t.Run("Groups", func(t *testing.T) {
+		t.Parallel()
+
+		fake, runner := setupLDAPTest(t, func(cfg *coderd.LDAPConfig) {
+			cfg.GroupAttribute = "memberOf"
+			cfg.GroupNameAttribute = "cn"
+			cfg.GroupMapping = map[string]string{"developers": "devs"}
+			cfg.CreateMissingGroups = true
+		})
+
+		fake.AddUser("alice", "alice-password", "alice@coder.com", "developers", "admins")
+		require.NoError(t, login(t, runner, "alice", "alice-password"))
+		runner.AssertGroups(t, "alice", []string{"devs", "admins"})
+
+		// Groups are synced on every login.
+		fake.AddUser("alice", "alice-password", "alice@coder.com", "developers")
+		require.NoError(t, login(t, runner, "alice", "alice-password"))
+		runner.AssertGroups(t, "alice", []string{"devs"})
+	})
+
+	t.Run("GroupAllowList", func(t *testing.T) {
+		t.Parallel()
+
+		fake, runner := setupLDAPTest(t, func(cfg *coderd.LDAPConfig) {
+			cfg.GroupAttribute = "memberOf"
+			cfg.GroupAllowList = map[string]bool{ldaptest.GroupDN("developers"): true}
+		})
+
+		// Groups are matched by their normalized DN.
+		fake.AddUser("alice", "alice-password", "alice@coder.com", "CN=developers, OU=groups, DC=example, DC=com")
+		fake.AddUser("bob", "bob-password", "bob@coder.com", "sales")
+		// A group with the same common name elsewhere in the directory
+		// does not match.
+		fake.AddUser("mallory", "mallory-password", "mallory@coder.com", "cn=developers,ou=people,"+ldaptest.BaseDN)
+		require.NoError(t, login(t, runner, "alice", "alice-password"))
+
+		for _, username := range []string{"bob", "mallory"} {
+			var apiErr *codersdk.Error
+			err := login(t, runner, username, username+"-password")
+			require.ErrorAs(t, err, &apiErr)
+			require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+		}
+	})
+
+	t.Run("RoleSync", func(t *testing.T) {
+		t.Parallel()
+
+		fake, runner := setupLDAPTest(t, func(cfg *coderd.LDAPConfig) {
+			cfg.GroupAttribute = "memberOf"
+			cfg.UserRoleMapping = map[string][]string{
+				ldaptest.GroupDN("admins"): {rbac.RoleTemplateAdmin().String()},
+			}
+			cfg.UserRolesDefault = []string{rbac.RoleAuditor().String()}
+		})
+
+		// Groups that are not mapped do not grant roles.
+		fake.AddUser("alice", "alice-password", "alice@coder.com", "admins", "owner")
+		require.NoError(t, login(t, runner, "alice", "alice-password"))
+		runner.AssertRoles(t, "alice", []string{rbac.RoleTemplateAdmin().String(), rbac.RoleAuditor().String()})
+
+		fake.AddUser("alice", "alice-password", "alice@coder.com")
+		require.NoError(t, login(t, runner, "alice", "alice-password"))
+		runner.AssertRoles(t, "alice", []string{rbac.RoleAuditor().String()})
+
+		// Roles of LDAP users are managed by the directory.
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		_, err := runner.AdminClient.UpdateUserRoles(ctx, "alice", codersdk.UpdateRoles{
+			Roles: []string{rbac.RoleUserAdmin().String()},
+		})
+		require.Error(t, err)
+	})
+}
+
 type oidcTestRunner struct {
 	AdminClient *codersdk.Client
 	AdminUser   codersdk.User
@@ -886,3 +973,31 @@ func setupOIDCTest(t *testing.T, settings oidcTestConfig) *oidcTestRunner {
 		},
 	}
 }
+
+// setupLDAPTest returns a fake LDAP server and a runner for asserting the
+// roles and groups of users that log in with it.
+func setupLDAPTest(t *testing.T, opt func(cfg *coderd.LDAPConfig)) (*ldaptest.FakeLDAP, *oidcTestRunner) {
+	t.Helper()
+
+	fake := ldaptest.New(t)
+	ctx := testutil.Context(t, testutil.WaitMedium)
+	owner, _, api, _ := coderdenttest.NewWithAPI(t, &coderdenttest.Options{
+		Options: &coderdtest.Options{
+			LDAPConfig: fake.LDAPConfig(opt),
+		},
+		LicenseOptions: &coderdenttest.LicenseOptions{
+			Features: license.Features{
+				codersdk.FeatureUserRoleManagement: 1,
+				codersdk.FeatureTemplateRBAC:       1,
+			},
+		},
+	})
+	admin, err := owner.User(ctx, "me")
+	require.NoError(t, err)
+
+	return fake, &oidcTestRunner{
+		AdminClient: owner,
+		AdminUser:   admin,
+		API:         api,
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/google/uuid"
 
@@ -13,14 +14,16 @@ import (
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/provisionerd/proto"
 )
 
 type committer struct {
-	Log      slog.Logger
-	Database database.Store
+	Log                   slog.Logger
+	Database              database.Store
+	NotificationsEnqueuer notifications.Enqueuer
 }
 
 func (c *committer) CommitQuota(
@@ -105,6 +108,10 @@ func (c *committer) CommitQuota(
 		return nil, err
 	}
 
+	if !permit {
+		c.notifyQuotaReached(ctx, workspace, request.DailyCost, consumed, budget)
+	}
+
 	return &proto.CommitQuotaResponse{
 		Ok:              permit,
 		CreditsConsumed: int32(consumed),
@@ -112,6 +119,22 @@ func (c *committer) CommitQuota(
 	}, nil
 }
 
+// notifyQuotaReached notifies the owner of a workspace that a build was rejected because it would exceed their quota.
+func (c *committer) notifyQuotaReached(ctx context.Context, workspace database.Workspace, dailyCost int32, consumed, budget int64) {
+	if _, err := c.NotificationsEnqueuer.Enqueue(ctx, workspace.OwnerID, notifications.TemplateWorkspaceQuotaReached,
+		map[string]string{
+			"name":             workspace.Name,
+			"daily_cost":       strconv.FormatInt(int64(dailyCost), 10),
+			"credits_consumed": strconv.FormatInt(consumed, 10),
+			"budget":           strconv.FormatInt(budget, 10),
+		}, "quota_committer",
+		// Associate this notification with all the related entities.
+		workspace.ID, workspace.OwnerID, workspace.TemplateID, workspace.OrganizationID,
+	); err != nil {
+		c.Log.Warn(ctx, "failed to notify of reached workspace quota", slog.F("workspace_id", workspace.ID), slog.Error(err))
+	}
+}
+
 // @Summary Get workspace quota by user
 // @ID get-workspace-quota-by-user
 // @Security CoderSessionToken
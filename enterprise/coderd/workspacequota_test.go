@@ -11,6 +11,7 @@ import (
 
 	"github.com/coder/coder/v2/coderd/coderdtest"
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/util/ptr"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/enterprise/coderd/coderdenttest"
@@ -42,7 +43,11 @@ func TestWorkspaceQuota(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
 		defer cancel()
 		max := 1
+		notifyEnq := &testutil.FakeNotificationsEnqueuer{}
 		client, _, api, user := coderdenttest.NewWithAPI(t, &coderdenttest.Options{
+			Options: &coderdtest.Options{
+				NotificationsEnqueuer: notifyEnq,
+			},
 			UserWorkspaceQuota: max,
 			LicenseOptions: &coderdenttest.LicenseOptions{
 				Features: license.Features{
@@ -134,6 +139,20 @@ func TestWorkspaceQuota(t *testing.T) {
 		require.Equal(t, codersdk.WorkspaceStatusFailed, build.Status)
 		require.Contains(t, build.Job.Error, "quota")
 
+		// The owner is notified that their quota has been reached
+		var quotaNotifications []*testutil.Notification
+		for _, sent := range notifyEnq.Sent {
+			if sent.TemplateID == notifications.TemplateWorkspaceQuotaReached {
+				quotaNotifications = append(quotaNotifications, sent)
+			}
+		}
+		require.Len(t, quotaNotifications, 1)
+		require.Equal(t, user.UserID, quotaNotifications[0].UserID)
+		require.Equal(t, workspace.Name, quotaNotifications[0].Labels["name"])
+		require.Equal(t, "4", quotaNotifications[0].Labels["credits_consumed"])
+		require.Equal(t, "4", quotaNotifications[0].Labels["budget"])
+		require.Contains(t, quotaNotifications[0].Targets, workspace.ID)
+
 		// Delete one random workspace, then quota should recover.
 		workspaces, err := client.Workspaces(ctx, codersdk.WorkspaceFilter{})
 		require.NoError(t, err)
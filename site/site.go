@@ -931,6 +931,12 @@ type RenderOAuthAllowData struct {
 	CancelURI   string
 	RedirectURI string
 	Username    string
+	// UserCode is shown when authorizing a device so the user can compare it
+	// with the code displayed on the device.
+	UserCode string
+	// EnterUserCode renders a form asking for the code displayed on the device
+	// instead of the allow button.
+	EnterUserCode bool
 }
 
 // RenderOAuthAllowPage renders the static page for a user to "Allow" an create
@@ -388,8 +388,9 @@ class ApiMethods {
   login = async (
     email: string,
     password: string,
+    mfaCode?: string,
   ): Promise<TypesGen.LoginWithPasswordResponse> => {
-    const payload = JSON.stringify({ email, password });
+    const payload = JSON.stringify({ email, password, mfa_code: mfaCode });
     const response = await this.axios.post<TypesGen.LoginWithPasswordResponse>(
       "/api/v2/users/login",
       payload,
@@ -399,6 +400,20 @@ class ApiMethods {
     return response.data;
   };
 
+  loginWithLDAP = async (
+    username: string,
+    password: string,
+  ): Promise<TypesGen.LoginWithPasswordResponse> => {
+    const payload = JSON.stringify({ username, password });
+    const response = await this.axios.post<TypesGen.LoginWithPasswordResponse>(
+      "/api/v2/users/ldap/login",
+      payload,
+      { headers: { ...BASE_CONTENT_TYPE_JSON } },
+    );
+
+    return response.data;
+  };
+
   convertToOAUTH = async (request: TypesGen.ConvertLoginRequest) => {
     const response = await this.axios.post<TypesGen.OAuthConversionResponse>(
       "/api/v2/users/me/convert-login",
@@ -494,6 +509,22 @@ class ApiMethods {
     return response.data;
   };
 
+  getUserSessions = async (user: string): Promise<TypesGen.UserSession[]> => {
+    const response = await this.axios.get<TypesGen.UserSession[]>(
+      `/api/v2/users/${user}/sessions`,
+    );
+
+    return response.data;
+  };
+
+  revokeUserSession = async (user: string, sessionId: string) => {
+    await this.axios.delete(`/api/v2/users/${user}/sessions/${sessionId}`);
+  };
+
+  revokeUserSessions = async (user: string) => {
+    await this.axios.delete(`/api/v2/users/${user}/sessions`);
+  };
+
   getUsers = async (
     options: TypesGen.UsersRequest,
     signal?: AbortSignal,
@@ -1199,6 +1230,26 @@ class ApiMethods {
     return response.data;
   };
 
+  enrollTOTP = async (userId = "me"): Promise<TypesGen.TOTPEnrollment> => {
+    const response = await this.axios.post<TypesGen.TOTPEnrollment>(
+      `/api/v2/users/${userId}/mfa/totp`,
+    );
+
+    return response.data;
+  };
+
+  verifyTOTP = async (
+    userId: string,
+    req: TypesGen.VerifyTOTPRequest,
+  ): Promise<TypesGen.TOTPRecoveryCodes> => {
+    const response = await this.axios.post<TypesGen.TOTPRecoveryCodes>(
+      `/api/v2/users/${userId}/mfa/totp/verify`,
+      req,
+    );
+
+    return response.data;
+  };
+
   getWorkspaceBuilds = async (
     workspaceId: string,
     req?: TypesGen.WorkspaceBuildsRequest,
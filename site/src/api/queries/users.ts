@@ -13,6 +13,7 @@ import type {
   UsersRequest,
   User,
   GenerateAPIKeyResponse,
+  VerifyTOTPRequest,
 } from "api/typesGenerated";
 import {
   defaultMetadataManager,
@@ -63,6 +64,18 @@ export const updatePassword = () => {
   };
 };
 
+export const enrollTOTP = (userId: string) => {
+  return {
+    mutationFn: () => API.enrollTOTP(userId),
+  };
+};
+
+export const verifyTOTP = (userId: string) => {
+  return {
+    mutationFn: (req: VerifyTOTPRequest) => API.verifyTOTP(userId, req),
+  };
+};
+
 export const createUser = (queryClient: QueryClient) => {
   return {
     mutationFn: API.createUser,
@@ -87,6 +100,12 @@ export const suspendUser = (queryClient: QueryClient) => {
   };
 };
 
+export const revokeUserSessions = () => {
+  return {
+    mutationFn: API.revokeUserSessions,
+  };
+};
+
 export const activateUser = (queryClient: QueryClient) => {
   return {
     mutationFn: API.activateUser,
@@ -154,8 +173,8 @@ export const login = (
   queryClient: QueryClient,
 ) => {
   return {
-    mutationFn: async (credentials: { email: string; password: string }) =>
-      loginFn({ ...credentials, authorization }),
+    mutationFn: async (credentials: LoginCredentials) =>
+      loginFn(credentials, authorization),
     onSuccess: async (data: Awaited<ReturnType<typeof loginFn>>) => {
       queryClient.setQueryData(["me"], data.user);
       queryClient.setQueryData(
@@ -166,16 +185,54 @@ export const login = (
   };
 };
 
-const loginFn = async ({
-  email,
-  password,
-  authorization,
-}: {
-  email: string;
-  password: string;
-  authorization: AuthorizationRequest;
-}) => {
-  await API.login(email, password);
+/**
+ * Thrown when the deployment enforces multi-factor authentication and the user
+ * has not enrolled yet. The session can only be used to enroll, so the user
+ * has to sign in again afterwards.
+ */
+export class MFAEnrollmentRequiredError extends Error {
+  constructor() {
+    super("Multi-factor authentication enrollment required.");
+  }
+}
+
+/**
+ * Thrown when the password of the user is older than the maximum password age
+ * of the deployment. The session can only be used to change the password,
+ * which also logs the session out.
+ */
+export class PasswordChangeRequiredError extends Error {
+  constructor(public readonly oldPassword: string) {
+    super("Password change required.");
+  }
+}
+
+/**
+ * Credentials for a password login, or for a login with the LDAP directory of
+ * the deployment when a username is given.
+ */
+export type LoginCredentials =
+  | { email: string; password: string; mfa_code?: string }
+  | { username: string; password: string };
+
+const loginFn = async (
+  credentials: LoginCredentials,
+  authorization: AuthorizationRequest,
+) => {
+  const response =
+    "username" in credentials
+      ? await API.loginWithLDAP(credentials.username, credentials.password)
+      : await API.login(
+          credentials.email,
+          credentials.password,
+          credentials.mfa_code,
+        );
+  if (response.mfa_enrollment_required) {
+    throw new MFAEnrollmentRequiredError();
+  }
+  if (response.password_change_required) {
+    throw new PasswordChangeRequiredError(credentials.password);
+  }
   const [user, permissions] = await Promise.all([
     API.getAuthenticatedUser(),
     API.checkAuthorization(authorization),
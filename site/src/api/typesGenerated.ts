@@ -18,6 +18,7 @@ export interface APIKey {
   readonly updated_at: string;
   readonly login_type: LoginType;
   readonly scope: APIKeyScope;
+  readonly scopes: readonly string[];
   readonly token_name: string;
   readonly lifetime_seconds: number;
 }
@@ -115,6 +116,38 @@ export interface AuditLogResponse {
   readonly count: number;
 }
 
+// From codersdk/deployment.go
+export interface AuditLoggingConfig {
+  readonly backends: string[];
+  readonly retention: number;
+  readonly syslog: AuditLoggingSyslogConfig;
+  readonly http: AuditLoggingHTTPConfig;
+  readonly file: AuditLoggingFileConfig;
+}
+
+// From codersdk/deployment.go
+export interface AuditLoggingFileConfig {
+  readonly path: string;
+  readonly max_size: number;
+  readonly max_backups: number;
+}
+
+// From codersdk/deployment.go
+export interface AuditLoggingHTTPConfig {
+  readonly endpoint: string;
+  readonly headers: Record<string, string>;
+  readonly batch_size: number;
+  readonly flush_interval: number;
+  readonly spool_dir: string;
+}
+
+// From codersdk/deployment.go
+export interface AuditLoggingSyslogConfig {
+  readonly address: string;
+  readonly tls: boolean;
+  readonly ca_file: string;
+}
+
 // From codersdk/audit.go
 export interface AuditLogsRequest extends Pagination {
   readonly q?: string;
@@ -131,6 +164,7 @@ export interface AuthMethods {
   readonly password: AuthMethod;
   readonly github: AuthMethod;
   readonly oidc: OIDCAuthMethod;
+  readonly ldap: LDAPAuthMethod;
 }
 
 // From codersdk/authorization.go
@@ -139,6 +173,54 @@ export interface AuthorizationCheck {
   readonly action: RBACAction;
 }
 
+// From codersdk/authorization.go
+export interface AuthorizationExplainRequest {
+  readonly object: AuthorizationObject;
+  readonly action: RBACAction;
+  readonly scope?: APIKeyScope;
+}
+
+// From codersdk/authorization.go
+export interface AuthorizationExplainedACLEntry {
+  readonly user_id?: string;
+  readonly group_id?: string;
+  readonly actions: readonly RBACAction[];
+}
+
+// From codersdk/authorization.go
+export interface AuthorizationExplainedPermission {
+  readonly role: string;
+  readonly scope: boolean;
+  readonly level: string;
+  readonly negate: boolean;
+  readonly resource_type: RBACResource;
+  readonly action: RBACAction;
+}
+
+// From codersdk/authorization.go
+export interface AuthorizationExplanation {
+  readonly object: AuthorizationObject;
+  readonly subject_roles: readonly string[];
+  readonly subject_groups: readonly string[];
+  readonly scope: string;
+  readonly allowed: boolean;
+  readonly role_allowed: boolean;
+  readonly acl_allowed: boolean;
+  readonly scope_allowed: boolean;
+  readonly organization_member: boolean;
+  readonly role_decisions: AuthorizationLevelDecisions;
+  readonly scope_decisions: AuthorizationLevelDecisions;
+  readonly permissions: readonly AuthorizationExplainedPermission[];
+  readonly acl: readonly AuthorizationExplainedACLEntry[];
+}
+
+// From codersdk/authorization.go
+export interface AuthorizationLevelDecisions {
+  readonly site: AuthorizationDecision;
+  readonly organization: AuthorizationDecision;
+  readonly user: AuthorizationDecision;
+}
+
 // From codersdk/authorization.go
 export interface AuthorizationObject {
   readonly resource_type: RBACResource;
@@ -245,6 +327,12 @@ export interface CreateProvisionerKeyResponse {
   readonly key: string;
 }
 
+// From codersdk/serviceaccounts.go
+export interface CreateServiceAccountRequest {
+  readonly username: string;
+  readonly name: string;
+}
+
 // From codersdk/organizations.go
 export interface CreateTemplateRequest {
   readonly name: string;
@@ -284,6 +372,7 @@ export interface CreateTemplateVersionRequest {
   readonly provisioner: ProvisionerType;
   readonly tags: Record<string, string>;
   readonly user_variable_values?: readonly VariableValue[];
+  readonly presets?: readonly TemplateVersionPresetRequest[];
 }
 
 // From codersdk/audit.go
@@ -301,6 +390,7 @@ export interface CreateTestAuditLogRequest {
 export interface CreateTokenRequest {
   readonly lifetime: number;
   readonly scope: APIKeyScope;
+  readonly scopes?: readonly string[];
   readonly token_name: string;
 }
 
@@ -326,6 +416,15 @@ export interface CreateWorkspaceBuildRequest {
   readonly log_level?: ProvisionerLogLevel;
 }
 
+// From codersdk/workspacebulkjobs.go
+export interface CreateWorkspaceBulkJobRequest {
+  readonly action: WorkspaceBulkAction;
+  readonly q: string;
+  readonly automatic_updates?: AutomaticUpdates;
+  readonly dormant?: boolean;
+  readonly concurrency?: number;
+}
+
 // From codersdk/workspaceproxy.go
 export interface CreateWorkspaceProxyRequest {
   readonly name: string;
@@ -342,6 +441,7 @@ export interface CreateWorkspaceRequest {
   readonly ttl_ms?: number;
   readonly rich_parameter_values?: readonly WorkspaceBuildParameter[];
   readonly automatic_updates?: AutomaticUpdates;
+  readonly template_version_preset_id?: string;
 }
 
 // From codersdk/deployment.go
@@ -440,6 +540,8 @@ export interface DeploymentValues {
   readonly pg_auth?: string;
   readonly oauth2?: OAuth2Config;
   readonly oidc?: OIDCConfig;
+  readonly ldap?: LDAPConfig;
+  readonly password_policy?: PasswordPolicyConfig;
   readonly telemetry?: TelemetryConfig;
   readonly tls?: TLSConfig;
   readonly trace?: TraceConfig;
@@ -463,6 +565,7 @@ export interface DeploymentValues {
   readonly disable_path_apps?: boolean;
   readonly session_lifetime?: SessionLifetime;
   readonly disable_password_auth?: boolean;
+  readonly enforce_mfa?: boolean;
   readonly support?: SupportConfig;
   readonly external_auth?: readonly ExternalAuthConfig[];
   readonly config_ssh?: SSHConfig;
@@ -477,11 +580,18 @@ export interface DeploymentValues {
   readonly cli_upgrade_message?: string;
   readonly terms_of_service_url?: string;
   readonly notifications?: NotificationsConfig;
+  readonly audit_logging?: AuditLoggingConfig;
   readonly config?: string;
   readonly write_config?: boolean;
   readonly address?: string;
 }
 
+// From codersdk/mfa.go
+export interface DisableTOTPRequest {
+  readonly code?: string;
+  readonly password?: string;
+}
+
 // From codersdk/deployment.go
 export interface Entitlements {
   readonly features: Record<FeatureName, Feature>;
@@ -496,6 +606,12 @@ export interface Entitlements {
 // From codersdk/deployment.go
 export type Experiments = readonly Experiment[];
 
+// From codersdk/audit.go
+export interface ExportAuditLogsRequest {
+  readonly q?: string;
+  readonly format: AuditLogExportFormat;
+}
+
 // From codersdk/externalauth.go
 export interface ExternalAuth {
   readonly authenticated: boolean;
@@ -629,6 +745,29 @@ export interface HealthcheckConfig {
   readonly threshold_database: number;
 }
 
+// From codersdk/notifications.go
+export interface InboxNotification {
+  readonly id: string;
+  readonly user_id: string;
+  readonly title: string;
+  readonly content: string;
+  readonly actions: Readonly<Array<InboxNotificationAction>>;
+  readonly read_at?: string;
+  readonly created_at: string;
+}
+
+// From codersdk/notifications.go
+export interface InboxNotificationAction {
+  readonly label: string;
+  readonly url: string;
+}
+
+// From codersdk/notifications.go
+export interface InboxNotificationsResponse {
+  readonly notifications: Readonly<Array<InboxNotification>>;
+  readonly unread_count: number;
+}
+
 // From codersdk/workspaceagents.go
 export interface IssueReconnectingPTYSignedTokenRequest {
   readonly url: string;
@@ -650,6 +789,35 @@ export interface JFrogXrayScan {
   readonly results_url: string;
 }
 
+// From codersdk/users.go
+export interface LDAPAuthMethod extends AuthMethod {
+  readonly signInText: string;
+}
+
+// From codersdk/deployment.go
+export interface LDAPConfig {
+  readonly url: string;
+  readonly start_tls: boolean;
+  readonly ca_file: string;
+  readonly bind_dn: string;
+  readonly bind_password: string;
+  readonly user_search_base_dn: string;
+  readonly user_search_filter: string;
+  readonly username_attribute: string;
+  readonly email_attribute: string;
+  readonly name_attribute: string;
+  readonly group_attribute: string;
+  readonly group_name_attribute: string;
+  readonly group_mapping: Record<string, string>;
+  readonly group_auto_create: boolean;
+  readonly group_regex_filter: string;
+  readonly group_allow_list: string[];
+  readonly user_role_mapping: Record<string, readonly string[]>;
+  readonly user_roles_default: string[];
+  readonly allow_signups: boolean;
+  readonly sign_in_text: string;
+}
+
 // From codersdk/licenses.go
 export interface License {
   readonly id: number;
@@ -681,15 +849,24 @@ export interface LoggingConfig {
   readonly stackdriver: string;
 }
 
+// From codersdk/users.go
+export interface LoginWithLDAPRequest {
+  readonly username: string;
+  readonly password: string;
+}
+
 // From codersdk/users.go
 export interface LoginWithPasswordRequest {
   readonly email: string;
   readonly password: string;
+  readonly mfa_code?: string;
 }
 
 // From codersdk/users.go
 export interface LoginWithPasswordResponse {
   readonly session_token: string;
+  readonly mfa_enrollment_required?: boolean;
+  readonly password_change_required?: boolean;
 }
 
 // From codersdk/organizations.go
@@ -707,6 +884,35 @@ export interface MinimalUser {
   readonly avatar_url: string;
 }
 
+// From codersdk/notifications.go
+export interface NotificationPreference {
+  readonly notification_template_id: string;
+  readonly notification_template_name: string;
+  readonly group: string;
+  readonly disabled: boolean;
+  readonly method?: string;
+  readonly updated_at?: string;
+}
+
+// From codersdk/notifications.go
+export interface NotificationTemplate {
+  readonly id: string;
+  readonly name: string;
+  readonly group: string;
+  readonly title_template: string;
+  readonly body_template: string;
+  readonly actions: string;
+  readonly labels: Readonly<Array<string>>;
+  readonly customized: boolean;
+  readonly digest_window_seconds: number;
+}
+
+// From codersdk/notifications.go
+export interface NotificationTemplatePreview {
+  readonly title: string;
+  readonly body: string;
+}
+
 // From codersdk/deployment.go
 export interface NotificationsConfig {
   readonly max_send_attempts: number;
@@ -720,6 +926,8 @@ export interface NotificationsConfig {
   readonly dispatch_timeout: number;
   readonly email: NotificationsEmailConfig;
   readonly webhook: NotificationsWebhookConfig;
+  readonly slack: NotificationsSlackConfig;
+  readonly teams: NotificationsTeamsConfig;
 }
 
 // From codersdk/deployment.go
@@ -755,9 +963,23 @@ export interface NotificationsSettings {
   readonly notifier_paused: boolean;
 }
 
+// From codersdk/deployment.go
+export interface NotificationsSlackConfig {
+  readonly endpoint: string;
+  readonly bot_token: string;
+  readonly channel: string;
+}
+
+// From codersdk/deployment.go
+export interface NotificationsTeamsConfig {
+  readonly endpoint: string;
+}
+
 // From codersdk/deployment.go
 export interface NotificationsWebhookConfig {
   readonly endpoint: string;
+  readonly signing_secret: string;
+  readonly headers: Record<string, string>;
 }
 
 // From codersdk/oauth2.go
@@ -772,6 +994,16 @@ export interface OAuth2Config {
   readonly github: OAuth2GithubConfig;
 }
 
+// From codersdk/oauth2.go
+export interface OAuth2DeviceAuthorizationResponse {
+  readonly device_code: string;
+  readonly user_code: string;
+  readonly verification_uri: string;
+  readonly verification_uri_complete: string;
+  readonly expires_in: number;
+  readonly interval: number;
+}
+
 // From codersdk/deployment.go
 export interface OAuth2GithubConfig {
   readonly client_id: string;
@@ -789,6 +1021,7 @@ export interface OAuth2ProviderApp {
   readonly name: string;
   readonly callback_url: string;
   readonly icon: string;
+  readonly service_account_id?: string;
   readonly endpoints: OAuth2AppEndpoints;
 }
 
@@ -810,6 +1043,42 @@ export interface OAuth2ProviderAppSecretFull {
   readonly client_secret_full: string;
 }
 
+// From codersdk/oauth2.go
+export interface OAuth2ProviderMetadata {
+  readonly issuer: string;
+  readonly authorization_endpoint: string;
+  readonly token_endpoint: string;
+  readonly device_authorization_endpoint: string;
+  readonly introspection_endpoint: string;
+  readonly userinfo_endpoint: string;
+  readonly response_types_supported: string[];
+  readonly grant_types_supported: string[];
+  readonly code_challenge_methods_supported: string[];
+  readonly token_endpoint_auth_methods_supported: string[];
+}
+
+// From codersdk/oauth2.go
+export interface OAuth2TokenIntrospection {
+  readonly active: boolean;
+  readonly scope?: string;
+  readonly client_id?: string;
+  readonly username?: string;
+  readonly token_type?: string;
+  readonly exp?: number;
+  readonly iat?: number;
+  readonly sub?: string;
+}
+
+// From codersdk/oauth2.go
+export interface OAuth2UserInfo {
+  readonly sub: string;
+  readonly name?: string;
+  readonly preferred_username: string;
+  readonly email: string;
+  readonly email_verified: boolean;
+  readonly picture?: string;
+}
+
 // From codersdk/users.go
 export interface OAuthConversionResponse {
   readonly state_string: string;
@@ -887,6 +1156,15 @@ export interface Pagination {
   readonly offset?: number;
 }
 
+// From codersdk/deployment.go
+export interface PasswordPolicyConfig {
+  readonly min_length: number;
+  readonly history: number;
+  readonly max_age: number;
+  readonly lockout_threshold: number;
+  readonly lockout_duration: number;
+}
+
 // From codersdk/groups.go
 export interface PatchGroupRequest {
   readonly add_users: readonly string[];
@@ -901,6 +1179,7 @@ export interface PatchGroupRequest {
 export interface PatchTemplateVersionRequest {
   readonly name: string;
   readonly message?: string;
+  readonly presets?: readonly TemplateVersionPresetRequest[];
 }
 
 // From codersdk/workspaceproxy.go
@@ -924,6 +1203,7 @@ export interface PostOAuth2ProviderAppRequest {
   readonly name: string;
   readonly callback_url: string;
   readonly icon: string;
+  readonly service_account_id?: string;
 }
 
 // From codersdk/workspaces.go
@@ -938,6 +1218,13 @@ export interface PprofConfig {
   readonly address: string;
 }
 
+// From codersdk/notifications.go
+export interface PreviewNotificationTemplateRequest {
+  readonly title_template?: string;
+  readonly body_template?: string;
+  readonly labels?: Record<string, string>;
+}
+
 // From codersdk/deployment.go
 export interface PrometheusConfig {
   readonly enable: boolean;
@@ -1022,6 +1309,7 @@ export interface PutOAuth2ProviderAppRequest {
   readonly name: string;
   readonly callback_url: string;
   readonly icon: string;
+  readonly service_account_id?: string;
 }
 
 // From codersdk/deployment.go
@@ -1111,6 +1399,15 @@ export interface ServerSentEvent {
   readonly data: any;
 }
 
+// From codersdk/serviceaccounts.go
+export interface ServiceAccount {
+  readonly id: string;
+  readonly username: string;
+  readonly name: string;
+  readonly organization_id: string;
+  readonly created_at: string;
+}
+
 // From codersdk/deployment.go
 export interface ServiceBannerConfig {
   readonly enabled: boolean;
@@ -1166,6 +1463,17 @@ export interface TLSConfig {
   readonly allow_insecure_ciphers: boolean;
 }
 
+// From codersdk/mfa.go
+export interface TOTPEnrollment {
+  readonly secret: string;
+  readonly provisioning_uri: string;
+}
+
+// From codersdk/mfa.go
+export interface TOTPRecoveryCodes {
+  readonly recovery_codes: readonly string[];
+}
+
 // From codersdk/deployment.go
 export interface TelemetryConfig {
   readonly enable: boolean;
@@ -1315,6 +1623,25 @@ export interface TemplateParameterValue {
   readonly count: number;
 }
 
+// From codersdk/templateprebuilds.go
+export interface TemplatePrebuildPool {
+  readonly id: string;
+  readonly name: string;
+  readonly desired_instances: number;
+  readonly parameters: readonly WorkspaceBuildParameter[];
+  readonly ready_instances: number;
+  readonly starting_instances: number;
+  readonly created_at: string;
+  readonly updated_at: string;
+}
+
+// From codersdk/templateprebuilds.go
+export interface TemplatePrebuildPoolRequest {
+  readonly name: string;
+  readonly desired_instances: number;
+  readonly parameters: readonly WorkspaceBuildParameter[];
+}
+
 // From codersdk/templates.go
 export interface TemplateUser extends User {
   readonly role: TemplateRole;
@@ -1333,6 +1660,7 @@ export interface TemplateVersion {
   readonly readme: string;
   readonly created_by: MinimalUser;
   readonly archived: boolean;
+  readonly presets: readonly TemplateVersionPreset[];
   readonly warnings?: readonly TemplateVersionWarning[];
 }
 
@@ -1375,6 +1703,19 @@ export interface TemplateVersionParameterOption {
   readonly icon: string;
 }
 
+// From codersdk/templateversions.go
+export interface TemplateVersionPreset {
+  readonly id: string;
+  readonly name: string;
+  readonly parameters: readonly WorkspaceBuildParameter[];
+}
+
+// From codersdk/templateversions.go
+export interface TemplateVersionPresetRequest {
+  readonly name: string;
+  readonly parameters: readonly WorkspaceBuildParameter[];
+}
+
 // From codersdk/templateversions.go
 export interface TemplateVersionVariable {
   readonly name: string;
@@ -1392,6 +1733,15 @@ export interface TemplateVersionsByTemplateRequest extends Pagination {
   readonly include_archived: boolean;
 }
 
+// From codersdk/users.go
+export interface TemporaryRoleGrant {
+  readonly role_name: string;
+  readonly organization_id?: string;
+  readonly justification: string;
+  readonly created_at: string;
+  readonly expires_at: string;
+}
+
 // From codersdk/apikey.go
 export interface TokenConfig {
   readonly max_token_lifetime: number;
@@ -1410,6 +1760,11 @@ export interface TraceConfig {
   readonly data_dog: boolean;
 }
 
+// From codersdk/workspaces.go
+export interface TransferWorkspaceRequest {
+  readonly owner_id: string;
+}
+
 // From codersdk/templates.go
 export interface TransitionStats {
   readonly P50?: number;
@@ -1436,6 +1791,29 @@ export interface UpdateCheckResponse {
   readonly url: string;
 }
 
+// From codersdk/notifications.go
+export interface UpdateInboxNotificationReadStatusRequest {
+  readonly is_read: boolean;
+}
+
+// From codersdk/notifications.go
+export interface UpdateNotificationPreference {
+  readonly notification_template_id: string;
+  readonly disabled: boolean;
+  readonly method?: string;
+}
+
+// From codersdk/notifications.go
+export interface UpdateNotificationTemplateDigestRequest {
+  readonly digest_window_seconds: number;
+}
+
+// From codersdk/notifications.go
+export interface UpdateNotificationTemplateRequest {
+  readonly title_template: string;
+  readonly body_template: string;
+}
+
 // From codersdk/organizations.go
 export interface UpdateOrganizationRequest {
   readonly name?: string;
@@ -1447,6 +1825,8 @@ export interface UpdateOrganizationRequest {
 // From codersdk/users.go
 export interface UpdateRoles {
   readonly roles: readonly string[];
+  readonly expires_at?: string;
+  readonly justification?: string;
 }
 
 // From codersdk/templates.go
@@ -1455,6 +1835,11 @@ export interface UpdateTemplateACL {
   readonly group_perms?: Record<string, TemplateRole>;
 }
 
+// From codersdk/templateprebuilds.go
+export interface UpdateTemplatePrebuildPoolsRequest {
+  readonly pools: readonly TemplatePrebuildPoolRequest[];
+}
+
 // From codersdk/templates.go
 export interface UpdateTemplateMeta {
   readonly name?: string;
@@ -1484,6 +1869,11 @@ export interface UpdateUserAppearanceSettingsRequest {
   readonly theme_preference: string;
 }
 
+// From codersdk/notifications.go
+export interface UpdateUserNotificationPreferences {
+  readonly preferences: Readonly<Array<UpdateNotificationPreference>>;
+}
+
 // From codersdk/users.go
 export interface UpdateUserPasswordRequest {
   readonly old_password: string;
@@ -1501,6 +1891,12 @@ export interface UpdateUserQuietHoursScheduleRequest {
   readonly schedule: string;
 }
 
+// From codersdk/workspaces.go
+export interface UpdateWorkspaceACL {
+  readonly user_perms?: Record<string, WorkspaceRole>;
+  readonly group_perms?: Record<string, WorkspaceRole>;
+}
+
 // From codersdk/workspaces.go
 export interface UpdateWorkspaceAutomaticUpdatesRequest {
   readonly automatic_updates: AutomaticUpdates;
@@ -1614,6 +2010,12 @@ export interface UserLoginType {
   readonly login_type: LoginType;
 }
 
+// From codersdk/mfa.go
+export interface UserMFAStatus {
+  readonly totp_enabled: boolean;
+  readonly recovery_codes_remaining: number;
+}
+
 // From codersdk/users.go
 export interface UserParameter {
   readonly name: string;
@@ -1640,6 +2042,19 @@ export interface UserQuietHoursScheduleResponse {
 export interface UserRoles {
   readonly roles: readonly string[];
   readonly organization_roles: Record<string, readonly string[]>;
+  readonly temporary_roles: readonly TemporaryRoleGrant[];
+}
+
+// From codersdk/sessions.go
+export interface UserSession {
+  readonly id: string;
+  readonly created_at: string;
+  readonly last_used: string;
+  readonly expires_at: string;
+  readonly login_type: LoginType;
+  readonly ip_address: string;
+  readonly user_agent: string;
+  readonly current: boolean;
 }
 
 // From codersdk/users.go
@@ -1659,6 +2074,11 @@ export interface VariableValue {
   readonly value: string;
 }
 
+// From codersdk/mfa.go
+export interface VerifyTOTPRequest {
+  readonly code: string;
+}
+
 // From codersdk/workspaces.go
 export interface Workspace {
   readonly id: string;
@@ -1690,6 +2110,12 @@ export interface Workspace {
   readonly favorite: boolean;
 }
 
+// From codersdk/workspaces.go
+export interface WorkspaceACL {
+  readonly users: readonly WorkspaceUser[];
+  readonly groups: readonly WorkspaceGroup[];
+}
+
 // From codersdk/workspaceagents.go
 export interface WorkspaceAgent {
   readonly id: string;
@@ -1863,6 +2289,36 @@ export interface WorkspaceBuildsRequest extends Pagination {
   readonly since?: string;
 }
 
+// From codersdk/workspacebulkjobs.go
+export interface WorkspaceBulkJob {
+  readonly id: string;
+  readonly initiator_id: string;
+  readonly action: WorkspaceBulkAction;
+  readonly q: string;
+  readonly automatic_updates?: AutomaticUpdates;
+  readonly dormant: boolean;
+  readonly concurrency: number;
+  readonly status: WorkspaceBulkJobStatus;
+  readonly total: number;
+  readonly succeeded: number;
+  readonly failed: number;
+  readonly skipped: number;
+  readonly created_at: string;
+  readonly completed_at?: string;
+  readonly results: readonly WorkspaceBulkJobResult[];
+}
+
+// From codersdk/workspacebulkjobs.go
+export interface WorkspaceBulkJobResult {
+  readonly workspace_id: string;
+  readonly workspace_name: string;
+  readonly workspace_owner_name: string;
+  readonly status: WorkspaceBulkResultStatus;
+  readonly error?: string;
+  readonly build_id?: string;
+  readonly updated_at: string;
+}
+
 // From codersdk/deployment.go
 export interface WorkspaceConnectionLatencyMS {
   readonly P50: number;
@@ -1886,6 +2342,14 @@ export interface WorkspaceFilter {
   readonly q?: string;
 }
 
+// From codersdk/workspaces.go
+export interface WorkspaceGroup {
+  readonly id: string;
+  readonly name: string;
+  readonly display_name: string;
+  readonly role: WorkspaceRole;
+}
+
 // From codersdk/workspaces.go
 export interface WorkspaceHealth {
   readonly healthy: boolean;
@@ -1949,6 +2413,11 @@ export interface WorkspaceResourceMetadata {
   readonly sensitive: boolean;
 }
 
+// From codersdk/workspaces.go
+export interface WorkspaceUser extends MinimalUser {
+  readonly role: WorkspaceRole;
+}
+
 // From codersdk/workspaces.go
 export interface WorkspacesRequest extends Pagination {
   readonly q?: string;
@@ -1974,8 +2443,10 @@ export const AgentSubsystems: AgentSubsystem[] = [
 
 // From codersdk/audit.go
 export type AuditAction =
+  | "connect"
   | "create"
   | "delete"
+  | "disconnect"
   | "login"
   | "logout"
   | "register"
@@ -1983,8 +2454,10 @@ export type AuditAction =
   | "stop"
   | "write";
 export const AuditActions: AuditAction[] = [
+  "connect",
   "create",
   "delete",
+  "disconnect",
   "login",
   "logout",
   "register",
@@ -1993,6 +2466,18 @@ export const AuditActions: AuditAction[] = [
   "write",
 ];
 
+// From codersdk/audit.go
+export type AuditLogExportFormat = "csv" | "jsonl";
+export const AuditLogExportFormats: AuditLogExportFormat[] = ["csv", "jsonl"];
+
+// From codersdk/authorization.go
+export type AuthorizationDecision = "abstain" | "allow" | "deny";
+export const AuthorizationDecisions: AuthorizationDecision[] = [
+  "abstain",
+  "allow",
+  "deny",
+];
+
 // From codersdk/workspaces.go
 export type AutomaticUpdates = "always" | "never";
 export const AutomaticUpdateses: AutomaticUpdates[] = ["always", "never"];
@@ -2143,10 +2628,18 @@ export type LogSource = "provisioner" | "provisioner_daemon";
 export const LogSources: LogSource[] = ["provisioner", "provisioner_daemon"];
 
 // From codersdk/apikey.go
-export type LoginType = "" | "github" | "none" | "oidc" | "password" | "token";
+export type LoginType =
+  | ""
+  | "github"
+  | "ldap"
+  | "none"
+  | "oidc"
+  | "password"
+  | "token";
 export const LoginTypes: LoginType[] = [
   "",
   "github",
+  "ldap",
   "none",
   "oidc",
   "password",
@@ -2154,10 +2647,22 @@ export const LoginTypes: LoginType[] = [
 ];
 
 // From codersdk/oauth2.go
-export type OAuth2ProviderGrantType = "authorization_code" | "refresh_token";
+export type OAuth2PKCECodeChallengeMethod = "S256";
+export const OAuth2PKCECodeChallengeMethods: OAuth2PKCECodeChallengeMethod[] = [
+  "S256",
+];
+
+// From codersdk/oauth2.go
+export type OAuth2ProviderGrantType =
+  | "authorization_code"
+  | "client_credentials"
+  | "refresh_token"
+  | "urn:ietf:params:oauth:grant-type:device_code";
 export const OAuth2ProviderGrantTypes: OAuth2ProviderGrantType[] = [
   "authorization_code",
+  "client_credentials",
   "refresh_token",
+  "urn:ietf:params:oauth:grant-type:device_code",
 ];
 
 // From codersdk/oauth2.go
@@ -2312,6 +2817,7 @@ export type ResourceType =
   | "health_settings"
   | "license"
   | "notifications_settings"
+  | "notification_template"
   | "oauth2_provider_app"
   | "oauth2_provider_app_secret"
   | "organization"
@@ -2330,6 +2836,7 @@ export const ResourceTypes: ResourceType[] = [
   "health_settings",
   "license",
   "notifications_settings",
+  "notification_template",
   "oauth2_provider_app",
   "oauth2_provider_app_secret",
   "organization",
@@ -2465,6 +2972,47 @@ export const WorkspaceAppSharingLevels: WorkspaceAppSharingLevel[] = [
   "public",
 ];
 
+// From codersdk/workspacebulkjobs.go
+export type WorkspaceBulkAction =
+  | "automatic_updates"
+  | "delete"
+  | "dormant"
+  | "start"
+  | "stop"
+  | "update";
+export const WorkspaceBulkActions: WorkspaceBulkAction[] = [
+  "automatic_updates",
+  "delete",
+  "dormant",
+  "start",
+  "stop",
+  "update",
+];
+
+// From codersdk/workspacebulkjobs.go
+export type WorkspaceBulkJobStatus = "completed" | "running";
+export const WorkspaceBulkJobStatuses: WorkspaceBulkJobStatus[] = [
+  "completed",
+  "running",
+];
+
+// From codersdk/workspacebulkjobs.go
+export type WorkspaceBulkResultStatus =
+  | "failed"
+  | "pending"
+  | "skipped"
+  | "succeeded";
+export const WorkspaceBulkResultStatuses: WorkspaceBulkResultStatus[] = [
+  "failed",
+  "pending",
+  "skipped",
+  "succeeded",
+];
+
+// From codersdk/workspaces.go
+export type WorkspaceRole = "" | "admin" | "use";
+export const WorkspaceRoles: WorkspaceRole[] = ["", "admin", "use"];
+
 // From codersdk/workspacebuilds.go
 export type WorkspaceStatus =
   | "canceled"
@@ -33,7 +33,8 @@ export type AuthContextValue = {
   signInError: unknown;
   updateProfileError: unknown;
   signOut: () => void;
-  signIn: (email: string, password: string) => Promise<void>;
+  signIn: (email: string, password: string, mfaCode?: string) => Promise<void>;
+  signInWithLDAP: (username: string, password: string) => Promise<void>;
   updateProfile: (data: UpdateUserProfileRequest) => void;
 };
 
@@ -88,8 +89,15 @@ export const AuthProvider: FC<PropsWithChildren> = ({ children }) => {
   }, [logoutMutation]);
 
   const signIn = useCallback(
-    async (email: string, password: string) => {
-      await loginMutation.mutateAsync({ email, password });
+    async (email: string, password: string, mfaCode?: string) => {
+      await loginMutation.mutateAsync({ email, password, mfa_code: mfaCode });
+    },
+    [loginMutation],
+  );
+
+  const signInWithLDAP = useCallback(
+    async (username: string, password: string) => {
+      await loginMutation.mutateAsync({ username, password });
     },
     [loginMutation],
   );
@@ -113,6 +121,7 @@ export const AuthProvider: FC<PropsWithChildren> = ({ children }) => {
         isUpdatingProfile,
         signOut,
         signIn,
+        signInWithLDAP,
         updateProfile,
         user: userQuery.data,
         permissions: permissionsQuery.data as Permissions | undefined,
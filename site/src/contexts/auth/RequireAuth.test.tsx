@@ -50,6 +50,7 @@ const createAuthWrapper = (override: Partial<AuthContextValue>) => {
     updateProfileError: undefined,
     signOut: jest.fn(),
     signIn: jest.fn(),
+    signInWithLDAP: jest.fn(),
     updateProfile: jest.fn(),
     ...override,
   };
@@ -12,6 +12,7 @@ export const checks = {
   viewExternalAuthConfig: "viewExternalAuthConfig",
   viewDeploymentStats: "viewDeploymentStats",
   editWorkspaceProxies: "editWorkspaceProxies",
+  revokeUserSessions: "revokeUserSessions",
 } as const;
 
 export const permissionsToCheck = {
@@ -93,6 +94,12 @@ export const permissionsToCheck = {
     },
     action: "create",
   },
+  [checks.revokeUserSessions]: {
+    object: {
+      resource_type: "api_key",
+    },
+    action: "delete",
+  },
 } as const;
 
 export type Permissions = Record<keyof typeof permissionsToCheck, boolean>;
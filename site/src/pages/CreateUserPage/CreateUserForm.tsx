@@ -38,6 +38,10 @@ export const authMethodLanguage = {
     displayName: "OpenID Connect",
     description: "Use an OpenID Connect provider for authentication",
   },
+  ldap: {
+    displayName: "LDAP",
+    description: "Use the LDAP directory of the deployment for authentication",
+  },
   github: {
     displayName: "Github",
     description: "Use Github OAuth for authentication",
@@ -109,6 +113,7 @@ export const CreateUserForm: FC<
   const methods = [
     authMethods?.password.enabled && "password",
     authMethods?.oidc.enabled && "oidc",
+    authMethods?.ldap.enabled && "ldap",
     authMethods?.github.enabled && "github",
     "none",
   ].filter(Boolean) as Array<keyof typeof authMethodLanguage>;
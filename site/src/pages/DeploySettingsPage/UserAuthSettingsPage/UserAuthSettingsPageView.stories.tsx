@@ -12,6 +12,11 @@ const ghGroup: SerpentGroup = {
   description: "",
 };
 
+const ldapGroup: SerpentGroup = {
+  name: "LDAP",
+  description: "",
+};
+
 const meta: Meta<typeof UserAuthSettingsPageView> = {
   title: "pages/DeploySettingsPage/UserAuthSettingsPageView",
   component: UserAuthSettingsPageView,
@@ -111,6 +116,23 @@ const meta: Meta<typeof UserAuthSettingsPageView> = {
         flag_shorthand: "o",
         hidden: false,
       },
+      {
+        name: "LDAP URL",
+        description:
+          "URL of the LDAP server to use for Login with LDAP, e.g. ldaps://ldap.example.com:636. Login with LDAP is disabled if this is not set.",
+        value: "ldaps://ldap.example.com:636",
+        group: ldapGroup,
+        flag: "ldap-url",
+        hidden: false,
+      },
+      {
+        name: "LDAP User Search Base DN",
+        description: "DN of the subtree that is searched for users.",
+        value: "ou=people,dc=example,dc=com",
+        group: ldapGroup,
+        flag: "ldap-user-search-base-dn",
+        hidden: false,
+      },
     ],
   },
 };
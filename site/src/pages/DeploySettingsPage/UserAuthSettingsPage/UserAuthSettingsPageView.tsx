@@ -22,6 +22,9 @@ export const UserAuthSettingsPageView = ({
   const githubEnabled = Boolean(
     useDeploymentOptions(options, "OAuth2 GitHub Client ID")[0].value,
   );
+  const ldapEnabled = Boolean(
+    useDeploymentOptions(options, "LDAP URL")[0].value,
+  );
 
   return (
     <>
@@ -67,6 +70,25 @@ export const UserAuthSettingsPageView = ({
             />
           )}
         </div>
+
+        <div>
+          <Header
+            title="Login with LDAP"
+            secondary
+            description="Set up authentication to login with an LDAP directory."
+            docsHref={docs("/admin/auth#ldap")}
+          />
+
+          <Badges>{ldapEnabled ? <EnabledBadge /> : <DisabledBadge />}</Badges>
+
+          {ldapEnabled && (
+            <OptionsTable
+              options={options.filter((o) =>
+                deploymentGroupHasParent(o.group, "LDAP"),
+              )}
+            />
+          )}
+        </div>
       </Stack>
     </>
   );
@@ -0,0 +1,70 @@
+import LoadingButton from "@mui/lab/LoadingButton";
+import TextField from "@mui/material/TextField";
+import { useFormik } from "formik";
+import type { FC } from "react";
+import * as Yup from "yup";
+import { Stack } from "components/Stack/Stack";
+import { getFormHelpers, onChangeTrimmed } from "utils/formUtils";
+import { Language } from "./SignInForm";
+
+type LDAPSignInFormProps = {
+  onSubmit: (credentials: { username: string; password: string }) => void;
+  isSigningIn: boolean;
+  autoFocus: boolean;
+  signInText: string;
+};
+
+export const LDAPSignInForm: FC<LDAPSignInFormProps> = ({
+  onSubmit,
+  isSigningIn,
+  autoFocus,
+  signInText,
+}) => {
+  const validationSchema = Yup.object({
+    username: Yup.string().trim().required(Language.usernameRequired),
+    password: Yup.string(),
+  });
+
+  const form = useFormik({
+    initialValues: {
+      username: "",
+      password: "",
+    },
+    validationSchema,
+    onSubmit,
+    validateOnBlur: false,
+  });
+  const getFieldHelpers = getFormHelpers(form);
+
+  return (
+    <form onSubmit={form.handleSubmit}>
+      <Stack spacing={2.5}>
+        <TextField
+          {...getFieldHelpers("username")}
+          onChange={onChangeTrimmed(form)}
+          autoFocus={autoFocus}
+          autoComplete="username"
+          fullWidth
+          id="ldap-username"
+          label={Language.usernameLabel}
+        />
+        <TextField
+          {...getFieldHelpers("password")}
+          autoComplete="current-password"
+          fullWidth
+          id="ldap-password"
+          label={Language.passwordLabel}
+          type="password"
+        />
+        <LoadingButton
+          size="xlarge"
+          loading={isSigningIn}
+          fullWidth
+          type="submit"
+        >
+          Sign in with {signInText || Language.ldapSignIn}
+        </LoadingButton>
+      </Stack>
+    </form>
+  );
+};
@@ -1,4 +1,4 @@
-import { fireEvent, screen } from "@testing-library/react";
+import { fireEvent, screen, waitFor } from "@testing-library/react";
 import userEvent from "@testing-library/user-event";
 import { HttpResponse, http } from "msw";
 import { createMemoryRouter } from "react-router-dom";
@@ -47,6 +47,43 @@ describe("LoginPage", () => {
     expect(errorMessage).toBeDefined();
   });
 
+  it("asks for a multi-factor authentication code", async () => {
+    // Given
+    let mfaCode: string | undefined;
+    server.use(
+      http.post("/api/v2/users/login", async ({ request }) => {
+        const body = (await request.json()) as { mfa_code?: string };
+        mfaCode = body.mfa_code;
+        return HttpResponse.json(
+          {
+            message: "Multi-factor authentication code required.",
+            validations: [{ field: "mfa_code", detail: "Enter a code." }],
+          },
+          { status: 401 },
+        );
+      }),
+    );
+
+    // When
+    render(<LoginPage />);
+    await waitForLoaderToBeRemoved();
+    await userEvent.type(
+      screen.getByLabelText(Language.emailLabel),
+      "test@coder.com",
+    );
+    await userEvent.type(
+      screen.getByLabelText(Language.passwordLabel),
+      "password",
+    );
+    fireEvent.click(await screen.findByText(Language.passwordSignIn));
+    const code = await screen.findByLabelText(Language.mfaCodeLabel);
+    await userEvent.type(code, "123456");
+    fireEvent.click(await screen.findByText(Language.passwordSignIn));
+
+    // Then
+    await waitFor(() => expect(mfaCode).toBe("123456"));
+  });
+
   it("redirects to the setup page if there is no first user", async () => {
     // Given
     server.use(
@@ -18,6 +18,7 @@ export const LoginPage: FC = () => {
     isSignedIn,
     isConfiguringTheFirstUser,
     signIn,
+    signInWithLDAP,
     isSigningIn,
     signInError,
     user,
@@ -94,8 +95,12 @@ export const LoginPage: FC = () => {
         isLoading={isLoading || authMethodsQuery.isLoading}
         buildInfo={buildInfoQuery.data}
         isSigningIn={isSigningIn}
-        onSignIn={async ({ email, password }) => {
-          await signIn(email, password);
+        onSignIn={async ({ email, password, mfa_code }) => {
+          await signIn(email, password, mfa_code);
+          navigate("/");
+        }}
+        onLDAPSignIn={async ({ username, password }) => {
+          await signInWithLDAP(username, password);
           navigate("/");
         }}
       />
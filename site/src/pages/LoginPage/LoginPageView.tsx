@@ -16,7 +16,12 @@ export interface LoginPageViewProps {
   isLoading: boolean;
   buildInfo?: BuildInfoResponse;
   isSigningIn: boolean;
-  onSignIn: (credentials: { email: string; password: string }) => void;
+  onSignIn: (credentials: {
+    email: string;
+    password: string;
+    mfa_code?: string;
+  }) => void;
+  onLDAPSignIn: (credentials: { username: string; password: string }) => void;
 }
 
 export const LoginPageView: FC<LoginPageViewProps> = ({
@@ -26,6 +31,7 @@ export const LoginPageView: FC<LoginPageViewProps> = ({
   buildInfo,
   isSigningIn,
   onSignIn,
+  onLDAPSignIn,
 }) => {
   const location = useLocation();
   const redirectTo = retrieveRedirect(location.search);
@@ -74,6 +80,7 @@ export const LoginPageView: FC<LoginPageViewProps> = ({
             error={error}
             message={message}
             onSubmit={onSignIn}
+            onLDAPSubmit={onLDAPSignIn}
           />
         )}
         <footer css={styles.footer}>
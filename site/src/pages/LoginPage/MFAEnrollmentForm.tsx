@@ -0,0 +1,104 @@
+import LoadingButton from "@mui/lab/LoadingButton";
+import Link from "@mui/material/Link";
+import TextField from "@mui/material/TextField";
+import { type FC, useState } from "react";
+import { useMutation } from "react-query";
+import { enrollTOTP, verifyTOTP } from "api/queries/users";
+import { ErrorAlert } from "components/Alert/ErrorAlert";
+import { CodeExample } from "components/CodeExample/CodeExample";
+import { Stack } from "components/Stack/Stack";
+
+export const Language = {
+  description:
+    "This deployment requires multi-factor authentication. Add an account to your authenticator app to continue.",
+  start: "Set up authenticator",
+  provisioningLink: "Open in authenticator app",
+  secretDescription: "Or enter this secret manually:",
+  codeLabel: "Code from authenticator app",
+  verify: "Verify",
+  recoveryCodesDescription:
+    "Store these recovery codes somewhere safe. Each can be used once in place of a code if you lose access to your authenticator app.",
+  done: "Continue to sign in",
+};
+
+type MFAEnrollmentFormProps = {
+  onComplete: () => void;
+};
+
+export const MFAEnrollmentForm: FC<MFAEnrollmentFormProps> = ({
+  onComplete,
+}) => {
+  const enrollMutation = useMutation(enrollTOTP("me"));
+  const verifyMutation = useMutation(verifyTOTP("me"));
+  const [code, setCode] = useState("");
+
+  if (verifyMutation.data) {
+    return (
+      <Stack spacing={2.5}>
+        <p>{Language.recoveryCodesDescription}</p>
+        {verifyMutation.data.recovery_codes.map((recoveryCode) => (
+          <CodeExample key={recoveryCode} code={recoveryCode} secret={false} />
+        ))}
+        <LoadingButton size="xlarge" fullWidth onClick={onComplete}>
+          {Language.done}
+        </LoadingButton>
+      </Stack>
+    );
+  }
+
+  if (!enrollMutation.data) {
+    return (
+      <Stack spacing={2.5}>
+        {Boolean(enrollMutation.error) && (
+          <ErrorAlert error={enrollMutation.error} />
+        )}
+        <p>{Language.description}</p>
+        <LoadingButton
+          size="xlarge"
+          fullWidth
+          loading={enrollMutation.isLoading}
+          onClick={() => enrollMutation.mutate()}
+        >
+          {Language.start}
+        </LoadingButton>
+      </Stack>
+    );
+  }
+
+  return (
+    <form
+      onSubmit={(event) => {
+        event.preventDefault();
+        verifyMutation.mutate({ code });
+      }}
+    >
+      <Stack spacing={2.5}>
+        {Boolean(verifyMutation.error) && (
+          <ErrorAlert error={verifyMutation.error} />
+        )}
+        <Link href={enrollMutation.data.provisioning_uri}>
+          {Language.provisioningLink}
+        </Link>
+        <p>{Language.secretDescription}</p>
+        <CodeExample code={enrollMutation.data.secret} secret={false} />
+        <TextField
+          autoFocus
+          autoComplete="one-time-code"
+          fullWidth
+          id="code"
+          label={Language.codeLabel}
+          value={code}
+          onChange={(event) => setCode(event.target.value.trim())}
+        />
+        <LoadingButton
+          size="xlarge"
+          fullWidth
+          type="submit"
+          loading={verifyMutation.isLoading}
+        >
+          {Language.verify}
+        </LoadingButton>
+      </Stack>
+    </form>
+  );
+};
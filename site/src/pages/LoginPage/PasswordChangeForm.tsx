@@ -0,0 +1,99 @@
+import LoadingButton from "@mui/lab/LoadingButton";
+import TextField from "@mui/material/TextField";
+import { type FormikContextType, useFormik } from "formik";
+import type { FC } from "react";
+import { useMutation } from "react-query";
+import * as Yup from "yup";
+import { updatePassword } from "api/queries/users";
+import { ErrorAlert } from "components/Alert/ErrorAlert";
+import { Stack } from "components/Stack/Stack";
+import { getFormHelpers } from "utils/formUtils";
+
+interface PasswordChangeFormValues {
+  password: string;
+  confirm_password: string;
+}
+
+export const Language = {
+  description: "Your password has expired. Choose a new password to continue.",
+  newPasswordLabel: "New Password",
+  confirmPasswordLabel: "Confirm Password",
+  newPasswordRequired: "New password is required",
+  confirmPasswordMatch: "Password and confirmation must match",
+  submit: "Change password",
+};
+
+const validationSchema = Yup.object({
+  password: Yup.string().trim().required(Language.newPasswordRequired),
+  confirm_password: Yup.string()
+    .trim()
+    .test("passwords-match", Language.confirmPasswordMatch, function (value) {
+      return (this.parent as PasswordChangeFormValues).password === value;
+    }),
+});
+
+type PasswordChangeFormProps = {
+  oldPassword: string;
+  onComplete: () => void;
+};
+
+export const PasswordChangeForm: FC<PasswordChangeFormProps> = ({
+  oldPassword,
+  onComplete,
+}) => {
+  const updateMutation = useMutation(updatePassword());
+  const form: FormikContextType<PasswordChangeFormValues> =
+    useFormik<PasswordChangeFormValues>({
+      initialValues: {
+        password: "",
+        confirm_password: "",
+      },
+      validationSchema,
+      onSubmit: async ({ password }) => {
+        await updateMutation.mutateAsync({
+          userId: "me",
+          old_password: oldPassword,
+          password,
+        });
+        onComplete();
+      },
+    });
+  const getFieldHelpers = getFormHelpers<PasswordChangeFormValues>(
+    form,
+    updateMutation.error,
+  );
+
+  return (
+    <form onSubmit={form.handleSubmit}>
+      <Stack spacing={2.5}>
+        {Boolean(updateMutation.error) && (
+          <ErrorAlert error={updateMutation.error} />
+        )}
+        <p>{Language.description}</p>
+        <TextField
+          {...getFieldHelpers("password")}
+          autoFocus
+          autoComplete="new-password"
+          fullWidth
+          label={Language.newPasswordLabel}
+          type="password"
+        />
+        <TextField
+          {...getFieldHelpers("confirm_password")}
+          autoComplete="new-password"
+          fullWidth
+          label={Language.confirmPasswordLabel}
+          type="password"
+        />
+        <LoadingButton
+          size="xlarge"
+          fullWidth
+          type="submit"
+          loading={updateMutation.isLoading}
+        >
+          {Language.submit}
+        </LoadingButton>
+      </Stack>
+    </form>
+  );
+};
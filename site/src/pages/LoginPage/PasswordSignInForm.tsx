@@ -8,15 +8,21 @@ import { getFormHelpers, onChangeTrimmed } from "utils/formUtils";
 import { Language } from "./SignInForm";
 
 type PasswordSignInFormProps = {
-  onSubmit: (credentials: { email: string; password: string }) => void;
+  onSubmit: (credentials: {
+    email: string;
+    password: string;
+    mfa_code?: string;
+  }) => void;
   isSigningIn: boolean;
   autoFocus: boolean;
+  mfaRequired?: boolean;
 };
 
 export const PasswordSignInForm: FC<PasswordSignInFormProps> = ({
   onSubmit,
   isSigningIn,
   autoFocus,
+  mfaRequired = false,
 }) => {
   const validationSchema = Yup.object({
     email: Yup.string()
@@ -24,12 +30,14 @@ export const PasswordSignInForm: FC<PasswordSignInFormProps> = ({
       .email(Language.emailInvalid)
       .required(Language.emailRequired),
     password: Yup.string(),
+    mfa_code: Yup.string().trim(),
   });
 
   const form = useFormik({
     initialValues: {
       email: "",
       password: "",
+      mfa_code: "",
     },
     validationSchema,
     onSubmit,
@@ -57,6 +65,18 @@ export const PasswordSignInForm: FC<PasswordSignInFormProps> = ({
           label={Language.passwordLabel}
           type="password"
         />
+        {mfaRequired && (
+          <TextField
+            {...getFieldHelpers("mfa_code", {
+              helperText: Language.mfaCodeHelperText,
+            })}
+            onChange={onChangeTrimmed(form)}
+            autoFocus
+            autoComplete="one-time-code"
+            fullWidth
+            label={Language.mfaCodeLabel}
+          />
+        )}
         <LoadingButton
           size="xlarge"
           loading={isSigningIn}
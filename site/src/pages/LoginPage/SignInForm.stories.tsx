@@ -1,4 +1,5 @@
 import type { Meta, StoryObj } from "@storybook/react";
+import { PasswordChangeRequiredError } from "api/queries/users";
 import { mockApiError } from "testHelpers/entities";
 import { SignInForm } from "./SignInForm";
 
@@ -22,6 +23,7 @@ export const SigningIn: Story = {
       password: { enabled: true },
       github: { enabled: true },
       oidc: { enabled: false, signInText: "", iconUrl: "" },
+      ldap: { enabled: false, signInText: "" },
     },
   },
 };
@@ -40,12 +42,34 @@ export const WithError: Story = {
   },
 };
 
+export const WithMFARequired: Story = {
+  args: {
+    error: mockApiError({
+      message: "Multi-factor authentication code required.",
+      validations: [
+        {
+          field: "mfa_code",
+          detail:
+            "Enter the code from your authenticator app or a recovery code.",
+        },
+      ],
+    }),
+  },
+};
+
+export const WithPasswordChangeRequired: Story = {
+  args: {
+    error: new PasswordChangeRequiredError("SomeSecurePassword!"),
+  },
+};
+
 export const WithGithub: Story = {
   args: {
     authMethods: {
       password: { enabled: true },
       github: { enabled: true },
       oidc: { enabled: false, signInText: "", iconUrl: "" },
+      ldap: { enabled: false, signInText: "" },
     },
   },
 };
@@ -56,6 +80,7 @@ export const WithOIDC: Story = {
       password: { enabled: true },
       github: { enabled: false },
       oidc: { enabled: true, signInText: "", iconUrl: "" },
+      ldap: { enabled: false, signInText: "" },
     },
   },
 };
@@ -66,6 +91,7 @@ export const WithOIDCWithoutPassword: Story = {
       password: { enabled: false },
       github: { enabled: false },
       oidc: { enabled: true, signInText: "", iconUrl: "" },
+      ldap: { enabled: false, signInText: "" },
     },
   },
 };
@@ -76,6 +102,7 @@ export const WithoutAny: Story = {
       password: { enabled: false },
       github: { enabled: false },
       oidc: { enabled: false, signInText: "", iconUrl: "" },
+      ldap: { enabled: false, signInText: "" },
     },
   },
 };
@@ -86,6 +113,29 @@ export const WithGithubAndOIDC: Story = {
       password: { enabled: true },
       github: { enabled: true },
       oidc: { enabled: true, signInText: "", iconUrl: "" },
+      ldap: { enabled: false, signInText: "" },
+    },
+  },
+};
+
+export const WithLDAP: Story = {
+  args: {
+    authMethods: {
+      password: { enabled: false },
+      github: { enabled: false },
+      oidc: { enabled: false, signInText: "", iconUrl: "" },
+      ldap: { enabled: true, signInText: "Active Directory" },
+    },
+  },
+};
+
+export const WithPasswordAndLDAP: Story = {
+  args: {
+    authMethods: {
+      password: { enabled: true },
+      github: { enabled: false },
+      oidc: { enabled: false, signInText: "", iconUrl: "" },
+      ldap: { enabled: true, signInText: "Active Directory" },
     },
   },
 };
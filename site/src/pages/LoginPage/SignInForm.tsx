@@ -1,10 +1,18 @@
 import type { Interpolation, Theme } from "@emotion/react";
-import type { FC, ReactNode } from "react";
+import { type FC, type ReactNode, useState } from "react";
+import { isApiError } from "api/errors";
+import {
+  MFAEnrollmentRequiredError,
+  PasswordChangeRequiredError,
+} from "api/queries/users";
 import type { AuthMethods } from "api/typesGenerated";
 import { Alert } from "components/Alert/Alert";
 import { ErrorAlert } from "components/Alert/ErrorAlert";
 import { getApplicationName } from "utils/appearance";
+import { LDAPSignInForm } from "./LDAPSignInForm";
+import { MFAEnrollmentForm } from "./MFAEnrollmentForm";
 import { OAuthSignInForm } from "./OAuthSignInForm";
+import { PasswordChangeForm } from "./PasswordChangeForm";
 import { PasswordSignInForm } from "./PasswordSignInForm";
 
 export const Language = {
@@ -12,9 +20,17 @@ export const Language = {
   passwordLabel: "Password",
   emailInvalid: "Please enter a valid email address.",
   emailRequired: "Please enter an email address.",
+  usernameLabel: "Username",
+  usernameRequired: "Please enter a username.",
+  mfaCodeLabel: "Authentication code",
+  mfaCodeHelperText:
+    "Enter the code from your authenticator app or a recovery code.",
+  mfaEnrolled: "Sign in again with a code from your authenticator app.",
+  passwordChanged: "Sign in again with your new password.",
   passwordSignIn: "Sign In",
   githubSignIn: "GitHub",
   oidcSignIn: "OpenID Connect",
+  ldapSignIn: "LDAP",
 };
 
 const styles = {
@@ -66,9 +82,20 @@ export interface SignInFormProps {
   error?: unknown;
   message?: ReactNode;
   authMethods?: AuthMethods;
-  onSubmit: (credentials: { email: string; password: string }) => void;
+  onSubmit: (credentials: {
+    email: string;
+    password: string;
+    mfa_code?: string;
+  }) => void;
+  onLDAPSubmit: (credentials: { username: string; password: string }) => void;
 }
 
+const isMFARequiredError = (error: unknown): boolean =>
+  isApiError(error) &&
+  Boolean(
+    error.response.data.validations?.some((v) => v.field === "mfa_code"),
+  );
+
 export const SignInForm: FC<SignInFormProps> = ({
   authMethods,
   redirectTo,
@@ -76,20 +103,61 @@ export const SignInForm: FC<SignInFormProps> = ({
   error,
   message,
   onSubmit,
+  onLDAPSubmit,
 }) => {
   const oAuthEnabled = Boolean(
     authMethods?.github.enabled || authMethods?.oidc.enabled,
   );
   const passwordEnabled = authMethods?.password.enabled ?? true;
+  const ldapEnabled = authMethods?.ldap.enabled ?? false;
   const applicationName = getApplicationName();
+  const [mfaEnrolled, setMFAEnrolled] = useState(false);
+  const mfaEnrollmentRequired = error instanceof MFAEnrollmentRequiredError;
+  const [passwordChanged, setPasswordChanged] = useState(false);
+  const passwordChangeRequired = error instanceof PasswordChangeRequiredError;
+
+  if (mfaEnrollmentRequired && !mfaEnrolled) {
+    return (
+      <div css={styles.root}>
+        <h1 css={styles.title}>{applicationName}</h1>
+        <MFAEnrollmentForm onComplete={() => setMFAEnrolled(true)} />
+      </div>
+    );
+  }
+
+  if (error instanceof PasswordChangeRequiredError && !passwordChanged) {
+    return (
+      <div css={styles.root}>
+        <h1 css={styles.title}>{applicationName}</h1>
+        <PasswordChangeForm
+          oldPassword={error.oldPassword}
+          onComplete={() => setPasswordChanged(true)}
+        />
+      </div>
+    );
+  }
 
   return (
     <div css={styles.root}>
       <h1 css={styles.title}>{applicationName}</h1>
 
-      {Boolean(error) && (
+      {Boolean(error) &&
+        !mfaEnrollmentRequired &&
+        !passwordChangeRequired && (
+          <div css={styles.alert}>
+            <ErrorAlert error={error} />
+          </div>
+        )}
+
+      {mfaEnrolled && (
         <div css={styles.alert}>
-          <ErrorAlert error={error} />
+          <Alert severity="info">{Language.mfaEnrolled}</Alert>
+        </div>
+      )}
+
+      {passwordChanged && (
+        <div css={styles.alert}>
+          <Alert severity="info">{Language.passwordChanged}</Alert>
         </div>
       )}
 
@@ -120,10 +188,28 @@ export const SignInForm: FC<SignInFormProps> = ({
           onSubmit={onSubmit}
           autoFocus={!oAuthEnabled}
           isSigningIn={isSigningIn}
+          mfaRequired={mfaEnrolled || isMFARequiredError(error)}
+        />
+      )}
+
+      {ldapEnabled && (passwordEnabled || oAuthEnabled) && (
+        <div css={styles.divider}>
+          <div css={styles.dividerLine} />
+          <div css={styles.dividerLabel}>or</div>
+          <div css={styles.dividerLine} />
+        </div>
+      )}
+
+      {ldapEnabled && (
+        <LDAPSignInForm
+          onSubmit={onLDAPSubmit}
+          autoFocus={!oAuthEnabled && !passwordEnabled}
+          isSigningIn={isSigningIn}
+          signInText={authMethods?.ldap.signInText ?? ""}
         />
       )}
 
-      {!passwordEnabled && !oAuthEnabled && (
+      {!passwordEnabled && !oAuthEnabled && !ldapEnabled && (
         <Alert severity="error">No authentication methods configured!</Alert>
       )}
     </div>
@@ -19,6 +19,7 @@ import {
   updatePassword,
   updateRoles,
   authMethods,
+  revokeUserSessions,
 } from "api/queries/users";
 import type { User } from "api/typesGenerated";
 import { ConfirmDialog } from "components/Dialogs/ConfirmDialog/ConfirmDialog";
@@ -51,6 +52,7 @@ const UsersPage: FC = () => {
   const {
     createUser: canCreateUser,
     updateUsers: canEditUsers,
+    revokeUserSessions: canSignOutUsers,
     viewDeploymentValues,
   } = permissions;
   const rolesQuery = useQuery(roles());
@@ -83,6 +85,9 @@ const UsersPage: FC = () => {
   const [userToDelete, setUserToDelete] = useState<User>();
   const deleteUserMutation = useMutation(deleteUser(queryClient));
 
+  const [userToSignOut, setUserToSignOut] = useState<User>();
+  const signOutUserMutation = useMutation(revokeUserSessions());
+
   const [confirmResetPassword, setConfirmResetPassword] = useState<{
     user: User;
     newPassword: string;
@@ -136,6 +141,7 @@ const UsersPage: FC = () => {
         onDeleteUser={setUserToDelete}
         onSuspendUser={setUserToSuspend}
         onActivateUser={setUserToActivate}
+        onSignOutUser={setUserToSignOut}
         onResetUserPassword={(user) => {
           setConfirmResetPassword({
             user,
@@ -156,6 +162,7 @@ const UsersPage: FC = () => {
         isLoading={isLoading}
         canEditUsers={canEditUsers}
         canViewActivity={entitlements.features.audit_log.enabled}
+        canSignOutUsers={canSignOutUsers}
         isNonInitialPage={isNonInitialPage(searchParams)}
         actorID={me.id}
         filterProps={{
@@ -236,6 +243,32 @@ const UsersPage: FC = () => {
         }
       />
 
+      <ConfirmDialog
+        type="delete"
+        hideCancel={false}
+        open={userToSignOut !== undefined}
+        confirmLoading={signOutUserMutation.isLoading}
+        title="Sign out user"
+        confirmText="Sign out"
+        onClose={() => setUserToSignOut(undefined)}
+        onConfirm={async () => {
+          try {
+            await signOutUserMutation.mutateAsync(userToSignOut!.id);
+            setUserToSignOut(undefined);
+            displaySuccess("Successfully signed out the user.");
+          } catch (e) {
+            displayError(getErrorMessage(e, "Error signing out user."));
+          }
+        }}
+        description={
+          <>
+            Do you want to sign out{" "}
+            <strong>{userToSignOut?.username ?? ""}</strong> everywhere? Tokens
+            are not revoked.
+          </>
+        }
+      />
+
       <ResetPasswordDialog
         key={confirmResetPassword?.user.username}
         open={confirmResetPassword !== undefined}
@@ -19,6 +19,7 @@ export interface UsersPageViewProps {
   canEditUsers: boolean;
   oidcRoleSyncEnabled: boolean;
   canViewActivity?: boolean;
+  canSignOutUsers?: boolean;
   isLoading: boolean;
   authMethods?: TypesGen.AuthMethods;
   onSuspendUser: (user: TypesGen.User) => void;
@@ -27,6 +28,7 @@ export interface UsersPageViewProps {
   onViewActivity: (user: TypesGen.User) => void;
   onActivateUser: (user: TypesGen.User) => void;
   onResetUserPassword: (user: TypesGen.User) => void;
+  onSignOutUser?: (user: TypesGen.User) => void;
   onUpdateUserRoles: (
     userId: string,
     roles: TypesGen.SlimRole["name"][],
@@ -51,11 +53,13 @@ export const UsersPageView: FC<UsersPageViewProps> = ({
   onViewActivity,
   onActivateUser,
   onResetUserPassword,
+  onSignOutUser,
   onUpdateUserRoles,
   isUpdatingUserRoles,
   canEditUsers,
   oidcRoleSyncEnabled,
   canViewActivity,
+  canSignOutUsers,
   isLoading,
   filterProps,
   isNonInitialPage,
@@ -103,11 +107,13 @@ export const UsersPageView: FC<UsersPageViewProps> = ({
           onViewActivity={onViewActivity}
           onActivateUser={onActivateUser}
           onResetUserPassword={onResetUserPassword}
+          onSignOutUser={onSignOutUser}
           onUpdateUserRoles={onUpdateUserRoles}
           isUpdatingUserRoles={isUpdatingUserRoles}
           canEditUsers={canEditUsers}
           oidcRoleSyncEnabled={oidcRoleSyncEnabled}
           canViewActivity={canViewActivity}
+          canSignOutUsers={canSignOutUsers}
           isLoading={isLoading}
           isNonInitialPage={isNonInitialPage}
           actorID={actorID}
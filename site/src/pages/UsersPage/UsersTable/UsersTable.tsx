@@ -27,6 +27,7 @@ export interface UsersTableProps {
   isUpdatingUserRoles?: boolean;
   canEditUsers: boolean;
   canViewActivity?: boolean;
+  canSignOutUsers?: boolean;
   isLoading: boolean;
   onSuspendUser: (user: TypesGen.User) => void;
   onActivateUser: (user: TypesGen.User) => void;
@@ -34,6 +35,7 @@ export interface UsersTableProps {
   onListWorkspaces: (user: TypesGen.User) => void;
   onViewActivity: (user: TypesGen.User) => void;
   onResetUserPassword: (user: TypesGen.User) => void;
+  onSignOutUser?: (user: TypesGen.User) => void;
   onUpdateUserRoles: (
     userId: string,
     roles: TypesGen.SlimRole["name"][],
@@ -53,10 +55,12 @@ export const UsersTable: FC<UsersTableProps> = ({
   onViewActivity,
   onActivateUser,
   onResetUserPassword,
+  onSignOutUser,
   onUpdateUserRoles,
   isUpdatingUserRoles,
   canEditUsers,
   canViewActivity,
+  canSignOutUsers,
   isLoading,
   isNonInitialPage,
   actorID,
@@ -101,12 +105,14 @@ export const UsersTable: FC<UsersTableProps> = ({
             isLoading={isLoading}
             canEditUsers={canEditUsers}
             canViewActivity={canViewActivity}
+            canSignOutUsers={canSignOutUsers}
             isUpdatingUserRoles={isUpdatingUserRoles}
             onActivateUser={onActivateUser}
             onDeleteUser={onDeleteUser}
             onListWorkspaces={onListWorkspaces}
             onViewActivity={onViewActivity}
             onResetUserPassword={onResetUserPassword}
+            onSignOutUser={onSignOutUser}
             onSuspendUser={onSuspendUser}
             onUpdateUserRoles={onUpdateUserRoles}
             isNonInitialPage={isNonInitialPage}
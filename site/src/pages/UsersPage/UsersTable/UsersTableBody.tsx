@@ -1,4 +1,5 @@
 import type { Interpolation, Theme } from "@emotion/react";
+import AccountTreeOutlined from "@mui/icons-material/AccountTreeOutlined";
 import GitHub from "@mui/icons-material/GitHub";
 import HideSourceOutlined from "@mui/icons-material/HideSourceOutlined";
 import KeyOutlined from "@mui/icons-material/KeyOutlined";
@@ -44,12 +45,14 @@ interface UsersTableBodyProps {
   canEditUsers: boolean;
   isLoading: boolean;
   canViewActivity?: boolean;
+  canSignOutUsers?: boolean;
   onSuspendUser: (user: TypesGen.User) => void;
   onDeleteUser: (user: TypesGen.User) => void;
   onListWorkspaces: (user: TypesGen.User) => void;
   onViewActivity: (user: TypesGen.User) => void;
   onActivateUser: (user: TypesGen.User) => void;
   onResetUserPassword: (user: TypesGen.User) => void;
+  onSignOutUser?: (user: TypesGen.User) => void;
   onUpdateUserRoles: (
     userId: string,
     roles: TypesGen.SlimRole["name"][],
@@ -72,10 +75,12 @@ export const UsersTableBody: FC<UsersTableBodyProps> = ({
   onViewActivity,
   onActivateUser,
   onResetUserPassword,
+  onSignOutUser,
   onUpdateUserRoles,
   isUpdatingUserRoles,
   canEditUsers,
   canViewActivity,
+  canSignOutUsers,
   isLoading,
   isNonInitialPage,
   actorID,
@@ -216,6 +221,11 @@ export const UsersTableBody: FC<UsersTableBodyProps> = ({
                     >
                       Reset password&hellip;
                     </MoreMenuItem>
+                    {canSignOutUsers && onSignOutUser && (
+                      <MoreMenuItem onClick={() => onSignOutUser(user)}>
+                        Sign out everywhere&hellip;
+                      </MoreMenuItem>
+                    )}
                     <Divider />
                     <MoreMenuItem
                       onClick={() => onDeleteUser(user)}
@@ -269,6 +279,10 @@ const LoginType: FC<LoginTypeProps> = ({ authMethods, value }) => {
           css={styles.icon}
         />
       );
+  } else if (value === "ldap") {
+    displayName =
+      authMethods.ldap.signInText === "" ? "LDAP" : authMethods.ldap.signInText;
+    icon = <AccountTreeOutlined css={styles.icon} />;
   }
 
   return (
@@ -71,6 +71,7 @@ export const MockToken: TypesGen.APIKeyWithOwner = {
   updated_at: "2022-12-16T20:10:45.637452Z",
   login_type: "token",
   scope: "all",
+  scopes: [],
   lifetime_seconds: 2592000,
   token_name: "token-one",
   username: "admin",
@@ -87,6 +88,7 @@ export const MockTokens: TypesGen.APIKeyWithOwner[] = [
     updated_at: "2022-12-16T20:10:45.637452Z",
     login_type: "token",
     scope: "all",
+    scopes: [],
     lifetime_seconds: 2592000,
     token_name: "token-two",
     username: "admin",
@@ -509,6 +511,7 @@ You can add instructions here
 [Some link info](https://coder.com)`,
   created_by: MockUser,
   archived: false,
+  presets: [],
 };
 
 export const MockTemplateVersion2: TypesGen.TemplateVersion = {
@@ -528,6 +531,7 @@ You can add instructions here
 [Some link info](https://coder.com)`,
   created_by: MockUser,
   archived: false,
+  presets: [],
 };
 
 export const MockTemplateVersionWithMarkdownMessage: TypesGen.TemplateVersion =
@@ -1467,6 +1471,7 @@ export const MockAuthMethodsPasswordOnly: TypesGen.AuthMethods = {
   password: { enabled: true },
   github: { enabled: false },
   oidc: { enabled: false, signInText: "", iconUrl: "" },
+  ldap: { enabled: false, signInText: "" },
 };
 
 export const MockAuthMethodsPasswordTermsOfService: TypesGen.AuthMethods = {
@@ -1474,6 +1479,7 @@ export const MockAuthMethodsPasswordTermsOfService: TypesGen.AuthMethods = {
   password: { enabled: true },
   github: { enabled: false },
   oidc: { enabled: false, signInText: "", iconUrl: "" },
+  ldap: { enabled: false, signInText: "" },
 };
 
 export const MockAuthMethodsExternal: TypesGen.AuthMethods = {
@@ -1484,6 +1490,7 @@ export const MockAuthMethodsExternal: TypesGen.AuthMethods = {
     signInText: "Google",
     iconUrl: "/icon/google.svg",
   },
+  ldap: { enabled: false, signInText: "" },
 };
 
 export const MockAuthMethodsAll: TypesGen.AuthMethods = {
@@ -1494,6 +1501,7 @@ export const MockAuthMethodsAll: TypesGen.AuthMethods = {
     signInText: "Google",
     iconUrl: "/icon/google.svg",
   },
+  ldap: { enabled: false, signInText: "" },
 };
 
 export const MockGitSSHKey: TypesGen.GitSSHKey = {
@@ -2485,6 +2493,7 @@ export const MockPermissions: Permissions = {
   viewDeploymentStats: true,
   viewExternalAuthConfig: true,
   editWorkspaceProxies: true,
+  revokeUserSessions: true,
 };
 
 export const MockDeploymentConfig: DeploymentConfig = {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/netip"
@@ -373,6 +374,10 @@ type Conn struct {
 	watchCancel func()
 
 	trafficStats *connstats.Statistics
+
+	// forwardTCPCallback is called for TCP connections forwarded to ports
+	// without a listener.
+	forwardTCPCallback func(src, dst netip.AddrPort) (closed func())
 }
 
 func (c *Conn) SetTunnelDestination(id uuid.UUID) {
@@ -664,9 +669,14 @@ func (c *Conn) forwardTCP(src, dst netip.AddrPort) (handler func(net.Conn), opts
 	logger := c.logger.Named("tcp").With(slog.F("src", src.String()), slog.F("dst", dst.String()))
 	c.mutex.Lock()
 	ln, ok := c.listeners[listenKey{"tcp", "", fmt.Sprint(dst.Port())}]
+	callback := c.forwardTCPCallback
 	c.mutex.Unlock()
 	if !ok {
-		return nil, nil, false
+		if callback == nil {
+			// Let netstack forward the connection to the loopback interface.
+			return nil, nil, false
+		}
+		return c.forwardTCPToLoopback(logger, src, dst, callback), nil, true
 	}
 	// See: https://github.com/tailscale/tailscale/blob/c7cea825aea39a00aca71ea02bab7266afc03e7c/wgengine/netstack/netstack.go#L888
 	if dst.Port() == WorkspaceAgentSSHPort || dst.Port() == 22 {
@@ -692,6 +702,68 @@ func (c *Conn) forwardTCP(src, dst netip.AddrPort) (handler func(net.Conn), opts
 	}, opts, true
 }
 
+// forwardTCPToLoopback returns a handler that forwards the connection to the
+// destination port on the loopback interface, like netstack does for ports
+// without a listener, and calls callback for the lifetime of the connection.
+func (*Conn) forwardTCPToLoopback(logger slog.Logger, src, dst netip.AddrPort, callback func(src, dst netip.AddrPort) (closed func())) func(net.Conn) {
+	return func(conn net.Conn) {
+		defer conn.Close()
+
+		var d net.Dialer
+		server, err := d.Dial("tcp", netip.AddrPortFrom(netip.AddrFrom4([4]byte{127, 0, 0, 1}), dst.Port()).String())
+		if err != nil {
+			// Retry with IPv6 loopback, as netstack does.
+			server, err = d.Dial("tcp", netip.AddrPortFrom(netip.IPv6Loopback(), dst.Port()).String())
+		}
+		if err != nil {
+			logger.Debug(context.Background(), "dial loopback for forwarded connection", slog.Error(err))
+			return
+		}
+		defer server.Close()
+
+		closed := callback(src, dst)
+		defer closed()
+
+		// Each direction is closed on its own once it reaches EOF, so that
+		// clients that close their side and then read the reply keep
+		// working. Both connections are closed if either side fails.
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			forwardHalf(server, conn)
+		}()
+		go func() {
+			defer wg.Done()
+			forwardHalf(conn, server)
+		}()
+		wg.Wait()
+	}
+}
+
+// forwardHalf copies src to dst and closes the write side of dst once src
+// reaches EOF. Both connections are closed if the copy fails.
+func forwardHalf(dst, src net.Conn) {
+	_, err := io.Copy(dst, src)
+	if err == nil {
+		if cw, ok := dst.(interface{ CloseWrite() error }); ok && cw.CloseWrite() == nil {
+			return
+		}
+	}
+	_ = dst.Close()
+	_ = src.Close()
+}
+
+// SetForwardTCPCallback sets a callback that is called when a TCP connection
+// to a port without a listener is forwarded to the loopback interface. The
+// returned function is called when the connection is closed. Multiple calls
+// overwrite the callback.
+func (c *Conn) SetForwardTCPCallback(callback func(src, dst netip.AddrPort) (closed func())) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.forwardTCPCallback = callback
+}
+
 // SetConnStatsCallback sets a callback to be called after maxPeriod or
 // maxConns, whichever comes first. Multiple calls overwrites the callback.
 func (c *Conn) SetConnStatsCallback(maxPeriod time.Duration, maxConns int, dump func(start, end time.Time, virtual, physical map[netlogtype.Connection]netlogtype.Counts)) {
@@ -2,6 +2,8 @@ package tailnet_test
 
 import (
 	"context"
+	"io"
+	"net"
 	"net/netip"
 	"testing"
 	"time"
@@ -246,6 +248,65 @@ func TestTailnet(t *testing.T) {
 		w1.Close()
 		w2.Close()
 	})
+
+	t.Run("ForwardTCPHalfClose", func(t *testing.T) {
+		t.Parallel()
+		logger := slogtest.Make(t, nil).Leveled(slog.LevelDebug)
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		// The server reads the request until EOF before it replies.
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer ln.Close()
+		go func() {
+			nc, err := ln.Accept()
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer nc.Close()
+			req, err := io.ReadAll(nc)
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, _ = nc.Write(append([]byte("reply to "), req...))
+		}()
+		port := uint16(ln.Addr().(*net.TCPAddr).Port)
+
+		w1IP := tailnet.IP()
+		w1, err := tailnet.NewConn(&tailnet.Options{
+			Addresses: []netip.Prefix{netip.PrefixFrom(w1IP, 128)},
+			Logger:    logger.Named("w1"),
+			DERPMap:   derpMap,
+		})
+		require.NoError(t, err)
+		w2, err := tailnet.NewConn(&tailnet.Options{
+			Addresses: []netip.Prefix{netip.PrefixFrom(tailnet.IP(), 128)},
+			Logger:    logger.Named("w2"),
+			DERPMap:   derpMap,
+		})
+		require.NoError(t, err)
+		defer w1.Close()
+		defer w2.Close()
+
+		forwarded := make(chan struct{})
+		w1.SetForwardTCPCallback(func(_, _ netip.AddrPort) func() {
+			return func() { close(forwarded) }
+		})
+		stitch(t, w2, w1)
+		stitch(t, w1, w2)
+		require.True(t, w2.AwaitReachable(ctx, w1IP))
+
+		nc, err := w2.DialContextTCP(ctx, netip.AddrPortFrom(w1IP, port))
+		require.NoError(t, err)
+		defer nc.Close()
+		_, err = nc.Write([]byte("hello"))
+		require.NoError(t, err)
+		require.NoError(t, nc.CloseWrite())
+		reply, err := io.ReadAll(nc)
+		require.NoError(t, err)
+		require.Equal(t, "reply to hello", string(reply))
+		_ = testutil.RequireRecvCtx(ctx, t, forwarded)
+	})
 }
 
 // TestConn_PreferredDERP tests that we only trigger the NodeCallback when we have a preferred DERP server.
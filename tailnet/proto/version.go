@@ -6,7 +6,7 @@ import (
 
 const (
 	CurrentMajor = 2
-	CurrentMinor = 2
+	CurrentMinor = 3
 )
 
 var CurrentVersion = apiversion.New(CurrentMajor, CurrentMinor).WithBackwardCompat(1)
@@ -86,6 +86,13 @@ func NewClientService(options ClientServiceOptions) (
 }
 
 func (s *ClientService) ServeClient(ctx context.Context, version string, conn net.Conn, id uuid.UUID, agent uuid.UUID) error {
+	return s.ServeClientWithAuth(ctx, version, conn, id, agent, ClientCoordinateeAuth{AgentID: agent})
+}
+
+// ServeClientWithAuth is like ServeClient, but authorizes the requests of
+// version 2 clients with auth. auth should wrap ClientCoordinateeAuth for the
+// same agent. Version 1 clients are always authorized by the coordinator.
+func (s *ClientService) ServeClientWithAuth(ctx context.Context, version string, conn net.Conn, id uuid.UUID, agent uuid.UUID, auth CoordinateeAuth) error {
 	major, _, err := apiversion.Parse(version)
 	if err != nil {
 		s.Logger.Warn(ctx, "serve client called with unparsable version", slog.Error(err))
@@ -96,7 +103,6 @@ func (s *ClientService) ServeClient(ctx context.Context, version string, conn ne
 		coord := *(s.CoordPtr.Load())
 		return coord.ServeClient(conn, id, agent)
 	case 2:
-		auth := ClientCoordinateeAuth{AgentID: agent}
 		streamID := StreamID{
 			Name: "client",
 			ID:   id,